@@ -0,0 +1,113 @@
+package backup
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/klog/v2"
+
+	"github.com/openshift-online/maestro/pkg/backup"
+	"github.com/openshift-online/maestro/pkg/client/objectstore"
+	"github.com/openshift-online/maestro/pkg/config"
+	"github.com/openshift-online/maestro/pkg/db/db_session"
+)
+
+var (
+	dbConfig          = config.NewDatabaseConfig()
+	objectStoreConfig = config.NewObjectStoreConfig()
+	file              string
+)
+
+// backup sub-command handles exporting and importing the consumers and resources
+func NewBackupCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Export or import the maestro consumers and resources",
+		Long:  "Export or import the maestro consumers and resources as newline-delimited JSON records",
+	}
+
+	exportCmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the consumers and resources",
+		Long:  "Export the consumers and resources in a consistent snapshot, the consumers are exported before the resources, the offloaded payloads are inlined from the object store",
+		Run:   runExport,
+	}
+	importCmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import the consumers and resources",
+		Long:  "Import the consumers and resources exported by the export command, the existing records with the same IDs are skipped",
+		Run:   runImport,
+	}
+
+	dbConfig.AddFlags(cmd.PersistentFlags())
+	objectStoreConfig.AddFlags(exportCmd.Flags())
+	cmd.PersistentFlags().StringVar(&file, "file", "", "The backup file, the standard output (export) or input (import) is used if it is not set")
+	cmd.AddCommand(exportCmd, importCmd)
+	return cmd
+}
+
+func runExport(_ *cobra.Command, _ []string) {
+	if err := dbConfig.ReadFiles(); err != nil {
+		klog.Fatal(err)
+	}
+	if err := objectStoreConfig.ReadFiles(); err != nil {
+		klog.Fatal(err)
+	}
+
+	// the object store is only required if the payloads are offloaded
+	var payloadStore objectstore.Client
+	if objectStoreConfig.OffloadEnabled() {
+		var err error
+		payloadStore, err = objectstore.NewS3Client(objectstore.Config{
+			Endpoint:        objectStoreConfig.Endpoint,
+			Bucket:          objectStoreConfig.Bucket,
+			Region:          objectStoreConfig.Region,
+			AccessKeyID:     objectStoreConfig.AccessKeyID,
+			SecretAccessKey: objectStoreConfig.SecretAccessKey,
+			Timeout:         objectStoreConfig.Timeout,
+		})
+		if err != nil {
+			klog.Fatal(err)
+		}
+	}
+
+	var w io.Writer = os.Stdout
+	if len(file) != 0 {
+		f, err := os.Create(file)
+		if err != nil {
+			klog.Fatal(err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	connection := db_session.NewProdFactory(dbConfig)
+	if err := backup.Export(context.Background(), connection.New(context.Background()), payloadStore, w); err != nil {
+		klog.Fatal(err)
+	}
+}
+
+func runImport(_ *cobra.Command, _ []string) {
+	if err := dbConfig.ReadFiles(); err != nil {
+		klog.Fatal(err)
+	}
+
+	var r io.Reader = os.Stdin
+	if len(file) != 0 {
+		f, err := os.Open(file)
+		if err != nil {
+			klog.Fatal(err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	connection := db_session.NewProdFactory(dbConfig)
+	result, err := backup.Import(context.Background(), connection.New(context.Background()), r)
+	if err != nil {
+		klog.Fatal(err)
+	}
+	klog.Infof("imported the backup, %d records are restored, %d existing records are skipped", result.Created, result.Skipped)
+}
@@ -55,5 +55,6 @@ func (e *testingEnvImpl) Flags() map[string]string {
 		"enable-sentry":        "false",
 		"source-id":            "maestro",
 		"enable-grpc-server":   "true",
+		"admin-users":          "maestro-admin",
 	}
 }
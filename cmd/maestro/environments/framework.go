@@ -4,12 +4,17 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/getsentry/sentry-go"
+	"github.com/openshift-online/maestro/pkg/api"
 	"github.com/openshift-online/maestro/pkg/client/cloudevents"
 	"github.com/openshift-online/maestro/pkg/client/grpcauthorizer"
+	"github.com/openshift-online/maestro/pkg/client/objectstore"
 	"github.com/openshift-online/maestro/pkg/client/ocm"
+	"github.com/openshift-online/maestro/pkg/client/statusforwarder"
 	"github.com/openshift-online/maestro/pkg/config"
+	"github.com/openshift-online/maestro/pkg/db"
 	"github.com/openshift-online/maestro/pkg/errors"
 	"github.com/spf13/pflag"
 	"k8s.io/client-go/kubernetes"
@@ -20,6 +25,9 @@ import (
 	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic"
 )
 
+// migrationGateCheckInterval is how often the pending migrations are checked until all the migrations are applied.
+const migrationGateCheckInterval = 5 * time.Second
+
 func init() {
 	once.Do(func() {
 		environment = &Env{}
@@ -93,11 +101,17 @@ func (e *Env) Initialize() error {
 		sentry.CaptureException(err)
 		klog.Fatalf("Unable to read configuration files:\n%s", strings.Join(messages, "\n"))
 	}
+	api.SetEventSource(e.Config.EventServer.EventSource)
 
 	// each env will set db explicitly because the DB impl has a `once` init section
 	if err := envImpl.VisitDatabase(&e.Database); err != nil {
 		klog.Fatalf("Failed to visit Database: %s", err)
 	}
+	e.Database.MigrationGate = db.NewMigrationGate(e.Database.SessionFactory, migrationGateCheckInterval)
+	if threshold := e.Config.Database.CircuitBreakerFailureThreshold; threshold > 0 {
+		e.Database.CircuitBreaker = db.NewCircuitBreaker(threshold, e.Config.Database.CircuitBreakerCooldown,
+			e.Config.Database.CircuitBreakerProbes)
+	}
 
 	if err := envImpl.VisitMessageBroker(&e.MessageBroker); err != nil {
 		klog.Fatalf("Failed to visit MessageBroker: %s", err)
@@ -146,6 +160,9 @@ func (e *Env) LoadServices() {
 	e.Services.Events = NewEventServiceLocator(e)
 	e.Services.StatusEvents = NewStatusEventServiceLocator(e)
 	e.Services.Consumers = NewConsumerServiceLocator(e)
+	e.Services.ConsumerTokens = NewConsumerTokenServiceLocator(e)
+	e.Services.ResourceLocks = NewResourceLockServiceLocator(e)
+	e.Services.ResourceTemplates = NewResourceTemplateServiceLocator(e)
 }
 
 func (e *Env) LoadClients() error {
@@ -172,6 +189,23 @@ func (e *Env) LoadClients() error {
 		return err
 	}
 
+	// Create the object store client before the CloudEvents Source client, the resource service of the source
+	// client reads the offloaded payloads with it
+	if e.Config.ObjectStore.OffloadEnabled() {
+		e.Clients.ObjectStore, err = objectstore.NewS3Client(objectstore.Config{
+			Endpoint:        e.Config.ObjectStore.Endpoint,
+			Bucket:          e.Config.ObjectStore.Bucket,
+			Region:          e.Config.ObjectStore.Region,
+			AccessKeyID:     e.Config.ObjectStore.AccessKeyID,
+			SecretAccessKey: e.Config.ObjectStore.SecretAccessKey,
+			Timeout:         e.Config.ObjectStore.Timeout,
+		})
+		if err != nil {
+			klog.Errorf("Unable to create object store client: %s", err.Error())
+			return err
+		}
+	}
+
 	// Create CloudEvents Source client
 	if e.Config.MessageBroker.EnableMock {
 		klog.Infof("Using Mock CloudEvents Source Client")
@@ -192,7 +226,8 @@ func (e *Env) LoadClients() error {
 				klog.Errorf("Unable to build cloudevent source options: %s", err.Error())
 				return err
 			}
-			e.Clients.CloudEventsSource, err = cloudevents.NewSourceClient(cloudEventsSourceOptions, e.Services.Resources())
+			e.Clients.CloudEventsSource, err = cloudevents.NewSourceClient(cloudEventsSourceOptions, e.Services.Resources(),
+				cloudevents.NewResourceStatusHashGetter(e.Config.MessageBroker.StatusHashIgnoredFields))
 			if err != nil {
 				klog.Errorf("Unable to create CloudEvents Source client: %s", err.Error())
 				return err
@@ -225,6 +260,21 @@ func (e *Env) LoadClients() error {
 		}
 	}
 
+	// Create the status forwarder with the enabled status sinks
+	if e.Config.StatusForwarder.Enabled() {
+		sinks := []statusforwarder.Sink{}
+		if e.Config.StatusForwarder.KafkaEnabled() {
+			kafkaSink, err := statusforwarder.NewKafkaSink(e.Config.StatusForwarder.KafkaConfigFile, e.Config.StatusForwarder.KafkaTopic)
+			if err != nil {
+				klog.Errorf("Unable to create Kafka status sink: %s", err.Error())
+				return err
+			}
+			sinks = append(sinks, kafkaSink)
+		}
+		e.Clients.StatusForwarder = statusforwarder.NewForwarder(e.Config.StatusForwarder.BufferSize,
+			e.Config.StatusForwarder.RetryInterval, sinks...)
+	}
+
 	return nil
 }
 
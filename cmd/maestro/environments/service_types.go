@@ -1,6 +1,9 @@
 package environments
 
 import (
+	"k8s.io/klog/v2"
+
+	"github.com/openshift-online/maestro/pkg/api"
 	"github.com/openshift-online/maestro/pkg/dao"
 	"github.com/openshift-online/maestro/pkg/db"
 	"github.com/openshift-online/maestro/pkg/services"
@@ -8,13 +11,60 @@ import (
 
 type ResourceServiceLocator func() services.ResourceService
 
+// newResourceDao returns the resource DAO that offloads the large payloads to the object store if it is configured.
+func newResourceDao(env *Env) dao.ResourceDao {
+	resourceDao := dao.NewResourceDao(&env.Database.SessionFactory)
+	if env.Clients.ObjectStore != nil {
+		resourceDao = dao.NewResourceDaoWithPayloadStore(&env.Database.SessionFactory, env.Clients.ObjectStore,
+			env.Config.ObjectStore.PayloadOffloadThreshold)
+	}
+	if env.Database.CircuitBreaker != nil {
+		resourceDao = dao.NewCircuitBreakerResourceDao(resourceDao, env.Database.CircuitBreaker)
+	}
+	return resourceDao
+}
+
 func NewResourceServiceLocator(env *Env) ResourceServiceLocator {
+	// the kind patterns are parsed once, so a malformed pattern fails the startup rather than being ignored
+	allowedKinds, err := api.ParseKindPatterns(env.Config.Resource.AllowedManifestKinds)
+	if err != nil {
+		klog.Fatalf("Invalid allowed manifest kinds: %s", err)
+	}
+	deniedKinds, err := api.ParseKindPatterns(env.Config.Resource.DeniedManifestKinds)
+	if err != nil {
+		klog.Fatalf("Invalid denied manifest kinds: %s", err)
+	}
+	immutableFields, err := api.ParseImmutableFields(env.Config.HTTPServer.ImmutableManifestFields)
+	if err != nil {
+		klog.Fatalf("Invalid immutable manifest fields: %s", err)
+	}
+
 	return func() services.ResourceService {
 		return services.NewResourceService(
 			db.NewAdvisoryLockFactory(env.Database.SessionFactory),
-			dao.NewResourceDao(&env.Database.SessionFactory),
+			db.NewTransactor(env.Database.SessionFactory),
+			newResourceDao(env),
+			dao.NewResourceRevisionDao(&env.Database.SessionFactory),
+			dao.NewResourceOwnershipTransferDao(&env.Database.SessionFactory),
+			dao.NewConsumerDao(&env.Database.SessionFactory),
 			env.Services.Events(),
 			env.Services.Generic(),
+			services.ResourceServiceOptions{
+				RevisionLimit: env.Config.Resource.RevisionLimit,
+				Limits: services.ManifestLimits{
+					MaxBundleManifests: env.Config.Resource.MaxBundleManifests,
+					MaxDepth:           env.Config.Resource.MaxManifestDepth,
+					MaxKeys:            env.Config.Resource.MaxManifestKeys,
+					AllowedKinds:       allowedKinds,
+					DeniedKinds:        deniedKinds,
+					ImmutableFields:    immutableFields,
+				},
+				QuarantineThreshold: env.Config.Resource.QuarantineThreshold,
+				LabelPropagation: &api.LabelPropagation{
+					Keys:     env.Config.Resource.LabelKeys,
+					Prefixes: env.Config.Resource.LabelPrefixes,
+				},
+			},
 		)
 	}
 }
@@ -46,12 +96,56 @@ func NewStatusEventServiceLocator(env *Env) StatusEventServiceLocator {
 type ConsumerServiceLocator func() services.ConsumerService
 
 func NewConsumerServiceLocator(env *Env) ConsumerServiceLocator {
+	// the cache is shared by all the consumer services of the instance
+	var cache *services.ConsumerCache
+	if env.Config.Consumer.CacheTTL > 0 {
+		cache = services.NewConsumerCache(env.Config.Consumer.CacheTTL)
+	}
+
 	return func() services.ConsumerService {
-		return services.NewConsumerService(
+		consumerService := services.NewConsumerService(
 			db.NewAdvisoryLockFactory(env.Database.SessionFactory),
 			dao.NewConsumerDao(&env.Database.SessionFactory),
-			dao.NewResourceDao(&env.Database.SessionFactory),
+			newResourceDao(env),
 			env.Services.Events(),
 		)
+		if cache == nil {
+			return consumerService
+		}
+		return services.NewCachedConsumerService(consumerService, cache)
+	}
+}
+
+type ConsumerTokenServiceLocator func() services.ConsumerTokenService
+
+func NewConsumerTokenServiceLocator(env *Env) ConsumerTokenServiceLocator {
+	return func() services.ConsumerTokenService {
+		return services.NewConsumerTokenService(
+			dao.NewConsumerDao(&env.Database.SessionFactory),
+			dao.NewConsumerTokenDao(&env.Database.SessionFactory),
+			env.Config.GRPCServer.ConsumerTokenDefaultTTL,
+		)
+	}
+}
+
+type ResourceLockServiceLocator func() services.ResourceLockService
+
+func NewResourceLockServiceLocator(env *Env) ResourceLockServiceLocator {
+	return func() services.ResourceLockService {
+		return services.NewResourceLockService(
+			db.NewAdvisoryLockFactory(env.Database.SessionFactory),
+			newResourceDao(env),
+			dao.NewResourceLockDao(&env.Database.SessionFactory),
+			env.Config.Resource.LockDefaultTTL,
+			env.Config.Resource.LockMaxTTL,
+		)
+	}
+}
+
+type ResourceTemplateServiceLocator func() services.ResourceTemplateService
+
+func NewResourceTemplateServiceLocator(env *Env) ResourceTemplateServiceLocator {
+	return func() services.ResourceTemplateService {
+		return services.NewResourceTemplateService(dao.NewResourceTemplateDao(&env.Database.SessionFactory))
 	}
 }
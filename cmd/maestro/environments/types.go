@@ -6,7 +6,9 @@ import (
 	"github.com/openshift-online/maestro/pkg/auth"
 	"github.com/openshift-online/maestro/pkg/client/cloudevents"
 	"github.com/openshift-online/maestro/pkg/client/grpcauthorizer"
+	"github.com/openshift-online/maestro/pkg/client/objectstore"
 	"github.com/openshift-online/maestro/pkg/client/ocm"
+	"github.com/openshift-online/maestro/pkg/client/statusforwarder"
 	"github.com/openshift-online/maestro/pkg/config"
 	"github.com/openshift-online/maestro/pkg/db"
 )
@@ -39,6 +41,10 @@ type ApplicationConfig struct {
 
 type Database struct {
 	SessionFactory db.SessionFactory
+	// MigrationGate tells whether the migrations are complete, the mutations are rejected until they are complete
+	MigrationGate *db.MigrationGate
+	// CircuitBreaker fast-fails the resource calls once the database is degraded, it is nil if it is disabled
+	CircuitBreaker *db.CircuitBreaker
 }
 
 type MessageBroker struct {
@@ -54,12 +60,22 @@ type Services struct {
 	Events       EventServiceLocator
 	StatusEvents StatusEventServiceLocator
 	Consumers    ConsumerServiceLocator
+	// ConsumerTokens is the service of the tokens scoped to a consumer
+	ConsumerTokens ConsumerTokenServiceLocator
+	// ResourceLocks is the service of the advisory soft-locks of the resources
+	ResourceLocks ResourceLockServiceLocator
+	// ResourceTemplates is the service of the parameterized manifests the resources are created from
+	ResourceTemplates ResourceTemplateServiceLocator
 }
 
 type Clients struct {
 	OCM               *ocm.Client
 	GRPCAuthorizer    grpcauthorizer.GRPCAuthorizer
 	CloudEventsSource cloudevents.SourceClient
+	// ObjectStore keeps the offloaded payloads of the large resource bundles, it is nil if the offloading is disabled
+	ObjectStore objectstore.Client
+	// StatusForwarder forwards the resource status changes to the external sinks, it is nil if there is no sink
+	StatusForwarder *statusforwarder.Forwarder
 }
 
 type ConfigDefaults struct {
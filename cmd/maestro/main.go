@@ -7,6 +7,7 @@ import (
 
 	"github.com/go-logr/zapr"
 	"github.com/openshift-online/maestro/cmd/maestro/agent"
+	"github.com/openshift-online/maestro/cmd/maestro/backup"
 	"github.com/openshift-online/maestro/cmd/maestro/migrate"
 	"github.com/openshift-online/maestro/cmd/maestro/servecmd"
 	"github.com/spf13/cobra"
@@ -62,9 +63,10 @@ func main() {
 	migrateCmd := migrate.NewMigrationCommand()
 	serveCmd := servecmd.NewServerCommand()
 	agentCmd := agent.NewAgentCommand()
+	backupCmd := backup.NewBackupCommand()
 
 	// Add subcommand(s)
-	rootCmd.AddCommand(migrateCmd, serveCmd, agentCmd)
+	rootCmd.AddCommand(migrateCmd, serveCmd, agentCmd, backupCmd)
 
 	if err := rootCmd.Execute(); err != nil {
 		klog.Fatalf("error running command: %v", err)
@@ -16,6 +16,7 @@ import (
 	"github.com/openshift-online/maestro/pkg/db"
 	"github.com/openshift-online/maestro/pkg/dispatcher"
 	"github.com/openshift-online/maestro/pkg/event"
+	"github.com/openshift-online/maestro/pkg/services"
 )
 
 func NewServerCommand() *cobra.Command {
@@ -40,7 +41,18 @@ func runServer(cmd *cobra.Command, args []string) {
 	}
 
 	// Create event broadcaster to broadcast resource status update events to subscribers
-	eventBroadcaster := event.NewEventBroadcaster()
+	eventServerConfig := environments.Environment().Config.EventServer
+	overflowPolicy, err := event.ParseOverflowPolicy(eventServerConfig.BroadcasterOverflowPolicy)
+	if err != nil {
+		klog.Fatalf("Unable to create event broadcaster: %s", err.Error())
+	}
+	eventBroadcaster := event.NewEventBroadcaster(eventServerConfig.BroadcasterBufferSize, overflowPolicy)
+
+	consumerMetricsMode, err := services.ParseConsumerMetricsMode(environments.Environment().Config.Metrics.ConsumerMetricsMode)
+	if err != nil {
+		klog.Fatalf("Unable to set up consumer metrics: %s", err.Error())
+	}
+	services.SetConsumerMetricsMode(consumerMetricsMode)
 
 	// Create the event server based on the message broker type:
 	// For gRPC, create a gRPC broker to handle resource spec and status events.
@@ -94,6 +106,11 @@ func runServer(cmd *cobra.Command, args []string) {
 	// Start the event broadcaster
 	go eventBroadcaster.Start(ctx)
 
+	// Start the status forwarder if there is any status sink
+	if statusForwarder := environments.Environment().Clients.StatusForwarder; statusForwarder != nil {
+		go statusForwarder.Start(ctx)
+	}
+
 	// Run the servers
 	go apiserver.Start()
 	go metricsServer.Start()
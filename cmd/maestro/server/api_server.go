@@ -37,7 +37,7 @@ func env() *environments.Env {
 func NewAPIServer(eventBroadcaster *event.EventBroadcaster) Server {
 	s := &apiServer{}
 
-	mainRouter := s.routes()
+	mainRouter := s.routes(eventBroadcaster)
 
 	// Sentryhttp middleware performs two operations:
 	// 1) Attaches an instance of *sentry.Hub to the request’s context. Accessit by using the sentry.GetHubFromContext() method on the request
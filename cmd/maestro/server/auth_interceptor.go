@@ -2,10 +2,14 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/openshift-online/maestro/pkg/api"
 	"github.com/openshift-online/maestro/pkg/client/grpcauthorizer"
+	"github.com/openshift-online/maestro/pkg/services"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
@@ -22,8 +26,14 @@ type contextKey string
 const (
 	contextUserKey   contextKey = "user"
 	contextGroupsKey contextKey = "groups"
+	// contextConsumerKey is the name of the consumer that the request is scoped to by a consumer token
+	contextConsumerKey contextKey = "consumer"
 )
 
+// consumerTokenRecheckInterval is the interval to recheck the consumer token of a stream, the stream is closed
+// once its token is expired or revoked, it is kept if the token can't be looked up.
+const consumerTokenRecheckInterval = 30 * time.Second
+
 func newContextWithIdentity(ctx context.Context, user string, groups []string) context.Context {
 	ctx = context.WithValue(ctx, contextUserKey, user)
 	return context.WithValue(ctx, contextGroupsKey, groups)
@@ -82,6 +92,45 @@ func identityFromToken(ctx context.Context, grpcAuthorizer grpcauthorizer.GRPCAu
 	return grpcAuthorizer.TokenReview(ctx, token)
 }
 
+// consumerFromToken validates the consumer token and returns the token metadata.
+func consumerFromToken(ctx context.Context, consumerTokenService services.ConsumerTokenService) (*api.ConsumerToken, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "missing metadata")
+	}
+
+	authorization, ok := md["authorization"]
+	if !ok || len(authorization) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+
+	consumerToken, err := consumerTokenService.Validate(ctx, strings.TrimPrefix(authorization[0], "Bearer "))
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidConsumerToken) {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		// the token can't be looked up, e.g. the database is unavailable, the client may retry
+		return nil, status.Errorf(codes.Unavailable, "unable to validate consumer token: %v", err)
+	}
+
+	return consumerToken, nil
+}
+
+// checkConsumer ensures the request scoped to a consumer only operates on the topic of that consumer.
+// The check is skipped if the request is not scoped to a consumer.
+func checkConsumer(ctx context.Context, clusterName string) error {
+	consumer, ok := ctx.Value(contextConsumerKey).(string)
+	if !ok {
+		return nil
+	}
+
+	if consumer != clusterName {
+		return status.Errorf(codes.PermissionDenied, "the token of consumer %s is not allowed to operate on cluster %s", consumer, clusterName)
+	}
+
+	return nil
+}
+
 // newAuthUnaryInterceptor creates a unary interceptor that retrieves the user and groups
 // based on the specified authentication type. It supports retrieving from either the access
 // token or the client certificate depending on the provided authNType.
@@ -178,3 +227,67 @@ func newAuthStreamInterceptor(authNType string, authorizer grpcauthorizer.GRPCAu
 		return handler(srv, newWrappedAuthStream(newContextWithIdentity(ss.Context(), user, groups), ss))
 	}
 }
+
+// newConsumerTokenUnaryInterceptor creates a unary interceptor that validates the consumer token of each request
+// and adds the consumer that the token is scoped to into the context.
+func newConsumerTokenUnaryInterceptor(consumerTokenService services.ConsumerTokenService) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		consumerToken, err := consumerFromToken(ctx, consumerTokenService)
+		if err != nil {
+			klog.Errorf("unable to validate consumer token: %v", err)
+			return nil, err
+		}
+
+		return handler(context.WithValue(ctx, contextConsumerKey, consumerToken.ConsumerName), req)
+	}
+}
+
+// newConsumerTokenStreamInterceptor creates a stream interceptor that validates the consumer token and adds the
+// consumer that the token is scoped to into the context. The token is rechecked periodically, the context of the
+// stream is canceled once the token is expired or revoked, but not if the token can't be looked up.
+func newConsumerTokenStreamInterceptor(consumerTokenService services.ConsumerTokenService) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		consumerToken, err := consumerFromToken(ss.Context(), consumerTokenService)
+		if err != nil {
+			klog.Errorf("unable to validate consumer token: %v", err)
+			return err
+		}
+
+		ctx, cancel := context.WithDeadline(context.WithValue(ss.Context(), contextConsumerKey, consumerToken.ConsumerName), consumerToken.ExpiresAt)
+		defer cancel()
+
+		go func() {
+			ticker := time.NewTicker(consumerTokenRecheckInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if _, err := consumerFromToken(ss.Context(), consumerTokenService); err != nil {
+						if status.Code(err) == codes.Unavailable {
+							// the token is not known to be invalid, keep the stream and recheck it later
+							klog.Warningf("unable to recheck the token of consumer %s: %v", consumerToken.ConsumerName, err)
+							continue
+						}
+						klog.Infof("closing the stream of consumer %s: %v", consumerToken.ConsumerName, err)
+						cancel()
+						return
+					}
+				}
+			}
+		}()
+
+		return handler(srv, newWrappedAuthStream(ctx, ss))
+	}
+}
@@ -0,0 +1,60 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/services"
+)
+
+// fakeConsumerTokenService validates the tokens with the given error.
+type fakeConsumerTokenService struct {
+	services.ConsumerTokenService
+	err error
+}
+
+func (s *fakeConsumerTokenService) Validate(ctx context.Context, token string) (*api.ConsumerToken, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &api.ConsumerToken{ConsumerName: "cluster1"}, nil
+}
+
+func TestConsumerFromToken(t *testing.T) {
+	cases := []struct {
+		name         string
+		validateErr  error
+		expectedCode codes.Code
+	}{
+		{
+			name:         "valid token",
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "invalid token",
+			validateErr:  fmt.Errorf("%w: consumer token abc has expired", services.ErrInvalidConsumerToken),
+			expectedCode: codes.Unauthenticated,
+		},
+		{
+			name:         "token lookup failure",
+			validateErr:  fmt.Errorf("driver: bad connection"),
+			expectedCode: codes.Unavailable,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer abc.secret"))
+			_, err := consumerFromToken(ctx, &fakeConsumerTokenService{err: c.validateErr})
+			if code := status.Code(err); code != c.expectedCode {
+				t.Errorf("expected the code %s, but got %s (%v)", c.expectedCode, code, err)
+			}
+		})
+	}
+}
@@ -2,11 +2,16 @@ package server
 
 import (
 	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
 
 	"github.com/openshift-online/maestro/pkg/api"
 	"github.com/openshift-online/maestro/pkg/controllers"
 	"github.com/openshift-online/maestro/pkg/dao"
 	"github.com/openshift-online/maestro/pkg/db"
+	"github.com/openshift-online/maestro/pkg/services"
 
 	"github.com/openshift-online/maestro/pkg/logger"
 )
@@ -33,6 +38,14 @@ func NewControllersServer(eventServer EventServer, eventFilter controllers.Event
 		},
 	})
 
+	// the consumer events are recorded once the consumer labels are patched, the resources are not changed by them
+	s.KindControllerManager.Add(&controllers.ControllerConfig{
+		Source: "Consumers",
+		Handlers: map[api.EventType][]controllers.ControllerHandlerFunc{
+			api.UpdateEventType: {s.onConsumerUpdate},
+		},
+	})
+
 	s.StatusController.Add(map[api.StatusEventType][]controllers.StatusHandlerFunc{
 		api.StatusUpdateEventType: {eventServer.OnStatusUpdate},
 		api.StatusDeleteEventType: {eventServer.OnStatusUpdate},
@@ -62,6 +75,224 @@ func (s ControllersServer) Start(ctx context.Context) {
 	log.Infof("Status controller listening for status events")
 	go env().Database.SessionFactory.NewListener(ctx, "status_events", s.StatusController.AddStatusEvent)
 
+	// periodically refresh the number of resources awaiting the deletion confirmation from the agents
+	go wait.UntilWithContext(ctx, s.syncPendingDeletionMetrics, pendingDeletionSyncInterval)
+
+	// periodically refresh the number of resources whose observed version drifts from the resource version
+	go wait.UntilWithContext(ctx, s.syncVersionDriftMetrics, versionDriftSyncInterval)
+
+	// periodically refresh the number of quarantined resources
+	go wait.UntilWithContext(ctx, s.syncQuarantinedMetrics, quarantinedSyncInterval)
+
+	// periodically delete the expired resource soft-locks, the expired locks are ignored before they are deleted
+	go wait.UntilWithContext(ctx, s.pruneExpiredResourceLocks, resourceLockPruneInterval)
+
+	// periodically delete the offloaded payloads that are not referenced by any resource, e.g. the payloads put by a
+	// rolled back transaction
+	if env().Clients.ObjectStore != nil {
+		go wait.UntilWithContext(ctx, s.pruneUnreferencedPayloads, payloadPruneInterval)
+	}
+
+	// periodically refresh the resource counts of the consumer-scoped metrics
+	if env().Config.Metrics.ConsumerMetricsMode != string(services.ConsumerMetricsModeNone) {
+		go wait.UntilWithContext(ctx, s.syncConsumerMetrics, consumerMetricsSyncInterval)
+	}
+
+	// periodically re-enqueue the events that are not reconciled, so the dispatch failed while the broker was
+	// unavailable is retried once the broker recovers
+	if cfg := env().Config.EventServer; cfg.EventOutboxDrainInterval > 0 {
+		log.Infof("Event outbox drainer re-enqueuing the unreconciled events every %s", cfg.EventOutboxDrainInterval)
+		drainer := controllers.NewEventOutboxDrainer(
+			env().Services.Events(),
+			s.KindControllerManager.AddEvent,
+			cfg.EventOutboxDrainInterval,
+		)
+		go drainer.Run(ctx)
+	}
+
+	// periodically prune the events older than the max age, only the leader instance prunes the events
+	if cfg := env().Config.EventServer; cfg.EventMaxAge > 0 {
+		log.Infof("Event pruner pruning the events older than %s", cfg.EventMaxAge)
+		pruner := controllers.NewEventPruner(
+			db.NewLeaderLock(env().Database.SessionFactory, eventPrunerLeaderLockKey, eventPrunerLeaderRenewInterval),
+			env().Services.Events(),
+			env().Services.StatusEvents(),
+			dao.NewEventInstanceDao(&env().Database.SessionFactory),
+			cfg.EventMaxAge,
+			cfg.EventPruneInterval,
+		)
+		go pruner.Run(ctx)
+	}
+
+	// periodically mark the resources that are not reconciled within the timeout, only the leader instance marks them
+	if timeout := env().Config.Resource.ReconcileTimeout; timeout > 0 {
+		log.Infof("Reconcile timeout controller marking the resources not reconciled within %s", timeout)
+		reconcileTimeoutController := controllers.NewReconcileTimeoutController(
+			db.NewLeaderLock(env().Database.SessionFactory, reconcileTimeoutLeaderLockKey, reconcileTimeoutLeaderRenewInterval),
+			env().Services.Resources(),
+			timeout,
+			reconcileTimeoutCheckInterval,
+		)
+		go reconcileTimeoutController.Run(ctx)
+	}
+
+	// periodically check the resources whose consumer doesn't exist, only the leader instance checks them
+	if cfg := env().Config.Resource; cfg.OrphanedResourceCheckInterval > 0 {
+		log.Infof("Orphaned resource controller checking the orphaned resources every %s", cfg.OrphanedResourceCheckInterval)
+		orphanedResourceController := controllers.NewOrphanedResourceController(
+			db.NewLeaderLock(env().Database.SessionFactory, orphanedResourceLeaderLockKey, orphanedResourceLeaderRenewInterval),
+			env().Services.Resources(),
+			cfg.OrphanedResourceDeletion,
+			cfg.OrphanedResourceCheckInterval,
+		)
+		go orphanedResourceController.Run(ctx)
+	}
+
 	// block until the context is done
 	<-ctx.Done()
 }
+
+// pendingDeletionSyncInterval is the interval to refresh the resource pending deletion metrics.
+const pendingDeletionSyncInterval = time.Minute
+
+// versionDriftSyncInterval is the interval to refresh the resource version drift metrics.
+const versionDriftSyncInterval = time.Minute
+
+// quarantinedSyncInterval is the interval to refresh the resource quarantined metrics.
+const quarantinedSyncInterval = time.Minute
+
+// resourceLockPruneInterval is the interval to delete the expired resource soft-locks.
+const resourceLockPruneInterval = 10 * time.Minute
+
+// payloadPruneInterval is the interval to delete the unreferenced offloaded payloads.
+const payloadPruneInterval = time.Hour
+
+// payloadPruneGracePeriod is how long an offloaded payload is kept before it can be deleted as unreferenced, so the
+// payload put by a transaction that is not committed yet is not deleted.
+const payloadPruneGracePeriod = time.Hour
+
+// consumerMetricsSyncInterval is the interval to refresh the consumer-scoped metrics.
+const consumerMetricsSyncInterval = time.Minute
+
+// versionDriftThreshold is how long a resource can be lagging (or unreported) before it is counted as drifting, so
+// the resources that are just created or updated are not counted before the agents report their statuses.
+const versionDriftThreshold = 10 * time.Minute
+
+// eventPrunerLeaderLockKey is the key of the leader lock held by the instance that prunes the events.
+const eventPrunerLeaderLockKey = "maestro-event-pruner"
+
+// eventPrunerLeaderRenewInterval is the interval to renew the leader lock of the event pruner.
+const eventPrunerLeaderRenewInterval = 30 * time.Second
+
+// reconcileTimeoutCheckInterval is the interval to mark the resources that are not reconciled within the timeout.
+const reconcileTimeoutCheckInterval = time.Minute
+
+// reconcileTimeoutLeaderLockKey is the key of the leader lock held by the instance that marks the stale resources.
+const reconcileTimeoutLeaderLockKey = "maestro-reconcile-timeout"
+
+// reconcileTimeoutLeaderRenewInterval is the interval to renew the leader lock of the reconcile timeout controller.
+const reconcileTimeoutLeaderRenewInterval = 30 * time.Second
+
+// orphanedResourceLeaderLockKey is the key of the leader lock held by the instance that checks the orphaned resources.
+const orphanedResourceLeaderLockKey = "maestro-orphaned-resource"
+
+// orphanedResourceLeaderRenewInterval is the interval to renew the leader lock of the orphaned resource controller.
+const orphanedResourceLeaderRenewInterval = 30 * time.Second
+
+// statusResyncLeaderLockKey is the key of the leader lock held by the instance that runs the status resync.
+const statusResyncLeaderLockKey = "maestro-status-resync"
+
+// statusResyncLeaderRenewInterval is the interval to renew the leader lock of the status resync.
+const statusResyncLeaderRenewInterval = 30 * time.Second
+
+func (s ControllersServer) syncPendingDeletionMetrics(ctx context.Context) {
+	log := logger.NewOCMLogger(ctx)
+	counts, svcErr := env().Services.Resources().CountPendingDeletion(ctx, time.Now())
+	if svcErr != nil {
+		log.Error(fmt.Sprintf("Unable to count pending deletion resources: %s", svcErr.Error()))
+		return
+	}
+
+	consumerCounts := map[string]int{}
+	for _, resourceType := range []api.ResourceType{api.ResourceTypeSingle, api.ResourceTypeBundle} {
+		total := 0
+		for consumerName, count := range counts[resourceType] {
+			total += count
+			consumerCounts[consumerName] += count
+		}
+		services.SetResourcePendingDeletionMetric(resourceType, total)
+	}
+	services.SyncConsumerPendingDeletionMetrics(consumerCounts)
+}
+
+func (s ControllersServer) syncVersionDriftMetrics(ctx context.Context) {
+	log := logger.NewOCMLogger(ctx)
+	counts, svcErr := env().Services.Resources().CountVersionDrift(ctx, time.Now().Add(-versionDriftThreshold))
+	if svcErr != nil {
+		log.Error(fmt.Sprintf("Unable to count version drift resources: %s", svcErr.Error()))
+		return
+	}
+	for _, state := range api.VersionDriftStates {
+		services.SetResourceVersionDriftMetric(state, counts[state])
+	}
+}
+
+func (s ControllersServer) syncQuarantinedMetrics(ctx context.Context) {
+	log := logger.NewOCMLogger(ctx)
+	counts, svcErr := env().Services.Resources().CountQuarantined(ctx)
+	if svcErr != nil {
+		log.Error(fmt.Sprintf("Unable to count quarantined resources: %s", svcErr.Error()))
+		return
+	}
+	for _, resourceType := range []api.ResourceType{api.ResourceTypeSingle, api.ResourceTypeBundle} {
+		services.SetResourceQuarantinedMetric(resourceType, counts[resourceType])
+	}
+}
+
+func (s ControllersServer) pruneExpiredResourceLocks(ctx context.Context) {
+	log := logger.NewOCMLogger(ctx)
+	deleted, svcErr := env().Services.ResourceLocks().DeleteExpired(ctx)
+	if svcErr != nil {
+		log.Error(fmt.Sprintf("Unable to delete expired resource locks: %s", svcErr.Error()))
+		return
+	}
+	if deleted > 0 {
+		log.V(4).Infof("Deleted %d expired resource locks", deleted)
+	}
+}
+
+func (s ControllersServer) pruneUnreferencedPayloads(ctx context.Context) {
+	log := logger.NewOCMLogger(ctx)
+	deleted, svcErr := env().Services.Resources().DeleteUnreferencedPayloads(ctx, time.Now().Add(-payloadPruneGracePeriod))
+	if svcErr != nil {
+		log.Error(fmt.Sprintf("Unable to delete unreferenced resource payloads: %s", svcErr.Error()))
+	}
+	if deleted > 0 {
+		log.V(4).Infof("Deleted %d unreferenced resource payloads", deleted)
+	}
+}
+
+// onConsumerUpdate refreshes the consumer-scoped metrics once the labels of a consumer are changed, since the consumer
+// groups are defined by the consumer labels.
+func (s ControllersServer) onConsumerUpdate(ctx context.Context, id string) error {
+	logger.NewOCMLogger(ctx).V(4).Infof("The consumer %s is updated", id)
+	if env().Config.Metrics.ConsumerMetricsMode != string(services.ConsumerMetricsModeNone) {
+		s.syncConsumerMetrics(ctx)
+	}
+	return nil
+}
+
+func (s ControllersServer) syncConsumerMetrics(ctx context.Context) {
+	log := logger.NewOCMLogger(ctx)
+	counts, svcErr := env().Services.Resources().CountByConsumer(ctx)
+	if svcErr != nil {
+		log.Error(fmt.Sprintf("Unable to count resources by consumer: %s", svcErr.Error()))
+		return
+	}
+	consumers, svcErr := env().Services.Consumers().All(ctx)
+	if svcErr != nil {
+		log.Error(fmt.Sprintf("Unable to get consumers: %s", svcErr.Error()))
+		return
+	}
+	services.SyncConsumerMetrics(counts, consumers)
+}
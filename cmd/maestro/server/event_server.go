@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/lib/pq"
 	"github.com/openshift-online/maestro/pkg/api"
 	"github.com/openshift-online/maestro/pkg/client/cloudevents"
+	"github.com/openshift-online/maestro/pkg/client/statusforwarder"
 	"github.com/openshift-online/maestro/pkg/dao"
 	"github.com/openshift-online/maestro/pkg/db"
 	"github.com/openshift-online/maestro/pkg/dispatcher"
@@ -54,7 +56,8 @@ type MessageQueueEventServer struct {
 	instanceID         string
 	eventInstanceDao   dao.EventInstanceDao
 	lockFactory        db.LockFactory
-	eventBroadcaster   *event.EventBroadcaster // event broadcaster to broadcast resource status update events to subscribers
+	eventBroadcaster   *event.EventBroadcaster    // event broadcaster to broadcast resource status update events to subscribers
+	statusForwarder    *statusforwarder.Forwarder // forwards the resource status update events to the external sinks
 	resourceService    services.ResourceService
 	statusEventService services.StatusEventService
 	sourceClient       cloudevents.SourceClient
@@ -68,6 +71,7 @@ func NewMessageQueueEventServer(eventBroadcaster *event.EventBroadcaster, status
 		eventInstanceDao:   dao.NewEventInstanceDao(&sessionFactory),
 		lockFactory:        db.NewAdvisoryLockFactory(sessionFactory),
 		eventBroadcaster:   eventBroadcaster,
+		statusForwarder:    env().Clients.StatusForwarder,
 		resourceService:    env().Services.Resources(),
 		statusEventService: env().Services.StatusEvents(),
 		sourceClient:       env().Clients.CloudEventsSource,
@@ -142,6 +146,7 @@ func (s *MessageQueueEventServer) OnStatusUpdate(ctx context.Context, eventID, r
 		s.resourceService,
 		s.eventInstanceDao,
 		s.eventBroadcaster,
+		s.statusForwarder,
 		s.instanceID,
 		eventID,
 		resourceID,
@@ -208,7 +213,9 @@ func handleStatusUpdate(ctx context.Context, resource *api.Resource, resourceSer
 		return fmt.Errorf("failed to decode cloudevent data as resource status: %v", err)
 	}
 
-	// if the resource has been deleted from agent, create status event and delete it from maestro
+	// if the resource has been deleted from agent, create status event and delete it from maestro.
+	// the ManifestsDeleted condition is the agent's deletion confirmation, before it the resource is kept
+	// (soft deleted) as pending deletion, see ResourceService.CountPendingDeletion.
 	if meta.IsStatusConditionTrue(statusPayload.Conditions, common.ManifestsDeleted) {
 		_, sErr := statusEventService.Create(ctx, &api.StatusEvent{
 			ResourceID:      resource.ID,
@@ -225,8 +232,11 @@ func handleStatusUpdate(ctx context.Context, resource *api.Resource, resourceSer
 			return fmt.Errorf("failed to delete resource %s: %s", resource.ID, svcErr.Error())
 		}
 	} else {
+		// keep the conditions summary before the update, so the condition transitions can be told on broadcast
+		previousConditions := append(pq.StringArray{}, found.Conditions...)
+
 		// update the resource status
-		_, updated, svcErr := resourceService.UpdateStatus(ctx, resource)
+		updatedResource, updated, svcErr := resourceService.UpdateStatus(ctx, resource)
 		if svcErr != nil {
 			return fmt.Errorf("failed to update resource status %s: %s", resource.ID, svcErr.Error())
 		}
@@ -234,13 +244,28 @@ func handleStatusUpdate(ctx context.Context, resource *api.Resource, resourceSer
 		// create the status event only when the resource is updated
 		if updated {
 			_, sErr := statusEventService.Create(ctx, &api.StatusEvent{
-				ResourceID:      resource.ID,
-				StatusEventType: api.StatusUpdateEventType,
+				ResourceID:         resource.ID,
+				StatusEventType:    api.StatusUpdateEventType,
+				PreviousConditions: previousConditions,
 			})
 			if sErr != nil {
 				return fmt.Errorf("failed to create status event for resource status update %s: %s", resource.ID, sErr.Error())
 			}
 		}
+
+		// switch the status feedback rules once the readiness of the resource is changed, the rules are checked
+		// without the lock first, so the resource is locked only when the rules are changed.
+		if updated {
+			_, changed, err := api.UpdateStatusFeedback(updatedResource.Type, updatedResource.Payload, api.IsReady(updatedResource.Conditions))
+			if err != nil {
+				return fmt.Errorf("failed to update the status feedback of resource %s: %v", resource.ID, err)
+			}
+			if changed {
+				if _, _, svcErr := resourceService.SyncStatusFeedback(ctx, resource.ID); svcErr != nil {
+					return fmt.Errorf("failed to sync the status feedback of resource %s: %s", resource.ID, svcErr.Error())
+				}
+			}
+		}
 	}
 
 	return nil
@@ -251,6 +276,7 @@ func broadcastStatusEvent(ctx context.Context,
 	resourceService services.ResourceService,
 	eventInstanceDao dao.EventInstanceDao,
 	eventBroadcaster *event.EventBroadcaster,
+	statusForwarder *statusforwarder.Forwarder,
 	instanceID, eventID, resourceID string) error {
 	statusEvent, sErr := statusEventService.Get(ctx, eventID)
 	if sErr != nil {
@@ -275,12 +301,32 @@ func broadcastStatusEvent(ctx context.Context,
 		if sErr != nil {
 			return fmt.Errorf("failed to get resource %s: %s", resourceID, sErr.Error())
 		}
+		// the resource is broadcast for a status update, an empty summary is kept for a resource without status
+		resource.PreviousConditions = append(pq.StringArray{}, statusEvent.PreviousConditions...)
 	}
 
 	// broadcast the resource status to subscribers
 	log.V(4).Infof("Broadcast the resource status %s", resource.ID)
 	eventBroadcaster.Broadcast(resource)
 
+	// forward the resource status to the external sinks, the status event is not failed if the forwarding fails.
+	if statusForwarder != nil {
+		evt, err := EncodeResourceStatus(resource, nil, "")
+		if err != nil {
+			log.Error(fmt.Sprintf("failed to encode the status of resource %s for forwarding: %s", resource.ID, err.Error()))
+		} else {
+			statusForwarder.Forward(evt)
+		}
+	}
+
+	if statusEvent.StatusEventType != api.StatusDeleteEventType {
+		// record the instance that dispatched the status for debugging the status delivery, the broadcast is not
+		// failed if the record fails.
+		if svcErr := resourceService.MarkDispatched(ctx, resource.ID, instanceID); svcErr != nil {
+			log.Error(fmt.Sprintf("failed to record the dispatch of resource %s status: %s", resource.ID, svcErr.Error()))
+		}
+	}
+
 	// add the event instance record
 	_, err := eventInstanceDao.Create(ctx, &api.EventInstance{
 		EventID:    eventID,
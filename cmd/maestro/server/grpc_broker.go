@@ -4,10 +4,12 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
 	"fmt"
 	"net"
 	"os"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -19,8 +21,10 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 	"k8s.io/klog/v2"
 	pbv1 "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/grpc/protobuf/v1"
 	grpcprotocol "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/grpc/protocol"
@@ -29,7 +33,12 @@ import (
 	workpayload "open-cluster-management.io/sdk-go/pkg/cloudevents/work/payload"
 
 	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/client/cloudevents/resourcelist"
+	"github.com/openshift-online/maestro/pkg/client/cloudevents/resourcestatus"
+	"github.com/openshift-online/maestro/pkg/client/statusforwarder"
 	"github.com/openshift-online/maestro/pkg/dao"
+	"github.com/openshift-online/maestro/pkg/db"
+	"github.com/openshift-online/maestro/pkg/dispatcher"
 	"github.com/openshift-online/maestro/pkg/event"
 	"github.com/openshift-online/maestro/pkg/logger"
 	"github.com/openshift-online/maestro/pkg/services"
@@ -37,11 +46,92 @@ import (
 
 type resourceHandler func(res *api.Resource) error
 
+// resourceTypeFilterKey is the gRPC metadata key for a subscriber to only subscribe to the given resource type
+// (Single or Bundle). The SubscriptionRequest is defined by the sdk-go, so the filter is carried by the metadata
+// of the Subscribe stream.
+const resourceTypeFilterKey = "maestro-resource-type"
+
+// conditionTransitionFilterKey is the gRPC metadata key for a source to only subscribe to the status updates that
+// transition the status of the given condition type (e.g. Available from True to False), see
+// api.Resource.ConditionTransitioned.
+const conditionTransitionFilterKey = "maestro-condition-transition"
+
+// maxSubscribeDurationKey is the gRPC metadata key for a subscriber to limit the duration of its Subscribe stream
+// (e.g. "10m"), the server closes the stream and unregisters the subscriber once the duration elapses.
+const maxSubscribeDurationKey = "maestro-max-subscribe-duration"
+
+// dataContentTypeKey is the gRPC metadata key for a subscriber to advertise the data content type of the events that
+// it accepts (see api.SupportedDataContentTypes), the events are sent with the JSON data if it is not set.
+const dataContentTypeKey = "maestro-data-content-type"
+
+// ownerAddressKey is the gRPC metadata key of the Subscribe response that carries the advertised address of the
+// instance that owns the consumer, it is set when the agent is redirected by the connection affinity.
+const ownerAddressKey = "maestro-owner-address"
+
+// listResourcesPageSize is the number of resources loaded from the database at a time to respond the ListResources
+// request, so the memory is bounded regardless of the number of resources of the cluster.
+const listResourcesPageSize = 500
+
+// errEncodeResource is wrapped by the errors of a subscriber handler that fail before the resource is sent, they are
+// caused by the resource rather than the stream, so they are not counted as the send failures of the subscriber.
+var errEncodeResource = errors.New("failed to encode the resource")
+
 // subscriber defines a subscriber that can receive and handle resource spec.
 type subscriber struct {
 	clusterName string
 	handler     resourceHandler
 	errChan     chan<- error
+
+	mu sync.Mutex
+	// sendFailures is the number of consecutive send failures since firstSendFailure.
+	sendFailures     int
+	firstSendFailure time.Time
+	// unreachable is true once the subscriber is going to be unregistered.
+	unreachable bool
+}
+
+// recordSendFailure records a send failure and returns true if the subscriber reaches the max consecutive send
+// failures within the window.
+func (s *subscriber) recordSendFailure(maxFailures int, window time.Duration) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if s.sendFailures == 0 || now.Sub(s.firstSendFailure) > window {
+		s.sendFailures = 0
+		s.firstSendFailure = now
+	}
+	s.sendFailures++
+
+	return maxFailures > 0 && s.sendFailures >= maxFailures
+}
+
+// recordSendSuccess resets the consecutive send failures.
+func (s *subscriber) recordSendSuccess() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sendFailures = 0
+}
+
+// markUnreachable marks the subscriber as unreachable, it returns false if the subscriber has already been marked,
+// so that the error is only pushed to the error channel of the subscriber once.
+func (s *subscriber) markUnreachable() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.unreachable {
+		return false
+	}
+	s.unreachable = true
+	return true
+}
+
+func (s *subscriber) isUnreachable() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.unreachable
 }
 
 var _ EventServer = &GRPCBroker{}
@@ -53,6 +143,8 @@ var _ EventServer = &GRPCBroker{}
 // ensure all work agents receive all the resource spec.
 type GRPCBroker struct {
 	pbv1.UnimplementedCloudEventServiceServer
+	resourcelist.UnimplementedResourceListServiceServer
+	resourcestatus.UnimplementedResourceStatusServiceServer
 	grpcServer         *grpc.Server
 	instanceID         string
 	eventInstanceDao   dao.EventInstanceDao
@@ -60,9 +152,23 @@ type GRPCBroker struct {
 	eventService       services.EventService
 	statusEventService services.StatusEventService
 	bindAddress        string
-	subscribers        map[string]*subscriber  // registered subscribers
-	eventBroadcaster   *event.EventBroadcaster // event broadcaster to broadcast resource status update events to subscribers
+	trustedProxyCIDRs  []string                   // the proxies trusted to send the PROXY protocol headers
+	subscribers        map[string]*subscriber     // registered subscribers
+	maxSendFailures    int                        // max consecutive send failures before a subscriber is unregistered
+	sendFailureWindow  time.Duration              // window in which the consecutive send failures are counted
+	eventBroadcaster   *event.EventBroadcaster    // event broadcaster to broadcast resource status update events to subscribers
+	statusForwarder    *statusforwarder.Forwarder // forwards the resource status update events to the external sinks
 	mu                 sync.RWMutex
+
+	// ownerLocator redirects the agents to the instances that own their consumers on the hashing ring, it is nil if
+	// the connection affinity is disabled.
+	ownerLocator consumerOwnerLocator
+}
+
+// consumerOwnerLocator locates the maestro instance that owns a consumer, it is implemented by dispatcher.HashRing.
+type consumerOwnerLocator interface {
+	Start(ctx context.Context, sessionFactory db.SessionFactory)
+	Locate(consumerName string) *api.ServerInstance
 }
 
 // NewGRPCBroker creates a new gRPC broker with the given configuration.
@@ -71,6 +177,7 @@ func NewGRPCBroker(eventBroadcaster *event.EventBroadcaster) EventServer {
 	grpcServerOptions := make([]grpc.ServerOption, 0)
 	grpcServerOptions = append(grpcServerOptions, grpc.MaxRecvMsgSize(config.MaxReceiveMessageSize))
 	grpcServerOptions = append(grpcServerOptions, grpc.MaxSendMsgSize(config.MaxSendMessageSize))
+	grpcServerOptions = append(grpcServerOptions, grpc.StatsHandler(newOversizedMessageHandler(grpcBrokerMetricsSubsystem)))
 	grpcServerOptions = append(grpcServerOptions, grpc.MaxConcurrentStreams(config.MaxConcurrentStreams))
 	grpcServerOptions = append(grpcServerOptions, grpc.ConnectionTimeout(config.ConnectionTimeout))
 	grpcServerOptions = append(grpcServerOptions, grpc.WriteBufferSize(config.WriteBufferSize))
@@ -124,7 +231,18 @@ func NewGRPCBroker(eventBroadcaster *event.EventBroadcaster) EventServer {
 		klog.Infof("Serving gRPC broker without TLS at %s", config.ServerBindPort)
 	}
 
+	if config.BrokerEnableConsumerTokenAuth {
+		// the agents must connect with a consumer token, and can only operate on the topic of its consumer
+		grpcServerOptions = append(grpcServerOptions,
+			grpc.UnaryInterceptor(newConsumerTokenUnaryInterceptor(env().Services.ConsumerTokens())),
+			grpc.StreamInterceptor(newConsumerTokenStreamInterceptor(env().Services.ConsumerTokens())))
+	}
+
 	sessionFactory := env().Database.SessionFactory
+	var ownerLocator consumerOwnerLocator
+	if config.BrokerEnableConnectionAffinity {
+		ownerLocator = dispatcher.NewHashRing(dao.NewInstanceDao(&sessionFactory), env().Config.EventServer.ConsistentHashConfig)
+	}
 	return &GRPCBroker{
 		grpcServer:         grpc.NewServer(grpcServerOptions...),
 		instanceID:         env().Config.MessageBroker.ClientID,
@@ -133,8 +251,13 @@ func NewGRPCBroker(eventBroadcaster *event.EventBroadcaster) EventServer {
 		eventService:       env().Services.Events(),
 		statusEventService: env().Services.StatusEvents(),
 		bindAddress:        env().Config.HTTPServer.Hostname + ":" + config.BrokerBindPort,
+		trustedProxyCIDRs:  config.ProxyProtocolTrustedCIDRs,
 		subscribers:        make(map[string]*subscriber),
+		maxSendFailures:    config.BrokerSubscriberMaxSendFailures,
+		sendFailureWindow:  config.BrokerSubscriberSendFailureWindow,
 		eventBroadcaster:   eventBroadcaster,
+		statusForwarder:    env().Clients.StatusForwarder,
+		ownerLocator:       ownerLocator,
 	}
 }
 
@@ -145,13 +268,23 @@ func (bkr *GRPCBroker) Start(ctx context.Context) {
 	if err != nil {
 		check(fmt.Errorf("failed to listen: %v", err), "Can't start gRPC broker")
 	}
+	lis, err = newProxyProtocolListener(lis, bkr.trustedProxyCIDRs)
+	if err != nil {
+		check(fmt.Errorf("failed to listen: %v", err), "Can't start gRPC broker")
+	}
 	pbv1.RegisterCloudEventServiceServer(bkr.grpcServer, bkr)
+	resourcelist.RegisterResourceListServiceServer(bkr.grpcServer, bkr)
+	resourcestatus.RegisterResourceStatusServiceServer(bkr.grpcServer, bkr)
 	go func() {
 		if err := bkr.grpcServer.Serve(lis); err != nil {
 			check(fmt.Errorf("failed to serve gRPC broker: %v", err), "Can't start gRPC broker")
 		}
 	}()
 
+	if bkr.ownerLocator != nil {
+		go bkr.ownerLocator.Start(ctx, env().Database.SessionFactory)
+	}
+
 	// wait until context is canceled
 	<-ctx.Done()
 	log.Infof("Shutting down gRPC broker")
@@ -159,6 +292,10 @@ func (bkr *GRPCBroker) Start(ctx context.Context) {
 
 // Publish in stub implementation for maestro agent publish resource status back to maestro server.
 func (bkr *GRPCBroker) Publish(ctx context.Context, pubReq *pbv1.PublishRequest) (*emptypb.Empty, error) {
+	if err := checkMigrated(ctx, true); err != nil {
+		return nil, err
+	}
+
 	// WARNING: don't use "evt, err := pb.FromProto(pubReq.Event)" to convert protobuf to cloudevent
 	evt, err := binding.ToEvent(ctx, grpcprotocol.NewMessage(pubReq.Event))
 	if err != nil {
@@ -170,7 +307,18 @@ func (bkr *GRPCBroker) Publish(ctx context.Context, pubReq *pbv1.PublishRequest)
 		return nil, fmt.Errorf("failed to parse cloud event type %s, %v", evt.Type(), err)
 	}
 
-	klog.V(4).Infof("receive the event with grpc broker, %s", evt)
+	klog.V(4).Infof("receive the event with grpc broker from %s, %s", peerAddress(ctx), evt)
+
+	// the status is kept with the JSON data, transcode the data if the agent sends another format
+	if err := api.DecodeCloudEventData(evt); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to decode cloudevent data: %v", err)
+	}
+
+	// the agent connected with a consumer token can only publish the events of its consumer
+	clusterName, _ := cetypes.ToString(evt.Extensions()[types.ExtensionClusterName])
+	if err := checkConsumer(ctx, clusterName); err != nil {
+		return nil, err
+	}
 
 	// handler resync request
 	if eventType.Action == types.ResyncRequestAction {
@@ -181,6 +329,11 @@ func (bkr *GRPCBroker) Publish(ctx context.Context, pubReq *pbv1.PublishRequest)
 		return &emptypb.Empty{}, nil
 	}
 
+	// the agents only report the statuses, a spec event from an agent must not change the resources
+	if eventType.SubResource != types.SubResourceStatus {
+		return nil, status.Errorf(codes.InvalidArgument, "unsupported sub-resource %s of the agent event", eventType.SubResource)
+	}
+
 	// decode the cloudevent data as resource with status
 	resource, err := decodeResourceStatus(eventType.CloudEventsDataType, evt)
 	if err != nil {
@@ -201,13 +354,15 @@ func (bkr *GRPCBroker) register(clusterName string, handler resourceHandler) (st
 	defer bkr.mu.Unlock()
 
 	id := uuid.NewString()
-	errChan := make(chan error)
+	// the channel is buffered, so the error is pushed without blocking the broker
+	errChan := make(chan error, 1)
 	bkr.subscribers[id] = &subscriber{
 		clusterName: clusterName,
 		handler:     handler,
 		errChan:     errChan,
 	}
 
+	grpcBrokerActiveSubscribersMetric.Inc()
 	klog.V(4).Infof("registered a subscriber %s (cluster name = %s)", id, clusterName)
 	return id, errChan
 }
@@ -219,6 +374,7 @@ func (bkr *GRPCBroker) unregister(id string) {
 
 	close(bkr.subscribers[id].errChan)
 	delete(bkr.subscribers, id)
+	grpcBrokerActiveSubscribersMetric.Dec()
 	klog.V(4).Infof("unregistered subscriber %s", id)
 }
 
@@ -227,22 +383,51 @@ func (bkr *GRPCBroker) unregister(id string) {
 // The work agent will continuously attempt to send status updates to the gRPC broker.
 // If the broker is down or disconnected, the agent will resend the status once the broker is back up or reconnected.
 func (bkr *GRPCBroker) Subscribe(subReq *pbv1.SubscriptionRequest, subServer pbv1.CloudEventService_SubscribeServer) error {
+	if err := checkMigrated(subServer.Context(), false); err != nil {
+		return err
+	}
 	if len(subReq.ClusterName) == 0 {
 		return fmt.Errorf("invalid subscription request: missing cluster name")
 	}
+	if err := checkConsumer(subServer.Context(), subReq.ClusterName); err != nil {
+		return err
+	}
+	resourceType, err := getResourceTypeFilter(subServer.Context())
+	if err != nil {
+		return err
+	}
+	contentType, err := getDataContentType(subServer.Context())
+	if err != nil {
+		return err
+	}
+	if owner := bkr.redirectOwner(subReq.ClusterName); owner != nil {
+		// hint the agent with the address of the owning instance, the agent (or a proxy in front of it) reconnects
+		// to that address
+		if err := subServer.SetHeader(metadata.Pairs(ownerAddressKey, owner.Address)); err != nil {
+			klog.Errorf("failed to set the owner address header for cluster %s: %v", subReq.ClusterName, err)
+		}
+		return status.Errorf(codes.Unavailable, "the consumer %s is owned by the maestro instance %s, reconnect to %s",
+			subReq.ClusterName, owner.ID, owner.Address)
+	}
 	// register the cluster for subscription to the resource spec
+	klog.V(4).Infof("cluster %s subscribes from %s", subReq.ClusterName, peerAddress(subServer.Context()))
 	subscriberID, errChan := bkr.register(subReq.ClusterName, func(res *api.Resource) error {
-		evt, err := encodeResourceSpec(res)
+		if !matchResourceType(resourceType, res) {
+			// the subscriber doesn't care about this resource type, skip it
+			return nil
+		}
+
+		evt, err := encodeResourceSpec(res, contentType)
 		if err != nil {
 			// return the error to requeue the event if encoding fails (e.g., due to invalid resource spec).
-			return fmt.Errorf("failed to encode resource %s to cloudevent: %v", res.ID, err)
+			return fmt.Errorf("%w %s to cloudevent: %v", errEncodeResource, res.ID, err)
 		}
 
 		// WARNING: don't use "pbEvt, err := pb.ToProto(evt)" to convert cloudevent to protobuf
 		pbEvt := &pbv1.CloudEvent{}
 		if err = grpcprotocol.WritePBMessage(context.TODO(), binding.ToMessage(evt), pbEvt); err != nil {
 			// return the error to requeue the event if converting to protobuf fails (e.g., due to invalid cloudevent).
-			return fmt.Errorf("failed to convert cloudevent to protobuf for resource(%s): %v", res.ID, err)
+			return fmt.Errorf("%w %s to protobuf: %v", errEncodeResource, res.ID, err)
 		}
 
 		// send the cloudevent to the subscriber
@@ -277,6 +462,178 @@ func (bkr *GRPCBroker) Subscribe(subReq *pbv1.SubscriptionRequest, subServer pbv
 	}
 }
 
+// ListResources streams the spec events of all the active resources of the cluster, so a bootstrapping agent can
+// pull the current resource specs in one call rather than relying on a resync. The resources are loaded page by page
+// and the resource type can be filtered with the same metadata as the Subscribe stream.
+func (bkr *GRPCBroker) ListResources(req *pbv1.SubscriptionRequest, stream resourcelist.ResourceListService_ListResourcesServer) error {
+	ctx := stream.Context()
+	if err := checkMigrated(ctx, false); err != nil {
+		return err
+	}
+	if len(req.ClusterName) == 0 {
+		return status.Errorf(codes.InvalidArgument, "invalid list resources request: missing cluster name")
+	}
+	if err := checkConsumer(ctx, req.ClusterName); err != nil {
+		return err
+	}
+	resourceType, err := getResourceTypeFilter(ctx)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	contentType, err := getDataContentType(ctx)
+	if err != nil {
+		return err
+	}
+
+	afterID := ""
+	for {
+		resources, svcErr := bkr.resourceService.FindActiveByConsumerName(ctx, req.ClusterName, resourceType, afterID, listResourcesPageSize)
+		if svcErr != nil {
+			return status.Errorf(codes.Internal, "failed to list resources of cluster %s: %s", req.ClusterName, svcErr)
+		}
+
+		for _, res := range resources {
+			evt, err := encodeResourceSpec(res, contentType)
+			if err != nil {
+				return status.Errorf(codes.Internal, "failed to encode resource %s to cloudevent: %v", res.ID, err)
+			}
+
+			// WARNING: don't use "pbEvt, err := pb.ToProto(evt)" to convert cloudevent to protobuf
+			pbEvt := &pbv1.CloudEvent{}
+			if err := grpcprotocol.WritePBMessage(ctx, binding.ToMessage(evt), pbEvt); err != nil {
+				return status.Errorf(codes.Internal, "failed to convert cloudevent to protobuf for resource(%s): %v", res.ID, err)
+			}
+			if err := stream.Send(pbEvt); err != nil {
+				return err
+			}
+		}
+
+		if len(resources) < listResourcesPageSize {
+			return nil
+		}
+		afterID = resources[len(resources)-1].ID
+	}
+}
+
+// GetResourceStatus returns the current status event of the resource, so an agent can pull the status of a resource
+// over its gRPC connection. An agent connected with a consumer token can only get the status of the resources of its
+// own cluster.
+func (bkr *GRPCBroker) GetResourceStatus(ctx context.Context, req *wrapperspb.StringValue) (*pbv1.CloudEvent, error) {
+	if err := checkMigrated(ctx, false); err != nil {
+		return nil, err
+	}
+	return getResourceStatus(ctx, bkr.resourceService, req.GetValue(), nil, func(res *api.Resource) error {
+		return checkConsumer(ctx, res.ConsumerName)
+	})
+}
+
+// redirectOwner returns the instance that the agent of the consumer should be redirected to, it returns nil if the
+// connection affinity is disabled, this instance owns the consumer, or the owner is unknown or has no advertised
+// address, so that the agent is served by this instance rather than rejected.
+func (bkr *GRPCBroker) redirectOwner(consumerName string) *api.ServerInstance {
+	if bkr.ownerLocator == nil {
+		return nil
+	}
+
+	owner := bkr.ownerLocator.Locate(consumerName)
+	if owner == nil || owner.ID == bkr.instanceID || len(owner.Address) == 0 {
+		return nil
+	}
+
+	return owner
+}
+
+// getResourceTypeFilter returns the resource type filter of the Subscribe stream, an empty resource type is
+// returned if no filter is specified.
+func getResourceTypeFilter(ctx context.Context) (api.ResourceType, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", nil
+	}
+
+	values := md.Get(resourceTypeFilterKey)
+	if len(values) == 0 {
+		return "", nil
+	}
+
+	switch resourceType := api.ResourceType(values[0]); resourceType {
+	case api.ResourceTypeSingle, api.ResourceTypeBundle:
+		return resourceType, nil
+	default:
+		return "", status.Errorf(codes.InvalidArgument, "unsupported resource type filter %s", values[0])
+	}
+}
+
+// getConditionTransitionFilter returns the condition type of the condition transition filter of the Subscribe stream,
+// an empty condition type is returned if there is no filter.
+func getConditionTransitionFilter(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", nil
+	}
+
+	values := md.Get(conditionTransitionFilterKey)
+	if len(values) == 0 {
+		return "", nil
+	}
+
+	if conditionType := strings.TrimSpace(values[0]); len(conditionType) != 0 && !strings.Contains(conditionType, "=") {
+		return conditionType, nil
+	}
+	return "", status.Errorf(codes.InvalidArgument, "invalid condition transition filter %q, it must be a condition type", values[0])
+}
+
+// getMaxSubscribeDuration returns the max duration of the Subscribe stream, 0 (no limit) is returned if it is not
+// set.
+func getMaxSubscribeDuration(ctx context.Context) (time.Duration, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return 0, nil
+	}
+
+	values := md.Get(maxSubscribeDurationKey)
+	if len(values) == 0 {
+		return 0, nil
+	}
+
+	duration, err := time.ParseDuration(values[0])
+	if err != nil || duration <= 0 {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid max subscribe duration %q, it must be a positive duration", values[0])
+	}
+	return duration, nil
+}
+
+// getDataContentType returns the data content type that the subscriber advertises with the Subscribe stream, an
+// empty content type (JSON) is returned if it is not advertised.
+func getDataContentType(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", nil
+	}
+
+	values := md.Get(dataContentTypeKey)
+	if len(values) == 0 {
+		return "", nil
+	}
+
+	if err := api.ValidateDataContentType(values[0]); err != nil {
+		return "", status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	return values[0], nil
+}
+
+// matchResourceType returns true if the resource matches the resource type filter, all the resources are matched
+// when there is no filter.
+func matchResourceType(resourceType api.ResourceType, res *api.Resource) bool {
+	return len(resourceType) == 0 || res.Type == resourceType
+}
+
+// matchConditionTransition returns true if the status of the condition type is transitioned by the status update
+// that the resource is broadcast for, all the resources are matched when there is no filter.
+func matchConditionTransition(conditionType string, res *api.Resource) bool {
+	return len(conditionType) == 0 || res.ConditionTransitioned(conditionType)
+}
+
 // decodeResourceStatus translates a CloudEvent into a resource containing the status JSON map.
 func decodeResourceStatus(eventDataType types.CloudEventsDataType, evt *ce.Event) (*api.Resource, error) {
 	evtExtensions := evt.Context.GetExtensions()
@@ -291,7 +648,7 @@ func decodeResourceStatus(eventDataType types.CloudEventsDataType, evt *ce.Event
 		return nil, fmt.Errorf("failed to get resourceid extension: %v", err)
 	}
 
-	resourceVersion, err := cetypes.ToInteger(evtExtensions[types.ExtensionResourceVersion])
+	resourceVersion, err := api.ToResourceVersion(evtExtensions[types.ExtensionResourceVersion])
 	if err != nil {
 		return nil, fmt.Errorf("failed to get resourceversion extension: %v", err)
 	}
@@ -323,8 +680,9 @@ func decodeResourceStatus(eventDataType types.CloudEventsDataType, evt *ce.Event
 	return resource, nil
 }
 
-// encodeResourceSpec translates a resource spec JSON map into a CloudEvent.
-func encodeResourceSpec(resource *api.Resource) (*ce.Event, error) {
+// encodeResourceSpec translates a resource spec JSON map into a CloudEvent, the data is encoded with the given data
+// content type, JSON is used if it is empty.
+func encodeResourceSpec(resource *api.Resource, contentType string) (*ce.Event, error) {
 	evt, err := api.JSONMAPToCloudEvent(resource.Payload)
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert resource payload to cloudevent: %v", err)
@@ -339,16 +697,27 @@ func encodeResourceSpec(resource *api.Resource) (*ce.Event, error) {
 		eventType.CloudEventsDataType = workpayload.ManifestBundleEventDataType
 	}
 	evt.SetType(eventType.String())
-	evt.SetSource("maestro")
+	evt.SetSource(api.EventSource())
 	// TODO set resource.Source with a new extension attribute if the agent needs
 	evt.SetExtension(types.ExtensionResourceID, resource.ID)
-	evt.SetExtension(types.ExtensionResourceVersion, int64(resource.Version))
+	if err := api.SetResourceVersion(evt, resource.Version); err != nil {
+		return nil, err
+	}
 	evt.SetExtension(types.ExtensionClusterName, resource.ConsumerName)
 
+	// attach the resource metadata, so the agent receives the same correlation metadata as the source
+	if err := api.SetMetadataExtensions(evt, resource.Metadata); err != nil {
+		return nil, fmt.Errorf("failed to set resource metadata to cloudevent: %v", err)
+	}
+
 	if !resource.GetDeletionTimestamp().IsZero() {
 		evt.SetExtension(types.ExtensionDeletionTimestamp, resource.GetDeletionTimestamp().Time)
 	}
 
+	if err := api.EncodeCloudEventData(evt, contentType); err != nil {
+		return nil, fmt.Errorf("failed to encode resource payload: %v", err)
+	}
+
 	return evt, nil
 }
 
@@ -399,6 +768,10 @@ func (bkr *GRPCBroker) respondResyncSpecRequest(ctx context.Context, eventDataTy
 			bkr.handleRes(obj)
 			continue
 		}
+		// the quarantined resource is kept on the agent, but its spec is not resent until it is released
+		if obj.IsQuarantined() {
+			continue
+		}
 
 		lastResourceVersion := findResourceVersion(string(obj.GetUID()), resourceVersions.Versions)
 		currentResourceVersion, err := strconv.ParseInt(obj.GetResourceVersion(), 10, 64)
@@ -425,7 +798,7 @@ func (bkr *GRPCBroker) respondResyncSpecRequest(ctx context.Context, eventDataTy
 			Meta: api.Meta{
 				ID: rv.ResourceID,
 			},
-			Version:      int32(rv.ResourceVersion),
+			Version:      rv.ResourceVersion,
 			ConsumerName: clusterName,
 			Type:         resyncType,
 		}
@@ -445,17 +818,30 @@ func (bkr *GRPCBroker) handleRes(resource *api.Resource) error {
 	defer bkr.mu.RUnlock()
 	for _, subscriber := range bkr.subscribers {
 		if subscriber.clusterName == resource.ConsumerName {
+			if subscriber.isUnreachable() {
+				// the subscriber is going to be unregistered, skip it
+				continue
+			}
 			if err := subscriber.handler(resource); err != nil {
-				// check if the error is recoverable. For unrecoverable errors,
-				// such as a connection closed by an intermediate proxy, push
-				// the error to subscriber's error channel to unregister the subscriber.
-				st, ok := status.FromError(err)
-				if ok && st.Code() == codes.Unavailable {
-					// TODO: handle more error codes that can't be recovered
-					subscriber.errChan <- err
+				if errors.Is(err, errEncodeResource) {
+					return err
+				}
+				// check if the error is recoverable. An unavailable stream, such as a connection closed by an
+				// intermediate proxy, can't be recovered. For the other send errors (e.g. the stream of an agent is
+				// dead but its context is not done yet), the subscriber is unregistered after the max consecutive
+				// send failures. The error is pushed to the subscriber's error channel to unregister the subscriber.
+				if status.Code(err) == codes.Unavailable ||
+					subscriber.recordSendFailure(bkr.maxSendFailures, bkr.sendFailureWindow) {
+					if subscriber.markUnreachable() {
+						select {
+						case subscriber.errChan <- err:
+						default:
+						}
+					}
 				}
 				return err
 			}
+			subscriber.recordSendSuccess()
 		}
 	}
 	return nil
@@ -472,6 +858,12 @@ func (bkr *GRPCBroker) OnCreate(ctx context.Context, id string) error {
 		return err
 	}
 
+	// the spec of a quarantined resource is not sent until the resource is released
+	if resource.IsQuarantined() {
+		klog.V(4).Infof("skip sending the spec of the quarantined resource %s", resource.ID)
+		return nil
+	}
+
 	return bkr.handleRes(resource)
 }
 
@@ -486,6 +878,12 @@ func (bkr *GRPCBroker) OnUpdate(ctx context.Context, id string) error {
 		return err
 	}
 
+	// the spec of a quarantined resource is not sent until the resource is released
+	if resource.IsQuarantined() {
+		klog.V(4).Infof("skip sending the spec of the quarantined resource %s", resource.ID)
+		return nil
+	}
+
 	return bkr.handleRes(resource)
 }
 
@@ -514,6 +912,7 @@ func (bkr *GRPCBroker) OnStatusUpdate(ctx context.Context, eventID, resourceID s
 		bkr.resourceService,
 		bkr.eventInstanceDao,
 		bkr.eventBroadcaster,
+		bkr.statusForwarder,
 		bkr.instanceID,
 		eventID,
 		resourceID,
@@ -534,6 +933,11 @@ func (bkr *GRPCBroker) PredicateEvent(ctx context.Context, eventID string) (bool
 		return false, nil
 	}
 
+	// the consumer events are not related to a resource, they can be processed by any instance
+	if evt.Source != "Resources" {
+		return true, nil
+	}
+
 	resource, svcErr := bkr.resourceService.Get(ctx, evt.SourceID)
 	if svcErr != nil {
 		// if the resource is not found, it indicates the resource has been handled by other instances.
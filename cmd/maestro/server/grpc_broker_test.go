@@ -0,0 +1,288 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/db"
+)
+
+// fakeOwnerLocator locates the consumers to the fixed owners.
+type fakeOwnerLocator struct {
+	owners map[string]*api.ServerInstance
+}
+
+func (l *fakeOwnerLocator) Start(ctx context.Context, sessionFactory db.SessionFactory) {}
+
+func (l *fakeOwnerLocator) Locate(consumerName string) *api.ServerInstance {
+	return l.owners[consumerName]
+}
+
+func TestRedirectOwner(t *testing.T) {
+	locator := &fakeOwnerLocator{
+		owners: map[string]*api.ServerInstance{
+			"local":      {Meta: api.Meta{ID: "maestro-1"}, Address: "maestro-1:8091"},
+			"remote":     {Meta: api.Meta{ID: "maestro-2"}, Address: "maestro-2:8091"},
+			"no-address": {Meta: api.Meta{ID: "maestro-3"}},
+		},
+	}
+
+	cases := []struct {
+		name            string
+		ownerLocator    consumerOwnerLocator
+		consumerName    string
+		expectedAddress string
+	}{
+		{
+			name:         "connection affinity disabled",
+			consumerName: "remote",
+		},
+		{
+			name:         "owned by the current instance",
+			ownerLocator: locator,
+			consumerName: "local",
+		},
+		{
+			name:            "owned by another instance",
+			ownerLocator:    locator,
+			consumerName:    "remote",
+			expectedAddress: "maestro-2:8091",
+		},
+		{
+			name:         "owner without advertised address",
+			ownerLocator: locator,
+			consumerName: "no-address",
+		},
+		{
+			name:         "owner unknown",
+			ownerLocator: locator,
+			consumerName: "unknown",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			bkr := &GRPCBroker{instanceID: "maestro-1", ownerLocator: c.ownerLocator}
+			owner := bkr.redirectOwner(c.consumerName)
+			if len(c.expectedAddress) == 0 {
+				if owner != nil {
+					t.Errorf("expected the consumer is served locally, but redirected to %s", owner.Address)
+				}
+				return
+			}
+			if owner == nil || owner.Address != c.expectedAddress {
+				t.Errorf("expected the consumer is redirected to %s, but got %v", c.expectedAddress, owner)
+			}
+		})
+	}
+}
+
+func TestResourceTypeFilter(t *testing.T) {
+	single := &api.Resource{Type: api.ResourceTypeSingle}
+	bundle := &api.Resource{Type: api.ResourceTypeBundle}
+
+	cases := []struct {
+		name          string
+		value         string
+		expectedErr   bool
+		matchesSingle bool
+		matchesBundle bool
+	}{
+		{name: "no filter", matchesSingle: true, matchesBundle: true},
+		{name: "single", value: string(api.ResourceTypeSingle), matchesSingle: true},
+		{name: "bundle", value: string(api.ResourceTypeBundle), matchesBundle: true},
+		{name: "unsupported", value: "Manifest", expectedErr: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ctx := context.Background()
+			if c.value != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(resourceTypeFilterKey, c.value))
+			}
+			resourceType, err := getResourceTypeFilter(ctx)
+			if c.expectedErr != (err != nil) {
+				t.Fatalf("expected error %v, but got %v", c.expectedErr, err)
+			}
+			if c.expectedErr {
+				return
+			}
+			if matched := matchResourceType(resourceType, single); matched != c.matchesSingle {
+				t.Errorf("expected the single resource is matched %v, but got %v", c.matchesSingle, matched)
+			}
+			if matched := matchResourceType(resourceType, bundle); matched != c.matchesBundle {
+				t.Errorf("expected the resource bundle is matched %v, but got %v", c.matchesBundle, matched)
+			}
+		})
+	}
+}
+
+func TestSubscriberSendFailures(t *testing.T) {
+	s := &subscriber{}
+
+	// the subscriber is unreachable after the max consecutive send failures
+	if s.recordSendFailure(3, time.Minute) || s.recordSendFailure(3, time.Minute) {
+		t.Errorf("expected the subscriber is reachable before the max send failures")
+	}
+	if !s.recordSendFailure(3, time.Minute) {
+		t.Errorf("expected the subscriber is unreachable after the max send failures")
+	}
+
+	// a successful send resets the consecutive send failures
+	s.recordSendSuccess()
+	if s.recordSendFailure(3, time.Minute) || s.recordSendFailure(3, time.Minute) {
+		t.Errorf("expected the send failures are reset by the successful send")
+	}
+
+	// the send failures out of the window are not counted
+	s.firstSendFailure = time.Now().Add(-2 * time.Minute)
+	if s.recordSendFailure(3, time.Minute) {
+		t.Errorf("expected the send failures out of the window are reset")
+	}
+	if s.sendFailures != 1 {
+		t.Errorf("expected one send failure in the window, but got %d", s.sendFailures)
+	}
+
+	// the subscriber is never unreachable by the send failures if the max send failures is 0
+	disabled := &subscriber{}
+	for i := 0; i < 10; i++ {
+		if disabled.recordSendFailure(0, time.Minute) {
+			t.Fatalf("expected the unregistration by the send failures is disabled")
+		}
+	}
+}
+
+func TestHandleResUnregistersUnreachableSubscriber(t *testing.T) {
+	ResetGRPCMetrics()
+	defer ResetGRPCMetrics()
+
+	bkr := &GRPCBroker{
+		subscribers:       make(map[string]*subscriber),
+		maxSendFailures:   3,
+		sendFailureWindow: time.Minute,
+	}
+	id, errChan := bkr.register("cluster1", func(res *api.Resource) error {
+		return status.Error(codes.Internal, "transport is closing")
+	})
+	if active := testutil.ToFloat64(grpcBrokerActiveSubscribersMetric); active != 1 {
+		t.Fatalf("expected one active subscriber, but got %v", active)
+	}
+
+	// the subscriber is unregistered once its error is received, as the Subscribe stream does
+	received := make(chan error, 1)
+	go func() {
+		err := <-errChan
+		received <- err
+		bkr.unregister(id)
+	}()
+
+	res := &api.Resource{Meta: api.Meta{ID: "r1"}, ConsumerName: "cluster1"}
+	for i := 0; i < 2; i++ {
+		if err := bkr.handleRes(res); err == nil {
+			t.Fatalf("expected the send failure is returned")
+		}
+	}
+	select {
+	case err := <-received:
+		t.Fatalf("expected the subscriber is kept before the max send failures, but got %v", err)
+	default:
+	}
+
+	if err := bkr.handleRes(res); err == nil {
+		t.Fatalf("expected the send failure is returned")
+	}
+	select {
+	case <-received:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected the subscriber is unregistered after the max send failures")
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		bkr.mu.RLock()
+		count := len(bkr.subscribers)
+		bkr.mu.RUnlock()
+		if count == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected no subscriber, but got %d", count)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if active := testutil.ToFloat64(grpcBrokerActiveSubscribersMetric); active != 0 {
+		t.Errorf("expected no active subscriber, but got %v", active)
+	}
+}
+
+func TestHandleResSendFailures(t *testing.T) {
+	cases := []struct {
+		name                string
+		sendErr             error
+		expectedUnreachable []bool
+	}{
+		{
+			name:                "unavailable stream",
+			sendErr:             status.Error(codes.Unavailable, "connection closed"),
+			expectedUnreachable: []bool{true},
+		},
+		{
+			name:                "send error without a status",
+			sendErr:             io.EOF,
+			expectedUnreachable: []bool{false, false, true},
+		},
+		{
+			name:                "encoding error",
+			sendErr:             fmt.Errorf("%w r1 to cloudevent: invalid manifest", errEncodeResource),
+			expectedUnreachable: []bool{false, false, false, false},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ResetGRPCMetrics()
+			defer ResetGRPCMetrics()
+
+			bkr := &GRPCBroker{
+				subscribers:       make(map[string]*subscriber),
+				maxSendFailures:   3,
+				sendFailureWindow: time.Minute,
+			}
+			// nobody receives the error, the broker must not be blocked by pushing it
+			id, errChan := bkr.register("cluster1", func(res *api.Resource) error {
+				return c.sendErr
+			})
+
+			res := &api.Resource{Meta: api.Meta{ID: "r1"}, ConsumerName: "cluster1"}
+			for i, expected := range c.expectedUnreachable {
+				if err := bkr.handleRes(res); err == nil {
+					t.Fatalf("expected the send failure is returned")
+				}
+				if unreachable := bkr.subscribers[id].isUnreachable(); unreachable != expected {
+					t.Fatalf("expected the subscriber is unreachable=%v after %d send failures, but got %v",
+						expected, i+1, unreachable)
+				}
+			}
+
+			select {
+			case err := <-errChan:
+				if !c.expectedUnreachable[len(c.expectedUnreachable)-1] {
+					t.Errorf("expected no error is pushed, but got %v", err)
+				}
+			default:
+				if c.expectedUnreachable[len(c.expectedUnreachable)-1] {
+					t.Errorf("expected the error is pushed to the subscriber")
+				}
+			}
+		})
+	}
+}
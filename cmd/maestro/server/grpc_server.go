@@ -7,16 +7,25 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"strings"
+	"sync"
 	"time"
 
 	ce "github.com/cloudevents/sdk-go/v2"
 	"github.com/cloudevents/sdk-go/v2/binding"
 	cetypes "github.com/cloudevents/sdk-go/v2/types"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 	"k8s.io/klog/v2"
 	pbv1 "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/grpc/protobuf/v1"
 	grpcprotocol "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/grpc/protocol"
@@ -28,8 +37,10 @@ import (
 
 	"github.com/openshift-online/maestro/pkg/api"
 	"github.com/openshift-online/maestro/pkg/client/cloudevents"
+	"github.com/openshift-online/maestro/pkg/client/cloudevents/resourcestatus"
 	"github.com/openshift-online/maestro/pkg/client/grpcauthorizer"
 	"github.com/openshift-online/maestro/pkg/config"
+	"github.com/openshift-online/maestro/pkg/constants"
 	"github.com/openshift-online/maestro/pkg/event"
 	"github.com/openshift-online/maestro/pkg/services"
 )
@@ -37,19 +48,51 @@ import (
 // GRPCServer includes a gRPC server and a resource service
 type GRPCServer struct {
 	pbv1.UnimplementedCloudEventServiceServer
-	grpcServer        *grpc.Server
-	eventBroadcaster  *event.EventBroadcaster
-	resourceService   services.ResourceService
-	disableAuthorizer bool
-	grpcAuthorizer    grpcauthorizer.GRPCAuthorizer
-	bindAddress       string
+	resourcestatus.UnimplementedResourceStatusServiceServer
+	grpcServer            *grpc.Server
+	eventBroadcaster      *event.EventBroadcaster
+	resourceService       services.ResourceService
+	statusEventService    services.StatusEventService
+	disableAuthorizer     bool
+	grpcAuthorizer        grpcauthorizer.GRPCAuthorizer
+	allowedSourcePrefixes map[string]string
+	passthroughExtensions []string
+	// propagateTraceContext starts the server spans as the children of the trace context of the published events
+	propagateTraceContext bool
+	sourceRewrites        map[string]string
+	enableAsyncPublish    bool
+	versionRollbackMode   string
+	trustedProxyCIDRs     []string
+	asyncCommits          chan *asyncCommit
+	asyncCommitsDone      chan struct{}
+	stopOnce              sync.Once
+	statusResender        *statusResender
+	bindAddress           string
 }
 
+// asyncCommit is a resource accepted with the async commit mode and waiting to be committed.
+type asyncCommit struct {
+	action   types.EventAction
+	resource *api.Resource
+}
+
+// tracerName is the name of the tracer of the gRPC server spans.
+const tracerName = "github.com/openshift-online/maestro/cmd/maestro/server"
+
+// asyncCommitQueueSize is the max number of the accepted resources waiting to be committed, the async publish is
+// rejected with ResourceExhausted once the queue is full.
+const asyncCommitQueueSize = 1000
+
 // NewGRPCServer creates a new GRPCServer
 func NewGRPCServer(resourceService services.ResourceService, eventBroadcaster *event.EventBroadcaster, config config.GRPCServerConfig, grpcAuthorizer grpcauthorizer.GRPCAuthorizer) *GRPCServer {
+	if err := api.ValidateMetadataExtensions(config.PassthroughExtensions); err != nil {
+		check(fmt.Errorf("invalid --grpc-passthrough-extensions: %v", err), "Can't start gRPC server")
+	}
+
 	grpcServerOptions := make([]grpc.ServerOption, 0)
 	grpcServerOptions = append(grpcServerOptions, grpc.MaxRecvMsgSize(config.MaxReceiveMessageSize))
 	grpcServerOptions = append(grpcServerOptions, grpc.MaxSendMsgSize(config.MaxSendMessageSize))
+	grpcServerOptions = append(grpcServerOptions, grpc.StatsHandler(newOversizedMessageHandler(grpcMetricsSubsystem)))
 	grpcServerOptions = append(grpcServerOptions, grpc.MaxConcurrentStreams(config.MaxConcurrentStreams))
 	grpcServerOptions = append(grpcServerOptions, grpc.ConnectionTimeout(config.ConnectionTimeout))
 	grpcServerOptions = append(grpcServerOptions, grpc.WriteBufferSize(config.WriteBufferSize))
@@ -127,13 +170,26 @@ func NewGRPCServer(resourceService services.ResourceService, eventBroadcaster *e
 		klog.Infof("Serving gRPC service without TLS at %s", config.ServerBindPort)
 	}
 
+	check(validateVersionRollbackMode(config.VersionRollbackMode), "Invalid gRPC version rollback mode")
+
 	return &GRPCServer{
-		grpcServer:        grpc.NewServer(grpcServerOptions...),
-		eventBroadcaster:  eventBroadcaster,
-		resourceService:   resourceService,
-		disableAuthorizer: config.DisableTLS,
-		grpcAuthorizer:    grpcAuthorizer,
-		bindAddress:       env().Config.HTTPServer.Hostname + ":" + config.ServerBindPort,
+		grpcServer:            grpc.NewServer(grpcServerOptions...),
+		eventBroadcaster:      eventBroadcaster,
+		resourceService:       resourceService,
+		statusEventService:    env().Services.StatusEvents(),
+		disableAuthorizer:     config.DisableTLS,
+		grpcAuthorizer:        grpcAuthorizer,
+		allowedSourcePrefixes: config.AllowedSourcePrefixes,
+		passthroughExtensions: config.PassthroughExtensions,
+		propagateTraceContext: config.TraceContextPropagation,
+		sourceRewrites:        config.SourceRewrites,
+		enableAsyncPublish:    config.EnableAsyncPublish,
+		versionRollbackMode:   config.VersionRollbackMode,
+		trustedProxyCIDRs:     config.ProxyProtocolTrustedCIDRs,
+		asyncCommits:          make(chan *asyncCommit, asyncCommitQueueSize),
+		asyncCommitsDone:      make(chan struct{}),
+		statusResender:        newStatusResender(eventBroadcaster.Broadcast, config.ResyncConcurrency, config.ResyncBatchInterval),
+		bindAddress:           env().Config.HTTPServer.Hostname + ":" + config.ServerBindPort,
 	}
 }
 
@@ -145,17 +201,63 @@ func (svr *GRPCServer) Start() error {
 		klog.Errorf("failed to listen: %v", err)
 		return err
 	}
+	lis, err = newProxyProtocolListener(lis, svr.trustedProxyCIDRs)
+	if err != nil {
+		klog.Errorf("failed to listen: %v", err)
+		return err
+	}
 	pbv1.RegisterCloudEventServiceServer(svr.grpcServer, svr)
+	resourcestatus.RegisterResourceStatusServiceServer(svr.grpcServer, svr)
+	if svr.enableAsyncPublish {
+		go svr.runAsyncCommits()
+	}
 	return svr.grpcServer.Serve(lis)
 }
 
-// Stop stops the gRPC server
+// Stop stops the gRPC server, it's safe to call it more than once.
 func (svr *GRPCServer) Stop() {
-	svr.grpcServer.GracefulStop()
+	svr.stopOnce.Do(func() {
+		svr.grpcServer.GracefulStop()
+		svr.statusResender.Stop()
+		// no publish is in flight after the graceful stop, wait for the remaining accepted resources to be committed.
+		close(svr.asyncCommits)
+		if svr.enableAsyncPublish {
+			<-svr.asyncCommitsDone
+		}
+	})
 }
 
-// Publish implements the Publish method of the CloudEventServiceServer interface
-func (svr *GRPCServer) Publish(ctx context.Context, pubReq *pbv1.PublishRequest) (*emptypb.Empty, error) {
+// Publish implements the Publish method of the CloudEventServiceServer interface, the duration of each publish is
+// observed by the maestro_publish_duration_seconds metric.
+func (svr *GRPCServer) Publish(ctx context.Context, pubReq *pbv1.PublishRequest) (resp *emptypb.Empty, err error) {
+	start := time.Now()
+	defer func() {
+		outcome := publishOutcomeSuccess
+		if err != nil {
+			outcome = publishOutcomeError
+		}
+		publishDurationHistogramMetric.WithLabelValues(publishAction(pubReq), outcome).Observe(time.Since(start).Seconds())
+	}()
+
+	return svr.publish(ctx, pubReq)
+}
+
+// publishAction returns the action of the published event for the publish duration metric, e.g. create for the
+// create_request action.
+func publishAction(pubReq *pbv1.PublishRequest) string {
+	eventType, err := types.ParseCloudEventsType(pubReq.GetEvent().GetType())
+	if err != nil {
+		return "unknown"
+	}
+	return strings.TrimSuffix(string(eventType.Action), "_request")
+}
+
+// publish handles the publish request, it decodes the event and commits the resource, or responds the resync request.
+func (svr *GRPCServer) publish(ctx context.Context, pubReq *pbv1.PublishRequest) (*emptypb.Empty, error) {
+	if err := checkMigrated(ctx, true); err != nil {
+		return nil, err
+	}
+
 	// WARNING: don't use "evt, err := pb.FromProto(pubReq.Event)" to convert protobuf to cloudevent
 	evt, err := binding.ToEvent(ctx, grpcprotocol.NewMessage(pubReq.Event))
 	if err != nil {
@@ -166,6 +268,9 @@ func (svr *GRPCServer) Publish(ctx context.Context, pubReq *pbv1.PublishRequest)
 		// check if the event is from the authorized source
 		user := ctx.Value(contextUserKey).(string)
 		groups := ctx.Value(contextGroupsKey).([]string)
+		if err := svr.checkSourcePrefix(user, evt.Source()); err != nil {
+			return nil, err
+		}
 		allowed, err := svr.grpcAuthorizer.AccessReview(ctx, "pub", "source", evt.Source(), user, groups)
 		if err != nil {
 			return nil, fmt.Errorf("failed to authorize the request: %v", err)
@@ -180,7 +285,18 @@ func (svr *GRPCServer) Publish(ctx context.Context, pubReq *pbv1.PublishRequest)
 		return nil, fmt.Errorf("failed to parse cloud event type %s, %v", evt.Type(), err)
 	}
 
-	klog.V(4).Infof("receive the event with grpc server, %s", evt)
+	if svr.propagateTraceContext {
+		var span trace.Span
+		ctx, span = startPublishSpan(ctx, eventType, evt)
+		defer span.End()
+	}
+
+	klog.V(4).Infof("receive the event with grpc server from %s, %s", peerAddress(ctx), evt)
+
+	// the spec is kept with the JSON data, transcode the data if the source sends another format
+	if err := api.DecodeCloudEventData(evt); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to decode cloudevent data: %v", err)
+	}
 
 	// handler resync request
 	if eventType.Action == types.ResyncRequestAction {
@@ -191,53 +307,227 @@ func (svr *GRPCServer) Publish(ctx context.Context, pubReq *pbv1.PublishRequest)
 		return &emptypb.Empty{}, nil
 	}
 
-	res, err := decodeResourceSpec(eventType.CloudEventsDataType, evt)
+	// the status events only update the status of the resources, they never overwrite the spec
+	if eventType.SubResource == types.SubResourceStatus {
+		if err := svr.updateStatus(ctx, eventType, evt); err != nil {
+			return nil, err
+		}
+		return &emptypb.Empty{}, nil
+	}
+
+	res, err := decodeResourceSpec(eventType.CloudEventsDataType, evt, svr.passthroughExtensions)
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode cloudevent: %v", err)
 	}
 
-	switch eventType.Action {
+	if eventType.Action == common.UpdateRequestAction || eventType.Action == common.DeleteRequestAction {
+		if err := svr.checkResourceSource(ctx, res.ID, evt.Source()); err != nil {
+			return nil, err
+		}
+	}
+
+	commitMode, err := getCommitMode(evt)
+	if err != nil {
+		return nil, err
+	}
+
+	if commitMode == constants.CommitModeAsync {
+		if !svr.enableAsyncPublish {
+			return nil, status.Errorf(codes.FailedPrecondition, "the %s commit mode is not enabled", constants.CommitModeAsync)
+		}
+
+		// the resource is accepted, it will be committed in the background
+		if err := svr.acceptAsyncCommit(&asyncCommit{action: eventType.Action, resource: res}); err != nil {
+			return nil, err
+		}
+		return &emptypb.Empty{}, nil
+	}
+
+	// the resource is committed before the publish returns
+	if err := svr.commit(ctx, eventType.Action, res); err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// startPublishSpan starts the server span of the published event as the child of the trace context of the event, a
+// root span is started if the event has no trace context. The span context is set back to the event, so the stored
+// spec carries it to the agent and the agent continues the trace from the server span.
+func startPublishSpan(ctx context.Context, eventType *types.CloudEventsType, evt *ce.Event) (context.Context, trace.Span) {
+	ctx, span := otel.Tracer(tracerName).Start(api.ExtractTraceContext(ctx, evt),
+		fmt.Sprintf("publish %s", eventType.Action), trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			attribute.String("cloudevents.event_source", evt.Source()),
+			attribute.String("cloudevents.event_type", evt.Type()),
+		))
+	api.InjectTraceContext(ctx, evt)
+	return ctx, span
+}
+
+// The modes of an update of a resource bundle without a version, see config.GRPCServerConfig.VersionRollbackMode.
+const (
+	versionRollbackModeLenient = "lenient"
+	versionRollbackModeStrict  = "strict"
+)
+
+func validateVersionRollbackMode(mode string) error {
+	switch mode {
+	case versionRollbackModeLenient, versionRollbackModeStrict:
+		return nil
+	default:
+		return fmt.Errorf("unsupported version rollback mode %q, must be %s or %s",
+			mode, versionRollbackModeLenient, versionRollbackModeStrict)
+	}
+}
+
+// commit creates, updates or marks as deleting the resource in the database according to the event action.
+func (svr *GRPCServer) commit(ctx context.Context, action types.EventAction, res *api.Resource) error {
+	switch action {
 	case common.CreateRequestAction:
 		_, err := svr.resourceService.Create(ctx, res)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create resource: %v", err)
+			if err.IsValidation() {
+				return status.Errorf(codes.InvalidArgument, "failed to create resource: %v", err)
+			}
+			if err.IsUnavailable() {
+				return status.Errorf(codes.Unavailable, "failed to create resource: %v", err)
+			}
+			if err.IsConflict() {
+				if existing, findErr := svr.resourceService.FindConflicting(ctx, res); findErr == nil {
+					return resourceAlreadyExistsError(existing)
+				}
+				return status.Errorf(codes.AlreadyExists, "failed to create resource: %v", err)
+			}
+			return fmt.Errorf("failed to create resource: %v", err)
 		}
 	case common.UpdateRequestAction:
 		if res.Type == api.ResourceTypeBundle {
 			found, err := svr.resourceService.Get(ctx, res.ID)
 			if err != nil {
-				return nil, fmt.Errorf("failed to get resource: %v", err)
+				if err.IsUnavailable() {
+					return status.Errorf(codes.Unavailable, "failed to get resource: %v", err)
+				}
+				return fmt.Errorf("failed to get resource: %v", err)
 			}
 
 			if res.Version == 0 {
 				// the resource version is not guaranteed to be increased by source client,
-				// using the latest resource version.
+				// using the latest resource version unless the strict mode is enabled.
+				if svr.versionRollbackMode == versionRollbackModeStrict {
+					return status.Errorf(codes.Aborted, "the update of resource %s has no version, the latest version: %d",
+						res.ID, found.Version)
+				}
+				klog.Warningf("the update of resource %s from source %s has no version, using the latest version %d",
+					res.ID, res.Source, found.Version)
+				versionRollbackCountMetric.WithLabelValues(res.Source).Inc()
 				res.Version = found.Version
 			}
 		}
 		_, err := svr.resourceService.Update(ctx, res)
 		if err != nil {
-			return nil, fmt.Errorf("failed to update resource: %v", err)
+			if err.IsValidation() {
+				return status.Errorf(codes.InvalidArgument, "failed to update resource: %v", err)
+			}
+			if err.IsUnavailable() {
+				return status.Errorf(codes.Unavailable, "failed to update resource: %v", err)
+			}
+			return fmt.Errorf("failed to update resource: %v", err)
 		}
 	case common.DeleteRequestAction:
 		err := svr.resourceService.MarkAsDeleting(ctx, res.ID)
 		if err != nil {
-			return nil, fmt.Errorf("failed to delete resource: %v", err)
+			if err.IsUnavailable() {
+				return status.Errorf(codes.Unavailable, "failed to delete resource: %v", err)
+			}
+			return fmt.Errorf("failed to delete resource: %v", err)
 		}
 	default:
-		return nil, fmt.Errorf("unsupported action %s", eventType.Action)
+		return fmt.Errorf("unsupported action %s", action)
 	}
 
-	return &emptypb.Empty{}, nil
+	return nil
+}
+
+// updateStatus updates the status of the resource with the status event, only the status and the reconcile fields of
+// the resource are written, the manifest and the version of the resource are kept, see ResourceService.UpdateStatus.
+func (svr *GRPCServer) updateStatus(ctx context.Context, eventType *types.CloudEventsType, evt *ce.Event) error {
+	if eventType.Action != common.UpdateRequestAction {
+		return status.Errorf(codes.InvalidArgument, "unsupported action %s of the status event", eventType.Action)
+	}
+
+	res, err := decodeResourceStatus(eventType.CloudEventsDataType, evt)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to decode cloudevent: %v", err)
+	}
+
+	if err := svr.checkResourceSource(ctx, res.ID, evt.Source()); err != nil {
+		return err
+	}
+
+	if err := handleStatusUpdate(ctx, res, svr.resourceService, svr.statusEventService); err != nil {
+		return fmt.Errorf("failed to handle resource status update %s: %s", res.ID, err.Error())
+	}
+	return nil
+}
+
+// acceptAsyncCommit queues the resource to be committed in the background. The publish is rejected with
+// ResourceExhausted rather than blocked once the queue is full, so the source backs off instead of hanging.
+func (svr *GRPCServer) acceptAsyncCommit(c *asyncCommit) error {
+	select {
+	case svr.asyncCommits <- c:
+		return nil
+	default:
+		return status.Errorf(codes.ResourceExhausted, "the async commit queue is full, retry later")
+	}
+}
+
+// runAsyncCommits commits the resources accepted with the async commit mode in the order they are accepted.
+// The commit failures are reported by the grpc_server_async_commit_failed_total metric.
+func (svr *GRPCServer) runAsyncCommits() {
+	defer close(svr.asyncCommitsDone)
+	for c := range svr.asyncCommits {
+		if err := svr.commit(context.Background(), c.action, c.resource); err != nil {
+			klog.Errorf("failed to commit the resource %s asynchronously from source %s: %v", c.resource.ID, c.resource.Source, err)
+			grpcAsyncCommitFailedCountMetric.WithLabelValues(string(c.action), c.resource.Source).Inc()
+		}
+	}
+}
+
+// getCommitMode returns the commit mode of the event, the sync commit mode is used by default.
+func getCommitMode(evt *ce.Event) (string, error) {
+	value, ok := evt.Extensions()[constants.ExtensionCommitMode]
+	if !ok {
+		return constants.CommitModeSync, nil
+	}
+
+	commitMode, err := cetypes.ToString(value)
+	if err != nil {
+		return "", status.Errorf(codes.InvalidArgument, "invalid %s extension: %v", constants.ExtensionCommitMode, err)
+	}
+
+	switch commitMode {
+	case constants.CommitModeSync, constants.CommitModeAsync:
+		return commitMode, nil
+	default:
+		return "", status.Errorf(codes.InvalidArgument, "unsupported commit mode %s", commitMode)
+	}
 }
 
 // Subscribe implements the Subscribe method of the CloudEventServiceServer interface
 func (svr *GRPCServer) Subscribe(subReq *pbv1.SubscriptionRequest, subServer pbv1.CloudEventService_SubscribeServer) error {
+	if err := checkMigrated(subServer.Context(), false); err != nil {
+		return err
+	}
+
 	if !svr.disableAuthorizer {
 		// check if the client is authorized to subscribe the event from the source
 		ctx := subServer.Context()
 		user := ctx.Value(contextUserKey).(string)
 		groups := ctx.Value(contextGroupsKey).([]string)
+		if err := svr.checkSourcePrefix(user, subReq.Source); err != nil {
+			return err
+		}
 		allowed, err := svr.grpcAuthorizer.AccessReview(ctx, "sub", "source", subReq.Source, user, groups)
 		if err != nil {
 			return fmt.Errorf("failed to authorize the request: %v", err)
@@ -247,8 +537,39 @@ func (svr *GRPCServer) Subscribe(subReq *pbv1.SubscriptionRequest, subServer pbv
 		}
 	}
 
-	clientID, errChan := svr.eventBroadcaster.Register(subReq.Source, func(res *api.Resource) error {
-		evt, err := encodeResourceStatus(res)
+	resourceType, err := getResourceTypeFilter(subServer.Context())
+	if err != nil {
+		return err
+	}
+	conditionType, err := getConditionTransitionFilter(subServer.Context())
+	if err != nil {
+		return err
+	}
+	contentType, err := getDataContentType(subServer.Context())
+	if err != nil {
+		return err
+	}
+	maxDuration, err := getMaxSubscribeDuration(subServer.Context())
+	if err != nil {
+		return err
+	}
+
+	clusterName, err := subscribedClusterName(subServer.Context(), subReq.ClusterName)
+	if err != nil {
+		return err
+	}
+
+	clientID, errChan := svr.eventBroadcaster.Register(subReq.Source, clusterName, func(res *api.Resource) error {
+		if !matchResourceType(resourceType, res) {
+			// the subscriber doesn't care about this resource type, skip it
+			return nil
+		}
+		if !matchConditionTransition(conditionType, res) {
+			// the status update doesn't transition the subscribed condition, skip it
+			return nil
+		}
+
+		evt, err := EncodeResourceStatus(res, svr.sourceRewrites, contentType)
 		if err != nil {
 			return fmt.Errorf("failed to encode resource %s to cloudevent: %v", res.ID, err)
 		}
@@ -270,6 +591,14 @@ func (svr *GRPCServer) Subscribe(subReq *pbv1.SubscriptionRequest, subServer pbv
 		return nil
 	})
 
+	// the stream is not limited if there is no max duration, a nil channel is never ready
+	var expired <-chan time.Time
+	if maxDuration > 0 {
+		timer := time.NewTimer(maxDuration)
+		defer timer.Stop()
+		expired = timer.C
+	}
+
 	select {
 	case err := <-errChan:
 		klog.Infof("unregistering client %s due to error= %v", clientID, err)
@@ -278,11 +607,158 @@ func (svr *GRPCServer) Subscribe(subReq *pbv1.SubscriptionRequest, subServer pbv
 	case <-subServer.Context().Done():
 		svr.eventBroadcaster.Unregister(clientID)
 		return nil
+	case <-expired:
+		klog.Infof("unregistering client %s after the max subscribe duration %s", clientID, maxDuration)
+		svr.eventBroadcaster.Unregister(clientID)
+		return nil
+	}
+}
+
+// resourceAlreadyExistsError returns the AlreadyExists error of a resource creation that conflicts with the existing
+// resource, the ID and the source of the existing resource are carried by the ResourceInfo of the error details, so
+// the client can adopt the existing resource.
+func resourceAlreadyExistsError(existing *api.Resource) error {
+	msg := fmt.Sprintf("the resource already exists with id %s", existing.ID)
+	st, err := status.New(codes.AlreadyExists, msg).WithDetails(&errdetails.ResourceInfo{
+		ResourceType: "Resource",
+		ResourceName: existing.ID,
+		Owner:        existing.Source,
+		Description:  fmt.Sprintf("the resource %s of consumer %s", existing.Name, existing.ConsumerName),
+	})
+	if err != nil {
+		return status.Error(codes.AlreadyExists, msg)
+	}
+	return st.Err()
+}
+
+// subscribedClusterName returns the cluster name that the subscriber registers with the event broadcaster. A source
+// subscribes to the statuses of all its clusters with an empty or the wildcard cluster name (the "+" of the status
+// topic pattern), or to the statuses of one cluster with a concrete cluster name. A subscriber with a token scoped to a
+// consumer can only subscribe to the statuses of the consumer, its wildcard subscription is narrowed to the consumer.
+func subscribedClusterName(ctx context.Context, clusterName string) (string, error) {
+	if len(clusterName) == 0 || clusterName == event.AllClusters {
+		if consumer, ok := ctx.Value(contextConsumerKey).(string); ok {
+			return consumer, nil
+		}
+		return event.AllClusters, nil
+	}
+
+	if err := checkConsumer(ctx, clusterName); err != nil {
+		return "", err
+	}
+	return clusterName, nil
+}
+
+// GetResourceStatus returns the current status event of the resource, so a source can pull the status of a resource
+// over its gRPC connection rather than the REST API. The resource must belong to a source that the client is
+// authorized to subscribe, and to the consumer of the client if the client is scoped to a consumer.
+func (svr *GRPCServer) GetResourceStatus(ctx context.Context, req *wrapperspb.StringValue) (*pbv1.CloudEvent, error) {
+	if err := checkMigrated(ctx, false); err != nil {
+		return nil, err
+	}
+	return getResourceStatus(ctx, svr.resourceService, req.GetValue(), svr.sourceRewrites, func(res *api.Resource) error {
+		if err := checkConsumer(ctx, res.ConsumerName); err != nil {
+			return err
+		}
+		if svr.disableAuthorizer {
+			return nil
+		}
+
+		user := ctx.Value(contextUserKey).(string)
+		groups := ctx.Value(contextGroupsKey).([]string)
+		if err := svr.checkSourcePrefix(user, res.Source); err != nil {
+			return err
+		}
+		allowed, err := svr.grpcAuthorizer.AccessReview(ctx, "sub", "source", res.Source, user, groups)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to authorize the request: %v", err)
+		}
+		if !allowed {
+			return status.Errorf(codes.PermissionDenied, "unauthorized to get the status of the resource from source %s", res.Source)
+		}
+		return nil
+	})
+}
+
+// getResourceStatus returns the status event of the resource with the given ID once the resource is authorized by the
+// given authorize func. The status is encoded as it is broadcast to the sources, with the data content type that the
+// client advertises.
+func getResourceStatus(ctx context.Context, resourceService services.ResourceService, id string,
+	sourceRewrites map[string]string, authorize func(res *api.Resource) error) (*pbv1.CloudEvent, error) {
+	if len(id) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid get resource status request: missing resource id")
+	}
+	contentType, err := getDataContentType(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res, svcErr := resourceService.Get(ctx, id)
+	if svcErr != nil {
+		if svcErr.Is404() {
+			return nil, status.Errorf(codes.NotFound, "resource %s is not found", id)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get resource %s: %s", id, svcErr)
 	}
+	if err := authorize(res); err != nil {
+		return nil, err
+	}
+	if len(res.Status) == 0 {
+		return nil, status.Errorf(codes.NotFound, "resource %s has no status yet", id)
+	}
+
+	evt, err := EncodeResourceStatus(res, sourceRewrites, contentType)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to encode resource %s to cloudevent: %v", id, err)
+	}
+
+	// WARNING: don't use "pbEvt, err := pb.ToProto(evt)" to convert cloudevent to protobuf
+	pbEvt := &pbv1.CloudEvent{}
+	if err := grpcprotocol.WritePBMessage(ctx, binding.ToMessage(evt), pbEvt); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to convert cloudevent to protobuf for resource(%s): %v", id, err)
+	}
+	return pbEvt, nil
+}
+
+// checkResourceSource ensures a source only updates or deletes its own resources, so a source cannot tamper with
+// the resources of another source even if it knows their IDs. The check is skipped if the resource doesn't exist,
+// the update or delete fails with the not found error in that case.
+func (svr *GRPCServer) checkResourceSource(ctx context.Context, id, source string) error {
+	found, serviceErr := svr.resourceService.Get(ctx, id)
+	if serviceErr != nil {
+		if serviceErr.Is404() {
+			return nil
+		}
+		return fmt.Errorf("failed to get resource: %s", serviceErr)
+	}
+	if found.Source != source {
+		return status.Errorf(codes.PermissionDenied, "the resource %s doesn't belong to the source %s", id, source)
+	}
+	return nil
+}
+
+// checkSourcePrefix ensures the user only operates on the sources under its allowed source prefix.
+// The check is skipped if no allowed source prefixes are configured.
+func (svr *GRPCServer) checkSourcePrefix(user, source string) error {
+	if len(svr.allowedSourcePrefixes) == 0 {
+		return nil
+	}
+
+	prefix, ok := svr.allowedSourcePrefixes[user]
+	if !ok {
+		return status.Errorf(codes.PermissionDenied, "user %s is not allowed to operate on source %s", user, source)
+	}
+
+	if !strings.HasPrefix(source, prefix) {
+		return status.Errorf(codes.PermissionDenied, "source %s is out of the allowed source prefix %s of user %s", source, prefix, user)
+	}
+
+	return nil
 }
 
-// decodeResourceSpec translates a CloudEvent into a resource containing the spec JSON map.
-func decodeResourceSpec(eventDataType types.CloudEventsDataType, evt *ce.Event) (*api.Resource, error) {
+// decodeResourceSpec translates a CloudEvent into a resource containing the spec JSON map, the given passthrough
+// extensions of the CloudEvent are kept as the resource metadata.
+func decodeResourceSpec(eventDataType types.CloudEventsDataType, evt *ce.Event, passthroughExtensions []string) (*api.Resource, error) {
 	evtExtensions := evt.Context.GetExtensions()
 
 	clusterName, err := cetypes.ToString(evtExtensions[types.ExtensionClusterName])
@@ -295,7 +771,7 @@ func decodeResourceSpec(eventDataType types.CloudEventsDataType, evt *ce.Event)
 		return nil, fmt.Errorf("failed to get resourceid extension: %v", err)
 	}
 
-	resourceVersion, err := cetypes.ToInteger(evtExtensions[types.ExtensionResourceVersion])
+	resourceVersion, err := api.ToResourceVersion(evtExtensions[types.ExtensionResourceVersion])
 	if err != nil {
 		return nil, fmt.Errorf("failed to get resourceversion extension: %v", err)
 	}
@@ -317,12 +793,26 @@ func decodeResourceSpec(eventDataType types.CloudEventsDataType, evt *ce.Event)
 		resource.Meta.DeletedAt.Time = deletionTimestamp
 	}
 
+	if priorityValue, exists := evtExtensions[api.ExtensionPriority]; exists {
+		priority, err := cetypes.ToInteger(priorityValue)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get priority extension: %v", err)
+		}
+		resource.Priority = api.ClampResourcePriority(priority)
+	}
+
 	payload, err := api.CloudEventToJSONMap(evt)
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert cloudevent to resource payload: %v", err)
 	}
 	resource.Payload = payload
 
+	metadata, err := api.GetMetadataExtensions(evt, passthroughExtensions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get resource metadata: %v", err)
+	}
+	resource.Metadata = metadata
+
 	switch eventDataType {
 	case workpayload.ManifestEventDataType:
 		resource.Type = api.ResourceTypeSingle
@@ -335,14 +825,41 @@ func decodeResourceSpec(eventDataType types.CloudEventsDataType, evt *ce.Event)
 	return resource, nil
 }
 
-// encodeResourceStatus translates a resource status JSON map into a CloudEvent.
-func encodeResourceStatus(resource *api.Resource) (*ce.Event, error) {
+// EncodeResourceStatus translates a resource status JSON map into a CloudEvent. If the resource source has a
+// rewrite, the rewritten source is set as the original source of the event. The data is encoded with the given data
+// content type, JSON is used if it is empty.
+func EncodeResourceStatus(resource *api.Resource, sourceRewrites map[string]string, contentType string) (*ce.Event, error) {
+	evt, err := encodeResourceStatus(resource, sourceRewrites)
+	if err != nil {
+		return nil, err
+	}
+	if err := api.EncodeCloudEventData(evt, contentType); err != nil {
+		return nil, fmt.Errorf("failed to encode resource status: %v", err)
+	}
+	return evt, nil
+}
+
+func encodeResourceStatus(resource *api.Resource, sourceRewrites map[string]string) (*ce.Event, error) {
 	if resource.Type == api.ResourceTypeSingle {
 		// single resource, return the status directly
-		return api.JSONMAPToCloudEvent(resource.Status)
+		evt, err := api.JSONMAPToCloudEvent(resource.Status)
+		if err != nil {
+			return nil, err
+		}
+
+		// set the resource metadata back to the status event
+		if err := api.SetMetadataExtensions(evt, resource.Metadata); err != nil {
+			return nil, err
+		}
+
+		// the status continues the trace of the spec if the agent doesn't report a trace context of its own
+		api.InheritTraceContext(evt, resource.Payload)
+
+		rewriteOriginalSource(evt, resource.Source, sourceRewrites)
+		return evt, nil
 	}
 
-	statusEvt, err := api.JSONMAPToCloudEvent(resource.Status)
+	statusEvt, err := bundleStatusEvent(resource)
 	if err != nil {
 		return nil, err
 	}
@@ -362,6 +879,11 @@ func encodeResourceStatus(resource *api.Resource) (*ce.Event, error) {
 		evt.SetExtension(codec.ExtensionWorkMeta, workMeta)
 	}
 
+	// set the resource metadata back to the status event
+	if err := api.SetMetadataExtensions(&evt, resource.Metadata); err != nil {
+		return nil, err
+	}
+
 	// manifest bundle status from the resource status
 	manifestBundleStatus := &workpayload.ManifestBundleStatus{}
 	if err := statusEvt.DataAs(manifestBundleStatus); err != nil {
@@ -386,22 +908,74 @@ func encodeResourceStatus(resource *api.Resource) (*ce.Event, error) {
 		return nil, err
 	}
 
+	// the status continues the trace of the spec if the agent doesn't report a trace context of its own
+	api.InheritTraceContext(&evt, resource.Payload)
+
+	rewriteOriginalSource(&evt, resource.Source, sourceRewrites)
 	return &evt, nil
 }
 
+// bundleStatusEvent returns the status event of the resource bundle. The agent may not report the status of the bundle
+// yet (e.g. the bundle is just created), then an empty status event is returned rather than failing the encoding, so
+// the bundle is still delivered to the subscribers. The empty status has no sequence ID, so it never overrides a
+// reported status.
+func bundleStatusEvent(resource *api.Resource) (*ce.Event, error) {
+	if len(resource.Status) != 0 {
+		return api.JSONMAPToCloudEvent(resource.Status)
+	}
+
+	evt := types.NewEventBuilder(resource.ConsumerName, types.CloudEventsType{
+		CloudEventsDataType: workpayload.ManifestBundleEventDataType,
+		SubResource:         types.SubResourceStatus,
+		Action:              common.UpdateRequestAction,
+	}).WithResourceID(resource.ID).
+		WithResourceVersion(resource.Version).
+		WithClusterName(resource.ConsumerName).
+		WithOriginalSource(resource.Source).
+		NewEvent()
+	evt.SetExtension(types.ExtensionStatusUpdateSequenceID, "")
+	return &evt, nil
+}
+
+// rewriteOriginalSource sets the original source of the event to the source identity that the subscribers expect
+// if the source has a rewrite, the event is unchanged otherwise.
+func rewriteOriginalSource(evt *ce.Event, source string, sourceRewrites map[string]string) {
+	if rewritten, ok := sourceRewrites[source]; ok {
+		evt.SetExtension(types.ExtensionOriginalSource, rewritten)
+	}
+}
+
 // respondResyncStatusRequest responds to the status resync request by comparing the status hash of the resources
 // from the database and the status hash in the request, and then respond the resources whose status is changed.
+// A request without the status hashes for all the resources of the source is responded in the background by the
+// status resender, see statusResender.
 func (svr *GRPCServer) respondResyncStatusRequest(ctx context.Context, eventDataType types.CloudEventsDataType, evt *ce.Event) error {
-	objs, serviceErr := svr.resourceService.FindBySource(ctx, evt.Source())
-	if serviceErr != nil {
-		return fmt.Errorf("failed to list resources: %s", serviceErr)
-	}
-
 	statusHashes, err := payload.DecodeStatusResyncRequest(*evt)
 	if err != nil {
 		return fmt.Errorf("failed to decode status resync request: %v", err)
 	}
 
+	ids, err := getResyncResourceIDs(evt)
+	if err != nil {
+		return err
+	}
+	if len(statusHashes.Hashes) == 0 && len(ids) == 0 {
+		source := evt.Source()
+		svr.statusResender.Resend(source, func(ctx context.Context) (api.ResourceList, error) {
+			objs, serviceErr := svr.resourceService.FindBySource(ctx, source)
+			if serviceErr != nil {
+				return nil, fmt.Errorf("failed to list resources: %s", serviceErr)
+			}
+			return objs, nil
+		})
+		return nil
+	}
+
+	objs, err := svr.findResyncResources(ctx, evt)
+	if err != nil {
+		return err
+	}
+
 	if len(statusHashes.Hashes) == 0 {
 		// publish all resources status
 		for _, obj := range objs {
@@ -444,6 +1018,68 @@ func (svr *GRPCServer) respondResyncStatusRequest(ctx context.Context, eventData
 	return nil
 }
 
+// findResyncResources returns the resources of the status resync request, they are the resources of the source, or
+// the given resources if the request is scoped by the resourceids extension. The scoped resources must belong to the
+// source, and to the consumer of the client if the client is scoped to a consumer, the resources that are not found
+// are ignored.
+func (svr *GRPCServer) findResyncResources(ctx context.Context, evt *ce.Event) (api.ResourceList, error) {
+	ids, err := getResyncResourceIDs(evt)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ids) == 0 {
+		objs, serviceErr := svr.resourceService.FindBySource(ctx, evt.Source())
+		if serviceErr != nil {
+			return nil, fmt.Errorf("failed to list resources: %s", serviceErr)
+		}
+		return objs, nil
+	}
+
+	objs, serviceErr := svr.resourceService.FindByIDs(ctx, ids)
+	if serviceErr != nil {
+		return nil, fmt.Errorf("failed to list resources: %s", serviceErr)
+	}
+
+	for _, obj := range objs {
+		if obj.Source != evt.Source() {
+			return nil, status.Errorf(codes.PermissionDenied, "the resource %s doesn't belong to the source %s", obj.ID, evt.Source())
+		}
+		if err := checkConsumer(ctx, obj.ConsumerName); err != nil {
+			return nil, err
+		}
+	}
+
+	return objs, nil
+}
+
+// getResyncResourceIDs returns the resource IDs of the resourceids extension, nil is returned if the status resync
+// request is not scoped. A scoped request must have at least one resource ID, so it is never widened to all the
+// resources of the source.
+func getResyncResourceIDs(evt *ce.Event) ([]string, error) {
+	value, ok := evt.Extensions()[constants.ExtensionResourceIDs]
+	if !ok {
+		return nil, nil
+	}
+
+	resourceIDs, err := cetypes.ToString(value)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid %s extension: %v", constants.ExtensionResourceIDs, err)
+	}
+
+	ids := []string{}
+	for _, id := range strings.Split(resourceIDs, ",") {
+		if id = strings.TrimSpace(id); len(id) != 0 {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid %s extension: no resource id", constants.ExtensionResourceIDs)
+	}
+
+	return ids, nil
+}
+
 // findStatusHash finds the status hash of the resource from the status resync request payload
 func findStatusHash(id string, hashes []payload.ResourceStatusHash) (string, bool) {
 	for _, hash := range hashes {
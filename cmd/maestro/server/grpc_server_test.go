@@ -0,0 +1,645 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	ce "github.com/cloudevents/sdk-go/v2"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gorm.io/datatypes"
+	pbv1 "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/grpc/protobuf/v1"
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/types"
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/work/common"
+	workpayload "open-cluster-management.io/sdk-go/pkg/cloudevents/work/payload"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/constants"
+	"github.com/openshift-online/maestro/pkg/errors"
+	"github.com/openshift-online/maestro/pkg/services"
+)
+
+func TestEncodeResourceStatusWithoutBundleStatus(t *testing.T) {
+	payload := datatypes.JSONMap{}
+	if err := json.Unmarshal([]byte("{\"specversion\":\"1.0\",\"id\":\"0\",\"source\":\"maestro\",\"type\":\"io.open-cluster-management.works.v1alpha1.manifestbundles.spec.create_request\",\"datacontenttype\":\"application/json\",\"data\":{\"manifests\":[{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"nginx\",\"namespace\":\"default\"}}]}}"), &payload); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resource := &api.Resource{
+		Meta:         api.Meta{ID: "0a2f2e5a-1f5f-4b43-9c2c-6d7e8e3c5e51"},
+		Version:      1,
+		Source:       "maestro",
+		ConsumerName: "cluster1",
+		Type:         api.ResourceTypeBundle,
+		Payload:      payload,
+	}
+
+	evt, err := encodeResourceStatus(resource, nil)
+	if err != nil {
+		t.Fatalf("expected the bundle without status is encoded, but got %v", err)
+	}
+
+	eventType, err := types.ParseCloudEventsType(evt.Type())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if eventType.CloudEventsDataType != workpayload.ManifestBundleEventDataType || eventType.SubResource != types.SubResourceStatus {
+		t.Errorf("unexpected event type %s", evt.Type())
+	}
+	if resourceID := evt.Extensions()[types.ExtensionResourceID]; resourceID != resource.ID {
+		t.Errorf("expected the resource id %s, but got %v", resource.ID, resourceID)
+	}
+
+	status := &workpayload.ManifestBundleStatus{}
+	if err := evt.DataAs(status); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(status.ResourceStatus) != 0 {
+		t.Errorf("expected an empty resource status, but got %v", status.ResourceStatus)
+	}
+	if status.ManifestBundle == nil || len(status.ManifestBundle.Manifests) != 1 {
+		t.Errorf("expected the manifest bundle is set back to the status, but got %v", status.ManifestBundle)
+	}
+}
+
+func TestGetMaxSubscribeDuration(t *testing.T) {
+	cases := []struct {
+		name        string
+		value       string
+		expected    time.Duration
+		expectedErr bool
+	}{
+		{name: "no limit"},
+		{name: "duration", value: "10m", expected: 10 * time.Minute},
+		{name: "invalid duration", value: "ten minutes", expectedErr: true},
+		{name: "negative duration", value: "-1s", expectedErr: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ctx := context.Background()
+			if c.value != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(maxSubscribeDurationKey, c.value))
+			}
+			duration, err := getMaxSubscribeDuration(ctx)
+			if c.expectedErr != (err != nil) {
+				t.Fatalf("expected error %v, but got %v", c.expectedErr, err)
+			}
+			if duration != c.expected {
+				t.Errorf("expected %s, but got %s", c.expected, duration)
+			}
+		})
+	}
+}
+
+func TestResourceAlreadyExistsError(t *testing.T) {
+	existing := &api.Resource{Meta: api.Meta{ID: "b9368296-3200-42ec-bfbb-f7d44a06c4e0"}, Source: "source1", ConsumerName: "cluster1"}
+
+	st := status.Convert(resourceAlreadyExistsError(existing))
+	if st.Code() != codes.AlreadyExists {
+		t.Fatalf("expected AlreadyExists, but got %s", st.Code())
+	}
+	details := st.Details()
+	if len(details) != 1 {
+		t.Fatalf("expected one error detail, but got %v", details)
+	}
+	info, ok := details[0].(*errdetails.ResourceInfo)
+	if !ok {
+		t.Fatalf("expected the resource info, but got %T", details[0])
+	}
+	if info.ResourceName != existing.ID || info.Owner != existing.Source {
+		t.Errorf("expected the resource %s of %s, but got %v", existing.ID, existing.Source, info)
+	}
+}
+
+func TestPublishAction(t *testing.T) {
+	cases := []struct {
+		eventType string
+		expected  string
+	}{
+		{eventType: "io.open-cluster-management.works.v1alpha1.manifestbundles.spec.create_request", expected: "create"},
+		{eventType: "io.open-cluster-management.works.v1alpha1.manifestbundles.spec.update_request", expected: "update"},
+		{eventType: "io.open-cluster-management.works.v1alpha1.manifestbundles.spec.delete_request", expected: "delete"},
+		{eventType: "io.open-cluster-management.works.v1alpha1.manifestbundles.status.resync_request", expected: "resync"},
+		{eventType: "invalid", expected: "unknown"},
+	}
+
+	for _, c := range cases {
+		action := publishAction(&pbv1.PublishRequest{Event: &pbv1.CloudEvent{Type: c.eventType}})
+		if action != c.expected {
+			t.Errorf("expected the action %s of %s, but got %s", c.expected, c.eventType, action)
+		}
+	}
+}
+
+func TestStartPublishSpan(t *testing.T) {
+	traceParent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	eventType := &types.CloudEventsType{
+		CloudEventsDataType: workpayload.ManifestBundleEventDataType,
+		SubResource:         types.SubResourceSpec,
+		Action:              "create_request",
+	}
+
+	// the span is the child of the trace context of the event, and is set back to the event
+	evt := ce.NewEvent()
+	evt.SetExtension(api.ExtensionTraceParent, traceParent)
+	ctx, span := startPublishSpan(context.Background(), eventType, &evt)
+	defer span.End()
+	if traceID := trace.SpanContextFromContext(ctx).TraceID().String(); traceID != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("expected the span is the child of the trace context of the event, but got the trace %s", traceID)
+	}
+	if _, ok := evt.Extensions()[api.ExtensionTraceParent]; !ok {
+		t.Errorf("expected the trace context is set back to the event")
+	}
+
+	// the event without the trace context is handled as it is
+	evt = ce.NewEvent()
+	_, span = startPublishSpan(context.Background(), eventType, &evt)
+	defer span.End()
+	if len(evt.Extensions()) != 0 {
+		t.Errorf("expected no trace context without a tracer provider, but got %v", evt.Extensions())
+	}
+}
+
+// fakeCommitResourceService records the committed resources in order, the commit of a resource fails with its
+// failure if it has one.
+type fakeCommitResourceService struct {
+	services.ResourceService
+
+	mu        sync.Mutex
+	committed []string
+	failures  map[string]*errors.ServiceError
+}
+
+func (s *fakeCommitResourceService) record(action, id string) *errors.ServiceError {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.committed = append(s.committed, action+" "+id)
+	return s.failures[id]
+}
+
+func (s *fakeCommitResourceService) Create(ctx context.Context, resource *api.Resource) (*api.Resource, *errors.ServiceError) {
+	if err := s.record("create", resource.ID); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+func (s *fakeCommitResourceService) Update(ctx context.Context, resource *api.Resource) (*api.Resource, *errors.ServiceError) {
+	if err := s.record("update", resource.ID); err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+func (s *fakeCommitResourceService) MarkAsDeleting(ctx context.Context, id string) *errors.ServiceError {
+	return s.record("delete", id)
+}
+
+func TestRunAsyncCommits(t *testing.T) {
+	ResetGRPCMetrics()
+	defer ResetGRPCMetrics()
+
+	resourceService := &fakeCommitResourceService{
+		failures: map[string]*errors.ServiceError{"r2": errors.Validation("invalid manifest")},
+	}
+	svr := &GRPCServer{
+		resourceService:    resourceService,
+		enableAsyncPublish: true,
+		asyncCommits:       make(chan *asyncCommit, asyncCommitQueueSize),
+		asyncCommitsDone:   make(chan struct{}),
+	}
+	go svr.runAsyncCommits()
+
+	commits := []*asyncCommit{
+		{action: common.CreateRequestAction, resource: &api.Resource{Meta: api.Meta{ID: "r1"}, Source: "source1"}},
+		{action: common.UpdateRequestAction, resource: &api.Resource{Meta: api.Meta{ID: "r2"}, Source: "source1", Type: api.ResourceTypeSingle}},
+		{action: common.DeleteRequestAction, resource: &api.Resource{Meta: api.Meta{ID: "r3"}, Source: "source1"}},
+		{action: common.CreateRequestAction, resource: &api.Resource{Meta: api.Meta{ID: "r4"}, Source: "source2"}},
+	}
+	for _, c := range commits {
+		svr.asyncCommits <- c
+	}
+	// the remaining accepted resources are committed before the commits are done
+	close(svr.asyncCommits)
+	select {
+	case <-svr.asyncCommitsDone:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected the async commits are done")
+	}
+
+	// the resources are committed in the order they are accepted, a failed commit doesn't block the next ones
+	expected := []string{"create r1", "update r2", "delete r3", "create r4"}
+	if len(resourceService.committed) != len(expected) {
+		t.Fatalf("expected the commits %v, but got %v", expected, resourceService.committed)
+	}
+	for i := range expected {
+		if resourceService.committed[i] != expected[i] {
+			t.Errorf("expected the commit %d is %q, but got %q", i, expected[i], resourceService.committed[i])
+		}
+	}
+
+	// the commit failures are reported by the metric
+	if count := testutil.CollectAndCount(grpcAsyncCommitFailedCountMetric); count != 1 {
+		t.Errorf("expected one failed commit series, but got %d", count)
+	}
+	if count := testutil.ToFloat64(grpcAsyncCommitFailedCountMetric.WithLabelValues(string(common.UpdateRequestAction), "source1")); count != 1 {
+		t.Errorf("expected one failed update from source1, but got %v", count)
+	}
+}
+
+func TestAcceptAsyncCommitQueueFull(t *testing.T) {
+	svr := &GRPCServer{
+		enableAsyncPublish: true,
+		asyncCommits:       make(chan *asyncCommit, 1),
+		asyncCommitsDone:   make(chan struct{}),
+	}
+
+	if err := svr.acceptAsyncCommit(&asyncCommit{action: common.CreateRequestAction, resource: &api.Resource{Meta: api.Meta{ID: "r1"}}}); err != nil {
+		t.Fatalf("expected the resource is accepted, but got %v", err)
+	}
+	// the full queue rejects the resource rather than blocking the publish
+	err := svr.acceptAsyncCommit(&asyncCommit{action: common.CreateRequestAction, resource: &api.Resource{Meta: api.Meta{ID: "r2"}}})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("expected the resource is rejected with %s, but got %v", codes.ResourceExhausted, err)
+	}
+}
+
+func TestStopTwice(t *testing.T) {
+	svr := &GRPCServer{
+		grpcServer:         grpc.NewServer(),
+		enableAsyncPublish: true,
+		asyncCommits:       make(chan *asyncCommit, asyncCommitQueueSize),
+		asyncCommitsDone:   make(chan struct{}),
+		statusResender:     newStatusResender(nil, 1, 0),
+	}
+	go svr.runAsyncCommits()
+
+	svr.Stop()
+	// the second stop doesn't close the async commits again
+	svr.Stop()
+}
+
+func TestCommitErrors(t *testing.T) {
+	resourceService := &fakeCommitResourceService{
+		failures: map[string]*errors.ServiceError{
+			"invalid":     errors.Validation("invalid manifest"),
+			"unavailable": errors.Unavailable("circuit breaker is open"),
+			"failed":      errors.GeneralError("connection reset"),
+		},
+	}
+	svr := &GRPCServer{resourceService: resourceService}
+
+	cases := []struct {
+		name         string
+		action       types.EventAction
+		resourceID   string
+		expectedCode codes.Code
+	}{
+		{
+			name:         "committed",
+			action:       common.CreateRequestAction,
+			resourceID:   "r1",
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "invalid",
+			action:       common.CreateRequestAction,
+			resourceID:   "invalid",
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name:         "unavailable",
+			action:       common.DeleteRequestAction,
+			resourceID:   "unavailable",
+			expectedCode: codes.Unavailable,
+		},
+		{
+			name:         "failed",
+			action:       common.UpdateRequestAction,
+			resourceID:   "failed",
+			expectedCode: codes.Unknown,
+		},
+		{
+			name:         "unsupported action",
+			action:       types.ResyncRequestAction,
+			resourceID:   "r1",
+			expectedCode: codes.Unknown,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := svr.commit(context.Background(), c.action, &api.Resource{Meta: api.Meta{ID: c.resourceID}, Type: api.ResourceTypeSingle})
+			if code := status.Code(err); code != c.expectedCode {
+				t.Errorf("expected the code %s, but got %s: %v", c.expectedCode, code, err)
+			}
+		})
+	}
+}
+
+func TestGetCommitMode(t *testing.T) {
+	cases := []struct {
+		name         string
+		commitMode   interface{}
+		expectedMode string
+		expectedCode codes.Code
+	}{
+		{
+			name:         "default",
+			expectedMode: constants.CommitModeSync,
+		},
+		{
+			name:         "sync",
+			commitMode:   constants.CommitModeSync,
+			expectedMode: constants.CommitModeSync,
+		},
+		{
+			name:         "async",
+			commitMode:   constants.CommitModeAsync,
+			expectedMode: constants.CommitModeAsync,
+		},
+		{
+			name:         "unsupported",
+			commitMode:   "fsync",
+			expectedCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			evt := ce.NewEvent()
+			if c.commitMode != nil {
+				evt.SetExtension(constants.ExtensionCommitMode, c.commitMode)
+			}
+			mode, err := getCommitMode(&evt)
+			if code := status.Code(err); code != c.expectedCode {
+				t.Errorf("expected the code %s, but got %s: %v", c.expectedCode, code, err)
+			}
+			if mode != c.expectedMode {
+				t.Errorf("expected the commit mode %q, but got %q", c.expectedMode, mode)
+			}
+		})
+	}
+}
+
+// fakeResyncResourceService finds the resources of the status resync requests from the given resources.
+type fakeResyncResourceService struct {
+	services.ResourceService
+
+	resources api.ResourceList
+}
+
+func (s *fakeResyncResourceService) FindByIDs(ctx context.Context, ids []string) (api.ResourceList, *errors.ServiceError) {
+	objs := api.ResourceList{}
+	for _, res := range s.resources {
+		for _, id := range ids {
+			if res.ID == id {
+				objs = append(objs, res)
+			}
+		}
+	}
+	return objs, nil
+}
+
+func (s *fakeResyncResourceService) FindBySource(ctx context.Context, source string) (api.ResourceList, *errors.ServiceError) {
+	objs := api.ResourceList{}
+	for _, res := range s.resources {
+		if res.Source == source {
+			objs = append(objs, res)
+		}
+	}
+	return objs, nil
+}
+
+func TestFindResyncResources(t *testing.T) {
+	svr := &GRPCServer{resourceService: &fakeResyncResourceService{
+		resources: api.ResourceList{
+			{Meta: api.Meta{ID: "r1"}, Source: "source1", ConsumerName: "cluster1"},
+			{Meta: api.Meta{ID: "r2"}, Source: "source1", ConsumerName: "cluster2"},
+			{Meta: api.Meta{ID: "r3"}, Source: "source2", ConsumerName: "cluster1"},
+		},
+	}}
+
+	cases := []struct {
+		name         string
+		consumer     string
+		resourceIDs  interface{}
+		expectedIDs  []string
+		expectedCode codes.Code
+	}{
+		{
+			name:        "not scoped",
+			expectedIDs: []string{"r1", "r2"},
+		},
+		{
+			name:        "scoped",
+			resourceIDs: "r2, r1",
+			expectedIDs: []string{"r1", "r2"},
+		},
+		{
+			name:        "unknown ids are ignored",
+			resourceIDs: "r1,unknown",
+			expectedIDs: []string{"r1"},
+		},
+		{
+			name:         "foreign source",
+			resourceIDs:  "r1,r3",
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:        "consumer token",
+			consumer:    "cluster1",
+			resourceIDs: "r1",
+			expectedIDs: []string{"r1"},
+		},
+		{
+			name:         "foreign consumer",
+			consumer:     "cluster1",
+			resourceIDs:  "r1,r2",
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:         "empty ids",
+			resourceIDs:  "",
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name:         "blank ids",
+			resourceIDs:  " , ",
+			expectedCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ctx := context.Background()
+			if c.consumer != "" {
+				ctx = context.WithValue(ctx, contextConsumerKey, c.consumer)
+			}
+			evt := ce.NewEvent()
+			evt.SetSource("source1")
+			if c.resourceIDs != nil {
+				evt.SetExtension(constants.ExtensionResourceIDs, c.resourceIDs)
+			}
+
+			objs, err := svr.findResyncResources(ctx, &evt)
+			if code := status.Code(err); code != c.expectedCode {
+				t.Fatalf("expected the code %s, but got %s: %v", c.expectedCode, code, err)
+			}
+			if len(objs) != len(c.expectedIDs) {
+				t.Fatalf("expected the resources %v, but got %d resources", c.expectedIDs, len(objs))
+			}
+			for i, obj := range objs {
+				if obj.ID != c.expectedIDs[i] {
+					t.Errorf("expected the resource %d is %s, but got %s", i, c.expectedIDs[i], obj.ID)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckSourcePrefix(t *testing.T) {
+	cases := []struct {
+		name                  string
+		allowedSourcePrefixes map[string]string
+		user                  string
+		source                string
+		expectedCode          codes.Code
+	}{
+		{
+			name:         "no isolation",
+			user:         "user-a",
+			source:       "team-b-source",
+			expectedCode: codes.OK,
+		},
+		{
+			name:                  "allowed source",
+			allowedSourcePrefixes: map[string]string{"user-a": "team-a-", "user-b": "team-b-"},
+			user:                  "user-a",
+			source:                "team-a-source",
+			expectedCode:          codes.OK,
+		},
+		{
+			name:                  "source of another team",
+			allowedSourcePrefixes: map[string]string{"user-a": "team-a-", "user-b": "team-b-"},
+			user:                  "user-a",
+			source:                "team-b-source",
+			expectedCode:          codes.PermissionDenied,
+		},
+		{
+			name:                  "prefix in the middle of the source",
+			allowedSourcePrefixes: map[string]string{"user-a": "team-a-"},
+			user:                  "user-a",
+			source:                "x-team-a-source",
+			expectedCode:          codes.PermissionDenied,
+		},
+		{
+			name:                  "user without a prefix",
+			allowedSourcePrefixes: map[string]string{"user-a": "team-a-"},
+			user:                  "user-c",
+			source:                "team-a-source",
+			expectedCode:          codes.PermissionDenied,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			svr := &GRPCServer{allowedSourcePrefixes: c.allowedSourcePrefixes}
+			if code := status.Code(svr.checkSourcePrefix(c.user, c.source)); code != c.expectedCode {
+				t.Errorf("expected the code %s, but got %s", c.expectedCode, code)
+			}
+		})
+	}
+}
+
+// fakeVersionResourceService keeps the latest version of a resource bundle and records its last update.
+type fakeVersionResourceService struct {
+	services.ResourceService
+
+	found   *api.Resource
+	updated *api.Resource
+}
+
+func (s *fakeVersionResourceService) Get(ctx context.Context, id string) (*api.Resource, *errors.ServiceError) {
+	return s.found, nil
+}
+
+func (s *fakeVersionResourceService) Update(ctx context.Context, resource *api.Resource) (*api.Resource, *errors.ServiceError) {
+	s.updated = resource
+	return resource, nil
+}
+
+func TestCommitVersionlessBundleUpdate(t *testing.T) {
+	cases := []struct {
+		name                string
+		versionRollbackMode string
+		version             int64
+		expectedCode        codes.Code
+		expectedVersion     int64
+		expectedRollbacks   float64
+	}{
+		{
+			name:                "lenient mode",
+			versionRollbackMode: versionRollbackModeLenient,
+			expectedVersion:     3,
+			expectedRollbacks:   1,
+		},
+		{
+			name:                "strict mode",
+			versionRollbackMode: versionRollbackModeStrict,
+			expectedCode:        codes.Aborted,
+		},
+		{
+			name:                "update with a version",
+			versionRollbackMode: versionRollbackModeStrict,
+			version:             2,
+			expectedVersion:     2,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ResetGRPCMetrics()
+			defer ResetGRPCMetrics()
+
+			resourceService := &fakeVersionResourceService{
+				found: &api.Resource{Meta: api.Meta{ID: "r1"}, Source: "source1", Type: api.ResourceTypeBundle, Version: 3},
+			}
+			svr := &GRPCServer{resourceService: resourceService, versionRollbackMode: c.versionRollbackMode}
+
+			err := svr.commit(context.Background(), common.UpdateRequestAction,
+				&api.Resource{Meta: api.Meta{ID: "r1"}, Source: "source1", Type: api.ResourceTypeBundle, Version: c.version})
+			if code := status.Code(err); code != c.expectedCode {
+				t.Fatalf("expected the code %s, but got %s: %v", c.expectedCode, code, err)
+			}
+			if c.expectedCode == codes.OK && resourceService.updated.Version != c.expectedVersion {
+				t.Errorf("expected the update with the version %d, but got %d", c.expectedVersion, resourceService.updated.Version)
+			}
+			if c.expectedCode != codes.OK && resourceService.updated != nil {
+				t.Errorf("expected the rejected update is not committed")
+			}
+			if count := testutil.ToFloat64(versionRollbackCountMetric.WithLabelValues("source1")); count != c.expectedRollbacks {
+				t.Errorf("expected %v version rollbacks, but got %v", c.expectedRollbacks, count)
+			}
+		})
+	}
+}
+
+func TestValidateVersionRollbackMode(t *testing.T) {
+	for _, mode := range []string{versionRollbackModeLenient, versionRollbackModeStrict} {
+		if err := validateVersionRollbackMode(mode); err != nil {
+			t.Errorf("expected the mode %s is valid, but got %v", mode, err)
+		}
+	}
+	if err := validateVersionRollbackMode("reject"); err == nil {
+		t.Errorf("expected the unsupported mode is invalid")
+	}
+}
@@ -23,6 +23,7 @@ type HealthCheckServer struct {
 	instanceID        string
 	heartbeatInterval int
 	brokerType        string
+	address           string
 }
 
 func NewHealthCheckServer() *HealthCheckServer {
@@ -40,6 +41,7 @@ func NewHealthCheckServer() *HealthCheckServer {
 		instanceID:        env().Config.MessageBroker.ClientID,
 		heartbeatInterval: env().Config.HealthCheck.HeartbeartInterval,
 		brokerType:        env().Config.MessageBroker.MessageBrokerType,
+		address:           env().Config.GRPCServer.BrokerAdvertiseAddress,
 	}
 
 	router.HandleFunc("/healthcheck", server.healthCheckHandler).Methods(http.MethodGet)
@@ -103,6 +105,7 @@ func (s *HealthCheckServer) pulse(ctx context.Context) {
 					ID: s.instanceID,
 				},
 				LastHeartbeat: time.Now(),
+				Address:       s.address,
 			}
 			_, err := s.instanceDao.Create(ctx, instance)
 			if err != nil {
@@ -114,6 +117,8 @@ func (s *HealthCheckServer) pulse(ctx context.Context) {
 		return
 	}
 	found.LastHeartbeat = time.Now()
+	// the address may be changed after the instance is restarted
+	found.Address = s.address
 	_, err = s.instanceDao.Replace(ctx, found)
 	if err != nil {
 		klog.Errorf("Unable to update heartbeat for maestro instance: %s", err.Error())
@@ -169,7 +174,8 @@ func (s *HealthCheckServer) checkInstances(ctx context.Context) {
 	}
 }
 
-// healthCheckHandler returns a 200 OK if the instance is ready, 503 Service Unavailable otherwise.
+// healthCheckHandler returns a 200 OK if the instance is ready and the database migrations are complete, 503 Service
+// Unavailable otherwise.
 func (s *HealthCheckServer) healthCheckHandler(w http.ResponseWriter, r *http.Request) {
 	instance, err := s.instanceDao.Get(r.Context(), s.instanceID)
 	if err != nil {
@@ -181,7 +187,7 @@ func (s *HealthCheckServer) healthCheckHandler(w http.ResponseWriter, r *http.Re
 		}
 		return
 	}
-	if instance.Ready {
+	if instance.Ready && env().Database.MigrationGate.Complete(r.Context()) {
 		klog.Infof("Instance is ready")
 		w.WriteHeader(http.StatusOK)
 		_, err := w.Write([]byte(`{"status": "ok"}`))
@@ -20,25 +20,29 @@ func init() {
 }
 
 // NewMetricsUnaryInterceptor creates a unary server interceptor for server metrics.
-// Currently supports the Publish method with PublishRequest.
+// Currently supports the Publish method with PublishRequest and the GetResourceStatus method, the source of the
+// GetResourceStatus method is unknown until the resource is found, so it is counted with an empty source.
 func newMetricsUnaryInterceptor() grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 		// extract the type from the method name
 		methodInfo := strings.Split(info.FullMethod, "/")
-		if len(methodInfo) != 3 || methodInfo[2] != "Publish" {
+		if len(methodInfo) != 3 || (methodInfo[2] != "Publish" && methodInfo[2] != "GetResourceStatus") {
 			return nil, fmt.Errorf("invalid method name: %s", info.FullMethod)
 		}
 		t := methodInfo[2]
-		pubReq, ok := req.(*pbv1.PublishRequest)
-		if !ok {
-			return nil, fmt.Errorf("invalid request type for Publish method")
-		}
-		// convert the request to cloudevent and extract the source
-		evt, err := binding.ToEvent(ctx, grpcprotocol.NewMessage(pubReq.Event))
-		if err != nil {
-			return nil, fmt.Errorf("failed to convert to cloudevent: %v", err)
+		source := ""
+		if t == "Publish" {
+			pubReq, ok := req.(*pbv1.PublishRequest)
+			if !ok {
+				return nil, fmt.Errorf("invalid request type for Publish method")
+			}
+			// convert the request to cloudevent and extract the source
+			evt, err := binding.ToEvent(ctx, grpcprotocol.NewMessage(pubReq.Event))
+			if err != nil {
+				return nil, fmt.Errorf("failed to convert to cloudevent: %v", err)
+			}
+			source = evt.Source()
 		}
-		source := evt.Source()
 		grpcCalledCountMetric.WithLabelValues(t, source).Inc()
 
 		grpcMessageReceivedCountMetric.WithLabelValues(t, source).Inc()
@@ -134,11 +138,27 @@ func statusFromError(err error) *status.Status {
 // Subsystem used to define the metrics:
 const grpcMetricsSubsystem = "grpc_server"
 
+// Subsystem used to define the gRPC broker metrics:
+const grpcBrokerMetricsSubsystem = "grpc_broker"
+
+// Subsystem used to define the metrics of the gRPC transport that are shared by the gRPC server and broker:
+const grpcTransportMetricsSubsystem = "grpc"
+
 // Names of the labels added to metrics:
 const (
-	grpcMetricsTypeLabel   = "type"
-	grpcMetricsSourceLabel = "source"
-	grpcMetricsCodeLabel   = "code"
+	grpcMetricsTypeLabel      = "type"
+	grpcMetricsSourceLabel    = "source"
+	grpcMetricsCodeLabel      = "code"
+	grpcMetricsServerLabel    = "server"
+	grpcMetricsDirectionLabel = "direction"
+	grpcMetricsActionLabel    = "action"
+	grpcMetricsOutcomeLabel   = "outcome"
+)
+
+// Outcomes of the publish requests:
+const (
+	publishOutcomeSuccess = "success"
+	publishOutcomeError   = "error"
 )
 
 // grpcMetricsLabels - Array of labels added to metrics:
@@ -161,6 +181,11 @@ const (
 	processedDurationMetric    = "processed_duration_seconds"
 	messageReceivedCountMetric = "message_received_total"
 	messageSentCountMetric     = "message_sent_total"
+	asyncCommitFailedMetric    = "async_commit_failed_total"
+	activeSubscribersMetric    = "active_subscribers"
+	oversizedMessagesMetric    = "oversized_messages_total"
+	versionRollbackMetric      = "version_rollback_total"
+	publishDurationMetric      = "publish_duration_seconds"
 )
 
 // Register the metrics:
@@ -170,6 +195,11 @@ func RegisterGRPCMetrics() {
 	prometheus.MustRegister(grpcProcessedDurationMetric)
 	prometheus.MustRegister(grpcMessageReceivedCountMetric)
 	prometheus.MustRegister(grpcMessageSentCountMetric)
+	prometheus.MustRegister(grpcAsyncCommitFailedCountMetric)
+	prometheus.MustRegister(grpcBrokerActiveSubscribersMetric)
+	prometheus.MustRegister(grpcOversizedMessagesCountMetric)
+	prometheus.MustRegister(versionRollbackCountMetric)
+	prometheus.MustRegister(publishDurationHistogramMetric)
 }
 
 // Unregister the metrics:
@@ -179,6 +209,11 @@ func UnregisterGRPCMetrics() {
 	prometheus.Unregister(grpcProcessedDurationMetric)
 	prometheus.Unregister(grpcMessageReceivedCountMetric)
 	prometheus.Unregister(grpcMessageSentCountMetric)
+	prometheus.Unregister(grpcAsyncCommitFailedCountMetric)
+	prometheus.Unregister(grpcBrokerActiveSubscribersMetric)
+	prometheus.Unregister(grpcOversizedMessagesCountMetric)
+	prometheus.Unregister(versionRollbackCountMetric)
+	prometheus.Unregister(publishDurationHistogramMetric)
 }
 
 // Reset the metrics:
@@ -188,6 +223,11 @@ func ResetGRPCMetrics() {
 	grpcProcessedDurationMetric.Reset()
 	grpcMessageReceivedCountMetric.Reset()
 	grpcMessageSentCountMetric.Reset()
+	grpcAsyncCommitFailedCountMetric.Reset()
+	grpcBrokerActiveSubscribersMetric.Set(0)
+	grpcOversizedMessagesCountMetric.Reset()
+	versionRollbackCountMetric.Reset()
+	publishDurationHistogramMetric.Reset()
 }
 
 // Description of the gRPC called count metric:
@@ -240,3 +280,60 @@ var grpcMessageSentCountMetric = prometheus.NewCounterVec(
 	},
 	grpcMetricsLabels,
 )
+
+// Description of the gRPC async commit failed count metric:
+var grpcAsyncCommitFailedCountMetric = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Subsystem: grpcMetricsSubsystem,
+		Name:      asyncCommitFailedMetric,
+		Help:      "Total number of resources published with the async commit mode but failed to be committed.",
+	},
+	grpcMetricsLabels,
+)
+
+// Description of the gRPC broker active subscribers metric:
+var grpcBrokerActiveSubscribersMetric = prometheus.NewGauge(
+	prometheus.GaugeOpts{
+		Subsystem: grpcBrokerMetricsSubsystem,
+		Name:      activeSubscribersMetric,
+		Help:      "Number of the agent subscribers registered on the gRPC broker.",
+	},
+)
+
+// Description of the gRPC oversized messages count metric:
+var grpcOversizedMessagesCountMetric = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Subsystem: grpcTransportMetricsSubsystem,
+		Name:      oversizedMessagesMetric,
+		Help:      "Total number of messages rejected by the gRPC transport for exceeding the message size limits.",
+	},
+	[]string{
+		grpcMetricsServerLabel,
+		grpcMetricsTypeLabel,
+		grpcMetricsDirectionLabel,
+	},
+)
+
+// Description of the version rollback count metric, it's exposed without the gRPC subsystem, i.e.
+// maestro_version_rollback_total:
+var versionRollbackCountMetric = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "maestro",
+		Name:      versionRollbackMetric,
+		Help:      "Total number of resource bundle updates without a version that are applied to the latest resource version.",
+	},
+	[]string{grpcMetricsSourceLabel},
+)
+
+// Description of the publish duration metric, it's exposed without the gRPC subsystem, i.e.
+// maestro_publish_duration_seconds, the action is create, update, delete, resync (or unknown if the event type cannot
+// be parsed), the outcome is success or error:
+var publishDurationHistogramMetric = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Namespace: "maestro",
+		Name:      publishDurationMetric,
+		Help:      "Histogram of the duration of the publish requests handled by the gRPC server.",
+		Buckets:   prometheus.DefBuckets,
+	},
+	[]string{grpcMetricsActionLabel, grpcMetricsOutcomeLabel},
+)
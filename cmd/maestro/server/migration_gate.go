@@ -0,0 +1,34 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// migrationRetryDelay is the delay that the clients are told to wait before retrying a request that is rejected until
+// the database migrations are complete.
+const migrationRetryDelay = 5 * time.Second
+
+// checkMigrated returns an unavailable error with a retry hint if the database migrations are not complete, so the
+// clients retry the request later rather than failing with the errors of a half-migrated schema. The reads are only
+// rejected if the reads are gated by the config.
+func checkMigrated(ctx context.Context, mutating bool) error {
+	if !mutating && !env().Config.Database.GateReadsUntilMigrated {
+		return nil
+	}
+	if env().Database.MigrationGate.Complete(ctx) {
+		return nil
+	}
+
+	st, err := status.New(codes.Unavailable, "the database migrations are not complete, retry later").
+		WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(migrationRetryDelay)})
+	if err != nil {
+		return status.Error(codes.Unavailable, "the database migrations are not complete, retry later")
+	}
+	return st.Err()
+}
@@ -0,0 +1,87 @@
+package server
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+	"k8s.io/klog/v2"
+)
+
+// oversizedMessagePattern matches the errors of the gRPC transport for the messages that exceed the message size
+// limits, e.g. "grpc: received message larger than max (5242880 vs. 4194304)", the groups are the message size and
+// the limit.
+var oversizedMessagePattern = regexp.MustCompile(`larger than max[^(]*\((\d+) vs\. (\d+)\)`)
+
+type oversizedMessageMethodKey struct{}
+
+// oversizedMessageHandler is a gRPC stats handler that detects the messages rejected by the transport for exceeding
+// the MaxReceiveMessageSize or MaxSendMessageSize. A rejected message never reaches the interceptors and the handlers,
+// so the peer only sees a ResourceExhausted error, the handler logs the peer and the message size and counts the
+// rejected messages with the grpc_oversized_messages_total metric.
+type oversizedMessageHandler struct {
+	// server is the name of the gRPC server, i.e. grpc_server or grpc_broker.
+	server string
+}
+
+var _ stats.Handler = &oversizedMessageHandler{}
+
+func newOversizedMessageHandler(server string) stats.Handler {
+	return &oversizedMessageHandler{server: server}
+}
+
+func (h *oversizedMessageHandler) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	return context.WithValue(ctx, oversizedMessageMethodKey{}, info.FullMethodName)
+}
+
+func (h *oversizedMessageHandler) HandleRPC(ctx context.Context, s stats.RPCStats) {
+	end, ok := s.(*stats.End)
+	if !ok || end.Error == nil || status.Code(end.Error) != codes.ResourceExhausted {
+		return
+	}
+
+	message := status.Convert(end.Error).Message()
+	matches := oversizedMessagePattern.FindStringSubmatch(message)
+	if matches == nil {
+		return
+	}
+	size, _ := strconv.Atoi(matches[1])
+	limit, _ := strconv.Atoi(matches[2])
+
+	direction := "sent"
+	if strings.Contains(message, "received message") {
+		direction = "received"
+	}
+
+	fullMethod, _ := ctx.Value(oversizedMessageMethodKey{}).(string)
+	method := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+
+	grpcOversizedMessagesCountMetric.WithLabelValues(h.server, method, direction).Inc()
+	klog.Warningf("%s rejected an oversized %s message of %s, peer=%s, size=%d bytes, limit=%d bytes",
+		h.server, direction, fullMethod, peerIdentity(ctx), size, limit)
+}
+
+func (h *oversizedMessageHandler) TagConn(ctx context.Context, info *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (h *oversizedMessageHandler) HandleConn(ctx context.Context, s stats.ConnStats) {}
+
+// peerIdentity returns the user of the client certificate and the address of the peer, the message is rejected before
+// it is authenticated, so the certificate is the only identity of the peer that is known.
+func peerIdentity(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "unknown"
+	}
+
+	if user, _, err := identityFromCertificate(ctx); err == nil {
+		return user + "@" + p.Addr.String()
+	}
+	return p.Addr.String()
+}
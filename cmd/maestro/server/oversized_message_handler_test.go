@@ -0,0 +1,72 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+)
+
+func TestOversizedMessageHandler(t *testing.T) {
+	cases := []struct {
+		name              string
+		stats             stats.RPCStats
+		expectedDirection string
+	}{
+		{
+			name:              "received message",
+			stats:             &stats.End{Error: status.Error(codes.ResourceExhausted, "grpc: received message larger than max (5242880 vs. 4194304)")},
+			expectedDirection: "received",
+		},
+		{
+			name:              "decompressed message",
+			stats:             &stats.End{Error: status.Error(codes.ResourceExhausted, "grpc: received message after decompression larger than max (5242880 vs. 4194304)")},
+			expectedDirection: "received",
+		},
+		{
+			name:              "sent message",
+			stats:             &stats.End{Error: status.Error(codes.ResourceExhausted, "grpc: trying to send message larger than max (5242880 vs. 4194304)")},
+			expectedDirection: "sent",
+		},
+		{
+			name:  "other resource exhausted error",
+			stats: &stats.End{Error: status.Error(codes.ResourceExhausted, "too many requests")},
+		},
+		{
+			name:  "other error",
+			stats: &stats.End{Error: status.Error(codes.Internal, "grpc: received message larger than max (5242880 vs. 4194304)")},
+		},
+		{
+			name:  "no error",
+			stats: &stats.End{},
+		},
+		{
+			name:  "not the end of the rpc",
+			stats: &stats.Begin{},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ResetGRPCMetrics()
+			defer ResetGRPCMetrics()
+
+			h := newOversizedMessageHandler(grpcMetricsSubsystem)
+			ctx := h.TagRPC(context.Background(), &stats.RPCTagInfo{FullMethodName: "/io.cloudevents.v1.CloudEventService/Publish"})
+			h.HandleRPC(ctx, c.stats)
+
+			if len(c.expectedDirection) == 0 {
+				if count := testutil.CollectAndCount(grpcOversizedMessagesCountMetric); count != 0 {
+					t.Errorf("expected no oversized message is counted, but got %d series", count)
+				}
+				return
+			}
+			if count := testutil.ToFloat64(grpcOversizedMessagesCountMetric.WithLabelValues(grpcMetricsSubsystem, "Publish", c.expectedDirection)); count != 1 {
+				t.Errorf("expected one oversized %s message of Publish, but got %v", c.expectedDirection, count)
+			}
+		})
+	}
+}
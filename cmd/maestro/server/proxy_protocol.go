@@ -0,0 +1,230 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc/peer"
+	"k8s.io/klog/v2"
+)
+
+var (
+	// proxyProtocolV1Prefix is the prefix of a PROXY protocol v1 (text) header.
+	proxyProtocolV1Prefix = []byte("PROXY ")
+	// proxyProtocolV2Signature is the signature of a PROXY protocol v2 (binary) header.
+	proxyProtocolV2Signature = []byte("\r\n\r\n\x00\r\nQUIT\n")
+)
+
+const (
+	// proxyProtocolV1MaxLength is the max length of a PROXY protocol v1 header, including the CRLF.
+	proxyProtocolV1MaxLength = 107
+	// proxyProtocolV2HeaderLength is the length of the fixed part of a PROXY protocol v2 header.
+	proxyProtocolV2HeaderLength = 16
+)
+
+// proxyProtocolListener recovers the client address of a connection from its PROXY protocol (v1 or v2) header, e.g.
+// the header sent by a L4 load balancer, so the peer address of the gRPC requests is the real client address.
+// Only the headers of the connections from the trusted proxies are read, the address of a connection from another peer
+// is kept as it is, so the clients cannot spoof their addresses.
+type proxyProtocolListener struct {
+	net.Listener
+	trustedProxies []*net.IPNet
+}
+
+// newProxyProtocolListener wraps the listener to read the PROXY protocol headers of the connections from the trusted
+// proxy CIDRs, the listener is returned as it is if there is no trusted proxy.
+func newProxyProtocolListener(lis net.Listener, trustedCIDRs []string) (net.Listener, error) {
+	if len(trustedCIDRs) == 0 {
+		return lis, nil
+	}
+
+	trustedProxies := make([]*net.IPNet, 0, len(trustedCIDRs))
+	for _, cidr := range trustedCIDRs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy CIDR %q: %v", cidr, err)
+		}
+		trustedProxies = append(trustedProxies, ipNet)
+	}
+	return &proxyProtocolListener{Listener: lis, trustedProxies: trustedProxies}, nil
+}
+
+func (l *proxyProtocolListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	if !l.isTrusted(conn.RemoteAddr()) {
+		return conn, nil
+	}
+	return &proxyProtocolConn{Conn: conn, reader: bufio.NewReader(conn)}, nil
+}
+
+func (l *proxyProtocolListener) isTrusted(addr net.Addr) bool {
+	tcpAddr, ok := addr.(*net.TCPAddr)
+	if !ok {
+		return false
+	}
+	for _, trustedProxy := range l.trustedProxies {
+		if trustedProxy.Contains(tcpAddr.IP) {
+			return true
+		}
+	}
+	return false
+}
+
+// proxyProtocolConn reads the PROXY protocol header on its first read (or the first lookup of its remote address),
+// the header is read within the connection timeout of the gRPC server as the server sets the deadline of the
+// connection before its handshake.
+type proxyProtocolConn struct {
+	net.Conn
+	reader *bufio.Reader
+
+	once       sync.Once
+	remoteAddr net.Addr
+	err        error
+}
+
+func (c *proxyProtocolConn) Read(b []byte) (int, error) {
+	c.once.Do(c.readHeader)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return c.reader.Read(b)
+}
+
+func (c *proxyProtocolConn) RemoteAddr() net.Addr {
+	c.once.Do(c.readHeader)
+	if c.remoteAddr != nil {
+		return c.remoteAddr
+	}
+	return c.Conn.RemoteAddr()
+}
+
+func (c *proxyProtocolConn) readHeader() {
+	addr, err := readProxyProtocolHeader(c.reader)
+	if err != nil {
+		c.err = fmt.Errorf("failed to read the PROXY protocol header from %s: %v", c.Conn.RemoteAddr(), err)
+		klog.Warning(c.err)
+		return
+	}
+	c.remoteAddr = addr
+}
+
+// readProxyProtocolHeader reads the PROXY protocol header and returns the source address in the header. A nil address
+// is returned if there is no header, or the header doesn't carry the source address (e.g. the health checks of the
+// proxy), then the address of the proxy is used.
+func readProxyProtocolHeader(r *bufio.Reader) (net.Addr, error) {
+	prefix, err := r.Peek(len(proxyProtocolV1Prefix))
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case bytes.Equal(prefix, proxyProtocolV1Prefix):
+		return readProxyProtocolV1Header(r)
+	case bytes.HasPrefix(proxyProtocolV2Signature, prefix):
+		return readProxyProtocolV2Header(r)
+	default:
+		return nil, nil
+	}
+}
+
+// readProxyProtocolV1Header reads a header, e.g. "PROXY TCP4 192.168.0.1 192.168.0.11 56324 443\r\n".
+func readProxyProtocolV1Header(r *bufio.Reader) (net.Addr, error) {
+	line, err := r.ReadSlice('\n')
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the v1 header: %v", err)
+	}
+	if len(line) > proxyProtocolV1MaxLength || !bytes.HasSuffix(line, []byte("\r\n")) {
+		return nil, fmt.Errorf("invalid v1 header %q", line)
+	}
+
+	fields := strings.Split(string(line[:len(line)-2]), " ")
+	if len(fields) >= 2 && fields[1] == "UNKNOWN" {
+		return nil, nil
+	}
+	if len(fields) != 6 || (fields[1] != "TCP4" && fields[1] != "TCP6") {
+		return nil, fmt.Errorf("invalid v1 header %q", line)
+	}
+
+	ip := net.ParseIP(fields[2])
+	if ip == nil {
+		return nil, fmt.Errorf("invalid source address %q in the v1 header", fields[2])
+	}
+	port, err := strconv.ParseUint(fields[4], 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("invalid source port %q in the v1 header", fields[4])
+	}
+	return &net.TCPAddr{IP: ip, Port: int(port)}, nil
+}
+
+// readProxyProtocolV2Header reads a binary header, its TLVs are skipped.
+func readProxyProtocolV2Header(r *bufio.Reader) (net.Addr, error) {
+	header, err := r.Peek(proxyProtocolV2HeaderLength)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the v2 header: %v", err)
+	}
+	if !bytes.Equal(header[:len(proxyProtocolV2Signature)], proxyProtocolV2Signature) {
+		return nil, fmt.Errorf("invalid v2 header signature")
+	}
+
+	versionCommand, family := header[12], header[13]
+	length := int(binary.BigEndian.Uint16(header[14:16]))
+	if versionCommand>>4 != 2 {
+		return nil, fmt.Errorf("unsupported version %d of the v2 header", versionCommand>>4)
+	}
+	if _, err := r.Discard(proxyProtocolV2HeaderLength); err != nil {
+		return nil, err
+	}
+	addresses := make([]byte, length)
+	if _, err := io.ReadFull(r, addresses); err != nil {
+		return nil, fmt.Errorf("failed to read the addresses of the v2 header: %v", err)
+	}
+
+	switch versionCommand & 0x0f {
+	case 0x00:
+		// LOCAL, the connection is initiated by the proxy itself
+		return nil, nil
+	case 0x01:
+		// PROXY
+	default:
+		return nil, fmt.Errorf("unsupported command %d of the v2 header", versionCommand&0x0f)
+	}
+
+	switch family >> 4 {
+	case 0x01:
+		// AF_INET, the source and destination addresses (4 bytes) and ports (2 bytes)
+		if length < 12 {
+			return nil, fmt.Errorf("invalid length %d of the v2 header for the IPv4 addresses", length)
+		}
+		return &net.TCPAddr{IP: net.IP(addresses[0:4]), Port: int(binary.BigEndian.Uint16(addresses[8:10]))}, nil
+	case 0x02:
+		// AF_INET6, the source and destination addresses (16 bytes) and ports (2 bytes)
+		if length < 36 {
+			return nil, fmt.Errorf("invalid length %d of the v2 header for the IPv6 addresses", length)
+		}
+		return &net.TCPAddr{IP: net.IP(addresses[0:16]), Port: int(binary.BigEndian.Uint16(addresses[32:34]))}, nil
+	default:
+		// AF_UNSPEC or AF_UNIX, the source address is unknown
+		return nil, nil
+	}
+}
+
+// peerAddress returns the address of the gRPC client, it is the real client address recovered from the PROXY protocol
+// header if the client connects through a trusted proxy.
+func peerAddress(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return "unknown"
+	}
+	return p.Addr.String()
+}
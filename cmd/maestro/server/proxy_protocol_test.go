@@ -0,0 +1,233 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"testing"
+)
+
+// proxyProtocolV2Header returns a v2 header with the given command, family and address block, the declared length
+// is the length of the address block unless it is overridden by a non-negative length.
+func proxyProtocolV2Header(command, family byte, addresses []byte, length int) []byte {
+	if length < 0 {
+		length = len(addresses)
+	}
+	header := append([]byte{}, proxyProtocolV2Signature...)
+	header = append(header, 0x20|command, family)
+	header = binary.BigEndian.AppendUint16(header, uint16(length))
+	return append(header, addresses...)
+}
+
+// proxyProtocolV2Addresses returns the address block of the source and destination addresses and ports.
+func proxyProtocolV2Addresses(src, dst net.IP, srcPort, dstPort uint16) []byte {
+	addresses := append(append([]byte{}, src...), dst...)
+	addresses = binary.BigEndian.AppendUint16(addresses, srcPort)
+	return binary.BigEndian.AppendUint16(addresses, dstPort)
+}
+
+func TestReadProxyProtocolHeader(t *testing.T) {
+	ipv4Addresses := proxyProtocolV2Addresses(net.ParseIP("192.168.0.1").To4(), net.ParseIP("192.168.0.11").To4(), 56324, 443)
+	ipv6Addresses := proxyProtocolV2Addresses(net.ParseIP("2001:db8::1"), net.ParseIP("2001:db8::2"), 56324, 443)
+
+	cases := []struct {
+		name         string
+		header       []byte
+		expectedAddr string
+		expectedErr  string
+	}{
+		{
+			name:         "v1 TCP4",
+			header:       []byte("PROXY TCP4 192.168.0.1 192.168.0.11 56324 443\r\n"),
+			expectedAddr: "192.168.0.1:56324",
+		},
+		{
+			name:         "v1 TCP6",
+			header:       []byte("PROXY TCP6 2001:db8::1 2001:db8::2 56324 443\r\n"),
+			expectedAddr: "[2001:db8::1]:56324",
+		},
+		{
+			name:   "v1 UNKNOWN",
+			header: []byte("PROXY UNKNOWN\r\n"),
+		},
+		{
+			name:        "v1 too long",
+			header:      []byte("PROXY TCP6 " + strings.Repeat("f", proxyProtocolV1MaxLength) + " 2001:db8::2 56324 443\r\n"),
+			expectedErr: "invalid v1 header",
+		},
+		{
+			name:        "v1 bad port",
+			header:      []byte("PROXY TCP4 192.168.0.1 192.168.0.11 65536 443\r\n"),
+			expectedErr: `invalid source port "65536" in the v1 header`,
+		},
+		{
+			name:        "v1 without CRLF",
+			header:      []byte("PROXY TCP4 192.168.0.1 192.168.0.11 56324 443\n"),
+			expectedErr: "invalid v1 header",
+		},
+		{
+			name:        "v1 bad address",
+			header:      []byte("PROXY TCP4 192.168.0 192.168.0.11 56324 443\r\n"),
+			expectedErr: `invalid source address "192.168.0" in the v1 header`,
+		},
+		{
+			name:         "v2 PROXY AF_INET",
+			header:       proxyProtocolV2Header(0x01, 0x11, ipv4Addresses, -1),
+			expectedAddr: "192.168.0.1:56324",
+		},
+		{
+			name:         "v2 PROXY AF_INET6",
+			header:       proxyProtocolV2Header(0x01, 0x21, ipv6Addresses, -1),
+			expectedAddr: "[2001:db8::1]:56324",
+		},
+		{
+			name:   "v2 LOCAL AF_INET",
+			header: proxyProtocolV2Header(0x00, 0x11, ipv4Addresses, -1),
+		},
+		{
+			name:   "v2 LOCAL AF_INET6",
+			header: proxyProtocolV2Header(0x00, 0x21, ipv6Addresses, -1),
+		},
+		{
+			name:        "v2 length shorter than the IPv4 addresses",
+			header:      proxyProtocolV2Header(0x01, 0x11, ipv4Addresses[:8], -1),
+			expectedErr: "invalid length 8 of the v2 header for the IPv4 addresses",
+		},
+		{
+			name:        "v2 length shorter than the IPv6 addresses",
+			header:      proxyProtocolV2Header(0x01, 0x21, ipv6Addresses[:32], -1),
+			expectedErr: "invalid length 32 of the v2 header for the IPv6 addresses",
+		},
+		{
+			name:        "v2 truncated addresses",
+			header:      proxyProtocolV2Header(0x01, 0x11, ipv4Addresses[:6], len(ipv4Addresses)),
+			expectedErr: "failed to read the addresses of the v2 header",
+		},
+		{
+			name:        "v2 truncated header",
+			header:      proxyProtocolV2Header(0x01, 0x11, nil, 0)[:14],
+			expectedErr: "failed to read the v2 header",
+		},
+		{
+			name:        "v2 bad signature",
+			header:      append([]byte("\r\n\r\n\x00\r\nQUIX\n"), proxyProtocolV2Header(0x01, 0x11, ipv4Addresses, -1)[12:]...),
+			expectedErr: "invalid v2 header signature",
+		},
+		{
+			name:        "v2 unsupported command",
+			header:      proxyProtocolV2Header(0x02, 0x11, ipv4Addresses, -1),
+			expectedErr: "unsupported command 2 of the v2 header",
+		},
+		{
+			name:   "no header",
+			header: []byte("PRI * HTTP/2.0\r\n\r\nSM\r\n\r\n"),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			addr, err := readProxyProtocolHeader(bufio.NewReader(bytes.NewReader(c.header)))
+			switch {
+			case len(c.expectedErr) == 0 && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case len(c.expectedErr) != 0 && (err == nil || !strings.Contains(err.Error(), c.expectedErr)):
+				t.Fatalf("expected error %q, but got %v", c.expectedErr, err)
+			}
+			if len(c.expectedAddr) == 0 {
+				if addr != nil {
+					t.Errorf("expected no address, but got %s", addr)
+				}
+				return
+			}
+			if addr == nil || addr.String() != c.expectedAddr {
+				t.Errorf("expected the address %s, but got %v", c.expectedAddr, addr)
+			}
+		})
+	}
+}
+
+// fakeConn is a connection from the given remote address that reads the given data.
+type fakeConn struct {
+	net.Conn
+	remoteAddr net.Addr
+	reader     io.Reader
+}
+
+func (c *fakeConn) Read(b []byte) (int, error) { return c.reader.Read(b) }
+
+func (c *fakeConn) RemoteAddr() net.Addr { return c.remoteAddr }
+
+// fakeListener accepts the given connection once.
+type fakeListener struct {
+	net.Listener
+	conn net.Conn
+}
+
+func (l *fakeListener) Accept() (net.Conn, error) { return l.conn, nil }
+
+func TestProxyProtocolListener(t *testing.T) {
+	v1Header := "PROXY TCP4 192.168.0.1 192.168.0.11 56324 443\r\n"
+	cases := []struct {
+		name         string
+		remoteAddr   string
+		data         string
+		expectedAddr string
+		expectedData string
+	}{
+		{
+			name:         "trusted proxy with a header",
+			remoteAddr:   "10.0.0.5:40000",
+			data:         v1Header + "payload",
+			expectedAddr: "192.168.0.1:56324",
+			expectedData: "payload",
+		},
+		{
+			// the header of an untrusted peer is not parsed, so the peer cannot spoof its address
+			name:         "untrusted peer with a header",
+			remoteAddr:   "172.16.0.5:40000",
+			data:         v1Header + "payload",
+			expectedAddr: "172.16.0.5:40000",
+			expectedData: v1Header + "payload",
+		},
+		{
+			name:         "trusted proxy without a header",
+			remoteAddr:   "10.0.0.5:40000",
+			data:         "payload",
+			expectedAddr: "10.0.0.5:40000",
+			expectedData: "payload",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			remoteAddr, err := net.ResolveTCPAddr("tcp", c.remoteAddr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			lis, err := newProxyProtocolListener(&fakeListener{
+				conn: &fakeConn{remoteAddr: remoteAddr, reader: strings.NewReader(c.data)},
+			}, []string{"10.0.0.0/16"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			conn, err := lis.Accept()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if addr := conn.RemoteAddr().String(); addr != c.expectedAddr {
+				t.Errorf("expected the remote address %s, but got %s", c.expectedAddr, addr)
+			}
+			data, err := io.ReadAll(conn)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != c.expectedData {
+				t.Errorf("expected the data %q, but got %q", c.expectedData, data)
+			}
+		})
+	}
+}
@@ -9,12 +9,15 @@ import (
 	"github.com/openshift-online/maestro/cmd/maestro/server/logging"
 	"github.com/openshift-online/maestro/pkg/api"
 	"github.com/openshift-online/maestro/pkg/auth"
+	"github.com/openshift-online/maestro/pkg/controllers"
+	"github.com/openshift-online/maestro/pkg/dao"
 	"github.com/openshift-online/maestro/pkg/db"
+	"github.com/openshift-online/maestro/pkg/event"
 	"github.com/openshift-online/maestro/pkg/handlers"
 	"github.com/openshift-online/maestro/pkg/logger"
 )
 
-func (s *apiServer) routes() *mux.Router {
+func (s *apiServer) routes(eventBroadcaster *event.EventBroadcaster) *mux.Router {
 	services := &env().Services
 
 	openAPIDefinitions, err := s.loadOpenAPISpec("openapi.yaml")
@@ -22,8 +25,34 @@ func (s *apiServer) routes() *mux.Router {
 		check(err, "Can't load OpenAPI specification")
 	}
 
-	resourceHandler := handlers.NewResourceHandler(services.Resources(), services.Generic())
+	// the default namespace can be set by the manifest annotation even if the server default namespace is not set
+	namespaceDefaults := &api.NamespaceDefaults{
+		Namespace:          env().Config.Resource.DefaultNamespace,
+		ClusterScopedKinds: env().Config.Resource.ClusterScopedKinds,
+	}
+
+	// the admin operations are open to every user if the JWT authentication is disabled, as there is no user identity
+	adminAuthorizer := auth.NewAdminAuthorizerMock()
+	if env().Config.HTTPServer.EnableJWT {
+		adminAuthorizer = auth.NewAdminAuthorizer(env().Config.HTTPServer.AdminUsers)
+	}
+
+	resourceHandler := handlers.NewResourceHandler(services.Resources(), services.Consumers(), services.Generic(),
+		services.ResourceLocks(), adminAuthorizer, namespaceDefaults)
+	resourceLockHandler := handlers.NewResourceLockHandler(services.ResourceLocks(), adminAuthorizer)
+	resourceTemplateHandler := handlers.NewResourceTemplateHandler(services.ResourceTemplates(), resourceHandler, services.Generic(), adminAuthorizer)
 	consumerHandler := handlers.NewConsumerHandler(services.Consumers(), services.Resources(), services.Generic())
+	consumerTokenHandler := handlers.NewConsumerTokenHandler(services.ConsumerTokens(), services.Consumers(), services.Generic())
+	consumerGroupHandler := handlers.NewConsumerGroupHandler(services.Consumers(), services.Resources(), services.Generic())
+	broadcasterHandler := handlers.NewBroadcasterHandler(eventBroadcaster, env().Config.MessageBroker.ClientID)
+	statusResyncHandler := handlers.NewStatusResyncHandler(controllers.NewStatusResyncer(
+		db.NewLeaderLock(env().Database.SessionFactory, statusResyncLeaderLockKey, statusResyncLeaderRenewInterval),
+		services.Consumers(),
+		dao.NewStatusResyncDao(&env().Database.SessionFactory),
+		env().Clients.CloudEventsSource,
+		env().Config.EventServer.StatusResyncInterval,
+		env().Config.MessageBroker.ClientID,
+	))
 	errorsHandler := handlers.NewErrorsHandler()
 
 	var authMiddleware auth.JWTMiddleware
@@ -45,7 +74,9 @@ func (s *apiServer) routes() *mux.Router {
 
 	// mainRouter is top level "/"
 	mainRouter := mux.NewRouter()
-	mainRouter.NotFoundHandler = http.HandlerFunc(api.SendNotFound)
+	// the router middlewares are not applied to these handlers, so the operation ID is set explicitly
+	mainRouter.NotFoundHandler = logger.OperationIDMiddleware(http.HandlerFunc(api.SendNotFound))
+	mainRouter.MethodNotAllowedHandler = logger.OperationIDMiddleware(http.HandlerFunc(api.SendMethodNotAllowed))
 
 	// Operation ID middleware sets a relatively unique operation ID in the context of each request for debugging purposes
 	mainRouter.Use(logger.OperationIDMiddleware)
@@ -71,23 +102,75 @@ func (s *apiServer) routes() *mux.Router {
 	apiV1ErrorsRouter.HandleFunc("", errorsHandler.List).Methods(http.MethodGet)
 	apiV1ErrorsRouter.HandleFunc("/{id}", errorsHandler.Get).Methods(http.MethodGet)
 
+	//  /api/maestro/v1/resources:batchCreate
+	// the ":batchCreate" is not a sub path, so it is registered ahead of the resources router
+	apiV1ResourcesBatchRouter := apiV1Router.Path("/resources:batchCreate").Subrouter()
+	apiV1ResourcesBatchRouter.HandleFunc("", resourceHandler.BatchCreate).Methods(http.MethodPost)
+	apiV1ResourcesBatchRouter.Use(authMiddleware.AuthenticateAccountJWT)
+	apiV1ResourcesBatchRouter.Use(authzMiddleware.AuthorizeApi)
+
+	//  /api/maestro/v1/manifests:validate
+	apiV1ManifestsValidateRouter := apiV1Router.Path("/manifests:validate").Subrouter()
+	apiV1ManifestsValidateRouter.HandleFunc("", resourceHandler.ValidateManifest).Methods(http.MethodPost)
+	apiV1ManifestsValidateRouter.Use(authMiddleware.AuthenticateAccountJWT)
+	apiV1ManifestsValidateRouter.Use(authzMiddleware.AuthorizeApi)
+
 	//  /api/maestro/v1/resources
 	apiV1ResourceRouter := apiV1Router.PathPrefix("/resources").Subrouter()
 	apiV1ResourceRouter.HandleFunc("", resourceHandler.List).Methods(http.MethodGet)
+	apiV1ResourceRouter.HandleFunc("/pending-deletion", resourceHandler.ListPendingDeletion).Methods(http.MethodGet)
+	apiV1ResourceRouter.HandleFunc("/version-drift", resourceHandler.ListVersionDrift).Methods(http.MethodGet)
+	apiV1ResourceRouter.Handle("/orphaned",
+		adminAuthorizer.RequireAdmin(http.HandlerFunc(resourceHandler.ListOrphaned))).Methods(http.MethodGet)
 	apiV1ResourceRouter.HandleFunc("/{id}", resourceHandler.Get).Methods(http.MethodGet)
 	apiV1ResourceRouter.HandleFunc("", resourceHandler.Create).Methods(http.MethodPost)
 	apiV1ResourceRouter.HandleFunc("/{id}", resourceHandler.Patch).Methods(http.MethodPatch)
 	apiV1ResourceRouter.HandleFunc("/{id}", resourceHandler.Delete).Methods(http.MethodDelete)
+	apiV1ResourceRouter.HandleFunc("/{id}/revisions", resourceHandler.ListRevisions).Methods(http.MethodGet)
+	apiV1ResourceRouter.HandleFunc("/{id}/revisions/{version}/revert", resourceHandler.Revert).Methods(http.MethodPost)
+	apiV1ResourceRouter.HandleFunc("/{id}/reconcile", resourceHandler.Reconcile).Methods(http.MethodPost)
+	apiV1ResourceRouter.Handle("/{id}/release",
+		adminAuthorizer.RequireAdmin(http.HandlerFunc(resourceHandler.ReleaseQuarantine))).Methods(http.MethodPost)
+	apiV1ResourceRouter.HandleFunc("/{id}/ownership-transfers", resourceHandler.ListOwnershipTransfers).Methods(http.MethodGet)
+	apiV1ResourceRouter.Handle("/{id}/ownership-transfers",
+		adminAuthorizer.RequireAdmin(http.HandlerFunc(resourceHandler.TransferOwnership))).Methods(http.MethodPost)
+	apiV1ResourceRouter.HandleFunc("/{id}/lock", resourceLockHandler.Get).Methods(http.MethodGet)
+	apiV1ResourceRouter.HandleFunc("/{id}/lock", resourceLockHandler.Acquire).Methods(http.MethodPost)
+	apiV1ResourceRouter.HandleFunc("/{id}/lock", resourceLockHandler.Release).Methods(http.MethodDelete)
 	apiV1ResourceRouter.Use(authMiddleware.AuthenticateAccountJWT)
 	apiV1ResourceRouter.Use(authzMiddleware.AuthorizeApi)
 
 	// /api/maestro/v1/resource-bundles
 	apiV1ResourceBundleRouter := apiV1Router.PathPrefix("/resource-bundles").Subrouter()
 	apiV1ResourceBundleRouter.HandleFunc("", resourceHandler.ListBundle).Methods(http.MethodGet)
+	apiV1ResourceBundleRouter.HandleFunc("/pending-deletion", resourceHandler.ListBundlePendingDeletion).Methods(http.MethodGet)
+	apiV1ResourceBundleRouter.Handle("/orphaned",
+		adminAuthorizer.RequireAdmin(http.HandlerFunc(resourceHandler.ListBundleOrphaned))).Methods(http.MethodGet)
 	apiV1ResourceBundleRouter.HandleFunc("/{id}", resourceHandler.GetBundle).Methods(http.MethodGet)
+	apiV1ResourceBundleRouter.HandleFunc("/{id}", resourceHandler.PatchBundle).Methods(http.MethodPatch)
+	apiV1ResourceBundleRouter.HandleFunc("/{id}/revisions", resourceHandler.ListBundleRevisions).Methods(http.MethodGet)
+	apiV1ResourceBundleRouter.HandleFunc("/{id}/revisions/{version}/revert", resourceHandler.RevertBundle).Methods(http.MethodPost)
+	apiV1ResourceBundleRouter.HandleFunc("/{id}/manifestwork", resourceHandler.GetBundleManifestWork).Methods(http.MethodGet)
 	apiV1ResourceBundleRouter.Use(authMiddleware.AuthenticateAccountJWT)
 	apiV1ResourceBundleRouter.Use(authzMiddleware.AuthorizeApi)
 
+	//  /api/maestro/v1/resource-templates
+	apiV1ResourceTemplateRouter := apiV1Router.PathPrefix("/resource-templates").Subrouter()
+	apiV1ResourceTemplateRouter.HandleFunc("", resourceTemplateHandler.List).Methods(http.MethodGet)
+	apiV1ResourceTemplateRouter.HandleFunc("", resourceTemplateHandler.Create).Methods(http.MethodPost)
+	apiV1ResourceTemplateRouter.HandleFunc("/{id}", resourceTemplateHandler.Get).Methods(http.MethodGet)
+	apiV1ResourceTemplateRouter.HandleFunc("/{id}", resourceTemplateHandler.Delete).Methods(http.MethodDelete)
+	apiV1ResourceTemplateRouter.HandleFunc("/{id}/resources", resourceTemplateHandler.CreateResource).Methods(http.MethodPost)
+	apiV1ResourceTemplateRouter.Use(authMiddleware.AuthenticateAccountJWT)
+	apiV1ResourceTemplateRouter.Use(authzMiddleware.AuthorizeApi)
+
+	//  /api/maestro/v1/consumers:batchCreate
+	// the ":batchCreate" is not a sub path, so it is registered ahead of the consumers router
+	apiV1ConsumersBatchRouter := apiV1Router.Path("/consumers:batchCreate").Subrouter()
+	apiV1ConsumersBatchRouter.HandleFunc("", consumerHandler.BatchCreate).Methods(http.MethodPost)
+	apiV1ConsumersBatchRouter.Use(authMiddleware.AuthenticateAccountJWT)
+	apiV1ConsumersBatchRouter.Use(authzMiddleware.AuthorizeApi)
+
 	//  /api/maestro/v1/consumers
 	apiV1ConsumersRouter := apiV1Router.PathPrefix("/consumers").Subrouter()
 	apiV1ConsumersRouter.HandleFunc("", consumerHandler.List).Methods(http.MethodGet)
@@ -95,15 +178,59 @@ func (s *apiServer) routes() *mux.Router {
 	apiV1ConsumersRouter.HandleFunc("", consumerHandler.Create).Methods(http.MethodPost)
 	apiV1ConsumersRouter.HandleFunc("/{id}", consumerHandler.Patch).Methods(http.MethodPatch)
 	apiV1ConsumersRouter.HandleFunc("/{id}", consumerHandler.Delete).Methods(http.MethodDelete)
+	// the consumer tokens are the agent credentials, they are only managed by the admins
+	apiV1ConsumersRouter.Handle("/{id}/tokens",
+		adminAuthorizer.RequireAdmin(http.HandlerFunc(consumerTokenHandler.List))).Methods(http.MethodGet)
+	apiV1ConsumersRouter.Handle("/{id}/tokens",
+		adminAuthorizer.RequireAdmin(http.HandlerFunc(consumerTokenHandler.Create))).Methods(http.MethodPost)
+	apiV1ConsumersRouter.Handle("/{id}/tokens/{token_id}",
+		adminAuthorizer.RequireAdmin(http.HandlerFunc(consumerTokenHandler.Delete))).Methods(http.MethodDelete)
+	apiV1ConsumersRouter.Handle("/{name}/resources",
+		adminAuthorizer.RequireAdmin(http.HandlerFunc(consumerHandler.DeleteResources))).Methods(http.MethodDelete)
 	apiV1ConsumersRouter.Use(authMiddleware.AuthenticateAccountJWT)
 	apiV1ConsumersRouter.Use(authzMiddleware.AuthorizeApi)
 
+	//  /api/maestro/v1/consumer-groups
+	apiV1ConsumerGroupsRouter := apiV1Router.PathPrefix("/consumer-groups").Subrouter()
+	apiV1ConsumerGroupsRouter.HandleFunc("", consumerGroupHandler.List).Methods(http.MethodGet)
+	apiV1ConsumerGroupsRouter.HandleFunc("/{name}/consumers", consumerGroupHandler.ListConsumers).Methods(http.MethodGet)
+	apiV1ConsumerGroupsRouter.HandleFunc("/{name}/resync", consumerGroupHandler.Resync).Methods(http.MethodPost)
+	apiV1ConsumerGroupsRouter.Use(authMiddleware.AuthenticateAccountJWT)
+	apiV1ConsumerGroupsRouter.Use(authzMiddleware.AuthorizeApi)
+
+	//  /api/maestro/v1/broadcaster
+	apiV1BroadcasterRouter := apiV1Router.PathPrefix("/broadcaster").Subrouter()
+	apiV1BroadcasterRouter.HandleFunc("", broadcasterHandler.Get).Methods(http.MethodGet)
+	apiV1BroadcasterRouter.Handle("/suspend",
+		adminAuthorizer.RequireAdmin(http.HandlerFunc(broadcasterHandler.Suspend))).Methods(http.MethodPost)
+	apiV1BroadcasterRouter.Handle("/resume",
+		adminAuthorizer.RequireAdmin(http.HandlerFunc(broadcasterHandler.Resume))).Methods(http.MethodPost)
+	apiV1BroadcasterRouter.Use(authMiddleware.AuthenticateAccountJWT)
+	apiV1BroadcasterRouter.Use(authzMiddleware.AuthorizeApi)
+
+	//  /api/maestro/v1/status-resync
+	apiV1StatusResyncRouter := apiV1Router.PathPrefix("/status-resync").Subrouter()
+	apiV1StatusResyncRouter.HandleFunc("", statusResyncHandler.Get).Methods(http.MethodGet)
+	apiV1StatusResyncRouter.Handle("",
+		adminAuthorizer.RequireAdmin(http.HandlerFunc(statusResyncHandler.Start))).Methods(http.MethodPost)
+	apiV1StatusResyncRouter.Handle("",
+		adminAuthorizer.RequireAdmin(http.HandlerFunc(statusResyncHandler.Cancel))).Methods(http.MethodDelete)
+	apiV1StatusResyncRouter.Use(authMiddleware.AuthenticateAccountJWT)
+	apiV1StatusResyncRouter.Use(authzMiddleware.AuthorizeApi)
+
 	return mainRouter
 }
 
 func registerApiMiddleware(router *mux.Router) {
 	router.Use(MetricsMiddleware)
 
+	// the transaction is created with the request context, so it is canceled once the request is timed out
+	router.Use(handlers.TimeoutMiddleware(env().Config.HTTPServer.HandlerTimeout))
+
+	// reject the requests before a transaction is created on a half-migrated schema
+	router.Use(handlers.MigrationGateMiddleware(
+		env().Database.MigrationGate.Complete, migrationRetryDelay, env().Config.Database.GateReadsUntilMigrated))
+
 	router.Use(
 		func(next http.Handler) http.Handler {
 			return db.TransactionMiddleware(next, env().Database.SessionFactory)
@@ -0,0 +1,109 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"github.com/openshift-online/maestro/pkg/api"
+)
+
+// statusResendBatchSize is the number of resource statuses that are broadcast before the status resend pauses for
+// the batch interval.
+const statusResendBatchSize = 100
+
+// statusResender resends the statuses of all the resources of a source in the background, it responds the status
+// resync requests without the status hashes, so the Publish of a large source returns once the resend is started.
+// The resends are de-duplicated by source, a resync request of a source whose resend is in flight is dropped, as the
+// in-flight resend and the later status updates deliver the latest statuses. At most the concurrency number of the
+// sources are resent at the same time, and each resend pauses for the batch interval every statusResendBatchSize
+// statuses, so the broadcaster and the subscribers are not overwhelmed.
+type statusResender struct {
+	broadcast     func(res *api.Resource)
+	batchInterval time.Duration
+	slots         chan struct{}
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu       sync.Mutex
+	inflight map[string]bool
+}
+
+func newStatusResender(broadcast func(res *api.Resource), concurrency int, batchInterval time.Duration) *statusResender {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &statusResender{
+		broadcast:     broadcast,
+		batchInterval: batchInterval,
+		slots:         make(chan struct{}, concurrency),
+		ctx:           ctx,
+		cancel:        cancel,
+		inflight:      map[string]bool{},
+	}
+}
+
+// Resend resends the statuses of the resources of the source that are listed by the given function in the
+// background, false is returned if a resend of the source is already in flight.
+func (r *statusResender) Resend(source string, list func(ctx context.Context) (api.ResourceList, error)) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.inflight[source] {
+		klog.V(4).Infof("the status resend of source %s is in flight, skip the resync request", source)
+		return false
+	}
+	r.inflight[source] = true
+
+	r.wg.Add(1)
+	go r.run(source, list)
+	return true
+}
+
+// Stop cancels the in-flight resends and waits for them to stop.
+func (r *statusResender) Stop() {
+	r.cancel()
+	r.wg.Wait()
+}
+
+func (r *statusResender) run(source string, list func(ctx context.Context) (api.ResourceList, error)) {
+	defer r.wg.Done()
+	defer func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		delete(r.inflight, source)
+	}()
+
+	select {
+	case r.slots <- struct{}{}:
+		defer func() { <-r.slots }()
+	case <-r.ctx.Done():
+		return
+	}
+
+	objs, err := list(r.ctx)
+	if err != nil {
+		klog.Errorf("failed to list the resources to resend the statuses of source %s: %v", source, err)
+		return
+	}
+
+	for i, obj := range objs {
+		if i > 0 && i%statusResendBatchSize == 0 {
+			select {
+			case <-r.ctx.Done():
+			case <-time.After(r.batchInterval):
+			}
+		}
+		if r.ctx.Err() != nil {
+			klog.Infof("the status resend of source %s is stopped, %d of %d statuses are resent", source, i, len(objs))
+			return
+		}
+		r.broadcast(obj)
+	}
+	klog.V(4).Infof("resent the statuses of %d resources of source %s", len(objs), source)
+}
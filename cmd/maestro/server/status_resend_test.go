@@ -0,0 +1,81 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openshift-online/maestro/pkg/api"
+)
+
+func TestStatusResender(t *testing.T) {
+	var mu sync.Mutex
+	resent := []string{}
+	resender := newStatusResender(func(res *api.Resource) {
+		mu.Lock()
+		defer mu.Unlock()
+		resent = append(resent, res.ID)
+	}, 1, time.Millisecond)
+	defer resender.Stop()
+
+	// the resend of the source is blocked until the resources are listed
+	listing := make(chan struct{})
+	listed := make(chan struct{})
+	objs := api.ResourceList{}
+	for i := 0; i < statusResendBatchSize+1; i++ {
+		objs = append(objs, &api.Resource{Meta: api.Meta{ID: fmt.Sprintf("%d", i)}})
+	}
+	if !resender.Resend("source1", func(ctx context.Context) (api.ResourceList, error) {
+		close(listing)
+		<-listed
+		return objs, nil
+	}) {
+		t.Fatalf("expected the resend of source1 is started")
+	}
+	<-listing
+
+	// the resync requests of the in-flight source are dropped
+	if resender.Resend("source1", func(ctx context.Context) (api.ResourceList, error) {
+		return nil, fmt.Errorf("unexpected resend")
+	}) {
+		t.Errorf("expected the resend of source1 is de-duplicated")
+	}
+
+	// the other sources wait for the concurrency slot
+	if !resender.Resend("source2", func(ctx context.Context) (api.ResourceList, error) {
+		return api.ResourceList{{Meta: api.Meta{ID: "source2"}}}, nil
+	}) {
+		t.Errorf("expected the resend of source2 is started")
+	}
+	close(listed)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		mu.Lock()
+		count := len(resent)
+		mu.Unlock()
+		if count == len(objs)+1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d resent statuses, but got %d", len(objs)+1, count)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if resent[len(resent)-1] != "source2" {
+		t.Errorf("expected source2 is resent after source1 with the concurrency 1, but got %v", resent)
+	}
+
+	// a source can be resent again once its resend is done
+	resender.mu.Lock()
+	inflight := resender.inflight["source1"]
+	resender.mu.Unlock()
+	if inflight {
+		t.Errorf("expected the resend of source1 is not in flight")
+	}
+}
@@ -0,0 +1,127 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"sort"
+	"strings"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// ContentTypeProtobuf is the data content type of the CloudEvents whose data is a serialized google.protobuf.Struct
+// of the JSON data, so the data can be (un)marshaled without the generated types of the work payloads.
+const ContentTypeProtobuf = "application/protobuf"
+
+// DataCodec (un)marshals the JSON object data of a CloudEvent from/to the binary format of a data content type.
+type DataCodec interface {
+	Marshal(data map[string]interface{}) ([]byte, error)
+	Unmarshal(data []byte) (map[string]interface{}, error)
+}
+
+// dataCodecs are the codecs of the supported non-JSON data content types, JSON is the default and is not transcoded.
+var dataCodecs = map[string]DataCodec{
+	ContentTypeProtobuf:      protobufDataCodec{},
+	"application/x-protobuf": protobufDataCodec{},
+}
+
+// SupportedDataContentTypes returns the sorted data content types that can be decoded and encoded.
+func SupportedDataContentTypes() []string {
+	contentTypes := []string{cloudevents.ApplicationJSON}
+	for contentType := range dataCodecs {
+		contentTypes = append(contentTypes, contentType)
+	}
+	sort.Strings(contentTypes)
+	return contentTypes
+}
+
+// ValidateDataContentType returns an error if the data content type is not supported, an empty content type is
+// treated as JSON.
+func ValidateDataContentType(contentType string) error {
+	_, err := lookupDataCodec(contentType)
+	return err
+}
+
+// DecodeCloudEventData transcodes the data of the CloudEvent to JSON according to its data content type, so the
+// resources are always kept with the JSON data regardless of the format that the sources or agents send. The event
+// is unchanged if its data is already JSON.
+func DecodeCloudEventData(evt *cloudevents.Event) error {
+	dataCodec, err := lookupDataCodec(evt.DataContentType())
+	if err != nil {
+		return err
+	}
+	if dataCodec == nil || len(evt.Data()) == 0 {
+		return nil
+	}
+
+	data, err := dataCodec.Unmarshal(evt.Data())
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal %s data: %v", evt.DataContentType(), err)
+	}
+	return evt.SetData(cloudevents.ApplicationJSON, data)
+}
+
+// EncodeCloudEventData transcodes the JSON data of the CloudEvent to the given data content type, it is the reverse
+// of DecodeCloudEventData. The event is unchanged if the content type is empty or JSON.
+func EncodeCloudEventData(evt *cloudevents.Event, contentType string) error {
+	dataCodec, err := lookupDataCodec(contentType)
+	if err != nil {
+		return err
+	}
+	if dataCodec == nil || len(evt.Data()) == 0 {
+		return nil
+	}
+
+	data := map[string]interface{}{}
+	if err := json.Unmarshal(evt.Data(), &data); err != nil {
+		return fmt.Errorf("failed to unmarshal JSON data: %v", err)
+	}
+	encoded, err := dataCodec.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s data: %v", contentType, err)
+	}
+	return evt.SetData(contentType, encoded)
+}
+
+// lookupDataCodec returns the codec of the data content type, a nil codec is returned for JSON.
+func lookupDataCodec(contentType string) (DataCodec, error) {
+	if len(contentType) == 0 {
+		return nil, nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, fmt.Errorf("invalid data content type %q: %v", contentType, err)
+	}
+	if mediaType == cloudevents.ApplicationJSON || mediaType == "text/json" || strings.HasSuffix(mediaType, "+json") {
+		return nil, nil
+	}
+	if dataCodec, ok := dataCodecs[mediaType]; ok {
+		return dataCodec, nil
+	}
+	return nil, fmt.Errorf("unsupported data content type %q, the supported data content types are %s",
+		contentType, strings.Join(SupportedDataContentTypes(), ", "))
+}
+
+// protobufDataCodec (un)marshals the JSON data as a google.protobuf.Struct, the numbers are kept as doubles, as they
+// are in JSON.
+type protobufDataCodec struct{}
+
+func (protobufDataCodec) Marshal(data map[string]interface{}) ([]byte, error) {
+	s, err := structpb.NewStruct(data)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(s)
+}
+
+func (protobufDataCodec) Unmarshal(data []byte) (map[string]interface{}, error) {
+	s := &structpb.Struct{}
+	if err := proto.Unmarshal(data, s); err != nil {
+		return nil, err
+	}
+	return s.AsMap(), nil
+}
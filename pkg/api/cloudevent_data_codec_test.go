@@ -0,0 +1,94 @@
+package api
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+)
+
+func newDataEvent(t *testing.T) *cloudevents.Event {
+	evt := cloudevents.NewEvent()
+	evt.SetID("1")
+	evt.SetSource("test")
+	evt.SetType("test")
+	data := map[string]interface{}{
+		"manifests": []interface{}{
+			map[string]interface{}{"kind": "ConfigMap", "data": map[string]interface{}{"test": "test"}},
+		},
+		"replicas": float64(3),
+	}
+	if err := evt.SetData(cloudevents.ApplicationJSON, data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return &evt
+}
+
+func TestCloudEventDataRoundTrip(t *testing.T) {
+	for _, contentType := range []string{"", cloudevents.ApplicationJSON, "application/json; charset=utf-8"} {
+		evt := newDataEvent(t)
+		expected := string(evt.Data())
+		if err := EncodeCloudEventData(evt, contentType); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if evt.DataContentType() != cloudevents.ApplicationJSON || string(evt.Data()) != expected {
+			t.Errorf("expected the JSON data is unchanged for %q, but got %s: %s", contentType, evt.DataContentType(), evt.Data())
+		}
+	}
+
+	evt := newDataEvent(t)
+	expected := map[string]interface{}{}
+	if err := evt.DataAs(&expected); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := EncodeCloudEventData(evt, ContentTypeProtobuf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if evt.DataContentType() != ContentTypeProtobuf {
+		t.Errorf("expected content type %s, but got %s", ContentTypeProtobuf, evt.DataContentType())
+	}
+
+	if err := DecodeCloudEventData(evt); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if evt.DataContentType() != cloudevents.ApplicationJSON {
+		t.Errorf("expected content type %s, but got %s", cloudevents.ApplicationJSON, evt.DataContentType())
+	}
+	actual := map[string]interface{}{}
+	if err := evt.DataAs(&actual); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected %v, but got %v", expected, actual)
+	}
+}
+
+func TestCloudEventDataInvalid(t *testing.T) {
+	evt := newDataEvent(t)
+	if err := EncodeCloudEventData(evt, "avro/binary"); err == nil || !strings.Contains(err.Error(), "unsupported data content type") {
+		t.Errorf("expected unsupported content type error, but got: %v", err)
+	}
+
+	if err := evt.SetData("avro/binary", []byte("test")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := DecodeCloudEventData(evt); err == nil || !strings.Contains(err.Error(), "unsupported data content type") {
+		t.Errorf("expected unsupported content type error, but got: %v", err)
+	}
+
+	if err := evt.SetData(ContentTypeProtobuf, []byte("invalid")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := DecodeCloudEventData(evt); err == nil {
+		t.Errorf("expected unmarshal error, but got nil")
+	}
+
+	if err := ValidateDataContentType("application/x-protobuf"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := ValidateDataContentType(";"); err == nil {
+		t.Errorf("expected invalid content type error, but got nil")
+	}
+}
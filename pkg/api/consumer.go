@@ -2,7 +2,10 @@ package api
 
 import (
 	"github.com/openshift-online/maestro/pkg/db"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
+
+	workv1 "open-cluster-management.io/api/work/v1"
 )
 
 type Consumer struct {
@@ -15,6 +18,12 @@ type Consumer struct {
 	// Cannot be updated.
 	Name   string
 	Labels *db.StringMap
+	// FeedbackRules are the status feedback rules collected from all the resources of the consumer, they are merged
+	// with the status feedback rules of each resource, see MergeFeedbackRules.
+	FeedbackRules datatypes.JSONSlice[workv1.FeedbackRule]
+	// DeletePropagationPolicy is the default delete propagation policy of the resources of the consumer, it applies
+	// to the resources without their own delete option, see ResolveDeleteOption.
+	DeletePropagationPolicy string
 }
 
 type ConsumerList []*Consumer
@@ -0,0 +1,39 @@
+package api
+
+import (
+	"sort"
+	"strings"
+)
+
+// ConsumerGroupLabelPrefix is the prefix of the consumer labels that define the consumer groups, a consumer belongs to
+// the group <name> if it has the label group.maestro.open-cluster-management.io/<name>, the label value is ignored
+// (conventionally "true"). A consumer can belong to zero or more groups.
+const ConsumerGroupLabelPrefix = "group.maestro.open-cluster-management.io/"
+
+// ConsumerGroup is a group of consumers, it is derived from the consumer labels and is not stored.
+type ConsumerGroup struct {
+	Name          string
+	ConsumerCount int
+}
+
+type ConsumerGroupList []*ConsumerGroup
+
+// ConsumerGroupLabel returns the label key of the given consumer group.
+func ConsumerGroupLabel(group string) string {
+	return ConsumerGroupLabelPrefix + group
+}
+
+// Groups returns the sorted names of the groups that the consumer belongs to.
+func (d *Consumer) Groups() []string {
+	groups := []string{}
+	if d.Labels == nil {
+		return groups
+	}
+	for key := range *d.Labels {
+		if group := strings.TrimPrefix(key, ConsumerGroupLabelPrefix); group != key && len(group) != 0 {
+			groups = append(groups, group)
+		}
+	}
+	sort.Strings(groups)
+	return groups
+}
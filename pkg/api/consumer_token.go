@@ -0,0 +1,38 @@
+package api
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ConsumerToken is the metadata of a token scoped to a single consumer. The token value itself is never stored,
+// only its hash is kept to validate the token. The record is kept after the token is revoked for audit.
+type ConsumerToken struct {
+	Meta
+	ConsumerID   string
+	ConsumerName string
+	// TokenHash is the SHA-256 hash of the token.
+	TokenHash string
+	ExpiresAt time.Time
+	RevokedAt *time.Time
+	// CreatedBy is the user who issued the token.
+	CreatedBy string
+}
+
+type ConsumerTokenList []*ConsumerToken
+
+func (t *ConsumerToken) BeforeCreate(tx *gorm.DB) error {
+	t.ID = NewID()
+	return nil
+}
+
+// IsRevoked returns true if the token has been revoked.
+func (t *ConsumerToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+// IsExpired returns true if the token is expired at the given time.
+func (t *ConsumerToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"fmt"
+
+	"gorm.io/datatypes"
+
+	workv1 "open-cluster-management.io/api/work/v1"
+	workpayload "open-cluster-management.io/sdk-go/pkg/cloudevents/work/payload"
+)
+
+// DefaultDeletePropagationPolicy is the global default delete propagation policy of the resources.
+const DefaultDeletePropagationPolicy = workv1.DeletePropagationPolicyTypeForeground
+
+var supportedConsumerDeletePropagationPolicies = []workv1.DeletePropagationPolicyType{
+	workv1.DeletePropagationPolicyTypeForeground,
+	workv1.DeletePropagationPolicyTypeOrphan,
+}
+
+// ValidateConsumerDeletePropagationPolicy validates the default delete propagation policy of a consumer is Foreground
+// or Orphan, an empty policy is allowed.
+func ValidateConsumerDeletePropagationPolicy(policy string) error {
+	if len(policy) == 0 {
+		return nil
+	}
+	for _, supported := range supportedConsumerDeletePropagationPolicies {
+		if workv1.DeletePropagationPolicyType(policy) == supported {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported delete propagation policy %s, the supported policies are %v",
+		policy, supportedConsumerDeletePropagationPolicies)
+}
+
+// ResolveDeleteOption resolves the delete option of a resource on the consumer, the precedence is:
+//  1. the delete option of the resource,
+//  2. the default delete propagation policy of the consumer,
+//  3. the global default delete propagation policy, see DefaultDeletePropagationPolicy.
+func ResolveDeleteOption(resourceOption *workv1.DeleteOption, consumerPolicy string) *workv1.DeleteOption {
+	if resourceOption != nil {
+		return resourceOption
+	}
+	if len(consumerPolicy) != 0 {
+		return &workv1.DeleteOption{PropagationPolicy: workv1.DeletePropagationPolicyType(consumerPolicy)}
+	}
+	return &workv1.DeleteOption{PropagationPolicy: DefaultDeletePropagationPolicy}
+}
+
+// ResolvePayloadDeleteOption sets the delete option resolved by ResolveDeleteOption in the CloudEvent JSONMap
+// representation of a resource manifest (or manifest bundle) that has no delete option of its own, the manifests and
+// the other options are not changed. The delete option of the payload is replaced if reresolve is true, i.e. it was
+// resolved before rather than set by the source. It returns false with the payload as it is if the payload has its own
+// delete option.
+func ResolvePayloadDeleteOption(resourceType ResourceType, payload datatypes.JSONMap,
+	consumerPolicy string, reresolve bool) (datatypes.JSONMap, bool, error) {
+	evt, err := JSONMAPToCloudEvent(payload)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to convert resource manifest to cloudevent: %v", err)
+	}
+
+	var eventPayload interface{}
+	if resourceType == ResourceTypeBundle {
+		bundle := &workpayload.ManifestBundle{}
+		if err := evt.DataAs(bundle); err != nil {
+			return nil, false, fmt.Errorf("failed to decode cloudevent payload as resource manifest bundle: %v", err)
+		}
+		if bundle.DeleteOption != nil && !reresolve {
+			return payload, false, nil
+		}
+		bundle.DeleteOption = ResolveDeleteOption(nil, consumerPolicy)
+		eventPayload = bundle
+	} else {
+		manifest := &workpayload.Manifest{}
+		if err := evt.DataAs(manifest); err != nil {
+			return nil, false, fmt.Errorf("failed to decode cloudevent payload as resource manifest: %v", err)
+		}
+		if manifest.DeleteOption != nil && !reresolve {
+			return payload, false, nil
+		}
+		manifest.DeleteOption = ResolveDeleteOption(nil, consumerPolicy)
+		eventPayload = manifest
+	}
+
+	if err := evt.SetData(evt.DataContentType(), eventPayload); err != nil {
+		return nil, false, fmt.Errorf("failed to set cloud event data: %v", err)
+	}
+
+	resolved, err := CloudEventToJSONMap(evt)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to convert cloudevent to resource manifest: %v", err)
+	}
+	return resolved, true, nil
+}
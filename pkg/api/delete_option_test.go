@@ -0,0 +1,144 @@
+package api
+
+import (
+	"testing"
+
+	workv1 "open-cluster-management.io/api/work/v1"
+	workpayload "open-cluster-management.io/sdk-go/pkg/cloudevents/work/payload"
+)
+
+func TestResolveDeleteOption(t *testing.T) {
+	orphan := &workv1.DeleteOption{PropagationPolicy: workv1.DeletePropagationPolicyTypeOrphan}
+	foreground := &workv1.DeleteOption{PropagationPolicy: workv1.DeletePropagationPolicyTypeForeground}
+
+	cases := []struct {
+		name           string
+		resourceOption *workv1.DeleteOption
+		consumerPolicy string
+		expected       workv1.DeletePropagationPolicyType
+	}{
+		{
+			name:     "the global default",
+			expected: workv1.DeletePropagationPolicyTypeForeground,
+		},
+		{
+			name:           "the consumer policy overrides the global default",
+			consumerPolicy: "Orphan",
+			expected:       workv1.DeletePropagationPolicyTypeOrphan,
+		},
+		{
+			name:           "the resource option overrides the consumer policy",
+			resourceOption: foreground,
+			consumerPolicy: "Orphan",
+			expected:       workv1.DeletePropagationPolicyTypeForeground,
+		},
+		{
+			name:           "the resource option overrides the global default",
+			resourceOption: orphan,
+			expected:       workv1.DeletePropagationPolicyTypeOrphan,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			resolved := ResolveDeleteOption(c.resourceOption, c.consumerPolicy)
+			if resolved.PropagationPolicy != c.expected {
+				t.Errorf("expected %s, but got %s", c.expected, resolved.PropagationPolicy)
+			}
+		})
+	}
+}
+
+func TestResolvePayloadDeleteOption(t *testing.T) {
+	manifest := map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": "test", "namespace": "default"},
+	}
+
+	// the resource without its own delete option gets the consumer policy
+	payload, err := EncodeManifest(manifest, nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resolvedPayload, resolved, err := ResolvePayloadDeleteOption(ResourceTypeSingle, payload, "Orphan", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resolved {
+		t.Errorf("expected the delete option is resolved")
+	}
+	_, deleteOption, _, err := DecodeManifest(resolvedPayload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deleteOption["propagationPolicy"] != "Orphan" {
+		t.Errorf("expected the consumer policy Orphan, but got %v", deleteOption)
+	}
+
+	// the delete option resolved before follows the consumer policy
+	reresolvedPayload, resolved, err := ResolvePayloadDeleteOption(ResourceTypeSingle, resolvedPayload, "Foreground", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resolved {
+		t.Errorf("expected the delete option is re-resolved")
+	}
+	_, deleteOption, _, err = DecodeManifest(reresolvedPayload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deleteOption["propagationPolicy"] != "Foreground" {
+		t.Errorf("expected the consumer policy Foreground, but got %v", deleteOption)
+	}
+
+	// the resource with its own delete option keeps it
+	payload, err = EncodeManifest(manifest, map[string]interface{}{"propagationPolicy": "Foreground"}, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, resolved, err = ResolvePayloadDeleteOption(ResourceTypeSingle, payload, "Orphan", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resolved {
+		t.Errorf("expected the delete option of the resource is kept")
+	}
+
+	// the bundle without its own delete option gets the global default
+	bundle := newJSONMap(t, "{\"specversion\":\"1.0\",\"id\":\"266a8cd2-2fab-4e89-9bf0-a56425ebcdf8\",\"source\":\"grpc\",\"type\":\"io.open-cluster-management.works.v1alpha1.manifestbundles.spec.create_request\",\"datacontenttype\":\"application/json\",\"data\":{\"manifests\":[{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"nginx\",\"namespace\":\"default\"}}]}}")
+	resolvedBundle, resolved, err := ResolvePayloadDeleteOption(ResourceTypeBundle, bundle, "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resolved {
+		t.Errorf("expected the delete option is resolved")
+	}
+	evt, err := JSONMAPToCloudEvent(resolvedBundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifestBundle := &workpayload.ManifestBundle{}
+	if err := evt.DataAs(manifestBundle); err != nil {
+		t.Fatal(err)
+	}
+	if manifestBundle.DeleteOption == nil || manifestBundle.DeleteOption.PropagationPolicy != DefaultDeletePropagationPolicy {
+		t.Errorf("expected the global default %s, but got %v", DefaultDeletePropagationPolicy, manifestBundle.DeleteOption)
+	}
+	if len(manifestBundle.Manifests) != 1 {
+		t.Errorf("expected the manifests are kept, but got %v", manifestBundle.Manifests)
+	}
+}
+
+func TestValidateConsumerDeletePropagationPolicy(t *testing.T) {
+	for _, policy := range []string{"", "Foreground", "Orphan"} {
+		if err := ValidateConsumerDeletePropagationPolicy(policy); err != nil {
+			t.Errorf("unexpected error for %q: %v", policy, err)
+		}
+	}
+	for _, policy := range []string{"SelectivelyOrphan", "Background"} {
+		if err := ValidateConsumerDeletePropagationPolicy(policy); err == nil {
+			t.Errorf("expected an error for %q", policy)
+		}
+	}
+}
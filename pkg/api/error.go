@@ -8,57 +8,37 @@ import (
 
 	"github.com/getsentry/sentry-go"
 	"github.com/openshift-online/maestro/pkg/errors"
+	"github.com/openshift-online/maestro/pkg/logger"
 	"k8s.io/klog/v2"
 )
 
 // SendNotFound sends a 404 response with some details about the non existing resource.
 func SendNotFound(w http.ResponseWriter, r *http.Request) {
-	// Set the content type:
-	w.Header().Set("Content-Type", "application/json")
-
-	// Prepare the body:
-	id := "404"
-	reason := fmt.Sprintf(
-		"The requested resource '%s' doesn't exist",
-		r.URL.Path,
-	)
-	body := Error{
-		Type:   ErrorType,
-		ID:     id,
-		HREF:   "/api/maestro/v1/errors/" + id,
-		Code:   "maestro-" + id,
-		Reason: reason,
-	}
-	data, err := json.Marshal(body)
-	if err != nil {
-		SendPanic(w, r)
-		return
-	}
+	sendError(w, r, errors.NotFound("The requested resource '%s' doesn't exist", r.URL.Path))
+}
 
-	// Send the response:
-	w.WriteHeader(http.StatusNotFound)
-	_, err = w.Write(data)
-	if err != nil {
-		err = fmt.Errorf("cannot send response body for request '%s'", r.URL.Path)
-		klog.Error(err)
-		sentry.CaptureException(err)
-		return
-	}
+// SendMethodNotAllowed sends a 405 response when the requested resource doesn't support the request method.
+func SendMethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	sendError(w, r, errors.NotImplemented("The method '%s' is not allowed for the requested resource '%s'", r.Method, r.URL.Path))
 }
 
 func SendUnauthorized(w http.ResponseWriter, r *http.Request, message string) {
+	sendError(w, r, errors.Unauthorized(message))
+}
+
+// sendError sends the error response with the same body as the API handlers.
+func sendError(w http.ResponseWriter, r *http.Request, serviceErr *errors.ServiceError) {
 	w.Header().Set("Content-Type", "application/json")
 
 	// Prepare the body:
-	apiError := errors.Unauthorized(message)
-	data, err := json.Marshal(apiError)
+	data, err := json.Marshal(serviceErr.AsOpenapiError(logger.GetOperationID(r.Context())))
 	if err != nil {
 		SendPanic(w, r)
 		return
 	}
 
 	// Send the response:
-	w.WriteHeader(http.StatusUnauthorized)
+	w.WriteHeader(serviceErr.HttpCode)
 	_, err = w.Write(data)
 	if err != nil {
 		err = fmt.Errorf("cannot send response body for request '%s'", r.URL.Path)
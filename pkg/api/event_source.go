@@ -0,0 +1,28 @@
+package api
+
+import (
+	"sync"
+
+	"github.com/openshift-online/maestro/pkg/constants"
+)
+
+var (
+	eventSourceLock sync.RWMutex
+	eventSource     = constants.DefaultSourceID
+)
+
+// EventSource returns the source of the events that maestro originates, e.g. the resource spec events sent to the
+// agents, so the downstream systems can attribute the events to a maestro deployment.
+func EventSource() string {
+	eventSourceLock.RLock()
+	defer eventSourceLock.RUnlock()
+	return eventSource
+}
+
+// SetEventSource sets the source of the events that maestro originates, it is set once from the config when the
+// environment is initialized, the source must be validated as a CloudEvent source before.
+func SetEventSource(source string) {
+	eventSourceLock.Lock()
+	defer eventSourceLock.Unlock()
+	eventSource = source
+}
@@ -22,6 +22,170 @@ import (
 // DefaultApiService DefaultApi service
 type DefaultApiService service
 
+type ApiApiMaestroV1ConsumersBatchCreatePostRequest struct {
+	ctx                        context.Context
+	ApiService                 *DefaultApiService
+	consumerBatchCreateRequest *ConsumerBatchCreateRequest
+}
+
+// Consumers to create
+func (r ApiApiMaestroV1ConsumersBatchCreatePostRequest) ConsumerBatchCreateRequest(consumerBatchCreateRequest ConsumerBatchCreateRequest) ApiApiMaestroV1ConsumersBatchCreatePostRequest {
+	r.consumerBatchCreateRequest = &consumerBatchCreateRequest
+	return r
+}
+
+func (r ApiApiMaestroV1ConsumersBatchCreatePostRequest) Execute() (*ConsumerBatchCreateResponse, *http.Response, error) {
+	return r.ApiService.ApiMaestroV1ConsumersBatchCreatePostExecute(r)
+}
+
+/*
+ApiMaestroV1ConsumersBatchCreatePost Create consumers in batch
+
+	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+	@return ApiApiMaestroV1ConsumersBatchCreatePostRequest
+*/
+func (a *DefaultApiService) ApiMaestroV1ConsumersBatchCreatePost(ctx context.Context) ApiApiMaestroV1ConsumersBatchCreatePostRequest {
+	return ApiApiMaestroV1ConsumersBatchCreatePostRequest{
+		ApiService: a,
+		ctx:        ctx,
+	}
+}
+
+// Execute executes the request
+//
+//	@return ConsumerBatchCreateResponse
+func (a *DefaultApiService) ApiMaestroV1ConsumersBatchCreatePostExecute(r ApiApiMaestroV1ConsumersBatchCreatePostRequest) (*ConsumerBatchCreateResponse, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodPost
+		localVarPostBody    interface{}
+		formFiles           []formFile
+		localVarReturnValue *ConsumerBatchCreateResponse
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultApiService.ApiMaestroV1ConsumersBatchCreatePost")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/api/maestro/v1/consumers:batchCreate"
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+	if r.consumerBatchCreateRequest == nil {
+		return localVarReturnValue, nil, reportError("consumerBatchCreateRequest is required and must be specified")
+	}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{"application/json"}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	// body params
+	localVarPostBody = r.consumerBatchCreateRequest
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 400 {
+			var v Error
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 401 {
+			var v Error
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 403 {
+			var v Error
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 409 {
+			var v Error
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 500 {
+			var v Error
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
 type ApiApiMaestroV1ConsumersGetRequest struct {
 	ctx        context.Context
 	ApiService *DefaultApiService
@@ -842,6 +1006,7 @@ type ApiApiMaestroV1ResourceBundlesGetRequest struct {
 	search     *string
 	orderBy    *string
 	fields     *string
+	condition  *string
 }
 
 // Page number of record list when record list exceeds specified page size
@@ -874,6 +1039,12 @@ func (r ApiApiMaestroV1ResourceBundlesGetRequest) Fields(fields string) ApiApiMa
 	return r
 }
 
+// Filters the resources by their reconcile conditions, the value is a comma-separated list of &#x60;&lt;type&gt;&#x3D;&lt;status&gt;&#x60; pairs, e.g. &#x60;Degraded&#x3D;True&#x60;. Only the resources that have all the conditions are returned, the resources without a status are never matched.
+func (r ApiApiMaestroV1ResourceBundlesGetRequest) Condition(condition string) ApiApiMaestroV1ResourceBundlesGetRequest {
+	r.condition = &condition
+	return r
+}
+
 func (r ApiApiMaestroV1ResourceBundlesGetRequest) Execute() (*ResourceBundleList, *http.Response, error) {
 	return r.ApiService.ApiMaestroV1ResourceBundlesGetExecute(r)
 }
@@ -928,6 +1099,9 @@ func (a *DefaultApiService) ApiMaestroV1ResourceBundlesGetExecute(r ApiApiMaestr
 	if r.fields != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "fields", r.fields, "")
 	}
+	if r.condition != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "condition", r.condition, "")
+	}
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{}
 
@@ -1167,6 +1341,7 @@ type ApiApiMaestroV1ResourcesGetRequest struct {
 	search     *string
 	orderBy    *string
 	fields     *string
+	condition  *string
 }
 
 // Page number of record list when record list exceeds specified page size
@@ -1199,6 +1374,12 @@ func (r ApiApiMaestroV1ResourcesGetRequest) Fields(fields string) ApiApiMaestroV
 	return r
 }
 
+// Filters the resources by their reconcile conditions, the value is a comma-separated list of &#x60;&lt;type&gt;&#x3D;&lt;status&gt;&#x60; pairs, e.g. &#x60;Degraded&#x3D;True&#x60;. Only the resources that have all the conditions are returned, the resources without a status are never matched.
+func (r ApiApiMaestroV1ResourcesGetRequest) Condition(condition string) ApiApiMaestroV1ResourcesGetRequest {
+	r.condition = &condition
+	return r
+}
+
 func (r ApiApiMaestroV1ResourcesGetRequest) Execute() (*ResourceList, *http.Response, error) {
 	return r.ApiService.ApiMaestroV1ResourcesGetExecute(r)
 }
@@ -1253,6 +1434,9 @@ func (a *DefaultApiService) ApiMaestroV1ResourcesGetExecute(r ApiApiMaestroV1Res
 	if r.fields != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "fields", r.fields, "")
 	}
+	if r.condition != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "condition", r.condition, "")
+	}
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{}
 
@@ -27,6 +27,10 @@ type Consumer struct {
 	Labels    *map[string]string `json:"labels,omitempty"`
 	CreatedAt *time.Time         `json:"created_at,omitempty"`
 	UpdatedAt *time.Time         `json:"updated_at,omitempty"`
+	// The status feedback rules merged into the manifests of all the resources of the consumer
+	FeedbackRules []map[string]interface{} `json:"feedback_rules,omitempty"`
+	// The default delete propagation policy of the resources of the consumer, Foreground or Orphan, it applies to the resources without their own delete option
+	DeletePropagationPolicy *string `json:"delete_propagation_policy,omitempty"`
 }
 
 // NewConsumer instantiates a new Consumer object
@@ -270,6 +274,70 @@ func (o *Consumer) SetUpdatedAt(v time.Time) {
 	o.UpdatedAt = &v
 }
 
+// GetFeedbackRules returns the FeedbackRules field value if set, zero value otherwise.
+func (o *Consumer) GetFeedbackRules() []map[string]interface{} {
+	if o == nil || IsNil(o.FeedbackRules) {
+		var ret []map[string]interface{}
+		return ret
+	}
+	return o.FeedbackRules
+}
+
+// GetFeedbackRulesOk returns a tuple with the FeedbackRules field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *Consumer) GetFeedbackRulesOk() ([]map[string]interface{}, bool) {
+	if o == nil || IsNil(o.FeedbackRules) {
+		return nil, false
+	}
+	return o.FeedbackRules, true
+}
+
+// HasFeedbackRules returns a boolean if a field has been set.
+func (o *Consumer) HasFeedbackRules() bool {
+	if o != nil && !IsNil(o.FeedbackRules) {
+		return true
+	}
+
+	return false
+}
+
+// SetFeedbackRules gets a reference to the given []map[string]interface{} and assigns it to the FeedbackRules field.
+func (o *Consumer) SetFeedbackRules(v []map[string]interface{}) {
+	o.FeedbackRules = v
+}
+
+// GetDeletePropagationPolicy returns the DeletePropagationPolicy field value if set, zero value otherwise.
+func (o *Consumer) GetDeletePropagationPolicy() string {
+	if o == nil || IsNil(o.DeletePropagationPolicy) {
+		var ret string
+		return ret
+	}
+	return *o.DeletePropagationPolicy
+}
+
+// GetDeletePropagationPolicyOk returns a tuple with the DeletePropagationPolicy field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *Consumer) GetDeletePropagationPolicyOk() (*string, bool) {
+	if o == nil || IsNil(o.DeletePropagationPolicy) {
+		return nil, false
+	}
+	return o.DeletePropagationPolicy, true
+}
+
+// HasDeletePropagationPolicy returns a boolean if a field has been set.
+func (o *Consumer) HasDeletePropagationPolicy() bool {
+	if o != nil && !IsNil(o.DeletePropagationPolicy) {
+		return true
+	}
+
+	return false
+}
+
+// SetDeletePropagationPolicy gets a reference to the given string and assigns it to the DeletePropagationPolicy field.
+func (o *Consumer) SetDeletePropagationPolicy(v string) {
+	o.DeletePropagationPolicy = &v
+}
+
 func (o Consumer) MarshalJSON() ([]byte, error) {
 	toSerialize, err := o.ToMap()
 	if err != nil {
@@ -301,6 +369,12 @@ func (o Consumer) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.UpdatedAt) {
 		toSerialize["updated_at"] = o.UpdatedAt
 	}
+	if !IsNil(o.FeedbackRules) {
+		toSerialize["feedback_rules"] = o.FeedbackRules
+	}
+	if !IsNil(o.DeletePropagationPolicy) {
+		toSerialize["delete_propagation_policy"] = o.DeletePropagationPolicy
+	}
 	return toSerialize, nil
 }
 
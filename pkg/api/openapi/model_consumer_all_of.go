@@ -24,6 +24,8 @@ type ConsumerAllOf struct {
 	Labels    *map[string]string `json:"labels,omitempty"`
 	CreatedAt *time.Time         `json:"created_at,omitempty"`
 	UpdatedAt *time.Time         `json:"updated_at,omitempty"`
+	// The status feedback rules merged into the manifests of all the resources of the consumer
+	FeedbackRules []map[string]interface{} `json:"feedback_rules,omitempty"`
 }
 
 // NewConsumerAllOf instantiates a new ConsumerAllOf object
@@ -171,6 +173,38 @@ func (o *ConsumerAllOf) SetUpdatedAt(v time.Time) {
 	o.UpdatedAt = &v
 }
 
+// GetFeedbackRules returns the FeedbackRules field value if set, zero value otherwise.
+func (o *ConsumerAllOf) GetFeedbackRules() []map[string]interface{} {
+	if o == nil || IsNil(o.FeedbackRules) {
+		var ret []map[string]interface{}
+		return ret
+	}
+	return o.FeedbackRules
+}
+
+// GetFeedbackRulesOk returns a tuple with the FeedbackRules field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ConsumerAllOf) GetFeedbackRulesOk() ([]map[string]interface{}, bool) {
+	if o == nil || IsNil(o.FeedbackRules) {
+		return nil, false
+	}
+	return o.FeedbackRules, true
+}
+
+// HasFeedbackRules returns a boolean if a field has been set.
+func (o *ConsumerAllOf) HasFeedbackRules() bool {
+	if o != nil && !IsNil(o.FeedbackRules) {
+		return true
+	}
+
+	return false
+}
+
+// SetFeedbackRules gets a reference to the given []map[string]interface{} and assigns it to the FeedbackRules field.
+func (o *ConsumerAllOf) SetFeedbackRules(v []map[string]interface{}) {
+	o.FeedbackRules = v
+}
+
 func (o ConsumerAllOf) MarshalJSON() ([]byte, error) {
 	toSerialize, err := o.ToMap()
 	if err != nil {
@@ -193,6 +227,9 @@ func (o ConsumerAllOf) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.UpdatedAt) {
 		toSerialize["updated_at"] = o.UpdatedAt
 	}
+	if !IsNil(o.FeedbackRules) {
+		toSerialize["feedback_rules"] = o.FeedbackRules
+	}
 	return toSerialize, nil
 }
 
@@ -20,6 +20,14 @@ var _ MappedNullable = &ConsumerPatchRequest{}
 // ConsumerPatchRequest struct for ConsumerPatchRequest
 type ConsumerPatchRequest struct {
 	Labels *map[string]string `json:"labels,omitempty"`
+	// The labels added to (or overriding) the labels of the consumer
+	AddLabels *map[string]string `json:"add_labels,omitempty"`
+	// The label keys removed from the labels of the consumer
+	RemoveLabels []string `json:"remove_labels,omitempty"`
+	// The status feedback rules replacing the feedback rules of the consumer, they are kept as they are if they are not set
+	FeedbackRules []map[string]interface{} `json:"feedback_rules,omitempty"`
+	// The default delete propagation policy replacing the policy of the consumer, Foreground or Orphan, it is kept as it is if it is not set and is removed if it is empty
+	DeletePropagationPolicy *string `json:"delete_propagation_policy,omitempty"`
 }
 
 // NewConsumerPatchRequest instantiates a new ConsumerPatchRequest object
@@ -71,6 +79,134 @@ func (o *ConsumerPatchRequest) SetLabels(v map[string]string) {
 	o.Labels = &v
 }
 
+// GetAddLabels returns the AddLabels field value if set, zero value otherwise.
+func (o *ConsumerPatchRequest) GetAddLabels() map[string]string {
+	if o == nil || IsNil(o.AddLabels) {
+		var ret map[string]string
+		return ret
+	}
+	return *o.AddLabels
+}
+
+// GetAddLabelsOk returns a tuple with the AddLabels field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ConsumerPatchRequest) GetAddLabelsOk() (*map[string]string, bool) {
+	if o == nil || IsNil(o.AddLabels) {
+		return nil, false
+	}
+	return o.AddLabels, true
+}
+
+// HasAddLabels returns a boolean if a field has been set.
+func (o *ConsumerPatchRequest) HasAddLabels() bool {
+	if o != nil && !IsNil(o.AddLabels) {
+		return true
+	}
+
+	return false
+}
+
+// SetAddLabels gets a reference to the given map[string]string and assigns it to the AddLabels field.
+func (o *ConsumerPatchRequest) SetAddLabels(v map[string]string) {
+	o.AddLabels = &v
+}
+
+// GetRemoveLabels returns the RemoveLabels field value if set, zero value otherwise.
+func (o *ConsumerPatchRequest) GetRemoveLabels() []string {
+	if o == nil || IsNil(o.RemoveLabels) {
+		var ret []string
+		return ret
+	}
+	return o.RemoveLabels
+}
+
+// GetRemoveLabelsOk returns a tuple with the RemoveLabels field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ConsumerPatchRequest) GetRemoveLabelsOk() ([]string, bool) {
+	if o == nil || IsNil(o.RemoveLabels) {
+		return nil, false
+	}
+	return o.RemoveLabels, true
+}
+
+// HasRemoveLabels returns a boolean if a field has been set.
+func (o *ConsumerPatchRequest) HasRemoveLabels() bool {
+	if o != nil && !IsNil(o.RemoveLabels) {
+		return true
+	}
+
+	return false
+}
+
+// SetRemoveLabels gets a reference to the given []string and assigns it to the RemoveLabels field.
+func (o *ConsumerPatchRequest) SetRemoveLabels(v []string) {
+	o.RemoveLabels = v
+}
+
+// GetFeedbackRules returns the FeedbackRules field value if set, zero value otherwise.
+func (o *ConsumerPatchRequest) GetFeedbackRules() []map[string]interface{} {
+	if o == nil || IsNil(o.FeedbackRules) {
+		var ret []map[string]interface{}
+		return ret
+	}
+	return o.FeedbackRules
+}
+
+// GetFeedbackRulesOk returns a tuple with the FeedbackRules field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ConsumerPatchRequest) GetFeedbackRulesOk() ([]map[string]interface{}, bool) {
+	if o == nil || IsNil(o.FeedbackRules) {
+		return nil, false
+	}
+	return o.FeedbackRules, true
+}
+
+// HasFeedbackRules returns a boolean if a field has been set.
+func (o *ConsumerPatchRequest) HasFeedbackRules() bool {
+	if o != nil && !IsNil(o.FeedbackRules) {
+		return true
+	}
+
+	return false
+}
+
+// SetFeedbackRules gets a reference to the given []map[string]interface{} and assigns it to the FeedbackRules field.
+func (o *ConsumerPatchRequest) SetFeedbackRules(v []map[string]interface{}) {
+	o.FeedbackRules = v
+}
+
+// GetDeletePropagationPolicy returns the DeletePropagationPolicy field value if set, zero value otherwise.
+func (o *ConsumerPatchRequest) GetDeletePropagationPolicy() string {
+	if o == nil || IsNil(o.DeletePropagationPolicy) {
+		var ret string
+		return ret
+	}
+	return *o.DeletePropagationPolicy
+}
+
+// GetDeletePropagationPolicyOk returns a tuple with the DeletePropagationPolicy field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ConsumerPatchRequest) GetDeletePropagationPolicyOk() (*string, bool) {
+	if o == nil || IsNil(o.DeletePropagationPolicy) {
+		return nil, false
+	}
+	return o.DeletePropagationPolicy, true
+}
+
+// HasDeletePropagationPolicy returns a boolean if a field has been set.
+func (o *ConsumerPatchRequest) HasDeletePropagationPolicy() bool {
+	if o != nil && !IsNil(o.DeletePropagationPolicy) {
+		return true
+	}
+
+	return false
+}
+
+// SetDeletePropagationPolicy gets a reference to the given string and assigns it to the DeletePropagationPolicy field.
+func (o *ConsumerPatchRequest) SetDeletePropagationPolicy(v string) {
+	o.DeletePropagationPolicy = &v
+}
+
 func (o ConsumerPatchRequest) MarshalJSON() ([]byte, error) {
 	toSerialize, err := o.ToMap()
 	if err != nil {
@@ -84,6 +220,18 @@ func (o ConsumerPatchRequest) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.Labels) {
 		toSerialize["labels"] = o.Labels
 	}
+	if !IsNil(o.AddLabels) {
+		toSerialize["add_labels"] = o.AddLabels
+	}
+	if !IsNil(o.RemoveLabels) {
+		toSerialize["remove_labels"] = o.RemoveLabels
+	}
+	if !IsNil(o.FeedbackRules) {
+		toSerialize["feedback_rules"] = o.FeedbackRules
+	}
+	if !IsNil(o.DeletePropagationPolicy) {
+		toSerialize["delete_propagation_policy"] = o.DeletePropagationPolicy
+	}
 	return toSerialize, nil
 }
 
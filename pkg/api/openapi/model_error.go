@@ -19,12 +19,20 @@ var _ MappedNullable = &Error{}
 
 // Error struct for Error
 type Error struct {
-	Id          *string `json:"id,omitempty"`
-	Kind        *string `json:"kind,omitempty"`
-	Href        *string `json:"href,omitempty"`
-	Code        *string `json:"code,omitempty"`
+	Id   *string `json:"id,omitempty"`
+	Kind *string `json:"kind,omitempty"`
+	Href *string `json:"href,omitempty"`
+	// The distinct code of the error, e.g. maestro-7
+	Code *string `json:"code,omitempty"`
+	// The context-specific reason of the error
 	Reason      *string `json:"reason,omitempty"`
 	OperationId *string `json:"operation_id,omitempty"`
+	// The general description of the error code
+	Message *string `json:"message,omitempty"`
+	// The HTTP status code of the response
+	Status *int32 `json:"status,omitempty"`
+	// The ID to correlate the error with the service logs, same as the X-Operation-ID response header
+	CorrelationId *string `json:"correlation_id,omitempty"`
 }
 
 // NewError instantiates a new Error object
@@ -236,6 +244,102 @@ func (o *Error) SetOperationId(v string) {
 	o.OperationId = &v
 }
 
+// GetMessage returns the Message field value if set, zero value otherwise.
+func (o *Error) GetMessage() string {
+	if o == nil || IsNil(o.Message) {
+		var ret string
+		return ret
+	}
+	return *o.Message
+}
+
+// GetMessageOk returns a tuple with the Message field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *Error) GetMessageOk() (*string, bool) {
+	if o == nil || IsNil(o.Message) {
+		return nil, false
+	}
+	return o.Message, true
+}
+
+// HasMessage returns a boolean if a field has been set.
+func (o *Error) HasMessage() bool {
+	if o != nil && !IsNil(o.Message) {
+		return true
+	}
+
+	return false
+}
+
+// SetMessage gets a reference to the given string and assigns it to the Message field.
+func (o *Error) SetMessage(v string) {
+	o.Message = &v
+}
+
+// GetStatus returns the Status field value if set, zero value otherwise.
+func (o *Error) GetStatus() int32 {
+	if o == nil || IsNil(o.Status) {
+		var ret int32
+		return ret
+	}
+	return *o.Status
+}
+
+// GetStatusOk returns a tuple with the Status field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *Error) GetStatusOk() (*int32, bool) {
+	if o == nil || IsNil(o.Status) {
+		return nil, false
+	}
+	return o.Status, true
+}
+
+// HasStatus returns a boolean if a field has been set.
+func (o *Error) HasStatus() bool {
+	if o != nil && !IsNil(o.Status) {
+		return true
+	}
+
+	return false
+}
+
+// SetStatus gets a reference to the given int32 and assigns it to the Status field.
+func (o *Error) SetStatus(v int32) {
+	o.Status = &v
+}
+
+// GetCorrelationId returns the CorrelationId field value if set, zero value otherwise.
+func (o *Error) GetCorrelationId() string {
+	if o == nil || IsNil(o.CorrelationId) {
+		var ret string
+		return ret
+	}
+	return *o.CorrelationId
+}
+
+// GetCorrelationIdOk returns a tuple with the CorrelationId field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *Error) GetCorrelationIdOk() (*string, bool) {
+	if o == nil || IsNil(o.CorrelationId) {
+		return nil, false
+	}
+	return o.CorrelationId, true
+}
+
+// HasCorrelationId returns a boolean if a field has been set.
+func (o *Error) HasCorrelationId() bool {
+	if o != nil && !IsNil(o.CorrelationId) {
+		return true
+	}
+
+	return false
+}
+
+// SetCorrelationId gets a reference to the given string and assigns it to the CorrelationId field.
+func (o *Error) SetCorrelationId(v string) {
+	o.CorrelationId = &v
+}
+
 func (o Error) MarshalJSON() ([]byte, error) {
 	toSerialize, err := o.ToMap()
 	if err != nil {
@@ -264,6 +368,15 @@ func (o Error) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.OperationId) {
 		toSerialize["operation_id"] = o.OperationId
 	}
+	if !IsNil(o.Message) {
+		toSerialize["message"] = o.Message
+	}
+	if !IsNil(o.Status) {
+		toSerialize["status"] = o.Status
+	}
+	if !IsNil(o.CorrelationId) {
+		toSerialize["correlation_id"] = o.CorrelationId
+	}
 	return toSerialize, nil
 }
 
@@ -20,19 +20,34 @@ var _ MappedNullable = &Resource{}
 
 // Resource struct for Resource
 type Resource struct {
-	Id             *string                `json:"id,omitempty"`
-	Kind           *string                `json:"kind,omitempty"`
-	Href           *string                `json:"href,omitempty"`
-	Name           *string                `json:"name,omitempty"`
-	ConsumerName   *string                `json:"consumer_name,omitempty"`
-	Version        *int32                 `json:"version,omitempty"`
-	CreatedAt      *time.Time             `json:"created_at,omitempty"`
-	UpdatedAt      *time.Time             `json:"updated_at,omitempty"`
-	DeletedAt      *time.Time             `json:"deleted_at,omitempty"`
-	Manifest       map[string]interface{} `json:"manifest,omitempty"`
-	DeleteOption   map[string]interface{} `json:"delete_option,omitempty"`
+	Id           *string                `json:"id,omitempty"`
+	Kind         *string                `json:"kind,omitempty"`
+	Href         *string                `json:"href,omitempty"`
+	Name         *string                `json:"name,omitempty"`
+	ConsumerName *string                `json:"consumer_name,omitempty"`
+	Version      *int32                 `json:"version,omitempty"`
+	CreatedAt    *time.Time             `json:"created_at,omitempty"`
+	UpdatedAt    *time.Time             `json:"updated_at,omitempty"`
+	DeletedAt    *time.Time             `json:"deleted_at,omitempty"`
+	Manifest     map[string]interface{} `json:"manifest,omitempty"`
+	DeleteOption map[string]interface{} `json:"delete_option,omitempty"`
+	// The update strategy of the manifest, its type is ServerSideApply (default), Update, CreateOnly or ReadOnly
 	UpdateStrategy map[string]interface{} `json:"update_strategy,omitempty"`
-	Status         map[string]interface{} `json:"status,omitempty"`
+	// Force the server-side apply of the manifest to take the ownership of the conflicting fields, false by default
+	ForceConflicts *bool `json:"force_conflicts,omitempty"`
+	// The dispatch priority of the resource from -100 to 100 (clamped), the resources with a higher priority are delivered first in a resync, 0 by default
+	Priority *int32                 `json:"priority,omitempty"`
+	Status   map[string]interface{} `json:"status,omitempty"`
+	// The maestro instance that last broadcast the status
+	LastDispatchedBy *string `json:"last_dispatched_by,omitempty"`
+	// The time when the status was last broadcast
+	LastDispatchedAt *time.Time `json:"last_dispatched_at,omitempty"`
+	// The time when the resource is quarantined after its consecutive reconcile failures, its spec is not sent to the agent until it is released
+	QuarantinedAt *time.Time `json:"quarantined_at,omitempty"`
+	// The effective update strategy of the resource, it is ServerSideApply, Update, CreateOnly, ReadOnly, Mixed (the manifests of a bundle are applied with different update strategies) or Unknown
+	EffectiveUpdateStrategy *string `json:"effective_update_strategy,omitempty"`
+	// Whether the version of the resource is not observed by the agent within the reconcile timeout (--resource-reconcile-timeout), it is cleared by the next status of the resource
+	ReconcileStale *bool `json:"reconcile_stale,omitempty"`
 }
 
 // NewResource instantiates a new Resource object
@@ -436,6 +451,70 @@ func (o *Resource) SetUpdateStrategy(v map[string]interface{}) {
 	o.UpdateStrategy = v
 }
 
+// GetForceConflicts returns the ForceConflicts field value if set, zero value otherwise.
+func (o *Resource) GetForceConflicts() bool {
+	if o == nil || IsNil(o.ForceConflicts) {
+		var ret bool
+		return ret
+	}
+	return *o.ForceConflicts
+}
+
+// GetForceConflictsOk returns a tuple with the ForceConflicts field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *Resource) GetForceConflictsOk() (*bool, bool) {
+	if o == nil || IsNil(o.ForceConflicts) {
+		return nil, false
+	}
+	return o.ForceConflicts, true
+}
+
+// HasForceConflicts returns a boolean if a field has been set.
+func (o *Resource) HasForceConflicts() bool {
+	if o != nil && !IsNil(o.ForceConflicts) {
+		return true
+	}
+
+	return false
+}
+
+// SetForceConflicts gets a reference to the given bool and assigns it to the ForceConflicts field.
+func (o *Resource) SetForceConflicts(v bool) {
+	o.ForceConflicts = &v
+}
+
+// GetPriority returns the Priority field value if set, zero value otherwise.
+func (o *Resource) GetPriority() int32 {
+	if o == nil || IsNil(o.Priority) {
+		var ret int32
+		return ret
+	}
+	return *o.Priority
+}
+
+// GetPriorityOk returns a tuple with the Priority field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *Resource) GetPriorityOk() (*int32, bool) {
+	if o == nil || IsNil(o.Priority) {
+		return nil, false
+	}
+	return o.Priority, true
+}
+
+// HasPriority returns a boolean if a field has been set.
+func (o *Resource) HasPriority() bool {
+	if o != nil && !IsNil(o.Priority) {
+		return true
+	}
+
+	return false
+}
+
+// SetPriority gets a reference to the given int32 and assigns it to the Priority field.
+func (o *Resource) SetPriority(v int32) {
+	o.Priority = &v
+}
+
 // GetStatus returns the Status field value if set, zero value otherwise.
 func (o *Resource) GetStatus() map[string]interface{} {
 	if o == nil || IsNil(o.Status) {
@@ -468,6 +547,166 @@ func (o *Resource) SetStatus(v map[string]interface{}) {
 	o.Status = v
 }
 
+// GetLastDispatchedBy returns the LastDispatchedBy field value if set, zero value otherwise.
+func (o *Resource) GetLastDispatchedBy() string {
+	if o == nil || IsNil(o.LastDispatchedBy) {
+		var ret string
+		return ret
+	}
+	return *o.LastDispatchedBy
+}
+
+// GetLastDispatchedByOk returns a tuple with the LastDispatchedBy field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *Resource) GetLastDispatchedByOk() (*string, bool) {
+	if o == nil || IsNil(o.LastDispatchedBy) {
+		return nil, false
+	}
+	return o.LastDispatchedBy, true
+}
+
+// HasLastDispatchedBy returns a boolean if a field has been set.
+func (o *Resource) HasLastDispatchedBy() bool {
+	if o != nil && !IsNil(o.LastDispatchedBy) {
+		return true
+	}
+
+	return false
+}
+
+// SetLastDispatchedBy gets a reference to the given string and assigns it to the LastDispatchedBy field.
+func (o *Resource) SetLastDispatchedBy(v string) {
+	o.LastDispatchedBy = &v
+}
+
+// GetLastDispatchedAt returns the LastDispatchedAt field value if set, zero value otherwise.
+func (o *Resource) GetLastDispatchedAt() time.Time {
+	if o == nil || IsNil(o.LastDispatchedAt) {
+		var ret time.Time
+		return ret
+	}
+	return *o.LastDispatchedAt
+}
+
+// GetLastDispatchedAtOk returns a tuple with the LastDispatchedAt field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *Resource) GetLastDispatchedAtOk() (*time.Time, bool) {
+	if o == nil || IsNil(o.LastDispatchedAt) {
+		return nil, false
+	}
+	return o.LastDispatchedAt, true
+}
+
+// HasLastDispatchedAt returns a boolean if a field has been set.
+func (o *Resource) HasLastDispatchedAt() bool {
+	if o != nil && !IsNil(o.LastDispatchedAt) {
+		return true
+	}
+
+	return false
+}
+
+// SetLastDispatchedAt gets a reference to the given time.Time and assigns it to the LastDispatchedAt field.
+func (o *Resource) SetLastDispatchedAt(v time.Time) {
+	o.LastDispatchedAt = &v
+}
+
+// GetQuarantinedAt returns the QuarantinedAt field value if set, zero value otherwise.
+func (o *Resource) GetQuarantinedAt() time.Time {
+	if o == nil || IsNil(o.QuarantinedAt) {
+		var ret time.Time
+		return ret
+	}
+	return *o.QuarantinedAt
+}
+
+// GetQuarantinedAtOk returns a tuple with the QuarantinedAt field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *Resource) GetQuarantinedAtOk() (*time.Time, bool) {
+	if o == nil || IsNil(o.QuarantinedAt) {
+		return nil, false
+	}
+	return o.QuarantinedAt, true
+}
+
+// HasQuarantinedAt returns a boolean if a field has been set.
+func (o *Resource) HasQuarantinedAt() bool {
+	if o != nil && !IsNil(o.QuarantinedAt) {
+		return true
+	}
+
+	return false
+}
+
+// SetQuarantinedAt gets a reference to the given time.Time and assigns it to the QuarantinedAt field.
+func (o *Resource) SetQuarantinedAt(v time.Time) {
+	o.QuarantinedAt = &v
+}
+
+// GetEffectiveUpdateStrategy returns the EffectiveUpdateStrategy field value if set, zero value otherwise.
+func (o *Resource) GetEffectiveUpdateStrategy() string {
+	if o == nil || IsNil(o.EffectiveUpdateStrategy) {
+		var ret string
+		return ret
+	}
+	return *o.EffectiveUpdateStrategy
+}
+
+// GetEffectiveUpdateStrategyOk returns a tuple with the EffectiveUpdateStrategy field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *Resource) GetEffectiveUpdateStrategyOk() (*string, bool) {
+	if o == nil || IsNil(o.EffectiveUpdateStrategy) {
+		return nil, false
+	}
+	return o.EffectiveUpdateStrategy, true
+}
+
+// HasEffectiveUpdateStrategy returns a boolean if a field has been set.
+func (o *Resource) HasEffectiveUpdateStrategy() bool {
+	if o != nil && !IsNil(o.EffectiveUpdateStrategy) {
+		return true
+	}
+
+	return false
+}
+
+// SetEffectiveUpdateStrategy gets a reference to the given string and assigns it to the EffectiveUpdateStrategy field.
+func (o *Resource) SetEffectiveUpdateStrategy(v string) {
+	o.EffectiveUpdateStrategy = &v
+}
+
+// GetReconcileStale returns the ReconcileStale field value if set, zero value otherwise.
+func (o *Resource) GetReconcileStale() bool {
+	if o == nil || IsNil(o.ReconcileStale) {
+		var ret bool
+		return ret
+	}
+	return *o.ReconcileStale
+}
+
+// GetReconcileStaleOk returns a tuple with the ReconcileStale field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *Resource) GetReconcileStaleOk() (*bool, bool) {
+	if o == nil || IsNil(o.ReconcileStale) {
+		return nil, false
+	}
+	return o.ReconcileStale, true
+}
+
+// HasReconcileStale returns a boolean if a field has been set.
+func (o *Resource) HasReconcileStale() bool {
+	if o != nil && !IsNil(o.ReconcileStale) {
+		return true
+	}
+
+	return false
+}
+
+// SetReconcileStale gets a reference to the given bool and assigns it to the ReconcileStale field.
+func (o *Resource) SetReconcileStale(v bool) {
+	o.ReconcileStale = &v
+}
+
 func (o Resource) MarshalJSON() ([]byte, error) {
 	toSerialize, err := o.ToMap()
 	if err != nil {
@@ -514,9 +753,30 @@ func (o Resource) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.UpdateStrategy) {
 		toSerialize["update_strategy"] = o.UpdateStrategy
 	}
+	if !IsNil(o.ForceConflicts) {
+		toSerialize["force_conflicts"] = o.ForceConflicts
+	}
+	if !IsNil(o.Priority) {
+		toSerialize["priority"] = o.Priority
+	}
 	if !IsNil(o.Status) {
 		toSerialize["status"] = o.Status
 	}
+	if !IsNil(o.LastDispatchedBy) {
+		toSerialize["last_dispatched_by"] = o.LastDispatchedBy
+	}
+	if !IsNil(o.LastDispatchedAt) {
+		toSerialize["last_dispatched_at"] = o.LastDispatchedAt
+	}
+	if !IsNil(o.QuarantinedAt) {
+		toSerialize["quarantined_at"] = o.QuarantinedAt
+	}
+	if !IsNil(o.EffectiveUpdateStrategy) {
+		toSerialize["effective_update_strategy"] = o.EffectiveUpdateStrategy
+	}
+	if !IsNil(o.ReconcileStale) {
+		toSerialize["reconcile_stale"] = o.ReconcileStale
+	}
 	return toSerialize, nil
 }
 
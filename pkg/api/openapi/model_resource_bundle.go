@@ -34,6 +34,16 @@ type ResourceBundle struct {
 	DeleteOption    map[string]interface{}   `json:"delete_option,omitempty"`
 	ManifestConfigs []map[string]interface{} `json:"manifest_configs,omitempty"`
 	Status          map[string]interface{}   `json:"status,omitempty"`
+	// The maestro instance that last broadcast the status
+	LastDispatchedBy *string `json:"last_dispatched_by,omitempty"`
+	// The time when the status was last broadcast
+	LastDispatchedAt *time.Time `json:"last_dispatched_at,omitempty"`
+	// The time when the resource is quarantined after its consecutive reconcile failures, its spec is not sent to the agent until it is released
+	QuarantinedAt *time.Time `json:"quarantined_at,omitempty"`
+	// The effective update strategy of the resource, it is ServerSideApply, Update, CreateOnly, ReadOnly, Mixed (the manifests of a bundle are applied with different update strategies) or Unknown
+	EffectiveUpdateStrategy *string `json:"effective_update_strategy,omitempty"`
+	// Whether the version of the resource is not observed by the agent within the reconcile timeout (--resource-reconcile-timeout), it is cleared by the next status of the resource
+	ReconcileStale *bool `json:"reconcile_stale,omitempty"`
 }
 
 // NewResourceBundle instantiates a new ResourceBundle object
@@ -501,6 +511,166 @@ func (o *ResourceBundle) SetStatus(v map[string]interface{}) {
 	o.Status = v
 }
 
+// GetLastDispatchedBy returns the LastDispatchedBy field value if set, zero value otherwise.
+func (o *ResourceBundle) GetLastDispatchedBy() string {
+	if o == nil || IsNil(o.LastDispatchedBy) {
+		var ret string
+		return ret
+	}
+	return *o.LastDispatchedBy
+}
+
+// GetLastDispatchedByOk returns a tuple with the LastDispatchedBy field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ResourceBundle) GetLastDispatchedByOk() (*string, bool) {
+	if o == nil || IsNil(o.LastDispatchedBy) {
+		return nil, false
+	}
+	return o.LastDispatchedBy, true
+}
+
+// HasLastDispatchedBy returns a boolean if a field has been set.
+func (o *ResourceBundle) HasLastDispatchedBy() bool {
+	if o != nil && !IsNil(o.LastDispatchedBy) {
+		return true
+	}
+
+	return false
+}
+
+// SetLastDispatchedBy gets a reference to the given string and assigns it to the LastDispatchedBy field.
+func (o *ResourceBundle) SetLastDispatchedBy(v string) {
+	o.LastDispatchedBy = &v
+}
+
+// GetLastDispatchedAt returns the LastDispatchedAt field value if set, zero value otherwise.
+func (o *ResourceBundle) GetLastDispatchedAt() time.Time {
+	if o == nil || IsNil(o.LastDispatchedAt) {
+		var ret time.Time
+		return ret
+	}
+	return *o.LastDispatchedAt
+}
+
+// GetLastDispatchedAtOk returns a tuple with the LastDispatchedAt field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ResourceBundle) GetLastDispatchedAtOk() (*time.Time, bool) {
+	if o == nil || IsNil(o.LastDispatchedAt) {
+		return nil, false
+	}
+	return o.LastDispatchedAt, true
+}
+
+// HasLastDispatchedAt returns a boolean if a field has been set.
+func (o *ResourceBundle) HasLastDispatchedAt() bool {
+	if o != nil && !IsNil(o.LastDispatchedAt) {
+		return true
+	}
+
+	return false
+}
+
+// SetLastDispatchedAt gets a reference to the given time.Time and assigns it to the LastDispatchedAt field.
+func (o *ResourceBundle) SetLastDispatchedAt(v time.Time) {
+	o.LastDispatchedAt = &v
+}
+
+// GetQuarantinedAt returns the QuarantinedAt field value if set, zero value otherwise.
+func (o *ResourceBundle) GetQuarantinedAt() time.Time {
+	if o == nil || IsNil(o.QuarantinedAt) {
+		var ret time.Time
+		return ret
+	}
+	return *o.QuarantinedAt
+}
+
+// GetQuarantinedAtOk returns a tuple with the QuarantinedAt field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ResourceBundle) GetQuarantinedAtOk() (*time.Time, bool) {
+	if o == nil || IsNil(o.QuarantinedAt) {
+		return nil, false
+	}
+	return o.QuarantinedAt, true
+}
+
+// HasQuarantinedAt returns a boolean if a field has been set.
+func (o *ResourceBundle) HasQuarantinedAt() bool {
+	if o != nil && !IsNil(o.QuarantinedAt) {
+		return true
+	}
+
+	return false
+}
+
+// SetQuarantinedAt gets a reference to the given time.Time and assigns it to the QuarantinedAt field.
+func (o *ResourceBundle) SetQuarantinedAt(v time.Time) {
+	o.QuarantinedAt = &v
+}
+
+// GetEffectiveUpdateStrategy returns the EffectiveUpdateStrategy field value if set, zero value otherwise.
+func (o *ResourceBundle) GetEffectiveUpdateStrategy() string {
+	if o == nil || IsNil(o.EffectiveUpdateStrategy) {
+		var ret string
+		return ret
+	}
+	return *o.EffectiveUpdateStrategy
+}
+
+// GetEffectiveUpdateStrategyOk returns a tuple with the EffectiveUpdateStrategy field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ResourceBundle) GetEffectiveUpdateStrategyOk() (*string, bool) {
+	if o == nil || IsNil(o.EffectiveUpdateStrategy) {
+		return nil, false
+	}
+	return o.EffectiveUpdateStrategy, true
+}
+
+// HasEffectiveUpdateStrategy returns a boolean if a field has been set.
+func (o *ResourceBundle) HasEffectiveUpdateStrategy() bool {
+	if o != nil && !IsNil(o.EffectiveUpdateStrategy) {
+		return true
+	}
+
+	return false
+}
+
+// SetEffectiveUpdateStrategy gets a reference to the given string and assigns it to the EffectiveUpdateStrategy field.
+func (o *ResourceBundle) SetEffectiveUpdateStrategy(v string) {
+	o.EffectiveUpdateStrategy = &v
+}
+
+// GetReconcileStale returns the ReconcileStale field value if set, zero value otherwise.
+func (o *ResourceBundle) GetReconcileStale() bool {
+	if o == nil || IsNil(o.ReconcileStale) {
+		var ret bool
+		return ret
+	}
+	return *o.ReconcileStale
+}
+
+// GetReconcileStaleOk returns a tuple with the ReconcileStale field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ResourceBundle) GetReconcileStaleOk() (*bool, bool) {
+	if o == nil || IsNil(o.ReconcileStale) {
+		return nil, false
+	}
+	return o.ReconcileStale, true
+}
+
+// HasReconcileStale returns a boolean if a field has been set.
+func (o *ResourceBundle) HasReconcileStale() bool {
+	if o != nil && !IsNil(o.ReconcileStale) {
+		return true
+	}
+
+	return false
+}
+
+// SetReconcileStale gets a reference to the given bool and assigns it to the ReconcileStale field.
+func (o *ResourceBundle) SetReconcileStale(v bool) {
+	o.ReconcileStale = &v
+}
+
 func (o ResourceBundle) MarshalJSON() ([]byte, error) {
 	toSerialize, err := o.ToMap()
 	if err != nil {
@@ -553,6 +723,21 @@ func (o ResourceBundle) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.Status) {
 		toSerialize["status"] = o.Status
 	}
+	if !IsNil(o.LastDispatchedBy) {
+		toSerialize["last_dispatched_by"] = o.LastDispatchedBy
+	}
+	if !IsNil(o.LastDispatchedAt) {
+		toSerialize["last_dispatched_at"] = o.LastDispatchedAt
+	}
+	if !IsNil(o.QuarantinedAt) {
+		toSerialize["quarantined_at"] = o.QuarantinedAt
+	}
+	if !IsNil(o.EffectiveUpdateStrategy) {
+		toSerialize["effective_update_strategy"] = o.EffectiveUpdateStrategy
+	}
+	if !IsNil(o.ReconcileStale) {
+		toSerialize["reconcile_stale"] = o.ReconcileStale
+	}
 	return toSerialize, nil
 }
 
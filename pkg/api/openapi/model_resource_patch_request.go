@@ -19,10 +19,15 @@ var _ MappedNullable = &ResourcePatchRequest{}
 
 // ResourcePatchRequest struct for ResourcePatchRequest
 type ResourcePatchRequest struct {
-	Version        *int32                 `json:"version,omitempty"`
-	Manifest       map[string]interface{} `json:"manifest,omitempty"`
-	DeleteOption   map[string]interface{} `json:"delete_option,omitempty"`
+	Version      *int32                 `json:"version,omitempty"`
+	Manifest     map[string]interface{} `json:"manifest,omitempty"`
+	DeleteOption map[string]interface{} `json:"delete_option,omitempty"`
+	// The update strategy of the manifest, its type is ServerSideApply (default), Update, CreateOnly or ReadOnly
 	UpdateStrategy map[string]interface{} `json:"update_strategy,omitempty"`
+	// Force the server-side apply of the manifest to take the ownership of the conflicting fields, false by default
+	ForceConflicts *bool `json:"force_conflicts,omitempty"`
+	// The patch type of the manifest, Replace (default), JSONMerge or StrategicMerge
+	PatchType *string `json:"patch_type,omitempty"`
 }
 
 // NewResourcePatchRequest instantiates a new ResourcePatchRequest object
@@ -170,6 +175,70 @@ func (o *ResourcePatchRequest) SetUpdateStrategy(v map[string]interface{}) {
 	o.UpdateStrategy = v
 }
 
+// GetForceConflicts returns the ForceConflicts field value if set, zero value otherwise.
+func (o *ResourcePatchRequest) GetForceConflicts() bool {
+	if o == nil || IsNil(o.ForceConflicts) {
+		var ret bool
+		return ret
+	}
+	return *o.ForceConflicts
+}
+
+// GetForceConflictsOk returns a tuple with the ForceConflicts field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ResourcePatchRequest) GetForceConflictsOk() (*bool, bool) {
+	if o == nil || IsNil(o.ForceConflicts) {
+		return nil, false
+	}
+	return o.ForceConflicts, true
+}
+
+// HasForceConflicts returns a boolean if a field has been set.
+func (o *ResourcePatchRequest) HasForceConflicts() bool {
+	if o != nil && !IsNil(o.ForceConflicts) {
+		return true
+	}
+
+	return false
+}
+
+// SetForceConflicts gets a reference to the given bool and assigns it to the ForceConflicts field.
+func (o *ResourcePatchRequest) SetForceConflicts(v bool) {
+	o.ForceConflicts = &v
+}
+
+// GetPatchType returns the PatchType field value if set, zero value otherwise.
+func (o *ResourcePatchRequest) GetPatchType() string {
+	if o == nil || IsNil(o.PatchType) {
+		var ret string
+		return ret
+	}
+	return *o.PatchType
+}
+
+// GetPatchTypeOk returns a tuple with the PatchType field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ResourcePatchRequest) GetPatchTypeOk() (*string, bool) {
+	if o == nil || IsNil(o.PatchType) {
+		return nil, false
+	}
+	return o.PatchType, true
+}
+
+// HasPatchType returns a boolean if a field has been set.
+func (o *ResourcePatchRequest) HasPatchType() bool {
+	if o != nil && !IsNil(o.PatchType) {
+		return true
+	}
+
+	return false
+}
+
+// SetPatchType gets a reference to the given string and assigns it to the PatchType field.
+func (o *ResourcePatchRequest) SetPatchType(v string) {
+	o.PatchType = &v
+}
+
 func (o ResourcePatchRequest) MarshalJSON() ([]byte, error) {
 	toSerialize, err := o.ToMap()
 	if err != nil {
@@ -192,6 +261,12 @@ func (o ResourcePatchRequest) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.UpdateStrategy) {
 		toSerialize["update_strategy"] = o.UpdateStrategy
 	}
+	if !IsNil(o.ForceConflicts) {
+		toSerialize["force_conflicts"] = o.ForceConflicts
+	}
+	if !IsNil(o.PatchType) {
+		toSerialize["patch_type"] = o.PatchType
+	}
 	return toSerialize, nil
 }
 
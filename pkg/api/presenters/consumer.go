@@ -1,31 +1,87 @@
 package presenters
 
 import (
+	"encoding/json"
+
+	workv1 "open-cluster-management.io/api/work/v1"
+
 	"github.com/openshift-online/maestro/pkg/api"
 	"github.com/openshift-online/maestro/pkg/api/openapi"
 	"github.com/openshift-online/maestro/pkg/db"
 	"github.com/openshift-online/maestro/pkg/util"
 )
 
-func ConvertConsumer(consumer openapi.Consumer) *api.Consumer {
+func ConvertConsumer(consumer openapi.Consumer) (*api.Consumer, error) {
+	feedbackRules, err := ConvertFeedbackRules(consumer.FeedbackRules)
+	if err != nil {
+		return nil, err
+	}
 	return &api.Consumer{
 		Meta: api.Meta{
 			ID: util.NilToEmptyString(consumer.Id),
 		},
-		Name:   util.NilToEmptyString(consumer.Name),
-		Labels: db.EmptyMapToNilStringMap(consumer.Labels),
+		Name:                    util.NilToEmptyString(consumer.Name),
+		Labels:                  db.EmptyMapToNilStringMap(consumer.Labels),
+		FeedbackRules:           feedbackRules,
+		DeletePropagationPolicy: util.NilToEmptyString(consumer.DeletePropagationPolicy),
+	}, nil
+}
+
+// ConvertFeedbackRules converts the status feedback rules from the openapi representation to the API, the rules are
+// decoded in the JSON format of the work API, e.g. {"type":"JSONPaths","jsonPaths":[{"name":"lb","path":".status"}]}.
+func ConvertFeedbackRules(rules []map[string]interface{}) ([]workv1.FeedbackRule, error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(rules)
+	if err != nil {
+		return nil, err
 	}
+	feedbackRules := []workv1.FeedbackRule{}
+	if err := json.Unmarshal(data, &feedbackRules); err != nil {
+		return nil, err
+	}
+	return feedbackRules, nil
+}
+
+// PresentFeedbackRules converts the status feedback rules from the API to the openapi representation.
+func PresentFeedbackRules(rules []workv1.FeedbackRule) []map[string]interface{} {
+	if len(rules) == 0 {
+		return nil
+	}
+	presented := make([]map[string]interface{}, 0, len(rules))
+	for _, rule := range rules {
+		presentedRule := map[string]interface{}{"type": string(rule.Type)}
+		if len(rule.JsonPaths) != 0 {
+			paths := make([]interface{}, 0, len(rule.JsonPaths))
+			for _, path := range rule.JsonPaths {
+				presentedPath := map[string]interface{}{"name": path.Name, "path": path.Path}
+				if path.Version != "" {
+					presentedPath["version"] = path.Version
+				}
+				paths = append(paths, presentedPath)
+			}
+			presentedRule["jsonPaths"] = paths
+		}
+		presented = append(presented, presentedRule)
+	}
+	return presented
 }
 
 func PresentConsumer(consumer *api.Consumer) openapi.Consumer {
 	reference := PresentReference(consumer.ID, consumer)
-	return openapi.Consumer{
-		Id:        reference.Id,
-		Kind:      reference.Kind,
-		Href:      reference.Href,
-		Name:      openapi.PtrString(consumer.Name),
-		Labels:    consumer.Labels.ToMap(),
-		CreatedAt: openapi.PtrTime(consumer.CreatedAt),
-		UpdatedAt: openapi.PtrTime(consumer.UpdatedAt),
+	presented := openapi.Consumer{
+		Id:            reference.Id,
+		Kind:          reference.Kind,
+		Href:          reference.Href,
+		Name:          openapi.PtrString(consumer.Name),
+		Labels:        consumer.Labels.ToMap(),
+		CreatedAt:     openapi.PtrTime(consumer.CreatedAt),
+		UpdatedAt:     openapi.PtrTime(consumer.UpdatedAt),
+		FeedbackRules: PresentFeedbackRules(consumer.FeedbackRules),
+	}
+	if consumer.DeletePropagationPolicy != "" {
+		presented.DeletePropagationPolicy = openapi.PtrString(consumer.DeletePropagationPolicy)
 	}
+	return presented
 }
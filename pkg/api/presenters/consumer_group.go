@@ -0,0 +1,13 @@
+package presenters
+
+import (
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/api/openapi"
+)
+
+func PresentConsumerGroup(group *api.ConsumerGroup) openapi.ConsumerGroup {
+	return openapi.ConsumerGroup{
+		Name:          openapi.PtrString(group.Name),
+		ConsumerCount: openapi.PtrInt32(int32(group.ConsumerCount)),
+	}
+}
@@ -0,0 +1,33 @@
+package presenters
+
+import (
+	"fmt"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/api/openapi"
+)
+
+// PresentConsumerToken presents the consumer token metadata, the token value is only presented when the token
+// is issued.
+func PresentConsumerToken(consumerToken *api.ConsumerToken, token string) openapi.ConsumerToken {
+	presented := openapi.ConsumerToken{
+		Id:           openapi.PtrString(consumerToken.ID),
+		Kind:         ObjectKind(consumerToken),
+		Href:         openapi.PtrString(fmt.Sprintf("%s/consumers/%s/tokens/%s", BasePath, consumerToken.ConsumerID, consumerToken.ID)),
+		ConsumerId:   openapi.PtrString(consumerToken.ConsumerID),
+		ConsumerName: openapi.PtrString(consumerToken.ConsumerName),
+		ExpiresAt:    openapi.PtrTime(consumerToken.ExpiresAt),
+		CreatedBy:    openapi.PtrString(consumerToken.CreatedBy),
+		CreatedAt:    openapi.PtrTime(consumerToken.CreatedAt),
+	}
+
+	if len(token) != 0 {
+		presented.Token = openapi.PtrString(token)
+	}
+
+	if consumerToken.RevokedAt != nil {
+		presented.RevokedAt = openapi.PtrTime(*consumerToken.RevokedAt)
+	}
+
+	return presented
+}
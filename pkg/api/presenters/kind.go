@@ -13,10 +13,28 @@ func ObjectKind(i interface{}) *string {
 		result = "Consumer"
 	case api.ConsumerList, *api.ConsumerList, []api.Consumer, []*api.Consumer:
 		result = "ConsumerList"
+	case api.ConsumerGroup, *api.ConsumerGroup:
+		result = "ConsumerGroup"
+	case api.ConsumerGroupList, *api.ConsumerGroupList, []api.ConsumerGroup, []*api.ConsumerGroup:
+		result = "ConsumerGroupList"
+	case api.ConsumerToken, *api.ConsumerToken:
+		result = "ConsumerToken"
+	case api.ConsumerTokenList, *api.ConsumerTokenList, []api.ConsumerToken, []*api.ConsumerToken:
+		result = "ConsumerTokenList"
 	case api.Resource, *api.Resource:
 		result = "Resource"
 	case api.ResourceList, *api.ResourceList, []api.Resource, []*api.Resource:
 		result = "ResourceList"
+	case api.ResourceTemplate, *api.ResourceTemplate:
+		result = "ResourceTemplate"
+	case api.ResourceTemplateList, *api.ResourceTemplateList, []api.ResourceTemplate, []*api.ResourceTemplate:
+		result = "ResourceTemplateList"
+	case api.ResourceOwnershipTransfer, *api.ResourceOwnershipTransfer:
+		result = "ResourceOwnershipTransfer"
+	case api.ResourceOwnershipTransferList, *api.ResourceOwnershipTransferList, []api.ResourceOwnershipTransfer, []*api.ResourceOwnershipTransfer:
+		result = "ResourceOwnershipTransferList"
+	case api.ResourceVersionDriftList, *api.ResourceVersionDriftList, []api.ResourceVersionDrift, []*api.ResourceVersionDrift:
+		result = "ResourceVersionDriftList"
 	case errors.ServiceError, *errors.ServiceError:
 		result = "Error"
 	}
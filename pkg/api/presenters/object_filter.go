@@ -0,0 +1,90 @@
+package presenters
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/openshift-online/maestro/pkg/errors"
+)
+
+/*
+	ObjectFilter
+
+Project a presented structure to the given fields.
+Non-existing fields will cause a validation error
+
+@param fields []string - list of fields to export (from `json` tag), the nested fields of the object fields can be
+selected with the dotted names, e.g. status.ContentStatus
+
+@param model interface{} - the presented structure to export, e.g. openapi.Resource
+
+@return map[string]interface{}
+*/
+func ObjectFilter(fields []string, model interface{}) (map[string]interface{}, *errors.ServiceError) {
+	if model == nil {
+		return nil, errors.Validation("Empty model")
+	}
+
+	// Validate the fields against the model, the object fields are free-form, so only the top-level names are
+	// validated
+	modelType := reflect.Indirect(reflect.ValueOf(model)).Type()
+	known := map[string]bool{}
+	for i := 0; i < modelType.NumField(); i++ {
+		name := strings.Split(modelType.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			known[name] = true
+		}
+	}
+	var unknown []string
+	for _, field := range fields {
+		if !known[strings.Split(field, ".")[0]] {
+			unknown = append(unknown, field)
+		}
+	}
+	if len(unknown) != 0 {
+		return nil, errors.Validation("The following field(s) doesn't exist in `%s`: %s",
+			modelType.Name(), strings.Join(unknown, ", "))
+	}
+
+	// Project the JSON form of the model, so the fields are rendered as they are without the projection
+	data, err := json.Marshal(model)
+	if err != nil {
+		return nil, errors.GeneralError("Unable to marshal %s: %s", modelType.Name(), err)
+	}
+	object := map[string]interface{}{}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, errors.GeneralError("Unable to unmarshal %s: %s", modelType.Name(), err)
+	}
+
+	result := map[string]interface{}{}
+	for _, field := range fields {
+		projectField(object, result, strings.Split(field, "."))
+	}
+	return result, nil
+}
+
+// projectField copies the value of the field path from the source to the destination, the unset fields are skipped.
+func projectField(src, dst map[string]interface{}, path []string) {
+	value, ok := src[path[0]]
+	if !ok {
+		return
+	}
+	if len(path) == 1 || path[1] == "*" {
+		dst[path[0]] = value
+		return
+	}
+
+	nested, ok := value.(map[string]interface{})
+	if !ok {
+		return
+	}
+	sub, ok := dst[path[0]].(map[string]interface{})
+	if !ok {
+		sub = map[string]interface{}{}
+	}
+	projectField(nested, sub, path[1:])
+	if len(sub) != 0 {
+		dst[path[0]] = sub
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"gorm.io/datatypes"
+	workv1 "open-cluster-management.io/api/work/v1"
 
 	"github.com/openshift-online/maestro/pkg/api"
 	"github.com/openshift-online/maestro/pkg/api/openapi"
@@ -12,9 +13,12 @@ import (
 	"github.com/openshift-online/maestro/pkg/util"
 )
 
-// ConvertResource converts a resource from the API to the openapi representation.
-func ConvertResource(resource openapi.Resource) (*api.Resource, error) {
-	payload, err := ConvertResourceManifest(resource.Manifest, resource.DeleteOption, resource.UpdateStrategy)
+// ConvertResource converts a resource from the API to the openapi representation, the consumerRules are the status
+// feedback rules of the consumer of the resource.
+func ConvertResource(resource openapi.Resource, namespaceDefaults *api.NamespaceDefaults,
+	consumerRules []workv1.FeedbackRule) (*api.Resource, error) {
+	payload, err := ConvertResourceManifest(resource.Manifest, resource.DeleteOption, resource.UpdateStrategy, resource.ForceConflicts,
+		namespaceDefaults, consumerRules)
 	if err != nil {
 		return nil, err
 	}
@@ -24,17 +28,32 @@ func ConvertResource(resource openapi.Resource) (*api.Resource, error) {
 			ID: util.NilToEmptyString(resource.Id),
 		},
 		ConsumerName: util.NilToEmptyString(resource.ConsumerName),
-		Version:      util.NilToEmptyInt32(resource.Version),
+		Version:      int64(util.NilToEmptyInt32(resource.Version)),
 		// Set the default source ID for RESTful API calls and do not allow modification
-		Source:  constants.DefaultSourceID,
-		Type:    api.ResourceTypeSingle,
-		Payload: payload,
+		Source:   constants.DefaultSourceID,
+		Type:     api.ResourceTypeSingle,
+		Payload:  payload,
+		Priority: api.ClampResourcePriority(resource.GetPriority()),
 	}, nil
 }
 
 // ConvertResourceManifest converts a resource manifest from the openapi representation to the API.
-func ConvertResourceManifest(manifest, deleteOption, updateStrategy map[string]interface{}) (datatypes.JSONMap, error) {
-	return api.EncodeManifest(manifest, deleteOption, updateStrategy)
+func ConvertResourceManifest(manifest, deleteOption, updateStrategy map[string]interface{}, forceConflicts *bool,
+	namespaceDefaults *api.NamespaceDefaults, consumerRules []workv1.FeedbackRule) (datatypes.JSONMap, error) {
+	return api.EncodeManifest(manifest, deleteOption, updateStrategy, forceConflicts, namespaceDefaults, consumerRules)
+}
+
+// PresentManifestValidation converts a validated resource from the API to the openapi representation of its
+// manifest validation.
+func PresentManifestValidation(resource *api.Resource) (*openapi.ManifestValidation, error) {
+	manifest, _, _, err := api.DecodeManifest(resource.Payload)
+	if err != nil {
+		return nil, err
+	}
+	return &openapi.ManifestValidation{
+		Manifest: manifest,
+		Encoded:  resource.Payload,
+	}, nil
 }
 
 // PresentResource converts a resource from the API to the openapi representation.
@@ -54,12 +73,14 @@ func PresentResource(resource *api.Resource) (*openapi.Resource, error) {
 		Href:           reference.Href,
 		Name:           openapi.PtrString(resource.Name),
 		ConsumerName:   openapi.PtrString(resource.ConsumerName),
-		Version:        openapi.PtrInt32(resource.Version),
+		Version:        openapi.PtrInt32(int32(resource.Version)),
 		CreatedAt:      openapi.PtrTime(resource.CreatedAt),
 		UpdatedAt:      openapi.PtrTime(resource.UpdatedAt),
 		Manifest:       manifest,
 		DeleteOption:   deleteOption,
 		UpdateStrategy: updateStrategy,
+		ForceConflicts: openapi.PtrBool(api.ForceConflicts(updateStrategy)),
+		Priority:       openapi.PtrInt32(resource.Priority),
 		Status:         status,
 	}
 
@@ -68,6 +89,24 @@ func PresentResource(resource *api.Resource) (*openapi.Resource, error) {
 		res.DeletedAt = openapi.PtrTime(resource.DeletedAt.Time)
 	}
 
+	// set the last dispatched fields if the resource status has been broadcast
+	if resource.LastDispatchedAt != nil {
+		res.LastDispatchedBy = openapi.PtrString(resource.LastDispatchedBy)
+		res.LastDispatchedAt = openapi.PtrTime(*resource.LastDispatchedAt)
+	}
+
+	if resource.QuarantinedAt != nil {
+		res.QuarantinedAt = openapi.PtrTime(*resource.QuarantinedAt)
+	}
+
+	if resource.UpdateStrategy != "" {
+		res.EffectiveUpdateStrategy = openapi.PtrString(resource.UpdateStrategy)
+	}
+
+	if resource.IsReconcileStale() {
+		res.ReconcileStale = openapi.PtrBool(true)
+	}
+
 	return res, nil
 }
 
@@ -81,6 +120,14 @@ func PresentResourceBundle(resource *api.Resource) (*openapi.ResourceBundle, err
 	if err != nil {
 		return nil, err
 	}
+	// report the apply result of each manifest along with the flat manifest statuses
+	results, err := api.BundleManifestResults(resource.Payload, resource.Status)
+	if err != nil {
+		return nil, err
+	}
+	if status != nil {
+		status["manifestResults"] = results
+	}
 
 	reference := openapi.ObjectReference{
 		Id:   openapi.PtrString(resource.ID),
@@ -129,7 +176,7 @@ func PresentResourceBundle(resource *api.Resource) (*openapi.ResourceBundle, err
 		Href:            reference.Href,
 		Name:            openapi.PtrString(resource.Name),
 		ConsumerName:    openapi.PtrString(resource.ConsumerName),
-		Version:         openapi.PtrInt32(resource.Version),
+		Version:         openapi.PtrInt32(int32(resource.Version)),
 		CreatedAt:       openapi.PtrTime(resource.CreatedAt),
 		UpdatedAt:       openapi.PtrTime(resource.UpdatedAt),
 		Metadata:        metadata,
@@ -144,5 +191,23 @@ func PresentResourceBundle(resource *api.Resource) (*openapi.ResourceBundle, err
 		res.DeletedAt = openapi.PtrTime(resource.DeletedAt.Time)
 	}
 
+	// set the last dispatched fields if the resource status has been broadcast
+	if resource.LastDispatchedAt != nil {
+		res.LastDispatchedBy = openapi.PtrString(resource.LastDispatchedBy)
+		res.LastDispatchedAt = openapi.PtrTime(*resource.LastDispatchedAt)
+	}
+
+	if resource.QuarantinedAt != nil {
+		res.QuarantinedAt = openapi.PtrTime(*resource.QuarantinedAt)
+	}
+
+	if resource.UpdateStrategy != "" {
+		res.EffectiveUpdateStrategy = openapi.PtrString(resource.UpdateStrategy)
+	}
+
+	if resource.IsReconcileStale() {
+		res.ReconcileStale = openapi.PtrBool(true)
+	}
+
 	return res, nil
 }
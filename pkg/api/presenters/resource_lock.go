@@ -0,0 +1,16 @@
+package presenters
+
+import (
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/api/openapi"
+)
+
+// PresentResourceLock presents the soft-lock of a resource.
+func PresentResourceLock(lock *api.ResourceLock) openapi.ResourceLock {
+	return openapi.ResourceLock{
+		ResourceId: openapi.PtrString(lock.ResourceID),
+		Owner:      openapi.PtrString(lock.Owner),
+		AcquiredAt: openapi.PtrTime(lock.AcquiredAt),
+		ExpiresAt:  openapi.PtrTime(lock.ExpiresAt),
+	}
+}
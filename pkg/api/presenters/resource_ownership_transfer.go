@@ -0,0 +1,19 @@
+package presenters
+
+import (
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/api/openapi"
+)
+
+// PresentResourceOwnershipTransfer presents the audit record of a resource ownership transfer.
+func PresentResourceOwnershipTransfer(transfer *api.ResourceOwnershipTransfer) openapi.ResourceOwnershipTransfer {
+	return openapi.ResourceOwnershipTransfer{
+		Id:            openapi.PtrString(transfer.ID),
+		Kind:          ObjectKind(transfer),
+		ResourceId:    openapi.PtrString(transfer.ResourceID),
+		FromSource:    openapi.PtrString(transfer.FromSource),
+		ToSource:      openapi.PtrString(transfer.ToSource),
+		TransferredBy: openapi.PtrString(transfer.TransferredBy),
+		CreatedAt:     openapi.PtrTime(transfer.CreatedAt),
+	}
+}
@@ -0,0 +1,60 @@
+package presenters
+
+import (
+	"fmt"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/api/openapi"
+	"github.com/openshift-online/maestro/pkg/util"
+)
+
+// ConvertResourceTemplate converts a resource template from the openapi representation to the API.
+func ConvertResourceTemplate(template openapi.ResourceTemplate) *api.ResourceTemplate {
+	parameters := []api.ResourceTemplateParameter{}
+	for _, param := range template.Parameters {
+		parameters = append(parameters, api.ResourceTemplateParameter{
+			Name:        param.GetName(),
+			Description: param.GetDescription(),
+			Required:    param.GetRequired(),
+			Value:       param.GetValue(),
+			Type:        api.ResourceTemplateParameterType(param.GetType()),
+		})
+	}
+
+	return &api.ResourceTemplate{
+		Meta: api.Meta{
+			ID: util.NilToEmptyString(template.Id),
+		},
+		Name:        template.GetName(),
+		Description: template.GetDescription(),
+		Manifest:    template.Manifest,
+		Parameters:  parameters,
+	}
+}
+
+// PresentResourceTemplate converts a resource template from the API to the openapi representation.
+func PresentResourceTemplate(template *api.ResourceTemplate) openapi.ResourceTemplate {
+	parameters := []openapi.ResourceTemplateParameter{}
+	for _, param := range template.Parameters {
+		parameters = append(parameters, openapi.ResourceTemplateParameter{
+			Name:        openapi.PtrString(param.Name),
+			Description: openapi.PtrString(param.Description),
+			Required:    openapi.PtrBool(param.Required),
+			Value:       openapi.PtrString(param.Value),
+			Type:        openapi.PtrString(string(param.Type)),
+		})
+	}
+
+	return openapi.ResourceTemplate{
+		Id:          openapi.PtrString(template.ID),
+		Kind:        ObjectKind(template),
+		Href:        openapi.PtrString(fmt.Sprintf("%s/resource-templates/%s", BasePath, template.ID)),
+		Name:        openapi.PtrString(template.Name),
+		Description: openapi.PtrString(template.Description),
+		Manifest:    template.Manifest,
+		Parameters:  parameters,
+		CreatedBy:   openapi.PtrString(template.CreatedBy),
+		CreatedAt:   openapi.PtrTime(template.CreatedAt),
+		UpdatedAt:   openapi.PtrTime(template.UpdatedAt),
+	}
+}
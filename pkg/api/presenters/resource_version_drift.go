@@ -0,0 +1,18 @@
+package presenters
+
+import (
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/api/openapi"
+)
+
+func PresentResourceVersionDrift(drift *api.ResourceVersionDrift) openapi.ResourceVersionDrift {
+	return openapi.ResourceVersionDrift{
+		ResourceId:      openapi.PtrString(drift.ResourceID),
+		ConsumerName:    openapi.PtrString(drift.ConsumerName),
+		Type:            openapi.PtrString(string(drift.Type)),
+		Version:         openapi.PtrInt64(drift.Version),
+		ObservedVersion: openapi.PtrInt64(drift.ObservedVersion),
+		State:           openapi.PtrString(string(drift.State)),
+		UpdatedAt:       openapi.PtrTime(drift.UpdatedAt),
+	}
+}
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/datatypes"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	workv1 "open-cluster-management.io/api/work/v1"
+	workpayload "open-cluster-management.io/sdk-go/pkg/cloudevents/work/payload"
+)
+
+// ManifestApplyState is the apply state of a manifest in a resource bundle.
+type ManifestApplyState string
+
+const (
+	ManifestApplied ManifestApplyState = "Applied"
+	ManifestFailed  ManifestApplyState = "Failed"
+	// ManifestPending is the state of a manifest that the agent doesn't report the apply result yet.
+	ManifestPending ManifestApplyState = "Pending"
+)
+
+// ManifestApplyResult is the apply result of a manifest in a resource bundle, it is decoded from the Applied
+// condition of the manifest in the bundle status, see BundleManifestResults.
+type ManifestApplyResult struct {
+	Group     string             `json:"group"`
+	Version   string             `json:"version"`
+	Kind      string             `json:"kind"`
+	Namespace string             `json:"namespace,omitempty"`
+	Name      string             `json:"name"`
+	Applied   bool               `json:"applied"`
+	State     ManifestApplyState `json:"state"`
+	// Message is the message of the Applied condition of a failed manifest, e.g. the apply error.
+	Message string `json:"message,omitempty"`
+}
+
+// BundleManifestResults returns the apply results of the manifests of a resource bundle in the order of the manifests.
+// The manifests are matched with the manifest statuses of the bundle status by their group, kind, namespace and name,
+// so a manifest that is added to the bundle after the reported status is pending, as well as the manifests that the
+// agent reports without the Applied condition. No results are returned if the bundle has no status yet.
+func BundleManifestResults(payload, status datatypes.JSONMap) ([]ManifestApplyResult, error) {
+	if len(status) == 0 {
+		return nil, nil
+	}
+
+	objs, err := DecodeManifestBundleToObjects(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	evt, err := JSONMAPToCloudEvent(status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert resource bundle status to cloudevent: %v", err)
+	}
+	bundleStatus := &workpayload.ManifestBundleStatus{}
+	if err := evt.DataAs(bundleStatus); err != nil {
+		return nil, fmt.Errorf("failed to decode cloudevent data as resource bundle status: %v", err)
+	}
+
+	results := make([]ManifestApplyResult, 0, len(objs))
+	for _, obj := range objs {
+		u := unstructured.Unstructured{Object: obj}
+		gvk := u.GroupVersionKind()
+		result := ManifestApplyResult{
+			Group:     gvk.Group,
+			Version:   gvk.Version,
+			Kind:      gvk.Kind,
+			Namespace: u.GetNamespace(),
+			Name:      u.GetName(),
+			State:     ManifestPending,
+		}
+
+		if condition := findManifestAppliedCondition(bundleStatus.ResourceStatus, result); condition != nil {
+			switch condition.Status {
+			case metav1.ConditionTrue:
+				result.Applied = true
+				result.State = ManifestApplied
+			case metav1.ConditionFalse:
+				result.State = ManifestFailed
+				result.Message = condition.Message
+			}
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// findManifestAppliedCondition returns the Applied condition of the manifest status that matches the manifest, nil
+// is returned if the manifest is not reported or is reported without the Applied condition.
+func findManifestAppliedCondition(manifests []workv1.ManifestCondition, result ManifestApplyResult) *metav1.Condition {
+	for _, manifest := range manifests {
+		resourceMeta := manifest.ResourceMeta
+		if resourceMeta.Group == result.Group && strings.EqualFold(resourceMeta.Kind, result.Kind) &&
+			resourceMeta.Namespace == result.Namespace && resourceMeta.Name == result.Name {
+			return meta.FindStatusCondition(manifest.Conditions, workv1.ManifestApplied)
+		}
+	}
+	return nil
+}
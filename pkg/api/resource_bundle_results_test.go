@@ -0,0 +1,38 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+
+	"gorm.io/datatypes"
+)
+
+func TestBundleManifestResults(t *testing.T) {
+	payload := newJSONMap(t, `{"specversion":"1.0","id":"1","source":"maestro","type":"io.open-cluster-management.works.v1alpha1.manifestbundles.spec.create_request","datacontenttype":"application/json","data":{"manifests":[`+
+		`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"web","namespace":"default"}},`+
+		`{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"web","namespace":"default"}},`+
+		`{"apiVersion":"rbac.authorization.k8s.io/v1","kind":"ClusterRole","metadata":{"name":"web"}}]}}`)
+	status := newJSONMap(t, `{"specversion":"1.0","id":"2","source":"cluster1-work-agent","type":"io.open-cluster-management.works.v1alpha1.manifestbundles.status.update_request","datacontenttype":"application/json","resourceversion":"1","sequenceid":"1","data":{"resourceStatus":[`+
+		`{"resourceMeta":{"ordinal":0,"group":"","version":"v1","kind":"ConfigMap","resource":"configmaps","name":"web","namespace":"default"},"conditions":[{"type":"Applied","status":"True","reason":"AppliedManifestComplete","message":"Apply manifest complete","lastTransitionTime":"2024-05-21T08:56:35Z"}]},`+
+		`{"resourceMeta":{"ordinal":1,"group":"apps","version":"v1","kind":"Deployment","resource":"deployments","name":"web","namespace":"default"},"conditions":[{"type":"Applied","status":"False","reason":"AppliedManifestFailed","message":"Failed to apply manifest: admission webhook denied the request","lastTransitionTime":"2024-05-21T08:56:35Z"}]}]}}`)
+
+	results, err := BundleManifestResults(payload, status)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []ManifestApplyResult{
+		{Version: "v1", Kind: "ConfigMap", Namespace: "default", Name: "web", Applied: true, State: ManifestApplied},
+		{Group: "apps", Version: "v1", Kind: "Deployment", Namespace: "default", Name: "web", State: ManifestFailed,
+			Message: "Failed to apply manifest: admission webhook denied the request"},
+		{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRole", Name: "web", State: ManifestPending},
+	}
+	if !reflect.DeepEqual(results, expected) {
+		t.Errorf("expected %#v but got: %#v", expected, results)
+	}
+
+	// no results are returned without status
+	results, err = BundleManifestResults(payload, datatypes.JSONMap{})
+	if err != nil || results != nil {
+		t.Errorf("expected no results without status, but got %#v, %v", results, err)
+	}
+}
@@ -7,13 +7,14 @@ import (
 	cloudeventstypes "github.com/cloudevents/sdk-go/v2/types"
 	"gorm.io/datatypes"
 
+	workv1 "open-cluster-management.io/api/work/v1"
 	cetypes "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/types"
 	workpayload "open-cluster-management.io/sdk-go/pkg/cloudevents/work/payload"
 	"open-cluster-management.io/sdk-go/pkg/cloudevents/work/source/codec"
 )
 
 type ResourceBundleStatus struct {
-	ObservedVersion int32
+	ObservedVersion int64
 	SequenceID      string
 	*workpayload.ManifestBundleStatus
 }
@@ -51,6 +52,69 @@ func DecodeManifestBundle(manifest datatypes.JSONMap) (map[string]any, *workpayl
 	return metaData, eventPayload, nil
 }
 
+// PatchManifestBundleOptions replaces the delete option and the manifest configs in the CloudEvent JSONMap
+// representation of a resource manifest bundle, a nil delete option or nil manifest configs are kept as they are.
+// The manifests and the CloudEvent extensions of the bundle are not changed.
+func PatchManifestBundleOptions(manifest datatypes.JSONMap, deleteOption map[string]interface{},
+	manifestConfigs []map[string]interface{}) (datatypes.JSONMap, error) {
+	evt, err := JSONMAPToCloudEvent(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert resource manifest to cloudevent: %v", err)
+	}
+
+	eventPayload := &workpayload.ManifestBundle{}
+	if err := evt.DataAs(eventPayload); err != nil {
+		return nil, fmt.Errorf("failed to decode cloudevent payload as resource manifest bundle: %v", err)
+	}
+
+	if deleteOption != nil {
+		delOption := &workv1.DeleteOption{}
+		if err := convertOption(deleteOption, delOption); err != nil {
+			return nil, fmt.Errorf("invalid delete option: %v", err)
+		}
+		eventPayload.DeleteOption = delOption
+	}
+
+	if manifestConfigs != nil {
+		configs := make([]workv1.ManifestConfigOption, 0, len(manifestConfigs))
+		for i, manifestConfig := range manifestConfigs {
+			config := workv1.ManifestConfigOption{}
+			if err := convertOption(manifestConfig, &config); err != nil {
+				return nil, fmt.Errorf("invalid manifest config %d: %v", i, err)
+			}
+			if len(config.ResourceIdentifier.Name) == 0 || len(config.ResourceIdentifier.Resource) == 0 {
+				return nil, fmt.Errorf("invalid manifest config %d: the resource name and resource of the resourceIdentifier are required", i)
+			}
+			if config.UpdateStrategy != nil {
+				if err := ValidateUpdateStrategyType(config.UpdateStrategy.Type); err != nil {
+					return nil, fmt.Errorf("invalid manifest config %d: %v", i, err)
+				}
+			}
+			configs = append(configs, config)
+		}
+		eventPayload.ManifestConfigs = configs
+	}
+
+	if err := evt.SetData(evt.DataContentType(), eventPayload); err != nil {
+		return nil, fmt.Errorf("failed to set cloud event data: %v", err)
+	}
+
+	patched, err := CloudEventToJSONMap(evt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert cloudevent to resource manifest: %v", err)
+	}
+	return patched, nil
+}
+
+// convertOption converts an option of the openapi representation (map[string]interface{}) into its work API type.
+func convertOption(option map[string]interface{}, out interface{}) error {
+	data, err := json.Marshal(option)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
 // DecodeManifestBundleToObjects converts a CloudEvent JSONMap representation of a list of resource manifest
 // into a list of resource object (map[string]interface{}).
 func DecodeManifestBundleToObjects(manifest datatypes.JSONMap) ([]map[string]interface{}, error) {
@@ -92,7 +156,7 @@ func DecodeBundleStatus(status datatypes.JSONMap) (map[string]interface{}, error
 	}
 
 	evtExtensions := evt.Extensions()
-	resourceVersion, err := cloudeventstypes.ToInteger(evtExtensions[cetypes.ExtensionResourceVersion])
+	resourceVersion, err := ToResourceVersion(evtExtensions[cetypes.ExtensionResourceVersion])
 	if err != nil {
 		return nil, fmt.Errorf("failed to get resourceversion extension: %v", err)
 	}
@@ -82,6 +82,64 @@ func TestDecodeManifestBundle(t *testing.T) {
 
 }
 
+func TestPatchManifestBundleOptions(t *testing.T) {
+	bundle := newJSONMap(t, "{\"specversion\":\"1.0\",\"id\":\"266a8cd2-2fab-4e89-9bf0-a56425ebcdf8\",\"source\":\"grpc\",\"type\":\"io.open-cluster-management.works.v1alpha1.manifestbundles.spec.create_request\",\"datacontenttype\":\"application/json\",\"metadata\":{\"name\":\"work1\"},\"data\":{\"manifests\":[{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"nginx\",\"namespace\":\"default\"}}],\"deleteOption\":{\"propagationPolicy\":\"Foreground\"},\"manifestConfigs\":[{\"updateStrategy\":{\"type\":\"ServerSideApply\"},\"resourceIdentifier\":{\"name\":\"nginx\",\"resource\":\"configmaps\",\"namespace\":\"default\"}}]}}")
+
+	// the delete option is replaced and the manifest configs are kept
+	patched, err := PatchManifestBundleOptions(bundle, map[string]interface{}{"propagationPolicy": "Orphan"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	metadata, manifestBundle, err := DecodeManifestBundle(patched)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if metadata["name"] != "work1" {
+		t.Errorf("expected the work metadata is kept, but got %v", metadata)
+	}
+	if manifestBundle.DeleteOption.PropagationPolicy != workv1.DeletePropagationPolicyTypeOrphan {
+		t.Errorf("unexpected delete option %v", manifestBundle.DeleteOption)
+	}
+	if len(manifestBundle.Manifests) != 1 || len(manifestBundle.ManifestConfigs) != 1 ||
+		manifestBundle.ManifestConfigs[0].UpdateStrategy.Type != workv1.UpdateStrategyTypeServerSideApply {
+		t.Errorf("expected the manifests and manifest configs are kept, but got %v", manifestBundle)
+	}
+
+	// the manifest configs are replaced
+	patched, err = PatchManifestBundleOptions(bundle, nil, []map[string]interface{}{
+		{
+			"resourceIdentifier": map[string]interface{}{"name": "nginx", "resource": "configmaps", "namespace": "default"},
+			"updateStrategy":     map[string]interface{}{"type": "ReadOnly"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, manifestBundle, err = DecodeManifestBundle(patched)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if manifestBundle.DeleteOption.PropagationPolicy != workv1.DeletePropagationPolicyTypeForeground {
+		t.Errorf("expected the delete option is kept, but got %v", manifestBundle.DeleteOption)
+	}
+	if manifestBundle.ManifestConfigs[0].UpdateStrategy.Type != workv1.UpdateStrategyTypeReadOnly {
+		t.Errorf("unexpected manifest configs %v", manifestBundle.ManifestConfigs)
+	}
+
+	// the invalid manifest configs are rejected
+	for _, manifestConfig := range []map[string]interface{}{
+		{"updateStrategy": map[string]interface{}{"type": "ReadOnly"}},
+		{
+			"resourceIdentifier": map[string]interface{}{"name": "nginx", "resource": "configmaps"},
+			"updateStrategy":     map[string]interface{}{"type": "Unknown"},
+		},
+	} {
+		if _, err := PatchManifestBundleOptions(bundle, nil, []map[string]interface{}{manifestConfig}); err == nil {
+			t.Errorf("expected error for the manifest config %v", manifestConfig)
+		}
+	}
+}
+
 func TestDecodeManifestBundleToObjects(t *testing.T) {
 	cases := []struct {
 		name             string
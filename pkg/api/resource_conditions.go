@@ -0,0 +1,169 @@
+package api
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/lib/pq"
+	"gorm.io/datatypes"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	workv1 "open-cluster-management.io/api/work/v1"
+	workpayload "open-cluster-management.io/sdk-go/pkg/cloudevents/work/payload"
+)
+
+// ReconcileStaleCondition is the synthetic condition in the conditions summary of a resource whose version is not
+// observed by the agent within the reconcile timeout, e.g. the agent is silent after it receives the spec. It is not
+// reported by the agent, so it is cleared once the conditions summary is refreshed with the next status of the agent.
+const ReconcileStaleCondition = "Stale=" + string(metav1.ConditionUnknown)
+
+// IsReconcileStale returns whether the resource is marked with the ReconcileStaleCondition.
+func (r *Resource) IsReconcileStale() bool {
+	return slices.Contains(r.Conditions, ReconcileStaleCondition)
+}
+
+// ConditionsSummary returns the summary of the reconcile conditions of the resource status, each condition is
+// summarized as a "<type>=<status>" pair and the pairs are sorted. The summarized conditions are the ones presented
+// in the resource status, see DecodeConditions. An empty summary is returned if the resource has no status yet.
+func ConditionsSummary(resourceType ResourceType, status datatypes.JSONMap) (pq.StringArray, error) {
+	conditions, err := DecodeConditions(resourceType, status)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := pq.StringArray{}
+	for _, condition := range conditions {
+		summary = append(summary, fmt.Sprintf("%s=%s", condition.Type, condition.Status))
+	}
+	sort.Strings(summary)
+	return summary, nil
+}
+
+// DecodeConditions decodes the reconcile conditions from the CloudEvent JSONMap representation of the resource status,
+// the conditions are the manifest conditions for a single resource and the manifest bundle conditions for a resource
+// bundle. No conditions are returned if the resource has no status yet.
+func DecodeConditions(resourceType ResourceType, status datatypes.JSONMap) ([]metav1.Condition, error) {
+	if len(status) == 0 {
+		return nil, nil
+	}
+
+	evt, err := JSONMAPToCloudEvent(status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert resource status to cloudevent: %v", err)
+	}
+
+	switch resourceType {
+	case ResourceTypeBundle:
+		eventPayload := &workpayload.ManifestBundleStatus{}
+		if err := evt.DataAs(eventPayload); err != nil {
+			return nil, fmt.Errorf("failed to decode cloudevent data as resource bundle status: %v", err)
+		}
+		return eventPayload.Conditions, nil
+	default:
+		eventPayload := &workpayload.ManifestStatus{}
+		if err := evt.DataAs(eventPayload); err != nil {
+			return nil, fmt.Errorf("failed to decode cloudevent data as resource status: %v", err)
+		}
+		if eventPayload.Status == nil {
+			return nil, nil
+		}
+		return eventPayload.Status.Conditions, nil
+	}
+}
+
+// GetCondition returns the reconcile condition of the given type in the resource status, false is returned if the
+// resource has no such condition or its status cannot be decoded.
+func (d *Resource) GetCondition(conditionType string) (*metav1.Condition, bool) {
+	conditions, err := DecodeConditions(d.Type, d.Status)
+	if err != nil {
+		return nil, false
+	}
+	return findCondition(conditions, conditionType)
+}
+
+// IsReady returns true if the resource is applied and available on the agent, see ReconcileStatus.IsReady.
+func (d *Resource) IsReady() bool {
+	conditions, err := DecodeConditions(d.Type, d.Status)
+	if err != nil {
+		return false
+	}
+	return (&ReconcileStatus{Conditions: conditions}).IsReady()
+}
+
+// GetCondition returns the reconcile condition of the given type, false is returned if there is no such condition.
+func (s *ReconcileStatus) GetCondition(conditionType string) (*metav1.Condition, bool) {
+	if s == nil {
+		return nil, false
+	}
+	return findCondition(s.Conditions, conditionType)
+}
+
+// IsReady returns true if both the Applied and Available conditions are true.
+func (s *ReconcileStatus) IsReady() bool {
+	if s == nil {
+		return false
+	}
+	return meta.IsStatusConditionTrue(s.Conditions, workv1.WorkApplied) &&
+		meta.IsStatusConditionTrue(s.Conditions, workv1.WorkAvailable)
+}
+
+func findCondition(conditions []metav1.Condition, conditionType string) (*metav1.Condition, bool) {
+	condition := meta.FindStatusCondition(conditions, conditionType)
+	if condition == nil {
+		return nil, false
+	}
+	copied := *condition
+	return &copied, true
+}
+
+// ConditionTransitioned returns true if the status of the given condition type is changed by the status update that
+// the resource is broadcast for, e.g. Available from True to False, a condition that is added or removed by the update
+// is transitioned as well. It always returns true if the resource is not broadcast for a status update, see
+// Resource.PreviousConditions.
+func (d *Resource) ConditionTransitioned(conditionType string) bool {
+	if d.PreviousConditions == nil {
+		return true
+	}
+	return conditionStatus(d.PreviousConditions, conditionType) != conditionStatus(d.Conditions, conditionType)
+}
+
+// conditionStatus returns the status of the given condition type in the conditions summary, an empty status is
+// returned if there is no such condition.
+func conditionStatus(summary pq.StringArray, conditionType string) string {
+	for _, condition := range summary {
+		if t, s, found := strings.Cut(condition, "="); found && t == conditionType {
+			return s
+		}
+	}
+	return ""
+}
+
+// ParseConditionFilter parses the comma-separated condition filter, e.g. "Applied=True,Degraded=True", into
+// the "<type>=<status>" pairs of the conditions summary, a resource matches the filter if it has all the conditions.
+func ParseConditionFilter(filter string) ([]string, error) {
+	conditions := []string{}
+	for _, condition := range strings.Split(filter, ",") {
+		condition = strings.TrimSpace(condition)
+		if len(condition) == 0 {
+			continue
+		}
+
+		conditionType, conditionStatus, found := strings.Cut(condition, "=")
+		if !found || len(conditionType) == 0 {
+			return nil, fmt.Errorf("invalid condition %q, it must be in the format of <type>=<status>", condition)
+		}
+
+		switch metav1.ConditionStatus(conditionStatus) {
+		case metav1.ConditionTrue, metav1.ConditionFalse, metav1.ConditionUnknown:
+		default:
+			return nil, fmt.Errorf("invalid condition status %q, it must be one of %s, %s or %s",
+				conditionStatus, metav1.ConditionTrue, metav1.ConditionFalse, metav1.ConditionUnknown)
+		}
+
+		conditions = append(conditions, condition)
+	}
+	return conditions, nil
+}
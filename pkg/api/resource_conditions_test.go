@@ -0,0 +1,204 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/lib/pq"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestConditionsSummary(t *testing.T) {
+	cases := []struct {
+		name         string
+		resourceType ResourceType
+		status       string
+		expected     pq.StringArray
+	}{
+		{
+			name:         "no status",
+			resourceType: ResourceTypeSingle,
+			expected:     pq.StringArray{},
+		},
+		{
+			name:         "single resource without reconcile status",
+			resourceType: ResourceTypeSingle,
+			status:       "{\"specversion\":\"1.0\",\"id\":\"1\",\"source\":\"test\",\"type\":\"io.open-cluster-management.works.v1alpha1.manifests.status.update_request\",\"datacontenttype\":\"application/json\",\"data\":{\"conditions\":[]}}",
+			expected:     pq.StringArray{},
+		},
+		{
+			name:         "single resource",
+			resourceType: ResourceTypeSingle,
+			status:       "{\"specversion\":\"1.0\",\"id\":\"1\",\"source\":\"test\",\"type\":\"io.open-cluster-management.works.v1alpha1.manifests.status.update_request\",\"datacontenttype\":\"application/json\",\"data\":{\"conditions\":[{\"type\":\"Applied\",\"status\":\"True\",\"lastTransitionTime\":\"2024-01-01T00:00:00Z\",\"reason\":\"\",\"message\":\"\"}],\"status\":{\"conditions\":[{\"type\":\"Degraded\",\"status\":\"True\",\"lastTransitionTime\":\"2024-01-01T00:00:00Z\",\"reason\":\"\",\"message\":\"\"},{\"type\":\"Applied\",\"status\":\"True\",\"lastTransitionTime\":\"2024-01-01T00:00:00Z\",\"reason\":\"\",\"message\":\"\"}]}}}",
+			expected:     pq.StringArray{"Applied=True", "Degraded=True"},
+		},
+		{
+			name:         "resource bundle",
+			resourceType: ResourceTypeBundle,
+			status:       "{\"specversion\":\"1.0\",\"id\":\"1\",\"source\":\"test\",\"type\":\"io.open-cluster-management.works.v1alpha1.manifestbundles.status.update_request\",\"datacontenttype\":\"application/json\",\"data\":{\"conditions\":[{\"type\":\"Available\",\"status\":\"False\",\"lastTransitionTime\":\"2024-01-01T00:00:00Z\",\"reason\":\"\",\"message\":\"\"},{\"type\":\"Applied\",\"status\":\"True\",\"lastTransitionTime\":\"2024-01-01T00:00:00Z\",\"reason\":\"\",\"message\":\"\"}]}}",
+			expected:     pq.StringArray{"Applied=True", "Available=False"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var status map[string]interface{}
+			if len(c.status) != 0 {
+				status = newJSONMap(t, c.status)
+			}
+			got, err := ConditionsSummary(c.resourceType, status)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, c.expected) {
+				t.Errorf("expected %v but got: %v", c.expected, got)
+			}
+		})
+	}
+}
+
+func TestParseConditionFilter(t *testing.T) {
+	cases := []struct {
+		filter      string
+		expected    []string
+		expectedErr bool
+	}{
+		{filter: "", expected: []string{}},
+		{filter: "Degraded=True", expected: []string{"Degraded=True"}},
+		{filter: "Applied=True, Degraded=False,", expected: []string{"Applied=True", "Degraded=False"}},
+		{filter: "Degraded", expectedErr: true},
+		{filter: "=True", expectedErr: true},
+		{filter: "Degraded=true", expectedErr: true},
+	}
+	for _, c := range cases {
+		t.Run(c.filter, func(t *testing.T) {
+			got, err := ParseConditionFilter(c.filter)
+			if (err != nil) != c.expectedErr {
+				t.Fatalf("expected error %v but got: %v", c.expectedErr, err)
+			}
+			if !c.expectedErr && !reflect.DeepEqual(got, c.expected) {
+				t.Errorf("expected %v but got: %v", c.expected, got)
+			}
+		})
+	}
+}
+
+func TestConditionTransitioned(t *testing.T) {
+	cases := []struct {
+		name       string
+		previous   pq.StringArray
+		conditions pq.StringArray
+		expected   bool
+	}{
+		{
+			name:       "not a status update",
+			conditions: pq.StringArray{"Available=True"},
+			expected:   true,
+		},
+		{
+			name:       "transitioned",
+			previous:   pq.StringArray{"Applied=True", "Available=True"},
+			conditions: pq.StringArray{"Applied=True", "Available=False"},
+			expected:   true,
+		},
+		{
+			name:       "added",
+			previous:   pq.StringArray{},
+			conditions: pq.StringArray{"Available=True"},
+			expected:   true,
+		},
+		{
+			name:       "other condition transitioned",
+			previous:   pq.StringArray{"Applied=False", "Available=True"},
+			conditions: pq.StringArray{"Applied=True", "Available=True"},
+			expected:   false,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			res := &Resource{Conditions: c.conditions, PreviousConditions: c.previous}
+			if got := res.ConditionTransitioned("Available"); got != c.expected {
+				t.Errorf("expected %v but got: %v", c.expected, got)
+			}
+		})
+	}
+}
+
+func TestResourceConditions(t *testing.T) {
+	cases := []struct {
+		name          string
+		resource      *Resource
+		expectedFound bool
+		expectedReady bool
+	}{
+		{
+			name:     "no status",
+			resource: &Resource{Type: ResourceTypeSingle},
+		},
+		{
+			name:     "empty status",
+			resource: &Resource{Type: ResourceTypeSingle, Status: map[string]interface{}{}},
+		},
+		{
+			name:     "invalid status",
+			resource: &Resource{Type: ResourceTypeSingle, Status: map[string]interface{}{"specversion": "1.0"}},
+		},
+		{
+			name: "single resource without reconcile status",
+			resource: &Resource{Type: ResourceTypeSingle, Status: newJSONMap(t,
+				"{\"specversion\":\"1.0\",\"id\":\"1\",\"source\":\"test\",\"type\":\"io.open-cluster-management.works.v1alpha1.manifests.status.update_request\",\"datacontenttype\":\"application/json\",\"data\":{\"conditions\":[]}}")},
+		},
+		{
+			name: "single resource is applied",
+			resource: &Resource{Type: ResourceTypeSingle, Status: newJSONMap(t,
+				"{\"specversion\":\"1.0\",\"id\":\"1\",\"source\":\"test\",\"type\":\"io.open-cluster-management.works.v1alpha1.manifests.status.update_request\",\"datacontenttype\":\"application/json\",\"data\":{\"status\":{\"conditions\":[{\"type\":\"Applied\",\"status\":\"True\",\"lastTransitionTime\":\"2024-01-01T00:00:00Z\",\"reason\":\"AppliedManifestComplete\",\"message\":\"\"},{\"type\":\"Available\",\"status\":\"False\",\"lastTransitionTime\":\"2024-01-01T00:00:00Z\",\"reason\":\"\",\"message\":\"\"}]}}}")},
+			expectedFound: true,
+		},
+		{
+			name: "resource bundle is ready",
+			resource: &Resource{Type: ResourceTypeBundle, Status: newJSONMap(t,
+				"{\"specversion\":\"1.0\",\"id\":\"1\",\"source\":\"test\",\"type\":\"io.open-cluster-management.works.v1alpha1.manifestbundles.status.update_request\",\"datacontenttype\":\"application/json\",\"data\":{\"conditions\":[{\"type\":\"Available\",\"status\":\"True\",\"lastTransitionTime\":\"2024-01-01T00:00:00Z\",\"reason\":\"\",\"message\":\"\"},{\"type\":\"Applied\",\"status\":\"True\",\"lastTransitionTime\":\"2024-01-01T00:00:00Z\",\"reason\":\"AppliedManifestComplete\",\"message\":\"\"}]}}")},
+			expectedFound: true,
+			expectedReady: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			condition, found := c.resource.GetCondition("Applied")
+			if found != c.expectedFound {
+				t.Fatalf("expected found %v but got: %v", c.expectedFound, found)
+			}
+			if found && (condition.Status != "True" || condition.Reason != "AppliedManifestComplete") {
+				t.Errorf("unexpected condition: %v", condition)
+			}
+			if ready := c.resource.IsReady(); ready != c.expectedReady {
+				t.Errorf("expected ready %v but got: %v", c.expectedReady, ready)
+			}
+		})
+	}
+}
+
+func TestReconcileStatusConditions(t *testing.T) {
+	var status *ReconcileStatus
+	if _, found := status.GetCondition("Applied"); found {
+		t.Errorf("expected no condition for the nil status")
+	}
+	if status.IsReady() {
+		t.Errorf("expected the nil status is not ready")
+	}
+
+	status = &ReconcileStatus{Conditions: []metav1.Condition{{Type: "Applied", Status: metav1.ConditionTrue}}}
+	condition, found := status.GetCondition("Applied")
+	if !found || condition.Status != metav1.ConditionTrue {
+		t.Errorf("unexpected condition: %v", condition)
+	}
+	// the returned condition is a copy
+	condition.Status = metav1.ConditionFalse
+	if status.Conditions[0].Status != metav1.ConditionTrue {
+		t.Errorf("expected the status is not changed")
+	}
+	if status.IsReady() {
+		t.Errorf("expected the status without the Available condition is not ready")
+	}
+}
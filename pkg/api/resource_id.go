@@ -1,11 +1,64 @@
 package api
 
-import "github.com/google/uuid"
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/google/uuid"
+)
+
+// IDGenerator generates the IDs of the API objects.
+type IDGenerator func() string
+
+var (
+	idGeneratorLock sync.RWMutex
+	idGenerator     IDGenerator = uuid.NewString
+)
 
 func NewID() string {
 	// resource id will be the k8s resource ".metadata.name",
 	// it must be validated with following regex expression:
 	// '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*'
 	// here use uuid as resource id because ksuid is not a valid k8s resource name
-	return uuid.NewString()
+	idGeneratorLock.RLock()
+	generate := idGenerator
+	idGeneratorLock.RUnlock()
+	return generate()
+}
+
+// SetIDGenerator replaces the generator of NewID and returns a function to restore the previous generator, it's only
+// for the tests to assert on the IDs, e.g.
+//
+//	defer api.SetIDGenerator(api.NewSequentialIDGenerator())()
+//
+// It is safe to call it concurrently with NewID, but the tests replacing the generator should not run in parallel.
+func SetIDGenerator(generator IDGenerator) func() {
+	idGeneratorLock.Lock()
+	defer idGeneratorLock.Unlock()
+
+	previous := idGenerator
+	idGenerator = generator
+	return func() {
+		idGeneratorLock.Lock()
+		defer idGeneratorLock.Unlock()
+		idGenerator = previous
+	}
+}
+
+// NewSequentialIDGenerator returns a deterministic generator of the UUID-formatted IDs in sequence, starting from
+// 00000000-0000-0000-0000-000000000001. It is safe for concurrent use.
+func NewSequentialIDGenerator() IDGenerator {
+	var sequence atomic.Uint64
+	return func() string {
+		return fmt.Sprintf("00000000-0000-0000-0000-%012d", sequence.Add(1))
+	}
+}
+
+// DeletionEventID returns the ID of the CloudEvent that requests the agent to delete the resource, the ID is derived
+// from the resource ID, version and deletion timestamp, so a redelivery of the deletion (e.g. the dispatch is retried
+// after a restart) has the same event ID and can be deduplicated by the receivers.
+func DeletionEventID(resource *Resource) string {
+	deletion := fmt.Sprintf("%s/%d/%d", resource.ID, resource.Version, resource.GetDeletionTimestamp().UnixNano())
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte(deletion)).String()
 }
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func TestSetIDGenerator(t *testing.T) {
+	restore := SetIDGenerator(NewSequentialIDGenerator())
+
+	for _, expected := range []string{"00000000-0000-0000-0000-000000000001", "00000000-0000-0000-0000-000000000002"} {
+		if id := NewID(); id != expected {
+			t.Errorf("expected %s, but got %s", expected, id)
+		}
+	}
+
+	resource := &Resource{}
+	if err := resource.BeforeCreate(nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resource.ID != "00000000-0000-0000-0000-000000000003" {
+		t.Errorf("unexpected resource id %s", resource.ID)
+	}
+
+	restore()
+	if _, err := uuid.Parse(NewID()); err != nil {
+		t.Errorf("expected a random uuid after the generator is restored, but got error: %v", err)
+	}
+}
+
+func TestSequentialIDGeneratorConcurrency(t *testing.T) {
+	generate := NewSequentialIDGenerator()
+
+	var mu sync.Mutex
+	ids := map[string]bool{}
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				id := generate()
+				mu.Lock()
+				ids[id] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(ids) != 1000 {
+		t.Errorf("expected 1000 unique ids, but got %d", len(ids))
+	}
+}
+
+func TestDeletionEventID(t *testing.T) {
+	deletedAt := time.Now()
+	resource := &Resource{Meta: Meta{ID: "test", DeletedAt: gorm.DeletedAt{Time: deletedAt, Valid: true}}, Version: 1}
+
+	id := DeletionEventID(resource)
+	if _, err := uuid.Parse(id); err != nil {
+		t.Errorf("expected a UUID, but got %s: %v", id, err)
+	}
+	if redelivered := DeletionEventID(resource); redelivered != id {
+		t.Errorf("expected the redelivered deletion has the event ID %s, but got %s", id, redelivered)
+	}
+
+	resource.Version = 2
+	if DeletionEventID(resource) == id {
+		t.Errorf("expected the deletion of another resource version has another event ID")
+	}
+}
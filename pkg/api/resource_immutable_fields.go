@@ -0,0 +1,61 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ImmutableFields are the field paths of the manifests that cannot be changed once the manifests are applied, e.g.
+// spec.volumeClaimTemplates of a StatefulSet, per GVK. Changing such a field fails to apply the manifest on the
+// agent, the failure is only reported by the resource status, so the updates that change the fields are rejected
+// up front instead.
+type ImmutableFields map[schema.GroupVersionKind][]string
+
+// ParseImmutableFields parses the immutable fields in the form of <apiVersion>/<kind>:<path>, e.g.
+// apps/v1/StatefulSet:spec.volumeClaimTemplates or v1/PersistentVolumeClaim:spec.storageClassName.
+func ParseImmutableFields(fields []string) (ImmutableFields, error) {
+	immutableFields := ImmutableFields{}
+	for _, field := range fields {
+		key, path, ok := strings.Cut(field, ":")
+		i := strings.LastIndex(key, "/")
+		if !ok || i <= 0 || i == len(key)-1 || len(path) == 0 {
+			return nil, fmt.Errorf("invalid immutable field %q, the format is <apiVersion>/<kind>:<path>", field)
+		}
+		gv, err := schema.ParseGroupVersion(key[:i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid immutable field %q: %v", field, err)
+		}
+		gvk := gv.WithKind(key[i+1:])
+		immutableFields[gvk] = append(immutableFields[gvk], path)
+	}
+	return immutableFields, nil
+}
+
+// Validate returns an error naming the first immutable field that is changed from the found manifest to the given
+// manifest, a field that is added or removed is changed. The manifests of different GVKs are not compared.
+func (f ImmutableFields) Validate(found, manifest map[string]interface{}) error {
+	if len(f) == 0 {
+		return nil
+	}
+
+	foundObj := &unstructured.Unstructured{Object: found}
+	obj := &unstructured.Unstructured{Object: manifest}
+	gvk := obj.GroupVersionKind()
+	if foundObj.GroupVersionKind() != gvk {
+		return nil
+	}
+
+	for _, path := range f[gvk] {
+		fields := strings.Split(path, ".")
+		foundValue, foundExists, _ := unstructured.NestedFieldNoCopy(found, fields...)
+		value, exists, _ := unstructured.NestedFieldNoCopy(manifest, fields...)
+		if foundExists != exists || !equality.Semantic.DeepEqual(foundValue, value) {
+			return fmt.Errorf("the field %s of the %s %s is immutable", path, gvk.Kind, obj.GetName())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestParseImmutableFields(t *testing.T) {
+	fields, err := ParseImmutableFields([]string{
+		"apps/v1/StatefulSet:spec.volumeClaimTemplates",
+		"apps/v1/StatefulSet:spec.selector",
+		"v1/PersistentVolumeClaim:spec.storageClassName",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(fields) != 2 {
+		t.Errorf("expected 2 GVKs, but got %v", fields)
+	}
+
+	for _, field := range []string{"StatefulSet:spec.selector", "apps/v1/StatefulSet", "apps/v1/:spec", "/StatefulSet:spec", "a/b/c/Kind:spec"} {
+		if _, err := ParseImmutableFields([]string{field}); err == nil {
+			t.Errorf("expected error for %q, but got nil", field)
+		}
+	}
+}
+
+func TestImmutableFieldsValidate(t *testing.T) {
+	fields, err := ParseImmutableFields([]string{"apps/v1/StatefulSet:spec.volumeClaimTemplates"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	found := "{\"apiVersion\":\"apps/v1\",\"kind\":\"StatefulSet\",\"metadata\":{\"name\":\"test\"},\"spec\":{\"replicas\":1,\"volumeClaimTemplates\":[{\"metadata\":{\"name\":\"data\"}}]}}"
+	cases := []struct {
+		name        string
+		fields      ImmutableFields
+		manifest    string
+		expectedErr string
+	}{
+		{
+			name:     "no immutable fields",
+			manifest: "{\"apiVersion\":\"apps/v1\",\"kind\":\"StatefulSet\",\"metadata\":{\"name\":\"test\"},\"spec\":{\"replicas\":1}}",
+		},
+		{
+			name:     "mutable field is changed",
+			fields:   fields,
+			manifest: "{\"apiVersion\":\"apps/v1\",\"kind\":\"StatefulSet\",\"metadata\":{\"name\":\"test\"},\"spec\":{\"replicas\":2,\"volumeClaimTemplates\":[{\"metadata\":{\"name\":\"data\"}}]}}",
+		},
+		{
+			name:        "immutable field is changed",
+			fields:      fields,
+			manifest:    "{\"apiVersion\":\"apps/v1\",\"kind\":\"StatefulSet\",\"metadata\":{\"name\":\"test\"},\"spec\":{\"replicas\":1,\"volumeClaimTemplates\":[{\"metadata\":{\"name\":\"logs\"}}]}}",
+			expectedErr: "the field spec.volumeClaimTemplates of the StatefulSet test is immutable",
+		},
+		{
+			name:        "immutable field is removed",
+			fields:      fields,
+			manifest:    "{\"apiVersion\":\"apps/v1\",\"kind\":\"StatefulSet\",\"metadata\":{\"name\":\"test\"},\"spec\":{\"replicas\":1}}",
+			expectedErr: "the field spec.volumeClaimTemplates of the StatefulSet test is immutable",
+		},
+		{
+			name:     "different GVK",
+			fields:   fields,
+			manifest: "{\"apiVersion\":\"apps/v1\",\"kind\":\"Deployment\",\"metadata\":{\"name\":\"test\"},\"spec\":{\"replicas\":1}}",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			foundManifest := map[string]interface{}{}
+			if err := json.Unmarshal([]byte(found), &foundManifest); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			manifest := map[string]interface{}{}
+			if err := json.Unmarshal([]byte(c.manifest), &manifest); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			err := c.fields.Validate(foundManifest, manifest)
+			if len(c.expectedErr) == 0 {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), c.expectedErr) {
+				t.Errorf("expected error %q, but got: %v", c.expectedErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// KindPattern is a pattern of the manifest kinds, the group, the version and the kind can be a * wildcard, and the
+// group can be a *.<suffix> wildcard too.
+type KindPattern struct {
+	Group   string
+	Version string
+	Kind    string
+}
+
+// KindPatterns are the kind patterns of the manifests, a kind matches the patterns if it matches any of them.
+type KindPatterns []KindPattern
+
+// ParseKindPatterns parses the kind patterns in the form of <apiVersion>/<kind>, e.g. apps/v1/Deployment,
+// v1/ConfigMap or *.example.com/*/*, an apiVersion of * matches all the groups and versions.
+func ParseKindPatterns(patterns []string) (KindPatterns, error) {
+	kindPatterns := KindPatterns{}
+	for _, pattern := range patterns {
+		i := strings.LastIndex(pattern, "/")
+		if i <= 0 || i == len(pattern)-1 {
+			return nil, fmt.Errorf("invalid kind pattern %q, the format is <apiVersion>/<kind>", pattern)
+		}
+		apiVersion, kind := pattern[:i], pattern[i+1:]
+		kindPattern := KindPattern{Group: "*", Version: "*", Kind: kind}
+		if apiVersion != "*" {
+			group, version, found := strings.Cut(apiVersion, "/")
+			if !found {
+				group, version = "", apiVersion
+			}
+			if (found && len(group) == 0) || len(version) == 0 || strings.Contains(version, "/") {
+				return nil, fmt.Errorf("invalid kind pattern %q, the apiVersion is <group>/<version> or <version>", pattern)
+			}
+			kindPattern.Group, kindPattern.Version = group, version
+		}
+		for _, segment := range []string{kindPattern.Group, kindPattern.Version, kindPattern.Kind} {
+			if segment == "*" || !strings.Contains(segment, "*") {
+				continue
+			}
+			if segment != kindPattern.Group || !strings.HasPrefix(segment, "*.") || strings.Count(segment, "*") != 1 {
+				return nil, fmt.Errorf("invalid kind pattern %q, a wildcard must be * or a group of *.<suffix>", pattern)
+			}
+		}
+		kindPatterns = append(kindPatterns, kindPattern)
+	}
+	return kindPatterns, nil
+}
+
+// Matches tells whether the gvk matches the pattern.
+func (p KindPattern) Matches(gvk schema.GroupVersionKind) bool {
+	return matchesKindSegment(p.Group, gvk.Group) && matchesKindSegment(p.Version, gvk.Version) &&
+		matchesKindSegment(p.Kind, gvk.Kind)
+}
+
+// Matches tells whether the gvk matches any of the patterns.
+func (p KindPatterns) Matches(gvk schema.GroupVersionKind) bool {
+	for _, pattern := range p {
+		if pattern.Matches(gvk) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesKindSegment(pattern, value string) bool {
+	if pattern == "*" || pattern == value {
+		return true
+	}
+	return strings.HasPrefix(pattern, "*.") && strings.HasSuffix(value, pattern[1:])
+}
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestParseKindPatterns(t *testing.T) {
+	patterns, err := ParseKindPatterns([]string{"apps/v1/Deployment", "v1/ConfigMap", "*.example.com/*/*", "*/Secret"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := KindPatterns{
+		{Group: "apps", Version: "v1", Kind: "Deployment"},
+		{Group: "", Version: "v1", Kind: "ConfigMap"},
+		{Group: "*.example.com", Version: "*", Kind: "*"},
+		{Group: "*", Version: "*", Kind: "Secret"},
+	}
+	if len(patterns) != len(expected) {
+		t.Fatalf("expected %v, but got %v", expected, patterns)
+	}
+	for i := range expected {
+		if patterns[i] != expected[i] {
+			t.Errorf("expected %v, but got %v", expected[i], patterns[i])
+		}
+	}
+
+	for _, pattern := range []string{"Deployment", "apps/v1/", "/Deployment", "apps//Deployment", "/v1/Deployment",
+		"a/b/c/Deployment", "app*/v1/Deployment", "apps/v*/Deployment", "apps/v1/Deploy*", "*.*.com/v1/Widget"} {
+		if _, err := ParseKindPatterns([]string{pattern}); err == nil {
+			t.Errorf("expected error for %q, but got nil", pattern)
+		}
+	}
+}
+
+func TestKindPatternsMatches(t *testing.T) {
+	patterns, err := ParseKindPatterns([]string{"v1/ConfigMap", "*.example.com/*/*"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cases := map[schema.GroupVersionKind]bool{
+		{Version: "v1", Kind: "ConfigMap"}:                                 true,
+		{Group: "apps", Version: "v1", Kind: "ConfigMap"}:                  false,
+		{Version: "v1", Kind: "Secret"}:                                    false,
+		{Group: "widgets.example.com", Version: "v1beta1", Kind: "Widget"}: true,
+		{Group: "example.com", Version: "v1", Kind: "Widget"}:              false,
+	}
+	for gvk, matches := range cases {
+		if patterns.Matches(gvk) != matches {
+			t.Errorf("expected %v matches %v, but got %v", gvk, matches, !matches)
+		}
+	}
+}
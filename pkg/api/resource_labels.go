@@ -0,0 +1,99 @@
+package api
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/lib/pq"
+	"gorm.io/datatypes"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// LabelPropagation selects the labels of the resource manifests that are mirrored to the labels of the resource, so
+// the resources can be filtered by their manifest labels with the indexed labels column rather than the payload. A
+// manifest label is selected if its key is one of the Keys or has one of the Prefixes, e.g. "app.kubernetes.io/".
+type LabelPropagation struct {
+	Keys     []string
+	Prefixes []string
+}
+
+// Enabled returns true if any manifest label can be selected.
+func (p *LabelPropagation) Enabled() bool {
+	return p != nil && (len(p.Keys) != 0 || len(p.Prefixes) != 0)
+}
+
+func (p *LabelPropagation) selects(key string) bool {
+	for _, k := range p.Keys {
+		if key == k {
+			return true
+		}
+	}
+	for _, prefix := range p.Prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Labels returns the selected labels of the resource manifests as the sorted "<key>=<value>" pairs, the labels of a
+// resource bundle are the labels of all its manifests, and the first manifest wins if the manifests have different
+// values of a label. No labels are returned if the propagation is not enabled.
+func (p *LabelPropagation) Labels(resourceType ResourceType, payload datatypes.JSONMap) (pq.StringArray, error) {
+	if !p.Enabled() {
+		return nil, nil
+	}
+
+	var objs []map[string]interface{}
+	switch resourceType {
+	case ResourceTypeBundle:
+		decoded, err := DecodeManifestBundleToObjects(payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode manifest bundle: %v", err)
+		}
+		objs = decoded
+	default:
+		obj, _, _, err := DecodeManifest(payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode manifest: %v", err)
+		}
+		objs = []map[string]interface{}{obj}
+	}
+
+	selected := map[string]string{}
+	for _, obj := range objs {
+		for key, value := range (&unstructured.Unstructured{Object: obj}).GetLabels() {
+			if _, ok := selected[key]; ok || !p.selects(key) {
+				continue
+			}
+			selected[key] = value
+		}
+	}
+
+	labels := pq.StringArray{}
+	for key, value := range selected {
+		labels = append(labels, key+"="+value)
+	}
+	sort.Strings(labels)
+	return labels, nil
+}
+
+// ParseLabelFilter parses the comma-separated label filter, e.g. "app.kubernetes.io/name=web,env=prod", into the
+// "<key>=<value>" pairs of the resource labels, a resource matches the filter if it has all the labels.
+func ParseLabelFilter(filter string) ([]string, error) {
+	labels := []string{}
+	for _, label := range strings.Split(filter, ",") {
+		label = strings.TrimSpace(label)
+		if len(label) == 0 {
+			continue
+		}
+
+		if key, _, found := strings.Cut(label, "="); !found || len(key) == 0 {
+			return nil, fmt.Errorf("invalid label %q, it must be in the format of <key>=<value>", label)
+		}
+
+		labels = append(labels, label)
+	}
+	return labels, nil
+}
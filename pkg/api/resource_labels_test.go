@@ -0,0 +1,92 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/lib/pq"
+)
+
+func TestLabelPropagation(t *testing.T) {
+	manifest := map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name": "nginx",
+			"labels": map[string]interface{}{
+				"app.kubernetes.io/name":    "nginx",
+				"app.kubernetes.io/part-of": "web",
+				"env":                       "prod",
+				"tier":                      "frontend",
+			},
+		},
+	}
+	payload, err := EncodeManifest(manifest, nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	bundle := newJSONMap(t, "{\"specversion\":\"1.0\",\"datacontenttype\":\"application/json\",\"data\":{\"manifests\":[{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"nginx\",\"namespace\":\"default\",\"labels\":{\"env\":\"prod\"}}},{\"apiVersion\":\"apps/v1\",\"kind\":\"Deployment\",\"metadata\":{\"name\":\"nginx\",\"namespace\":\"default\",\"labels\":{\"env\":\"dev\",\"app.kubernetes.io/name\":\"nginx\"}}}]}}")
+
+	cases := []struct {
+		name         string
+		propagation  *LabelPropagation
+		resourceType ResourceType
+		payload      map[string]interface{}
+		expected     pq.StringArray
+	}{
+		{
+			name:         "disabled",
+			resourceType: ResourceTypeSingle,
+			payload:      payload,
+		},
+		{
+			name:         "keys and prefixes",
+			propagation:  &LabelPropagation{Keys: []string{"env"}, Prefixes: []string{"app.kubernetes.io/"}},
+			resourceType: ResourceTypeSingle,
+			payload:      payload,
+			expected:     pq.StringArray{"app.kubernetes.io/name=nginx", "app.kubernetes.io/part-of=web", "env=prod"},
+		},
+		{
+			name:         "no selected labels",
+			propagation:  &LabelPropagation{Keys: []string{"team"}},
+			resourceType: ResourceTypeSingle,
+			payload:      payload,
+			expected:     pq.StringArray{},
+		},
+		{
+			name:         "the first manifest of a bundle wins",
+			propagation:  &LabelPropagation{Keys: []string{"env", "app.kubernetes.io/name"}},
+			resourceType: ResourceTypeBundle,
+			payload:      bundle,
+			expected:     pq.StringArray{"app.kubernetes.io/name=nginx", "env=prod"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			labels, err := c.propagation.Labels(c.resourceType, c.payload)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(labels, c.expected) {
+				t.Errorf("expected labels %v, but got %v", c.expected, labels)
+			}
+		})
+	}
+}
+
+func TestParseLabelFilter(t *testing.T) {
+	labels, err := ParseLabelFilter("app.kubernetes.io/name=nginx, env=")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(labels, []string{"app.kubernetes.io/name=nginx", "env="}) {
+		t.Errorf("unexpected labels %v", labels)
+	}
+
+	for _, filter := range []string{"env", "=prod"} {
+		if _, err := ParseLabelFilter(filter); err == nil {
+			t.Errorf("expected error for the label filter %q", filter)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package api
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ResourceLock is an advisory soft-lock of a resource, e.g. held by an operator editing the resource through a UI, so
+// the resource is not patched or deleted by another owner until the lock is released or expired.
+type ResourceLock struct {
+	Meta
+	ResourceID string
+	// Owner is the client who holds the lock.
+	Owner      string
+	AcquiredAt time.Time
+	ExpiresAt  time.Time
+}
+
+func (l *ResourceLock) BeforeCreate(tx *gorm.DB) error {
+	l.ID = NewID()
+	return nil
+}
+
+// IsExpired returns true if the lock is expired at the given time.
+func (l *ResourceLock) IsExpired(now time.Time) bool {
+	return !now.Before(l.ExpiresAt)
+}
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// DefaultNamespaceAnnotation is the annotation of a manifest to override the default namespace for the manifest, the
+// default namespace is not applied to the manifest if the annotation value is empty.
+const DefaultNamespaceAnnotation = "maestro.open-cluster-management.io/default-namespace"
+
+// NamespaceDefaults is the default namespace applied to the namespaceless manifests of the namespaced kinds, the
+// kinds in the ClusterScopedKinds are cluster-scoped and are skipped.
+type NamespaceDefaults struct {
+	Namespace          string
+	ClusterScopedKinds []string
+}
+
+// Apply returns the manifest with the default namespace if the manifest is of a namespaced kind and has no namespace,
+// the default namespace of the manifest can be overridden by the DefaultNamespaceAnnotation. The given manifest is
+// not modified.
+func (d *NamespaceDefaults) Apply(manifest map[string]interface{}) map[string]interface{} {
+	if d == nil || len(manifest) == 0 {
+		return manifest
+	}
+
+	obj := &unstructured.Unstructured{Object: manifest}
+	if len(obj.GetNamespace()) != 0 {
+		return manifest
+	}
+	for _, kind := range d.ClusterScopedKinds {
+		if obj.GetKind() == kind {
+			return manifest
+		}
+	}
+
+	namespace := d.Namespace
+	if override, ok := obj.GetAnnotations()[DefaultNamespaceAnnotation]; ok {
+		namespace = override
+	}
+	if len(namespace) == 0 {
+		return manifest
+	}
+
+	// only the metadata is changed, so the manifest is copied shallowly
+	defaulted := make(map[string]interface{}, len(manifest))
+	for k, v := range manifest {
+		defaulted[k] = v
+	}
+	metadata := map[string]interface{}{}
+	if found, ok := manifest["metadata"].(map[string]interface{}); ok {
+		for k, v := range found {
+			metadata[k] = v
+		}
+	}
+	metadata["namespace"] = namespace
+	defaulted["metadata"] = metadata
+	return defaulted
+}
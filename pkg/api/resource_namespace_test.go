@@ -0,0 +1,79 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestNamespaceDefaults(t *testing.T) {
+	defaults := &NamespaceDefaults{Namespace: "default-ns", ClusterScopedKinds: []string{"ClusterRole", "Namespace"}}
+	cases := []struct {
+		name      string
+		defaults  *NamespaceDefaults
+		manifest  string
+		namespace string
+	}{
+		{
+			name:     "no defaults",
+			manifest: "{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"test\"}}",
+		},
+		{
+			name:      "namespaceless manifest",
+			defaults:  defaults,
+			manifest:  "{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"test\"}}",
+			namespace: "default-ns",
+		},
+		{
+			name:      "manifest without metadata",
+			defaults:  defaults,
+			manifest:  "{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\"}",
+			namespace: "default-ns",
+		},
+		{
+			name:      "namespaced manifest",
+			defaults:  defaults,
+			manifest:  "{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"test\",\"namespace\":\"test\"}}",
+			namespace: "test",
+		},
+		{
+			name:     "cluster-scoped manifest",
+			defaults: defaults,
+			manifest: "{\"apiVersion\":\"rbac.authorization.k8s.io/v1\",\"kind\":\"ClusterRole\",\"metadata\":{\"name\":\"test\"}}",
+		},
+		{
+			name:      "override the default namespace",
+			defaults:  defaults,
+			manifest:  "{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"test\",\"annotations\":{\"maestro.open-cluster-management.io/default-namespace\":\"test\"}}}",
+			namespace: "test",
+		},
+		{
+			name:     "disable the default namespace",
+			defaults: defaults,
+			manifest: "{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"test\",\"annotations\":{\"maestro.open-cluster-management.io/default-namespace\":\"\"}}}",
+		},
+		{
+			name:      "override without the server default namespace",
+			defaults:  &NamespaceDefaults{},
+			manifest:  "{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"test\",\"annotations\":{\"maestro.open-cluster-management.io/default-namespace\":\"test\"}}}",
+			namespace: "test",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			manifest := newJSONMap(t, c.manifest)
+			original := newJSONMap(t, c.manifest)
+
+			got := c.defaults.Apply(manifest)
+			namespace, _, _ := unstructured.NestedString(got, "metadata", "namespace")
+			if namespace != c.namespace {
+				t.Errorf("expected namespace %q but got: %q", c.namespace, namespace)
+			}
+			if !reflect.DeepEqual(map[string]interface{}(original), map[string]interface{}(manifest)) {
+				t.Errorf("expected the given manifest is not modified, but got: %v", manifest)
+			}
+		})
+	}
+}
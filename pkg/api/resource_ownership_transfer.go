@@ -0,0 +1,23 @@
+package api
+
+import (
+	"gorm.io/gorm"
+)
+
+// ResourceOwnershipTransfer is the audit record of a resource whose source is reassigned, e.g. when the pipeline that
+// manages the resource is migrated to a new source identity.
+type ResourceOwnershipTransfer struct {
+	Meta
+	ResourceID string
+	FromSource string
+	ToSource   string
+	// TransferredBy is the user who transferred the resource.
+	TransferredBy string
+}
+
+type ResourceOwnershipTransferList []*ResourceOwnershipTransfer
+
+func (t *ResourceOwnershipTransfer) BeforeCreate(tx *gorm.DB) error {
+	t.ID = NewID()
+	return nil
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+// ManifestPatchType is the type of the patch applied to a resource manifest.
+type ManifestPatchType string
+
+const (
+	// ManifestPatchTypeReplace replaces the stored manifest with the requested manifest.
+	ManifestPatchTypeReplace ManifestPatchType = "Replace"
+	// ManifestPatchTypeJSONMerge applies the requested manifest to the stored manifest as a JSON merge patch (RFC 7386).
+	ManifestPatchTypeJSONMerge ManifestPatchType = "JSONMerge"
+	// ManifestPatchTypeStrategicMerge applies the requested manifest to the stored manifest as a strategic merge
+	// patch, the lists are merged by their patch merge keys (e.g. the containers of a Deployment are merged by
+	// name). It falls back to the JSON merge patch if the manifest type is not a Kubernetes built-in type.
+	ManifestPatchTypeStrategicMerge ManifestPatchType = "StrategicMerge"
+)
+
+// ParseManifestPatchType parses the manifest patch type, an empty patch type is Replace.
+func ParseManifestPatchType(patchType string) (ManifestPatchType, error) {
+	switch ManifestPatchType(patchType) {
+	case "", ManifestPatchTypeReplace:
+		return ManifestPatchTypeReplace, nil
+	case ManifestPatchTypeJSONMerge, ManifestPatchTypeStrategicMerge:
+		return ManifestPatchType(patchType), nil
+	default:
+		return "", fmt.Errorf("unsupported patch type %q, it must be one of %s, %s or %s",
+			patchType, ManifestPatchTypeReplace, ManifestPatchTypeJSONMerge, ManifestPatchTypeStrategicMerge)
+	}
+}
+
+// PatchManifest applies the patch to the manifest with the given patch type and returns the patched manifest.
+func PatchManifest(manifest, patch map[string]interface{}, patchType ManifestPatchType) (map[string]interface{}, error) {
+	if patchType == ManifestPatchTypeReplace {
+		return patch, nil
+	}
+
+	original, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal manifest: %v", err)
+	}
+
+	patchData, err := json.Marshal(patch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal manifest patch: %v", err)
+	}
+
+	var patched []byte
+	switch patchType {
+	case ManifestPatchTypeJSONMerge:
+		patched, err = jsonpatch.MergePatch(original, patchData)
+	case ManifestPatchTypeStrategicMerge:
+		// the patch merge keys are only known for the built-in types, the unstructured and unknown types (e.g. the
+		// custom resources) use the JSON merge patch instead.
+		dataStruct, schemeErr := scheme.Scheme.New((&unstructured.Unstructured{Object: manifest}).GroupVersionKind())
+		if schemeErr != nil {
+			patched, err = jsonpatch.MergePatch(original, patchData)
+			break
+		}
+		patched, err = strategicpatch.StrategicMergePatch(original, patchData, dataStruct)
+	default:
+		return nil, fmt.Errorf("unsupported patch type %q", patchType)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply %s patch to manifest: %v", patchType, err)
+	}
+
+	patchedManifest := map[string]interface{}{}
+	if err := json.Unmarshal(patched, &patchedManifest); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal patched manifest: %v", err)
+	}
+
+	return patchedManifest, nil
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+const deploymentManifest = `{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"nginx","namespace":"default"},` +
+	`"spec":{"replicas":1,"template":{"spec":{"containers":[{"name":"nginx","image":"nginx:1.25"},{"name":"sidecar","image":"busybox"}]}}}}`
+
+func TestPatchManifest(t *testing.T) {
+	cases := []struct {
+		name             string
+		manifest         string
+		patch            string
+		patchType        ManifestPatchType
+		expected         string
+		expectedErrorMsg string
+	}{
+		{
+			name:      "replace",
+			manifest:  deploymentManifest,
+			patch:     `{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"nginx","namespace":"default"}}`,
+			patchType: ManifestPatchTypeReplace,
+			expected:  `{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"nginx","namespace":"default"}}`,
+		},
+		{
+			name:      "json merge replaces the containers",
+			manifest:  deploymentManifest,
+			patch:     `{"spec":{"template":{"spec":{"containers":[{"name":"nginx","image":"nginx:1.27"}]}}}}`,
+			patchType: ManifestPatchTypeJSONMerge,
+			expected: `{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"nginx","namespace":"default"},` +
+				`"spec":{"replicas":1,"template":{"spec":{"containers":[{"name":"nginx","image":"nginx:1.27"}]}}}}`,
+		},
+		{
+			name:      "strategic merge merges the containers by name",
+			manifest:  deploymentManifest,
+			patch:     `{"spec":{"replicas":2,"template":{"spec":{"containers":[{"name":"nginx","image":"nginx:1.27"}]}}}}`,
+			patchType: ManifestPatchTypeStrategicMerge,
+			expected: `{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"nginx","namespace":"default"},` +
+				`"spec":{"replicas":2,"template":{"spec":{"containers":[{"name":"nginx","image":"nginx:1.27"},{"name":"sidecar","image":"busybox"}]}}}}`,
+		},
+		{
+			name:      "strategic merge deletes a container with the patch directive",
+			manifest:  deploymentManifest,
+			patch:     `{"spec":{"template":{"spec":{"containers":[{"name":"sidecar","$patch":"delete"}]}}}}`,
+			patchType: ManifestPatchTypeStrategicMerge,
+			expected: `{"apiVersion":"apps/v1","kind":"Deployment","metadata":{"name":"nginx","namespace":"default"},` +
+				`"spec":{"replicas":1,"template":{"spec":{"containers":[{"name":"nginx","image":"nginx:1.25"}]}}}}`,
+		},
+		{
+			name:      "strategic merge falls back to json merge for unknown types",
+			manifest:  `{"apiVersion":"example.com/v1","kind":"Foo","metadata":{"name":"foo"},"spec":{"items":[{"name":"a"},{"name":"b"}]}}`,
+			patch:     `{"spec":{"items":[{"name":"c"}]}}`,
+			patchType: ManifestPatchTypeStrategicMerge,
+			expected:  `{"apiVersion":"example.com/v1","kind":"Foo","metadata":{"name":"foo"},"spec":{"items":[{"name":"c"}]}}`,
+		},
+		{
+			name:             "strategic merge with an invalid patch",
+			manifest:         deploymentManifest,
+			patch:            `{"spec":{"template":{"spec":{"containers":[{"name":"nginx","$patch":"unknown"}]}}}}`,
+			patchType:        ManifestPatchTypeStrategicMerge,
+			expectedErrorMsg: "failed to apply StrategicMerge patch to manifest",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := PatchManifest(newJSONMap(t, c.manifest), newJSONMap(t, c.patch), c.patchType)
+			if len(c.expectedErrorMsg) != 0 {
+				if err == nil || !strings.Contains(err.Error(), c.expectedErrorMsg) {
+					t.Fatalf("expected error %q but got: %v", c.expectedErrorMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			expected := newJSONMap(t, c.expected)
+			if !equality.Semantic.DeepEqual(map[string]interface{}(expected), got) {
+				t.Errorf("expected %#v but got: %#v", expected, got)
+			}
+		})
+	}
+}
+
+func TestParseManifestPatchType(t *testing.T) {
+	cases := []struct {
+		patchType   string
+		expected    ManifestPatchType
+		expectedErr bool
+	}{
+		{patchType: "", expected: ManifestPatchTypeReplace},
+		{patchType: "Replace", expected: ManifestPatchTypeReplace},
+		{patchType: "JSONMerge", expected: ManifestPatchTypeJSONMerge},
+		{patchType: "StrategicMerge", expected: ManifestPatchTypeStrategicMerge},
+		{patchType: "JSONPatch", expectedErr: true},
+	}
+	for _, c := range cases {
+		t.Run(c.patchType, func(t *testing.T) {
+			got, err := ParseManifestPatchType(c.patchType)
+			if (err != nil) != c.expectedErr {
+				t.Fatalf("expected error %v but got: %v", c.expectedErr, err)
+			}
+			if got != c.expected {
+				t.Errorf("expected %q but got: %q", c.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package api
+
+import "sort"
+
+const (
+	// MinResourcePriority is the lowest dispatch priority of a resource.
+	MinResourcePriority int32 = -100
+	// MaxResourcePriority is the highest dispatch priority of a resource.
+	MaxResourcePriority int32 = 100
+	// DefaultResourcePriority is the dispatch priority of a resource if it is not set.
+	DefaultResourcePriority int32 = 0
+)
+
+// ExtensionPriority is the CloudEvent extension of a resource spec event to set the dispatch priority of the
+// resource on a gRPC source.
+const ExtensionPriority = "priority"
+
+// ClampResourcePriority clamps the priority to the range of MinResourcePriority and MaxResourcePriority.
+func ClampResourcePriority(priority int32) int32 {
+	if priority < MinResourcePriority {
+		return MinResourcePriority
+	}
+	if priority > MaxResourcePriority {
+		return MaxResourcePriority
+	}
+	return priority
+}
+
+// SortByPriority sorts the resources by their priority, the resources with a higher priority come first. The sort
+// is stable, so the resources of the same priority keep their order.
+func SortByPriority(resources ResourceList) {
+	sort.SliceStable(resources, func(i, j int) bool {
+		return resources[i].Priority > resources[j].Priority
+	})
+}
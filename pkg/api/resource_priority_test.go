@@ -0,0 +1,40 @@
+package api
+
+import (
+	"testing"
+)
+
+func TestClampResourcePriority(t *testing.T) {
+	cases := map[int32]int32{
+		-1000: MinResourcePriority,
+		-100:  -100,
+		0:     0,
+		50:    50,
+		1000:  MaxResourcePriority,
+	}
+	for priority, expected := range cases {
+		if got := ClampResourcePriority(priority); got != expected {
+			t.Errorf("expected %d for %d, but got %d", expected, priority, got)
+		}
+	}
+}
+
+func TestSortByPriority(t *testing.T) {
+	resources := ResourceList{
+		{Meta: Meta{ID: "a"}},
+		{Meta: Meta{ID: "b"}, Priority: -10},
+		{Meta: Meta{ID: "c"}, Priority: 100},
+		{Meta: Meta{ID: "d"}},
+		{Meta: Meta{ID: "e"}, Priority: 100},
+	}
+
+	SortByPriority(resources)
+
+	ids := ""
+	for _, res := range resources {
+		ids += res.ID
+	}
+	if ids != "ceadb" {
+		t.Errorf("expected the order ceadb, but got %s", ids)
+	}
+}
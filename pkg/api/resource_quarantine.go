@@ -0,0 +1,27 @@
+package api
+
+import (
+	"github.com/lib/pq"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	workv1 "open-cluster-management.io/api/work/v1"
+)
+
+// ReconcileFailedCondition is the condition in the conditions summary of a resource that failed to apply.
+const ReconcileFailedCondition = workv1.WorkApplied + "=" + string(metav1.ConditionFalse)
+
+// IsReconcileFailed returns whether the conditions summary of a resource reports the resource failed to apply.
+func IsReconcileFailed(conditions pq.StringArray) bool {
+	for _, condition := range conditions {
+		if condition == ReconcileFailedCondition {
+			return true
+		}
+	}
+	return false
+}
+
+// IsQuarantined returns whether the resource is quarantined, the spec of a quarantined resource is not sent to the
+// agent, except its deletion.
+func (r *Resource) IsQuarantined() bool {
+	return r.QuarantinedAt != nil
+}
@@ -0,0 +1,21 @@
+package api
+
+import (
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// ResourceRevision is a snapshot of the resource manifest at a given resource version.
+type ResourceRevision struct {
+	Meta
+	ResourceID string
+	Version    int64
+	Payload    datatypes.JSONMap
+}
+
+type ResourceRevisionList []*ResourceRevision
+
+func (r *ResourceRevision) BeforeCreate(tx *gorm.DB) error {
+	r.ID = NewID()
+	return nil
+}
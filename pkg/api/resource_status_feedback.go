@@ -0,0 +1,249 @@
+package api
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/lib/pq"
+	"gorm.io/datatypes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	workv1 "open-cluster-management.io/api/work/v1"
+	workpayload "open-cluster-management.io/sdk-go/pkg/cloudevents/work/payload"
+)
+
+// StatusFeedbackAnnotation is the annotation of a manifest to opt in a status feedback policy for the resource, the
+// full status is collected if the annotation is not set, see StatusFeedbackPolicy.
+const StatusFeedbackAnnotation = "maestro.open-cluster-management.io/status-feedback"
+
+// StatusFeedbackPolicy is the policy of the status feedback collected by the agent for a single resource.
+type StatusFeedbackPolicy string
+
+const (
+	// StatusFeedbackPolicyFull collects the full status of the resource, it's the default policy.
+	StatusFeedbackPolicyFull StatusFeedbackPolicy = "Full"
+	// StatusFeedbackPolicyConditionsWhenReady collects the full status of the resource until the resource is ready,
+	// then only the status conditions are collected until the resource is not ready anymore. The status content of
+	// a ready resource is not fresh, it trades the status freshness for the throughput of the status updates.
+	StatusFeedbackPolicyConditionsWhenReady StatusFeedbackPolicy = "ConditionsWhenReady"
+)
+
+// ReadyCondition is the summarized condition of a ready resource, see ConditionsSummary.
+const ReadyCondition = workv1.ManifestAvailable + "=" + string(metav1.ConditionTrue)
+
+var (
+	fullStatusFeedbackRules = []workv1.FeedbackRule{
+		{
+			Type: workv1.JSONPathsType,
+			JsonPaths: []workv1.JsonPath{
+				{
+					Name: "status",
+					Path: ".status",
+				},
+			},
+		},
+	}
+	conditionsStatusFeedbackRules = []workv1.FeedbackRule{
+		{
+			Type: workv1.JSONPathsType,
+			JsonPaths: []workv1.JsonPath{
+				{
+					Name: "conditions",
+					Path: ".status.conditions",
+				},
+			},
+		},
+	}
+)
+
+// ParseStatusFeedbackPolicy returns the status feedback policy of the manifest from the StatusFeedbackAnnotation.
+func ParseStatusFeedbackPolicy(manifest map[string]interface{}) (StatusFeedbackPolicy, error) {
+	obj := &unstructured.Unstructured{Object: manifest}
+	policy, ok := obj.GetAnnotations()[StatusFeedbackAnnotation]
+	if !ok {
+		return StatusFeedbackPolicyFull, nil
+	}
+
+	switch p := StatusFeedbackPolicy(policy); p {
+	case StatusFeedbackPolicyFull, StatusFeedbackPolicyConditionsWhenReady:
+		return p, nil
+	default:
+		return "", fmt.Errorf("unsupported status feedback policy %q in the annotation %s, must be %s or %s",
+			policy, StatusFeedbackAnnotation, StatusFeedbackPolicyFull, StatusFeedbackPolicyConditionsWhenReady)
+	}
+}
+
+// StatusFeedbackRules returns the status feedback rules of the manifest with its status feedback policy, the ready
+// indicates whether the resource is ready.
+func StatusFeedbackRules(manifest map[string]interface{}, ready bool) ([]workv1.FeedbackRule, error) {
+	policy, err := ParseStatusFeedbackPolicy(manifest)
+	if err != nil {
+		return nil, err
+	}
+	if policy == StatusFeedbackPolicyConditionsWhenReady && ready {
+		return conditionsStatusFeedbackRules, nil
+	}
+	return fullStatusFeedbackRules, nil
+}
+
+// MergeFeedbackRules merges the status feedback rules of a consumer with the status feedback rules of a resource of
+// the consumer, the resource rules override the consumer rules: a JSON path of the consumer is dropped if the
+// resource has a JSON path with the same name, and the well known status is collected once. The resource rules are
+// kept as they are, the remaining consumer JSON paths are appended as a single JSONPaths rule.
+func MergeFeedbackRules(consumerRules, resourceRules []workv1.FeedbackRule) []workv1.FeedbackRule {
+	if len(consumerRules) == 0 {
+		return resourceRules
+	}
+
+	wellKnownStatus := false
+	names := map[string]bool{}
+	for _, rule := range resourceRules {
+		switch rule.Type {
+		case workv1.WellKnownStatusType:
+			wellKnownStatus = true
+		case workv1.JSONPathsType:
+			for _, path := range rule.JsonPaths {
+				names[path.Name] = true
+			}
+		}
+	}
+
+	merged := append([]workv1.FeedbackRule{}, resourceRules...)
+	consumerPaths := []workv1.JsonPath{}
+	for _, rule := range consumerRules {
+		switch rule.Type {
+		case workv1.WellKnownStatusType:
+			if !wellKnownStatus {
+				wellKnownStatus = true
+				merged = append(merged, rule)
+			}
+		case workv1.JSONPathsType:
+			for _, path := range rule.JsonPaths {
+				if !names[path.Name] {
+					names[path.Name] = true
+					consumerPaths = append(consumerPaths, path)
+				}
+			}
+		}
+	}
+	if len(consumerPaths) != 0 {
+		merged = append(merged, workv1.FeedbackRule{Type: workv1.JSONPathsType, JsonPaths: consumerPaths})
+	}
+	return merged
+}
+
+// ValidateFeedbackRules validates the status feedback rules, the rule types must be known, a JSONPaths rule must
+// have at least one JSON path, and the JSON paths must have a path and a unique name.
+func ValidateFeedbackRules(rules []workv1.FeedbackRule) error {
+	names := map[string]bool{}
+	for i, rule := range rules {
+		switch rule.Type {
+		case workv1.WellKnownStatusType:
+			if len(rule.JsonPaths) != 0 {
+				return fmt.Errorf("the feedback rule %d of type %s must not have jsonPaths", i, rule.Type)
+			}
+		case workv1.JSONPathsType:
+			if len(rule.JsonPaths) == 0 {
+				return fmt.Errorf("the feedback rule %d of type %s must have at least one of jsonPaths", i, rule.Type)
+			}
+			for _, path := range rule.JsonPaths {
+				if path.Name == "" || path.Path == "" {
+					return fmt.Errorf("the jsonPaths of the feedback rule %d must have a name and a path", i)
+				}
+				if names[path.Name] {
+					return fmt.Errorf("the jsonPath name %q of the feedback rules is duplicated", path.Name)
+				}
+				names[path.Name] = true
+			}
+		default:
+			return fmt.Errorf("unsupported feedback rule type %q, must be %s or %s",
+				rule.Type, workv1.WellKnownStatusType, workv1.JSONPathsType)
+		}
+	}
+	return nil
+}
+
+// withoutPolicyFeedbackRules returns the status feedback rules without the JSON paths of the status feedback
+// policies, e.g. the rules merged from the consumer.
+func withoutPolicyFeedbackRules(rules []workv1.FeedbackRule) []workv1.FeedbackRule {
+	policyPaths := map[workv1.JsonPath]bool{}
+	for _, policyRules := range [][]workv1.FeedbackRule{fullStatusFeedbackRules, conditionsStatusFeedbackRules} {
+		for _, rule := range policyRules {
+			for _, path := range rule.JsonPaths {
+				policyPaths[path] = true
+			}
+		}
+	}
+
+	remaining := []workv1.FeedbackRule{}
+	for _, rule := range rules {
+		if rule.Type != workv1.JSONPathsType {
+			remaining = append(remaining, rule)
+			continue
+		}
+		paths := []workv1.JsonPath{}
+		for _, path := range rule.JsonPaths {
+			if !policyPaths[path] {
+				paths = append(paths, path)
+			}
+		}
+		if len(paths) != 0 {
+			remaining = append(remaining, workv1.FeedbackRule{Type: rule.Type, JsonPaths: paths})
+		}
+	}
+	return remaining
+}
+
+// IsReady returns whether the resource is ready with the summary of its reconcile conditions.
+func IsReady(conditions pq.StringArray) bool {
+	for _, condition := range conditions {
+		if condition == ReadyCondition {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateStatusFeedback updates the status feedback rules in the CloudEvent JSONMap representation of the resource
+// manifest with the status feedback policy of the manifest and the readiness of the resource, the rules that are not
+// from the policy (e.g. the consumer rules) are kept. It returns whether the rules are changed, the manifest is
+// returned unchanged if the rules are not changed or the resource is a bundle.
+func UpdateStatusFeedback(resourceType ResourceType, payload datatypes.JSONMap, ready bool) (datatypes.JSONMap, bool, error) {
+	if resourceType == ResourceTypeBundle || len(payload) == 0 {
+		return payload, false, nil
+	}
+
+	evt, err := JSONMAPToCloudEvent(payload)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to convert resource manifest to cloudevent: %v", err)
+	}
+
+	eventPayload := &workpayload.Manifest{}
+	if err := evt.DataAs(eventPayload); err != nil {
+		return nil, false, fmt.Errorf("failed to decode cloudevent data as resource manifest: %v", err)
+	}
+
+	rules, err := StatusFeedbackRules(eventPayload.Manifest.Object, ready)
+	if err != nil {
+		return nil, false, err
+	}
+	if eventPayload.ConfigOption == nil {
+		eventPayload.ConfigOption = &workpayload.ManifestConfigOption{}
+	}
+	rules = MergeFeedbackRules(withoutPolicyFeedbackRules(eventPayload.ConfigOption.FeedbackRules), rules)
+	if reflect.DeepEqual(eventPayload.ConfigOption.FeedbackRules, rules) {
+		return payload, false, nil
+	}
+
+	eventPayload.ConfigOption.FeedbackRules = rules
+	if err := evt.SetData(evt.DataContentType(), eventPayload); err != nil {
+		return nil, false, fmt.Errorf("failed to set cloud event data: %v", err)
+	}
+
+	updated, err := CloudEventToJSONMap(evt)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to convert cloudevent to resource manifest: %v", err)
+	}
+	return updated, true, nil
+}
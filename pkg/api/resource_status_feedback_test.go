@@ -0,0 +1,247 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/lib/pq"
+
+	workv1 "open-cluster-management.io/api/work/v1"
+	workpayload "open-cluster-management.io/sdk-go/pkg/cloudevents/work/payload"
+)
+
+func TestParseStatusFeedbackPolicy(t *testing.T) {
+	cases := []struct {
+		name        string
+		annotations map[string]interface{}
+		expected    StatusFeedbackPolicy
+		expectedErr bool
+	}{
+		{
+			name:     "no annotation",
+			expected: StatusFeedbackPolicyFull,
+		},
+		{
+			name:        "conditions when ready",
+			annotations: map[string]interface{}{StatusFeedbackAnnotation: "ConditionsWhenReady"},
+			expected:    StatusFeedbackPolicyConditionsWhenReady,
+		},
+		{
+			name:        "unknown policy",
+			annotations: map[string]interface{}{StatusFeedbackAnnotation: "Never"},
+			expectedErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			manifest := newStatusFeedbackManifest(c.annotations)
+			policy, err := ParseStatusFeedbackPolicy(manifest)
+			if c.expectedErr {
+				if err == nil {
+					t.Errorf("expected error, but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if policy != c.expected {
+				t.Errorf("expected %s, but got %s", c.expected, policy)
+			}
+		})
+	}
+}
+
+func TestUpdateStatusFeedback(t *testing.T) {
+	manifest := newStatusFeedbackManifest(map[string]interface{}{StatusFeedbackAnnotation: "ConditionsWhenReady"})
+	payload, err := EncodeManifest(manifest, nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rules := feedbackRulesOf(t, payload); !reflect.DeepEqual(rules, fullStatusFeedbackRules) {
+		t.Errorf("expected the full status feedback rules, but got %v", rules)
+	}
+
+	// only the conditions are collected once the resource is ready
+	ready := IsReady(pq.StringArray{"Applied=True", ReadyCondition})
+	readyPayload, changed, err := UpdateStatusFeedback(ResourceTypeSingle, payload, ready)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !changed {
+		t.Errorf("expected the status feedback rules are changed")
+	}
+	if rules := feedbackRulesOf(t, readyPayload); !reflect.DeepEqual(rules, conditionsStatusFeedbackRules) {
+		t.Errorf("expected the conditions status feedback rules, but got %v", rules)
+	}
+	if _, changed, _ := UpdateStatusFeedback(ResourceTypeSingle, readyPayload, ready); changed {
+		t.Errorf("expected the status feedback rules are not changed")
+	}
+
+	// the full status is collected again once the resource is not ready
+	notReady := IsReady(pq.StringArray{"Applied=True", "Available=False"})
+	notReadyPayload, changed, err := UpdateStatusFeedback(ResourceTypeSingle, readyPayload, notReady)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !changed {
+		t.Errorf("expected the status feedback rules are changed")
+	}
+	if rules := feedbackRulesOf(t, notReadyPayload); !reflect.DeepEqual(rules, fullStatusFeedbackRules) {
+		t.Errorf("expected the full status feedback rules, but got %v", rules)
+	}
+
+	// the resource without the policy is not changed
+	payload, err = EncodeManifest(newStatusFeedbackManifest(nil), nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, changed, _ := UpdateStatusFeedback(ResourceTypeSingle, payload, true); changed {
+		t.Errorf("expected the status feedback rules are not changed")
+	}
+}
+
+func TestMergeFeedbackRules(t *testing.T) {
+	consumerRules := []workv1.FeedbackRule{
+		{Type: workv1.WellKnownStatusType},
+		{
+			Type: workv1.JSONPathsType,
+			JsonPaths: []workv1.JsonPath{
+				{Name: "loadBalancer", Path: ".status.loadBalancer"},
+				{Name: "status", Path: ".status.replicas"},
+			},
+		},
+	}
+
+	// the resource JSON path overrides the consumer JSON path with the same name
+	merged := MergeFeedbackRules(consumerRules, fullStatusFeedbackRules)
+	expected := []workv1.FeedbackRule{
+		fullStatusFeedbackRules[0],
+		{Type: workv1.WellKnownStatusType},
+		{
+			Type:      workv1.JSONPathsType,
+			JsonPaths: []workv1.JsonPath{{Name: "loadBalancer", Path: ".status.loadBalancer"}},
+		},
+	}
+	if !reflect.DeepEqual(merged, expected) {
+		t.Errorf("expected %v, but got %v", expected, merged)
+	}
+	if err := ValidateFeedbackRules(merged); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// the consumer rules are kept once the resource is ready, and merged again once it is not ready
+	consumerRules = expected[1:]
+	manifest := newStatusFeedbackManifest(map[string]interface{}{StatusFeedbackAnnotation: "ConditionsWhenReady"})
+	payload, err := EncodeManifest(manifest, nil, nil, nil, nil, consumerRules)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rules := feedbackRulesOf(t, payload); !reflect.DeepEqual(rules, merged) {
+		t.Errorf("expected %v, but got %v", merged, rules)
+	}
+	readyPayload, _, err := UpdateStatusFeedback(ResourceTypeSingle, payload, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected = MergeFeedbackRules(consumerRules, conditionsStatusFeedbackRules)
+	if rules := feedbackRulesOf(t, readyPayload); !reflect.DeepEqual(rules, expected) {
+		t.Errorf("expected %v, but got %v", expected, rules)
+	}
+	notReadyPayload, _, err := UpdateStatusFeedback(ResourceTypeSingle, readyPayload, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rules := feedbackRulesOf(t, notReadyPayload); !reflect.DeepEqual(rules, merged) {
+		t.Errorf("expected %v, but got %v", merged, rules)
+	}
+}
+
+func TestValidateFeedbackRules(t *testing.T) {
+	cases := []struct {
+		name        string
+		rules       []workv1.FeedbackRule
+		expectedErr bool
+	}{
+		{
+			name: "no rules",
+		},
+		{
+			name: "valid rules",
+			rules: []workv1.FeedbackRule{
+				{Type: workv1.WellKnownStatusType},
+				{Type: workv1.JSONPathsType, JsonPaths: []workv1.JsonPath{{Name: "lb", Path: ".status.loadBalancer"}}},
+			},
+		},
+		{
+			name:        "unknown type",
+			rules:       []workv1.FeedbackRule{{Type: "Everything"}},
+			expectedErr: true,
+		},
+		{
+			name:        "no json paths",
+			rules:       []workv1.FeedbackRule{{Type: workv1.JSONPathsType}},
+			expectedErr: true,
+		},
+		{
+			name:        "no path",
+			rules:       []workv1.FeedbackRule{{Type: workv1.JSONPathsType, JsonPaths: []workv1.JsonPath{{Name: "lb"}}}},
+			expectedErr: true,
+		},
+		{
+			name: "duplicated names",
+			rules: []workv1.FeedbackRule{
+				{Type: workv1.JSONPathsType, JsonPaths: []workv1.JsonPath{{Name: "lb", Path: ".status.loadBalancer"}}},
+				{Type: workv1.JSONPathsType, JsonPaths: []workv1.JsonPath{{Name: "lb", Path: ".status.ingress"}}},
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := ValidateFeedbackRules(c.rules)
+			if c.expectedErr != (err != nil) {
+				t.Errorf("expected error %v, but got %v", c.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestDecodeConditionsStatusFeedback(t *testing.T) {
+	status := newJSONMap(t, "{\"id\":\"1f21fcbe-3e41-4639-ab8d-1713c578e4cd\",\"time\":\"2024-03-07T03:29:12.094854533Z\",\"type\":\"io.open-cluster-management.works.v1alpha1.manifests.status.update_request\",\"source\":\"maestro-agent-59d9c485d9-7bvwb\",\"specversion\":\"1.0\",\"datacontenttype\":\"application/json\",\"resourceid\":\"b9368296-3200-42ec-bfbb-f7d44a06c4e0\",\"sequenceid\":\"1765580430112722944\",\"clustername\":\"b288a9da-8bfe-4c82-94cc-2b48e773fc46\",\"originalsource\":\"maestro\",\"resourceversion\":\"2\",\"data\":{\"status\":{\"conditions\":[{\"type\":\"Available\",\"reason\":\"ResourceAvailable\",\"status\":\"True\",\"message\":\"Resource is available\",\"lastTransitionTime\":\"2024-03-07T03:29:03Z\"}],\"resourceMeta\":{\"kind\":\"Deployment\",\"name\":\"nginx1\",\"group\":\"apps\",\"ordinal\":0,\"version\":\"v1\",\"resource\":\"deployments\",\"namespace\":\"default\"},\"statusFeedback\":{\"values\":[{\"name\":\"conditions\",\"fieldValue\":{\"type\":\"JsonRaw\",\"jsonRaw\":\"[{\\\"status\\\":\\\"True\\\",\\\"type\\\":\\\"Available\\\"}]\"}}]}}}}")
+	expected := newJSONMap(t, "{\"ContentStatus\":{\"conditions\":[{\"status\":\"True\",\"type\":\"Available\"}]}}")
+
+	got, err := DecodeStatus(status)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(expected["ContentStatus"], got["ContentStatus"]) {
+		t.Errorf("expected %v, but got %v", expected["ContentStatus"], got["ContentStatus"])
+	}
+}
+
+func newStatusFeedbackManifest(annotations map[string]interface{}) map[string]interface{} {
+	metadata := map[string]interface{}{"name": "nginx", "namespace": "default"}
+	if annotations != nil {
+		metadata["annotations"] = annotations
+	}
+	return map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   metadata,
+	}
+}
+
+func feedbackRulesOf(t *testing.T, payload map[string]interface{}) []workv1.FeedbackRule {
+	evt, err := JSONMAPToCloudEvent(payload)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	eventPayload := &workpayload.Manifest{}
+	if err := evt.DataAs(eventPayload); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return eventPayload.ConfigOption.FeedbackRules
+}
@@ -0,0 +1,228 @@
+package api
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// ResourceTemplate is a parameterized manifest stored server-side, the resources are created from the template by
+// rendering its manifest with the parameter values, see Render. A parameter is referenced by ${NAME} in the string
+// values of the manifest, a string value that is exactly ${NAME} is replaced by the typed value of the parameter.
+type ResourceTemplate struct {
+	Meta
+	Name        string
+	Description string
+	Manifest    datatypes.JSONMap
+	Parameters  datatypes.JSONSlice[ResourceTemplateParameter]
+	// CreatedBy is the user who created the template.
+	CreatedBy string
+}
+
+type ResourceTemplateList []*ResourceTemplate
+
+// ResourceTemplateParameter is a named parameter of a resource template, the Value is the default value of the
+// parameter, a required parameter without a default value must be given when the template is rendered. The Type is
+// the type of the value that replaces a whole string reference of the parameter, it is a string if it is not set.
+type ResourceTemplateParameter struct {
+	Name        string                        `json:"name"`
+	Description string                        `json:"description,omitempty"`
+	Required    bool                          `json:"required,omitempty"`
+	Value       string                        `json:"value,omitempty"`
+	Type        ResourceTemplateParameterType `json:"type,omitempty"`
+}
+
+// ResourceTemplateParameterType is the type of the value of a resource template parameter.
+type ResourceTemplateParameterType string
+
+const (
+	TemplateParameterString  ResourceTemplateParameterType = "string"
+	TemplateParameterInteger ResourceTemplateParameterType = "integer"
+	TemplateParameterNumber  ResourceTemplateParameterType = "number"
+	TemplateParameterBoolean ResourceTemplateParameterType = "boolean"
+)
+
+// typedValue parses the value of the parameter to its type.
+func (p ResourceTemplateParameter) typedValue(value string) (interface{}, error) {
+	switch p.Type {
+	case "", TemplateParameterString:
+		return value, nil
+	case TemplateParameterInteger:
+		return strconv.ParseInt(value, 10, 64)
+	case TemplateParameterNumber:
+		return strconv.ParseFloat(value, 64)
+	case TemplateParameterBoolean:
+		return strconv.ParseBool(value)
+	default:
+		return nil, fmt.Errorf("unsupported type %q", p.Type)
+	}
+}
+
+func (t *ResourceTemplate) BeforeCreate(tx *gorm.DB) error {
+	t.ID = NewID()
+	return nil
+}
+
+var (
+	templateParameterName      = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	templateParameterReference = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+)
+
+// ValidateResourceTemplate validates the parameters of the template have valid and unique names, supported types and
+// default values of their types, and each parameter referenced by the manifest is defined.
+func ValidateResourceTemplate(template *ResourceTemplate) error {
+	if len(template.Manifest) == 0 {
+		return fmt.Errorf("the manifest of the template is empty")
+	}
+
+	defined := map[string]bool{}
+	for _, param := range template.Parameters {
+		if !templateParameterName.MatchString(param.Name) {
+			return fmt.Errorf("invalid parameter name %q, it must match %s", param.Name, templateParameterName)
+		}
+		if defined[param.Name] {
+			return fmt.Errorf("the parameter %s is duplicated", param.Name)
+		}
+		defined[param.Name] = true
+		switch param.Type {
+		case "", TemplateParameterString, TemplateParameterInteger, TemplateParameterNumber, TemplateParameterBoolean:
+		default:
+			return fmt.Errorf("unsupported type %q of the parameter %s, it must be one of %s, %s, %s and %s", param.Type,
+				param.Name, TemplateParameterString, TemplateParameterInteger, TemplateParameterNumber, TemplateParameterBoolean)
+		}
+		if len(param.Value) != 0 {
+			if _, err := param.typedValue(param.Value); err != nil {
+				return fmt.Errorf("the default value %q of the parameter %s is not a valid %s", param.Value, param.Name, param.Type)
+			}
+		}
+	}
+
+	undefined := []string{}
+	walkTemplateStrings(map[string]interface{}(template.Manifest), func(s string) interface{} {
+		for _, match := range templateParameterReference.FindAllStringSubmatch(s, -1) {
+			if !defined[match[1]] {
+				undefined = append(undefined, match[1])
+			}
+		}
+		return s
+	})
+	if len(undefined) != 0 {
+		return fmt.Errorf("the manifest references the undefined parameters %s", strings.Join(uniqueSorted(undefined), ", "))
+	}
+	return nil
+}
+
+// Render returns a copy of the template manifest with the parameter references replaced by the given values, the
+// default value is used for a parameter that is not given. A string value that is exactly a reference of a typed
+// parameter with a value is replaced by the typed value, e.g. "${REPLICAS}" is replaced by the integer 3. It returns an
+// error if a value is given for an unknown parameter, a required parameter has no value or a value is not of the type
+// of its parameter, the template manifest is not changed.
+func (t *ResourceTemplate) Render(values map[string]string) (datatypes.JSONMap, error) {
+	resolved := map[string]string{}
+	for _, param := range t.Parameters {
+		resolved[param.Name] = param.Value
+	}
+
+	unknown := []string{}
+	for name, value := range values {
+		if _, ok := resolved[name]; !ok {
+			unknown = append(unknown, name)
+			continue
+		}
+		resolved[name] = value
+	}
+	if len(unknown) != 0 {
+		return nil, fmt.Errorf("unknown parameters %s", strings.Join(uniqueSorted(unknown), ", "))
+	}
+
+	missing := []string{}
+	for _, param := range t.Parameters {
+		if param.Required && len(resolved[param.Name]) == 0 {
+			missing = append(missing, param.Name)
+		}
+	}
+	if len(missing) != 0 {
+		return nil, fmt.Errorf("the required parameters %s are not given", strings.Join(missing, ", "))
+	}
+
+	typed := map[string]interface{}{}
+	for _, param := range t.Parameters {
+		value := resolved[param.Name]
+		if len(value) == 0 {
+			continue
+		}
+		typedValue, err := param.typedValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("the value %q of the parameter %s is not a valid %s", value, param.Name, param.Type)
+		}
+		typed[param.Name] = typedValue
+	}
+
+	rendered := walkTemplateStrings(copyTemplateValue(map[string]interface{}(t.Manifest)), func(s string) interface{} {
+		if match := templateParameterReference.FindStringSubmatch(s); match != nil && match[0] == s {
+			if typedValue, ok := typed[match[1]]; ok {
+				return typedValue
+			}
+		}
+		return templateParameterReference.ReplaceAllStringFunc(s, func(ref string) string {
+			return resolved[templateParameterReference.FindStringSubmatch(ref)[1]]
+		})
+	})
+	return datatypes.JSONMap(rendered.(map[string]interface{})), nil
+}
+
+// walkTemplateStrings replaces each string value of the value in place with the result of fn, the keys of the
+// objects are not changed.
+func walkTemplateStrings(value interface{}, fn func(string) interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return fn(v)
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = walkTemplateStrings(item, fn)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = walkTemplateStrings(item, fn)
+		}
+	}
+	return value
+}
+
+// copyTemplateValue deep copies the objects and arrays of the value, so it can be rendered in place.
+func copyTemplateValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			copied[key] = copyTemplateValue(item)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i, item := range v {
+			copied[i] = copyTemplateValue(item)
+		}
+		return copied
+	default:
+		return v
+	}
+}
+
+func uniqueSorted(values []string) []string {
+	seen := map[string]bool{}
+	unique := []string{}
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+	sort.Strings(unique)
+	return unique
+}
@@ -0,0 +1,234 @@
+package api
+
+import (
+	"fmt"
+	"testing"
+
+	"gorm.io/datatypes"
+)
+
+func TestResourceTemplateRender(t *testing.T) {
+	template := &ResourceTemplate{
+		Name: "deployment",
+		Manifest: datatypes.JSONMap{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata":   map[string]interface{}{"name": "${NAME}", "namespace": "default"},
+			"spec": map[string]interface{}{
+				"replicas": float64(1),
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"containers": []interface{}{
+							map[string]interface{}{"name": "${NAME}", "image": "${IMAGE}:${TAG}"},
+						},
+					},
+				},
+			},
+		},
+		Parameters: []ResourceTemplateParameter{
+			{Name: "NAME", Required: true},
+			{Name: "IMAGE", Required: true, Value: "nginx"},
+			{Name: "TAG", Value: "latest"},
+		},
+	}
+
+	if err := ValidateResourceTemplate(template); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cases := []struct {
+		name          string
+		values        map[string]string
+		expectedError bool
+		expectedName  string
+		expectedImage string
+	}{
+		{
+			name:          "the required parameter is not given",
+			values:        map[string]string{"TAG": "1.25"},
+			expectedError: true,
+		},
+		{
+			name:          "an unknown parameter is given",
+			values:        map[string]string{"NAME": "web", "REPLICAS": "2"},
+			expectedError: true,
+		},
+		{
+			name:          "the default values are used",
+			values:        map[string]string{"NAME": "web"},
+			expectedName:  "web",
+			expectedImage: "nginx:latest",
+		},
+		{
+			name:          "the default values are overridden",
+			values:        map[string]string{"NAME": "web", "IMAGE": "httpd", "TAG": "2.4"},
+			expectedName:  "web",
+			expectedImage: "httpd:2.4",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			rendered, err := template.Render(c.values)
+			if c.expectedError {
+				if err == nil {
+					t.Errorf("expected an error, but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			name := rendered["metadata"].(map[string]interface{})["name"]
+			container := rendered["spec"].(map[string]interface{})["template"].(map[string]interface{})["spec"].(map[string]interface{})["containers"].([]interface{})[0].(map[string]interface{})
+			if name != c.expectedName || container["name"] != c.expectedName || container["image"] != c.expectedImage {
+				t.Errorf("expected the name %s and the image %s, but got %v", c.expectedName, c.expectedImage, rendered)
+			}
+		})
+	}
+
+	// the template manifest is not changed by the rendering
+	if name := template.Manifest["metadata"].(map[string]interface{})["name"]; name != "${NAME}" {
+		t.Errorf("expected the template manifest is not changed, but got %v", name)
+	}
+}
+
+func TestResourceTemplateRenderTypedValues(t *testing.T) {
+	template := &ResourceTemplate{
+		Name: "deployment",
+		Manifest: datatypes.JSONMap{
+			"metadata": map[string]interface{}{"name": "${NAME}", "annotations": map[string]interface{}{"replicas": "replicas-${REPLICAS}"}},
+			"spec": map[string]interface{}{
+				"replicas": "${REPLICAS}",
+				"paused":   "${PAUSED}",
+				"ratio":    "${RATIO}",
+				"args":     []interface{}{"${REPLICAS}", "--paused=${PAUSED}"},
+			},
+		},
+		Parameters: []ResourceTemplateParameter{
+			{Name: "NAME", Value: "web", Type: TemplateParameterString},
+			{Name: "REPLICAS", Value: "1", Type: TemplateParameterInteger},
+			{Name: "PAUSED", Value: "false", Type: TemplateParameterBoolean},
+			{Name: "RATIO", Type: TemplateParameterNumber},
+		},
+	}
+
+	if err := ValidateResourceTemplate(template); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cases := []struct {
+		name             string
+		values           map[string]string
+		expectedError    bool
+		expectedReplicas interface{}
+		expectedPaused   interface{}
+		expectedRatio    interface{}
+	}{
+		{
+			name:             "the default values are typed",
+			values:           map[string]string{},
+			expectedReplicas: int64(1),
+			expectedPaused:   false,
+			expectedRatio:    "",
+		},
+		{
+			name:             "the given values are typed",
+			values:           map[string]string{"REPLICAS": "3", "PAUSED": "true", "RATIO": "0.5"},
+			expectedReplicas: int64(3),
+			expectedPaused:   true,
+			expectedRatio:    0.5,
+		},
+		{
+			name:          "a value of another type",
+			values:        map[string]string{"REPLICAS": "three"},
+			expectedError: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			rendered, err := template.Render(c.values)
+			if c.expectedError {
+				if err == nil {
+					t.Errorf("expected an error, but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			spec := rendered["spec"].(map[string]interface{})
+			if spec["replicas"] != c.expectedReplicas || spec["paused"] != c.expectedPaused || spec["ratio"] != c.expectedRatio {
+				t.Errorf("expected the replicas %v, paused %v and ratio %v, but got %v", c.expectedReplicas, c.expectedPaused, c.expectedRatio, spec)
+			}
+			// the whole string references in the arrays are typed, the references in the strings are not
+			args := spec["args"].([]interface{})
+			if args[0] != c.expectedReplicas || args[1] != fmt.Sprintf("--paused=%v", c.expectedPaused) {
+				t.Errorf("unexpected args %v", args)
+			}
+			annotations := rendered["metadata"].(map[string]interface{})["annotations"].(map[string]interface{})
+			if annotations["replicas"] != fmt.Sprintf("replicas-%v", c.expectedReplicas) {
+				t.Errorf("unexpected annotations %v", annotations)
+			}
+			if name := rendered["metadata"].(map[string]interface{})["name"]; name != "web" {
+				t.Errorf("expected the name web, but got %v", name)
+			}
+		})
+	}
+}
+
+func TestValidateResourceTemplate(t *testing.T) {
+	manifest := datatypes.JSONMap{"apiVersion": "v1", "kind": "ConfigMap", "metadata": map[string]interface{}{"name": "${NAME}"}}
+
+	cases := []struct {
+		name       string
+		template   *ResourceTemplate
+		expectedOK bool
+	}{
+		{
+			name:       "a valid template",
+			template:   &ResourceTemplate{Manifest: manifest, Parameters: []ResourceTemplateParameter{{Name: "NAME"}}},
+			expectedOK: true,
+		},
+		{
+			name:     "an empty manifest",
+			template: &ResourceTemplate{Parameters: []ResourceTemplateParameter{{Name: "NAME"}}},
+		},
+		{
+			name:     "an undefined parameter",
+			template: &ResourceTemplate{Manifest: manifest},
+		},
+		{
+			name:     "a duplicated parameter",
+			template: &ResourceTemplate{Manifest: manifest, Parameters: []ResourceTemplateParameter{{Name: "NAME"}, {Name: "NAME"}}},
+		},
+		{
+			name:     "an invalid parameter name",
+			template: &ResourceTemplate{Manifest: manifest, Parameters: []ResourceTemplateParameter{{Name: "NAME"}, {Name: "my-name"}}},
+		},
+		{
+			name:       "a typed parameter",
+			template:   &ResourceTemplate{Manifest: manifest, Parameters: []ResourceTemplateParameter{{Name: "NAME"}, {Name: "REPLICAS", Value: "3", Type: TemplateParameterInteger}}},
+			expectedOK: true,
+		},
+		{
+			name:     "an unsupported parameter type",
+			template: &ResourceTemplate{Manifest: manifest, Parameters: []ResourceTemplateParameter{{Name: "NAME", Type: "object"}}},
+		},
+		{
+			name:     "a default value of another type",
+			template: &ResourceTemplate{Manifest: manifest, Parameters: []ResourceTemplateParameter{{Name: "NAME"}, {Name: "REPLICAS", Value: "three", Type: TemplateParameterInteger}}},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := ValidateResourceTemplate(c.template)
+			if c.expectedOK != (err == nil) {
+				t.Errorf("expected ok %v, but got %v", c.expectedOK, err)
+			}
+		})
+	}
+}
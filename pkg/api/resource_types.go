@@ -3,10 +3,15 @@ package api
 import (
 	"encoding/json"
 	"fmt"
+	"math"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 	cloudeventstypes "github.com/cloudevents/sdk-go/v2/types"
+	"github.com/lib/pq"
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -17,6 +22,8 @@ import (
 	cetypes "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/types"
 	workpayload "open-cluster-management.io/sdk-go/pkg/cloudevents/work/payload"
 	"open-cluster-management.io/sdk-go/pkg/cloudevents/work/source/codec"
+
+	"github.com/openshift-online/maestro/pkg/constants"
 )
 
 type ResourceType string
@@ -28,7 +35,7 @@ const (
 
 type Resource struct {
 	Meta
-	Version      int32
+	Version      int64
 	Source       string
 	ConsumerName string
 	Type         ResourceType
@@ -39,6 +46,47 @@ type Resource struct {
 	// When creating a resource, if its name is not specified, the resource id will be used as its name.
 	// Cannot be updated.
 	Name string
+	// Metadata holds the passthrough CloudEvent extensions (e.g. a GitOps commit SHA) of the resource, they are
+	// attached back to the events of the resource.
+	Metadata datatypes.JSONMap
+	// Conditions is the summary of the reconcile conditions in the resource status, see ConditionsSummary. It is
+	// refreshed on each status update and is used to filter resources by condition.
+	Conditions pq.StringArray `gorm:"type:text[]"`
+	// Labels are the "<key>=<value>" pairs of the manifest labels that are mirrored to the resource, see
+	// LabelPropagation. They are refreshed on each create and update and are used to filter resources by label.
+	Labels pq.StringArray `gorm:"type:text[]"`
+	// LastDispatchedBy and LastDispatchedAt record the maestro instance that last broadcast the status of the
+	// resource and when, they are used to debug the status delivery.
+	LastDispatchedBy string
+	LastDispatchedAt *time.Time
+	// PayloadRef is the object key of the payload if the payload is offloaded to the object store, only the
+	// CloudEvent attributes of the payload are kept in the database in that case. The payload is fetched back by the
+	// resource DAO on read.
+	PayloadRef string
+	// Priority is the dispatch priority of the resource, the resources with a higher priority are delivered first in
+	// a resync of the consumer, see SortByPriority. It is clamped to the range of MinResourcePriority and
+	// MaxResourcePriority.
+	Priority int32 `gorm:"not null;default:0"`
+	// ReconcileFailures is the number of the consecutive status reports of the resource that failed to apply, and
+	// QuarantinedAt is when the resource is quarantined once the failures reach the quarantine threshold. The spec of
+	// a quarantined resource is no longer sent to the agent until the resource is released, see IsQuarantined.
+	ReconcileFailures int32 `gorm:"not null;default:0"`
+	QuarantinedAt     *time.Time
+	// UpdateStrategy is the effective update strategy of the resource, see EffectiveUpdateStrategy. It is refreshed
+	// on each create and update and is used to filter and segment the resources by update strategy.
+	UpdateStrategy string `gorm:"index"`
+	// SpecUpdatedAt is when the spec of the resource is last changed, i.e. the resource is created or its version is
+	// increased. Unlike the UpdatedAt, it is not bumped by the status updates, so it is used to find the resources that
+	// are not reconciled within the reconcile timeout.
+	SpecUpdatedAt *time.Time
+	// DeleteOptionResolved tells the delete option in the payload is resolved from the default delete propagation
+	// policy of the consumer (or the global default) rather than set by the source, so it is resolved again once the
+	// consumer policy is changed, see ResolvePayloadDeleteOption.
+	DeleteOptionResolved bool `gorm:"not null;default:false"`
+	// PreviousConditions is the conditions summary of the resource before the status update that the resource is
+	// broadcast for, see ConditionTransitioned. It is not persisted, and is nil if the resource is not broadcast for
+	// a status update, e.g. it is broadcast for a status resync or a deletion.
+	PreviousConditions pq.StringArray `gorm:"-"`
 }
 
 type ResourceStatus struct {
@@ -47,7 +95,7 @@ type ResourceStatus struct {
 }
 
 type ReconcileStatus struct {
-	ObservedVersion int32
+	ObservedVersion int64
 	SequenceID      string
 	Conditions      []metav1.Condition
 }
@@ -75,6 +123,10 @@ func (d *Resource) BeforeCreate(tx *gorm.DB) error {
 	if d.Version == 0 {
 		d.Version = 1
 	}
+	if d.SpecUpdatedAt == nil {
+		now := time.Now()
+		d.SpecUpdatedAt = &now
+	}
 	return nil
 }
 
@@ -83,7 +135,7 @@ func (d *Resource) GetUID() ktypes.UID {
 }
 
 func (d *Resource) GetResourceVersion() string {
-	return strconv.FormatInt(int64(d.Version), 10)
+	return strconv.FormatInt(d.Version, 10)
 }
 
 func (d *Resource) GetDeletionTimestamp() *metav1.Time {
@@ -92,6 +144,123 @@ func (d *Resource) GetDeletionTimestamp() *metav1.Time {
 
 type ResourcePatchRequest struct{}
 
+// MaxResourceVersion is the max resource version that the agents support.
+const MaxResourceVersion = math.MaxInt32
+
+// ToResourceVersion converts the CloudEvent resourceversion extension value to the resource version, a version
+// formatted as a string is tolerated.
+func ToResourceVersion(value interface{}) (int64, error) {
+	if version, ok := value.(string); ok {
+		return strconv.ParseInt(version, 10, 64)
+	}
+
+	version, err := cloudeventstypes.ToInteger(value)
+	if err != nil {
+		return 0, err
+	}
+	return int64(version), nil
+}
+
+// SetResourceVersion sets the resource version to the CloudEvent resourceversion extension. The agents read the
+// extension as a CloudEvent integer (int32), so a resource version beyond MaxResourceVersion is rejected instead of
+// being truncated.
+func SetResourceVersion(evt *cloudevents.Event, version int64) error {
+	if version > MaxResourceVersion || version < 0 {
+		return fmt.Errorf("the resource version %d is out of the supported range [0, %d]", version, MaxResourceVersion)
+	}
+	evt.SetExtension(cetypes.ExtensionResourceVersion, int32(version))
+	return nil
+}
+
+// reservedMetadataExtensions are the CloudEvent attributes and the extensions that maestro and the agents interpret,
+// they cannot be passed through as the resource metadata, otherwise a source could override e.g. the resource
+// version or the cluster name of the events sent to the agents.
+var reservedMetadataExtensions = map[string]bool{
+	"specversion":                           true,
+	"id":                                    true,
+	"source":                                true,
+	"type":                                  true,
+	"subject":                               true,
+	"time":                                  true,
+	"datacontenttype":                       true,
+	"dataschema":                            true,
+	"data":                                  true,
+	"data_base64":                           true,
+	cetypes.ExtensionResourceID:             true,
+	cetypes.ExtensionResourceVersion:        true,
+	cetypes.ExtensionStatusUpdateSequenceID: true,
+	cetypes.ExtensionDeletionTimestamp:      true,
+	cetypes.ExtensionClusterName:            true,
+	cetypes.ExtensionOriginalSource:         true,
+	codec.ExtensionWorkMeta:                 true,
+	constants.ExtensionCommitMode:           true,
+	constants.ExtensionResourceIDs:          true,
+	ExtensionPriority:                       true,
+	ExtensionTraceParent:                    true,
+	ExtensionTraceState:                     true,
+}
+
+// ValidateMetadataExtensions returns an error if one of the given extensions is reserved, see
+// reservedMetadataExtensions.
+func ValidateMetadataExtensions(extensions []string) error {
+	for _, extension := range extensions {
+		if err := validateMetadataExtension(extension); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateMetadataExtension(extension string) error {
+	if reservedMetadataExtensions[strings.ToLower(extension)] {
+		return fmt.Errorf("the CloudEvent extension %s is reserved, it cannot be used as the resource metadata", extension)
+	}
+	return nil
+}
+
+// GetMetadataExtensions returns the given extensions of the CloudEvent as the resource metadata, the extension values
+// are kept in their canonical string format. It returns nil if the CloudEvent has none of the given extensions, and
+// an error if one of the given extensions is reserved.
+func GetMetadataExtensions(evt *cloudevents.Event, extensions []string) (datatypes.JSONMap, error) {
+	if err := ValidateMetadataExtensions(extensions); err != nil {
+		return nil, err
+	}
+
+	var metadata datatypes.JSONMap
+	evtExtensions := evt.Extensions()
+	for _, extension := range extensions {
+		value, ok := evtExtensions[extension]
+		if !ok {
+			continue
+		}
+
+		formatted, err := cloudeventstypes.Format(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to format extension %s: %v", extension, err)
+		}
+
+		if metadata == nil {
+			metadata = datatypes.JSONMap{}
+		}
+		metadata[extension] = formatted
+	}
+	return metadata, nil
+}
+
+// SetMetadataExtensions attaches the resource metadata to the CloudEvent as its extensions, a reserved extension in
+// the metadata is rejected rather than overriding the one set by maestro.
+func SetMetadataExtensions(evt *cloudevents.Event, metadata datatypes.JSONMap) error {
+	for extension, value := range metadata {
+		if err := validateMetadataExtension(extension); err != nil {
+			return err
+		}
+		if err := evt.Context.SetExtension(extension, value); err != nil {
+			return fmt.Errorf("failed to set extension %s: %v", extension, err)
+		}
+	}
+	return nil
+}
+
 // JSONMAPToCloudEvent converts a JSONMap (resource manifest or status) to a CloudEvent
 func JSONMAPToCloudEvent(res datatypes.JSONMap) (*cloudevents.Event, error) {
 	var err error
@@ -160,10 +329,17 @@ func CloudEventToJSONMap(evt *cloudevents.Event) (datatypes.JSONMap, error) {
 }
 
 // EncodeManifest converts resource manifest, deleteOption and updateStrategy (map[string]interface{}) into a CloudEvent JSONMap representation.
-func EncodeManifest(manifest, deleteOption, updateStrategy map[string]interface{}) (datatypes.JSONMap, error) {
+// If the forceConflicts is set, it overrides the force option of the ServerSideApply update strategy, the force
+// can only be enabled with the ServerSideApply update strategy.
+// If the namespaceDefaults is set, the default namespace is applied to the manifest, see NamespaceDefaults.Apply.
+// The status feedback rules follow the status feedback policy of the manifest, see StatusFeedbackPolicy, the
+// consumerRules of the consumer are merged into them, see MergeFeedbackRules.
+func EncodeManifest(manifest, deleteOption, updateStrategy map[string]interface{}, forceConflicts *bool,
+	namespaceDefaults *NamespaceDefaults, consumerRules []workv1.FeedbackRule) (datatypes.JSONMap, error) {
 	if len(manifest) == 0 {
 		return nil, nil
 	}
+	manifest = namespaceDefaults.Apply(manifest)
 
 	// default update strategy is ServerSideApply
 	upStrategy := &workv1.UpdateStrategy{
@@ -180,11 +356,22 @@ func EncodeManifest(manifest, deleteOption, updateStrategy map[string]interface{
 		}
 	}
 
-	// default delete option is Foreground
-	delOption := &workv1.DeleteOption{
-		PropagationPolicy: workv1.DeletePropagationPolicyTypeForeground,
+	if forceConflicts != nil {
+		if err := ValidateForceConflicts(updateStrategy, *forceConflicts); err != nil {
+			return nil, err
+		}
+		if upStrategy.Type == workv1.UpdateStrategyTypeServerSideApply {
+			if upStrategy.ServerSideApply == nil {
+				upStrategy.ServerSideApply = &workv1.ServerSideApplyConfig{}
+			}
+			upStrategy.ServerSideApply.Force = *forceConflicts
+		}
 	}
 
+	// the delete option is left unset if it is not given, it is resolved with the default delete propagation policy
+	// of the consumer once the resource is marked as deleting, see ResolveDeleteOption
+	var delOption *workv1.DeleteOption
+
 	// set delete option to Orphan if update strategy is ReadOnly
 	if upStrategy.Type == workv1.UpdateStrategyTypeReadOnly {
 		delOption = &workv1.DeleteOption{
@@ -192,6 +379,7 @@ func EncodeManifest(manifest, deleteOption, updateStrategy map[string]interface{
 		}
 	} else {
 		if len(deleteOption) != 0 {
+			delOption = &workv1.DeleteOption{}
 			deleteOptionBytes, err := json.Marshal(deleteOption)
 			if err != nil {
 				return nil, fmt.Errorf("failed to marshal deleteOption to json: %v", err)
@@ -203,23 +391,23 @@ func EncodeManifest(manifest, deleteOption, updateStrategy map[string]interface{
 		}
 	}
 
+	// the full status is collected until the resource is ready, see UpdateStatusFeedback
+	feedbackRules, err := StatusFeedbackRules(manifest, false)
+	if err != nil {
+		return nil, err
+	}
+	feedbackRules = MergeFeedbackRules(consumerRules, feedbackRules)
+	if err := ValidateFeedbackRules(feedbackRules); err != nil {
+		return nil, err
+	}
+
 	// create a cloud event with the manifest as the data
-	evt := cetypes.NewEventBuilder("maestro", cetypes.CloudEventsType{}).NewEvent()
+	evt := cetypes.NewEventBuilder(EventSource(), cetypes.CloudEventsType{}).NewEvent()
 	eventPayload := &workpayload.Manifest{
 		Manifest:     unstructured.Unstructured{Object: manifest},
 		DeleteOption: delOption,
 		ConfigOption: &workpayload.ManifestConfigOption{
-			FeedbackRules: []workv1.FeedbackRule{
-				{
-					Type: workv1.JSONPathsType,
-					JsonPaths: []workv1.JsonPath{
-						{
-							Name: "status",
-							Path: ".status",
-						},
-					},
-				},
-			},
+			FeedbackRules:  feedbackRules,
 			UpdateStrategy: upStrategy,
 		},
 	}
@@ -229,12 +417,62 @@ func EncodeManifest(manifest, deleteOption, updateStrategy map[string]interface{
 	}
 
 	// convert cloudevent to JSONMap
-	manifest, err := CloudEventToJSONMap(&evt)
+	encoded, err := CloudEventToJSONMap(&evt)
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert cloudevent to resource manifest: %v", err)
 	}
 
-	return manifest, nil
+	return encoded, nil
+}
+
+// ForceConflicts returns whether the update strategy (map[string]interface{}) forces the server-side apply to
+// take the ownership of the conflicting fields.
+func ForceConflicts(updateStrategy map[string]interface{}) bool {
+	force, _, _ := unstructured.NestedBool(updateStrategy, "serverSideApply", "force")
+	return force
+}
+
+// ValidateForceConflicts validates the force conflicts can be enabled with the update strategy (map[string]interface{}),
+// the force conflicts is only supported by the ServerSideApply update strategy, which is the default update strategy.
+func ValidateForceConflicts(updateStrategy map[string]interface{}, forceConflicts bool) error {
+	if !forceConflicts {
+		return nil
+	}
+	strategyType, _, _ := unstructured.NestedString(updateStrategy, "type")
+	if len(strategyType) != 0 && strategyType != string(workv1.UpdateStrategyTypeServerSideApply) {
+		return fmt.Errorf("force conflicts is only supported by the %s update strategy, but got %s",
+			workv1.UpdateStrategyTypeServerSideApply, strategyType)
+	}
+	return nil
+}
+
+// supportedUpdateStrategyTypes are the update strategy types supported by the work agent. The CreateOnly creates the
+// object on the cluster once and never updates it, so the changes made on the cluster are kept, and unlike the
+// ReadOnly, the object is still deleted once the resource is deleted.
+var supportedUpdateStrategyTypes = []workv1.UpdateStrategyType{
+	workv1.UpdateStrategyTypeServerSideApply,
+	workv1.UpdateStrategyTypeUpdate,
+	workv1.UpdateStrategyTypeCreateOnly,
+	workv1.UpdateStrategyTypeReadOnly,
+}
+
+// ValidateUpdateStrategy validates the type of the update strategy (map[string]interface{}) is supported by the work
+// agent, the ServerSideApply is used if the type is not set.
+func ValidateUpdateStrategy(updateStrategy map[string]interface{}) error {
+	strategyType, _, err := unstructured.NestedString(updateStrategy, "type")
+	if err != nil {
+		return fmt.Errorf("invalid update strategy type: %v", err)
+	}
+	return ValidateUpdateStrategyType(workv1.UpdateStrategyType(strategyType))
+}
+
+// ValidateUpdateStrategyType validates the update strategy type is supported by the work agent, an empty type is
+// allowed.
+func ValidateUpdateStrategyType(strategyType workv1.UpdateStrategyType) error {
+	if len(strategyType) == 0 || slices.Contains(supportedUpdateStrategyTypes, strategyType) {
+		return nil
+	}
+	return fmt.Errorf("unsupported update strategy type %s, the supported types are %v", strategyType, supportedUpdateStrategyTypes)
 }
 
 // DecodeManifest converts a CloudEvent JSONMap representation of a resource manifest
@@ -292,7 +530,7 @@ func DecodeStatus(status datatypes.JSONMap) (map[string]interface{}, error) {
 	}
 
 	evtExtensions := evt.Extensions()
-	resourceVersion, err := cloudeventstypes.ToInteger(evtExtensions[cetypes.ExtensionResourceVersion])
+	resourceVersion, err := ToResourceVersion(evtExtensions[cetypes.ExtensionResourceVersion])
 	if err != nil {
 		return nil, fmt.Errorf("failed to get resourceversion extension: %v", err)
 	}
@@ -324,6 +562,14 @@ func DecodeStatus(status datatypes.JSONMap) (map[string]interface{}, error) {
 				}
 				resourceStatus.ContentStatus = contentStatus
 			}
+			// only the conditions are collected for a ready resource, see StatusFeedbackPolicyConditionsWhenReady
+			if value.Name == "conditions" {
+				conditions := []interface{}{}
+				if err := json.Unmarshal([]byte(*value.Value.JsonRaw), &conditions); err != nil {
+					return nil, fmt.Errorf("failed to convert status feedback value to content status: %v", err)
+				}
+				resourceStatus.ContentStatus = map[string]interface{}{"conditions": conditions}
+			}
 		}
 	}
 
@@ -2,10 +2,14 @@ package api
 
 import (
 	"encoding/json"
+	"math"
+	"strconv"
 	"testing"
 
+	cloudevents "github.com/cloudevents/sdk-go/v2"
 	"gorm.io/datatypes"
 	"k8s.io/apimachinery/pkg/api/equality"
+	cetypes "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/types"
 )
 
 func TestEncodeManifest(t *testing.T) {
@@ -14,6 +18,7 @@ func TestEncodeManifest(t *testing.T) {
 		input            map[string]interface{}
 		deleteOption     map[string]interface{}
 		updateStrategy   map[string]interface{}
+		forceConflicts   *bool
 		expected         datatypes.JSONMap
 		expectedErrorMsg string
 	}{
@@ -34,12 +39,32 @@ func TestEncodeManifest(t *testing.T) {
 			input:          newJSONMap(t, "{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"test\",\"namespace\":\"test\"}}"),
 			expected:       newJSONMap(t, "{\"specversion\":\"1.0\",\"datacontenttype\":\"application/json\",\"data\":{\"configOption\":{\"updateStrategy\": {\"type\": \"CreateOnly\"}},\"deleteOption\": {\"propagationPolicy\": \"Orphan\"},\"manifest\":{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"test\",\"namespace\":\"test\"}}}}"),
 		},
+		{
+			name:           "force conflicts",
+			forceConflicts: boolPtr(true),
+			input:          newJSONMap(t, "{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"test\",\"namespace\":\"test\"}}"),
+			expected:       newJSONMap(t, "{\"specversion\":\"1.0\",\"datacontenttype\":\"application/json\",\"data\":{\"configOption\":{\"updateStrategy\": {\"type\": \"ServerSideApply\",\"serverSideApply\":{\"force\":true}}},\"manifest\":{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"test\",\"namespace\":\"test\"}}}}"),
+		},
+		{
+			name:           "disable force conflicts",
+			updateStrategy: newJSONMap(t, "{\"type\": \"ServerSideApply\",\"serverSideApply\":{\"force\":true,\"fieldManager\":\"work-agent-maestro\"}}"),
+			forceConflicts: boolPtr(false),
+			input:          newJSONMap(t, "{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"test\",\"namespace\":\"test\"}}"),
+			expected:       newJSONMap(t, "{\"specversion\":\"1.0\",\"datacontenttype\":\"application/json\",\"data\":{\"configOption\":{\"updateStrategy\": {\"type\": \"ServerSideApply\",\"serverSideApply\":{\"force\":false,\"fieldManager\":\"work-agent-maestro\"}}},\"manifest\":{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"test\",\"namespace\":\"test\"}}}}"),
+		},
+		{
+			name:             "force conflicts with non server-side apply update strategy",
+			updateStrategy:   newJSONMap(t, "{\"type\": \"Update\"}"),
+			forceConflicts:   boolPtr(true),
+			input:            newJSONMap(t, "{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"test\",\"namespace\":\"test\"}}"),
+			expectedErrorMsg: "force conflicts is only supported by the ServerSideApply update strategy, but got Update",
+		},
 	}
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
-			gotManifest, err := EncodeManifest(c.input, c.deleteOption, c.updateStrategy)
-			if err != nil {
-				if err.Error() != c.expectedErrorMsg {
+			gotManifest, err := EncodeManifest(c.input, c.deleteOption, c.updateStrategy, c.forceConflicts, nil, nil)
+			if err != nil || len(c.expectedErrorMsg) != 0 {
+				if err == nil || err.Error() != c.expectedErrorMsg {
 					t.Errorf("expected %#v but got: %#v", c.expectedErrorMsg, err)
 				}
 				return
@@ -51,6 +76,41 @@ func TestEncodeManifest(t *testing.T) {
 	}
 }
 
+func TestValidateUpdateStrategy(t *testing.T) {
+	cases := []struct {
+		name             string
+		updateStrategy   map[string]interface{}
+		expectedErrorMsg string
+	}{
+		{
+			name: "default",
+		},
+		{
+			name:           "create only",
+			updateStrategy: newJSONMap(t, "{\"type\": \"CreateOnly\"}"),
+		},
+		{
+			name:           "server-side apply",
+			updateStrategy: newJSONMap(t, "{\"type\": \"ServerSideApply\",\"serverSideApply\":{\"force\":true}}"),
+		},
+		{
+			name:             "unsupported",
+			updateStrategy:   newJSONMap(t, "{\"type\": \"Replace\"}"),
+			expectedErrorMsg: "unsupported update strategy type Replace, the supported types are [ServerSideApply Update CreateOnly ReadOnly]",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := ValidateUpdateStrategy(c.updateStrategy)
+			if err != nil || len(c.expectedErrorMsg) != 0 {
+				if err == nil || err.Error() != c.expectedErrorMsg {
+					t.Errorf("expected %#v but got: %#v", c.expectedErrorMsg, err)
+				}
+			}
+		})
+	}
+}
+
 func TestDecodeManifest(t *testing.T) {
 	cases := []struct {
 		name                   string
@@ -133,6 +193,170 @@ func TestDecodeStatus(t *testing.T) {
 	}
 }
 
+func TestReservedMetadataExtensions(t *testing.T) {
+	reserved := []string{"clustername", "resourceid", "resourceversion", "sequenceid", "deletiontimestamp",
+		"originalsource", "metadata", "commitmode", "resourceids", "priority", "traceparent", "tracestate", "id",
+		"source", "type", "specversion", "ResourceVersion"}
+	for _, extension := range reserved {
+		t.Run(extension, func(t *testing.T) {
+			if err := ValidateMetadataExtensions([]string{"commitsha", extension}); err == nil {
+				t.Errorf("expected the extension %s to be reserved", extension)
+			}
+
+			// a reserved extension is not passed through from a source event
+			evt := cloudevents.NewEvent()
+			evt.SetExtension("commitsha", "abc123")
+			if _, err := GetMetadataExtensions(&evt, []string{"commitsha", extension}); err == nil {
+				t.Errorf("expected an error getting the reserved extension %s", extension)
+			}
+
+			// a reserved extension in the metadata does not override the one set by maestro
+			evt.SetExtension(cetypes.ExtensionResourceVersion, int32(1))
+			if err := SetMetadataExtensions(&evt, datatypes.JSONMap{extension: "2"}); err == nil {
+				t.Errorf("expected an error setting the reserved extension %s", extension)
+			}
+			if evt.Extensions()[cetypes.ExtensionResourceVersion] != int32(1) {
+				t.Errorf("expected the resource version to be kept but got: %v", evt.Extensions()[cetypes.ExtensionResourceVersion])
+			}
+		})
+	}
+
+	if err := ValidateMetadataExtensions([]string{"commitsha", "pipelinerun"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestMetadataExtensions(t *testing.T) {
+	cases := []struct {
+		name       string
+		extensions map[string]interface{}
+		names      []string
+		expected   datatypes.JSONMap
+	}{
+		{
+			name:       "no passthrough extensions",
+			extensions: map[string]interface{}{"commitsha": "abc123"},
+			names:      []string{},
+			expected:   nil,
+		},
+		{
+			name:       "missing passthrough extensions",
+			extensions: map[string]interface{}{"commitsha": "abc123"},
+			names:      []string{"pipelinerun"},
+			expected:   nil,
+		},
+		{
+			name:       "passthrough extensions",
+			extensions: map[string]interface{}{"commitsha": "abc123", "attempt": 2, "clustername": "cluster1"},
+			names:      []string{"commitsha", "attempt"},
+			expected:   datatypes.JSONMap{"commitsha": "abc123", "attempt": "2"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			evt := cloudevents.NewEvent()
+			for key, value := range c.extensions {
+				evt.SetExtension(key, value)
+			}
+
+			metadata, err := GetMetadataExtensions(&evt, c.names)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !equality.Semantic.DeepEqual(c.expected, metadata) {
+				t.Errorf("expected %#v but got: %#v", c.expected, metadata)
+			}
+
+			// the metadata round-trips to a new event
+			newEvt := cloudevents.NewEvent()
+			if err := SetMetadataExtensions(&newEvt, metadata); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for key, value := range metadata {
+				if newEvt.Extensions()[key] != value {
+					t.Errorf("expected extension %s to be %v but got: %v", key, value, newEvt.Extensions()[key])
+				}
+			}
+		})
+	}
+}
+
+func TestResourceVersion(t *testing.T) {
+	cases := []struct {
+		name        string
+		version     int64
+		expectedErr bool
+	}{
+		{
+			name:    "zero",
+			version: 0,
+		},
+		{
+			name:    "int32 max",
+			version: math.MaxInt32,
+		},
+		{
+			name:        "beyond int32 max",
+			version:     math.MaxInt32 + 1,
+			expectedErr: true,
+		},
+		{
+			name:        "negative",
+			version:     -1,
+			expectedErr: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			evt := cloudevents.NewEvent()
+			evt.SetID("1")
+			evt.SetSource("test")
+			evt.SetType("test")
+			err := SetResourceVersion(&evt, c.version)
+			if c.expectedErr {
+				if err == nil {
+					t.Errorf("expected error but got nil")
+				}
+				if _, ok := evt.Extensions()[cetypes.ExtensionResourceVersion]; ok {
+					t.Errorf("expected no resource version but got one")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			// the agents read the resource version as a CloudEvent integer
+			if _, ok := evt.Extensions()[cetypes.ExtensionResourceVersion].(int32); !ok {
+				t.Errorf("expected an int32 resource version but got: %T", evt.Extensions()[cetypes.ExtensionResourceVersion])
+			}
+
+			// the resource version round-trips through the storage
+			evtMap, err := CloudEventToJSONMap(&evt)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			storedEvt, err := JSONMAPToCloudEvent(evtMap)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			version, err := ToResourceVersion(storedEvt.Extensions()[cetypes.ExtensionResourceVersion])
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if version != c.version {
+				t.Errorf("expected %d but got: %d", c.version, version)
+			}
+
+			res := &Resource{Version: c.version}
+			if res.GetResourceVersion() != strconv.FormatInt(c.version, 10) {
+				t.Errorf("expected %d but got: %s", c.version, res.GetResourceVersion())
+			}
+		})
+	}
+}
+
 func newJSONMap(t *testing.T, data string) datatypes.JSONMap {
 	jsonmap := map[string]interface{}{}
 	if err := json.Unmarshal([]byte(data), &jsonmap); err != nil {
@@ -141,3 +365,24 @@ func newJSONMap(t *testing.T, data string) datatypes.JSONMap {
 
 	return jsonmap
 }
+
+func TestForceConflictsRoundTrip(t *testing.T) {
+	for _, force := range []bool{true, false} {
+		manifest, err := EncodeManifest(newJSONMap(t, "{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"test\",\"namespace\":\"test\"}}"),
+			nil, nil, boolPtr(force), nil, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		_, _, updateStrategy, err := DecodeManifest(manifest)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := ForceConflicts(updateStrategy); got != force {
+			t.Errorf("expected force conflicts %v but got: %v", force, got)
+		}
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
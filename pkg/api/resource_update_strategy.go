@@ -0,0 +1,82 @@
+package api
+
+import (
+	"gorm.io/datatypes"
+
+	workv1 "open-cluster-management.io/api/work/v1"
+	workpayload "open-cluster-management.io/sdk-go/pkg/cloudevents/work/payload"
+)
+
+// UpdateStrategyMixed is the effective update strategy of a resource bundle whose manifests are applied with
+// different update strategies.
+const UpdateStrategyMixed = "Mixed"
+
+// UpdateStrategyUnknown is the effective update strategy of a resource whose manifest cannot be decoded or has an
+// update strategy that is not known, so the values of the update strategy are bounded.
+const UpdateStrategyUnknown = "Unknown"
+
+// EffectiveUpdateStrategy returns the update strategy type that the agent applies the resource with, it is derived
+// from the manifest config of the CloudEvent JSONMap representation of the resource manifest. A single resource is
+// applied with ServerSideApply if its update strategy is not set, see EncodeManifest, and the manifests of a bundle
+// without a manifest config are applied with Update by the agent. The returned value is one of the known update
+// strategy types, UpdateStrategyMixed or UpdateStrategyUnknown.
+func EffectiveUpdateStrategy(resourceType ResourceType, payload datatypes.JSONMap) string {
+	if len(payload) == 0 {
+		return UpdateStrategyUnknown
+	}
+
+	if resourceType == ResourceTypeBundle {
+		_, bundle, err := DecodeManifestBundle(payload)
+		if err != nil || bundle == nil {
+			return UpdateStrategyUnknown
+		}
+		strategy := ""
+		for _, config := range bundle.ManifestConfigs {
+			configStrategy := knownUpdateStrategy(config.UpdateStrategy, workv1.UpdateStrategyTypeUpdate)
+			if configStrategy == UpdateStrategyUnknown {
+				return configStrategy
+			}
+			if strategy != "" && strategy != configStrategy {
+				return UpdateStrategyMixed
+			}
+			strategy = configStrategy
+		}
+		// the manifests without a config are applied with the default update strategy of the agent
+		if len(bundle.ManifestConfigs) < len(bundle.Manifests) && strategy != "" &&
+			strategy != string(workv1.UpdateStrategyTypeUpdate) {
+			return UpdateStrategyMixed
+		}
+		if strategy == "" {
+			return string(workv1.UpdateStrategyTypeUpdate)
+		}
+		return strategy
+	}
+
+	evt, err := JSONMAPToCloudEvent(payload)
+	if err != nil {
+		return UpdateStrategyUnknown
+	}
+	eventPayload := &workpayload.Manifest{}
+	if err := evt.DataAs(eventPayload); err != nil {
+		return UpdateStrategyUnknown
+	}
+	if eventPayload.ConfigOption == nil {
+		return string(workv1.UpdateStrategyTypeServerSideApply)
+	}
+	return knownUpdateStrategy(eventPayload.ConfigOption.UpdateStrategy, workv1.UpdateStrategyTypeServerSideApply)
+}
+
+// knownUpdateStrategy returns the type of the update strategy, the default type if it is not set, or
+// UpdateStrategyUnknown if the type is not known.
+func knownUpdateStrategy(strategy *workv1.UpdateStrategy, defaultType workv1.UpdateStrategyType) string {
+	if strategy == nil || strategy.Type == "" {
+		return string(defaultType)
+	}
+	switch strategy.Type {
+	case workv1.UpdateStrategyTypeServerSideApply, workv1.UpdateStrategyTypeUpdate,
+		workv1.UpdateStrategyTypeCreateOnly, workv1.UpdateStrategyTypeReadOnly:
+		return string(strategy.Type)
+	default:
+		return UpdateStrategyUnknown
+	}
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"testing"
+
+	"gorm.io/datatypes"
+)
+
+func TestEffectiveUpdateStrategy(t *testing.T) {
+	manifest := map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": "test", "namespace": "test"},
+	}
+	single, err := EncodeManifest(manifest, nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	createOnly, err := EncodeManifest(manifest, nil, map[string]interface{}{"type": "CreateOnly"}, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	bundle := func(configs string) datatypes.JSONMap {
+		return newJSONMap(t, "{\"specversion\":\"1.0\",\"datacontenttype\":\"application/json\",\"data\":{\"manifests\":[{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"a\",\"namespace\":\"test\"}},{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"b\",\"namespace\":\"test\"}}],\"manifestConfigs\":"+configs+"}}")
+	}
+	config := func(name, strategy string) string {
+		return "{\"resourceIdentifier\":{\"resource\":\"configmaps\",\"name\":\"" + name + "\",\"namespace\":\"test\"},\"updateStrategy\":{\"type\":\"" + strategy + "\"}}"
+	}
+
+	cases := []struct {
+		name         string
+		resourceType ResourceType
+		payload      datatypes.JSONMap
+		expected     string
+	}{
+		{
+			name:         "single resource with the default update strategy",
+			resourceType: ResourceTypeSingle,
+			payload:      single,
+			expected:     "ServerSideApply",
+		},
+		{
+			name:         "single resource with an update strategy",
+			resourceType: ResourceTypeSingle,
+			payload:      createOnly,
+			expected:     "CreateOnly",
+		},
+		{
+			name:         "bundle without manifest configs",
+			resourceType: ResourceTypeBundle,
+			payload:      bundle("[]"),
+			expected:     "Update",
+		},
+		{
+			name:         "bundle with the same update strategy",
+			resourceType: ResourceTypeBundle,
+			payload:      bundle("[" + config("a", "ReadOnly") + "," + config("b", "ReadOnly") + "]"),
+			expected:     "ReadOnly",
+		},
+		{
+			name:         "bundle with different update strategies",
+			resourceType: ResourceTypeBundle,
+			payload:      bundle("[" + config("a", "ServerSideApply") + "," + config("b", "Update") + "]"),
+			expected:     UpdateStrategyMixed,
+		},
+		{
+			name:         "bundle with a manifest without config",
+			resourceType: ResourceTypeBundle,
+			payload:      bundle("[" + config("a", "CreateOnly") + "]"),
+			expected:     UpdateStrategyMixed,
+		},
+		{
+			name:         "bundle with an unknown update strategy",
+			resourceType: ResourceTypeBundle,
+			payload:      bundle("[" + config("a", "Replace") + "]"),
+			expected:     UpdateStrategyUnknown,
+		},
+		{
+			name:         "no payload",
+			resourceType: ResourceTypeSingle,
+			expected:     UpdateStrategyUnknown,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if strategy := EffectiveUpdateStrategy(c.resourceType, c.payload); strategy != c.expected {
+				t.Errorf("expected %s, but got %s", c.expected, strategy)
+			}
+		})
+	}
+}
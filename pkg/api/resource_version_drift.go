@@ -0,0 +1,79 @@
+package api
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/datatypes"
+	cetypes "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/types"
+)
+
+// VersionDriftState is the drift state of the resource version observed by the agent against the resource version of
+// the server.
+type VersionDriftState string
+
+const (
+	// VersionDriftUnreported means the agent never reported the status of the resource, its observed version is 0.
+	VersionDriftUnreported VersionDriftState = "Unreported"
+	// VersionDriftLagging means the observed version is behind the resource version, e.g. the agent is disconnected.
+	VersionDriftLagging VersionDriftState = "Lagging"
+	// VersionDriftAhead means the observed version is ahead of the resource version, it is never expected and
+	// usually indicates a replayed status or a restored database.
+	VersionDriftAhead VersionDriftState = "Ahead"
+)
+
+// VersionDriftStates is the list of the version drift states.
+var VersionDriftStates = []VersionDriftState{VersionDriftUnreported, VersionDriftLagging, VersionDriftAhead}
+
+// ParseVersionDriftState parses the version drift state, an empty state means all the states.
+func ParseVersionDriftState(state string) (VersionDriftState, error) {
+	if state == "" {
+		return "", nil
+	}
+	for _, s := range VersionDriftStates {
+		if string(s) == state {
+			return s, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported version drift state %q, it must be one of %v", state, VersionDriftStates)
+}
+
+// VersionDriftStateOf returns the drift state of the observed version against the resource version, an empty state
+// means the observed version is the resource version.
+func VersionDriftStateOf(version, observedVersion int64) VersionDriftState {
+	switch {
+	case observedVersion == 0:
+		return VersionDriftUnreported
+	case observedVersion < version:
+		return VersionDriftLagging
+	case observedVersion > version:
+		return VersionDriftAhead
+	default:
+		return ""
+	}
+}
+
+// ObservedVersion returns the resource version observed by the agent from the CloudEvent JSONMap representation of
+// the resource status, 0 is returned if the resource has no status yet.
+func ObservedVersion(status datatypes.JSONMap) (int64, error) {
+	value, ok := status[cetypes.ExtensionResourceVersion]
+	if !ok {
+		return 0, nil
+	}
+	return ToResourceVersion(value)
+}
+
+// ResourceVersionDrift is a resource whose observed version drifts from its resource version, it is read from the
+// resource_version_drifts view. Drift is the absolute difference between the versions.
+type ResourceVersionDrift struct {
+	ResourceID      string
+	ConsumerName    string
+	Type            ResourceType
+	Version         int64
+	ObservedVersion int64
+	State           VersionDriftState
+	Drift           int64
+	UpdatedAt       time.Time
+}
+
+type ResourceVersionDriftList []*ResourceVersionDrift
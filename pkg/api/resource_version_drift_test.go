@@ -0,0 +1,74 @@
+package api
+
+import (
+	"testing"
+
+	"gorm.io/datatypes"
+)
+
+func TestVersionDriftStateOf(t *testing.T) {
+	cases := []struct {
+		name            string
+		version         int64
+		observedVersion int64
+		expected        VersionDriftState
+	}{
+		{name: "never reported", version: 3, observedVersion: 0, expected: VersionDriftUnreported},
+		{name: "lagging", version: 3, observedVersion: 2, expected: VersionDriftLagging},
+		{name: "ahead", version: 3, observedVersion: 4, expected: VersionDriftAhead},
+		{name: "in sync", version: 3, observedVersion: 3, expected: ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if state := VersionDriftStateOf(c.version, c.observedVersion); state != c.expected {
+				t.Errorf("expected %q, but got %q", c.expected, state)
+			}
+		})
+	}
+}
+
+func TestObservedVersion(t *testing.T) {
+	cases := []struct {
+		name      string
+		status    datatypes.JSONMap
+		expected  int64
+		expectErr bool
+	}{
+		{name: "no status", expected: 0},
+		{name: "integer version", status: datatypes.JSONMap{"resourceversion": float64(2)}, expected: 2},
+		{name: "string version", status: datatypes.JSONMap{"resourceversion": "3000000000"}, expected: 3000000000},
+		{name: "invalid version", status: datatypes.JSONMap{"resourceversion": "v1"}, expectErr: true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			version, err := ObservedVersion(c.status)
+			if c.expectErr {
+				if err == nil {
+					t.Errorf("expected error, but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if version != c.expected {
+				t.Errorf("expected %d, but got %d", c.expected, version)
+			}
+		})
+	}
+}
+
+func TestParseVersionDriftState(t *testing.T) {
+	for _, state := range append(VersionDriftStates, "") {
+		parsed, err := ParseVersionDriftState(string(state))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if parsed != state {
+			t.Errorf("expected %q, but got %q", state, parsed)
+		}
+	}
+	if _, err := ParseVersionDriftState("lagging"); err == nil {
+		t.Errorf("expected error, but got nil")
+	}
+}
@@ -10,6 +10,9 @@ type ServerInstance struct {
 	Meta
 	LastHeartbeat time.Time // LastHeartbeat indicates the last time the instance sent a heartbeat.
 	Ready         bool      // Ready indicates whether the instance is ready to serve requests.
+	// Address is the advertised address of the gRPC broker of the instance, the agents are redirected to this
+	// address when the connection affinity is enabled.
+	Address string
 }
 
 type ServerInstanceList []*ServerInstance
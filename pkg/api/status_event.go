@@ -3,6 +3,7 @@ package api
 import (
 	"time"
 
+	"github.com/lib/pq"
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
@@ -23,6 +24,9 @@ type StatusEvent struct {
 	Status          datatypes.JSONMap
 	StatusEventType StatusEventType // Update|Delete
 	ReconciledDate  *time.Time      `json:"gorm:null"`
+	// PreviousConditions is the conditions summary of the resource before the status update, it is compared with
+	// the conditions summary of the updated resource to tell which conditions are transitioned by the update.
+	PreviousConditions pq.StringArray `gorm:"type:text[]"`
 }
 
 type StatusEventList []*StatusEvent
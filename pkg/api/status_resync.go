@@ -0,0 +1,38 @@
+package api
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// StatusResyncState is the state of a status resync.
+type StatusResyncState string
+
+const (
+	StatusResyncRunning   StatusResyncState = "Running"
+	StatusResyncCompleted StatusResyncState = "Completed"
+	StatusResyncCanceled  StatusResyncState = "Canceled"
+)
+
+// StatusResync is a status resync of all the consumers, it is kept in the database, so its progress is reported and
+// it is canceled by any maestro instance, while it is only run by the instance given by InstanceID.
+type StatusResync struct {
+	Meta
+	State      StatusResyncState
+	Consumers  int
+	Processed  int
+	Failed     int
+	StartedAt  time.Time
+	FinishedAt *time.Time
+	// InstanceID is the maestro instance that runs the resync.
+	InstanceID string
+	// CancelRequested is set once the resync is canceled, the running instance stops the resync before its next
+	// consumer.
+	CancelRequested bool
+}
+
+func (r *StatusResync) BeforeCreate(tx *gorm.DB) error {
+	r.ID = NewID()
+	return nil
+}
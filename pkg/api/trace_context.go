@@ -0,0 +1,80 @@
+package api
+
+import (
+	"context"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	cloudeventstypes "github.com/cloudevents/sdk-go/v2/types"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/datatypes"
+)
+
+// The CloudEvent extensions of the W3C trace context, see the distributed tracing extension of the CloudEvents spec.
+const (
+	ExtensionTraceParent = "traceparent"
+	ExtensionTraceState  = "tracestate"
+)
+
+var traceContextPropagator = propagation.TraceContext{}
+
+// traceContextCarrier reads and writes the trace context from and to the extensions of a CloudEvent.
+type traceContextCarrier struct {
+	evt *cloudevents.Event
+}
+
+var _ propagation.TextMapCarrier = &traceContextCarrier{}
+
+func (c *traceContextCarrier) Get(key string) string {
+	value, ok := c.evt.Extensions()[key]
+	if !ok {
+		return ""
+	}
+	s, err := cloudeventstypes.ToString(value)
+	if err != nil {
+		return ""
+	}
+	return s
+}
+
+func (c *traceContextCarrier) Set(key, value string) {
+	c.evt.SetExtension(key, value)
+}
+
+func (c *traceContextCarrier) Keys() []string {
+	return traceContextPropagator.Fields()
+}
+
+// ExtractTraceContext returns the context with the remote span context of the traceparent and tracestate extensions
+// of the CloudEvent, so the spans started with the context are the children of the span of the event sender. The
+// context is returned as it is if the event has no trace context or its trace context is malformed.
+func ExtractTraceContext(ctx context.Context, evt *cloudevents.Event) context.Context {
+	return traceContextPropagator.Extract(ctx, &traceContextCarrier{evt: evt})
+}
+
+// InjectTraceContext sets the span context of the context to the traceparent and tracestate extensions of the
+// CloudEvent, so the event receiver can continue the trace. The event is not changed if the context has no valid
+// span context.
+func InjectTraceContext(ctx context.Context, evt *cloudevents.Event) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return
+	}
+	traceContextPropagator.Inject(ctx, &traceContextCarrier{evt: evt})
+}
+
+// InheritTraceContext sets the trace context extensions of the CloudEvent JSON map (e.g. the spec of a resource) to
+// the CloudEvent that has no trace context of its own (e.g. the status of a resource), so the event continues the
+// trace of the JSON map.
+func InheritTraceContext(evt *cloudevents.Event, from datatypes.JSONMap) {
+	if _, ok := evt.Extensions()[ExtensionTraceParent]; ok {
+		return
+	}
+	traceParent, ok := from[ExtensionTraceParent].(string)
+	if !ok || len(traceParent) == 0 {
+		return
+	}
+	evt.SetExtension(ExtensionTraceParent, traceParent)
+	if traceState, ok := from[ExtensionTraceState].(string); ok && len(traceState) != 0 {
+		evt.SetExtension(ExtensionTraceState, traceState)
+	}
+}
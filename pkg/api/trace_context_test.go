@@ -0,0 +1,90 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/datatypes"
+)
+
+const testTraceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func TestExtractTraceContext(t *testing.T) {
+	cases := []struct {
+		name        string
+		traceParent string
+		valid       bool
+	}{
+		{name: "no trace context"},
+		{name: "malformed trace context", traceParent: "00-invalid-01"},
+		{name: "trace context", traceParent: testTraceParent, valid: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			evt := cloudevents.NewEvent()
+			if len(c.traceParent) != 0 {
+				evt.SetExtension(ExtensionTraceParent, c.traceParent)
+				evt.SetExtension(ExtensionTraceState, "vendor=value")
+			}
+
+			spanContext := trace.SpanContextFromContext(ExtractTraceContext(context.Background(), &evt))
+			if spanContext.IsValid() != c.valid {
+				t.Fatalf("expected the span context is valid: %v, but got %v", c.valid, spanContext.IsValid())
+			}
+			if !c.valid {
+				return
+			}
+			if !spanContext.IsRemote() || spanContext.TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+				t.Errorf("unexpected span context %v", spanContext)
+			}
+			if spanContext.TraceState().Get("vendor") != "value" {
+				t.Errorf("expected the trace state is extracted, but got %v", spanContext.TraceState())
+			}
+		})
+	}
+}
+
+func TestInjectTraceContext(t *testing.T) {
+	// the event is not changed without a span context
+	evt := cloudevents.NewEvent()
+	InjectTraceContext(context.Background(), &evt)
+	if _, ok := evt.Extensions()[ExtensionTraceParent]; ok {
+		t.Errorf("expected no trace context, but got %v", evt.Extensions())
+	}
+
+	from := cloudevents.NewEvent()
+	from.SetExtension(ExtensionTraceParent, testTraceParent)
+	InjectTraceContext(ExtractTraceContext(context.Background(), &from), &evt)
+	if traceParent := evt.Extensions()[ExtensionTraceParent]; traceParent != testTraceParent {
+		t.Errorf("expected the trace parent %s, but got %v", testTraceParent, traceParent)
+	}
+}
+
+func TestInheritTraceContext(t *testing.T) {
+	spec := datatypes.JSONMap{ExtensionTraceParent: testTraceParent, ExtensionTraceState: "vendor=value"}
+
+	evt := cloudevents.NewEvent()
+	InheritTraceContext(&evt, spec)
+	if evt.Extensions()[ExtensionTraceParent] != testTraceParent || evt.Extensions()[ExtensionTraceState] != "vendor=value" {
+		t.Errorf("expected the trace context of the spec, but got %v", evt.Extensions())
+	}
+
+	// the trace context of the event is kept
+	traceParent := "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"
+	evt = cloudevents.NewEvent()
+	evt.SetExtension(ExtensionTraceParent, traceParent)
+	InheritTraceContext(&evt, spec)
+	if evt.Extensions()[ExtensionTraceParent] != traceParent {
+		t.Errorf("expected the trace context of the event is kept, but got %v", evt.Extensions())
+	}
+
+	// nothing is inherited from the spec without a trace context
+	evt = cloudevents.NewEvent()
+	InheritTraceContext(&evt, datatypes.JSONMap{})
+	if len(evt.Extensions()) != 0 {
+		t.Errorf("expected no extensions, but got %v", evt.Extensions())
+	}
+}
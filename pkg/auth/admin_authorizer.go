@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/openshift-online/maestro/pkg/errors"
+)
+
+// AdminAuthorizer authorizes the admin operations, e.g. transferring the ownership of a resource or force-releasing
+// the soft-lock of another user, an admin is an authenticated user whose username is in the admin users.
+type AdminAuthorizer interface {
+	// IsAdmin returns true if the authenticated user of the context is an admin.
+	IsAdmin(ctx context.Context) bool
+	// RequireAdmin rejects the request with 403 Forbidden if the authenticated user of the request is not an admin.
+	RequireAdmin(next http.Handler) http.Handler
+}
+
+type adminAuthorizer struct {
+	admins map[string]bool
+}
+
+var _ AdminAuthorizer = &adminAuthorizer{}
+
+func NewAdminAuthorizer(admins []string) AdminAuthorizer {
+	authorizer := &adminAuthorizer{admins: map[string]bool{}}
+	for _, admin := range admins {
+		if admin != "" {
+			authorizer.admins[admin] = true
+		}
+	}
+	return authorizer
+}
+
+func (a *adminAuthorizer) IsAdmin(ctx context.Context) bool {
+	username := GetUsernameFromContext(ctx)
+	return username != "" && a.admins[username]
+}
+
+func (a *adminAuthorizer) RequireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if !a.IsAdmin(ctx) {
+			handleError(ctx, w, errors.ErrorForbidden,
+				fmt.Sprintf("User '%s' is not an admin, the operation requires an admin", GetUsernameFromContext(ctx)))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
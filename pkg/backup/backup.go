@@ -0,0 +1,179 @@
+package backup
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/client/objectstore"
+)
+
+const (
+	KindConsumer = "Consumer"
+	KindResource = "Resource"
+
+	// maxRecordSize is the max size of a record in the backup, a record holds a resource with its manifests.
+	maxRecordSize = 64 * 1024 * 1024
+)
+
+// Record is a line of the backup, it holds either a consumer or a resource.
+type Record struct {
+	Kind     string        `json:"kind"`
+	Consumer *api.Consumer `json:"consumer,omitempty"`
+	Resource *api.Resource `json:"resource,omitempty"`
+}
+
+// ImportResult is the summary of an import.
+type ImportResult struct {
+	// Created is the number of the records that are restored.
+	Created int
+	// Skipped is the number of the records that are skipped since a record with the same ID already exists.
+	Skipped int
+}
+
+// Export writes all the consumers and resources as the newline-delimited JSON records to the writer, the consumers
+// are written before the resources, so a resource always comes after its consumer. The records are read in a single
+// repeatable read transaction, so the backup is a consistent snapshot of the database.
+//
+// The soft-deleted records (e.g. the resources that are being deleted) are exported as well, so the state of the
+// resources is kept as it is. The payloads offloaded to the object store are fetched from the payloadStore and
+// inlined in the resource records, so the backup does not depend on the object store. The export fails if a payload
+// is offloaded but the payloadStore is nil.
+func Export(ctx context.Context, g2 *gorm.DB, payloadStore objectstore.Client, w io.Writer) error {
+	tx := g2.WithContext(ctx).Begin(&sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if tx.Error != nil {
+		return fmt.Errorf("failed to begin the export transaction: %v", tx.Error)
+	}
+	defer tx.Rollback()
+
+	encoder := json.NewEncoder(w)
+	if err := exportTable(tx, encoder, &api.Consumer{}, func() (interface{}, Record) {
+		consumer := &api.Consumer{}
+		return consumer, Record{Kind: KindConsumer, Consumer: consumer}
+	}, nil); err != nil {
+		return fmt.Errorf("failed to export the consumers: %v", err)
+	}
+	if err := exportTable(tx, encoder, &api.Resource{}, func() (interface{}, Record) {
+		resource := &api.Resource{}
+		return resource, Record{Kind: KindResource, Resource: resource}
+	}, func(record Record) error {
+		return inlinePayload(ctx, payloadStore, record.Resource)
+	}); err != nil {
+		return fmt.Errorf("failed to export the resources: %v", err)
+	}
+
+	return tx.Commit().Error
+}
+
+// exportTable writes the rows of the model in the creation order, the rows are scanned one by one into the records
+// returned by newRecord, so the whole table is not loaded into memory. The prepare func, if it is set, is called on
+// each record before it is written.
+func exportTable(tx *gorm.DB, encoder *json.Encoder, model interface{}, newRecord func() (interface{}, Record),
+	prepare func(Record) error) error {
+	rows, err := tx.Unscoped().Model(model).Order("created_at, id").Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		dest, record := newRecord()
+		if err := tx.ScanRows(rows, dest); err != nil {
+			return err
+		}
+		if prepare != nil {
+			if err := prepare(record); err != nil {
+				return err
+			}
+		}
+		if err := encoder.Encode(record); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// inlinePayload replaces the payload of the resource with its offloaded payload in the object store, and drops the
+// reference of the offloaded payload, so the resource is restored with its full payload.
+func inlinePayload(ctx context.Context, payloadStore objectstore.Client, resource *api.Resource) error {
+	if len(resource.PayloadRef) == 0 {
+		return nil
+	}
+	if payloadStore == nil {
+		return fmt.Errorf("the payload of resource %s is offloaded to the object store, but the object store is not configured", resource.ID)
+	}
+
+	data, err := payloadStore.Get(ctx, resource.PayloadRef)
+	if err != nil {
+		return fmt.Errorf("failed to fetch the payload of resource %s from the object store: %v", resource.ID, err)
+	}
+	payload := datatypes.JSONMap{}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal the offloaded payload of resource %s: %v", resource.ID, err)
+	}
+	resource.Payload = payload
+	resource.PayloadRef = ""
+	return nil
+}
+
+// Import restores the consumers and resources from the newline-delimited JSON records written by Export, the records
+// are restored by their IDs in a single transaction, so either all or none of the records are restored. The import
+// is idempotent, a record is skipped if a record with the same ID already exists, other conflicts (e.g. a consumer
+// with the same name but a different ID) fail the import. The records are restored in the order of the backup, so a
+// resource must come after its consumer.
+func Import(ctx context.Context, g2 *gorm.DB, r io.Reader) (*ImportResult, error) {
+	result := &ImportResult{}
+	err := g2.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// the records are restored as they are, the hooks (e.g. generating the IDs) are skipped
+		tx = tx.Session(&gorm.Session{SkipHooks: true})
+
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxRecordSize)
+		for line := 1; scanner.Scan(); line++ {
+			if len(scanner.Bytes()) == 0 {
+				continue
+			}
+
+			record := Record{}
+			if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+				return fmt.Errorf("invalid record at line %d: %v", line, err)
+			}
+
+			var value interface{}
+			switch {
+			case record.Kind == KindConsumer && record.Consumer != nil:
+				value = record.Consumer
+			case record.Kind == KindResource && record.Resource != nil:
+				value = record.Resource
+			default:
+				return fmt.Errorf("invalid record at line %d: unsupported kind %q", line, record.Kind)
+			}
+
+			created := tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "id"}}, DoNothing: true}).Create(value)
+			if created.Error != nil {
+				return fmt.Errorf("failed to restore the record at line %d: %v", line, created.Error)
+			}
+			if created.RowsAffected == 0 {
+				result.Skipped++
+				continue
+			}
+			result.Created++
+		}
+		if err := scanner.Err(); err != nil {
+			return fmt.Errorf("failed to read the backup: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
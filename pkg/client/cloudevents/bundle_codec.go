@@ -6,9 +6,11 @@ import (
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 	cloudeventstypes "github.com/cloudevents/sdk-go/v2/types"
-	"github.com/google/uuid"
+	workv1 "open-cluster-management.io/api/work/v1"
 	cegeneric "open-cluster-management.io/sdk-go/pkg/cloudevents/generic"
 	cetypes "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/types"
+	agentcodec "open-cluster-management.io/sdk-go/pkg/cloudevents/work/agent/codec"
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/work/common"
 	workpayload "open-cluster-management.io/sdk-go/pkg/cloudevents/work/payload"
 
 	"github.com/openshift-online/maestro/pkg/api"
@@ -34,13 +36,21 @@ func (codec *BundleCodec) Encode(source string, eventType cetypes.CloudEventsTyp
 	evt.SetType(eventType.String())
 	// TODO set resource.Source with a new extension attribute if the agent needs
 	evt.SetExtension(cetypes.ExtensionResourceID, res.ID)
-	evt.SetExtension(cetypes.ExtensionResourceVersion, int64(res.Version))
+	if err := api.SetResourceVersion(evt, res.Version); err != nil {
+		return nil, err
+	}
 	evt.SetExtension(cetypes.ExtensionClusterName, res.ConsumerName)
 
+	// attach the resource metadata, so the agent receives the same correlation metadata as the source
+	if err := api.SetMetadataExtensions(evt, res.Metadata); err != nil {
+		return nil, fmt.Errorf("failed to set resource metadata to cloudevent: %v", err)
+	}
+
 	if !res.GetDeletionTimestamp().IsZero() {
 		// in the deletion case, the event ID and time remain unchanged in storage.
-		// set the event ID and time before publishing, so the agent can identify the deletion event.
-		evt.SetID(uuid.New().String())
+		// set the event ID and time before publishing, so the agent can identify the deletion event, the event ID is
+		// the same for the redeliveries of the deletion, so they can be deduplicated.
+		evt.SetID(api.DeletionEventID(res))
 		evt.SetTime(time.Now())
 		// set deletion timestamp extension
 		evt.SetExtension(cetypes.ExtensionDeletionTimestamp, res.GetDeletionTimestamp().Time)
@@ -66,7 +76,7 @@ func (codec *BundleCodec) Decode(evt *cloudevents.Event) (*api.Resource, error)
 		return nil, fmt.Errorf("failed to get resourceid extension: %v", err)
 	}
 
-	resourceVersion, err := cloudeventstypes.ToInteger(evtExtensions[cetypes.ExtensionResourceVersion])
+	resourceVersion, err := api.ToResourceVersion(evtExtensions[cetypes.ExtensionResourceVersion])
 	if err != nil {
 		return nil, fmt.Errorf("failed to get resourceversion extension: %v", err)
 	}
@@ -102,3 +112,29 @@ func (codec *BundleCodec) Decode(evt *cloudevents.Event) (*api.Resource, error)
 
 	return resource, nil
 }
+
+// DecodeManifestWork returns the ManifestWork that the agent reconstructs from the spec event of the resource bundle,
+// the resource bundle is encoded as it is published to the agent, and then decoded by the codec of the agent, so the
+// delete option and the manifest configs are the ones the agent receives. A deleting resource bundle is decoded to
+// the ManifestWork with the deletion timestamp and without the spec.
+func DecodeManifestWork(res *api.Resource) (*workv1.ManifestWork, error) {
+	if res.Type != api.ResourceTypeBundle {
+		return nil, fmt.Errorf("the resource %s is not a resource bundle", res.ID)
+	}
+
+	eventType := cetypes.CloudEventsType{
+		CloudEventsDataType: workpayload.ManifestBundleEventDataType,
+		SubResource:         cetypes.SubResourceSpec,
+		Action:              common.UpdateRequestAction,
+	}
+	evt, err := (&BundleCodec{}).Encode(api.EventSource(), eventType, res)
+	if err != nil {
+		return nil, err
+	}
+
+	work, err := agentcodec.NewManifestBundleCodec().Decode(evt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the manifestwork of resource %s: %v", res.ID, err)
+	}
+	return work, nil
+}
@@ -0,0 +1,52 @@
+package cloudevents
+
+import (
+	"testing"
+	"time"
+
+	workv1 "open-cluster-management.io/api/work/v1"
+
+	"github.com/openshift-online/maestro/pkg/api"
+)
+
+func TestDecodeManifestWork(t *testing.T) {
+	res := &api.Resource{
+		Meta:         api.Meta{ID: "c4df9ff0-bfeb-5bc6-a0ab-4c9128d698b4"},
+		Version:      2,
+		ConsumerName: "cluster1",
+		Type:         api.ResourceTypeBundle,
+		Payload:      newJSONMap(t, "{\"specversion\":\"1.0\",\"id\":\"266a8cd2-2fab-4e89-9bf0-a56425ebcdf8\",\"source\":\"grpc\",\"type\":\"io.open-cluster-management.works.v1alpha1.manifestbundles.spec.create_request\",\"datacontenttype\":\"application/json\",\"data\":{\"manifests\":[{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"nginx\",\"namespace\":\"default\"}}],\"deleteOption\":{\"propagationPolicy\":\"Orphan\"},\"manifestConfigs\":[{\"updateStrategy\":{\"type\":\"CreateOnly\"},\"resourceIdentifier\":{\"name\":\"nginx\",\"resource\":\"configmaps\",\"namespace\":\"default\"}}]}}"),
+	}
+
+	work, err := DecodeManifestWork(res)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if work.Name != res.ID || work.Namespace != res.ConsumerName || work.ResourceVersion != "2" {
+		t.Errorf("unexpected manifestwork %s/%s of version %s", work.Namespace, work.Name, work.ResourceVersion)
+	}
+	if len(work.Spec.Workload.Manifests) != 1 {
+		t.Errorf("expected 1 manifest, but got %d", len(work.Spec.Workload.Manifests))
+	}
+	if work.Spec.DeleteOption == nil || work.Spec.DeleteOption.PropagationPolicy != workv1.DeletePropagationPolicyTypeOrphan {
+		t.Errorf("expected the orphan delete option, but got %v", work.Spec.DeleteOption)
+	}
+	if len(work.Spec.ManifestConfigs) != 1 || work.Spec.ManifestConfigs[0].UpdateStrategy.Type != workv1.UpdateStrategyTypeCreateOnly {
+		t.Errorf("expected the create only manifest config, but got %v", work.Spec.ManifestConfigs)
+	}
+
+	// the deleting resource bundle only has the deletion timestamp
+	res.Meta.DeletedAt.Time = time.Now()
+	res.Meta.DeletedAt.Valid = true
+	work, err = DecodeManifestWork(res)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if work.DeletionTimestamp == nil || len(work.Spec.Workload.Manifests) != 0 {
+		t.Errorf("expected the deleting manifestwork without manifests, but got %v", work)
+	}
+
+	if _, err := DecodeManifestWork(&api.Resource{Type: api.ResourceTypeSingle}); err == nil {
+		t.Errorf("expected an error for the single resource")
+	}
+}
@@ -6,7 +6,6 @@ import (
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 	cloudeventstypes "github.com/cloudevents/sdk-go/v2/types"
-	"github.com/google/uuid"
 	cegeneric "open-cluster-management.io/sdk-go/pkg/cloudevents/generic"
 	cetypes "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/types"
 	workpayload "open-cluster-management.io/sdk-go/pkg/cloudevents/work/payload"
@@ -34,13 +33,21 @@ func (codec *Codec) Encode(source string, eventType cetypes.CloudEventsType, res
 	evt.SetType(eventType.String())
 	// TODO set resource.Source with a new extension attribute if the agent needs
 	evt.SetExtension(cetypes.ExtensionResourceID, res.ID)
-	evt.SetExtension(cetypes.ExtensionResourceVersion, int64(res.Version))
+	if err := api.SetResourceVersion(evt, res.Version); err != nil {
+		return nil, err
+	}
 	evt.SetExtension(cetypes.ExtensionClusterName, res.ConsumerName)
 
+	// attach the resource metadata, so the agent receives the same correlation metadata as the source
+	if err := api.SetMetadataExtensions(evt, res.Metadata); err != nil {
+		return nil, fmt.Errorf("failed to set resource metadata to cloudevent: %v", err)
+	}
+
 	if !res.GetDeletionTimestamp().IsZero() {
 		// in the deletion case, the event ID and time remain unchanged in storage.
-		// set the event ID and time before publishing, so the agent can identify the deletion event.
-		evt.SetID(uuid.New().String())
+		// set the event ID and time before publishing, so the agent can identify the deletion event, the event ID is
+		// the same for the redeliveries of the deletion, so they can be deduplicated.
+		evt.SetID(api.DeletionEventID(res))
 		evt.SetTime(time.Now())
 		// set deletion timestamp extension
 		evt.SetExtension(cetypes.ExtensionDeletionTimestamp, res.GetDeletionTimestamp().Time)
@@ -66,7 +73,7 @@ func (codec *Codec) Decode(evt *cloudevents.Event) (*api.Resource, error) {
 		return nil, fmt.Errorf("failed to get resourceid extension: %v", err)
 	}
 
-	resourceVersion, err := cloudeventstypes.ToInteger(evtExtensions[cetypes.ExtensionResourceVersion])
+	resourceVersion, err := api.ToResourceVersion(evtExtensions[cetypes.ExtensionResourceVersion])
 	if err != nil {
 		return nil, fmt.Errorf("failed to get resourceversion extension: %v", err)
 	}
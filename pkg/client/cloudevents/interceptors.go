@@ -0,0 +1,139 @@
+package cloudevents
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/openshift-online/maestro/pkg/logger"
+)
+
+// operationIDMetadataKey is the gRPC metadata key of the operation ID, it is the gRPC form of the X-Operation-ID
+// header of the maestro RESTful API.
+const operationIDMetadataKey = "x-operation-id"
+
+// TokenSource returns the token to access the maestro gRPC server, it is called for each request, so a rotated
+// token is picked up without recreating the client.
+type TokenSource func(ctx context.Context) (string, error)
+
+// StaticToken returns a TokenSource that always returns the given token.
+func StaticToken(token string) TokenSource {
+	return func(ctx context.Context) (string, error) {
+		return token, nil
+	}
+}
+
+// NewTokenUnaryClientInterceptor creates a unary interceptor that sets the token of the token source as the bearer
+// token in the authorization metadata of each request.
+func NewTokenUnaryClientInterceptor(tokenSource TokenSource) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, err := withToken(ctx, tokenSource)
+		if err != nil {
+			return err
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// NewTokenStreamClientInterceptor creates a stream interceptor that sets the token of the token source as the bearer
+// token in the authorization metadata of each stream.
+func NewTokenStreamClientInterceptor(tokenSource TokenSource) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string,
+		streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx, err := withToken(ctx, tokenSource)
+		if err != nil {
+			return nil, err
+		}
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
+
+// NewRetryUnaryClientInterceptor creates a unary interceptor that retries the request with the backoff when the
+// server is Unavailable (e.g. the server is restarting or the connection is being reestablished). The last error is
+// returned once the backoff steps are exhausted, the other errors are returned without retry.
+func NewRetryUnaryClientInterceptor(backoff wait.Backoff) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		var lastErr error
+		err := wait.ExponentialBackoffWithContext(ctx, backoff, func(ctx context.Context) (bool, error) {
+			lastErr = invoker(ctx, method, req, reply, cc, opts...)
+			if status.Code(lastErr) == codes.Unavailable {
+				return false, nil
+			}
+			return true, lastErr
+		})
+		if wait.Interrupted(err) && lastErr != nil {
+			return lastErr
+		}
+		return err
+	}
+}
+
+// NewRetryStreamClientInterceptor creates a stream interceptor that retries establishing the stream with the backoff
+// when the server is Unavailable. The errors after the stream is established are not retried, the caller should
+// resubscribe in that case.
+func NewRetryStreamClientInterceptor(backoff wait.Backoff) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string,
+		streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		var stream grpc.ClientStream
+		var lastErr error
+		err := wait.ExponentialBackoffWithContext(ctx, backoff, func(ctx context.Context) (bool, error) {
+			stream, lastErr = streamer(ctx, desc, cc, method, opts...)
+			if status.Code(lastErr) == codes.Unavailable {
+				return false, nil
+			}
+			return true, lastErr
+		})
+		if wait.Interrupted(err) && lastErr != nil {
+			return nil, lastErr
+		}
+		if err != nil {
+			return nil, err
+		}
+		return stream, nil
+	}
+}
+
+// NewOperationIDUnaryClientInterceptor creates a unary interceptor that propagates the operation ID of the context
+// (see logger.WithOpID) to the server with the x-operation-id metadata, so a request can be traced across the
+// source and the maestro server logs.
+func NewOperationIDUnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(withOperationID(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// NewOperationIDStreamClientInterceptor creates a stream interceptor that propagates the operation ID of the context
+// to the server with the x-operation-id metadata.
+func NewOperationIDStreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string,
+		streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(withOperationID(ctx), desc, cc, method, opts...)
+	}
+}
+
+func withToken(ctx context.Context, tokenSource TokenSource) (context.Context, error) {
+	token, err := tokenSource(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "failed to get token: %v", err)
+	}
+	if len(token) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "the token is empty")
+	}
+	return metadata.AppendToOutgoingContext(ctx, "authorization", fmt.Sprintf("Bearer %s", token)), nil
+}
+
+func withOperationID(ctx context.Context) context.Context {
+	opID := logger.GetOperationID(ctx)
+	if len(opID) == 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, operationIDMetadataKey, opID)
+}
@@ -0,0 +1,173 @@
+package cloudevents
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"k8s.io/apimachinery/pkg/util/wait"
+	pbv1 "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/grpc/protobuf/v1"
+
+	"github.com/openshift-online/maestro/pkg/logger"
+)
+
+// fakeCloudEventServer fails the first unavailableCount requests with Unavailable and records the metadata of the
+// requests.
+type fakeCloudEventServer struct {
+	pbv1.UnimplementedCloudEventServiceServer
+
+	mu               sync.Mutex
+	unavailableCount int
+	requests         int
+	metadata         []metadata.MD
+}
+
+func (s *fakeCloudEventServer) Publish(ctx context.Context, req *pbv1.PublishRequest) (*emptypb.Empty, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	s.metadata = append(s.metadata, md)
+	s.requests++
+	if s.requests <= s.unavailableCount {
+		return nil, status.Error(codes.Unavailable, "server is unavailable")
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *fakeCloudEventServer) Subscribe(req *pbv1.SubscriptionRequest, stream pbv1.CloudEventService_SubscribeServer) error {
+	s.mu.Lock()
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	s.metadata = append(s.metadata, md)
+	s.requests++
+	s.mu.Unlock()
+	return nil
+}
+
+func newFakeCloudEventClient(t *testing.T, server *fakeCloudEventServer, opts ...grpc.DialOption) pbv1.CloudEventServiceClient {
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer()
+	pbv1.RegisterCloudEventServiceServer(grpcServer, server)
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	t.Cleanup(grpcServer.Stop)
+
+	opts = append(opts,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+	)
+	conn, err := grpc.NewClient("passthrough:///bufnet", opts...)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	return pbv1.NewCloudEventServiceClient(conn)
+}
+
+func TestRetryUnaryClientInterceptor(t *testing.T) {
+	backoff := wait.Backoff{Duration: 10 * time.Millisecond, Factor: 1, Steps: 3}
+	cases := []struct {
+		name             string
+		unavailableCount int
+		expectedRequests int
+		expectedCode     codes.Code
+	}{
+		{
+			name:             "succeed without retry",
+			unavailableCount: 0,
+			expectedRequests: 1,
+			expectedCode:     codes.OK,
+		},
+		{
+			name:             "succeed after retry",
+			unavailableCount: 2,
+			expectedRequests: 3,
+			expectedCode:     codes.OK,
+		},
+		{
+			name:             "unavailable after the backoff is exhausted",
+			unavailableCount: 5,
+			expectedRequests: 3,
+			expectedCode:     codes.Unavailable,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			server := &fakeCloudEventServer{unavailableCount: c.unavailableCount}
+			client := newFakeCloudEventClient(t, server, grpc.WithUnaryInterceptor(NewRetryUnaryClientInterceptor(backoff)))
+
+			_, err := client.Publish(context.Background(), &pbv1.PublishRequest{})
+			if status.Code(err) != c.expectedCode {
+				t.Errorf("expected code %s, but got: %v", c.expectedCode, err)
+			}
+			if server.requests != c.expectedRequests {
+				t.Errorf("expected %d requests, but got %d", c.expectedRequests, server.requests)
+			}
+		})
+	}
+}
+
+func TestTokenClientInterceptor(t *testing.T) {
+	server := &fakeCloudEventServer{}
+	client := newFakeCloudEventClient(t, server,
+		grpc.WithChainUnaryInterceptor(
+			NewTokenUnaryClientInterceptor(StaticToken("test-token")),
+			NewOperationIDUnaryClientInterceptor(),
+		),
+		grpc.WithChainStreamInterceptor(
+			NewTokenStreamClientInterceptor(StaticToken("test-token")),
+			NewOperationIDStreamClientInterceptor(),
+		),
+	)
+
+	ctx := logger.WithOpID(context.Background())
+	if _, err := client.Publish(ctx, &pbv1.PublishRequest{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	stream, err := client.Subscribe(ctx, &pbv1.SubscriptionRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// wait for the stream to be closed by the server
+	for {
+		if _, err := stream.Recv(); err != nil {
+			break
+		}
+	}
+
+	if len(server.metadata) != 2 {
+		t.Fatalf("expected 2 requests, but got %d", len(server.metadata))
+	}
+	for _, md := range server.metadata {
+		if auth := md.Get("authorization"); len(auth) != 1 || auth[0] != "Bearer test-token" {
+			t.Errorf("expected the bearer token, but got: %v", auth)
+		}
+		if opID := md.Get(operationIDMetadataKey); len(opID) != 1 || opID[0] != logger.GetOperationID(ctx) {
+			t.Errorf("expected the operation id %s, but got: %v", logger.GetOperationID(ctx), opID)
+		}
+	}
+
+	// the request is rejected before it is sent if the token is empty
+	emptyTokenClient := newFakeCloudEventClient(t, &fakeCloudEventServer{},
+		grpc.WithUnaryInterceptor(NewTokenUnaryClientInterceptor(StaticToken(""))))
+	if _, err := emptyTokenClient.Publish(context.Background(), &pbv1.PublishRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected unauthenticated error, but got: %v", err)
+	}
+}
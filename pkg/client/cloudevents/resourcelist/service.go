@@ -0,0 +1,118 @@
+// Package resourcelist defines the gRPC service of the maestro gRPC broker for an agent to pull the current resource
+// specs of its cluster in one call. The service reuses the messages of the CloudEvent service of the sdk-go, so it is
+// defined here manually rather than generated from a proto file.
+package resourcelist
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	pbv1 "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/grpc/protobuf/v1"
+)
+
+const (
+	ServiceName = "io.openshift.maestro.v1.ResourceListService"
+
+	ResourceListService_ListResources_FullMethodName = "/" + ServiceName + "/ListResources"
+)
+
+// ResourceListServiceClient is the client API for the ResourceListService service.
+type ResourceListServiceClient interface {
+	// ListResources streams the spec events of the active resources of the cluster in the request, the cluster name
+	// of the request is required. The stream is ended once all the resources are sent.
+	ListResources(ctx context.Context, in *pbv1.SubscriptionRequest, opts ...grpc.CallOption) (ResourceListService_ListResourcesClient, error)
+}
+
+type resourceListServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewResourceListServiceClient(cc grpc.ClientConnInterface) ResourceListServiceClient {
+	return &resourceListServiceClient{cc}
+}
+
+func (c *resourceListServiceClient) ListResources(ctx context.Context, in *pbv1.SubscriptionRequest, opts ...grpc.CallOption) (ResourceListService_ListResourcesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ResourceListService_ServiceDesc.Streams[0], ResourceListService_ListResources_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &resourceListServiceListResourcesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ResourceListService_ListResourcesClient interface {
+	Recv() (*pbv1.CloudEvent, error)
+	grpc.ClientStream
+}
+
+type resourceListServiceListResourcesClient struct {
+	grpc.ClientStream
+}
+
+func (x *resourceListServiceListResourcesClient) Recv() (*pbv1.CloudEvent, error) {
+	m := new(pbv1.CloudEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ResourceListServiceServer is the server API for the ResourceListService service.
+type ResourceListServiceServer interface {
+	ListResources(*pbv1.SubscriptionRequest, ResourceListService_ListResourcesServer) error
+}
+
+// UnimplementedResourceListServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedResourceListServiceServer struct {
+}
+
+func (UnimplementedResourceListServiceServer) ListResources(*pbv1.SubscriptionRequest, ResourceListService_ListResourcesServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListResources not implemented")
+}
+
+func RegisterResourceListServiceServer(s grpc.ServiceRegistrar, srv ResourceListServiceServer) {
+	s.RegisterService(&ResourceListService_ServiceDesc, srv)
+}
+
+func _ResourceListService_ListResources_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(pbv1.SubscriptionRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ResourceListServiceServer).ListResources(m, &resourceListServiceListResourcesServer{stream})
+}
+
+type ResourceListService_ListResourcesServer interface {
+	Send(*pbv1.CloudEvent) error
+	grpc.ServerStream
+}
+
+type resourceListServiceListResourcesServer struct {
+	grpc.ServerStream
+}
+
+func (x *resourceListServiceListResourcesServer) Send(m *pbv1.CloudEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// ResourceListService_ServiceDesc is the grpc.ServiceDesc for the ResourceListService service.
+var ResourceListService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*ResourceListServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListResources",
+			Handler:       _ResourceListService_ListResources_Handler,
+			ServerStreams: true,
+		},
+	},
+}
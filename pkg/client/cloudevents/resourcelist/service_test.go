@@ -0,0 +1,111 @@
+package resourcelist
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	pbv1 "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/grpc/protobuf/v1"
+)
+
+// fakeResourceListServer streams a spec event for each of its resource IDs of the requested cluster.
+type fakeResourceListServer struct {
+	UnimplementedResourceListServiceServer
+	resources map[string][]string
+}
+
+func (s *fakeResourceListServer) ListResources(req *pbv1.SubscriptionRequest, stream ResourceListService_ListResourcesServer) error {
+	if len(req.ClusterName) == 0 {
+		return status.Error(codes.InvalidArgument, "missing cluster name")
+	}
+	for _, id := range s.resources[req.ClusterName] {
+		if err := stream.Send(&pbv1.CloudEvent{Id: id, Source: "maestro", SpecVersion: "1.0", Type: "test"}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newFakeResourceListClient(t *testing.T, server ResourceListServiceServer) ResourceListServiceClient {
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer()
+	RegisterResourceListServiceServer(grpcServer, server)
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	return NewResourceListServiceClient(conn)
+}
+
+func listResources(ctx context.Context, client ResourceListServiceClient, clusterName string) ([]string, error) {
+	stream, err := client.ListResources(ctx, &pbv1.SubscriptionRequest{ClusterName: clusterName})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []string{}
+	for {
+		evt, err := stream.Recv()
+		if err == io.EOF {
+			return ids, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, evt.Id)
+	}
+}
+
+func TestListResources(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeResourceListClient(t, &fakeResourceListServer{
+		resources: map[string][]string{"cluster1": {"r1", "r2"}},
+	})
+
+	ids, err := listResources(ctx, client, "cluster1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ids) != 2 || ids[0] != "r1" || ids[1] != "r2" {
+		t.Errorf("unexpected resources: %v", ids)
+	}
+
+	ids, err = listResources(ctx, client, "cluster2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ids) != 0 {
+		t.Errorf("expected no resources, but got: %v", ids)
+	}
+
+	if _, err := listResources(ctx, client, ""); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected invalid argument error, but got: %v", err)
+	}
+}
+
+func TestListResourcesUnimplemented(t *testing.T) {
+	client := newFakeResourceListClient(t, &UnimplementedResourceListServiceServer{})
+	if _, err := listResources(context.Background(), client, "cluster1"); status.Code(err) != codes.Unimplemented {
+		t.Errorf("expected unimplemented error, but got: %v", err)
+	}
+}
@@ -0,0 +1,90 @@
+// Package resourcestatus defines the gRPC service of the maestro gRPC server and broker for a source or an agent to
+// pull the current status of a resource in one call. The service reuses the messages of the CloudEvent service of the
+// sdk-go and the well-known types, so it is defined here manually rather than generated from a proto file.
+package resourcestatus
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	pbv1 "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/grpc/protobuf/v1"
+)
+
+const (
+	ServiceName = "io.openshift.maestro.v1.ResourceStatusService"
+
+	ResourceStatusService_GetResourceStatus_FullMethodName = "/" + ServiceName + "/GetResourceStatus"
+)
+
+// ResourceStatusServiceClient is the client API for the ResourceStatusService service.
+type ResourceStatusServiceClient interface {
+	// GetResourceStatus returns the current status event of the resource, the request is the resource ID.
+	GetResourceStatus(ctx context.Context, in *wrapperspb.StringValue, opts ...grpc.CallOption) (*pbv1.CloudEvent, error)
+}
+
+type resourceStatusServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewResourceStatusServiceClient(cc grpc.ClientConnInterface) ResourceStatusServiceClient {
+	return &resourceStatusServiceClient{cc}
+}
+
+func (c *resourceStatusServiceClient) GetResourceStatus(ctx context.Context, in *wrapperspb.StringValue, opts ...grpc.CallOption) (*pbv1.CloudEvent, error) {
+	out := new(pbv1.CloudEvent)
+	if err := c.cc.Invoke(ctx, ResourceStatusService_GetResourceStatus_FullMethodName, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ResourceStatusServiceServer is the server API for the ResourceStatusService service.
+type ResourceStatusServiceServer interface {
+	GetResourceStatus(context.Context, *wrapperspb.StringValue) (*pbv1.CloudEvent, error)
+}
+
+// UnimplementedResourceStatusServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedResourceStatusServiceServer struct {
+}
+
+func (UnimplementedResourceStatusServiceServer) GetResourceStatus(context.Context, *wrapperspb.StringValue) (*pbv1.CloudEvent, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetResourceStatus not implemented")
+}
+
+func RegisterResourceStatusServiceServer(s grpc.ServiceRegistrar, srv ResourceStatusServiceServer) {
+	s.RegisterService(&ResourceStatusService_ServiceDesc, srv)
+}
+
+func _ResourceStatusService_GetResourceStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(wrapperspb.StringValue)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourceStatusServiceServer).GetResourceStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResourceStatusService_GetResourceStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourceStatusServiceServer).GetResourceStatus(ctx, req.(*wrapperspb.StringValue))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ResourceStatusService_ServiceDesc is the grpc.ServiceDesc for the ResourceStatusService service.
+var ResourceStatusService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*ResourceStatusServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetResourceStatus",
+			Handler:    _ResourceStatusService_GetResourceStatus_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
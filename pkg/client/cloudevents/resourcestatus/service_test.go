@@ -0,0 +1,87 @@
+package resourcestatus
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	pbv1 "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/grpc/protobuf/v1"
+)
+
+// fakeResourceStatusServer returns a status event for each of its resource IDs.
+type fakeResourceStatusServer struct {
+	UnimplementedResourceStatusServiceServer
+	resources map[string]string
+}
+
+func (s *fakeResourceStatusServer) GetResourceStatus(_ context.Context, req *wrapperspb.StringValue) (*pbv1.CloudEvent, error) {
+	if len(req.GetValue()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "missing resource id")
+	}
+	source, ok := s.resources[req.GetValue()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "resource %s is not found", req.GetValue())
+	}
+	return &pbv1.CloudEvent{Id: req.GetValue(), Source: source, SpecVersion: "1.0", Type: "test"}, nil
+}
+
+func newFakeResourceStatusClient(t *testing.T, server ResourceStatusServiceServer) ResourceStatusServiceClient {
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer()
+	RegisterResourceStatusServiceServer(grpcServer, server)
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	return NewResourceStatusServiceClient(conn)
+}
+
+func TestGetResourceStatus(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeResourceStatusClient(t, &fakeResourceStatusServer{
+		resources: map[string]string{"r1": "source1"},
+	})
+
+	evt, err := client.GetResourceStatus(ctx, wrapperspb.String("r1"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if evt.Id != "r1" || evt.Source != "source1" {
+		t.Errorf("unexpected status event: %v", evt)
+	}
+
+	if _, err := client.GetResourceStatus(ctx, wrapperspb.String("r2")); status.Code(err) != codes.NotFound {
+		t.Errorf("expected not found error, but got: %v", err)
+	}
+
+	if _, err := client.GetResourceStatus(ctx, wrapperspb.String("")); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected invalid argument error, but got: %v", err)
+	}
+}
+
+func TestGetResourceStatusUnimplemented(t *testing.T) {
+	client := newFakeResourceStatusClient(t, &UnimplementedResourceStatusServiceServer{})
+	if _, err := client.GetResourceStatus(context.Background(), wrapperspb.String("r1")); status.Code(err) != codes.Unimplemented {
+		t.Errorf("expected unimplemented error, but got: %v", err)
+	}
+}
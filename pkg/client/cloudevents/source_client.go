@@ -35,11 +35,12 @@ type SourceClientImpl struct {
 	ResourceService        services.ResourceService
 }
 
-func NewSourceClient(sourceOptions *ceoptions.CloudEventsSourceOptions, resourceService services.ResourceService) (SourceClient, error) {
+func NewSourceClient(sourceOptions *ceoptions.CloudEventsSourceOptions, resourceService services.ResourceService,
+	statusHashGetter func(res *api.Resource) (string, error)) (SourceClient, error) {
 	ctx := context.Background()
 	codec, bundleCodec := &Codec{sourceID: sourceOptions.SourceID}, &BundleCodec{sourceID: sourceOptions.SourceID}
 	ceSourceClient, err := cegeneric.NewCloudEventSourceClient[*api.Resource](ctx, sourceOptions,
-		resourceService, ResourceStatusHashGetter, codec, bundleCodec)
+		resourceService, statusHashGetter, codec, bundleCodec)
 	if err != nil {
 		return nil, err
 	}
@@ -63,6 +64,12 @@ func (s *SourceClientImpl) OnCreate(ctx context.Context, id string) error {
 		return err
 	}
 
+	// the spec of a quarantined resource is not published until the resource is released
+	if resource.IsQuarantined() {
+		logger.V(4).Infof("Skip publishing the quarantined resource %s for db row insert", resource.ID)
+		return nil
+	}
+
 	logger.V(4).Infof("Publishing resource %s for db row insert", resource.ID)
 	eventType := cetypes.CloudEventsType{
 		CloudEventsDataType: s.Codec.EventDataType(),
@@ -88,6 +95,12 @@ func (s *SourceClientImpl) OnUpdate(ctx context.Context, id string) error {
 		return err
 	}
 
+	// the spec of a quarantined resource is not published until the resource is released
+	if resource.IsQuarantined() {
+		logger.V(4).Infof("Skip publishing the quarantined resource %s for db row update", resource.ID)
+		return nil
+	}
+
 	logger.V(4).Infof("Publishing resource %s for db row update", resource.ID)
 	eventType := cetypes.CloudEventsType{
 		CloudEventsDataType: s.Codec.EventDataType(),
@@ -161,6 +174,19 @@ func (s *SourceClientImpl) ReconnectedChan() <-chan struct{} {
 // with the agent's status calculation. The resource status is converted to
 // manifestwork status based on resource type before calculating the hash.
 func ResourceStatusHashGetter(res *api.Resource) (string, error) {
+	return resourceStatusHash(res, nil)
+}
+
+// NewResourceStatusHashGetter returns a ResourceStatusHashGetter that drops the given status feedback fields
+// before calculating the hash, see NormalizeWorkStatus.
+func NewResourceStatusHashGetter(ignoredFields []string) func(res *api.Resource) (string, error) {
+	ignoredFieldPaths := ParseStatusFieldPaths(ignoredFields)
+	return func(res *api.Resource) (string, error) {
+		return resourceStatusHash(res, ignoredFieldPaths)
+	}
+}
+
+func resourceStatusHash(res *api.Resource, ignoredFieldPaths [][]string) (string, error) {
 	if len(res.Status) == 0 {
 		return fmt.Sprintf("%x", sha256.Sum256([]byte(""))), nil
 	}
@@ -175,8 +201,13 @@ func ResourceStatusHashGetter(res *api.Resource) (string, error) {
 			return "", fmt.Errorf("failed to decode cloudevent data as manifest status: %v", err)
 		}
 		workStatus.Conditions = eventPayload.Conditions
+		// the agent may not report the manifest status (reconcile status) yet, use an empty
+		// manifest condition list in this case to avoid dereferencing a nil status.
 		workStatus.ResourceStatus = workv1.ManifestResourceStatus{
-			Manifests: []workv1.ManifestCondition{*eventPayload.Status},
+			Manifests: []workv1.ManifestCondition{},
+		}
+		if eventPayload.Status != nil {
+			workStatus.ResourceStatus.Manifests = append(workStatus.ResourceStatus.Manifests, *eventPayload.Status)
 		}
 	} else if res.Type == api.ResourceTypeBundle {
 		eventPayload := &workpayload.ManifestBundleStatus{}
@@ -187,6 +218,9 @@ func ResourceStatusHashGetter(res *api.Resource) (string, error) {
 		workStatus.ResourceStatus.Manifests = eventPayload.ResourceStatus
 	}
 
+	// canonicalize the status, so the semantically-equal statuses have the same hash
+	NormalizeWorkStatus(&workStatus, ignoredFieldPaths)
+
 	workStatusBytes, err := json.Marshal(workStatus)
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal work status, %v", err)
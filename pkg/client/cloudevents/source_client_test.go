@@ -0,0 +1,155 @@
+package cloudevents
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"gorm.io/datatypes"
+
+	"github.com/openshift-online/maestro/pkg/api"
+)
+
+func TestResourceStatusHashGetter(t *testing.T) {
+	cases := []struct {
+		name     string
+		resource *api.Resource
+	}{
+		{
+			name: "empty status",
+			resource: &api.Resource{
+				Type: api.ResourceTypeSingle,
+			},
+		},
+		{
+			name: "single resource without reconcile status",
+			resource: &api.Resource{
+				Type:   api.ResourceTypeSingle,
+				Status: newJSONMap(t, "{\"specversion\":\"1.0\",\"id\":\"1\",\"source\":\"test\",\"type\":\"io.open-cluster-management.works.v1alpha1.manifests.status.update_request\",\"datacontenttype\":\"application/json\",\"data\":{\"conditions\":[]}}"),
+			},
+		},
+		{
+			name: "single resource with reconcile status",
+			resource: &api.Resource{
+				Type:   api.ResourceTypeSingle,
+				Status: newJSONMap(t, "{\"specversion\":\"1.0\",\"id\":\"1\",\"source\":\"test\",\"type\":\"io.open-cluster-management.works.v1alpha1.manifests.status.update_request\",\"datacontenttype\":\"application/json\",\"data\":{\"conditions\":[],\"status\":{\"conditions\":[{\"type\":\"Applied\",\"status\":\"True\",\"lastTransitionTime\":\"2024-01-01T00:00:00Z\",\"reason\":\"\",\"message\":\"\"}]}}}"),
+			},
+		},
+		{
+			name: "bundle resource without reconcile status",
+			resource: &api.Resource{
+				Type:   api.ResourceTypeBundle,
+				Status: newJSONMap(t, "{\"specversion\":\"1.0\",\"id\":\"1\",\"source\":\"test\",\"type\":\"io.open-cluster-management.works.v1alpha1.manifestbundles.status.update_request\",\"datacontenttype\":\"application/json\",\"data\":{\"conditions\":[]}}"),
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			hash, err := ResourceStatusHashGetter(c.resource)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if len(hash) == 0 {
+				t.Errorf("expected non-empty hash")
+			}
+		})
+	}
+}
+
+func TestResourceStatusHashNormalization(t *testing.T) {
+	jsonRaw := `{"replicas":1,"conditions":[{"type":"Available","status":"True"}],"observedGeneration":1}`
+	cases := []struct {
+		name          string
+		ignoredFields []string
+		jsonRaw       string
+		expectedEqual bool
+	}{
+		{
+			name:          "reordered keys",
+			jsonRaw:       `{"observedGeneration":1,"conditions":[{"status":"True","type":"Available"}],"replicas":1}`,
+			expectedEqual: true,
+		},
+		{
+			name: "reformatted value",
+			jsonRaw: `{
+  "replicas": 1,
+  "conditions": [{"type": "Available", "status": "True"}],
+  "observedGeneration": 1
+}`,
+			expectedEqual: true,
+		},
+		{
+			name:          "ignored defaulted fields",
+			ignoredFields: []string{"metadata.resourceVersion", " observedGeneration "},
+			jsonRaw:       `{"replicas":1,"conditions":[{"type":"Available","status":"True"}],"observedGeneration":2,"metadata":{"resourceVersion":"100"}}`,
+			expectedEqual: true,
+		},
+		{
+			name:          "defaulted fields are not ignored",
+			jsonRaw:       `{"replicas":1,"conditions":[{"type":"Available","status":"True"}],"observedGeneration":1,"metadata":{"resourceVersion":"100"}}`,
+			expectedEqual: false,
+		},
+		{
+			name:          "meaningful field changed",
+			ignoredFields: []string{"metadata.resourceVersion"},
+			jsonRaw:       `{"replicas":2,"conditions":[{"type":"Available","status":"True"}],"observedGeneration":1}`,
+			expectedEqual: false,
+		},
+		{
+			name:          "reordered list items",
+			jsonRaw:       `{"replicas":1,"conditions":[{"type":"Progressing","status":"True"},{"type":"Available","status":"True"}],"observedGeneration":1}`,
+			expectedEqual: false,
+		},
+	}
+
+	for _, c := range cases {
+		for _, resourceType := range []api.ResourceType{api.ResourceTypeSingle, api.ResourceTypeBundle} {
+			t.Run(fmt.Sprintf("%s %s", resourceType, c.name), func(t *testing.T) {
+				hashGetter := NewResourceStatusHashGetter(c.ignoredFields)
+				hash, err := hashGetter(newStatusFeedbackResource(t, resourceType, jsonRaw))
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				otherHash, err := hashGetter(newStatusFeedbackResource(t, resourceType, c.jsonRaw))
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if (hash == otherHash) != c.expectedEqual {
+					t.Errorf("expected the hashes are equal %v, but got %s and %s", c.expectedEqual, hash, otherHash)
+				}
+			})
+		}
+	}
+}
+
+func newStatusFeedbackResource(t *testing.T, resourceType api.ResourceType, jsonRaw string) *api.Resource {
+	rawValue, err := json.Marshal(jsonRaw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	manifestStatus := fmt.Sprintf(`{"resourceMeta":{"group":"apps","version":"v1","kind":"Deployment","resource":"deployments","name":"nginx","namespace":"default"},`+
+		`"statusFeedback":{"values":[{"name":"status","fieldValue":{"type":"JsonRaw","jsonRaw":%s}}]},"conditions":[]}`, rawValue)
+	eventType := "io.open-cluster-management.works.v1alpha1.manifests.status.update_request"
+	data := fmt.Sprintf(`{"conditions":[],"status":%s}`, manifestStatus)
+	if resourceType == api.ResourceTypeBundle {
+		eventType = "io.open-cluster-management.works.v1alpha1.manifestbundles.status.update_request"
+		data = fmt.Sprintf(`{"conditions":[],"resourceStatus":[%s]}`, manifestStatus)
+	}
+
+	return &api.Resource{
+		Type: resourceType,
+		Status: newJSONMap(t, fmt.Sprintf(`{"specversion":"1.0","id":"1","source":"test","type":%q,"datacontenttype":"application/json","data":%s}`,
+			eventType, data)),
+	}
+}
+
+func newJSONMap(t *testing.T, data string) datatypes.JSONMap {
+	jsonmap := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(data), &jsonmap); err != nil {
+		t.Fatal(err)
+	}
+
+	return jsonmap
+}
@@ -0,0 +1,96 @@
+package cloudevents
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	workv1 "open-cluster-management.io/api/work/v1"
+)
+
+// ParseStatusFieldPaths parses the dot-separated status field paths, e.g. "metadata.managedFields".
+func ParseStatusFieldPaths(fields []string) [][]string {
+	paths := [][]string{}
+	for _, field := range fields {
+		field = strings.TrimSpace(field)
+		if len(field) == 0 {
+			continue
+		}
+		paths = append(paths, strings.Split(field, "."))
+	}
+	return paths
+}
+
+// NormalizeWorkStatus canonicalizes the raw JSON status feedback values of the manifestwork status, the keys of a
+// raw JSON value are sorted and the value is compacted, then the ignored fields are dropped from the value. The
+// ignored field paths are relative to the raw JSON value, e.g. "metadata.resourceVersion".
+//
+// The other status fields are structured and already marshaled in a stable order, so they are kept as is. A raw JSON
+// value that cannot be decoded is kept as is too.
+//
+// Note: the agent compares the status hash with the hash of its own status, only ignore the fields that are
+// server-defaulted noise, otherwise the agent resends the status on every resync.
+func NormalizeWorkStatus(status *workv1.ManifestWorkStatus, ignoredFieldPaths [][]string) {
+	for i := range status.ResourceStatus.Manifests {
+		values := status.ResourceStatus.Manifests[i].StatusFeedbacks.Values
+		for j := range values {
+			raw := values[j].Value.JsonRaw
+			if raw == nil {
+				continue
+			}
+
+			normalized, ok := normalizeJSONRaw(*raw, ignoredFieldPaths)
+			if !ok {
+				continue
+			}
+			values[j].Value.JsonRaw = &normalized
+		}
+	}
+}
+
+func normalizeJSONRaw(raw string, ignoredFieldPaths [][]string) (string, bool) {
+	// use number to keep the original number format, e.g. avoid converting big integers to float
+	decoder := json.NewDecoder(bytes.NewBufferString(raw))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return "", false
+	}
+
+	if obj, ok := value.(map[string]interface{}); ok {
+		for _, path := range ignoredFieldPaths {
+			removeField(obj, path)
+		}
+	}
+
+	// the map keys are sorted when marshaling
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+// removeField removes the field of the path from the object, the parent objects that become empty after removing the
+// field are removed too, so a status with the ignored field has the same hash as the status without it.
+func removeField(obj map[string]interface{}, path []string) {
+	if len(path) == 0 {
+		return
+	}
+
+	if len(path) == 1 {
+		delete(obj, path[0])
+		return
+	}
+
+	child, ok := obj[path[0]].(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	removeField(child, path[1:])
+	if len(child) == 0 {
+		delete(obj, path[0])
+	}
+}
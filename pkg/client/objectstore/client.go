@@ -0,0 +1,40 @@
+package objectstore
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNotFound is returned when the requested object does not exist in the object store.
+var ErrNotFound = errors.New("object not found")
+
+// Client is the client of an object store, it is used to keep the resource payloads that are offloaded from the
+// database.
+type Client interface {
+	// Put stores the data with the given key, the existing object of the key is overwritten.
+	Put(ctx context.Context, key string, data []byte) error
+	// Get returns the data of the given key, ErrNotFound is returned if the object does not exist.
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Delete deletes the object of the given key, it succeeds if the object does not exist.
+	Delete(ctx context.Context, key string) error
+	// List returns the objects with the given prefix.
+	List(ctx context.Context, prefix string) ([]Object, error)
+}
+
+// Object is an object listed from the object store.
+type Object struct {
+	Key          string
+	LastModified time.Time
+}
+
+type Config struct {
+	// Endpoint is the URL of the S3-compatible object store, e.g. https://s3.us-east-1.amazonaws.com, the objects
+	// are addressed with the path-style URLs (<endpoint>/<bucket>/<key>).
+	Endpoint        string
+	Bucket          string
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+	Timeout         time.Duration
+}
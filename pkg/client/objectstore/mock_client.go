@@ -0,0 +1,71 @@
+package objectstore
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MockClient keeps the objects in memory, it is used in testing.
+type MockClient struct {
+	mu       sync.RWMutex
+	objects  map[string][]byte
+	modified map[string]time.Time
+}
+
+var _ Client = &MockClient{}
+
+func NewMockClient() *MockClient {
+	return &MockClient{objects: map[string][]byte{}, modified: map[string]time.Time{}}
+}
+
+func (c *MockClient) Put(ctx context.Context, key string, data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.objects[key] = append([]byte{}, data...)
+	c.modified[key] = time.Now()
+	return nil
+}
+
+func (c *MockClient) Get(ctx context.Context, key string) ([]byte, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	data, ok := c.objects[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return append([]byte{}, data...), nil
+}
+
+func (c *MockClient) Delete(ctx context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.objects, key)
+	delete(c.modified, key)
+	return nil
+}
+
+func (c *MockClient) List(ctx context.Context, prefix string) ([]Object, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	objects := []Object{}
+	for key := range c.objects {
+		if strings.HasPrefix(key, prefix) {
+			objects = append(objects, Object{Key: key, LastModified: c.modified[key]})
+		}
+	}
+	return objects, nil
+}
+
+// SetLastModified sets the last modified time of the object, it is used to test the expiry of the objects.
+func (c *MockClient) SetLastModified(key string, modified time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.modified[key] = modified
+}
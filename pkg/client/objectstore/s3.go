@@ -0,0 +1,133 @@
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// s3Client is the client of the S3-compatible object stores, it is built on the minio-go client which signs the
+// requests with the AWS Signature Version 4.
+type s3Client struct {
+	bucket  string
+	timeout time.Duration
+	client  *minio.Client
+}
+
+var _ Client = &s3Client{}
+
+// NewS3Client returns a client of the S3-compatible object store with the given config.
+func NewS3Client(config Config) (Client, error) {
+	endpoint, err := url.Parse(strings.TrimSuffix(config.Endpoint, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid object store endpoint %q: %v", config.Endpoint, err)
+	}
+	if endpoint.Scheme != "http" && endpoint.Scheme != "https" {
+		return nil, fmt.Errorf("invalid object store endpoint %q: the scheme must be http or https", config.Endpoint)
+	}
+	if len(endpoint.Path) != 0 {
+		return nil, fmt.Errorf("invalid object store endpoint %q: the endpoint must not have a path", config.Endpoint)
+	}
+	if len(config.Bucket) == 0 {
+		return nil, fmt.Errorf("the object store bucket is required")
+	}
+
+	client, err := minio.New(endpoint.Host, &minio.Options{
+		Creds:        credentials.NewStaticV4(config.AccessKeyID, config.SecretAccessKey, ""),
+		Secure:       endpoint.Scheme == "https",
+		Region:       config.Region,
+		BucketLookup: minio.BucketLookupPath,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the object store client: %v", err)
+	}
+
+	return &s3Client{
+		bucket:  config.Bucket,
+		timeout: config.Timeout,
+		client:  client,
+	}, nil
+}
+
+func (c *s3Client) Put(ctx context.Context, key string, data []byte) error {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	if _, err := c.client.PutObject(ctx, c.bucket, key, bytes.NewReader(data), int64(len(data)),
+		minio.PutObjectOptions{ContentType: "application/json"}); err != nil {
+		return c.toError(fmt.Sprintf("failed to put the object %s", key), err)
+	}
+	return nil
+}
+
+func (c *s3Client) Get(ctx context.Context, key string) ([]byte, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	object, err := c.client.GetObject(ctx, c.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, c.toError(fmt.Sprintf("failed to get the object %s", key), err)
+	}
+	defer object.Close()
+
+	// the object is fetched on the first read
+	data, err := io.ReadAll(object)
+	if err != nil {
+		return nil, c.toError(fmt.Sprintf("failed to read the object %s", key), err)
+	}
+	return data, nil
+}
+
+func (c *s3Client) Delete(ctx context.Context, key string) error {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	if err := c.client.RemoveObject(ctx, c.bucket, key, minio.RemoveObjectOptions{}); err != nil {
+		if err := c.toError(fmt.Sprintf("failed to delete the object %s", key), err); err != ErrNotFound {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *s3Client) List(ctx context.Context, prefix string) ([]Object, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	objects := []Object{}
+	for object := range c.client.ListObjects(ctx, c.bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if object.Err != nil {
+			return nil, c.toError(fmt.Sprintf("failed to list the objects with prefix %s", prefix), object.Err)
+		}
+		objects = append(objects, Object{Key: object.Key, LastModified: object.LastModified})
+	}
+	return objects, nil
+}
+
+func (c *s3Client) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, c.timeout)
+}
+
+// toError returns ErrNotFound if the object does not exist, otherwise the error is wrapped with the message, the
+// errors of the unreachable or failing object store are reported as unavailable.
+func (c *s3Client) toError(message string, err error) error {
+	resp := minio.ToErrorResponse(err)
+	switch {
+	case resp.Code == "NoSuchKey":
+		return ErrNotFound
+	case resp.StatusCode == 0 || resp.StatusCode >= 500:
+		return fmt.Errorf("%s, the object store %s is unavailable: %v", message, c.client.EndpointURL().Host, err)
+	default:
+		return fmt.Errorf("%s: %v", message, err)
+	}
+}
@@ -0,0 +1,171 @@
+package objectstore
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeS3Server is a minimal S3-compatible server that keeps the objects of the bucket "maestro" in memory, the
+// requests must be signed with the credential of the "test-key".
+type fakeS3Server struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (s *fakeS3Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=test-key/") {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	if r.URL.Path == "/maestro/" || r.URL.Path == "/maestro" {
+		if r.Method != http.MethodGet || r.URL.Query().Get("list-type") != "2" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?><ListBucketResult><Name>maestro</Name>`)
+		for key := range s.objects {
+			if strings.HasPrefix(key, r.URL.Query().Get("prefix")) {
+				fmt.Fprintf(w, "<Contents><Key>%s</Key><LastModified>2024-01-01T00:00:00.000Z</LastModified></Contents>", key)
+			}
+		}
+		fmt.Fprint(w, "<IsTruncated>false</IsTruncated></ListBucketResult>")
+		return
+	}
+
+	key := strings.TrimPrefix(r.URL.Path, "/maestro/")
+	switch r.Method {
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		if r.Header.Get("x-amz-content-sha256") == "STREAMING-AWS4-HMAC-SHA256-PAYLOAD" {
+			body = decodeChunkedPayload(body)
+		}
+		s.objects[key] = body
+		w.Header().Set("ETag", `"etag"`)
+	case http.MethodGet, http.MethodHead:
+		data, ok := s.objects[key]
+		if !ok {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusNotFound)
+			if r.Method == http.MethodGet {
+				fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?><Error><Code>NoSuchKey</Code><Key>%s</Key></Error>`, key)
+			}
+			return
+		}
+		w.Header().Set("Content-Length", fmt.Sprint(len(data)))
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		w.Header().Set("ETag", `"etag"`)
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(data)
+		}
+	case http.MethodDelete:
+		delete(s.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// decodeChunkedPayload returns the data of the chunks of a payload signed in the streaming mode, each chunk is
+// "<size in hex>;chunk-signature=<signature>\r\n<data>\r\n".
+func decodeChunkedPayload(body []byte) []byte {
+	data := []byte{}
+	for len(body) != 0 {
+		header, rest, _ := strings.Cut(string(body), "\r\n")
+		size, err := strconv.ParseInt(strings.Split(header, ";")[0], 16, 64)
+		if err != nil || size == 0 {
+			break
+		}
+		data = append(data, rest[:size]...)
+		body = []byte(rest[size+2:])
+	}
+	return data
+}
+
+func newTestS3Client(t *testing.T, endpoint string) Client {
+	client, err := NewS3Client(Config{
+		Endpoint:        endpoint,
+		Bucket:          "maestro",
+		Region:          "us-east-1",
+		AccessKeyID:     "test-key",
+		SecretAccessKey: "test-secret",
+		Timeout:         time.Second,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return client
+}
+
+func TestS3Client(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewServer(&fakeS3Server{objects: map[string][]byte{}})
+	defer server.Close()
+
+	client := newTestS3Client(t, server.URL)
+
+	if err := client.Put(ctx, "resources/test/1", []byte(`{"data":"test"}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err := client.Get(ctx, "resources/test/1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(data) != `{"data":"test"}` {
+		t.Errorf("unexpected object: %s", data)
+	}
+
+	objects, err := client.List(ctx, "resources/test/")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(objects) != 1 || objects[0].Key != "resources/test/1" ||
+		!objects[0].LastModified.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected objects: %v", objects)
+	}
+
+	if err := client.Delete(ctx, "resources/test/1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Get(ctx, "resources/test/1"); err != ErrNotFound {
+		t.Errorf("expected not found error, but got: %v", err)
+	}
+	// deleting a nonexistent object succeeds
+	if err := client.Delete(ctx, "resources/test/1"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestS3ClientUnavailable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	endpoint := server.URL
+	server.Close()
+
+	client := newTestS3Client(t, endpoint)
+	_, err := client.Get(context.Background(), "resources/test/1")
+	if err == nil || !strings.Contains(err.Error(), "is unavailable") {
+		t.Errorf("expected unavailable error, but got: %v", err)
+	}
+}
+
+func TestNewS3ClientInvalidConfig(t *testing.T) {
+	for _, config := range []Config{
+		{Endpoint: "s3.us-east-1.amazonaws.com", Bucket: "maestro"},
+		{Endpoint: "https://s3.us-east-1.amazonaws.com/maestro", Bucket: "maestro"},
+		{Endpoint: "https://s3.us-east-1.amazonaws.com"},
+	} {
+		if _, err := NewS3Client(config); err == nil {
+			t.Errorf("expected an error for the config %v", config)
+		}
+	}
+}
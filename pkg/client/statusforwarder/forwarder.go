@@ -0,0 +1,183 @@
+// Package statusforwarder forwards the resource status changes of maestro to the external sinks, e.g. a Kafka topic,
+// so the downstream consumers can receive the status changes without polling the RESTful API.
+package statusforwarder
+
+import (
+	"context"
+	"time"
+
+	ce "github.com/cloudevents/sdk-go/v2"
+	cloudeventstypes "github.com/cloudevents/sdk-go/v2/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/klog/v2"
+
+	cetypes "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/types"
+)
+
+func init() {
+	RegisterStatusForwarderMetrics()
+}
+
+// Sink is an external destination of the resource status changes.
+type Sink interface {
+	// Name returns the name of the sink, e.g. kafka.
+	Name() string
+	// Send sends the status event with the given partition key, the key is the consumer name of the resource, so the
+	// sink can keep the order of the status changes of a consumer. It returns an error if the event is not delivered,
+	// then the event is sent again.
+	Send(ctx context.Context, key string, evt *ce.Event) error
+	// Close releases the resources of the sink once the forwarder is stopped.
+	Close()
+}
+
+// Forwarder forwards the resource status events to its sinks with at-least-once delivery. Each sink has its own
+// bounded buffer, so a slow sink doesn't hold back the others. The events of a sink are sent in order, and a failed
+// event is retried until it is delivered before the next event is sent. Once the buffer of a sink is full, the new
+// events of the sink are dropped rather than blocking the status processing of maestro.
+type Forwarder struct {
+	workers       []*sinkWorker
+	retryInterval time.Duration
+}
+
+type sinkWorker struct {
+	sink   Sink
+	events chan *ce.Event
+}
+
+// NewForwarder returns a forwarder of the given sinks, each sink buffers up to the buffer size events.
+func NewForwarder(bufferSize int, retryInterval time.Duration, sinks ...Sink) *Forwarder {
+	workers := make([]*sinkWorker, 0, len(sinks))
+	for _, sink := range sinks {
+		workers = append(workers, &sinkWorker{sink: sink, events: make(chan *ce.Event, bufferSize)})
+	}
+	return &Forwarder{workers: workers, retryInterval: retryInterval}
+}
+
+// Forward enqueues the status event to each sink, it never blocks.
+func (f *Forwarder) Forward(evt *ce.Event) {
+	for _, worker := range f.workers {
+		select {
+		case worker.events <- evt:
+		default:
+			klog.Warningf("The buffer of the status sink %s is full, dropping the status event %s", worker.sink.Name(), evt.ID())
+			statusForwarderDroppedCountMetric.WithLabelValues(worker.sink.Name()).Inc()
+		}
+	}
+}
+
+// Start sends the buffered status events to the sinks until the context is done, then the sinks are closed.
+func (f *Forwarder) Start(ctx context.Context) {
+	klog.Infof("Starting the status forwarder with %d sinks", len(f.workers))
+	done := make(chan struct{}, len(f.workers))
+	for _, worker := range f.workers {
+		go func(worker *sinkWorker) {
+			defer func() { done <- struct{}{} }()
+			f.run(ctx, worker)
+		}(worker)
+	}
+	for range f.workers {
+		<-done
+	}
+}
+
+func (f *Forwarder) run(ctx context.Context, worker *sinkWorker) {
+	defer worker.sink.Close()
+
+	name := worker.sink.Name()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case evt := <-worker.events:
+			key := partitionKey(evt)
+			for {
+				err := worker.sink.Send(ctx, key, evt)
+				if err == nil {
+					statusForwarderSentCountMetric.WithLabelValues(name).Inc()
+					break
+				}
+
+				klog.Errorf("Failed to send the status event %s to the status sink %s, retrying in %s: %v", evt.ID(), name, f.retryInterval, err)
+				statusForwarderFailureCountMetric.WithLabelValues(name).Inc()
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(f.retryInterval):
+				}
+			}
+		}
+	}
+}
+
+// partitionKey returns the cluster name (the consumer name) of the status event.
+func partitionKey(evt *ce.Event) string {
+	clusterName, err := cloudeventstypes.ToString(evt.Extensions()[cetypes.ExtensionClusterName])
+	if err != nil {
+		return ""
+	}
+	return clusterName
+}
+
+// Subsystem used to define the metrics:
+const metricsSubsystem = "status_forwarder"
+
+// Names of the labels added to metrics:
+const metricsSinkLabel = "sink"
+
+// Names of the metrics:
+const (
+	sentCountMetric    = "sent_total"
+	failureCountMetric = "failures_total"
+	droppedCountMetric = "dropped_total"
+)
+
+// Register the metrics:
+func RegisterStatusForwarderMetrics() {
+	prometheus.MustRegister(statusForwarderSentCountMetric)
+	prometheus.MustRegister(statusForwarderFailureCountMetric)
+	prometheus.MustRegister(statusForwarderDroppedCountMetric)
+}
+
+// Unregister the metrics:
+func UnregisterStatusForwarderMetrics() {
+	prometheus.Unregister(statusForwarderSentCountMetric)
+	prometheus.Unregister(statusForwarderFailureCountMetric)
+	prometheus.Unregister(statusForwarderDroppedCountMetric)
+}
+
+// Reset the metrics:
+func ResetStatusForwarderMetrics() {
+	statusForwarderSentCountMetric.Reset()
+	statusForwarderFailureCountMetric.Reset()
+	statusForwarderDroppedCountMetric.Reset()
+}
+
+// Description of the status forwarder sent count metric:
+var statusForwarderSentCountMetric = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Subsystem: metricsSubsystem,
+		Name:      sentCountMetric,
+		Help:      "Number of resource status events that are delivered to the status sink.",
+	},
+	[]string{metricsSinkLabel},
+)
+
+// Description of the status forwarder failure count metric:
+var statusForwarderFailureCountMetric = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Subsystem: metricsSubsystem,
+		Name:      failureCountMetric,
+		Help:      "Number of failed attempts to send a resource status event to the status sink, the event is retried.",
+	},
+	[]string{metricsSinkLabel},
+)
+
+// Description of the status forwarder dropped count metric:
+var statusForwarderDroppedCountMetric = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Subsystem: metricsSubsystem,
+		Name:      droppedCountMetric,
+		Help:      "Number of resource status events that are dropped since the buffer of the status sink is full.",
+	},
+	[]string{metricsSinkLabel},
+)
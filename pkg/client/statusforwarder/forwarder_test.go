@@ -0,0 +1,119 @@
+package statusforwarder
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	ce "github.com/cloudevents/sdk-go/v2"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	cetypes "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/types"
+)
+
+// fakeSink records the delivered events, its first sends fail until the failures are used up.
+type fakeSink struct {
+	mu       sync.Mutex
+	failures int
+	keys     []string
+	events   []string
+	closed   bool
+}
+
+func (s *fakeSink) Name() string {
+	return "fake"
+}
+
+func (s *fakeSink) Send(_ context.Context, key string, evt *ce.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.failures > 0 {
+		s.failures--
+		return fmt.Errorf("send failure")
+	}
+	s.keys = append(s.keys, key)
+	s.events = append(s.events, evt.ID())
+	return nil
+}
+
+func (s *fakeSink) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+}
+
+func (s *fakeSink) delivered() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string{}, s.events...)
+}
+
+func newStatusEvent(id, clusterName string) *ce.Event {
+	evt := ce.NewEvent()
+	evt.SetID(id)
+	evt.SetExtension(cetypes.ExtensionClusterName, clusterName)
+	return &evt
+}
+
+func TestForwarder(t *testing.T) {
+	ResetStatusForwarderMetrics()
+	defer ResetStatusForwarderMetrics()
+
+	sink := &fakeSink{failures: 2}
+	forwarder := NewForwarder(10, 10*time.Millisecond, sink)
+	for i := 1; i <= 3; i++ {
+		forwarder.Forward(newStatusEvent(fmt.Sprintf("evt%d", i), "cluster1"))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		forwarder.Start(ctx)
+		close(stopped)
+	}()
+
+	// the failed event is retried before the next events, so the order is kept
+	deadline := time.Now().Add(5 * time.Second)
+	for len(sink.delivered()) < 3 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if delivered := sink.delivered(); fmt.Sprint(delivered) != "[evt1 evt2 evt3]" {
+		t.Errorf("unexpected delivered events %v", delivered)
+	}
+	if fmt.Sprint(sink.keys) != "[cluster1 cluster1 cluster1]" {
+		t.Errorf("unexpected partition keys %v", sink.keys)
+	}
+	if failures := testutil.ToFloat64(statusForwarderFailureCountMetric.WithLabelValues("fake")); failures != 2 {
+		t.Errorf("expected 2 failures, but got %v", failures)
+	}
+	if sent := testutil.ToFloat64(statusForwarderSentCountMetric.WithLabelValues("fake")); sent != 3 {
+		t.Errorf("expected 3 sent events, but got %v", sent)
+	}
+
+	cancel()
+	<-stopped
+	if !sink.closed {
+		t.Errorf("expected the sink is closed once the forwarder is stopped")
+	}
+}
+
+func TestForwarderBufferFull(t *testing.T) {
+	ResetStatusForwarderMetrics()
+	defer ResetStatusForwarderMetrics()
+
+	// the forwarder is not started, so the events are kept in the buffer
+	forwarder := NewForwarder(2, time.Second, &fakeSink{})
+	for i := 1; i <= 3; i++ {
+		forwarder.Forward(newStatusEvent(fmt.Sprintf("evt%d", i), "cluster1"))
+	}
+
+	if dropped := testutil.ToFloat64(statusForwarderDroppedCountMetric.WithLabelValues("fake")); dropped != 1 {
+		t.Errorf("expected 1 dropped event, but got %v", dropped)
+	}
+	if buffered := len(forwarder.workers[0].events); buffered != 2 {
+		t.Errorf("expected 2 buffered events, but got %d", buffered)
+	}
+}
@@ -0,0 +1,87 @@
+//go:build kafka
+
+package statusforwarder
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	ce "github.com/cloudevents/sdk-go/v2"
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"k8s.io/klog/v2"
+
+	kafkaoptions "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/kafka"
+)
+
+// kafkaSink publishes the status events to a Kafka topic in the structured content mode, the events are keyed by
+// the consumer name, so the events of a consumer are kept in order in a partition.
+type kafkaSink struct {
+	producer *kafka.Producer
+	topic    string
+}
+
+var _ Sink = &kafkaSink{}
+
+// NewKafkaSink returns a sink of the given Kafka topic, the producer is configured with the config file of the
+// Kafka message broker format.
+func NewKafkaSink(configFile, topic string) (Sink, error) {
+	opts, err := kafkaoptions.BuildKafkaOptionsFromFlags(configFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the Kafka config file %s: %v", configFile, err)
+	}
+	producer, err := kafka.NewProducer(&opts.ConfigMap)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the Kafka producer: %v", err)
+	}
+
+	// the delivery reports are sent to the channel of each message, only the client errors are sent to the events
+	// channel, it must be drained, otherwise the producer is blocked once the channel is full.
+	go func() {
+		for e := range producer.Events() {
+			if kafkaErr, ok := e.(kafka.Error); ok {
+				klog.V(4).Infof("The Kafka producer of the status sink received the error %v", kafkaErr)
+			}
+		}
+	}()
+
+	return &kafkaSink{producer: producer, topic: topic}, nil
+}
+
+func (s *kafkaSink) Name() string {
+	return "kafka"
+}
+
+func (s *kafkaSink) Send(ctx context.Context, key string, evt *ce.Event) error {
+	value, err := json.Marshal(evt)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the status event: %v", err)
+	}
+
+	deliveryChan := make(chan kafka.Event, 1)
+	if err := s.producer.Produce(&kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &s.topic, Partition: kafka.PartitionAny},
+		Key:            []byte(key),
+		Value:          value,
+		Headers:        []kafka.Header{{Key: "content-type", Value: []byte(ce.ApplicationCloudEventsJSON)}},
+	}, deliveryChan); err != nil {
+		return err
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case e := <-deliveryChan:
+		msg, ok := e.(*kafka.Message)
+		if !ok {
+			return fmt.Errorf("unexpected delivery report %v", e)
+		}
+		return msg.TopicPartition.Error
+	}
+}
+
+func (s *kafkaSink) Close() {
+	// wait up to 5 seconds for the in-flight messages
+	s.producer.Flush(5000)
+	s.producer.Close()
+}
@@ -0,0 +1,11 @@
+//go:build !kafka
+
+package statusforwarder
+
+import "fmt"
+
+// NewKafkaSink is not supported unless maestro is built with the kafka tag, as the confluent-kafka-go doesn't support
+// the cross-compilation.
+func NewKafkaSink(configFile, topic string) (Sink, error) {
+	return nil, fmt.Errorf("the Kafka status sink is not enabled, try adding -tags=kafka to build")
+}
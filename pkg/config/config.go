@@ -13,28 +13,36 @@ import (
 )
 
 type ApplicationConfig struct {
-	HTTPServer    *HTTPServerConfig    `json:"http_server"`
-	GRPCServer    *GRPCServerConfig    `json:"grpc_server"`
-	Metrics       *MetricsConfig       `json:"metrics"`
-	HealthCheck   *HealthCheckConfig   `json:"health_check"`
-	EventServer   *EventServerConfig   `json:"event_server"`
-	Database      *DatabaseConfig      `json:"database"`
-	MessageBroker *MessageBrokerConfig `json:"message_broker"`
-	OCM           *OCMConfig           `json:"ocm"`
-	Sentry        *SentryConfig        `json:"sentry"`
+	HTTPServer      *HTTPServerConfig      `json:"http_server"`
+	GRPCServer      *GRPCServerConfig      `json:"grpc_server"`
+	Metrics         *MetricsConfig         `json:"metrics"`
+	HealthCheck     *HealthCheckConfig     `json:"health_check"`
+	EventServer     *EventServerConfig     `json:"event_server"`
+	Database        *DatabaseConfig        `json:"database"`
+	Resource        *ResourceConfig        `json:"resource"`
+	Consumer        *ConsumerConfig        `json:"consumer"`
+	MessageBroker   *MessageBrokerConfig   `json:"message_broker"`
+	OCM             *OCMConfig             `json:"ocm"`
+	Sentry          *SentryConfig          `json:"sentry"`
+	ObjectStore     *ObjectStoreConfig     `json:"object_store"`
+	StatusForwarder *StatusForwarderConfig `json:"status_forwarder"`
 }
 
 func NewApplicationConfig() *ApplicationConfig {
 	return &ApplicationConfig{
-		HTTPServer:    NewHTTPServerConfig(),
-		GRPCServer:    NewGRPCServerConfig(),
-		Metrics:       NewMetricsConfig(),
-		HealthCheck:   NewHealthCheckConfig(),
-		EventServer:   NewEventServerConfig(),
-		Database:      NewDatabaseConfig(),
-		MessageBroker: NewMessageBrokerConfig(),
-		OCM:           NewOCMConfig(),
-		Sentry:        NewSentryConfig(),
+		HTTPServer:      NewHTTPServerConfig(),
+		GRPCServer:      NewGRPCServerConfig(),
+		Metrics:         NewMetricsConfig(),
+		HealthCheck:     NewHealthCheckConfig(),
+		EventServer:     NewEventServerConfig(),
+		Database:        NewDatabaseConfig(),
+		Resource:        NewResourceConfig(),
+		Consumer:        NewConsumerConfig(),
+		MessageBroker:   NewMessageBrokerConfig(),
+		OCM:             NewOCMConfig(),
+		Sentry:          NewSentryConfig(),
+		ObjectStore:     NewObjectStoreConfig(),
+		StatusForwarder: NewStatusForwarderConfig(),
 	}
 }
 
@@ -46,9 +54,13 @@ func (c *ApplicationConfig) AddFlags(flagset *pflag.FlagSet) {
 	c.HealthCheck.AddFlags(flagset)
 	c.EventServer.AddFlags(flagset)
 	c.Database.AddFlags(flagset)
+	c.Resource.AddFlags(flagset)
+	c.Consumer.AddFlags(flagset)
 	c.MessageBroker.AddFlags(flagset)
 	c.OCM.AddFlags(flagset)
 	c.Sentry.AddFlags(flagset)
+	c.ObjectStore.AddFlags(flagset)
+	c.StatusForwarder.AddFlags(flagset)
 }
 
 func (c *ApplicationConfig) ReadFiles() []string {
@@ -57,12 +69,17 @@ func (c *ApplicationConfig) ReadFiles() []string {
 		name string
 	}{
 		{c.HTTPServer.ReadFiles, "Server"},
+		{c.GRPCServer.ReadFiles, "GRPCServer"},
 		{c.Database.ReadFiles, "Database"},
+		{c.Resource.ReadFiles, "Resource"},
+		{c.Consumer.ReadFiles, "Consumer"},
 		{c.OCM.ReadFiles, "OCM"},
 		{c.Metrics.ReadFiles, "Metrics"},
 		{c.HealthCheck.ReadFiles, "HealthCheck"},
 		{c.EventServer.ReadFiles, "EventServer"},
 		{c.Sentry.ReadFiles, "Sentry"},
+		{c.ObjectStore.ReadFiles, "ObjectStore"},
+		{c.StatusForwarder.ReadFiles, "StatusForwarder"},
 	}
 	messages := []string{}
 	for _, rf := range readFiles {
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// ConsumerConfig is the config of the consumer management.
+type ConsumerConfig struct {
+	// CacheTTL is how long a consumer is cached in memory, 0 disables the consumer cache.
+	CacheTTL time.Duration `json:"cache_ttl"`
+}
+
+func NewConsumerConfig() *ConsumerConfig {
+	return &ConsumerConfig{
+		CacheTTL: 30 * time.Second,
+	}
+}
+
+func (c *ConsumerConfig) AddFlags(fs *pflag.FlagSet) {
+	fs.DurationVar(&c.CacheTTL, "consumer-cache-ttl", c.CacheTTL, "How long a consumer is cached in memory, a consumer changed by another instance is visible after at most this duration. Set 0 to disable the cache")
+}
+
+func (c *ConsumerConfig) ReadFiles() error {
+	if c.CacheTTL < 0 {
+		return fmt.Errorf("the consumer cache TTL must not be negative, got %s", c.CacheTTL)
+	}
+	return nil
+}
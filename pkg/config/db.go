@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/spf13/pflag"
@@ -14,6 +15,14 @@ type DatabaseConfig struct {
 	SSLMode            string `json:"sslmode"`
 	Debug              bool   `json:"debug"`
 	MaxOpenConnections int    `json:"max_connections"`
+	// GateReadsUntilMigrated rejects the reads as well as the mutations until the migrations are complete.
+	GateReadsUntilMigrated bool `json:"gate_reads_until_migrated"`
+	// CircuitBreakerFailureThreshold is the number of the consecutive database failures of the resource calls after
+	// which the circuit breaker is opened for the CircuitBreakerCooldown, then the CircuitBreakerProbes calls are let
+	// through to probe the database, see db.CircuitBreaker. 0 disables the circuit breaker.
+	CircuitBreakerFailureThreshold int           `json:"circuit_breaker_failure_threshold"`
+	CircuitBreakerCooldown         time.Duration `json:"circuit_breaker_cooldown"`
+	CircuitBreakerProbes           int           `json:"circuit_breaker_probes"`
 
 	Host     string `json:"host"`
 	Port     int    `json:"port"`
@@ -28,6 +37,14 @@ type DatabaseConfig struct {
 	PasswordFile string `json:"password_file"`
 	RootCertFile string `json:"certificate_file"`
 
+	// ReplicaHost and ReplicaPort are the address of the read replica of the database, the read-only queries are
+	// routed to the replica if the replica host is set, see ReplicaConfig. The replica shares the name, the
+	// credentials and the ssl settings of the primary, its port is the port of the primary if it is not set.
+	ReplicaHost     string `json:"replica_host"`
+	ReplicaPort     int    `json:"replica_port"`
+	ReplicaHostFile string `json:"replica_host_file"`
+	ReplicaPortFile string `json:"replica_port_file"`
+
 	AuthMethod        string `json:"auth_method"`
 	TokenRequestScope string `json:"token_request_scope"`
 	Token             *azcore.AccessToken
@@ -43,6 +60,9 @@ func NewDatabaseConfig() *DatabaseConfig {
 		Debug:              false,
 		MaxOpenConnections: 50,
 
+		CircuitBreakerCooldown: 30 * time.Second,
+		CircuitBreakerProbes:   3,
+
 		HostFile:     "secrets/db.host",
 		PortFile:     "secrets/db.port",
 		NameFile:     "secrets/db.name",
@@ -62,9 +82,15 @@ func (c *DatabaseConfig) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&c.PasswordFile, "db-password-file", c.PasswordFile, "Database password file")
 	fs.StringVar(&c.NameFile, "db-name-file", c.NameFile, "Database name file")
 	fs.StringVar(&c.RootCertFile, "db-rootcert", c.RootCertFile, "Database root certificate file")
+	fs.StringVar(&c.ReplicaHostFile, "db-replica-host-file", c.ReplicaHostFile, "Database read replica host string file, the list and search queries are routed to the replica if it is set. The replica can lag behind the primary, so a list may not include the latest changes yet")
+	fs.StringVar(&c.ReplicaPortFile, "db-replica-port-file", c.ReplicaPortFile, "Database read replica port file, the port of the primary is used if it is not set")
 	fs.StringVar(&c.SSLMode, "db-sslmode", c.SSLMode, "Database ssl mode (disable | require | verify-ca | verify-full)")
 	fs.BoolVar(&c.Debug, "enable-db-debug", c.Debug, "framework's debug mode")
 	fs.IntVar(&c.MaxOpenConnections, "db-max-open-connections", c.MaxOpenConnections, "Maximum open DB connections for this instance")
+	fs.BoolVar(&c.GateReadsUntilMigrated, "gate-reads-until-migrated", c.GateReadsUntilMigrated, "Reject the reads (e.g. the gRPC subscriptions and the REST GET requests) as well as the mutations with an unavailable error until all the database migrations are applied, the mutations are always rejected until then")
+	fs.IntVar(&c.CircuitBreakerFailureThreshold, "db-circuit-breaker-failure-threshold", c.CircuitBreakerFailureThreshold, "Number of the consecutive database failures (e.g. the connection errors and the timeouts) of the resource calls after which the circuit breaker is opened, the calls fail fast with an unavailable error while it is open. Set 0 to disable the circuit breaker")
+	fs.DurationVar(&c.CircuitBreakerCooldown, "db-circuit-breaker-cooldown", c.CircuitBreakerCooldown, "Duration for which an open circuit breaker fails the resource calls fast before it probes the database")
+	fs.IntVar(&c.CircuitBreakerProbes, "db-circuit-breaker-probes", c.CircuitBreakerProbes, "Number of the calls let through to probe the database after the cooldown, the circuit breaker is closed once all of them succeed and opened again once one of them fails")
 }
 
 func (c *DatabaseConfig) ReadFiles() error {
@@ -91,7 +117,32 @@ func (c *DatabaseConfig) ReadFiles() error {
 	}
 
 	err = readFileValueString(c.NameFile, &c.Name)
-	return err
+	if err != nil {
+		return err
+	}
+
+	err = readFileValueString(c.ReplicaHostFile, &c.ReplicaHost)
+	if err != nil || len(c.ReplicaPortFile) == 0 {
+		return err
+	}
+
+	return readFileValueInt(c.ReplicaPortFile, &c.ReplicaPort)
+}
+
+// ReplicaEnabled returns true if the read replica of the database is configured.
+func (c *DatabaseConfig) ReplicaEnabled() bool {
+	return len(c.ReplicaHost) != 0
+}
+
+// ReplicaConfig returns the config to connect the read replica of the database, it is a copy of the config with the
+// host and port of the replica.
+func (c *DatabaseConfig) ReplicaConfig() *DatabaseConfig {
+	replica := *c
+	replica.Host = c.ReplicaHost
+	if c.ReplicaPort != 0 {
+		replica.Port = c.ReplicaPort
+	}
+	return &replica
 }
 
 func (c *DatabaseConfig) ConnectionString(withSSL bool) string {
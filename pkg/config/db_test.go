@@ -0,0 +1,33 @@
+package config
+
+import (
+	"testing"
+)
+
+func TestDatabaseReplicaConfig(t *testing.T) {
+	c := NewDatabaseConfig()
+	c.Host = "primary"
+	c.Port = 5432
+	c.Name = "maestro"
+	if c.ReplicaEnabled() {
+		t.Errorf("expected the replica is not enabled without the replica host")
+	}
+
+	c.ReplicaHost = "replica"
+	if !c.ReplicaEnabled() {
+		t.Errorf("expected the replica is enabled with the replica host")
+	}
+	replica := c.ReplicaConfig()
+	if replica.Host != "replica" || replica.Port != 5432 || replica.Name != "maestro" {
+		t.Errorf("expected the replica shares the port and name of the primary, but got %s:%d/%s",
+			replica.Host, replica.Port, replica.Name)
+	}
+	if c.Host != "primary" {
+		t.Errorf("expected the primary config is not changed, but got host %s", c.Host)
+	}
+
+	c.ReplicaPort = 5433
+	if replica := c.ReplicaConfig(); replica.Port != 5433 {
+		t.Errorf("expected the replica port 5433, but got %d", replica.Port)
+	}
+}
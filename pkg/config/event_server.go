@@ -1,6 +1,10 @@
 package config
 
 import (
+	"fmt"
+	"net/url"
+	"time"
+
 	"github.com/spf13/pflag"
 )
 
@@ -15,6 +19,24 @@ const (
 type EventServerConfig struct {
 	SubscriptionType     string                `json:"subscription_type"`
 	ConsistentHashConfig *ConsistentHashConfig `json:"consistent_hash_config"`
+	// BroadcasterBufferSize is the size of the buffer of the resource status events broadcast to the subscribers.
+	BroadcasterBufferSize int `json:"broadcaster_buffer_size"`
+	// BroadcasterOverflowPolicy is the policy when the broadcaster buffer is full, either "block", "drop-oldest"
+	// or "drop-newest".
+	BroadcasterOverflowPolicy string `json:"broadcaster_overflow_policy"`
+	// EventMaxAge is the maximum age of the rows kept in the events and status_events tables, the older rows are
+	// pruned by the leader instance every EventPruneInterval, 0 disables the pruning.
+	EventMaxAge        time.Duration `json:"event_max_age"`
+	EventPruneInterval time.Duration `json:"event_prune_interval"`
+	// EventSource is the CloudEvent source of the events that maestro originates, e.g. the instance or deployment
+	// name, so the events of the maestro deployments in a federation don't collide.
+	EventSource string `json:"event_source"`
+	// StatusResyncInterval is the interval between the consumers of a status resync, so the broker and the
+	// subscribers are not overwhelmed by the resent statuses.
+	StatusResyncInterval time.Duration `json:"status_resync_interval"`
+	// EventOutboxDrainInterval is the interval to re-enqueue the events that are not reconciled yet, e.g. their
+	// dispatch failed while the broker was unavailable, 0 disables the draining.
+	EventOutboxDrainInterval time.Duration `json:"event_outbox_drain_interval"`
 }
 
 // ConsistentHashConfig contains the configuration for the consistent hashing algorithm.
@@ -22,13 +44,23 @@ type ConsistentHashConfig struct {
 	PartitionCount    int     `json:"partition_count"`
 	ReplicationFactor int     `json:"replication_factor"`
 	Load              float64 `json:"load"`
+	// WarmupDelay is the delay after an instance becomes ready before it is admitted into the hashing ring, so the
+	// consumers are only transferred to the instance after it is truly serving.
+	WarmupDelay time.Duration `json:"warmup_delay"`
 }
 
 // NewEventServerConfig creates a new EventServerConfig with default settings.
 func NewEventServerConfig() *EventServerConfig {
 	return &EventServerConfig{
-		SubscriptionType:     "shared",
-		ConsistentHashConfig: NewConsistentHashConfig(),
+		SubscriptionType:          "shared",
+		ConsistentHashConfig:      NewConsistentHashConfig(),
+		BroadcasterBufferSize:     1000,
+		BroadcasterOverflowPolicy: "block",
+		EventMaxAge:               0,
+		EventPruneInterval:        10 * time.Minute,
+		EventSource:               "maestro",
+		StatusResyncInterval:      100 * time.Millisecond,
+		EventOutboxDrainInterval:  30 * time.Second,
 	}
 }
 
@@ -36,6 +68,7 @@ func NewEventServerConfig() *EventServerConfig {
 //   - PartitionCount: 7
 //   - ReplicationFactor: 20
 //   - Load: 1.25
+//   - WarmupDelay: 0 (the instance is admitted into the hashing ring once it is ready)
 func NewConsistentHashConfig() *ConsistentHashConfig {
 	return &ConsistentHashConfig{
 		PartitionCount:    7,
@@ -52,11 +85,26 @@ func NewConsistentHashConfig() *ConsistentHashConfig {
 //     If subscription type is "broadcast", ConsistentHashConfig settings can be configured for the hashing algorithm.
 func (c *EventServerConfig) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&c.SubscriptionType, "subscription-type", c.SubscriptionType, "Sets the subscription type for resource status updates from message broker, Options: \"shared\" (only one instance receives resource status message, MQTT feature ensures exclusivity) or \"broadcast\" (all instances receive messages, hashed to determine processing instance)")
+	fs.IntVar(&c.BroadcasterBufferSize, "broadcaster-buffer-size", c.BroadcasterBufferSize, "Sets the buffer size of the resource status events broadcast to the subscribers")
+	fs.StringVar(&c.BroadcasterOverflowPolicy, "broadcaster-overflow-policy", c.BroadcasterOverflowPolicy, "Sets the policy when the broadcaster buffer is full, Options: \"block\" (wait for room in the buffer), \"drop-oldest\" (drop the oldest buffered event) or \"drop-newest\" (drop the new event)")
+	fs.DurationVar(&c.EventMaxAge, "event-max-age", c.EventMaxAge, "Sets the maximum age of the events and status events kept in the database, the older events are pruned by the leader instance, 0 disables the pruning")
+	fs.DurationVar(&c.EventPruneInterval, "event-prune-interval", c.EventPruneInterval, "Sets the interval to prune the events older than the event max age")
+	fs.StringVar(&c.EventSource, "event-source", c.EventSource, "Sets the CloudEvent source of the events that maestro originates, e.g. the instance or deployment name, it must be a valid URI reference")
+	fs.DurationVar(&c.StatusResyncInterval, "status-resync-interval", c.StatusResyncInterval, "Sets the interval between the consumers of a status resync to pace the status resync requests to the agents")
+	fs.DurationVar(&c.EventOutboxDrainInterval, "event-outbox-drain-interval", c.EventOutboxDrainInterval, "Sets the interval to re-enqueue the events that are not dispatched yet, e.g. while the broker is unavailable, 0 disables the draining")
 	c.ConsistentHashConfig.AddFlags(fs)
 }
 
+// ReadFiles validates the event source, it must be a non-empty URI reference as required by the CloudEvents spec.
 func (c *EventServerConfig) ReadFiles() error {
 	c.ConsistentHashConfig.ReadFiles()
+
+	if len(c.EventSource) == 0 {
+		return fmt.Errorf("the event source cannot be empty")
+	}
+	if _, err := url.Parse(c.EventSource); err != nil {
+		return fmt.Errorf("invalid event source %q, it must be a URI reference: %v", c.EventSource, err)
+	}
 	return nil
 }
 
@@ -66,6 +114,7 @@ func (c *ConsistentHashConfig) AddFlags(fs *pflag.FlagSet) {
 	fs.IntVar(&c.PartitionCount, "consistent-hash-partition-count", c.PartitionCount, "Sets the partition count for consistent hashing algorithm, select a big PartitionCount for more consumers. only take effect when subscription type is \"broadcast\"")
 	fs.IntVar(&c.ReplicationFactor, "consistent-hash-replication-factor", c.ReplicationFactor, "Sets the replication factor for maestro instances to be replicated on consistent hash ring. only take effect when subscription type is \"broadcast\"")
 	fs.Float64Var(&c.Load, "consistent-hash-load", c.Load, "Sets the load for consistent hashing algorithm, only take effect when subscription type is \"broadcast\"")
+	fs.DurationVar(&c.WarmupDelay, "consistent-hash-warmup-delay", c.WarmupDelay, "Sets the delay after a maestro instance becomes ready before it is admitted into the consistent hash ring, only take effect when subscription type is \"broadcast\" or the gRPC broker connection affinity is enabled")
 }
 
 func (c *ConsistentHashConfig) ReadFiles() error {
@@ -3,6 +3,7 @@ package config
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/spf13/pflag"
 )
@@ -23,6 +24,12 @@ func TestEventServerConfig(t *testing.T) {
 					ReplicationFactor: 20,
 					Load:              1.25,
 				},
+				BroadcasterBufferSize:     1000,
+				BroadcasterOverflowPolicy: "block",
+				EventPruneInterval:        10 * time.Minute,
+				EventSource:               "maestro",
+				StatusResyncInterval:      100 * time.Millisecond,
+				EventOutboxDrainInterval:  30 * time.Second,
 			},
 		},
 		{
@@ -37,6 +44,12 @@ func TestEventServerConfig(t *testing.T) {
 					ReplicationFactor: 20,
 					Load:              1.25,
 				},
+				BroadcasterBufferSize:     1000,
+				BroadcasterOverflowPolicy: "block",
+				EventPruneInterval:        10 * time.Minute,
+				EventSource:               "maestro",
+				StatusResyncInterval:      100 * time.Millisecond,
+				EventOutboxDrainInterval:  30 * time.Second,
 			},
 		},
 		{
@@ -46,6 +59,7 @@ func TestEventServerConfig(t *testing.T) {
 				"consistent-hash-partition-count":    "10",
 				"consistent-hash-replication-factor": "30",
 				"consistent-hash-load":               "1.5",
+				"consistent-hash-warmup-delay":       "30s",
 			},
 			want: &EventServerConfig{
 				SubscriptionType: "broadcast",
@@ -53,7 +67,14 @@ func TestEventServerConfig(t *testing.T) {
 					PartitionCount:    10,
 					ReplicationFactor: 30,
 					Load:              1.5,
+					WarmupDelay:       30 * time.Second,
 				},
+				BroadcasterBufferSize:     1000,
+				BroadcasterOverflowPolicy: "block",
+				EventPruneInterval:        10 * time.Minute,
+				EventSource:               "maestro",
+				StatusResyncInterval:      100 * time.Millisecond,
+				EventOutboxDrainInterval:  30 * time.Second,
 			},
 		},
 	}
@@ -78,3 +99,24 @@ func TestEventServerConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestEventServerConfigEventSource(t *testing.T) {
+	cases := []struct {
+		source    string
+		expectErr bool
+	}{
+		{source: "maestro"},
+		{source: "https://maestro.example.com/region-a"},
+		{source: "urn:maestro:region-a"},
+		{source: "", expectErr: true},
+		{source: "maestro\nregion-a", expectErr: true},
+	}
+
+	for _, c := range cases {
+		config := NewEventServerConfig()
+		config.EventSource = c.source
+		if err := config.ReadFiles(); (err != nil) != c.expectErr {
+			t.Errorf("expected error %v for the event source %q, but got %v", c.expectErr, c.source, err)
+		}
+	}
+}
@@ -1,7 +1,10 @@
 package config
 
 import (
+	"fmt"
 	"math"
+	"net"
+	"sort"
 	"time"
 
 	"github.com/spf13/pflag"
@@ -31,6 +34,44 @@ type GRPCServerConfig struct {
 	ServerPingInterval      time.Duration `json:"server_ping_interval"`
 	ServerPingTimeout       time.Duration `json:"server_ping_timeout"`
 	PermitPingWithoutStream bool          `json:"permit_ping_without_stream"`
+	// AllowedSourcePrefixes maps an authenticated user (the mTLS certificate CN or the token user)
+	// to the source prefix the user is allowed to publish and subscribe to.
+	AllowedSourcePrefixes map[string]string `json:"allowed_source_prefixes"`
+	// PassthroughExtensions are the CloudEvent extensions of the source events that are kept as the resource
+	// metadata and attached back to the resource events.
+	PassthroughExtensions []string `json:"passthrough_extensions"`
+	// EnableAsyncPublish allows the sources to publish with the async commit mode.
+	EnableAsyncPublish bool `json:"enable_async_publish"`
+	// VersionRollbackMode is how an update of a resource bundle without a version is handled, either lenient (the
+	// latest version is used) or strict (the update is rejected).
+	VersionRollbackMode string `json:"grpc_version_rollback_mode"`
+	// BrokerEnableConsumerTokenAuth requires the agents to connect the gRPC broker with a consumer token.
+	BrokerEnableConsumerTokenAuth bool `json:"grpc_broker_enable_consumer_token_auth"`
+	// ConsumerTokenDefaultTTL is the lifetime of an issued consumer token if its expiration is not specified.
+	ConsumerTokenDefaultTTL time.Duration `json:"consumer_token_default_ttl"`
+	// BrokerSubscriberMaxSendFailures is the number of consecutive send failures within the
+	// BrokerSubscriberSendFailureWindow after which an agent subscriber is considered unreachable and unregistered.
+	BrokerSubscriberMaxSendFailures   int           `json:"grpc_broker_subscriber_max_send_failures"`
+	BrokerSubscriberSendFailureWindow time.Duration `json:"grpc_broker_subscriber_send_failure_window"`
+	// BrokerAdvertiseAddress is the address (host:port) on which the agents can reach the gRPC broker of this
+	// instance directly, bypassing the load balancer.
+	BrokerAdvertiseAddress string `json:"grpc_broker_advertise_address"`
+	// BrokerEnableConnectionAffinity redirects an agent to the instance that owns its consumer on the hashing ring.
+	BrokerEnableConnectionAffinity bool `json:"grpc_broker_enable_connection_affinity"`
+	// SourceRewrites maps the source of the stored resources to the source identity that the subscribers expect,
+	// the mapped source is set as the original source of the outbound resource status events. The resources are
+	// still stored with their canonical sources.
+	SourceRewrites map[string]string `json:"source_rewrites"`
+	// ProxyProtocolTrustedCIDRs are the CIDRs of the proxies (e.g. a L4 load balancer) in front of the gRPC server
+	// and broker that are trusted to send the PROXY protocol headers with the real client addresses.
+	ProxyProtocolTrustedCIDRs []string `json:"grpc_proxy_protocol_trusted_cidrs"`
+	// ResyncConcurrency is the max number of the sources whose statuses are resent at the same time for the status
+	// resync requests without the status hashes, and ResyncBatchInterval is how long a resend pauses between batches.
+	ResyncConcurrency   int           `json:"grpc_resync_concurrency"`
+	ResyncBatchInterval time.Duration `json:"grpc_resync_batch_interval"`
+	// TraceContextPropagation starts the server span of a published event as the child of the trace context of the
+	// event (the traceparent and tracestate extensions), and propagates the server span to the agent.
+	TraceContextPropagation bool `json:"grpc_trace_context_propagation"`
 }
 
 func NewGRPCServerConfig() *GRPCServerConfig {
@@ -61,4 +102,49 @@ func (s *GRPCServerConfig) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&s.GRPCAuthorizerConfig, "grpc-authorizer-config", "", "Path to the gRPC authorizer configuration file")
 	fs.StringVar(&s.ClientCAFile, "grpc-client-ca-file", "", "The path to the client ca file, must specify if using mtls authentication type")
 	fs.StringVar(&s.BrokerClientCAFile, "grpc-broker-client-ca-file", "", "The path to the broker client ca file")
+	fs.StringToStringVar(&s.AllowedSourcePrefixes, "grpc-allowed-source-prefixes", map[string]string{}, "The allowed source prefix for each authenticated user (e.g. user-a=team-a-,user-b=team-b-), if it is set, a user can only publish and subscribe to the sources with its prefix")
+	fs.StringSliceVar(&s.PassthroughExtensions, "grpc-passthrough-extensions", []string{}, "The CloudEvent extensions (e.g. commitsha) of the source events that are kept as the resource metadata and attached back to the resource status events")
+	fs.BoolVar(&s.EnableAsyncPublish, "grpc-enable-async-publish", false, "Allow sources to publish with the async commit mode (commitmode=async extension), the publish returns once the resource is accepted and the resource is committed in the background")
+	fs.StringVar(&s.VersionRollbackMode, "grpc-version-rollback-mode", "lenient", "How an update of a resource bundle without a version is handled, lenient uses the latest resource version (counted by the maestro_version_rollback_total metric), strict rejects the update with a conflict")
+	fs.BoolVar(&s.BrokerEnableConsumerTokenAuth, "grpc-broker-enable-consumer-token-auth", false, "Require the agents to connect the gRPC broker with a consumer token, an agent can only subscribe and publish to the topic of the consumer that its token is scoped to")
+	fs.IntVar(&s.BrokerSubscriberMaxSendFailures, "grpc-broker-subscriber-max-send-failures", 3, "The number of consecutive send failures within the send failure window after which an agent subscriber is unregistered, set to 0 to disable it")
+	fs.DurationVar(&s.BrokerSubscriberSendFailureWindow, "grpc-broker-subscriber-send-failure-window", time.Minute, "The window in which the consecutive send failures of an agent subscriber are counted")
+	fs.StringVar(&s.BrokerAdvertiseAddress, "grpc-broker-advertise-address", "", "The address (host:port) on which the agents can reach the gRPC broker of this instance directly, it is required by the connection affinity")
+	fs.BoolVar(&s.BrokerEnableConnectionAffinity, "grpc-broker-enable-connection-affinity", false, "Redirect an agent that subscribes to an instance that doesn't own its consumer to the advertised address of the owning instance")
+	fs.StringToStringVar(&s.SourceRewrites, "grpc-source-rewrites", map[string]string{}, "The source identity expected by the subscribers for each stored resource source (e.g. maestro=proxy-a), it is set as the original source of the outbound resource status events")
+	fs.StringSliceVar(&s.ProxyProtocolTrustedCIDRs, "grpc-proxy-protocol-trusted-cidrs", []string{}, "The CIDRs of the proxies (e.g. 10.0.0.0/16) that are trusted to send the PROXY protocol (v1 or v2) headers with the real client addresses to the gRPC server and broker, the headers of the other peers are not read. It is disabled by default")
+	fs.IntVar(&s.ResyncConcurrency, "grpc-resync-concurrency", 4, "The max number of the sources whose statuses are resent at the same time in the background for the status resync requests without the status hashes, a resync request of a source whose resend is in flight is dropped")
+	fs.DurationVar(&s.ResyncBatchInterval, "grpc-resync-batch-interval", 100*time.Millisecond, "How long a background status resend pauses after every 100 resent statuses, set 0 to resend without pausing")
+	fs.BoolVar(&s.TraceContextPropagation, "grpc-trace-context-propagation", true, "Start the server span of a published event as the child of the trace context (traceparent and tracestate extensions) of the event, and propagate the server span to the agent with the resource spec")
+	fs.DurationVar(&s.ConsumerTokenDefaultTTL, "consumer-token-default-ttl", 24*time.Hour, "The default lifetime of an issued consumer token if its expiration is not specified")
+}
+
+// ReadFiles validates the source rewrites, a source cannot be rewritten to an empty source, and two sources cannot be
+// rewritten to the same source, otherwise the subscribers cannot tell their resources apart. It also validates the
+// trusted proxy CIDRs.
+func (s *GRPCServerConfig) ReadFiles() error {
+	for _, cidr := range s.ProxyProtocolTrustedCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("invalid PROXY protocol trusted CIDR %q: %v", cidr, err)
+		}
+	}
+
+	sources := make([]string, 0, len(s.SourceRewrites))
+	for source := range s.SourceRewrites {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+
+	rewrittenSources := map[string]string{}
+	for _, source := range sources {
+		rewritten := s.SourceRewrites[source]
+		if len(source) == 0 || len(rewritten) == 0 {
+			return fmt.Errorf("invalid source rewrite %q=%q, the source and the rewritten source cannot be empty", source, rewritten)
+		}
+		if other, ok := rewrittenSources[rewritten]; ok {
+			return fmt.Errorf("invalid source rewrites, both the source %s and %s are rewritten to %s", other, source, rewritten)
+		}
+		rewrittenSources[rewritten] = source
+	}
+	return nil
 }
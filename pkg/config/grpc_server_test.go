@@ -0,0 +1,57 @@
+package config
+
+import (
+	"testing"
+)
+
+func TestGRPCServerConfigReadFiles(t *testing.T) {
+	cases := []struct {
+		name           string
+		sourceRewrites map[string]string
+		trustedCIDRs   []string
+		expectedErr    string
+	}{
+		{
+			name: "no source rewrites",
+		},
+		{
+			name:           "valid source rewrites",
+			sourceRewrites: map[string]string{"maestro": "proxy-a", "team-b": "proxy-b"},
+		},
+		{
+			name:           "empty rewritten source",
+			sourceRewrites: map[string]string{"maestro": ""},
+			expectedErr:    `invalid source rewrite "maestro"="", the source and the rewritten source cannot be empty`,
+		},
+		{
+			name:           "duplicate rewritten sources",
+			sourceRewrites: map[string]string{"team-a": "proxy", "team-b": "proxy"},
+			expectedErr:    "invalid source rewrites, both the source team-a and team-b are rewritten to proxy",
+		},
+		{
+			name:         "valid trusted proxy CIDRs",
+			trustedCIDRs: []string{"10.0.0.0/16", "fd00::/8"},
+		},
+		{
+			name:         "invalid trusted proxy CIDR",
+			trustedCIDRs: []string{"10.0.0.1"},
+			expectedErr:  `invalid PROXY protocol trusted CIDR "10.0.0.1": invalid CIDR address: 10.0.0.1`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			config := NewGRPCServerConfig()
+			config.SourceRewrites = c.sourceRewrites
+			config.ProxyProtocolTrustedCIDRs = c.trustedCIDRs
+
+			err := config.ReadFiles()
+			switch {
+			case len(c.expectedErr) == 0 && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case len(c.expectedErr) != 0 && (err == nil || err.Error() != c.expectedErr):
+				t.Errorf("expected error %q, but got %v", c.expectedErr, err)
+			}
+		})
+	}
+}
@@ -7,34 +7,44 @@ import (
 )
 
 type HTTPServerConfig struct {
-	Hostname      string        `json:"hostname"`
-	BindPort      string        `json:"bind_port"`
-	ReadTimeout   time.Duration `json:"read_timeout"`
-	WriteTimeout  time.Duration `json:"write_timeout"`
-	HTTPSCertFile string        `json:"https_cert_file"`
-	HTTPSKeyFile  string        `json:"https_key_file"`
-	EnableHTTPS   bool          `json:"enable_https"`
-	EnableJWT     bool          `json:"enable_jwt"`
-	EnableAuthz   bool          `json:"enable_authz"`
-	JwkCertFile   string        `json:"jwk_cert_file"`
-	JwkCertURL    string        `json:"jwk_cert_url"`
-	ACLFile       string        `json:"acl_file"`
+	Hostname     string        `json:"hostname"`
+	BindPort     string        `json:"bind_port"`
+	ReadTimeout  time.Duration `json:"read_timeout"`
+	WriteTimeout time.Duration `json:"write_timeout"`
+	// HandlerTimeout is the timeout to handle a REST API request, it should be less than the WriteTimeout, so the
+	// timeout error can be written to the client. 0 disables the timeout.
+	HandlerTimeout time.Duration `json:"handler_timeout"`
+	HTTPSCertFile  string        `json:"https_cert_file"`
+	HTTPSKeyFile   string        `json:"https_key_file"`
+	EnableHTTPS    bool          `json:"enable_https"`
+	EnableJWT      bool          `json:"enable_jwt"`
+	EnableAuthz    bool          `json:"enable_authz"`
+	JwkCertFile    string        `json:"jwk_cert_file"`
+	JwkCertURL     string        `json:"jwk_cert_url"`
+	ACLFile        string        `json:"acl_file"`
+	// ImmutableManifestFields are the immutable fields of the manifests in the form of <apiVersion>/<kind>:<path>,
+	// the resource updates (e.g. patches and reverts) that change the fields are rejected. Empty disables the validation.
+	ImmutableManifestFields []string `json:"immutable_manifest_fields"`
+	// AdminUsers are the usernames of the authenticated users that are allowed to perform the admin operations, e.g.
+	// transferring the ownership of a resource or force-releasing a resource soft-lock.
+	AdminUsers []string `json:"admin_users"`
 }
 
 func NewHTTPServerConfig() *HTTPServerConfig {
 	return &HTTPServerConfig{
-		Hostname:      "localhost",
-		BindPort:      "8000",
-		ReadTimeout:   5 * time.Second,
-		WriteTimeout:  30 * time.Second,
-		EnableHTTPS:   false,
-		EnableJWT:     true,
-		EnableAuthz:   true,
-		JwkCertFile:   "",
-		JwkCertURL:    "https://sso.redhat.com/auth/realms/redhat-external/protocol/openid-connect/certs",
-		ACLFile:       "",
-		HTTPSCertFile: "",
-		HTTPSKeyFile:  "",
+		Hostname:       "localhost",
+		BindPort:       "8000",
+		ReadTimeout:    5 * time.Second,
+		WriteTimeout:   30 * time.Second,
+		HandlerTimeout: 25 * time.Second,
+		EnableHTTPS:    false,
+		EnableJWT:      true,
+		EnableAuthz:    true,
+		JwkCertFile:    "",
+		JwkCertURL:     "https://sso.redhat.com/auth/realms/redhat-external/protocol/openid-connect/certs",
+		ACLFile:        "",
+		HTTPSCertFile:  "",
+		HTTPSKeyFile:   "",
 	}
 }
 
@@ -43,6 +53,7 @@ func (s *HTTPServerConfig) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&s.Hostname, "server-hostname", s.Hostname, "Server's public hostname")
 	fs.DurationVar(&s.ReadTimeout, "http-read-timeout", s.ReadTimeout, "HTTP server read timeout")
 	fs.DurationVar(&s.WriteTimeout, "http-write-timeout", s.WriteTimeout, "HTTP server write timeout")
+	fs.DurationVar(&s.HandlerTimeout, "http-handler-timeout", s.HandlerTimeout, "The timeout to handle a REST API request, 0 disables the timeout")
 	fs.StringVar(&s.HTTPSCertFile, "https-cert-file", s.HTTPSCertFile, "The path to the tls.crt file.")
 	fs.StringVar(&s.HTTPSKeyFile, "https-key-file", s.HTTPSKeyFile, "The path to the tls.key file.")
 	fs.BoolVar(&s.EnableHTTPS, "enable-https", s.EnableHTTPS, "Enable HTTPS rather than HTTP")
@@ -51,6 +62,10 @@ func (s *HTTPServerConfig) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&s.JwkCertFile, "jwk-cert-file", s.JwkCertFile, "JWK Certificate file")
 	fs.StringVar(&s.JwkCertURL, "jwk-cert-url", s.JwkCertURL, "JWK Certificate URL")
 	fs.StringVar(&s.ACLFile, "acl-file", s.ACLFile, "Access control list file")
+	fs.StringSliceVar(&s.ImmutableManifestFields, "immutable-manifest-fields", s.ImmutableManifestFields,
+		"The immutable manifest fields in the form of <apiVersion>/<kind>:<path>, e.g. apps/v1/StatefulSet:spec.volumeClaimTemplates, the resource updates (e.g. patches and reverts) that change these fields are rejected")
+	fs.StringSliceVar(&s.AdminUsers, "admin-users", s.AdminUsers,
+		"The usernames of the users that are allowed to perform the admin operations, e.g. transferring the ownership of a resource")
 }
 
 func (s *HTTPServerConfig) ReadFiles() error {
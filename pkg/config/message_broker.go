@@ -12,6 +12,9 @@ type MessageBrokerConfig struct {
 	ClientID            string `json:"client_id"`
 	MessageBrokerType   string `json:"message_broker_type"`
 	MessageBrokerConfig string `json:"message_broker_file"`
+	// StatusHashIgnoredFields are the dot-separated paths of the status feedback fields that are dropped before
+	// hashing the resource status for the agent status resync, e.g. "metadata.resourceVersion".
+	StatusHashIgnoredFields []string `json:"status_hash_ignored_fields"`
 }
 
 func NewMessageBrokerConfig() *MessageBrokerConfig {
@@ -30,4 +33,5 @@ func (c *MessageBrokerConfig) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&c.ClientID, "client-id", c.ClientID, "Client ID")
 	fs.StringVar(&c.MessageBrokerType, "message-broker-type", c.MessageBrokerType, "Message broker type ('grpc' or 'mqtt'). Default is 'mqtt'.")
 	fs.StringVar(&c.MessageBrokerConfig, "message-broker-config-file", c.MessageBrokerConfig, "The config file path of message broker")
+	fs.StringSliceVar(&c.StatusHashIgnoredFields, "status-hash-ignored-fields", c.StatusHashIgnoredFields, "Comma-separated dot-separated paths of the status feedback fields that are ignored when hashing the resource status, e.g. \"metadata.resourceVersion\"")
 }
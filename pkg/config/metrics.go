@@ -10,6 +10,8 @@ type MetricsConfig struct {
 	BindPort                      string        `json:"bind_port"`
 	EnableHTTPS                   bool          `json:"enable_https"`
 	LabelMetricsInclusionDuration time.Duration `json:"label_metrics_inclusion_duration"`
+	// ConsumerMetricsMode is the mode of the consumer-scoped metrics, none, consumer or group.
+	ConsumerMetricsMode string `json:"consumer_metrics_mode"`
 }
 
 func NewMetricsConfig() *MetricsConfig {
@@ -17,6 +19,7 @@ func NewMetricsConfig() *MetricsConfig {
 		BindPort:                      "8080",
 		EnableHTTPS:                   false,
 		LabelMetricsInclusionDuration: 7 * 24 * time.Hour,
+		ConsumerMetricsMode:           "none",
 	}
 }
 
@@ -24,6 +27,8 @@ func (s *MetricsConfig) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&s.BindPort, "metrics-server-bindport", s.BindPort, "Metrics server bind port")
 	fs.BoolVar(&s.EnableHTTPS, "enable-metrics-https", s.EnableHTTPS, "Enable HTTPS for metrics server")
 	fs.DurationVar(&s.LabelMetricsInclusionDuration, "label-metrics-inclusion-duration", 7*24*time.Hour, "A cluster's last telemetry date needs be within in this duration in order to have labels collected")
+	fs.StringVar(&s.ConsumerMetricsMode, "consumer-metrics-mode", s.ConsumerMetricsMode,
+		"The mode of the consumer-scoped metrics: none, consumer (a series per consumer, for a few consumers) or group (a series per consumer group)")
 }
 
 func (s *MetricsConfig) ReadFiles() error {
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// ObjectStoreConfig is the config of the S3-compatible object store that keeps the payloads of the large resource
+// bundles, the offloading is disabled by default.
+type ObjectStoreConfig struct {
+	// PayloadOffloadThreshold is the size (in bytes) beyond which the payload of a resource bundle is offloaded to
+	// the object store, 0 disables the offloading.
+	PayloadOffloadThreshold int           `json:"payload_offload_threshold"`
+	Endpoint                string        `json:"endpoint"`
+	Bucket                  string        `json:"bucket"`
+	Region                  string        `json:"region"`
+	Timeout                 time.Duration `json:"timeout"`
+	AccessKeyID             string        `json:"-"`
+	SecretAccessKey         string        `json:"-"`
+
+	AccessKeyIDFile     string `json:"access_key_id_file"`
+	SecretAccessKeyFile string `json:"secret_access_key_file"`
+}
+
+func NewObjectStoreConfig() *ObjectStoreConfig {
+	return &ObjectStoreConfig{
+		PayloadOffloadThreshold: 0,
+		Region:                  "us-east-1",
+		Timeout:                 10 * time.Second,
+		AccessKeyIDFile:         "secrets/object-store.access-key-id",
+		SecretAccessKeyFile:     "secrets/object-store.secret-access-key",
+	}
+}
+
+func (c *ObjectStoreConfig) AddFlags(fs *pflag.FlagSet) {
+	fs.IntVar(&c.PayloadOffloadThreshold, "payload-offload-threshold", c.PayloadOffloadThreshold,
+		"The size (in bytes) beyond which the payload of a resource bundle is stored in the object store, 0 disables the offloading")
+	fs.StringVar(&c.Endpoint, "object-store-endpoint", c.Endpoint, "The URL of the S3-compatible object store")
+	fs.StringVar(&c.Bucket, "object-store-bucket", c.Bucket, "The bucket of the object store to keep the offloaded payloads")
+	fs.StringVar(&c.Region, "object-store-region", c.Region, "The region of the object store")
+	fs.DurationVar(&c.Timeout, "object-store-timeout", c.Timeout, "Timeout for each request made to the object store")
+	fs.StringVar(&c.AccessKeyIDFile, "object-store-access-key-id-file", c.AccessKeyIDFile, "File containing the access key ID of the object store")
+	fs.StringVar(&c.SecretAccessKeyFile, "object-store-secret-access-key-file", c.SecretAccessKeyFile, "File containing the secret access key of the object store")
+}
+
+// OffloadEnabled returns true if the payloads of the large resource bundles are offloaded to the object store.
+func (c *ObjectStoreConfig) OffloadEnabled() bool {
+	return c.PayloadOffloadThreshold > 0
+}
+
+func (c *ObjectStoreConfig) ReadFiles() error {
+	if !c.OffloadEnabled() {
+		return nil
+	}
+	if len(c.Endpoint) == 0 || len(c.Bucket) == 0 {
+		return fmt.Errorf("the object store endpoint and bucket are required when the payload offloading is enabled")
+	}
+	if err := readFileValueString(c.AccessKeyIDFile, &c.AccessKeyID); err != nil {
+		return err
+	}
+	return readFileValueString(c.SecretAccessKeyFile, &c.SecretAccessKey)
+}
@@ -0,0 +1,119 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// ResourceConfig is the config of the resource management, e.g. the limits of the resource manifests and the checks
+// of the resources that are run by the leader instance.
+type ResourceConfig struct {
+	// OrphanedResourceCheckInterval is the interval to check the resources whose consumer doesn't exist, 0 disables
+	// the check.
+	OrphanedResourceCheckInterval time.Duration `json:"orphaned_resource_check_interval"`
+	// OrphanedResourceDeletion marks the orphaned resources as deleting once they are found.
+	OrphanedResourceDeletion bool `json:"orphaned_resource_deletion"`
+	// MaxBundleManifests is the max number of the manifests in a resource bundle.
+	MaxBundleManifests int `json:"max_bundle_manifests"`
+	// MaxManifestDepth is the max nesting depth of a resource manifest.
+	MaxManifestDepth int `json:"max_manifest_depth"`
+	// MaxManifestKeys is the max number of the object keys in a resource manifest.
+	MaxManifestKeys int `json:"max_manifest_keys"`
+	// AllowedManifestKinds and DeniedManifestKinds are the kind patterns of the manifests that are admitted and
+	// rejected, all the kinds are admitted if both are empty.
+	AllowedManifestKinds []string `json:"allowed_manifest_kinds"`
+	DeniedManifestKinds  []string `json:"denied_manifest_kinds"`
+	// DefaultNamespace is applied to the namespaceless manifests of the namespaced kinds submitted by the REST API,
+	// the kinds in the ClusterScopedKinds are skipped. Empty disables the defaulting.
+	DefaultNamespace   string   `json:"default_namespace"`
+	ClusterScopedKinds []string `json:"cluster_scoped_kinds"`
+	// LockDefaultTTL is the TTL of a resource soft-lock that is acquired without a TTL, a lock cannot be acquired with
+	// a TTL longer than the LockMaxTTL.
+	LockDefaultTTL time.Duration `json:"lock_default_ttl"`
+	LockMaxTTL     time.Duration `json:"lock_max_ttl"`
+	// ReconcileTimeout is how long the version of a resource can be unobserved by the agent since its last spec change
+	// before the resource is marked with the Stale condition, 0 disables the marking.
+	ReconcileTimeout time.Duration `json:"reconcile_timeout"`
+	// RevisionLimit is the max number of the manifest revisions kept for each resource, 0 disables the revisions.
+	RevisionLimit int `json:"revision_limit"`
+	// QuarantineThreshold is the number of the consecutive reconcile failures after which a resource is quarantined,
+	// 0 disables the quarantine.
+	QuarantineThreshold int `json:"quarantine_threshold"`
+	// LabelKeys and LabelPrefixes select the manifest labels that are mirrored to the resource labels.
+	LabelKeys     []string `json:"label_keys"`
+	LabelPrefixes []string `json:"label_prefixes"`
+}
+
+func NewResourceConfig() *ResourceConfig {
+	return &ResourceConfig{
+		OrphanedResourceCheckInterval: 10 * time.Minute,
+		MaxBundleManifests:            1000,
+		MaxManifestDepth:              100,
+		MaxManifestKeys:               1000000,
+		ClusterScopedKinds: []string{
+			"APIService",
+			"ClusterRole",
+			"ClusterRoleBinding",
+			"CustomResourceDefinition",
+			"IngressClass",
+			"MutatingWebhookConfiguration",
+			"Namespace",
+			"Node",
+			"PersistentVolume",
+			"PriorityClass",
+			"RuntimeClass",
+			"StorageClass",
+			"ValidatingAdmissionPolicy",
+			"ValidatingAdmissionPolicyBinding",
+			"ValidatingWebhookConfiguration",
+			"VolumeAttachment",
+		},
+		LockDefaultTTL: 5 * time.Minute,
+		LockMaxTTL:     time.Hour,
+		RevisionLimit:  10,
+	}
+}
+
+func (c *ResourceConfig) AddFlags(fs *pflag.FlagSet) {
+	fs.DurationVar(&c.OrphanedResourceCheckInterval, "orphaned-resource-check-interval", c.OrphanedResourceCheckInterval, "Interval at which the leader instance checks the resources whose consumer doesn't exist, the orphaned resources are logged and counted by the maestro_orphaned_resources metric. Set 0 to disable the check")
+	fs.BoolVar(&c.OrphanedResourceDeletion, "orphaned-resource-deletion", c.OrphanedResourceDeletion, "Mark the orphaned resources as deleting once they are found by the orphaned resource check")
+	fs.IntVar(&c.MaxBundleManifests, "max-bundle-manifests", c.MaxBundleManifests, "Maximum number of the manifests in a resource bundle, the oversized bundles are rejected. Set 0 to disable the limit")
+	fs.IntVar(&c.MaxManifestDepth, "max-manifest-depth", c.MaxManifestDepth, "Maximum nesting depth of a resource manifest, the deeply nested manifests are rejected before they are written to the database. Set 0 to disable the limit")
+	fs.IntVar(&c.MaxManifestKeys, "max-manifest-keys", c.MaxManifestKeys, "Maximum number of the object keys in a resource manifest, the manifests with more keys are rejected before they are written to the database. Set 0 to disable the limit")
+	fs.StringSliceVar(&c.AllowedManifestKinds, "allowed-manifest-kinds", c.AllowedManifestKinds, "Comma-separated <apiVersion>/<kind> patterns (e.g. apps/v1/Deployment,v1/ConfigMap,*.example.com/*/*) of the manifest kinds that are allowed on create and update, the group, version and kind can be a * wildcard. All the kinds are allowed if it is empty, a malformed pattern fails the startup")
+	fs.StringSliceVar(&c.DeniedManifestKinds, "denied-manifest-kinds", c.DeniedManifestKinds, "Comma-separated <apiVersion>/<kind> patterns of the manifest kinds that are rejected on create and update, a denied kind is rejected even if it is allowed by --allowed-manifest-kinds")
+	fs.StringVar(&c.DefaultNamespace, "default-namespace", c.DefaultNamespace, "The namespace applied to the namespaceless manifests of the namespaced kinds, empty disables the defaulting")
+	fs.StringSliceVar(&c.ClusterScopedKinds, "cluster-scoped-kinds", c.ClusterScopedKinds, "The cluster-scoped kinds, the default namespace is not applied to the manifests of these kinds")
+	fs.DurationVar(&c.LockDefaultTTL, "resource-lock-default-ttl", c.LockDefaultTTL, "The TTL of a resource soft-lock that is acquired without a TTL")
+	fs.DurationVar(&c.LockMaxTTL, "resource-lock-max-ttl", c.LockMaxTTL, "The max TTL of a resource soft-lock")
+	fs.DurationVar(&c.ReconcileTimeout, "resource-reconcile-timeout", c.ReconcileTimeout, "Duration after which a resource whose version is not observed by the agent since its last spec change is marked with the Stale=Unknown condition by the leader instance, the condition is cleared by the next status of the resource. Set 0 to disable the marking")
+	fs.IntVar(&c.RevisionLimit, "resource-revision-limit", c.RevisionLimit, "Maximum number of the manifest revisions kept for each resource, the older revisions are pruned. Set 0 to disable the resource revision history")
+	fs.IntVar(&c.QuarantineThreshold, "resource-quarantine-threshold", c.QuarantineThreshold, "Number of the consecutive status reports of a resource that failed to apply after which the resource is quarantined, the spec of a quarantined resource is not sent to the agent until it is released. Set 0 to disable the quarantine")
+	fs.StringSliceVar(&c.LabelKeys, "resource-label-keys", c.LabelKeys, "Comma-separated keys of the manifest labels that are mirrored to the resource labels on create and update, so the resources can be filtered by the label query parameter")
+	fs.StringSliceVar(&c.LabelPrefixes, "resource-label-prefixes", c.LabelPrefixes, "Comma-separated key prefixes (e.g. app.kubernetes.io/) of the manifest labels that are mirrored to the resource labels on create and update, so the resources can be filtered by the label query parameter")
+}
+
+func (c *ResourceConfig) ReadFiles() error {
+	if c.OrphanedResourceCheckInterval < 0 {
+		return fmt.Errorf("the orphaned resource check interval must not be negative, got %s", c.OrphanedResourceCheckInterval)
+	}
+	if c.MaxBundleManifests < 0 {
+		return fmt.Errorf("the max bundle manifests must not be negative, got %d", c.MaxBundleManifests)
+	}
+	if c.ReconcileTimeout < 0 {
+		return fmt.Errorf("the resource reconcile timeout must not be negative, got %s", c.ReconcileTimeout)
+	}
+	if c.RevisionLimit < 0 {
+		return fmt.Errorf("the resource revision limit must not be negative, got %d", c.RevisionLimit)
+	}
+	if c.QuarantineThreshold < 0 {
+		return fmt.Errorf("the resource quarantine threshold must not be negative, got %d", c.QuarantineThreshold)
+	}
+	if c.LockMaxTTL <= 0 || c.LockDefaultTTL <= 0 || c.LockDefaultTTL > c.LockMaxTTL {
+		return fmt.Errorf("the resource lock default TTL %s must be positive and at most the max TTL %s",
+			c.LockDefaultTTL, c.LockMaxTTL)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package config
+
+import (
+	"testing"
+)
+
+func TestResourceConfigReadFiles(t *testing.T) {
+	cases := []struct {
+		name        string
+		update      func(config *ResourceConfig)
+		expectedErr string
+	}{
+		{
+			name:   "default config",
+			update: func(config *ResourceConfig) {},
+		},
+		{
+			name:   "revision history disabled",
+			update: func(config *ResourceConfig) { config.RevisionLimit = 0 },
+		},
+		{
+			name:        "negative revision limit",
+			update:      func(config *ResourceConfig) { config.RevisionLimit = -1 },
+			expectedErr: "the resource revision limit must not be negative, got -1",
+		},
+		{
+			name:        "negative quarantine threshold",
+			update:      func(config *ResourceConfig) { config.QuarantineThreshold = -1 },
+			expectedErr: "the resource quarantine threshold must not be negative, got -1",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			config := NewResourceConfig()
+			c.update(config)
+
+			err := config.ReadFiles()
+			switch {
+			case len(c.expectedErr) == 0 && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case len(c.expectedErr) != 0 && (err == nil || err.Error() != c.expectedErr):
+				t.Errorf("expected error %q, but got %v", c.expectedErr, err)
+			}
+		})
+	}
+}
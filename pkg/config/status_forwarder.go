@@ -0,0 +1,65 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// StatusForwarderConfig is the config of the status forwarder that publishes the resource status changes to the
+// external sinks, each sink is enabled independently and the forwarder is disabled if there is no sink.
+type StatusForwarderConfig struct {
+	// KafkaTopic is the Kafka topic that the status changes are published to, empty disables the Kafka sink.
+	KafkaTopic string `json:"kafka_topic"`
+	// KafkaConfigFile is the config file of the Kafka producer, it has the same format as the Kafka message broker
+	// config file.
+	KafkaConfigFile string `json:"kafka_config_file"`
+	// BufferSize is the max number of the status changes kept for each sink while they are being sent or retried.
+	BufferSize    int           `json:"buffer_size"`
+	RetryInterval time.Duration `json:"retry_interval"`
+}
+
+func NewStatusForwarderConfig() *StatusForwarderConfig {
+	return &StatusForwarderConfig{
+		BufferSize:    1000,
+		RetryInterval: 5 * time.Second,
+	}
+}
+
+func (c *StatusForwarderConfig) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&c.KafkaTopic, "status-forwarder-kafka-topic", c.KafkaTopic,
+		"The Kafka topic that the resource status changes are published to, empty disables the Kafka status sink")
+	fs.StringVar(&c.KafkaConfigFile, "status-forwarder-kafka-config-file", c.KafkaConfigFile,
+		"The config file path of the Kafka producer of the Kafka status sink")
+	fs.IntVar(&c.BufferSize, "status-forwarder-buffer-size", c.BufferSize,
+		"The max number of the resource status changes buffered for each status sink, the changes beyond it are dropped")
+	fs.DurationVar(&c.RetryInterval, "status-forwarder-retry-interval", c.RetryInterval,
+		"The interval between the retries of a resource status change that failed to be sent to a status sink")
+}
+
+// KafkaEnabled returns true if the resource status changes are published to Kafka.
+func (c *StatusForwarderConfig) KafkaEnabled() bool {
+	return len(c.KafkaTopic) > 0
+}
+
+// Enabled returns true if there is any sink of the status forwarder.
+func (c *StatusForwarderConfig) Enabled() bool {
+	return c.KafkaEnabled()
+}
+
+func (c *StatusForwarderConfig) ReadFiles() error {
+	if !c.Enabled() {
+		return nil
+	}
+	if c.KafkaEnabled() && len(c.KafkaConfigFile) == 0 {
+		return fmt.Errorf("the Kafka config file is required when the Kafka status sink is enabled")
+	}
+	if c.BufferSize <= 0 {
+		return fmt.Errorf("the status forwarder buffer size must be positive, got %d", c.BufferSize)
+	}
+	if c.RetryInterval <= 0 {
+		return fmt.Errorf("the status forwarder retry interval must be positive, got %s", c.RetryInterval)
+	}
+	return nil
+}
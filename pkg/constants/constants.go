@@ -9,4 +9,17 @@ const (
 	// MinTokenLifeThreshold defines the minimum remaining lifetime (in seconds) of the access token before
 	// it should be refreshed.
 	MinTokenLifeThreshold = 60.0
+
+	// ExtensionCommitMode is the CloudEvent extension for a source to choose how its published resource is committed.
+	ExtensionCommitMode = "commitmode"
+	// CommitModeSync (default) commits the resource before the publish returns, a successful publish means the
+	// resource is durably committed.
+	CommitModeSync = "sync"
+	// CommitModeAsync only accepts the resource when the publish returns, the resource is committed in the
+	// background.
+	CommitModeAsync = "async"
+
+	// ExtensionResourceIDs is the CloudEvent extension for a source to scope its status resync request to the given
+	// resources, the value is a comma-separated list of the resource IDs.
+	ExtensionResourceIDs = "resourceids"
 )
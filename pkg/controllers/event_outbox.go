@@ -0,0 +1,61 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/openshift-online/maestro/pkg/services"
+)
+
+// EventOutboxDrainer periodically re-enqueues the events that are not reconciled yet to the event controller. The
+// events table is the outbox of the resource dispatches: an event is recorded with the resource change before the
+// request returns, and it is reconciled only after its dispatch is published to the broker. If the broker is
+// unavailable, the failed events are retried with a growing backoff in memory, so they are re-enqueued every interval
+// to be dispatched shortly after the broker recovers, and the events missed by the listener (e.g. the instance is
+// restarted) are not left until the next events sync.
+type EventOutboxDrainer struct {
+	events   services.EventService
+	enqueue  func(id string)
+	interval time.Duration
+}
+
+func NewEventOutboxDrainer(events services.EventService, enqueue func(id string), interval time.Duration) *EventOutboxDrainer {
+	return &EventOutboxDrainer{
+		events:   events,
+		enqueue:  enqueue,
+		interval: interval,
+	}
+}
+
+// Run drains the event outbox every interval until the context is done.
+func (d *EventOutboxDrainer) Run(ctx context.Context) {
+	wait.UntilWithContext(ctx, d.Drain, d.interval)
+}
+
+// Drain refreshes the event outbox depth and re-enqueues the unreconciled events created more than an interval ago,
+// the newer events are still being handled after their notifications. The event filter decides whether the current
+// instance processes a re-enqueued event, and the reconciled events are skipped by the event controller.
+func (d *EventOutboxDrainer) Drain(ctx context.Context) {
+	events, svcErr := d.events.FindAllUnreconciledEvents(ctx)
+	if svcErr != nil {
+		// the draining is retried in the next cycle
+		logger.Error(fmt.Sprintf("Failed to list the unreconciled events: %s", svcErr))
+		return
+	}
+	eventOutboxDepthGauge.Set(float64(len(events)))
+
+	before := time.Now().Add(-d.interval)
+	drained := 0
+	for _, event := range events {
+		if event.CreatedAt.Before(before) {
+			d.enqueue(event.ID)
+			drained++
+		}
+	}
+	if drained > 0 {
+		logger.Infof("re-enqueued %d of %d unreconciled events", drained, len(events))
+	}
+}
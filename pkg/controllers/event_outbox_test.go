@@ -0,0 +1,41 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/dao/mocks"
+	"github.com/openshift-online/maestro/pkg/services"
+)
+
+func TestEventOutboxDrainer(t *testing.T) {
+	RegisterTestingT(t)
+	ResetStatusControllerMetrics()
+
+	ctx := context.Background()
+	now := time.Now()
+	eventDao := mocks.NewEventDao()
+	for id, age := range map[string]time.Duration{"failed": time.Hour, "new": time.Second, "reconciled": time.Hour} {
+		event := &api.Event{Meta: api.Meta{ID: id, CreatedAt: now.Add(-age)}}
+		if id == "reconciled" {
+			event.ReconciledDate = &now
+		}
+		_, err := eventDao.Create(ctx, event)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	enqueued := []string{}
+	drainer := NewEventOutboxDrainer(services.NewEventService(eventDao), func(id string) {
+		enqueued = append(enqueued, id)
+	}, time.Minute)
+
+	// only the unreconciled events older than the interval are re-enqueued
+	drainer.Drain(ctx)
+	Expect(enqueued).To(ConsistOf("failed"))
+	Expect(testutil.ToFloat64(eventOutboxDepthGauge)).To(Equal(2.0))
+}
@@ -0,0 +1,85 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/openshift-online/maestro/pkg/dao"
+	"github.com/openshift-online/maestro/pkg/services"
+)
+
+// LeaderElector elects the only maestro instance that runs a singleton controller, e.g. db.LeaderLock.
+type LeaderElector interface {
+	TryAcquire(ctx context.Context) (bool, error)
+}
+
+// EventPruner periodically deletes the rows of the events and status_events tables that are older than the max age,
+// so the tables don't grow unbounded if some events are never reconciled. Only the leader instance prunes the events.
+type EventPruner struct {
+	leader           LeaderElector
+	events           services.EventService
+	statusEvents     services.StatusEventService
+	eventInstanceDao dao.EventInstanceDao
+	maxAge           time.Duration
+	interval         time.Duration
+}
+
+func NewEventPruner(leader LeaderElector, events services.EventService, statusEvents services.StatusEventService,
+	eventInstanceDao dao.EventInstanceDao, maxAge, interval time.Duration) *EventPruner {
+	return &EventPruner{
+		leader:           leader,
+		events:           events,
+		statusEvents:     statusEvents,
+		eventInstanceDao: eventInstanceDao,
+		maxAge:           maxAge,
+		interval:         interval,
+	}
+}
+
+// Run prunes the events every interval until the context is done.
+func (p *EventPruner) Run(ctx context.Context) {
+	wait.UntilWithContext(ctx, p.Prune, p.interval)
+}
+
+// Prune deletes the events created before the max age if the current instance is the leader. The event instances
+// reference both the status events and the events, so they are deleted first.
+func (p *EventPruner) Prune(ctx context.Context) {
+	isLeader, err := p.leader.TryAcquire(ctx)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to elect the event pruner leader: %v", err))
+		return
+	}
+	if !isLeader {
+		return
+	}
+
+	before := time.Now().Add(-p.maxAge)
+
+	instances, err := p.eventInstanceDao.DeleteByEventsCreatedBefore(ctx, before)
+	if err != nil {
+		// the pruning is retried in the next cycle
+		logger.Error(fmt.Sprintf("Failed to prune event instances created before %s: %v", before, err))
+		return
+	}
+	eventsPrunedCounter.WithLabelValues("event_instances").Add(float64(instances))
+
+	statusEvents, svcErr := p.statusEvents.DeleteCreatedBefore(ctx, before)
+	if svcErr != nil {
+		logger.Error(fmt.Sprintf("Failed to prune status events: %s", svcErr))
+		return
+	}
+	eventsPrunedCounter.WithLabelValues("status_events").Add(float64(statusEvents))
+
+	events, svcErr := p.events.DeleteCreatedBefore(ctx, before)
+	if svcErr != nil {
+		logger.Error(fmt.Sprintf("Failed to prune events: %s", svcErr))
+		return
+	}
+	eventsPrunedCounter.WithLabelValues("events").Add(float64(events))
+
+	logger.Infof("pruned %d events, %d status events and %d event instances created before %s",
+		events, statusEvents, instances, before)
+}
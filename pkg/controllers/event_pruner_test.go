@@ -0,0 +1,62 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/dao/mocks"
+	"github.com/openshift-online/maestro/pkg/services"
+)
+
+type fakeLeaderElector struct {
+	leader bool
+}
+
+func (e *fakeLeaderElector) TryAcquire(ctx context.Context) (bool, error) {
+	return e.leader, nil
+}
+
+func TestEventPruner(t *testing.T) {
+	RegisterTestingT(t)
+	ResetStatusControllerMetrics()
+
+	ctx := context.Background()
+	now := time.Now()
+	eventDao := mocks.NewEventDao()
+	statusEventDao := mocks.NewStatusEventDao()
+	for id, age := range map[string]time.Duration{"old": 2 * time.Hour, "new": time.Minute} {
+		_, err := eventDao.Create(ctx, &api.Event{Meta: api.Meta{ID: id, CreatedAt: now.Add(-age)}})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = statusEventDao.Create(ctx, &api.StatusEvent{Meta: api.Meta{ID: id, CreatedAt: now.Add(-age)}})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	leader := &fakeLeaderElector{}
+	pruner := NewEventPruner(leader, services.NewEventService(eventDao), services.NewStatusEventService(statusEventDao),
+		mocks.NewEventInstanceDaoMock(), time.Hour, time.Minute)
+
+	// only the leader prunes the events
+	pruner.Prune(ctx)
+	events, err := eventDao.All(ctx)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(len(events)).To(Equal(2))
+
+	leader.leader = true
+	pruner.Prune(ctx)
+	events, err = eventDao.All(ctx)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(len(events)).To(Equal(1))
+	Expect(events[0].ID).To(Equal("new"))
+	statusEvents, err := statusEventDao.All(ctx)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(len(statusEvents)).To(Equal(1))
+	Expect(statusEvents[0].ID).To(Equal("new"))
+
+	Expect(testutil.ToFloat64(eventsPrunedCounter.WithLabelValues("events"))).To(Equal(1.0))
+	Expect(testutil.ToFloat64(eventsPrunedCounter.WithLabelValues("status_events"))).To(Equal(1.0))
+}
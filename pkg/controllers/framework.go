@@ -23,6 +23,8 @@ The implementation is specific to the Event table in this service and leverages
 	2. advisory locks are used for concurrency when doing background work
 
 DAOs decorated similarly to the ResourceDAO will persist Events to the database and listeners are notified of the changed.
+The services commit the Events in the same transaction as the changes, so the Events table is a transactional outbox, an
+Event is delivered at least once even if the instance restarts before the Event is processed.
 A worker attempting to process the Event will first obtain a fail-fast advisory lock. Of many competing workers, only
 one would first successfully obtain the lock. All other workers will *not* wait to obtain the lock.
 
@@ -215,3 +215,64 @@ func TestControllerFrameworkWithPredicatedEventFilter(t *testing.T) {
 	Expect(err).To(BeNil())
 	Expect(eve.ReconciledDate).To(BeNil(), "event reconcile date should not be set")
 }
+
+// failingController fails to send the resources until the broker is available, e.g. the instance crashes after the
+// resource and its event are committed but before the resource is sent.
+type failingController struct {
+	available bool
+	sent      []string
+}
+
+func (c *failingController) OnAdd(ctx context.Context, id string) error {
+	if !c.available {
+		return fmt.Errorf("the broker is unavailable")
+	}
+	c.sent = append(c.sent, id)
+	return nil
+}
+
+func TestControllerFrameworkRedeliversUnsentEvents(t *testing.T) {
+	RegisterTestingT(t)
+
+	ctx := context.Background()
+	eventsDao := mocks.NewEventDao()
+	events := services.NewEventService(eventsDao)
+
+	// the event is committed with the resource, but it is not sent before the instance crashes
+	_, err := eventsDao.Create(ctx, &api.Event{
+		Meta:      api.Meta{ID: "1"},
+		Source:    "Resources",
+		SourceID:  "resource1",
+		EventType: api.CreateEventType,
+	})
+	Expect(err).To(BeNil())
+
+	crashed := &failingController{}
+	mgr := NewKindControllerManager(NewLockBasedEventFilter(dbmocks.NewMockAdvisoryLockFactory()), events)
+	mgr.Add(&ControllerConfig{
+		Source:   "Resources",
+		Handlers: map[api.EventType][]ControllerHandlerFunc{api.CreateEventType: {crashed.OnAdd}},
+	})
+	Expect(mgr.handleEvent("1")).NotTo(Succeed())
+
+	// the restarted instance redelivers the unreconciled event once it syncs the events
+	restarted := &failingController{available: true}
+	mgr = NewKindControllerManager(NewLockBasedEventFilter(dbmocks.NewMockAdvisoryLockFactory()), events)
+	mgr.Add(&ControllerConfig{
+		Source:   "Resources",
+		Handlers: map[api.EventType][]ControllerHandlerFunc{api.CreateEventType: {restarted.OnAdd}},
+	})
+	mgr.syncEvents()
+	Expect(mgr.eventsQueue.Len()).To(Equal(1))
+	Expect(mgr.processNextEvent()).To(BeTrue())
+	Expect(restarted.sent).To(Equal([]string{"resource1"}))
+
+	eve, err := eventsDao.Get(ctx, "1")
+	Expect(err).To(BeNil())
+	Expect(eve.ReconciledDate).ToNot(BeNil(), "event reconcile date should be set")
+
+	// the delivered event is deduplicated by its ID, e.g. it is re-enqueued by the event outbox drainer
+	mgr.AddEvent("1")
+	Expect(mgr.processNextEvent()).To(BeTrue())
+	Expect(restarted.sent).To(Equal([]string{"resource1"}))
+}
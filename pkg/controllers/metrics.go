@@ -0,0 +1,196 @@
+package controllers
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	// Register the metrics for the status controller
+	RegisterStatusControllerMetrics()
+}
+
+// Namespace used to define the metrics:
+const metricsNamespace = "maestro"
+
+// Names of the metrics:
+const (
+	oldestPendingDispatchMetric = "oldest_pending_dispatch_seconds"
+	eventsPrunedCountMetric     = "events_pruned_total"
+	resourcesMarkedStaleMetric  = "resources_marked_stale_total"
+	statusResyncConsumersMetric = "status_resync_consumers_total"
+	orphanedResourcesMetric     = "orphaned_resources"
+	orphansDeletedCountMetric   = "orphaned_resources_deleted_total"
+	eventOutboxDepthMetric      = "event_outbox_depth"
+)
+
+// Names of the labels added to metrics:
+const (
+	metricsTableLabel  = "table"
+	metricsResultLabel = "result"
+	metricsTypeLabel   = "type"
+)
+
+// Values of the result label of the status resync consumers metric:
+const (
+	statusResyncSucceeded = "succeeded"
+	statusResyncFailed    = "failed"
+)
+
+// Register the metrics:
+func RegisterStatusControllerMetrics() {
+	prometheus.MustRegister(oldestPendingDispatchGauge)
+	prometheus.MustRegister(eventsPrunedCounter)
+	prometheus.MustRegister(resourcesMarkedStaleCounter)
+	prometheus.MustRegister(statusResyncConsumersCounter)
+	prometheus.MustRegister(orphanedResourcesGauge)
+	prometheus.MustRegister(orphanedResourcesDeletedCounter)
+	prometheus.MustRegister(eventOutboxDepthGauge)
+}
+
+// Unregister the metrics:
+func UnregisterStatusControllerMetrics() {
+	prometheus.Unregister(oldestPendingDispatchGauge)
+	prometheus.Unregister(eventsPrunedCounter)
+	prometheus.Unregister(resourcesMarkedStaleCounter)
+	prometheus.Unregister(statusResyncConsumersCounter)
+	prometheus.Unregister(orphanedResourcesGauge)
+	prometheus.Unregister(orphanedResourcesDeletedCounter)
+	prometheus.Unregister(eventOutboxDepthGauge)
+}
+
+// Reset the metrics:
+func ResetStatusControllerMetrics() {
+	pendingDispatches.reset()
+	eventsPrunedCounter.Reset()
+	statusResyncConsumersCounter.Reset()
+	orphanedResourcesGauge.Reset()
+	eventOutboxDepthGauge.Set(0)
+}
+
+// pendingDispatches tracks the status events that are received but not yet dispatched by the current instance.
+var pendingDispatches = newPendingDispatchTracker()
+
+// Description of the oldest pending dispatch metric:
+var oldestPendingDispatchGauge = prometheus.NewGaugeFunc(
+	prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      oldestPendingDispatchMetric,
+		Help: "Age in seconds of the oldest status change received by the maestro instance but not yet broadcast to " +
+			"the subscribers, 0 if there is no pending status change.",
+	},
+	func() float64 {
+		return pendingDispatches.oldestAge(time.Now())
+	},
+)
+
+// Description of the events pruned count metric:
+var eventsPrunedCounter = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      eventsPrunedCountMetric,
+		Help:      "Number of rows pruned from the event tables because they are older than the event max age.",
+	},
+	[]string{metricsTableLabel},
+)
+
+// Description of the resources marked stale count metric:
+var resourcesMarkedStaleCounter = prometheus.NewCounter(
+	prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      resourcesMarkedStaleMetric,
+		Help:      "Number of resources marked with the Stale condition because they are not reconciled within the reconcile timeout.",
+	},
+)
+
+// Description of the status resync consumers count metric:
+var statusResyncConsumersCounter = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      statusResyncConsumersMetric,
+		Help:      "Number of consumers processed by the status resyncs, partitioned by the result of the resync request.",
+	},
+	[]string{metricsResultLabel},
+)
+
+// Description of the orphaned resources metric:
+var orphanedResourcesGauge = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      orphanedResourcesMetric,
+		Help:      "Number of resources whose consumer doesn't exist, refreshed by the leader instance.",
+	},
+	[]string{metricsTypeLabel},
+)
+
+// Description of the orphaned resources deleted count metric:
+var orphanedResourcesDeletedCounter = prometheus.NewCounter(
+	prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      orphansDeletedCountMetric,
+		Help:      "Number of orphaned resources marked as deleting by the orphaned resource controller.",
+	},
+)
+
+// Description of the event outbox depth metric:
+var eventOutboxDepthGauge = prometheus.NewGauge(
+	prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      eventOutboxDepthMetric,
+		Help:      "Number of events that are not reconciled yet, e.g. their dispatch is pending or failed, refreshed by the event outbox drainer.",
+	},
+)
+
+// pendingDispatchTracker records when each pending status event is received.
+type pendingDispatchTracker struct {
+	mu    sync.Mutex
+	since map[string]time.Time
+}
+
+func newPendingDispatchTracker() *pendingDispatchTracker {
+	return &pendingDispatchTracker{since: map[string]time.Time{}}
+}
+
+// add records the status event as pending, the first received time is kept if the event is received again.
+func (t *pendingDispatchTracker) add(id string, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, ok := t.since[id]; !ok {
+		t.since[id] = now
+	}
+}
+
+// done removes the status event once it is dispatched.
+func (t *pendingDispatchTracker) done(id string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.since, id)
+}
+
+// oldestAge returns the age in seconds of the oldest pending status event.
+func (t *pendingDispatchTracker) oldestAge(now time.Time) float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var oldest time.Time
+	for _, since := range t.since {
+		if oldest.IsZero() || since.Before(oldest) {
+			oldest = since
+		}
+	}
+	if oldest.IsZero() {
+		return 0
+	}
+	return now.Sub(oldest).Seconds()
+}
+
+func (t *pendingDispatchTracker) reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.since = map[string]time.Time{}
+}
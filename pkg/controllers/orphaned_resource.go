@@ -0,0 +1,94 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/errors"
+	"github.com/openshift-online/maestro/pkg/services"
+)
+
+// orphanedResourcePageSize is the number of the orphaned resources loaded at once.
+const orphanedResourcePageSize = 500
+
+// OrphanedResourceController periodically checks the resources whose consumer doesn't exist, e.g. the consumer is
+// deleted from the database directly, so it is not guarded against having resources. The orphaned resources are
+// logged and counted by the orphaned_resources metric, and they are marked as deleting if the deletion is enabled.
+// Only the leader instance checks the resources.
+type OrphanedResourceController struct {
+	leader       LeaderElector
+	resources    services.ResourceService
+	markDeleting bool
+	interval     time.Duration
+}
+
+func NewOrphanedResourceController(leader LeaderElector, resources services.ResourceService, markDeleting bool,
+	interval time.Duration) *OrphanedResourceController {
+	return &OrphanedResourceController{
+		leader:       leader,
+		resources:    resources,
+		markDeleting: markDeleting,
+		interval:     interval,
+	}
+}
+
+// Run checks the orphaned resources every interval until the context is done.
+func (c *OrphanedResourceController) Run(ctx context.Context) {
+	wait.UntilWithContext(ctx, c.Check, c.interval)
+}
+
+// Check finds the orphaned resources if the current instance is the leader.
+func (c *OrphanedResourceController) Check(ctx context.Context) {
+	isLeader, err := c.leader.TryAcquire(ctx)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to elect the orphaned resource controller leader: %v", err))
+		return
+	}
+	if !isLeader {
+		return
+	}
+
+	for _, resourceType := range []api.ResourceType{api.ResourceTypeSingle, api.ResourceTypeBundle} {
+		resources, svcErr := c.findOrphaned(ctx, resourceType)
+		if svcErr != nil {
+			// the check is retried in the next cycle
+			logger.Error(fmt.Sprintf("Failed to find the orphaned resources: %s", svcErr))
+			continue
+		}
+		orphanedResourcesGauge.WithLabelValues(string(resourceType)).Set(float64(len(resources)))
+
+		for _, resource := range resources {
+			logger.Warning(fmt.Sprintf("The %s resource %s is orphaned, its consumer %s doesn't exist",
+				resourceType, resource.ID, resource.ConsumerName))
+			if !c.markDeleting {
+				continue
+			}
+			if svcErr := c.resources.MarkAsDeleting(ctx, resource.ID); svcErr != nil {
+				logger.Error(fmt.Sprintf("Failed to mark the orphaned resource %s as deleting: %s", resource.ID, svcErr))
+				continue
+			}
+			orphanedResourcesDeletedCounter.Inc()
+		}
+	}
+}
+
+// findOrphaned collects the orphaned resources page by page before they are marked, so the resources that are marked
+// as deleting don't shift the pages.
+func (c *OrphanedResourceController) findOrphaned(ctx context.Context, resourceType api.ResourceType) (api.ResourceList, *errors.ServiceError) {
+	orphaned := api.ResourceList{}
+	for page := 1; ; page++ {
+		resources, paging, svcErr := c.resources.FindOrphaned(ctx, resourceType,
+			&services.ListArguments{Page: page, Size: orphanedResourcePageSize})
+		if svcErr != nil {
+			return nil, svcErr
+		}
+		orphaned = append(orphaned, resources...)
+		if len(resources) == 0 || int64(len(orphaned)) >= paging.Total {
+			return orphaned, nil
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/dao/mocks"
+	dbmocks "github.com/openshift-online/maestro/pkg/db/mocks"
+	"github.com/openshift-online/maestro/pkg/services"
+)
+
+func TestOrphanedResourceController(t *testing.T) {
+	RegisterTestingT(t)
+	ResetStatusControllerMetrics()
+
+	ctx := context.Background()
+	consumerDao := mocks.NewConsumerDao()
+	_, err := consumerDao.Create(ctx, &api.Consumer{Meta: api.Meta{ID: "cluster1"}, Name: "cluster1"})
+	Expect(err).NotTo(HaveOccurred())
+
+	resourceDao := mocks.NewResourceDaoWithConsumers(consumerDao)
+	for _, resource := range []*api.Resource{
+		{Meta: api.Meta{ID: "owned"}, ConsumerName: "cluster1", Type: api.ResourceTypeSingle},
+		{Meta: api.Meta{ID: "orphaned"}, ConsumerName: "cluster2", Type: api.ResourceTypeSingle},
+		{Meta: api.Meta{ID: "orphaned-bundle"}, ConsumerName: "cluster2", Type: api.ResourceTypeBundle},
+	} {
+		_, err := resourceDao.Create(ctx, resource)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	leader := &fakeLeaderElector{}
+	resourceService := services.NewResourceService(dbmocks.NewMockAdvisoryLockFactory(), dbmocks.NewMockTransactor(), resourceDao, mocks.NewResourceRevisionDao(),
+		mocks.NewResourceOwnershipTransferDao(), mocks.NewConsumerDao(), services.NewEventService(mocks.NewEventDao()), nil, services.ResourceServiceOptions{})
+
+	// only the leader checks the resources
+	NewOrphanedResourceController(leader, resourceService, false, time.Minute).Check(ctx)
+	Expect(testutil.CollectAndCount(orphanedResourcesGauge)).To(Equal(0))
+
+	leader.leader = true
+	NewOrphanedResourceController(leader, resourceService, false, time.Minute).Check(ctx)
+	Expect(testutil.ToFloat64(orphanedResourcesGauge.WithLabelValues(string(api.ResourceTypeSingle)))).To(Equal(1.0))
+	Expect(testutil.ToFloat64(orphanedResourcesGauge.WithLabelValues(string(api.ResourceTypeBundle)))).To(Equal(1.0))
+	orphaned, err := resourceDao.Get(ctx, "orphaned")
+	Expect(err).NotTo(HaveOccurred())
+	Expect(orphaned.DeletedAt.Valid).To(BeFalse())
+
+	// the orphaned resources are paged
+	resources, paging, svcErr := resourceService.FindOrphaned(ctx, api.ResourceTypeSingle, &services.ListArguments{Page: 1, Size: 1})
+	Expect(svcErr).To(BeNil())
+	Expect(resources).To(HaveLen(1))
+	Expect(resources[0].ID).To(Equal("orphaned"))
+	Expect(paging.Total).To(Equal(int64(1)))
+	resources, paging, svcErr = resourceService.FindOrphaned(ctx, api.ResourceTypeSingle, &services.ListArguments{Page: 2, Size: 1})
+	Expect(svcErr).To(BeNil())
+	Expect(resources).To(BeEmpty())
+	Expect(paging.Total).To(Equal(int64(1)))
+
+	// the orphaned resources are marked as deleting once the deletion is enabled
+	deleted := testutil.ToFloat64(orphanedResourcesDeletedCounter)
+	NewOrphanedResourceController(leader, resourceService, true, time.Minute).Check(ctx)
+	Expect(testutil.ToFloat64(orphanedResourcesDeletedCounter) - deleted).To(Equal(2.0))
+	for _, id := range []string{"orphaned", "orphaned-bundle"} {
+		resource, err := resourceDao.Get(ctx, id)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resource.DeletedAt.Valid).To(BeTrue())
+	}
+	owned, err := resourceDao.Get(ctx, "owned")
+	Expect(err).NotTo(HaveOccurred())
+	Expect(owned.DeletedAt.Valid).To(BeFalse())
+
+	// the resources marked as deleting are not orphaned any more
+	NewOrphanedResourceController(leader, resourceService, true, time.Minute).Check(ctx)
+	Expect(testutil.ToFloat64(orphanedResourcesGauge.WithLabelValues(string(api.ResourceTypeSingle)))).To(Equal(0.0))
+	Expect(testutil.ToFloat64(orphanedResourcesDeletedCounter) - deleted).To(Equal(2.0))
+}
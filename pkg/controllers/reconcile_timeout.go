@@ -0,0 +1,61 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/openshift-online/maestro/pkg/services"
+)
+
+// ReconcileTimeoutController periodically marks the resources whose version is not observed by the agent within the
+// reconcile timeout with the synthetic Stale=Unknown condition, so the silent agents are surfaced without waiting for
+// the operators to notice. The condition is cleared once the agent reports the next status of the resource. Only the
+// leader instance marks the resources.
+type ReconcileTimeoutController struct {
+	leader    LeaderElector
+	resources services.ResourceService
+	timeout   time.Duration
+	interval  time.Duration
+}
+
+func NewReconcileTimeoutController(leader LeaderElector, resources services.ResourceService,
+	timeout, interval time.Duration) *ReconcileTimeoutController {
+	return &ReconcileTimeoutController{
+		leader:    leader,
+		resources: resources,
+		timeout:   timeout,
+		interval:  interval,
+	}
+}
+
+// Run marks the stale resources every interval until the context is done.
+func (c *ReconcileTimeoutController) Run(ctx context.Context) {
+	wait.UntilWithContext(ctx, c.MarkStale, c.interval)
+}
+
+// MarkStale marks the resources that are not reconciled within the timeout if the current instance is the leader.
+func (c *ReconcileTimeoutController) MarkStale(ctx context.Context) {
+	isLeader, err := c.leader.TryAcquire(ctx)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to elect the reconcile timeout controller leader: %v", err))
+		return
+	}
+	if !isLeader {
+		return
+	}
+
+	before := time.Now().Add(-c.timeout)
+	marked, svcErr := c.resources.MarkReconcileStale(ctx, before)
+	if svcErr != nil {
+		// the marking is retried in the next cycle
+		logger.Error(fmt.Sprintf("Failed to mark the reconcile stale resources: %s", svcErr))
+		return
+	}
+	if marked > 0 {
+		resourcesMarkedStaleCounter.Add(float64(marked))
+		logger.Infof("marked %d resources that are not reconciled since %s as stale", marked, before)
+	}
+}
@@ -0,0 +1,77 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"gorm.io/datatypes"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/dao"
+	"github.com/openshift-online/maestro/pkg/dao/mocks"
+	dbmocks "github.com/openshift-online/maestro/pkg/db/mocks"
+	"github.com/openshift-online/maestro/pkg/services"
+)
+
+func TestReconcileTimeoutController(t *testing.T) {
+	RegisterTestingT(t)
+
+	ctx := context.Background()
+	now := time.Now()
+	specUpdatedAt := now.Add(-time.Hour)
+	resourceDao := mocks.NewResourceDao()
+	for _, resource := range []*api.Resource{
+		{Meta: api.Meta{ID: "silent", UpdatedAt: specUpdatedAt}, Version: 2, SpecUpdatedAt: &specUpdatedAt,
+			Status: datatypes.JSONMap{"resourceversion": 1}},
+		{Meta: api.Meta{ID: "unreported", UpdatedAt: specUpdatedAt}, Version: 1, SpecUpdatedAt: &specUpdatedAt},
+		// the status updates bump the updated_at, but the spec is still not reconciled
+		{Meta: api.Meta{ID: "status-updated", UpdatedAt: now}, Version: 3, SpecUpdatedAt: &specUpdatedAt,
+			Status: datatypes.JSONMap{"resourceversion": 2}},
+		{Meta: api.Meta{ID: "reconciled", UpdatedAt: specUpdatedAt}, Version: 2, SpecUpdatedAt: &specUpdatedAt,
+			Status: datatypes.JSONMap{"resourceversion": 2}},
+		{Meta: api.Meta{ID: "recent", UpdatedAt: specUpdatedAt}, Version: 1, SpecUpdatedAt: &now},
+	} {
+		_, err := resourceDao.Create(ctx, resource)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	leader := &fakeLeaderElector{}
+	resourceService := services.NewResourceService(dbmocks.NewMockAdvisoryLockFactory(), dbmocks.NewMockTransactor(), resourceDao, mocks.NewResourceRevisionDao(),
+		mocks.NewResourceOwnershipTransferDao(), mocks.NewConsumerDao(), services.NewEventService(mocks.NewEventDao()), nil, services.ResourceServiceOptions{})
+	controller := NewReconcileTimeoutController(leader, resourceService, 10*time.Minute, time.Minute)
+	marked := testutil.ToFloat64(resourcesMarkedStaleCounter)
+
+	// only the leader marks the resources
+	controller.MarkStale(ctx)
+	Expect(staleResources(ctx, resourceDao)).To(BeEmpty())
+
+	leader.leader = true
+	controller.MarkStale(ctx)
+	Expect(staleResources(ctx, resourceDao)).To(ConsistOf("silent", "unreported", "status-updated"))
+	Expect(testutil.ToFloat64(resourcesMarkedStaleCounter) - marked).To(Equal(3.0))
+
+	// the marked resources are not marked again
+	controller.MarkStale(ctx)
+	found, err := resourceDao.Get(ctx, "silent")
+	Expect(err).NotTo(HaveOccurred())
+	Expect(found.Conditions).To(ConsistOf(api.ReconcileStaleCondition))
+	Expect(found.IsReconcileStale()).To(BeTrue())
+	Expect(testutil.ToFloat64(resourcesMarkedStaleCounter) - marked).To(Equal(3.0))
+}
+
+func staleResources(ctx context.Context, resourceDao dao.ResourceDao) []string {
+	resources, err := resourceDao.All(ctx)
+	Expect(err).NotTo(HaveOccurred())
+	ids := []string{}
+	for _, resource := range resources {
+		for _, condition := range resource.Conditions {
+			if condition == api.ReconcileStaleCondition {
+				ids = append(ids, resource.ID)
+			}
+		}
+	}
+	return ids
+}
@@ -36,8 +36,10 @@ func NewStatusController(statusEvents services.StatusEventService,
 	}
 }
 
-// AddStatusEvent adds a status event to the queue to be processed.
+// AddStatusEvent adds a status event to the queue to be processed, the event is tracked as pending dispatch until
+// it is handled successfully.
 func (sc *StatusController) AddStatusEvent(id string) {
+	pendingDispatches.add(id, time.Now())
 	sc.eventsQueue.Add(id)
 }
 
@@ -86,6 +88,7 @@ func (sc *StatusController) processNextEvent() bool {
 
 	// we handle the status event successfully, tell the queue to stop tracking history for this status event
 	sc.eventsQueue.Forget(key)
+	pendingDispatches.done(key.(string))
 	return true
 }
 
@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"testing"
+	"time"
 )
 
 func TestBatchStatusEventIDs(t *testing.T) {
@@ -62,3 +63,30 @@ func TestBatchStatusEventIDs(t *testing.T) {
 		})
 	}
 }
+
+func TestPendingDispatchTracker(t *testing.T) {
+	tracker := newPendingDispatchTracker()
+	now := time.Now()
+
+	if age := tracker.oldestAge(now); age != 0 {
+		t.Errorf("expected no pending dispatch, but got %v", age)
+	}
+
+	tracker.add("event-1", now.Add(-30*time.Second))
+	tracker.add("event-2", now.Add(-10*time.Second))
+	// the first received time is kept
+	tracker.add("event-1", now)
+	if age := tracker.oldestAge(now); age != 30 {
+		t.Errorf("expected the oldest pending dispatch is 30s, but got %v", age)
+	}
+
+	tracker.done("event-1")
+	if age := tracker.oldestAge(now); age != 10 {
+		t.Errorf("expected the oldest pending dispatch is 10s, but got %v", age)
+	}
+
+	tracker.done("event-2")
+	if age := tracker.oldestAge(now); age != 0 {
+		t.Errorf("expected no pending dispatch, but got %v", age)
+	}
+}
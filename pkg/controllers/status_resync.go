@@ -0,0 +1,308 @@
+package controllers
+
+import (
+	"context"
+	e "errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/dao"
+	"github.com/openshift-online/maestro/pkg/services"
+)
+
+var (
+	// ErrStatusResyncRunning is returned when a status resync is started while another one is running on this or
+	// another maestro instance.
+	ErrStatusResyncRunning = e.New("a status resync is already running")
+	// ErrStatusResyncNotRunning is returned when no status resync is running on any maestro instance.
+	ErrStatusResyncNotRunning = e.New("no status resync is running")
+	// ErrStatusResyncNotSupported is returned when there is no status resync client, e.g. the gRPC broker doesn't
+	// send the status resync requests to the agents.
+	ErrStatusResyncNotSupported = e.New("the status resync is not supported by the message broker")
+)
+
+// StatusResyncClient requests the agents of the consumers to resend their resource statuses, e.g. the cloudevents
+// source client.
+type StatusResyncClient interface {
+	Resync(ctx context.Context, consumers []string) error
+}
+
+// StatusResyncState is the state of a status resync.
+type StatusResyncState = api.StatusResyncState
+
+const (
+	StatusResyncRunning   = api.StatusResyncRunning
+	StatusResyncCompleted = api.StatusResyncCompleted
+	StatusResyncCanceled  = api.StatusResyncCanceled
+)
+
+// statusResyncCancelPollInterval is the interval at which the running status resync checks whether it is canceled by
+// another maestro instance.
+const statusResyncCancelPollInterval = time.Second
+
+// StatusResyncProgress is the progress of a status resync, the failed consumers are counted as processed.
+type StatusResyncProgress struct {
+	State      StatusResyncState
+	Consumers  int
+	Processed  int
+	Failed     int
+	StartedAt  time.Time
+	FinishedAt time.Time
+	// InstanceID is the maestro instance that runs the resync.
+	InstanceID string
+}
+
+// StatusResyncer requests the agents of all the consumers to resync their resource statuses, e.g. after an upgrade
+// that changes the status encoding. The consumers are processed one by one every interval, so the broker and the
+// subscribers are not overwhelmed by the resent statuses. Only the leader runs a status resync, the leader lock is
+// held until the resync is completed or canceled, so a resync runs once across the maestro instances.
+//
+// The progress of the resync is kept in the database, so it is reported and the resync is canceled by any instance,
+// the running instance polls the cancel request. A resync whose instance stopped before finishing it is left running
+// until the next resync is started, which marks it canceled.
+type StatusResyncer struct {
+	leader     LeaderElector
+	consumers  services.ConsumerService
+	resyncs    dao.StatusResyncDao
+	client     StatusResyncClient
+	interval   time.Duration
+	instanceID string
+
+	mu sync.Mutex
+	// cancel cancels the resync running on this instance, it is nil if there is no such resync.
+	cancel context.CancelFunc
+}
+
+func NewStatusResyncer(leader LeaderElector, consumers services.ConsumerService, resyncs dao.StatusResyncDao,
+	client StatusResyncClient, interval time.Duration, instanceID string) *StatusResyncer {
+	return &StatusResyncer{
+		leader:     leader,
+		consumers:  consumers,
+		resyncs:    resyncs,
+		client:     client,
+		interval:   interval,
+		instanceID: instanceID,
+	}
+}
+
+// Start starts a status resync of all the consumers in the background if the current instance is elected as the
+// leader. The resync is not bound to the given context, it runs until it is completed or canceled. The leader lock
+// must be released once the context of its TryAcquire is done, see db.LeaderLock.
+func (r *StatusResyncer) Start(ctx context.Context) (StatusResyncProgress, error) {
+	if r.client == nil {
+		return StatusResyncProgress{}, ErrStatusResyncNotSupported
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.cancel != nil {
+		return r.runningProgress(ctx), ErrStatusResyncRunning
+	}
+
+	runCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	isLeader, err := r.leader.TryAcquire(runCtx)
+	if err != nil {
+		cancel()
+		return StatusResyncProgress{}, fmt.Errorf("failed to elect the status resync leader: %v", err)
+	}
+	if !isLeader {
+		cancel()
+		return r.runningProgress(ctx), ErrStatusResyncRunning
+	}
+
+	// the leader lock is held, so the last resync that is still running was left by a stopped instance
+	last, err := r.resyncs.Latest(runCtx)
+	if err != nil && !e.Is(err, gorm.ErrRecordNotFound) {
+		cancel()
+		return StatusResyncProgress{}, fmt.Errorf("failed to get the last status resync: %v", err)
+	}
+	if last != nil && last.State == StatusResyncRunning {
+		now := time.Now()
+		last.State = StatusResyncCanceled
+		last.FinishedAt = &now
+		if err := r.resyncs.UpdateProgress(runCtx, last); err != nil {
+			cancel()
+			return StatusResyncProgress{}, fmt.Errorf("failed to cancel the stopped status resync %s: %v", last.ID, err)
+		}
+	}
+
+	consumers, svcErr := r.consumers.All(runCtx)
+	if svcErr != nil {
+		cancel()
+		return StatusResyncProgress{}, fmt.Errorf("failed to list the consumers: %s", svcErr)
+	}
+	names := make([]string, 0, len(consumers))
+	for _, consumer := range consumers {
+		names = append(names, consumer.Name)
+	}
+
+	resync, err := r.resyncs.Create(runCtx, &api.StatusResync{
+		State:      StatusResyncRunning,
+		Consumers:  len(names),
+		StartedAt:  time.Now(),
+		InstanceID: r.instanceID,
+	})
+	if err != nil {
+		cancel()
+		return StatusResyncProgress{}, fmt.Errorf("failed to record the status resync: %v", err)
+	}
+	r.cancel = cancel
+	go r.watchCancel(runCtx, resync.ID)
+	go r.run(runCtx, *resync, names)
+
+	logger.Infof("started the status resync %s of %d consumers", resync.ID, len(names))
+	return toStatusResyncProgress(resync), nil
+}
+
+// Cancel cancels the running status resync, the resync stops before its next consumer. The resync running on another
+// instance stops once it polls the cancel request.
+func (r *StatusResyncer) Cancel(ctx context.Context) (StatusResyncProgress, error) {
+	resync, err := r.resyncs.Latest(ctx)
+	if err != nil {
+		if e.Is(err, gorm.ErrRecordNotFound) {
+			return StatusResyncProgress{}, ErrStatusResyncNotRunning
+		}
+		return StatusResyncProgress{}, fmt.Errorf("failed to get the last status resync: %v", err)
+	}
+
+	requested, err := r.resyncs.RequestCancel(ctx, resync.ID)
+	if err != nil {
+		return StatusResyncProgress{}, fmt.Errorf("failed to cancel the status resync %s: %v", resync.ID, err)
+	}
+	if !requested {
+		return StatusResyncProgress{}, ErrStatusResyncNotRunning
+	}
+
+	if resync.InstanceID == r.instanceID {
+		r.mu.Lock()
+		if r.cancel != nil {
+			r.cancel()
+		}
+		r.mu.Unlock()
+	}
+	return toStatusResyncProgress(resync), nil
+}
+
+// Progress returns the progress of the running or the last status resync across the maestro instances, false is
+// returned if no status resync is started.
+func (r *StatusResyncer) Progress(ctx context.Context) (StatusResyncProgress, bool, error) {
+	resync, err := r.resyncs.Latest(ctx)
+	if err != nil {
+		if e.Is(err, gorm.ErrRecordNotFound) {
+			return StatusResyncProgress{}, false, nil
+		}
+		return StatusResyncProgress{}, false, fmt.Errorf("failed to get the last status resync: %v", err)
+	}
+	return toStatusResyncProgress(resync), true, nil
+}
+
+// runningProgress returns the progress of the last status resync for a rejected start, the failure is only logged.
+func (r *StatusResyncer) runningProgress(ctx context.Context) StatusResyncProgress {
+	progress, _, err := r.Progress(ctx)
+	if err != nil {
+		logger.Error(err.Error())
+	}
+	return progress
+}
+
+// watchCancel cancels the resync running on this instance once it is canceled by another instance.
+func (r *StatusResyncer) watchCancel(ctx context.Context, id string) {
+	ticker := time.NewTicker(statusResyncCancelPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		resync, err := r.resyncs.Get(ctx, id)
+		if err != nil {
+			if ctx.Err() == nil {
+				logger.Error(fmt.Sprintf("Failed to get the status resync %s: %v", id, err))
+			}
+			continue
+		}
+		if resync.CancelRequested {
+			r.mu.Lock()
+			if r.cancel != nil {
+				r.cancel()
+			}
+			r.mu.Unlock()
+			return
+		}
+	}
+}
+
+func (r *StatusResyncer) run(ctx context.Context, resync api.StatusResync, consumers []string) {
+	// the progress is recorded even if the resync is canceled
+	recordCtx := context.WithoutCancel(ctx)
+
+	state := StatusResyncCompleted
+	for i, consumer := range consumers {
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+			case <-time.After(r.interval):
+			}
+		}
+		if ctx.Err() != nil {
+			state = StatusResyncCanceled
+			break
+		}
+
+		result := statusResyncSucceeded
+		if err := r.client.Resync(ctx, []string{consumer}); err != nil {
+			// the failed consumers are not retried, their agents resync the statuses once they are reconnected
+			logger.Error(fmt.Sprintf("Failed to resync the statuses of consumer %s: %v", consumer, err))
+			result = statusResyncFailed
+		}
+		statusResyncConsumersCounter.WithLabelValues(result).Inc()
+
+		resync.Processed++
+		if result == statusResyncFailed {
+			resync.Failed++
+		}
+		if err := r.resyncs.UpdateProgress(recordCtx, &resync); err != nil {
+			logger.Error(fmt.Sprintf("Failed to record the progress of the status resync %s: %v", resync.ID, err))
+		}
+	}
+
+	now := time.Now()
+	resync.State = state
+	resync.FinishedAt = &now
+	if err := r.resyncs.UpdateProgress(recordCtx, &resync); err != nil {
+		logger.Error(fmt.Sprintf("Failed to record the progress of the status resync %s: %v", resync.ID, err))
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// release the leader lock
+	r.cancel()
+	r.cancel = nil
+
+	logger.Infof("the status resync %s is %s, %d of %d consumers are processed, %d failed",
+		resync.ID, state, resync.Processed, resync.Consumers, resync.Failed)
+}
+
+func toStatusResyncProgress(resync *api.StatusResync) StatusResyncProgress {
+	progress := StatusResyncProgress{
+		State:      resync.State,
+		Consumers:  resync.Consumers,
+		Processed:  resync.Processed,
+		Failed:     resync.Failed,
+		StartedAt:  resync.StartedAt,
+		InstanceID: resync.InstanceID,
+	}
+	if resync.FinishedAt != nil {
+		progress.FinishedAt = *resync.FinishedAt
+	}
+	return progress
+}
@@ -0,0 +1,203 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/dao/mocks"
+	dbmocks "github.com/openshift-online/maestro/pkg/db/mocks"
+	"github.com/openshift-online/maestro/pkg/services"
+)
+
+type fakeStatusResyncClient struct {
+	mu        sync.Mutex
+	failed    string
+	consumers []string
+}
+
+func (c *fakeStatusResyncClient) Resync(ctx context.Context, consumers []string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.consumers = append(c.consumers, consumers...)
+	for _, consumer := range consumers {
+		if consumer == c.failed {
+			return fmt.Errorf("failed to resync %s", consumer)
+		}
+	}
+	return nil
+}
+
+func (c *fakeStatusResyncClient) resynced() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]string{}, c.consumers...)
+}
+
+func TestStatusResyncer(t *testing.T) {
+	RegisterTestingT(t)
+	ResetStatusControllerMetrics()
+
+	ctx := context.Background()
+	consumerDao := mocks.NewConsumerDao()
+	for _, name := range []string{"cluster1", "cluster2", "cluster3"} {
+		_, err := consumerDao.Create(ctx, &api.Consumer{Meta: api.Meta{ID: name}, Name: name})
+		Expect(err).NotTo(HaveOccurred())
+	}
+	consumers := services.NewConsumerService(dbmocks.NewMockAdvisoryLockFactory(), consumerDao, mocks.NewResourceDao(), nil)
+
+	// the resync is not supported without a client
+	_, err := NewStatusResyncer(&fakeLeaderElector{leader: true}, consumers, mocks.NewStatusResyncDao(), nil, 0, "maestro").Start(ctx)
+	Expect(err).To(Equal(ErrStatusResyncNotSupported))
+
+	// only the leader runs the resync
+	leader := &fakeLeaderElector{}
+	client := &fakeStatusResyncClient{failed: "cluster2"}
+	resyncer := NewStatusResyncer(leader, consumers, mocks.NewStatusResyncDao(), client, time.Millisecond, "maestro")
+	_, err = resyncer.Start(ctx)
+	Expect(err).To(Equal(ErrStatusResyncRunning))
+	_, started, err := resyncer.Progress(ctx)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(started).To(BeFalse())
+
+	leader.leader = true
+	progress, err := resyncer.Start(ctx)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(progress.State).To(Equal(StatusResyncRunning))
+	Expect(progress.Consumers).To(Equal(3))
+	Expect(progress.InstanceID).To(Equal("maestro"))
+
+	Eventually(func() StatusResyncState {
+		progress, _, _ := resyncer.Progress(ctx)
+		return progress.State
+	}).Should(Equal(StatusResyncCompleted))
+	progress, _, _ = resyncer.Progress(ctx)
+	Expect(progress.Processed).To(Equal(3))
+	Expect(progress.Failed).To(Equal(1))
+	Expect(progress.FinishedAt).NotTo(BeZero())
+	Expect(client.resynced()).To(ConsistOf("cluster1", "cluster2", "cluster3"))
+	Expect(testutil.ToFloat64(statusResyncConsumersCounter.WithLabelValues(statusResyncSucceeded))).To(Equal(2.0))
+	Expect(testutil.ToFloat64(statusResyncConsumersCounter.WithLabelValues(statusResyncFailed))).To(Equal(1.0))
+
+	_, err = resyncer.Cancel(ctx)
+	Expect(err).To(Equal(ErrStatusResyncNotRunning))
+}
+
+func TestStatusResyncerCancel(t *testing.T) {
+	RegisterTestingT(t)
+
+	ctx := context.Background()
+	consumerDao := mocks.NewConsumerDao()
+	for _, name := range []string{"cluster1", "cluster2"} {
+		_, err := consumerDao.Create(ctx, &api.Consumer{Meta: api.Meta{ID: name}, Name: name})
+		Expect(err).NotTo(HaveOccurred())
+	}
+	consumers := services.NewConsumerService(dbmocks.NewMockAdvisoryLockFactory(), consumerDao, mocks.NewResourceDao(), nil)
+
+	client := &fakeStatusResyncClient{}
+	resyncer := NewStatusResyncer(&fakeLeaderElector{leader: true}, consumers, mocks.NewStatusResyncDao(), client, time.Hour, "maestro")
+	_, err := resyncer.Start(ctx)
+	Expect(err).NotTo(HaveOccurred())
+
+	// the resync waits for the pacing interval before the second consumer
+	Eventually(client.resynced).Should(HaveLen(1))
+	_, err = resyncer.Start(ctx)
+	Expect(err).To(Equal(ErrStatusResyncRunning))
+
+	_, err = resyncer.Cancel(ctx)
+	Expect(err).NotTo(HaveOccurred())
+	Eventually(func() StatusResyncState {
+		progress, _, _ := resyncer.Progress(ctx)
+		return progress.State
+	}).Should(Equal(StatusResyncCanceled))
+	progress, _, _ := resyncer.Progress(ctx)
+	Expect(progress.Processed).To(Equal(1))
+	Expect(client.resynced()).To(HaveLen(1))
+}
+
+func TestStatusResyncerAcrossInstances(t *testing.T) {
+	RegisterTestingT(t)
+
+	ctx := context.Background()
+	consumerDao := mocks.NewConsumerDao()
+	for _, name := range []string{"cluster1", "cluster2"} {
+		_, err := consumerDao.Create(ctx, &api.Consumer{Meta: api.Meta{ID: name}, Name: name})
+		Expect(err).NotTo(HaveOccurred())
+	}
+	consumers := services.NewConsumerService(dbmocks.NewMockAdvisoryLockFactory(), consumerDao, mocks.NewResourceDao(), nil)
+
+	// the instances share the database, only the leader runs the resync
+	resyncs := mocks.NewStatusResyncDao()
+	client := &fakeStatusResyncClient{}
+	leader := NewStatusResyncer(&fakeLeaderElector{leader: true}, consumers, resyncs, client, time.Hour, "maestro-1")
+	follower := NewStatusResyncer(&fakeLeaderElector{}, consumers, resyncs, client, time.Hour, "maestro-2")
+
+	_, started, err := follower.Progress(ctx)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(started).To(BeFalse())
+	_, err = follower.Cancel(ctx)
+	Expect(err).To(Equal(ErrStatusResyncNotRunning))
+
+	_, err = leader.Start(ctx)
+	Expect(err).NotTo(HaveOccurred())
+	Eventually(client.resynced).Should(HaveLen(1))
+
+	// the progress of the resync is reported by the other instance
+	progress, err := follower.Start(ctx)
+	Expect(err).To(Equal(ErrStatusResyncRunning))
+	Expect(progress.InstanceID).To(Equal("maestro-1"))
+	Eventually(func() int {
+		progress, _, _ := follower.Progress(ctx)
+		return progress.Processed
+	}).Should(Equal(1))
+	progress, _, _ = follower.Progress(ctx)
+	Expect(progress.State).To(Equal(StatusResyncRunning))
+	Expect(progress.InstanceID).To(Equal("maestro-1"))
+
+	// the resync is canceled by the other instance
+	_, err = follower.Cancel(ctx)
+	Expect(err).NotTo(HaveOccurred())
+	Eventually(func() StatusResyncState {
+		progress, _, _ := leader.Progress(ctx)
+		return progress.State
+	}, 3*statusResyncCancelPollInterval).Should(Equal(StatusResyncCanceled))
+	Expect(client.resynced()).To(HaveLen(1))
+}
+
+func TestStatusResyncerStoppedInstance(t *testing.T) {
+	RegisterTestingT(t)
+
+	ctx := context.Background()
+	consumers := services.NewConsumerService(dbmocks.NewMockAdvisoryLockFactory(), mocks.NewConsumerDao(), mocks.NewResourceDao(), nil)
+
+	// the resync of a stopped instance is left running
+	resyncs := mocks.NewStatusResyncDao()
+	stopped, err := resyncs.Create(ctx, &api.StatusResync{State: StatusResyncRunning, Consumers: 2,
+		StartedAt: time.Now().Add(-time.Hour), InstanceID: "maestro-1"})
+	Expect(err).NotTo(HaveOccurred())
+
+	resyncer := NewStatusResyncer(&fakeLeaderElector{leader: true}, consumers, resyncs, &fakeStatusResyncClient{},
+		time.Millisecond, "maestro-2")
+	_, err = resyncer.Start(ctx)
+	Expect(err).NotTo(HaveOccurred())
+
+	// it is canceled once the next resync is started
+	found, err := resyncs.Get(ctx, stopped.ID)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(found.State).To(Equal(StatusResyncCanceled))
+	Expect(found.FinishedAt).NotTo(BeNil())
+	Eventually(func() StatusResyncState {
+		progress, _, _ := resyncer.Progress(ctx)
+		return progress.State
+	}).Should(Equal(StatusResyncCompleted))
+	progress, _, _ := resyncer.Progress(ctx)
+	Expect(progress.InstanceID).To(Equal("maestro-2"))
+}
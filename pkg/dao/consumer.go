@@ -2,8 +2,15 @@ package dao
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
 
+	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
 
 	"github.com/openshift-online/maestro/pkg/api"
 	"github.com/openshift-online/maestro/pkg/db"
@@ -15,7 +22,50 @@ type ConsumerDao interface {
 	Replace(ctx context.Context, consumer *api.Consumer) (*api.Consumer, error)
 	Delete(ctx context.Context, id string, unscoped bool) error
 	FindByIDs(ctx context.Context, ids []string) (api.ConsumerList, error)
+	FindByNames(ctx context.Context, names []string) (api.ConsumerList, error)
 	All(ctx context.Context) (api.ConsumerList, error)
+	// FindByGroup returns the consumers that have the label of the given consumer group.
+	FindByGroup(ctx context.Context, group string) (api.ConsumerList, error)
+	// Groups returns a page of the consumer groups that have at least one consumer, ordered by name, and the total
+	// number of the groups. The page starts from 1.
+	Groups(ctx context.Context, page int, size int64) (api.ConsumerGroupList, int64, error)
+	// List returns a page of the consumers that match the list options, ordered by name.
+	List(ctx context.Context, opts ConsumerListOptions) (*ConsumerPage, error)
+}
+
+// ConsumerListOptions are the options to list the consumers page by page.
+type ConsumerListOptions struct {
+	// Limit is the max number of the consumers in a page, all the matched consumers are returned if it is not positive.
+	Limit int
+	// Cursor is the NextCursor of the previous page, the first page is returned if it is empty.
+	Cursor string
+	// NamePrefix filters the consumers by the prefix of their names.
+	NamePrefix string
+	// LabelSelector filters the consumers by their labels, the numeric (Gt and Lt) requirements are not supported.
+	LabelSelector labels.Selector
+}
+
+// ConsumerPage is a page of the listed consumers.
+type ConsumerPage struct {
+	Items api.ConsumerList
+	// Total is the number of all the consumers that match the filters of the list options.
+	Total int64
+	// NextCursor is the cursor of the next page, it is empty on the last page.
+	NextCursor string
+}
+
+// NewConsumerCursor returns the cursor of the page that starts after the consumer of the given name.
+func NewConsumerCursor(name string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(name))
+}
+
+// ParseConsumerCursor returns the name of the consumer that the page of the given cursor starts after.
+func ParseConsumerCursor(cursor string) (string, error) {
+	name, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", fmt.Errorf("invalid cursor %q: %v", cursor, err)
+	}
+	return string(name), nil
 }
 
 var _ ConsumerDao = &sqlConsumerDao{}
@@ -77,6 +127,15 @@ func (d *sqlConsumerDao) FindByIDs(ctx context.Context, ids []string) (api.Consu
 	return consumers, nil
 }
 
+func (d *sqlConsumerDao) FindByNames(ctx context.Context, names []string) (api.ConsumerList, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	consumers := api.ConsumerList{}
+	if err := g2.Where("name in (?)", names).Find(&consumers).Error; err != nil {
+		return nil, err
+	}
+	return consumers, nil
+}
+
 func (d *sqlConsumerDao) All(ctx context.Context) (api.ConsumerList, error) {
 	g2 := (*d.sessionFactory).New(ctx)
 	consumers := api.ConsumerList{}
@@ -85,3 +144,130 @@ func (d *sqlConsumerDao) All(ctx context.Context) (api.ConsumerList, error) {
 	}
 	return consumers, nil
 }
+
+func (d *sqlConsumerDao) FindByGroup(ctx context.Context, group string) (api.ConsumerList, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	consumers := api.ConsumerList{}
+	// the labels column is json, it is cast to jsonb to check the label key
+	if err := g2.Where("jsonb_exists(labels::jsonb, ?)", api.ConsumerGroupLabel(group)).Order("name").Find(&consumers).Error; err != nil {
+		return nil, err
+	}
+	return consumers, nil
+}
+
+func (d *sqlConsumerDao) Groups(ctx context.Context, page int, size int64) (api.ConsumerGroupList, int64, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	// the labels that are not a json object (e.g. null) are treated as empty
+	groupsQuery := fmt.Sprintf(`SELECT substr(label_key, %d) AS name, count(*) AS consumer_count
+FROM consumers, jsonb_object_keys(CASE WHEN jsonb_typeof(labels::jsonb) = 'object' THEN labels::jsonb ELSE '{}'::jsonb END) AS label_key
+WHERE deleted_at IS NULL AND label_key LIKE ? AND length(label_key) > %d
+GROUP BY label_key`, len(api.ConsumerGroupLabelPrefix)+1, len(api.ConsumerGroupLabelPrefix))
+	labelPattern := api.ConsumerGroupLabelPrefix + "%"
+
+	var total int64
+	if err := g2.Raw(fmt.Sprintf("SELECT count(*) FROM (%s) AS groups", groupsQuery), labelPattern).Scan(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	groups := api.ConsumerGroupList{}
+	if size == 0 {
+		return groups, total, nil
+	}
+	if err := g2.Raw(groupsQuery+" ORDER BY name OFFSET ? LIMIT ?", labelPattern, (page-1)*int(size), size).
+		Scan(&groups).Error; err != nil {
+		return nil, 0, err
+	}
+	return groups, total, nil
+}
+
+func (d *sqlConsumerDao) List(ctx context.Context, opts ConsumerListOptions) (*ConsumerPage, error) {
+	g2 := (*d.sessionFactory).NewReadOnly(ctx).Model(&api.Consumer{})
+	if opts.NamePrefix != "" {
+		// the name prefix is matched with the name_pattern index
+		g2 = g2.Where("name LIKE ?", escapeLike(opts.NamePrefix)+"%")
+	}
+	if opts.LabelSelector != nil {
+		query, args, err := consumerLabelSelectorQuery(opts.LabelSelector)
+		if err != nil {
+			return nil, err
+		}
+		if query != "" {
+			g2 = g2.Where(query, args...)
+		}
+	}
+
+	page := &ConsumerPage{Items: api.ConsumerList{}}
+	if err := g2.Session(&gorm.Session{}).Count(&page.Total).Error; err != nil {
+		return nil, err
+	}
+
+	if opts.Cursor != "" {
+		after, err := ParseConsumerCursor(opts.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		g2 = g2.Where("name > ?", after)
+	}
+	g2 = g2.Order("name")
+	if opts.Limit > 0 {
+		// one more consumer is queried to know whether there is a next page
+		g2 = g2.Limit(opts.Limit + 1)
+	}
+	if err := g2.Find(&page.Items).Error; err != nil {
+		return nil, err
+	}
+
+	if opts.Limit > 0 && len(page.Items) > opts.Limit {
+		page.Items = page.Items[:opts.Limit]
+		page.NextCursor = NewConsumerCursor(page.Items[opts.Limit-1].Name)
+	}
+	return page, nil
+}
+
+// consumerLabelSelectorQuery translates the label selector to the conditions of the consumer labels. The labels
+// column is json, it is cast to jsonb, so the equality requirements are matched with the labels GIN index. The
+// consumers without the label key match the inequality requirements, as the label selector does.
+func consumerLabelSelectorQuery(selector labels.Selector) (string, []interface{}, error) {
+	requirements, selectable := selector.Requirements()
+	if !selectable {
+		// the selector selects nothing
+		return "false", nil, nil
+	}
+
+	conditions := []string{}
+	args := []interface{}{}
+	for _, requirement := range requirements {
+		key := requirement.Key()
+		switch requirement.Operator() {
+		case selection.Equals, selection.DoubleEquals, selection.In, selection.NotEquals, selection.NotIn:
+			matches := []string{}
+			for _, value := range requirement.Values().List() {
+				label, err := json.Marshal(map[string]string{key: value})
+				if err != nil {
+					return "", nil, err
+				}
+				matches = append(matches, "labels::jsonb @> ?::jsonb")
+				args = append(args, string(label))
+			}
+			condition := fmt.Sprintf("COALESCE(%s, false)", strings.Join(matches, " OR "))
+			if requirement.Operator() == selection.NotEquals || requirement.Operator() == selection.NotIn {
+				condition = "NOT " + condition
+			}
+			conditions = append(conditions, condition)
+		case selection.Exists:
+			conditions = append(conditions, "COALESCE(jsonb_exists(labels::jsonb, ?), false)")
+			args = append(args, key)
+		case selection.DoesNotExist:
+			conditions = append(conditions, "NOT COALESCE(jsonb_exists(labels::jsonb, ?), false)")
+			args = append(args, key)
+		default:
+			return "", nil, fmt.Errorf("unsupported operator %q of the label selector %q", requirement.Operator(), selector)
+		}
+	}
+	return strings.Join(conditions, " AND "), args, nil
+}
+
+// escapeLike escapes the wildcards of the LIKE pattern.
+func escapeLike(pattern string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(pattern)
+}
@@ -0,0 +1,63 @@
+package dao
+
+import (
+	"context"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/db"
+)
+
+type ConsumerTokenDao interface {
+	Get(ctx context.Context, id string) (*api.ConsumerToken, error)
+	Create(ctx context.Context, token *api.ConsumerToken) (*api.ConsumerToken, error)
+	Replace(ctx context.Context, token *api.ConsumerToken) (*api.ConsumerToken, error)
+	FindByConsumerID(ctx context.Context, consumerID string) (api.ConsumerTokenList, error)
+}
+
+var _ ConsumerTokenDao = &sqlConsumerTokenDao{}
+
+type sqlConsumerTokenDao struct {
+	sessionFactory *db.SessionFactory
+}
+
+func NewConsumerTokenDao(sessionFactory *db.SessionFactory) ConsumerTokenDao {
+	return &sqlConsumerTokenDao{sessionFactory: sessionFactory}
+}
+
+func (d *sqlConsumerTokenDao) Get(ctx context.Context, id string) (*api.ConsumerToken, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	var token api.ConsumerToken
+	if err := g2.Take(&token, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (d *sqlConsumerTokenDao) Create(ctx context.Context, token *api.ConsumerToken) (*api.ConsumerToken, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	if err := g2.Omit(clause.Associations).Create(token).Error; err != nil {
+		db.MarkForRollback(ctx, err)
+		return nil, err
+	}
+	return token, nil
+}
+
+func (d *sqlConsumerTokenDao) Replace(ctx context.Context, token *api.ConsumerToken) (*api.ConsumerToken, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	if err := g2.Omit(clause.Associations).Save(token).Error; err != nil {
+		db.MarkForRollback(ctx, err)
+		return nil, err
+	}
+	return token, nil
+}
+
+func (d *sqlConsumerTokenDao) FindByConsumerID(ctx context.Context, consumerID string) (api.ConsumerTokenList, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	tokens := api.ConsumerTokenList{}
+	if err := g2.Where("consumer_id = ?", consumerID).Order("created_at desc").Find(&tokens).Error; err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
@@ -3,6 +3,7 @@ package dao
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"gorm.io/gorm/clause"
 
@@ -20,6 +21,9 @@ type EventDao interface {
 
 	DeleteAllReconciledEvents(ctx context.Context) error
 	FindAllUnreconciledEvents(ctx context.Context) (api.EventList, error)
+	// DeleteCreatedBefore permanently deletes the events created before the given time and returns the number of the
+	// deleted events.
+	DeleteCreatedBefore(ctx context.Context, before time.Time) (int64, error)
 }
 
 var _ EventDao = &sqlEventDao{}
@@ -111,3 +115,13 @@ func (d *sqlEventDao) All(ctx context.Context) (api.EventList, error) {
 	}
 	return events, nil
 }
+
+func (d *sqlEventDao) DeleteCreatedBefore(ctx context.Context, before time.Time) (int64, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	result := g2.Unscoped().Omit(clause.Associations).Where("created_at < ?", before).Delete(&api.Event{})
+	if result.Error != nil {
+		db.MarkForRollback(ctx, result.Error)
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
@@ -2,6 +2,7 @@ package dao
 
 import (
 	"context"
+	"time"
 
 	"gorm.io/gorm/clause"
 
@@ -15,6 +16,9 @@ type EventInstanceDao interface {
 
 	FindStatusEvents(ctx context.Context, ids []string) (api.EventInstanceList, error)
 	GetEventsAssociatedWithInstances(ctx context.Context, instanceIDs []string) ([]string, error)
+	// DeleteByEventsCreatedBefore deletes the event instances of the status events or the spec events that are
+	// created before the given time, it returns the number of the deleted event instances.
+	DeleteByEventsCreatedBefore(ctx context.Context, before time.Time) (int64, error)
 }
 
 var _ EventInstanceDao = &sqlEventInstanceDao{}
@@ -79,3 +83,15 @@ func (d *sqlEventInstanceDao) GetEventsAssociatedWithInstances(ctx context.Conte
 
 	return eventIDs, nil
 }
+
+func (d *sqlEventInstanceDao) DeleteByEventsCreatedBefore(ctx context.Context, before time.Time) (int64, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	result := g2.Exec(`DELETE FROM event_instances
+WHERE event_id IN (SELECT id FROM status_events WHERE created_at < ?)
+OR spec_event_id IN (SELECT id FROM events WHERE created_at < ?)`, before, before)
+	if result.Error != nil {
+		db.MarkForRollback(ctx, result.Error)
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
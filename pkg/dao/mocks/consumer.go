@@ -2,12 +2,14 @@ package mocks
 
 import (
 	"context"
+	"sort"
+	"strings"
 
 	"gorm.io/gorm"
+	"k8s.io/apimachinery/pkg/labels"
 
 	"github.com/openshift-online/maestro/pkg/api"
 	"github.com/openshift-online/maestro/pkg/dao"
-	"github.com/openshift-online/maestro/pkg/errors"
 )
 
 var _ dao.ConsumerDao = &consumerDaoMock{}
@@ -35,17 +37,129 @@ func (d *consumerDaoMock) Create(ctx context.Context, consumer *api.Consumer) (*
 }
 
 func (d *consumerDaoMock) Replace(ctx context.Context, consumer *api.Consumer) (*api.Consumer, error) {
-	return nil, errors.NotImplemented("Consumer").AsError()
+	for i, c := range d.consumers {
+		if c.ID == consumer.ID {
+			d.consumers[i] = consumer
+			return consumer, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
 }
 
 func (d *consumerDaoMock) Delete(ctx context.Context, id string, unscoped bool) error {
-	return errors.NotImplemented("Consumer").AsError()
+	consumers := api.ConsumerList{}
+	for _, consumer := range d.consumers {
+		if consumer.ID != id {
+			consumers = append(consumers, consumer)
+		}
+	}
+	d.consumers = consumers
+	return nil
 }
 
 func (d *consumerDaoMock) FindByIDs(ctx context.Context, ids []string) (api.ConsumerList, error) {
-	return nil, errors.NotImplemented("Consumer").AsError()
+	consumers := api.ConsumerList{}
+	for _, consumer := range d.consumers {
+		for _, id := range ids {
+			if consumer.ID == id {
+				consumers = append(consumers, consumer)
+				break
+			}
+		}
+	}
+	return consumers, nil
+}
+
+func (d *consumerDaoMock) FindByNames(ctx context.Context, names []string) (api.ConsumerList, error) {
+	consumers := api.ConsumerList{}
+	for _, consumer := range d.consumers {
+		for _, name := range names {
+			if consumer.Name == name {
+				consumers = append(consumers, consumer)
+				break
+			}
+		}
+	}
+	return consumers, nil
 }
 
 func (d *consumerDaoMock) All(ctx context.Context) (api.ConsumerList, error) {
 	return d.consumers, nil
 }
+
+func (d *consumerDaoMock) FindByGroup(ctx context.Context, group string) (api.ConsumerList, error) {
+	consumers := api.ConsumerList{}
+	for _, consumer := range d.consumers {
+		if consumer.Labels == nil {
+			continue
+		}
+		if _, ok := (*consumer.Labels)[api.ConsumerGroupLabel(group)]; ok {
+			consumers = append(consumers, consumer)
+		}
+	}
+	sort.Slice(consumers, func(i, j int) bool { return consumers[i].Name < consumers[j].Name })
+	return consumers, nil
+}
+
+func (d *consumerDaoMock) Groups(ctx context.Context, page int, size int64) (api.ConsumerGroupList, int64, error) {
+	counts := map[string]int{}
+	for _, consumer := range d.consumers {
+		for _, group := range consumer.Groups() {
+			counts[group]++
+		}
+	}
+	groups := api.ConsumerGroupList{}
+	for name, count := range counts {
+		groups = append(groups, &api.ConsumerGroup{Name: name, ConsumerCount: count})
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+	total := int64(len(groups))
+	start := int64(page-1) * size
+	if start > total {
+		start = total
+	}
+	end := start + size
+	if end > total {
+		end = total
+	}
+	return groups[start:end], total, nil
+}
+
+func (d *consumerDaoMock) List(ctx context.Context, opts dao.ConsumerListOptions) (*dao.ConsumerPage, error) {
+	after := ""
+	if opts.Cursor != "" {
+		name, err := dao.ParseConsumerCursor(opts.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		after = name
+	}
+
+	matched := api.ConsumerList{}
+	for _, consumer := range d.consumers {
+		if !strings.HasPrefix(consumer.Name, opts.NamePrefix) {
+			continue
+		}
+		consumerLabels := labels.Set{}
+		if consumer.Labels != nil {
+			consumerLabels = labels.Set(*consumer.Labels)
+		}
+		if opts.LabelSelector != nil && !opts.LabelSelector.Matches(consumerLabels) {
+			continue
+		}
+		matched = append(matched, consumer)
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].Name < matched[j].Name })
+
+	page := &dao.ConsumerPage{Items: api.ConsumerList{}, Total: int64(len(matched))}
+	for _, consumer := range matched {
+		if consumer.Name > after {
+			page.Items = append(page.Items, consumer)
+		}
+	}
+	if opts.Limit > 0 && len(page.Items) > opts.Limit {
+		page.Items = page.Items[:opts.Limit]
+		page.NextCursor = dao.NewConsumerCursor(page.Items[opts.Limit-1].Name)
+	}
+	return page, nil
+}
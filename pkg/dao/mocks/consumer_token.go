@@ -0,0 +1,57 @@
+package mocks
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/dao"
+)
+
+var _ dao.ConsumerTokenDao = &consumerTokenDaoMock{}
+
+type consumerTokenDaoMock struct {
+	tokens api.ConsumerTokenList
+}
+
+func NewConsumerTokenDao() *consumerTokenDaoMock {
+	return &consumerTokenDaoMock{}
+}
+
+func (d *consumerTokenDaoMock) Get(ctx context.Context, id string) (*api.ConsumerToken, error) {
+	for _, token := range d.tokens {
+		if token.ID == id {
+			return token, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (d *consumerTokenDaoMock) Create(ctx context.Context, token *api.ConsumerToken) (*api.ConsumerToken, error) {
+	if token.ID == "" {
+		token.ID = api.NewID()
+	}
+	d.tokens = append(d.tokens, token)
+	return token, nil
+}
+
+func (d *consumerTokenDaoMock) Replace(ctx context.Context, token *api.ConsumerToken) (*api.ConsumerToken, error) {
+	for i, t := range d.tokens {
+		if t.ID == token.ID {
+			d.tokens[i] = token
+			return token, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (d *consumerTokenDaoMock) FindByConsumerID(ctx context.Context, consumerID string) (api.ConsumerTokenList, error) {
+	tokens := api.ConsumerTokenList{}
+	for i := len(d.tokens) - 1; i >= 0; i-- {
+		if d.tokens[i].ConsumerID == consumerID {
+			tokens = append(tokens, d.tokens[i])
+		}
+	}
+	return tokens, nil
+}
@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"gorm.io/gorm"
 
@@ -98,3 +99,16 @@ func (d *eventDaoMock) FindAllUnreconciledEvents(ctx context.Context) (api.Event
 
 	return filteredEvents, nil
 }
+
+func (d *eventDaoMock) DeleteCreatedBefore(ctx context.Context, before time.Time) (int64, error) {
+	events := api.EventList{}
+	for _, e := range d.events {
+		if e.CreatedAt.Before(before) {
+			continue
+		}
+		events = append(events, e)
+	}
+	deleted := int64(len(d.events) - len(events))
+	d.events = events
+	return deleted, nil
+}
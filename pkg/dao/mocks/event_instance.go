@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/openshift-online/maestro/pkg/api"
 	"github.com/openshift-online/maestro/pkg/dao"
@@ -74,3 +75,8 @@ func (d *eventInstanceDaoMock) GetEventsAssociatedWithInstances(ctx context.Cont
 
 	return eventIDs, nil
 }
+
+// DeleteByEventsCreatedBefore is not supported by the mock, the event instances don't record the event creation time.
+func (d *eventInstanceDaoMock) DeleteByEventsCreatedBefore(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
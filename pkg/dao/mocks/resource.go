@@ -2,9 +2,14 @@ package mocks
 
 import (
 	"context"
+	"slices"
+	"sort"
+	"time"
 
 	"github.com/openshift-online/maestro/pkg/dao"
 
+	"github.com/lib/pq"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 
 	"github.com/openshift-online/maestro/pkg/api"
@@ -15,12 +20,20 @@ var _ dao.ResourceDao = &resourceDaoMock{}
 
 type resourceDaoMock struct {
 	resources api.ResourceList
+	// consumerDao finds the orphaned resources, no resource is orphaned without it
+	consumerDao dao.ConsumerDao
 }
 
 func NewResourceDao() *resourceDaoMock {
 	return &resourceDaoMock{}
 }
 
+// NewResourceDaoWithConsumers returns a resource DAO mock whose resources are orphaned if their consumers are not in
+// the consumer DAO.
+func NewResourceDaoWithConsumers(consumerDao dao.ConsumerDao) *resourceDaoMock {
+	return &resourceDaoMock{consumerDao: consumerDao}
+}
+
 func (d *resourceDaoMock) Get(ctx context.Context, id string) (*api.Resource, error) {
 	for _, resource := range d.resources {
 		if resource.ID == id {
@@ -30,17 +43,62 @@ func (d *resourceDaoMock) Get(ctx context.Context, id string) (*api.Resource, er
 	return nil, gorm.ErrRecordNotFound
 }
 
+func (d *resourceDaoMock) GetByName(ctx context.Context, name string) (*api.Resource, error) {
+	for _, resource := range d.resources {
+		if resource.Name == name {
+			return resource, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
 func (d *resourceDaoMock) Create(ctx context.Context, resource *api.Resource) (*api.Resource, error) {
 	d.resources = append(d.resources, resource)
 	return resource, nil
 }
 
+func (d *resourceDaoMock) BatchCreate(ctx context.Context, resources api.ResourceList) (api.ResourceList, error) {
+	d.resources = append(d.resources, resources...)
+	return resources, nil
+}
+
 func (d *resourceDaoMock) Update(ctx context.Context, resource *api.Resource) (*api.Resource, error) {
-	return nil, errors.NotImplemented("Resource").AsError()
+	for i, found := range d.resources {
+		if found.ID == resource.ID {
+			d.resources[i] = resource
+			return resource, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (d *resourceDaoMock) UpdateStatus(ctx context.Context, id string, version int64, status datatypes.JSONMap,
+	conditions pq.StringArray) (bool, error) {
+	for _, resource := range d.resources {
+		if resource.ID == id {
+			if resource.Version != version {
+				return false, nil
+			}
+			resource.Status = status
+			resource.Conditions = conditions
+			return true, nil
+		}
+	}
+	return false, nil
 }
 
 func (d *resourceDaoMock) Delete(ctx context.Context, id string, unscoped bool) error {
-	return errors.NotImplemented("Resource").AsError()
+	for i, resource := range d.resources {
+		if resource.ID == id {
+			if unscoped {
+				d.resources = append(d.resources[:i], d.resources[i+1:]...)
+				return nil
+			}
+			resource.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+			return nil
+		}
+	}
+	return nil
 }
 
 func (d *resourceDaoMock) FindByIDs(ctx context.Context, ids []string) (api.ResourceList, error) {
@@ -71,6 +129,24 @@ func (d *resourceDaoMock) FindByConsumerNameAndResourceType(ctx context.Context,
 	return resources, nil
 }
 
+func (d *resourceDaoMock) FindActiveByConsumerName(ctx context.Context, consumerName string, resourceType api.ResourceType, afterID string, limit int) (api.ResourceList, error) {
+	resources := api.ResourceList{}
+	for _, resource := range d.resources {
+		if resource.ConsumerName != consumerName || !resource.DeletedAt.Time.IsZero() || resource.ID <= afterID {
+			continue
+		}
+		if len(resourceType) != 0 && resource.Type != resourceType {
+			continue
+		}
+		resources = append(resources, resource)
+	}
+	sort.Slice(resources, func(i, j int) bool { return resources[i].ID < resources[j].ID })
+	if len(resources) > limit {
+		resources = resources[:limit]
+	}
+	return resources, nil
+}
+
 func (d *resourceDaoMock) FindBySource(ctx context.Context, source string) (api.ResourceList, error) {
 	var resources api.ResourceList
 	for _, resource := range d.resources {
@@ -81,6 +157,79 @@ func (d *resourceDaoMock) FindBySource(ctx context.Context, source string) (api.
 	return resources, nil
 }
 
+func (d *resourceDaoMock) FindOrphaned(ctx context.Context, resourceType api.ResourceType, page int, size int64) (api.ResourceList, int64, error) {
+	resources := api.ResourceList{}
+	if d.consumerDao == nil {
+		return resources, 0, nil
+	}
+	consumers, err := d.consumerDao.All(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	names := map[string]bool{}
+	for _, consumer := range consumers {
+		names[consumer.Name] = true
+	}
+	for _, resource := range d.resources {
+		if resource.Type != resourceType || resource.DeletedAt.Valid || names[resource.ConsumerName] {
+			continue
+		}
+		resources = append(resources, resource)
+	}
+	sort.Slice(resources, func(i, j int) bool { return resources[i].ID < resources[j].ID })
+	total := int64(len(resources))
+	start := int64(page-1) * size
+	if start > total {
+		start = total
+	}
+	end := start + size
+	if end > total {
+		end = total
+	}
+	return resources[start:end], total, nil
+}
+
+func (d *resourceDaoMock) CountVersionDrift(ctx context.Context, updatedBefore time.Time) (map[api.VersionDriftState]int, error) {
+	counts := map[api.VersionDriftState]int{}
+	for _, resource := range d.resources {
+		if resource.DeletedAt.Valid {
+			continue
+		}
+		// a non-numeric observed version is treated as unreported, as the resource_version_drifts view does
+		observedVersion, _ := api.ObservedVersion(resource.Status)
+		driftState := api.VersionDriftStateOf(resource.Version, observedVersion)
+		if driftState == "" || (driftState != api.VersionDriftAhead && !resource.UpdatedAt.Before(updatedBefore)) {
+			continue
+		}
+		counts[driftState]++
+	}
+	return counts, nil
+}
+
+func (d *resourceDaoMock) CountByConsumer(ctx context.Context) (map[string]int, error) {
+	counts := map[string]int{}
+	for _, resource := range d.resources {
+		if !resource.DeletedAt.Valid {
+			counts[resource.ConsumerName]++
+		}
+	}
+	return counts, nil
+}
+
+func (d *resourceDaoMock) CountDeletingByConsumer(ctx context.Context, deletedBefore time.Time) (map[api.ResourceType]map[string]int, error) {
+	counts := map[api.ResourceType]map[string]int{}
+	for _, resource := range d.resources {
+		if !resource.DeletedAt.Valid || !resource.DeletedAt.Time.Before(deletedBefore) {
+			continue
+		}
+		if counts[resource.Type] == nil {
+			counts[resource.Type] = map[string]int{}
+		}
+		counts[resource.Type][resource.ConsumerName]++
+	}
+	return counts, nil
+}
+
 func (d *resourceDaoMock) All(ctx context.Context) (api.ResourceList, error) {
 	return d.resources, nil
 }
@@ -88,3 +237,71 @@ func (d *resourceDaoMock) All(ctx context.Context) (api.ResourceList, error) {
 func (d *resourceDaoMock) FirstByConsumerName(ctx context.Context, consumerName string, unscoped bool) (api.Resource, error) {
 	return *d.resources[0], errors.NotImplemented("Resource").AsError()
 }
+
+func (d *resourceDaoMock) MarkDispatched(ctx context.Context, id, instanceID string, dispatchedAt time.Time) error {
+	for _, resource := range d.resources {
+		if resource.ID == id {
+			resource.LastDispatchedBy = instanceID
+			resource.LastDispatchedAt = &dispatchedAt
+			return nil
+		}
+	}
+	return gorm.ErrRecordNotFound
+}
+
+func (d *resourceDaoMock) UpdateQuarantine(ctx context.Context, id string, reconcileFailures int32, quarantinedAt *time.Time) error {
+	for _, resource := range d.resources {
+		if resource.ID == id {
+			resource.ReconcileFailures = reconcileFailures
+			resource.QuarantinedAt = quarantinedAt
+			return nil
+		}
+	}
+	return gorm.ErrRecordNotFound
+}
+
+func (d *resourceDaoMock) CountQuarantined(ctx context.Context) (map[api.ResourceType]int, error) {
+	counts := map[api.ResourceType]int{}
+	for _, resource := range d.resources {
+		if !resource.DeletedAt.Valid && resource.IsQuarantined() {
+			counts[resource.Type]++
+		}
+	}
+	return counts, nil
+}
+
+func (d *resourceDaoMock) MarkReconcileStale(ctx context.Context, specUpdatedBefore time.Time) (int64, error) {
+	marked := int64(0)
+	for _, resource := range d.resources {
+		if resource.DeletedAt.Valid || resource.SpecUpdatedAt == nil || !resource.SpecUpdatedAt.Before(specUpdatedBefore) {
+			continue
+		}
+		if observedVersion, _ := api.ObservedVersion(resource.Status); observedVersion >= resource.Version {
+			continue
+		}
+		if slices.Contains(resource.Conditions, api.ReconcileStaleCondition) {
+			continue
+		}
+		resource.Conditions = append(resource.Conditions, api.ReconcileStaleCondition)
+		marked++
+	}
+	return marked, nil
+}
+
+func (d *resourceDaoMock) UpdateSource(ctx context.Context, id, source string) error {
+	for _, resource := range d.resources {
+		if resource.ID == id {
+			resource.Source = source
+			return nil
+		}
+	}
+	return gorm.ErrRecordNotFound
+}
+
+func (d *resourceDaoMock) LoadPayloads(ctx context.Context, resources api.ResourceList) error {
+	return nil
+}
+
+func (d *resourceDaoMock) DeleteUnreferencedPayloads(ctx context.Context, modifiedBefore time.Time) (int, error) {
+	return 0, nil
+}
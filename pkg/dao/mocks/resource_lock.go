@@ -0,0 +1,72 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/dao"
+)
+
+var _ dao.ResourceLockDao = &resourceLockDaoMock{}
+
+type resourceLockDaoMock struct {
+	locks []*api.ResourceLock
+}
+
+func NewResourceLockDao() *resourceLockDaoMock {
+	return &resourceLockDaoMock{}
+}
+
+func (d *resourceLockDaoMock) GetByResourceID(ctx context.Context, resourceID string) (*api.ResourceLock, error) {
+	for _, lock := range d.locks {
+		if lock.ResourceID == resourceID {
+			return lock, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (d *resourceLockDaoMock) Create(ctx context.Context, lock *api.ResourceLock) (*api.ResourceLock, error) {
+	if lock.ID == "" {
+		lock.ID = api.NewID()
+	}
+	d.locks = append(d.locks, lock)
+	return lock, nil
+}
+
+func (d *resourceLockDaoMock) Replace(ctx context.Context, lock *api.ResourceLock) (*api.ResourceLock, error) {
+	for i, l := range d.locks {
+		if l.ID == lock.ID {
+			d.locks[i] = lock
+			return lock, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (d *resourceLockDaoMock) Delete(ctx context.Context, id string) error {
+	locks := []*api.ResourceLock{}
+	for _, lock := range d.locks {
+		if lock.ID != id {
+			locks = append(locks, lock)
+		}
+	}
+	d.locks = locks
+	return nil
+}
+
+func (d *resourceLockDaoMock) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	locks := []*api.ResourceLock{}
+	for _, lock := range d.locks {
+		if lock.IsExpired(before) {
+			continue
+		}
+		locks = append(locks, lock)
+	}
+	deleted := int64(len(d.locks) - len(locks))
+	d.locks = locks
+	return deleted, nil
+}
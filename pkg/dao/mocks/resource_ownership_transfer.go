@@ -0,0 +1,47 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/dao"
+)
+
+var _ dao.ResourceOwnershipTransferDao = &resourceOwnershipTransferDaoMock{}
+
+type resourceOwnershipTransferDaoMock struct {
+	transfers api.ResourceOwnershipTransferList
+}
+
+func NewResourceOwnershipTransferDao() *resourceOwnershipTransferDaoMock {
+	return &resourceOwnershipTransferDaoMock{}
+}
+
+func (d *resourceOwnershipTransferDaoMock) Create(ctx context.Context, transfer *api.ResourceOwnershipTransfer) (*api.ResourceOwnershipTransfer, error) {
+	if transfer.ID == "" {
+		transfer.ID = api.NewID()
+	}
+	transfer.CreatedAt = time.Now()
+	d.transfers = append(d.transfers, transfer)
+	return transfer, nil
+}
+
+func (d *resourceOwnershipTransferDaoMock) FindByResourceID(ctx context.Context, resourceID string, page int, size int64) (api.ResourceOwnershipTransferList, int64, error) {
+	transfers := api.ResourceOwnershipTransferList{}
+	for i := len(d.transfers) - 1; i >= 0; i-- {
+		if d.transfers[i].ResourceID == resourceID {
+			transfers = append(transfers, d.transfers[i])
+		}
+	}
+	total := int64(len(transfers))
+	start := int64(page-1) * size
+	if start >= total {
+		return api.ResourceOwnershipTransferList{}, total, nil
+	}
+	end := start + size
+	if end > total {
+		end = total
+	}
+	return transfers[start:end], total, nil
+}
@@ -0,0 +1,61 @@
+package mocks
+
+import (
+	"context"
+	"math"
+
+	"gorm.io/gorm"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/dao"
+)
+
+var _ dao.ResourceRevisionDao = &resourceRevisionDaoMock{}
+
+type resourceRevisionDaoMock struct {
+	revisions api.ResourceRevisionList
+}
+
+func NewResourceRevisionDao() *resourceRevisionDaoMock {
+	return &resourceRevisionDaoMock{}
+}
+
+func (d *resourceRevisionDaoMock) Get(ctx context.Context, resourceID string, version int64) (*api.ResourceRevision, error) {
+	for _, revision := range d.revisions {
+		if revision.ResourceID == resourceID && revision.Version == version {
+			return revision, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (d *resourceRevisionDaoMock) Create(ctx context.Context, revision *api.ResourceRevision) (*api.ResourceRevision, error) {
+	d.revisions = append(d.revisions, revision)
+	return revision, nil
+}
+
+func (d *resourceRevisionDaoMock) FindByResourceID(ctx context.Context, resourceID string) (api.ResourceRevisionList, error) {
+	revisions := api.ResourceRevisionList{}
+	for i := len(d.revisions) - 1; i >= 0; i-- {
+		if d.revisions[i].ResourceID == resourceID {
+			revisions = append(revisions, d.revisions[i])
+		}
+	}
+	return revisions, nil
+}
+
+func (d *resourceRevisionDaoMock) DeleteBeforeVersion(ctx context.Context, resourceID string, version int64) error {
+	revisions := api.ResourceRevisionList{}
+	for _, revision := range d.revisions {
+		if revision.ResourceID == resourceID && revision.Version < version {
+			continue
+		}
+		revisions = append(revisions, revision)
+	}
+	d.revisions = revisions
+	return nil
+}
+
+func (d *resourceRevisionDaoMock) DeleteByResourceID(ctx context.Context, resourceID string) error {
+	return d.DeleteBeforeVersion(ctx, resourceID, math.MaxInt64)
+}
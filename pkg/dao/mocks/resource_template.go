@@ -0,0 +1,58 @@
+package mocks
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/dao"
+)
+
+var _ dao.ResourceTemplateDao = &resourceTemplateDaoMock{}
+
+type resourceTemplateDaoMock struct {
+	templates api.ResourceTemplateList
+}
+
+func NewResourceTemplateDao() *resourceTemplateDaoMock {
+	return &resourceTemplateDaoMock{}
+}
+
+func (d *resourceTemplateDaoMock) Get(ctx context.Context, id string) (*api.ResourceTemplate, error) {
+	for _, template := range d.templates {
+		if template.ID == id {
+			return template, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (d *resourceTemplateDaoMock) Create(ctx context.Context, template *api.ResourceTemplate) (*api.ResourceTemplate, error) {
+	for _, existing := range d.templates {
+		if existing.Name == template.Name {
+			return nil, fmt.Errorf("duplicate key value violates unique constraint \"idx_resource_templates_name\"")
+		}
+	}
+	if template.ID == "" {
+		template.ID = api.NewID()
+	}
+	d.templates = append(d.templates, template)
+	return template, nil
+}
+
+func (d *resourceTemplateDaoMock) Delete(ctx context.Context, id string) error {
+	templates := api.ResourceTemplateList{}
+	for _, template := range d.templates {
+		if template.ID != id {
+			templates = append(templates, template)
+		}
+	}
+	d.templates = templates
+	return nil
+}
+
+func (d *resourceTemplateDaoMock) All(ctx context.Context) (api.ResourceTemplateList, error) {
+	return d.templates, nil
+}
@@ -0,0 +1,109 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/dao"
+)
+
+var _ dao.StatusEventDao = &statusEventDaoMock{}
+
+type statusEventDaoMock struct {
+	statusEvents api.StatusEventList
+}
+
+func NewStatusEventDao() *statusEventDaoMock {
+	return &statusEventDaoMock{}
+}
+
+func (d *statusEventDaoMock) Get(ctx context.Context, id string) (*api.StatusEvent, error) {
+	for _, e := range d.statusEvents {
+		if e.ID == id {
+			return e, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (d *statusEventDaoMock) Create(ctx context.Context, statusEvent *api.StatusEvent) (*api.StatusEvent, error) {
+	d.statusEvents = append(d.statusEvents, statusEvent)
+	return statusEvent, nil
+}
+
+func (d *statusEventDaoMock) Replace(ctx context.Context, statusEvent *api.StatusEvent) (*api.StatusEvent, error) {
+	for i, e := range d.statusEvents {
+		if e.ID == statusEvent.ID {
+			d.statusEvents[i] = statusEvent
+			return statusEvent, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (d *statusEventDaoMock) Delete(ctx context.Context, id string) error {
+	return d.DeleteAllEvents(ctx, []string{id})
+}
+
+func (d *statusEventDaoMock) FindByIDs(ctx context.Context, ids []string) (api.StatusEventList, error) {
+	statusEvents := api.StatusEventList{}
+	for _, id := range ids {
+		for _, e := range d.statusEvents {
+			if e.ID == id {
+				statusEvents = append(statusEvents, e)
+			}
+		}
+	}
+	return statusEvents, nil
+}
+
+func (d *statusEventDaoMock) All(ctx context.Context) (api.StatusEventList, error) {
+	return d.statusEvents, nil
+}
+
+func (d *statusEventDaoMock) DeleteAllReconciledEvents(ctx context.Context) error {
+	statusEvents := api.StatusEventList{}
+	for _, e := range d.statusEvents {
+		if e.ReconciledDate == nil {
+			statusEvents = append(statusEvents, e)
+		}
+	}
+	d.statusEvents = statusEvents
+	return nil
+}
+
+func (d *statusEventDaoMock) DeleteAllEvents(ctx context.Context, eventIDs []string) error {
+	statusEvents := api.StatusEventList{}
+	for _, e := range d.statusEvents {
+		if !contains(eventIDs, e.ID) {
+			statusEvents = append(statusEvents, e)
+		}
+	}
+	d.statusEvents = statusEvents
+	return nil
+}
+
+func (d *statusEventDaoMock) FindAllUnreconciledEvents(ctx context.Context) (api.StatusEventList, error) {
+	statusEvents := api.StatusEventList{}
+	for _, e := range d.statusEvents {
+		if e.ReconciledDate == nil {
+			statusEvents = append(statusEvents, e)
+		}
+	}
+	return statusEvents, nil
+}
+
+func (d *statusEventDaoMock) DeleteCreatedBefore(ctx context.Context, before time.Time) (int64, error) {
+	statusEvents := api.StatusEventList{}
+	for _, e := range d.statusEvents {
+		if !e.CreatedAt.Before(before) {
+			statusEvents = append(statusEvents, e)
+		}
+	}
+	deleted := int64(len(d.statusEvents) - len(statusEvents))
+	d.statusEvents = statusEvents
+	return deleted, nil
+}
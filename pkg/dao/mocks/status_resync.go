@@ -0,0 +1,94 @@
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"gorm.io/gorm"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/dao"
+)
+
+var _ dao.StatusResyncDao = &statusResyncDaoMock{}
+
+// statusResyncDaoMock is shared by a running resync and its callers, so it is guarded by a mutex and returns copies.
+type statusResyncDaoMock struct {
+	mu      sync.Mutex
+	resyncs []*api.StatusResync
+}
+
+func NewStatusResyncDao() *statusResyncDaoMock {
+	return &statusResyncDaoMock{}
+}
+
+func (d *statusResyncDaoMock) Get(ctx context.Context, id string) (*api.StatusResync, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, resync := range d.resyncs {
+		if resync.ID == id {
+			found := *resync
+			return &found, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (d *statusResyncDaoMock) Latest(ctx context.Context) (*api.StatusResync, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var latest *api.StatusResync
+	for _, resync := range d.resyncs {
+		if latest == nil || !resync.StartedAt.Before(latest.StartedAt) {
+			latest = resync
+		}
+	}
+	if latest == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	found := *latest
+	return &found, nil
+}
+
+func (d *statusResyncDaoMock) Create(ctx context.Context, resync *api.StatusResync) (*api.StatusResync, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if resync.ID == "" {
+		resync.ID = api.NewID()
+	}
+	created := *resync
+	d.resyncs = append(d.resyncs, &created)
+	return resync, nil
+}
+
+func (d *statusResyncDaoMock) UpdateProgress(ctx context.Context, resync *api.StatusResync) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, r := range d.resyncs {
+		if r.ID == resync.ID {
+			r.State = resync.State
+			r.Processed = resync.Processed
+			r.Failed = resync.Failed
+			r.FinishedAt = resync.FinishedAt
+			return nil
+		}
+	}
+	return gorm.ErrRecordNotFound
+}
+
+func (d *statusResyncDaoMock) RequestCancel(ctx context.Context, id string) (bool, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, r := range d.resyncs {
+		if r.ID == id && r.State == api.StatusResyncRunning {
+			r.CancelRequested = true
+			return true, nil
+		}
+	}
+	return false, nil
+}
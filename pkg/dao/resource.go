@@ -2,61 +2,214 @@ package dao
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
 
+	"github.com/lib/pq"
+	"golang.org/x/sync/errgroup"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
 	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/client/objectstore"
 	"github.com/openshift-online/maestro/pkg/db"
+	"github.com/openshift-online/maestro/pkg/logger"
 )
 
 type ResourceDao interface {
 	Get(ctx context.Context, id string) (*api.Resource, error)
+	// GetByName returns the resource with the given name, including the resource that is marked as deleting.
+	GetByName(ctx context.Context, name string) (*api.Resource, error)
 	Create(ctx context.Context, resource *api.Resource) (*api.Resource, error)
+	// BatchCreate creates the given resources in a single statement, so either all or none of the resources are
+	// created.
+	BatchCreate(ctx context.Context, resources api.ResourceList) (api.ResourceList, error)
 	Update(ctx context.Context, resource *api.Resource) (*api.Resource, error)
+	// UpdateStatus updates the status and the conditions summary of the resource if it is still at the given version,
+	// the spec of the resource is never written, so a status update can't overwrite a concurrent spec update. It
+	// returns false if the resource is not at the version.
+	UpdateStatus(ctx context.Context, id string, version int64, status datatypes.JSONMap, conditions pq.StringArray) (bool, error)
 	Delete(ctx context.Context, id string, unscoped bool) error
 	FindByIDs(ctx context.Context, ids []string) (api.ResourceList, error)
 	FindBySource(ctx context.Context, source string) (api.ResourceList, error)
 	FindByConsumerName(ctx context.Context, consumerName string) (api.ResourceList, error)
 	FindByConsumerNameAndResourceType(ctx context.Context, consumerName string, resourceType api.ResourceType) (api.ResourceList, error)
+	// FindOrphaned returns a page of the resources that are not marked as deleting and whose consumer doesn't exist,
+	// e.g. the consumer is deleted by bypassing the consumer service, and the total number of these resources.
+	FindOrphaned(ctx context.Context, resourceType api.ResourceType, page int, size int64) (api.ResourceList, int64, error)
+	// CountVersionDrift counts the resources whose observed version drifts from the resource version by the drift
+	// state, the resources that are ahead are always counted, the others are counted only if they are not updated
+	// since the given time.
+	CountVersionDrift(ctx context.Context, updatedBefore time.Time) (map[api.VersionDriftState]int, error)
+	// CountByConsumer counts the resources that are not marked as deleting by the consumer name.
+	CountByConsumer(ctx context.Context) (map[string]int, error)
+	// CountDeletingByConsumer counts the resources that are marked as deleting before the given time but not yet
+	// confirmed deleted by the agent, by the resource type and the consumer name.
+	CountDeletingByConsumer(ctx context.Context, deletedBefore time.Time) (map[api.ResourceType]map[string]int, error)
+	// FindActiveByConsumerName returns a page of the resources of the consumer that are not marked as deleting, the
+	// resources are ordered by ID and the page starts after the given resource ID.
+	FindActiveByConsumerName(ctx context.Context, consumerName string, resourceType api.ResourceType, afterID string, limit int) (api.ResourceList, error)
 	All(ctx context.Context) (api.ResourceList, error)
 	FirstByConsumerName(ctx context.Context, name string, unscoped bool) (api.Resource, error)
+	// MarkDispatched records the instance that dispatched the resource status, the resource version and update
+	// time are not changed.
+	MarkDispatched(ctx context.Context, id, instanceID string, dispatchedAt time.Time) error
+	// UpdateSource reassigns the source of the resource, the resource version and update time are not changed.
+	UpdateSource(ctx context.Context, id, source string) error
+	// UpdateQuarantine records the consecutive reconcile failures of the resource and when it is quarantined (nil if
+	// it is not quarantined), the resource version and update time are not changed.
+	UpdateQuarantine(ctx context.Context, id string, reconcileFailures int32, quarantinedAt *time.Time) error
+	// CountQuarantined counts the quarantined resources that are not marked as deleting by the resource type.
+	CountQuarantined(ctx context.Context) (map[api.ResourceType]int, error)
+	// MarkReconcileStale adds the ReconcileStaleCondition to the conditions summary of the resources that are not
+	// marked as deleting, whose observed version is behind the resource version and whose spec is not changed since
+	// the given time. It returns the number of the newly marked resources.
+	MarkReconcileStale(ctx context.Context, specUpdatedBefore time.Time) (int64, error)
+	// LoadPayloads fetches the offloaded payloads of the resources that are not read by this DAO (e.g. listed by the
+	// generic DAO) back from the object store, the payloads are fetched concurrently.
+	LoadPayloads(ctx context.Context, resources api.ResourceList) error
+	// DeleteUnreferencedPayloads deletes the offloaded payloads that are last modified before the given time and are
+	// not referenced by any resource, e.g. the payloads put by a rolled back transaction or the payloads of the
+	// deleted resources whose deletion failed. It returns the number of the deleted payloads.
+	DeleteUnreferencedPayloads(ctx context.Context, modifiedBefore time.Time) (int, error)
 }
 
+// maxConcurrentPayloadLoads is the max number of the offloaded payloads fetched concurrently by LoadPayloads.
+const maxConcurrentPayloadLoads = 8
+
 var _ ResourceDao = &sqlResourceDao{}
 
 type sqlResourceDao struct {
 	sessionFactory *db.SessionFactory
+
+	// payloadStore keeps the payloads of the resource bundles that are larger than the payloadOffloadThreshold (in
+	// bytes), nil disables the offloading.
+	payloadStore            objectstore.Client
+	payloadOffloadThreshold int
 }
 
 func NewResourceDao(sessionFactory *db.SessionFactory) ResourceDao {
 	return &sqlResourceDao{sessionFactory: sessionFactory}
 }
 
+// NewResourceDaoWithPayloadStore returns a resource DAO that offloads the payloads of the resource bundles that are
+// larger than the threshold (in bytes) to the payload store, and keeps the smaller payloads in the database.
+func NewResourceDaoWithPayloadStore(sessionFactory *db.SessionFactory, payloadStore objectstore.Client, threshold int) ResourceDao {
+	return &sqlResourceDao{
+		sessionFactory:          sessionFactory,
+		payloadStore:            payloadStore,
+		payloadOffloadThreshold: threshold,
+	}
+}
+
 func (d *sqlResourceDao) Get(ctx context.Context, id string) (*api.Resource, error) {
 	g2 := (*d.sessionFactory).New(ctx)
 	var resource api.Resource
 	if err := g2.Unscoped().Take(&resource, "id = ?", id).Error; err != nil {
 		return nil, err
 	}
+	if err := d.loadPayload(ctx, &resource); err != nil {
+		return nil, err
+	}
+	return &resource, nil
+}
+
+func (d *sqlResourceDao) GetByName(ctx context.Context, name string) (*api.Resource, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	var resource api.Resource
+	if err := g2.Unscoped().Take(&resource, "name = ?", name).Error; err != nil {
+		return nil, err
+	}
+	if err := d.loadPayload(ctx, &resource); err != nil {
+		return nil, err
+	}
 	return &resource, nil
 }
 
 func (d *sqlResourceDao) Create(ctx context.Context, resource *api.Resource) (*api.Resource, error) {
+	row, err := d.offloadPayload(ctx, resource)
+	if err != nil {
+		return nil, err
+	}
+
+	g2 := (*d.sessionFactory).New(ctx)
+	if err := g2.Omit(clause.Associations).Create(row).Error; err != nil {
+		db.MarkForRollback(ctx, err)
+		return nil, err
+	}
+	return restorePayload(resource, row), nil
+}
+
+func (d *sqlResourceDao) BatchCreate(ctx context.Context, resources api.ResourceList) (api.ResourceList, error) {
+	rows := make(api.ResourceList, 0, len(resources))
+	for _, resource := range resources {
+		row, err := d.offloadPayload(ctx, resource)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+
 	g2 := (*d.sessionFactory).New(ctx)
-	if err := g2.Omit(clause.Associations).Create(resource).Error; err != nil {
+	if err := g2.Omit(clause.Associations).Create(&rows).Error; err != nil {
 		db.MarkForRollback(ctx, err)
 		return nil, err
 	}
-	return resource, nil
+	for i, resource := range resources {
+		restorePayload(resource, rows[i])
+	}
+	return resources, nil
 }
 
 func (d *sqlResourceDao) Update(ctx context.Context, resource *api.Resource) (*api.Resource, error) {
+	row, err := d.offloadPayload(ctx, resource)
+	if err != nil {
+		return nil, err
+	}
+
 	g2 := (*d.sessionFactory).New(ctx)
-	if err := g2.Omit(clause.Associations).Updates(resource).Error; err != nil {
+	if err := g2.Omit(clause.Associations).Updates(row).Error; err != nil {
 		db.MarkForRollback(ctx, err)
 		return nil, err
 	}
-	return resource, nil
+	if len(row.PayloadRef) == 0 && len(resource.PayloadRef) != 0 {
+		// the payload is no longer offloaded, the zero value is not updated by the Updates.
+		if err := g2.Model(row).UpdateColumn("payload_ref", "").Error; err != nil {
+			db.MarkForRollback(ctx, err)
+			return nil, err
+		}
+	}
+	if !row.DeleteOptionResolved {
+		// the delete option may be set by the source now, the zero value is not updated by the Updates.
+		if err := g2.Model(row).UpdateColumn("delete_option_resolved", false).Error; err != nil {
+			db.MarkForRollback(ctx, err)
+			return nil, err
+		}
+	}
+	if previous := resource.PayloadRef; len(previous) != 0 && previous != row.PayloadRef {
+		// the previous payload is still referenced until the update is committed
+		db.AfterCommit(ctx, func() { d.deletePayload(ctx, previous) })
+	}
+	return restorePayload(resource, row), nil
+}
+
+func (d *sqlResourceDao) UpdateStatus(ctx context.Context, id string, version int64, status datatypes.JSONMap,
+	conditions pq.StringArray) (bool, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	result := g2.Model(&api.Resource{}).Where("id = ? AND version = ?", id, version).Updates(map[string]interface{}{
+		"status":     status,
+		"conditions": conditions,
+	})
+	if result.Error != nil {
+		db.MarkForRollback(ctx, result.Error)
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
 }
 
 func (d *sqlResourceDao) Delete(ctx context.Context, id string, unscoped bool) error {
@@ -69,6 +222,10 @@ func (d *sqlResourceDao) Delete(ctx context.Context, id string, unscoped bool) e
 		db.MarkForRollback(ctx, err)
 		return err
 	}
+	if unscoped {
+		// the payloads are still referenced until the deletion is committed
+		db.AfterCommit(ctx, func() { d.deletePayloads(ctx, id) })
+	}
 	return nil
 }
 
@@ -78,6 +235,9 @@ func (d *sqlResourceDao) FindByIDs(ctx context.Context, ids []string) (api.Resou
 	if err := g2.Unscoped().Where("id in (?)", ids).Find(&resources).Error; err != nil {
 		return nil, err
 	}
+	if err := d.LoadPayloads(ctx, resources); err != nil {
+		return nil, err
+	}
 	return resources, nil
 }
 
@@ -87,6 +247,9 @@ func (d *sqlResourceDao) FindBySource(ctx context.Context, source string) (api.R
 	if err := g2.Unscoped().Where("source = ?", source).Find(&resources).Error; err != nil {
 		return nil, err
 	}
+	if err := d.LoadPayloads(ctx, resources); err != nil {
+		return nil, err
+	}
 	return resources, nil
 }
 
@@ -96,6 +259,9 @@ func (d *sqlResourceDao) FindByConsumerName(ctx context.Context, consumerName st
 	if err := g2.Unscoped().Where("consumer_name = ?", consumerName).Find(&resources).Error; err != nil {
 		return nil, err
 	}
+	if err := d.LoadPayloads(ctx, resources); err != nil {
+		return nil, err
+	}
 	return resources, nil
 }
 
@@ -105,15 +271,122 @@ func (d *sqlResourceDao) FindByConsumerNameAndResourceType(ctx context.Context,
 	if err := g2.Unscoped().Where("consumer_name = ? and type = ?", consumerName, resourceType).Find(&resources).Error; err != nil {
 		return nil, err
 	}
+	if err := d.LoadPayloads(ctx, resources); err != nil {
+		return nil, err
+	}
+	return resources, nil
+}
+
+func (d *sqlResourceDao) FindOrphaned(ctx context.Context, resourceType api.ResourceType, page int, size int64) (api.ResourceList, int64, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	// the consumers are soft deleted, a resource of a deleted consumer is orphaned even if the consumer row remains
+	orphaned := g2.Model(&api.Resource{}).
+		Where("type = ? AND NOT EXISTS (SELECT 1 FROM consumers WHERE consumers.name = resources.consumer_name AND consumers.deleted_at IS NULL)", resourceType)
+
+	var total int64
+	if err := orphaned.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	resources := api.ResourceList{}
+	if size == 0 {
+		return resources, total, nil
+	}
+	if err := orphaned.Session(&gorm.Session{}).Order("id").Offset((page - 1) * int(size)).Limit(int(size)).
+		Find(&resources).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := d.LoadPayloads(ctx, resources); err != nil {
+		return nil, 0, err
+	}
+	return resources, total, nil
+}
+
+// versionDriftQuery selects the version drift of the resources from the resource_version_drifts view, the lagging and
+// unreported resources are only selected once they are not updated since the given time.
+const versionDriftQuery = `SELECT * FROM resource_version_drifts WHERE observed_version > version OR updated_at < ?`
+
+func (d *sqlResourceDao) CountVersionDrift(ctx context.Context, updatedBefore time.Time) (map[api.VersionDriftState]int, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	counts := []struct {
+		State api.VersionDriftState
+		Count int
+	}{}
+	if err := g2.Raw("SELECT state, count(*) AS count FROM ("+versionDriftQuery+") c GROUP BY state", updatedBefore).
+		Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+	result := map[api.VersionDriftState]int{}
+	for _, c := range counts {
+		result[c.State] = c.Count
+	}
+	return result, nil
+}
+
+func (d *sqlResourceDao) FindActiveByConsumerName(ctx context.Context, consumerName string, resourceType api.ResourceType, afterID string, limit int) (api.ResourceList, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	query := g2.Where("consumer_name = ? and id > ?", consumerName, afterID)
+	if len(resourceType) != 0 {
+		query = query.Where("type = ?", resourceType)
+	}
+	resources := api.ResourceList{}
+	if err := query.Order("id").Limit(limit).Find(&resources).Error; err != nil {
+		return nil, err
+	}
+	if err := d.LoadPayloads(ctx, resources); err != nil {
+		return nil, err
+	}
 	return resources, nil
 }
 
+func (d *sqlResourceDao) CountByConsumer(ctx context.Context) (map[string]int, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	counts := []struct {
+		ConsumerName string
+		Count        int
+	}{}
+	if err := g2.Model(&api.Resource{}).Select("consumer_name, count(*) AS count").
+		Group("consumer_name").Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+	result := map[string]int{}
+	for _, c := range counts {
+		result[c.ConsumerName] = c.Count
+	}
+	return result, nil
+}
+
+func (d *sqlResourceDao) CountDeletingByConsumer(ctx context.Context, deletedBefore time.Time) (map[api.ResourceType]map[string]int, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	counts := []struct {
+		Type         api.ResourceType
+		ConsumerName string
+		Count        int
+	}{}
+	if err := g2.Unscoped().Model(&api.Resource{}).Select("type, consumer_name, count(*) AS count").
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", deletedBefore).
+		Group("type, consumer_name").Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+	result := map[api.ResourceType]map[string]int{}
+	for _, c := range counts {
+		if result[c.Type] == nil {
+			result[c.Type] = map[string]int{}
+		}
+		result[c.Type][c.ConsumerName] = c.Count
+	}
+	return result, nil
+}
+
 func (d *sqlResourceDao) All(ctx context.Context) (api.ResourceList, error) {
 	g2 := (*d.sessionFactory).New(ctx)
 	resources := api.ResourceList{}
 	if err := g2.Unscoped().Find(&resources).Error; err != nil {
 		return nil, err
 	}
+	if err := d.LoadPayloads(ctx, resources); err != nil {
+		return nil, err
+	}
 	return resources, nil
 }
 
@@ -125,6 +398,265 @@ func (d *sqlResourceDao) FirstByConsumerName(ctx context.Context, consumerName s
 		g2 = g2.Unscoped()
 	}
 	resource := api.Resource{}
-	err := g2.Where("consumer_name = ?", consumerName).First(&resource).Error
+	if err := g2.Where("consumer_name = ?", consumerName).First(&resource).Error; err != nil {
+		return resource, err
+	}
+	err := d.loadPayload(ctx, &resource)
 	return resource, err
 }
+
+func (d *sqlResourceDao) MarkDispatched(ctx context.Context, id, instanceID string, dispatchedAt time.Time) error {
+	g2 := (*d.sessionFactory).New(ctx)
+	if err := g2.Model(&api.Resource{}).Where("id = ?", id).UpdateColumns(map[string]interface{}{
+		"last_dispatched_by": instanceID,
+		"last_dispatched_at": dispatchedAt,
+	}).Error; err != nil {
+		db.MarkForRollback(ctx, err)
+		return err
+	}
+	return nil
+}
+
+func (d *sqlResourceDao) UpdateQuarantine(ctx context.Context, id string, reconcileFailures int32, quarantinedAt *time.Time) error {
+	g2 := (*d.sessionFactory).New(ctx)
+	if err := g2.Model(&api.Resource{}).Where("id = ?", id).UpdateColumns(map[string]interface{}{
+		"reconcile_failures": reconcileFailures,
+		"quarantined_at":     quarantinedAt,
+	}).Error; err != nil {
+		db.MarkForRollback(ctx, err)
+		return err
+	}
+	return nil
+}
+
+func (d *sqlResourceDao) CountQuarantined(ctx context.Context) (map[api.ResourceType]int, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	counts := []struct {
+		Type  api.ResourceType
+		Count int
+	}{}
+	if err := g2.Model(&api.Resource{}).Select("type, count(*) AS count").Where("quarantined_at IS NOT NULL").
+		Group("type").Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+	result := map[api.ResourceType]int{}
+	for _, c := range counts {
+		result[c.Type] = c.Count
+	}
+	return result, nil
+}
+
+func (d *sqlResourceDao) MarkReconcileStale(ctx context.Context, specUpdatedBefore time.Time) (int64, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	// the updated_at is not changed, so the resource is not treated as updated. The status updates bump the
+	// updated_at, so the resources are checked by their spec_updated_at, and a non-numeric observed version is
+	// treated as unobserved rather than failing the whole marking.
+	result := g2.Exec(`UPDATE resources SET conditions = array_append(COALESCE(conditions, '{}'::text[]), ?::text)
+		WHERE deleted_at IS NULL AND spec_updated_at < ?
+		AND (CASE WHEN status->>'resourceversion' ~ '^[0-9]{1,18}$' THEN (status->>'resourceversion')::bigint ELSE 0 END) < version
+		AND NOT (?::text = ANY(COALESCE(conditions, '{}'::text[])))`,
+		api.ReconcileStaleCondition, specUpdatedBefore, api.ReconcileStaleCondition)
+	if result.Error != nil {
+		db.MarkForRollback(ctx, result.Error)
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+func (d *sqlResourceDao) UpdateSource(ctx context.Context, id, source string) error {
+	g2 := (*d.sessionFactory).New(ctx)
+	if err := g2.Model(&api.Resource{}).Where("id = ?", id).UpdateColumn("source", source).Error; err != nil {
+		db.MarkForRollback(ctx, err)
+		return err
+	}
+	return nil
+}
+
+func (d *sqlResourceDao) loadPayload(ctx context.Context, resource *api.Resource) error {
+	if len(resource.PayloadRef) == 0 {
+		return nil
+	}
+	if d.payloadStore == nil {
+		return fmt.Errorf("the payload of resource %s is offloaded to the object store, but the object store is not configured", resource.ID)
+	}
+
+	data, err := d.payloadStore.Get(ctx, resource.PayloadRef)
+	if err != nil {
+		return fmt.Errorf("failed to fetch the payload of resource %s from the object store: %v", resource.ID, err)
+	}
+	payload := datatypes.JSONMap{}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal the offloaded payload of resource %s: %v", resource.ID, err)
+	}
+	resource.Payload = payload
+	return nil
+}
+
+func (d *sqlResourceDao) LoadPayloads(ctx context.Context, resources api.ResourceList) error {
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(maxConcurrentPayloadLoads)
+	for _, resource := range resources {
+		if len(resource.PayloadRef) == 0 {
+			continue
+		}
+		g.Go(func() error {
+			return d.loadPayload(ctx, resource)
+		})
+	}
+	return g.Wait()
+}
+
+func (d *sqlResourceDao) DeleteUnreferencedPayloads(ctx context.Context, modifiedBefore time.Time) (int, error) {
+	if d.payloadStore == nil {
+		return 0, nil
+	}
+
+	objects, err := d.payloadStore.List(ctx, payloadKeyRoot)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list the offloaded payloads: %v", err)
+	}
+
+	// the recently modified payloads may be put by a transaction that is not committed yet
+	keysByID := map[string][]string{}
+	for _, object := range objects {
+		if !object.LastModified.Before(modifiedBefore) {
+			continue
+		}
+		id, _, ok := strings.Cut(strings.TrimPrefix(object.Key, payloadKeyRoot), "/")
+		if !ok {
+			continue
+		}
+		keysByID[id] = append(keysByID[id], object.Key)
+	}
+	if len(keysByID) == 0 {
+		return 0, nil
+	}
+
+	ids := make([]string, 0, len(keysByID))
+	for id := range keysByID {
+		ids = append(ids, id)
+	}
+	// the soft-deleted resources keep their payloads until they are permanently deleted
+	referenced := map[string]bool{}
+	g2 := (*d.sessionFactory).New(ctx)
+	for start := 0; start < len(ids); start += payloadRefQueryBatchSize {
+		end := min(start+payloadRefQueryBatchSize, len(ids))
+		refs := []string{}
+		if err := g2.Unscoped().Model(&api.Resource{}).Where("id in (?)", ids[start:end]).
+			Pluck("payload_ref", &refs).Error; err != nil {
+			return 0, err
+		}
+		for _, ref := range refs {
+			referenced[ref] = true
+		}
+	}
+
+	deleted := 0
+	for _, keys := range keysByID {
+		for _, key := range keys {
+			if referenced[key] {
+				continue
+			}
+			if err := d.payloadStore.Delete(ctx, key); err != nil {
+				return deleted, fmt.Errorf("failed to delete the offloaded payload %s: %v", key, err)
+			}
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// offloadPayload returns a copy of the resource to store in the database. If the resource is a bundle whose payload
+// is larger than the threshold, the payload is put to the object store before the row is written, and only the
+// CloudEvent attributes of the payload are kept in the copy. The key of the payload is addressed by its content, so
+// a retried write puts the same object, and an object put by a rolled back transaction is not referenced by any row,
+// it is deleted by DeleteUnreferencedPayloads.
+func (d *sqlResourceDao) offloadPayload(ctx context.Context, resource *api.Resource) (*api.Resource, error) {
+	row := *resource
+	row.PayloadRef = ""
+	if d.payloadStore == nil || d.payloadOffloadThreshold <= 0 || resource.Type != api.ResourceTypeBundle {
+		return &row, nil
+	}
+
+	data, err := json.Marshal(resource.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the payload of resource %s: %v", resource.ID, err)
+	}
+	if len(data) <= d.payloadOffloadThreshold {
+		return &row, nil
+	}
+
+	// the key requires the resource id, it is set here instead of the BeforeCreate hook.
+	if row.ID == "" {
+		row.ID = api.NewID()
+	}
+	key := payloadKey(row.ID, data)
+	// the payload does not change for e.g. the status updates, so it is put only once.
+	if resource.PayloadRef != key {
+		if err := d.payloadStore.Put(ctx, key, data); err != nil {
+			return nil, fmt.Errorf("failed to offload the payload of resource %s to the object store: %v", row.ID, err)
+		}
+	}
+
+	attributes := datatypes.JSONMap{}
+	for k, v := range resource.Payload {
+		if k != "data" {
+			attributes[k] = v
+		}
+	}
+	row.Payload = attributes
+	row.PayloadRef = key
+	return &row, nil
+}
+
+// deletePayload deletes the offloaded payload that is no longer referenced, the failure is only logged since the
+// payload is deleted by DeleteUnreferencedPayloads later.
+func (d *sqlResourceDao) deletePayload(ctx context.Context, key string) {
+	if d.payloadStore == nil {
+		return
+	}
+	if err := d.payloadStore.Delete(ctx, key); err != nil {
+		logger.NewOCMLogger(ctx).Error(fmt.Sprintf("Failed to delete the offloaded payload %s: %v", key, err))
+	}
+}
+
+// deletePayloads deletes the offloaded payloads of the permanently deleted resource, the failure is only logged
+// since the payloads are deleted by DeleteUnreferencedPayloads later.
+func (d *sqlResourceDao) deletePayloads(ctx context.Context, id string) {
+	if d.payloadStore == nil {
+		return
+	}
+
+	objects, err := d.payloadStore.List(ctx, payloadKeyPrefix(id))
+	if err != nil {
+		logger.NewOCMLogger(ctx).Error(fmt.Sprintf("Failed to list the offloaded payloads of resource %s: %v", id, err))
+		return
+	}
+	for _, object := range objects {
+		d.deletePayload(ctx, object.Key)
+	}
+}
+
+// restorePayload copies the stored row back to the resource, the full payload of the resource is kept.
+func restorePayload(resource, row *api.Resource) *api.Resource {
+	payload := resource.Payload
+	*resource = *row
+	resource.Payload = payload
+	return resource
+}
+
+// payloadKeyRoot is the prefix of the keys of all the offloaded payloads.
+const payloadKeyRoot = "resources/"
+
+// payloadRefQueryBatchSize is the max number of the resources whose payload references are read in a query.
+const payloadRefQueryBatchSize = 500
+
+func payloadKeyPrefix(id string) string {
+	return fmt.Sprintf("%s%s/", payloadKeyRoot, id)
+}
+
+// payloadKey returns the key of the payload addressed by the SHA-256 digest of its content.
+func payloadKey(id string, data []byte) string {
+	sum := sha256.Sum256(data)
+	return payloadKeyPrefix(id) + hex.EncodeToString(sum[:])
+}
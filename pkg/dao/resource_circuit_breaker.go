@@ -0,0 +1,227 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/lib/pq"
+	"gorm.io/datatypes"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/db"
+)
+
+// circuitBreakerResourceDao calls the resource DAO through the circuit breaker, the calls fail with
+// db.ErrCircuitOpen once the breaker is open, see db.CircuitBreaker.
+type circuitBreakerResourceDao struct {
+	dao     ResourceDao
+	breaker *db.CircuitBreaker
+}
+
+var _ ResourceDao = &circuitBreakerResourceDao{}
+
+func NewCircuitBreakerResourceDao(dao ResourceDao, breaker *db.CircuitBreaker) ResourceDao {
+	return &circuitBreakerResourceDao{dao: dao, breaker: breaker}
+}
+
+// call runs the call if it is allowed by the breaker, and records its result.
+func (d *circuitBreakerResourceDao) call(fn func() error) error {
+	if err := d.breaker.Allow(); err != nil {
+		return err
+	}
+	err := fn()
+	d.breaker.Record(err)
+	return err
+}
+
+func (d *circuitBreakerResourceDao) Get(ctx context.Context, id string) (resource *api.Resource, err error) {
+	err = d.call(func() error {
+		resource, err = d.dao.Get(ctx, id)
+		return err
+	})
+	return resource, err
+}
+
+func (d *circuitBreakerResourceDao) GetByName(ctx context.Context, name string) (resource *api.Resource, err error) {
+	err = d.call(func() error {
+		resource, err = d.dao.GetByName(ctx, name)
+		return err
+	})
+	return resource, err
+}
+
+func (d *circuitBreakerResourceDao) Create(ctx context.Context, resource *api.Resource) (created *api.Resource, err error) {
+	err = d.call(func() error {
+		created, err = d.dao.Create(ctx, resource)
+		return err
+	})
+	return created, err
+}
+
+func (d *circuitBreakerResourceDao) BatchCreate(ctx context.Context, resources api.ResourceList) (created api.ResourceList, err error) {
+	err = d.call(func() error {
+		created, err = d.dao.BatchCreate(ctx, resources)
+		return err
+	})
+	return created, err
+}
+
+func (d *circuitBreakerResourceDao) Update(ctx context.Context, resource *api.Resource) (updated *api.Resource, err error) {
+	err = d.call(func() error {
+		updated, err = d.dao.Update(ctx, resource)
+		return err
+	})
+	return updated, err
+}
+
+func (d *circuitBreakerResourceDao) UpdateStatus(ctx context.Context, id string, version int64, status datatypes.JSONMap,
+	conditions pq.StringArray) (updated bool, err error) {
+	err = d.call(func() error {
+		updated, err = d.dao.UpdateStatus(ctx, id, version, status, conditions)
+		return err
+	})
+	return updated, err
+}
+
+func (d *circuitBreakerResourceDao) Delete(ctx context.Context, id string, unscoped bool) error {
+	return d.call(func() error {
+		return d.dao.Delete(ctx, id, unscoped)
+	})
+}
+
+func (d *circuitBreakerResourceDao) FindByIDs(ctx context.Context, ids []string) (resources api.ResourceList, err error) {
+	err = d.call(func() error {
+		resources, err = d.dao.FindByIDs(ctx, ids)
+		return err
+	})
+	return resources, err
+}
+
+func (d *circuitBreakerResourceDao) FindBySource(ctx context.Context, source string) (resources api.ResourceList, err error) {
+	err = d.call(func() error {
+		resources, err = d.dao.FindBySource(ctx, source)
+		return err
+	})
+	return resources, err
+}
+
+func (d *circuitBreakerResourceDao) FindByConsumerName(ctx context.Context, consumerName string) (resources api.ResourceList, err error) {
+	err = d.call(func() error {
+		resources, err = d.dao.FindByConsumerName(ctx, consumerName)
+		return err
+	})
+	return resources, err
+}
+
+func (d *circuitBreakerResourceDao) FindByConsumerNameAndResourceType(ctx context.Context, consumerName string,
+	resourceType api.ResourceType) (resources api.ResourceList, err error) {
+	err = d.call(func() error {
+		resources, err = d.dao.FindByConsumerNameAndResourceType(ctx, consumerName, resourceType)
+		return err
+	})
+	return resources, err
+}
+
+func (d *circuitBreakerResourceDao) FindOrphaned(ctx context.Context, resourceType api.ResourceType, page int, size int64) (resources api.ResourceList, total int64, err error) {
+	err = d.call(func() error {
+		resources, total, err = d.dao.FindOrphaned(ctx, resourceType, page, size)
+		return err
+	})
+	return resources, total, err
+}
+
+func (d *circuitBreakerResourceDao) CountVersionDrift(ctx context.Context, updatedBefore time.Time) (counts map[api.VersionDriftState]int, err error) {
+	err = d.call(func() error {
+		counts, err = d.dao.CountVersionDrift(ctx, updatedBefore)
+		return err
+	})
+	return counts, err
+}
+
+func (d *circuitBreakerResourceDao) CountDeletingByConsumer(ctx context.Context,
+	deletedBefore time.Time) (counts map[api.ResourceType]map[string]int, err error) {
+	err = d.call(func() error {
+		counts, err = d.dao.CountDeletingByConsumer(ctx, deletedBefore)
+		return err
+	})
+	return counts, err
+}
+
+func (d *circuitBreakerResourceDao) CountByConsumer(ctx context.Context) (counts map[string]int, err error) {
+	err = d.call(func() error {
+		counts, err = d.dao.CountByConsumer(ctx)
+		return err
+	})
+	return counts, err
+}
+
+func (d *circuitBreakerResourceDao) FindActiveByConsumerName(ctx context.Context, consumerName string, resourceType api.ResourceType,
+	afterID string, limit int) (resources api.ResourceList, err error) {
+	err = d.call(func() error {
+		resources, err = d.dao.FindActiveByConsumerName(ctx, consumerName, resourceType, afterID, limit)
+		return err
+	})
+	return resources, err
+}
+
+func (d *circuitBreakerResourceDao) All(ctx context.Context) (resources api.ResourceList, err error) {
+	err = d.call(func() error {
+		resources, err = d.dao.All(ctx)
+		return err
+	})
+	return resources, err
+}
+
+func (d *circuitBreakerResourceDao) FirstByConsumerName(ctx context.Context, name string, unscoped bool) (resource api.Resource, err error) {
+	err = d.call(func() error {
+		resource, err = d.dao.FirstByConsumerName(ctx, name, unscoped)
+		return err
+	})
+	return resource, err
+}
+
+func (d *circuitBreakerResourceDao) MarkDispatched(ctx context.Context, id, instanceID string, dispatchedAt time.Time) error {
+	return d.call(func() error {
+		return d.dao.MarkDispatched(ctx, id, instanceID, dispatchedAt)
+	})
+}
+
+func (d *circuitBreakerResourceDao) UpdateSource(ctx context.Context, id, source string) error {
+	return d.call(func() error {
+		return d.dao.UpdateSource(ctx, id, source)
+	})
+}
+
+func (d *circuitBreakerResourceDao) UpdateQuarantine(ctx context.Context, id string, reconcileFailures int32, quarantinedAt *time.Time) error {
+	return d.call(func() error {
+		return d.dao.UpdateQuarantine(ctx, id, reconcileFailures, quarantinedAt)
+	})
+}
+
+func (d *circuitBreakerResourceDao) CountQuarantined(ctx context.Context) (counts map[api.ResourceType]int, err error) {
+	err = d.call(func() error {
+		counts, err = d.dao.CountQuarantined(ctx)
+		return err
+	})
+	return counts, err
+}
+
+func (d *circuitBreakerResourceDao) MarkReconcileStale(ctx context.Context, specUpdatedBefore time.Time) (marked int64, err error) {
+	err = d.call(func() error {
+		marked, err = d.dao.MarkReconcileStale(ctx, specUpdatedBefore)
+		return err
+	})
+	return marked, err
+}
+
+// LoadPayloads is not called through the breaker, it fetches the payloads from the object store, so its failures are
+// not the failures of the database.
+func (d *circuitBreakerResourceDao) LoadPayloads(ctx context.Context, resources api.ResourceList) error {
+	return d.dao.LoadPayloads(ctx, resources)
+}
+
+// DeleteUnreferencedPayloads is not called through the breaker, it is a periodic cleanup of the object store that
+// should neither be rejected by the breaker nor count its object store failures as the failures of the database.
+func (d *circuitBreakerResourceDao) DeleteUnreferencedPayloads(ctx context.Context, modifiedBefore time.Time) (int, error) {
+	return d.dao.DeleteUnreferencedPayloads(ctx, modifiedBefore)
+}
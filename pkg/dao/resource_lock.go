@@ -0,0 +1,79 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/db"
+)
+
+type ResourceLockDao interface {
+	// GetByResourceID returns the lock of the resource, the lock may be expired.
+	GetByResourceID(ctx context.Context, resourceID string) (*api.ResourceLock, error)
+	Create(ctx context.Context, lock *api.ResourceLock) (*api.ResourceLock, error)
+	Replace(ctx context.Context, lock *api.ResourceLock) (*api.ResourceLock, error)
+	// Delete permanently deletes the lock, so the resource can be locked again.
+	Delete(ctx context.Context, id string) error
+	// DeleteExpired permanently deletes the locks expired before the given time and returns the number of the
+	// deleted locks.
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
+
+var _ ResourceLockDao = &sqlResourceLockDao{}
+
+type sqlResourceLockDao struct {
+	sessionFactory *db.SessionFactory
+}
+
+func NewResourceLockDao(sessionFactory *db.SessionFactory) ResourceLockDao {
+	return &sqlResourceLockDao{sessionFactory: sessionFactory}
+}
+
+func (d *sqlResourceLockDao) GetByResourceID(ctx context.Context, resourceID string) (*api.ResourceLock, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	var lock api.ResourceLock
+	if err := g2.Take(&lock, "resource_id = ?", resourceID).Error; err != nil {
+		return nil, err
+	}
+	return &lock, nil
+}
+
+func (d *sqlResourceLockDao) Create(ctx context.Context, lock *api.ResourceLock) (*api.ResourceLock, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	if err := g2.Omit(clause.Associations).Create(lock).Error; err != nil {
+		db.MarkForRollback(ctx, err)
+		return nil, err
+	}
+	return lock, nil
+}
+
+func (d *sqlResourceLockDao) Replace(ctx context.Context, lock *api.ResourceLock) (*api.ResourceLock, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	if err := g2.Omit(clause.Associations).Save(lock).Error; err != nil {
+		db.MarkForRollback(ctx, err)
+		return nil, err
+	}
+	return lock, nil
+}
+
+func (d *sqlResourceLockDao) Delete(ctx context.Context, id string) error {
+	g2 := (*d.sessionFactory).New(ctx)
+	if err := g2.Unscoped().Omit(clause.Associations).Delete(&api.ResourceLock{Meta: api.Meta{ID: id}}).Error; err != nil {
+		db.MarkForRollback(ctx, err)
+		return err
+	}
+	return nil
+}
+
+func (d *sqlResourceLockDao) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	result := g2.Unscoped().Omit(clause.Associations).Where("expires_at <= ?", before).Delete(&api.ResourceLock{})
+	if result.Error != nil {
+		db.MarkForRollback(ctx, result.Error)
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,53 @@
+package dao
+
+import (
+	"context"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/db"
+)
+
+type ResourceOwnershipTransferDao interface {
+	Create(ctx context.Context, transfer *api.ResourceOwnershipTransfer) (*api.ResourceOwnershipTransfer, error)
+	// FindByResourceID returns a page of the ownership transfers of the resource and the total number of its
+	// transfers, the latest transfer comes first. The page starts from 1.
+	FindByResourceID(ctx context.Context, resourceID string, page int, size int64) (api.ResourceOwnershipTransferList, int64, error)
+}
+
+var _ ResourceOwnershipTransferDao = &sqlResourceOwnershipTransferDao{}
+
+type sqlResourceOwnershipTransferDao struct {
+	sessionFactory *db.SessionFactory
+}
+
+func NewResourceOwnershipTransferDao(sessionFactory *db.SessionFactory) ResourceOwnershipTransferDao {
+	return &sqlResourceOwnershipTransferDao{sessionFactory: sessionFactory}
+}
+
+func (d *sqlResourceOwnershipTransferDao) Create(ctx context.Context, transfer *api.ResourceOwnershipTransfer) (*api.ResourceOwnershipTransfer, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	if err := g2.Omit(clause.Associations).Create(transfer).Error; err != nil {
+		db.MarkForRollback(ctx, err)
+		return nil, err
+	}
+	return transfer, nil
+}
+
+func (d *sqlResourceOwnershipTransferDao) FindByResourceID(ctx context.Context, resourceID string, page int, size int64) (api.ResourceOwnershipTransferList, int64, error) {
+	g2 := (*d.sessionFactory).New(ctx).Model(&api.ResourceOwnershipTransfer{}).Where("resource_id = ?", resourceID)
+	var total int64
+	if err := g2.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	transfers := api.ResourceOwnershipTransferList{}
+	if size == 0 {
+		return transfers, total, nil
+	}
+	if err := g2.Order("created_at desc").Offset((page - 1) * int(size)).Limit(int(size)).Find(&transfers).Error; err != nil {
+		return nil, 0, err
+	}
+	return transfers, total, nil
+}
@@ -0,0 +1,76 @@
+package dao
+
+import (
+	"context"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/db"
+)
+
+type ResourceRevisionDao interface {
+	Get(ctx context.Context, resourceID string, version int64) (*api.ResourceRevision, error)
+	Create(ctx context.Context, revision *api.ResourceRevision) (*api.ResourceRevision, error)
+	FindByResourceID(ctx context.Context, resourceID string) (api.ResourceRevisionList, error)
+	DeleteBeforeVersion(ctx context.Context, resourceID string, version int64) error
+	DeleteByResourceID(ctx context.Context, resourceID string) error
+}
+
+var _ ResourceRevisionDao = &sqlResourceRevisionDao{}
+
+type sqlResourceRevisionDao struct {
+	sessionFactory *db.SessionFactory
+}
+
+func NewResourceRevisionDao(sessionFactory *db.SessionFactory) ResourceRevisionDao {
+	return &sqlResourceRevisionDao{sessionFactory: sessionFactory}
+}
+
+func (d *sqlResourceRevisionDao) Get(ctx context.Context, resourceID string, version int64) (*api.ResourceRevision, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	var revision api.ResourceRevision
+	if err := g2.Take(&revision, "resource_id = ? and version = ?", resourceID, version).Error; err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+func (d *sqlResourceRevisionDao) Create(ctx context.Context, revision *api.ResourceRevision) (*api.ResourceRevision, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	if err := g2.Omit(clause.Associations).Create(revision).Error; err != nil {
+		db.MarkForRollback(ctx, err)
+		return nil, err
+	}
+	return revision, nil
+}
+
+// FindByResourceID returns the revisions of the resource, the latest revision comes first.
+func (d *sqlResourceRevisionDao) FindByResourceID(ctx context.Context, resourceID string) (api.ResourceRevisionList, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	revisions := api.ResourceRevisionList{}
+	if err := g2.Where("resource_id = ?", resourceID).Order("version desc").Find(&revisions).Error; err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+// DeleteBeforeVersion permanently deletes the revisions of the resource that are older than the given version.
+func (d *sqlResourceRevisionDao) DeleteBeforeVersion(ctx context.Context, resourceID string, version int64) error {
+	g2 := (*d.sessionFactory).New(ctx)
+	if err := g2.Unscoped().Omit(clause.Associations).Where("resource_id = ? and version < ?", resourceID, version).Delete(&api.ResourceRevision{}).Error; err != nil {
+		db.MarkForRollback(ctx, err)
+		return err
+	}
+	return nil
+}
+
+// DeleteByResourceID permanently deletes all the revisions of the resource.
+func (d *sqlResourceRevisionDao) DeleteByResourceID(ctx context.Context, resourceID string) error {
+	g2 := (*d.sessionFactory).New(ctx)
+	if err := g2.Unscoped().Omit(clause.Associations).Where("resource_id = ?", resourceID).Delete(&api.ResourceRevision{}).Error; err != nil {
+		db.MarkForRollback(ctx, err)
+		return err
+	}
+	return nil
+}
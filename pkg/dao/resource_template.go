@@ -0,0 +1,64 @@
+package dao
+
+import (
+	"context"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/db"
+)
+
+type ResourceTemplateDao interface {
+	Get(ctx context.Context, id string) (*api.ResourceTemplate, error)
+	Create(ctx context.Context, template *api.ResourceTemplate) (*api.ResourceTemplate, error)
+	// Delete permanently deletes the template, so its name can be used again.
+	Delete(ctx context.Context, id string) error
+	All(ctx context.Context) (api.ResourceTemplateList, error)
+}
+
+var _ ResourceTemplateDao = &sqlResourceTemplateDao{}
+
+type sqlResourceTemplateDao struct {
+	sessionFactory *db.SessionFactory
+}
+
+func NewResourceTemplateDao(sessionFactory *db.SessionFactory) ResourceTemplateDao {
+	return &sqlResourceTemplateDao{sessionFactory: sessionFactory}
+}
+
+func (d *sqlResourceTemplateDao) Get(ctx context.Context, id string) (*api.ResourceTemplate, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	var template api.ResourceTemplate
+	if err := g2.Take(&template, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (d *sqlResourceTemplateDao) Create(ctx context.Context, template *api.ResourceTemplate) (*api.ResourceTemplate, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	if err := g2.Omit(clause.Associations).Create(template).Error; err != nil {
+		db.MarkForRollback(ctx, err)
+		return nil, err
+	}
+	return template, nil
+}
+
+func (d *sqlResourceTemplateDao) Delete(ctx context.Context, id string) error {
+	g2 := (*d.sessionFactory).New(ctx)
+	if err := g2.Unscoped().Omit(clause.Associations).Delete(&api.ResourceTemplate{Meta: api.Meta{ID: id}}).Error; err != nil {
+		db.MarkForRollback(ctx, err)
+		return err
+	}
+	return nil
+}
+
+func (d *sqlResourceTemplateDao) All(ctx context.Context) (api.ResourceTemplateList, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	templates := api.ResourceTemplateList{}
+	if err := g2.Order("name").Find(&templates).Error; err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
@@ -3,6 +3,7 @@ package dao
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"gorm.io/gorm/clause"
 
@@ -21,6 +22,9 @@ type StatusEventDao interface {
 	DeleteAllReconciledEvents(ctx context.Context) error
 	DeleteAllEvents(ctx context.Context, eventIDs []string) error
 	FindAllUnreconciledEvents(ctx context.Context) (api.StatusEventList, error)
+	// DeleteCreatedBefore permanently deletes the status events created before the given time and returns the number
+	// of the deleted status events.
+	DeleteCreatedBefore(ctx context.Context, before time.Time) (int64, error)
 }
 
 var _ StatusEventDao = &sqlStatusEventDao{}
@@ -125,3 +129,13 @@ func (d *sqlStatusEventDao) All(ctx context.Context) (api.StatusEventList, error
 	}
 	return statusEvents, nil
 }
+
+func (d *sqlStatusEventDao) DeleteCreatedBefore(ctx context.Context, before time.Time) (int64, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	result := g2.Unscoped().Omit(clause.Associations).Where("created_at < ?", before).Delete(&api.StatusEvent{})
+	if result.Error != nil {
+		db.MarkForRollback(ctx, result.Error)
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,79 @@
+package dao
+
+import (
+	"context"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/db"
+)
+
+type StatusResyncDao interface {
+	Get(ctx context.Context, id string) (*api.StatusResync, error)
+	// Latest returns the last started status resync.
+	Latest(ctx context.Context) (*api.StatusResync, error)
+	Create(ctx context.Context, resync *api.StatusResync) (*api.StatusResync, error)
+	// UpdateProgress updates the state, the processed and failed consumers and the finish time of the resync, the
+	// cancel request of the resync is kept.
+	UpdateProgress(ctx context.Context, resync *api.StatusResync) error
+	// RequestCancel requests the running resync to be canceled, it returns false if the resync is not running.
+	RequestCancel(ctx context.Context, id string) (bool, error)
+}
+
+var _ StatusResyncDao = &sqlStatusResyncDao{}
+
+type sqlStatusResyncDao struct {
+	sessionFactory *db.SessionFactory
+}
+
+func NewStatusResyncDao(sessionFactory *db.SessionFactory) StatusResyncDao {
+	return &sqlStatusResyncDao{sessionFactory: sessionFactory}
+}
+
+func (d *sqlStatusResyncDao) Get(ctx context.Context, id string) (*api.StatusResync, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	var resync api.StatusResync
+	if err := g2.Take(&resync, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &resync, nil
+}
+
+func (d *sqlStatusResyncDao) Latest(ctx context.Context) (*api.StatusResync, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	var resync api.StatusResync
+	if err := g2.Order("started_at desc").Take(&resync).Error; err != nil {
+		return nil, err
+	}
+	return &resync, nil
+}
+
+func (d *sqlStatusResyncDao) Create(ctx context.Context, resync *api.StatusResync) (*api.StatusResync, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	if err := g2.Omit(clause.Associations).Create(resync).Error; err != nil {
+		db.MarkForRollback(ctx, err)
+		return nil, err
+	}
+	return resync, nil
+}
+
+func (d *sqlStatusResyncDao) UpdateProgress(ctx context.Context, resync *api.StatusResync) error {
+	g2 := (*d.sessionFactory).New(ctx)
+	if err := g2.Model(resync).Select("state", "processed", "failed", "finished_at").Updates(resync).Error; err != nil {
+		db.MarkForRollback(ctx, err)
+		return err
+	}
+	return nil
+}
+
+func (d *sqlStatusResyncDao) RequestCancel(ctx context.Context, id string) (bool, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	result := g2.Model(&api.StatusResync{}).Where("id = ? AND state = ?", id, api.StatusResyncRunning).
+		UpdateColumn("cancel_requested", true)
+	if result.Error != nil {
+		db.MarkForRollback(ctx, result.Error)
+		return false, result.Error
+	}
+	return result.RowsAffected != 0, nil
+}
@@ -23,6 +23,8 @@ const (
 	ResourceStatus LockType = "resource_status"
 	Events         LockType = "events"
 	Instances      LockType = "instances"
+	Consumers      LockType = "consumers"
+	ResourceLocks  LockType = "resource_locks"
 )
 
 // LockFactory provides the blocking/unblocking locks based on PostgreSQL advisory lock.
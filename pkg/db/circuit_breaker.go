@@ -0,0 +1,182 @@
+package db
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"k8s.io/klog/v2"
+)
+
+// ErrCircuitOpen is returned by the database calls that are rejected by an open circuit breaker.
+var ErrCircuitOpen = errors.New("the database is unavailable, the circuit breaker is open")
+
+// CircuitBreakerState is the state of a circuit breaker.
+type CircuitBreakerState string
+
+const (
+	// CircuitBreakerClosed lets all the calls through.
+	CircuitBreakerClosed CircuitBreakerState = "closed"
+	// CircuitBreakerOpen rejects all the calls until the cooldown is over.
+	CircuitBreakerOpen CircuitBreakerState = "open"
+	// CircuitBreakerHalfOpen lets the probe calls through, the breaker is closed once all the probes succeed, and
+	// is opened again once a probe fails.
+	CircuitBreakerHalfOpen CircuitBreakerState = "half_open"
+)
+
+var circuitBreakerStates = []CircuitBreakerState{CircuitBreakerClosed, CircuitBreakerOpen, CircuitBreakerHalfOpen}
+
+// CircuitBreaker fast-fails the database calls once the database is degraded, so the calls don't pile up on the
+// timeouts of a degraded database. The breaker is opened after the failureThreshold consecutive failures and rejects
+// the calls with ErrCircuitOpen for the cooldown, then it lets the probe calls through, it is closed once all the
+// probes succeed and is opened again once a probe fails. Only the errors of a degraded database are failures, see
+// IsDatabaseFailure.
+type CircuitBreaker struct {
+	failureThreshold int
+	cooldown         time.Duration
+	probes           int
+
+	mu        sync.Mutex
+	state     CircuitBreakerState
+	failures  int
+	openedAt  time.Time
+	probing   int
+	succeeded int
+}
+
+func NewCircuitBreaker(failureThreshold int, cooldown time.Duration, probes int) *CircuitBreaker {
+	if probes <= 0 {
+		probes = 1
+	}
+	b := &CircuitBreaker{
+		failureThreshold: failureThreshold,
+		cooldown:         cooldown,
+		probes:           probes,
+	}
+	b.setState(CircuitBreakerClosed)
+	return b
+}
+
+// State returns the current state of the circuit breaker.
+func (b *CircuitBreaker) State() CircuitBreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// Allow returns ErrCircuitOpen if the call is rejected, otherwise the result of the call must be recorded by Record.
+func (b *CircuitBreaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == CircuitBreakerOpen {
+		if time.Since(b.openedAt) < b.cooldown {
+			return ErrCircuitOpen
+		}
+		b.probing, b.succeeded = 0, 0
+		b.setState(CircuitBreakerHalfOpen)
+	}
+	if b.state == CircuitBreakerHalfOpen {
+		if b.probing >= b.probes {
+			return ErrCircuitOpen
+		}
+		b.probing++
+	}
+	return nil
+}
+
+// Record records the result of an allowed call, a canceled call is neither a failure nor a success.
+func (b *CircuitBreaker) Record(err error) {
+	failed := IsDatabaseFailure(err)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if errors.Is(err, context.Canceled) {
+		if b.state == CircuitBreakerHalfOpen && b.probing > 0 {
+			// the canceled probe is not counted, so another call can probe the database
+			b.probing--
+		}
+		return
+	}
+
+	switch b.state {
+	case CircuitBreakerClosed:
+		if !failed {
+			b.failures = 0
+			return
+		}
+		b.failures++
+		if b.failures >= b.failureThreshold {
+			klog.Warningf("the database circuit breaker is open after %d consecutive failures, the last failure: %v",
+				b.failures, err)
+			b.open()
+		}
+	case CircuitBreakerHalfOpen:
+		if failed {
+			klog.Warningf("the database circuit breaker is open again, the probe failed: %v", err)
+			b.open()
+			return
+		}
+		b.succeeded++
+		if b.succeeded >= b.probes {
+			klog.Infof("the database circuit breaker is closed after %d succeeded probes", b.succeeded)
+			b.failures = 0
+			b.setState(CircuitBreakerClosed)
+		}
+	}
+}
+
+func (b *CircuitBreaker) open() {
+	b.openedAt = time.Now()
+	b.setState(CircuitBreakerOpen)
+}
+
+func (b *CircuitBreaker) setState(state CircuitBreakerState) {
+	b.state = state
+	for _, s := range circuitBreakerStates {
+		value := 0.0
+		if s == state {
+			value = 1
+		}
+		circuitBreakerStateMetric.WithLabelValues(string(s)).Set(value)
+	}
+}
+
+// IsDatabaseFailure tells whether the error is a failure of a degraded database. Only the connection-level errors are
+// failures, i.e. the errors to connect to the database, the broken connections, the network errors and timeouts, and
+// the server errors of the connection, resource or operator classes. Any other error, e.g. a record is not found, a
+// constraint is violated or a canceled call, is not a failure.
+func IsDatabaseFailure(err error) bool {
+	if err == nil || errors.Is(err, ErrCircuitOpen) || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	// a broken connection or a timeout
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, context.DeadlineExceeded) || pgconn.Timeout(err) {
+		return true
+	}
+
+	// the errors to connect to the database wrap the network errors, e.g. the connection is refused
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && len(pgErr.Code) >= 2 {
+		switch pgErr.Code[:2] {
+		// connection exception, insufficient resources, operator intervention (e.g. a statement timeout) and system
+		// error
+		case "08", "53", "57", "58":
+			return true
+		}
+	}
+	return false
+}
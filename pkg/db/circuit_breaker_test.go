@@ -0,0 +1,103 @@
+package db
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"gorm.io/gorm"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	breaker := NewCircuitBreaker(2, time.Hour, 2)
+	failure := &net.OpError{Op: "dial", Net: "tcp", Err: fmt.Errorf("i/o timeout")}
+
+	// the errors of a working database don't open the breaker
+	for _, err := range []error{failure, gorm.ErrRecordNotFound, failure, context.Canceled} {
+		if allowErr := breaker.Allow(); allowErr != nil {
+			t.Fatalf("unexpected error: %v", allowErr)
+		}
+		breaker.Record(err)
+	}
+	if state := breaker.State(); state != CircuitBreakerClosed {
+		t.Errorf("expected the breaker is closed, but got %s", state)
+	}
+
+	breaker.Record(failure)
+	if state := breaker.State(); state != CircuitBreakerOpen {
+		t.Errorf("expected the breaker is open, but got %s", state)
+	}
+	if err := breaker.Allow(); err != ErrCircuitOpen {
+		t.Errorf("expected the call is rejected, but got %v", err)
+	}
+	if value := testutil.ToFloat64(circuitBreakerStateMetric.WithLabelValues(string(CircuitBreakerOpen))); value != 1 {
+		t.Errorf("expected the open state metric is 1, but got %v", value)
+	}
+
+	// the probes are let through once the cooldown is over
+	breaker.openedAt = time.Now().Add(-time.Hour)
+	for i := 0; i < 2; i++ {
+		if err := breaker.Allow(); err != nil {
+			t.Fatalf("expected the probe %d is allowed, but got %v", i, err)
+		}
+	}
+	if err := breaker.Allow(); err != ErrCircuitOpen {
+		t.Errorf("expected the call beyond the probes is rejected, but got %v", err)
+	}
+	if state := breaker.State(); state != CircuitBreakerHalfOpen {
+		t.Errorf("expected the breaker is half open, but got %s", state)
+	}
+	breaker.Record(nil)
+	breaker.Record(nil)
+	if state := breaker.State(); state != CircuitBreakerClosed {
+		t.Errorf("expected the breaker is closed after the probes succeed, but got %s", state)
+	}
+
+	// a failed probe opens the breaker again
+	breaker.Record(failure)
+	breaker.Record(failure)
+	breaker.openedAt = time.Now().Add(-time.Hour)
+	if err := breaker.Allow(); err != nil {
+		t.Fatalf("expected the probe is allowed, but got %v", err)
+	}
+	breaker.Record(failure)
+	if state := breaker.State(); state != CircuitBreakerOpen {
+		t.Errorf("expected the breaker is open after the probe fails, but got %s", state)
+	}
+}
+
+func TestIsDatabaseFailure(t *testing.T) {
+	cases := []struct {
+		err     error
+		failure bool
+	}{
+		{err: nil, failure: false},
+		{err: gorm.ErrRecordNotFound, failure: false},
+		{err: fmt.Errorf("failed to get: %w", context.Canceled), failure: false},
+		{err: context.DeadlineExceeded, failure: true},
+		{err: fmt.Errorf("failed to query: %w", driver.ErrBadConn), failure: true},
+		{err: fmt.Errorf("failed to connect: %w", &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}), failure: true},
+		{err: &net.DNSError{Err: "no such host", Name: "db.example.com"}, failure: true},
+		{err: io.ErrUnexpectedEOF, failure: true},
+		// the errors that are not classified as connection-level are not failures
+		{err: fmt.Errorf("failed to connect to the database"), failure: false},
+		{err: fmt.Errorf("failed to fetch the payload of resource abc from the object store: unavailable"), failure: false},
+		{err: ErrCircuitOpen, failure: false},
+		{err: &pgconn.PgError{Code: "23505"}, failure: false},
+		{err: &pgconn.PgError{Code: "57014"}, failure: true},
+		{err: &pgconn.PgError{Code: "53300"}, failure: true},
+	}
+
+	for _, c := range cases {
+		if failure := IsDatabaseFailure(c.err); failure != c.failure {
+			t.Errorf("expected the error %v is a failure: %v, but got %v", c.err, c.failure, failure)
+		}
+	}
+}
@@ -4,6 +4,9 @@ package db_context
 
 import (
 	"context"
+	"sync"
+
+	"gorm.io/gorm"
 
 	"github.com/openshift-online/maestro/pkg/db/transaction"
 )
@@ -12,6 +15,8 @@ type contextKey int
 
 const (
 	transactionKey contextKey = iota
+	sessionKey
+	afterCommitKey
 )
 
 // WithTransaction adds the transaction to the context and returns a new context
@@ -33,3 +38,51 @@ func TxID(ctx context.Context) (id int64, ok bool) {
 	}
 	return tx.TxID(), true
 }
+
+// WithSession adds the session of a database transaction to the context, the sessions created from the context join
+// the transaction, see db.Transactor.
+func WithSession(ctx context.Context, session *gorm.DB) context.Context {
+	return context.WithValue(ctx, sessionKey, session)
+}
+
+// Session extracts the session of the database transaction from the context
+func Session(ctx context.Context) (session *gorm.DB, ok bool) {
+	session, ok = ctx.Value(sessionKey).(*gorm.DB)
+	return session, ok
+}
+
+// AfterCommitHooks are the functions to run once the database transaction is committed.
+type AfterCommitHooks struct {
+	mu    sync.Mutex
+	hooks []func()
+}
+
+// Add adds the function to run once the transaction is committed.
+func (h *AfterCommitHooks) Add(fn func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.hooks = append(h.hooks, fn)
+}
+
+// Run runs the added functions in their added order.
+func (h *AfterCommitHooks) Run() {
+	h.mu.Lock()
+	hooks := h.hooks
+	h.hooks = nil
+	h.mu.Unlock()
+
+	for _, fn := range hooks {
+		fn()
+	}
+}
+
+// WithAfterCommitHooks adds the after-commit hooks of a database transaction to the context.
+func WithAfterCommitHooks(ctx context.Context, hooks *AfterCommitHooks) context.Context {
+	return context.WithValue(ctx, afterCommitKey, hooks)
+}
+
+// AfterCommit extracts the after-commit hooks of the database transaction from the context
+func AfterCommit(ctx context.Context) (hooks *AfterCommitHooks, ok bool) {
+	hooks, ok = ctx.Value(afterCommitKey).(*AfterCommitHooks)
+	return hooks, ok
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/openshift-online/maestro/pkg/config"
 	"github.com/openshift-online/maestro/pkg/constants"
 	"github.com/openshift-online/maestro/pkg/db"
+	dbContext "github.com/openshift-online/maestro/pkg/db/db_context"
 	ocmlogger "github.com/openshift-online/maestro/pkg/logger"
 )
 
@@ -31,6 +32,11 @@ type Default struct {
 	// - to setup/close connection because GORM V2 removed gorm.Close()
 	// - to work with pq.CopyIn because connection returned by GORM V2 gorm.DB() in "not the same"
 	db *sql.DB
+
+	// replicaG2 and replicaDB are the connection to the read replica of the database, they are nil if the replica is
+	// not configured.
+	replicaG2 *gorm.DB
+	replicaDB *sql.DB
 }
 
 var _ db.SessionFactory = &Default{}
@@ -47,51 +53,54 @@ func NewProdFactory(config *config.DatabaseConfig) *Default {
 func (f *Default) Init(config *config.DatabaseConfig) {
 	// Only the first time
 	once.Do(func() {
-		var (
-			dbx *sql.DB
-			g2  *gorm.DB
-			err error
-		)
+		f.config = config
+		f.db, f.g2 = openDB(config)
 
-		connConfig, err := pgx.ParseConfig(config.ConnectionString(config.SSLMode != disable))
-		if err != nil {
-			panic(fmt.Sprintf(
-				"GORM failed to parse the connection string: %s\nError: %s",
-				config.LogSafeConnectionString(config.SSLMode != disable),
-				err.Error(),
-			))
+		if config.ReplicaEnabled() {
+			replicaConfig := config.ReplicaConfig()
+			f.replicaDB, f.replicaG2 = openDB(replicaConfig)
+			ocmlogger.NewOCMLogger(context.Background()).Infof("Routing the read-only queries to the database replica %s:%d",
+				replicaConfig.Host, replicaConfig.Port)
 		}
+	})
+}
 
-		dbx = stdlib.OpenDB(*connConfig, stdlib.OptionBeforeConnect(setPassword(config)))
-		dbx.SetMaxOpenConns(config.MaxOpenConnections)
+func openDB(config *config.DatabaseConfig) (*sql.DB, *gorm.DB) {
+	connConfig, err := pgx.ParseConfig(config.ConnectionString(config.SSLMode != disable))
+	if err != nil {
+		panic(fmt.Sprintf(
+			"GORM failed to parse the connection string: %s\nError: %s",
+			config.LogSafeConnectionString(config.SSLMode != disable),
+			err.Error(),
+		))
+	}
 
-		// Connect GORM to use the same connection
-		conf := &gorm.Config{
-			PrepareStmt:          false,
-			FullSaveAssociations: false,
-		}
-		g2, err = gorm.Open(postgres.New(postgres.Config{
-			Conn: dbx,
-			// Disable implicit prepared statement usage (GORM V2 uses pgx as database/sql driver and it enables prepared
-			/// statement cache by default)
-			// In migrations we both change tables' structure and running SQLs to modify data.
-			// This way all prepared statements becomes invalid.
-			PreferSimpleProtocol: true,
-		}), conf)
-		if err != nil {
-			panic(fmt.Sprintf(
-				"GORM failed to connect to %s database %s with connection string: %s\nError: %s",
-				config.Dialect,
-				config.Name,
-				config.LogSafeConnectionString(config.SSLMode != disable),
-				err.Error(),
-			))
-		}
+	dbx := stdlib.OpenDB(*connConfig, stdlib.OptionBeforeConnect(setPassword(config)))
+	dbx.SetMaxOpenConns(config.MaxOpenConnections)
 
-		f.config = config
-		f.g2 = g2
-		f.db = dbx
-	})
+	// Connect GORM to use the same connection
+	conf := &gorm.Config{
+		PrepareStmt:          false,
+		FullSaveAssociations: false,
+	}
+	g2, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: dbx,
+		// Disable implicit prepared statement usage (GORM V2 uses pgx as database/sql driver and it enables prepared
+		/// statement cache by default)
+		// In migrations we both change tables' structure and running SQLs to modify data.
+		// This way all prepared statements becomes invalid.
+		PreferSimpleProtocol: true,
+	}), conf)
+	if err != nil {
+		panic(fmt.Sprintf(
+			"GORM failed to connect to %s database %s with connection string: %s\nError: %s",
+			config.Dialect,
+			config.Name,
+			config.LogSafeConnectionString(config.SSLMode != disable),
+			err.Error(),
+		))
+	}
+	return dbx, g2
 }
 
 func setPassword(dbConfig *config.DatabaseConfig) func(ctx context.Context, connConfig *pgx.ConnConfig) error {
@@ -208,9 +217,25 @@ func (f *Default) NewListener(ctx context.Context, channel string, callback func
 }
 
 func (f *Default) New(ctx context.Context) *gorm.DB {
-	conn := f.g2.Session(&gorm.Session{
+	return f.newSession(ctx, f.g2)
+}
+
+func (f *Default) NewReadOnly(ctx context.Context) *gorm.DB {
+	if f.replicaG2 == nil {
+		return f.New(ctx)
+	}
+	return f.newSession(ctx, f.replicaG2)
+}
+
+func (f *Default) newSession(ctx context.Context, g2 *gorm.DB) *gorm.DB {
+	// join the transaction of the context, the reads of the transaction must see its writes
+	if tx, ok := dbContext.Session(ctx); ok {
+		return tx.Session(&gorm.Session{Context: ctx})
+	}
+
+	conn := g2.Session(&gorm.Session{
 		Context: ctx,
-		Logger:  f.g2.Logger.LogMode(logger.Silent),
+		Logger:  g2.Logger.LogMode(logger.Silent),
 	})
 	if f.config.Debug {
 		conn = conn.Debug()
@@ -226,6 +251,11 @@ func (f *Default) CheckConnection() error {
 // THIS MUST **NOT** BE CALLED UNTIL THE SERVER/PROCESS IS EXITING!!
 // This should only ever be called once for the entire duration of the application and only at the end.
 func (f *Default) Close() error {
+	if f.replicaDB != nil {
+		if err := f.replicaDB.Close(); err != nil {
+			return err
+		}
+	}
 	return f.db.Close()
 }
 
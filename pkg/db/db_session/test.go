@@ -16,6 +16,7 @@ import (
 	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/openshift-online/maestro/pkg/config"
 	"github.com/openshift-online/maestro/pkg/db"
+	dbContext "github.com/openshift-online/maestro/pkg/db/db_context"
 )
 
 type Test struct {
@@ -185,6 +186,11 @@ func (f *Test) DirectDB() *sql.DB {
 }
 
 func (f *Test) New(ctx context.Context) *gorm.DB {
+	// join the transaction of the context
+	if tx, ok := dbContext.Session(ctx); ok {
+		return tx.Session(&gorm.Session{Context: ctx})
+	}
+
 	if f.wasDisconnected {
 		// Connection was killed in order to reset DB
 		f.db, f.g2 = connectFactory(f.config)
@@ -201,6 +207,11 @@ func (f *Test) New(ctx context.Context) *gorm.DB {
 	return conn
 }
 
+// NewReadOnly returns the same session as New, there is no read replica of the test database.
+func (f *Test) NewReadOnly(ctx context.Context) *gorm.DB {
+	return f.New(ctx)
+}
+
 // CheckConnection checks to ensure a connection is present
 func (f *Test) CheckConnection() error {
 	_, err := f.db.Exec("SELECT 1")
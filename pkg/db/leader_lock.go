@@ -0,0 +1,171 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/openshift-online/maestro/pkg/logger"
+)
+
+// Leader is the lock type of the leader locks.
+const Leader LockType = "leader"
+
+// LeaderLock is a leader lock based on the PostgreSQL session-level advisory lock, it is used by the singleton
+// controllers (e.g. the garbage collectors and the metrics collectors) to ensure only one maestro instance runs
+// them. The lock is defined by a well-known key of the controller and is held by a dedicated DB connection:
+//
+//	select pg_try_advisory_lock(key, 'leader')  # obtain the lock (nonblocking)
+//	select 1                                    # renew the lock by keeping the session alive
+//	select pg_advisory_unlock(key, 'leader')    # release the lock
+//
+// The lock is released by PostgreSQL once the session ends, so the leadership is handed over if the leader crashes
+// or loses its DB connection.
+type LeaderLock struct {
+	connection    SessionFactory
+	key           string
+	renewInterval time.Duration
+
+	mu         sync.Mutex
+	conn       *sql.Conn
+	stopRenew  context.CancelFunc
+	acquiredAt time.Time
+}
+
+// NewLeaderLock returns a new leader lock with the given key, the held lock is renewed every renewInterval.
+func NewLeaderLock(connection SessionFactory, key string, renewInterval time.Duration) *LeaderLock {
+	return &LeaderLock{
+		connection:    connection,
+		key:           key,
+		renewInterval: renewInterval,
+	}
+}
+
+// TryAcquire tries to obtain the leader lock without blocking and returns true if the current instance is the
+// leader. Once acquired, the lock is renewed in the background until it is released or the context is done.
+func (l *LeaderLock) TryAcquire(ctx context.Context) (bool, error) {
+	log := logger.NewOCMLogger(ctx)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn != nil {
+		// the lock is already held by the current instance
+		return true, nil
+	}
+
+	// a dedicated connection is required, the session-level lock is bound to the session that obtains it.
+	conn, err := l.connection.DirectDB().Conn(ctx)
+	if err != nil {
+		UpdateAdvisoryLockCountMetric(Leader, "lock error")
+		return false, fmt.Errorf("failed to get the connection for leader lock %s: %v", l.key, err)
+	}
+
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, "select pg_try_advisory_lock($1, $2)",
+		hash(l.key), hash(string(Leader))).Scan(&acquired); err != nil {
+		UpdateAdvisoryLockCountMetric(Leader, "lock error")
+		_ = conn.Close()
+		return false, fmt.Errorf("error obtaining the leader lock %s: %v", l.key, err)
+	}
+
+	if !acquired {
+		_ = conn.Close()
+		return false, nil
+	}
+
+	log.V(4).Infof("Acquired leader lock %s", l.key)
+	renewCtx, stopRenew := context.WithCancel(ctx)
+	l.conn = conn
+	l.stopRenew = stopRenew
+	l.acquiredAt = time.Now()
+	go l.renew(renewCtx, conn)
+
+	return true, nil
+}
+
+// IsLeader returns true if the leader lock is held by the current instance.
+func (l *LeaderLock) IsLeader() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.conn != nil
+}
+
+// Release releases the leader lock if it is held by the current instance.
+func (l *LeaderLock) Release(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn == nil {
+		return nil
+	}
+
+	return l.release(ctx, l.conn)
+}
+
+// release unlocks the lock and closes its connection, the caller must hold the mutex.
+func (l *LeaderLock) release(ctx context.Context, conn *sql.Conn) error {
+	log := logger.NewOCMLogger(ctx)
+
+	l.stopRenew()
+	_, unlockErr := conn.ExecContext(ctx, "select pg_advisory_unlock($1, $2)", hash(l.key), hash(string(Leader)))
+	// the lock is released once the session ends even if the unlock fails
+	closeErr := conn.Close()
+
+	l.conn = nil
+	l.stopRenew = nil
+
+	if unlockErr != nil {
+		UpdateAdvisoryLockCountMetric(Leader, "unlock error")
+		return fmt.Errorf("error releasing the leader lock %s: %v", l.key, unlockErr)
+	}
+	UpdateAdvisoryLockCountMetric(Leader, "OK")
+	UpdateAdvisoryLockDurationMetric(Leader, "OK", l.acquiredAt)
+	if closeErr != nil {
+		return fmt.Errorf("error closing the connection of leader lock %s: %v", l.key, closeErr)
+	}
+
+	log.V(4).Infof("Released leader lock %s", l.key)
+	return nil
+}
+
+// renew keeps the session of the lock alive, the leadership is lost if the session is broken. The lock is released
+// once the context is done, e.g. the instance is shutting down.
+func (l *LeaderLock) renew(ctx context.Context, conn *sql.Conn) {
+	log := logger.NewOCMLogger(ctx)
+
+	ticker := time.NewTicker(l.renewInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			l.mu.Lock()
+			// the lock may be released or reacquired with another connection already
+			if l.conn == conn {
+				if err := l.release(context.Background(), conn); err != nil {
+					log.Error(err.Error())
+				}
+			}
+			l.mu.Unlock()
+			return
+		case <-ticker.C:
+			if _, err := conn.ExecContext(ctx, "select 1"); err != nil && ctx.Err() == nil {
+				log.Error(fmt.Sprintf("Lost leader lock %s: %v", l.key, err))
+				UpdateAdvisoryLockCountMetric(Leader, "renew error")
+				l.mu.Lock()
+				if l.conn == conn {
+					l.stopRenew()
+					_ = conn.Close()
+					l.conn = nil
+					l.stopRenew = nil
+				}
+				l.mu.Unlock()
+				return
+			}
+		}
+	}
+}
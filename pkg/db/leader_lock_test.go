@@ -0,0 +1,105 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// unlockDriver is a database driver whose connections fail to execute the statements with unlockErr.
+type unlockDriver struct {
+	unlockErr error
+}
+
+func (d *unlockDriver) Open(name string) (driver.Conn, error) {
+	return &unlockConn{driver: d}, nil
+}
+
+type unlockConn struct {
+	driver *unlockDriver
+}
+
+func (c *unlockConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, fmt.Errorf("not supported")
+}
+
+func (c *unlockConn) Close() error { return nil }
+
+func (c *unlockConn) Begin() (driver.Tx, error) {
+	return nil, fmt.Errorf("not supported")
+}
+
+func (c *unlockConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if c.driver.unlockErr != nil {
+		return nil, c.driver.unlockErr
+	}
+	return driver.RowsAffected(0), nil
+}
+
+func TestLeaderLockReleaseMetrics(t *testing.T) {
+	unlockDrv := &unlockDriver{}
+	sql.Register("leaderlocktest", unlockDrv)
+	sqlDB, err := sql.Open("leaderlocktest", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlDB.Close()
+
+	cases := []struct {
+		name        string
+		unlockErr   error
+		expectedErr bool
+		okCount     float64
+		errorCount  float64
+	}{
+		{
+			name:    "released",
+			okCount: 1,
+		},
+		{
+			name:        "unlock error",
+			unlockErr:   fmt.Errorf("connection reset"),
+			expectedErr: true,
+			errorCount:  1,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ResetAdvisoryLockMetricsCollectors()
+			defer ResetAdvisoryLockMetricsCollectors()
+
+			ctx := context.Background()
+			unlockDrv.unlockErr = c.unlockErr
+			conn, err := sqlDB.Conn(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			lock := NewLeaderLock(nil, "test", time.Second)
+			lock.conn = conn
+			lock.stopRenew = func() {}
+			lock.acquiredAt = time.Now()
+
+			if err := lock.release(ctx, conn); (err != nil) != c.expectedErr {
+				t.Errorf("expected error %v, but got %v", c.expectedErr, err)
+			}
+
+			// exactly one outcome is recorded for the release
+			if count := testutil.CollectAndCount(advisoryLockCountMetric); count != 1 {
+				t.Errorf("expected one outcome, but got %d", count)
+			}
+			if count := testutil.ToFloat64(advisoryLockCountMetric.WithLabelValues(string(Leader), "OK")); count != c.okCount {
+				t.Errorf("expected %v OK releases, but got %v", c.okCount, count)
+			}
+			if count := testutil.ToFloat64(advisoryLockCountMetric.WithLabelValues(string(Leader), "unlock error")); count != c.errorCount {
+				t.Errorf("expected %v unlock errors, but got %v", c.errorCount, count)
+			}
+		})
+	}
+}
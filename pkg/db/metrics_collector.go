@@ -9,6 +9,8 @@ import (
 func init() {
 	// Register the metrics for advisory locks
 	RegisterAdvisoryLockMetrics()
+	// Register the metrics for the circuit breaker
+	RegisterCircuitBreakerMetrics()
 }
 
 type MetricsCollector interface {
@@ -63,6 +65,16 @@ var advisoryLockDurationMetric = prometheus.NewHistogramVec(
 	metricsLabels,
 )
 
+// the circuit breaker state metric is 1 for the current state and 0 for the other states.
+var circuitBreakerStateMetric = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Subsystem: "db_circuit_breaker",
+		Name:      "state",
+		Help:      "State of the database circuit breaker, 1 for the current state and 0 for the other states.",
+	},
+	[]string{"state"},
+)
+
 // Register the metrics:
 func RegisterAdvisoryLockMetrics() {
 	prometheus.MustRegister(advisoryLockCountMetric)
@@ -75,6 +87,16 @@ func UnregisterAdvisoryLockMetrics() {
 	prometheus.Unregister(advisoryLockDurationMetric)
 }
 
+// RegisterCircuitBreakerMetrics registers the metrics of the database circuit breaker.
+func RegisterCircuitBreakerMetrics() {
+	prometheus.MustRegister(circuitBreakerStateMetric)
+}
+
+// UnregisterCircuitBreakerMetrics unregisters the metrics of the database circuit breaker.
+func UnregisterCircuitBreakerMetrics() {
+	prometheus.Unregister(circuitBreakerStateMetric)
+}
+
 // ResetAdvisoryLockMetricsCollectors resets all collectors
 func ResetAdvisoryLockMetricsCollectors() {
 	advisoryLockCountMetric.Reset()
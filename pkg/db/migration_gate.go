@@ -0,0 +1,93 @@
+package db
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/openshift-online/maestro/pkg/db/migrations"
+	"k8s.io/klog/v2"
+)
+
+// MigrationGate tells whether all the migrations of the migration list are applied to the database, so the servers
+// can reject the mutations while the migrations are still running (e.g. the server starts before the migration job
+// finishes) rather than failing with the errors of a half-migrated schema. The pending migrations are checked at most
+// once every check interval, and the gate stays complete once all the migrations are applied.
+type MigrationGate struct {
+	pending       func(ctx context.Context) ([]string, error)
+	checkInterval time.Duration
+
+	mu        sync.Mutex
+	complete  bool
+	checkedAt time.Time
+}
+
+// NewMigrationGate returns a migration gate that checks the applied migrations in the database of the session
+// factory every check interval until all the migrations are applied.
+func NewMigrationGate(sessionFactory SessionFactory, checkInterval time.Duration) *MigrationGate {
+	return newMigrationGate(func(ctx context.Context) ([]string, error) {
+		return PendingMigrations(ctx, sessionFactory)
+	}, checkInterval)
+}
+
+func newMigrationGate(pending func(ctx context.Context) ([]string, error), checkInterval time.Duration) *MigrationGate {
+	return &MigrationGate{pending: pending, checkInterval: checkInterval}
+}
+
+// Complete returns true if all the migrations are applied or there is no gate. A failed check is treated as
+// incomplete, and is retried after the check interval.
+func (g *MigrationGate) Complete(ctx context.Context) bool {
+	if g == nil {
+		return true
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.complete || time.Since(g.checkedAt) < g.checkInterval {
+		return g.complete
+	}
+	g.checkedAt = time.Now()
+
+	pending, err := g.pending(ctx)
+	if err != nil {
+		klog.Errorf("failed to check the pending migrations: %v", err)
+		return false
+	}
+	if len(pending) != 0 {
+		klog.Warningf("the migrations %v are not applied yet", pending)
+		return false
+	}
+
+	klog.Infof("all the migrations are applied")
+	g.complete = true
+	return true
+}
+
+// PendingMigrations returns the IDs of the migrations in the migration list that are not applied to the database yet
+// in the order of the list, all the migrations are pending if the migrations table doesn't exist.
+func PendingMigrations(ctx context.Context, sessionFactory SessionFactory) ([]string, error) {
+	g2 := sessionFactory.New(ctx)
+	options := gormigrate.DefaultOptions
+
+	applied := []string{}
+	if g2.Migrator().HasTable(options.TableName) {
+		if err := g2.Table(options.TableName).Pluck(options.IDColumnName, &applied).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	appliedIDs := make(map[string]bool, len(applied))
+	for _, id := range applied {
+		appliedIDs[id] = true
+	}
+
+	pending := []string{}
+	for _, migration := range migrations.MigrationList {
+		if !appliedIDs[migration.ID] {
+			pending = append(pending, migration.ID)
+		}
+	}
+	return pending, nil
+}
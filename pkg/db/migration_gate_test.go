@@ -0,0 +1,55 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestMigrationGate(t *testing.T) {
+	ctx := context.Background()
+
+	checks := 0
+	results := []struct {
+		pending []string
+		err     error
+	}{
+		{err: fmt.Errorf("connection refused")},
+		{pending: []string{"202610142230"}},
+		{},
+	}
+	gate := newMigrationGate(func(ctx context.Context) ([]string, error) {
+		result := results[checks]
+		checks++
+		return result.pending, result.err
+	}, 0)
+
+	for i, expected := range []bool{false, false, true} {
+		if complete := gate.Complete(ctx); complete != expected {
+			t.Errorf("expected the check %d is %v, but got %v", i, expected, complete)
+		}
+	}
+
+	// the gate stays complete without checking again
+	if !gate.Complete(ctx) || checks != 3 {
+		t.Errorf("expected the gate stays complete after 3 checks, but got %d checks", checks)
+	}
+}
+
+func TestMigrationGateCheckInterval(t *testing.T) {
+	checks := 0
+	gate := newMigrationGate(func(ctx context.Context) ([]string, error) {
+		checks++
+		return []string{"202610142230"}, nil
+	}, time.Hour)
+
+	for i := 0; i < 3; i++ {
+		if gate.Complete(context.Background()) {
+			t.Errorf("expected the gate is not complete")
+		}
+	}
+	if checks != 1 {
+		t.Errorf("expected the pending migrations are checked once in the check interval, but got %d checks", checks)
+	}
+}
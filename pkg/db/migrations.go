@@ -14,6 +14,11 @@ import (
 // For help writing migration steps, see the gorm documentation on migrations: http://doc.gorm.io/database.html#migration
 
 func Migrate(g2 *gorm.DB) error {
+	// fail fast before any migration is applied if the migrations are out of order
+	if err := migrations.ValidateMigrationList(migrations.MigrationList); err != nil {
+		return err
+	}
+
 	m := newGormigrate(g2)
 
 	if err := m.Migrate(); err != nil {
@@ -26,6 +31,10 @@ func Migrate(g2 *gorm.DB) error {
 // schema based on the most recent migration
 // This should be for testing purposes mainly
 func MigrateTo(sessionFactory SessionFactory, migrationID string) {
+	if err := migrations.ValidateMigrationList(migrations.MigrationList); err != nil {
+		klog.Fatalf("Could not migrate: %v", err)
+	}
+
 	g2 := sessionFactory.New(context.Background())
 	m := newGormigrate(g2)
 
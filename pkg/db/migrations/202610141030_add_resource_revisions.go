@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+)
+
+func addResourceRevisions() *gormigrate.Migration {
+	type ResourceRevision struct {
+		Model
+		ResourceID string `gorm:"index:idx_resource_revisions_resource_version,unique"`
+		Version    int    `gorm:"not null;index:idx_resource_revisions_resource_version,unique"`
+		// Payload is the snapshot of the resource payload at the version.
+		Payload datatypes.JSON `gorm:"type:json"`
+	}
+
+	return &gormigrate.Migration{
+		ID: "202610141030",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&ResourceRevision{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&ResourceRevision{})
+		},
+	}
+}
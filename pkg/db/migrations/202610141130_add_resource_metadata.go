@@ -0,0 +1,25 @@
+package migrations
+
+import (
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+)
+
+func addResourceMetadata() *gormigrate.Migration {
+	type Resource struct {
+		// Metadata holds the passthrough CloudEvent extensions of the resource (JSON representation).
+		Metadata datatypes.JSON `gorm:"type:json"`
+	}
+
+	return &gormigrate.Migration{
+		ID: "202610141130",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&Resource{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn(&Resource{}, "metadata")
+		},
+	}
+}
@@ -0,0 +1,41 @@
+package migrations
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+)
+
+// alterResourceVersion ensures the resource version is stored as a bigint, so that the version of a long-lived,
+// frequently-updated resource is not truncated.
+func alterResourceVersion() *gormigrate.Migration {
+	type Resource struct {
+		Version int64 `gorm:"type:bigint;not null"`
+	}
+
+	type ResourceRevision struct {
+		Version int64 `gorm:"type:bigint;not null"`
+	}
+
+	return &gormigrate.Migration{
+		ID: "202610141230",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.Migrator().AlterColumn(&Resource{}, "Version"); err != nil {
+				return err
+			}
+			return tx.Migrator().AlterColumn(&ResourceRevision{}, "Version")
+		},
+		Rollback: func(tx *gorm.DB) error {
+			type Resource struct {
+				Version int32 `gorm:"type:integer;not null"`
+			}
+			type ResourceRevision struct {
+				Version int32 `gorm:"type:integer;not null"`
+			}
+			if err := tx.Migrator().AlterColumn(&Resource{}, "Version"); err != nil {
+				return err
+			}
+			return tx.Migrator().AlterColumn(&ResourceRevision{}, "Version")
+		},
+	}
+}
@@ -0,0 +1,31 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+)
+
+func addConsumerTokens() *gormigrate.Migration {
+	type ConsumerToken struct {
+		Model
+		ConsumerID   string `gorm:"index;not null"`
+		ConsumerName string `gorm:"not null"`
+		TokenHash    string `gorm:"not null"`
+		ExpiresAt    time.Time
+		RevokedAt    *time.Time
+		CreatedBy    string
+	}
+
+	return &gormigrate.Migration{
+		ID: "202610141330",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&ConsumerToken{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&ConsumerToken{})
+		},
+	}
+}
@@ -0,0 +1,44 @@
+package migrations
+
+import (
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+)
+
+// addResourceConditions adds the conditions summary column of the resources, the column holds the "<type>=<status>"
+// pairs of the reconcile conditions in the resource status and is indexed with a GIN index, so the resources can be
+// filtered by condition with the array containment operator.
+func addResourceConditions() *gormigrate.Migration {
+	type Resource struct {
+		Conditions pq.StringArray `gorm:"type:text[];index:idx_resources_conditions,type:gin"`
+	}
+
+	// summarize the conditions of the existing resource statuses, the single resource conditions are in the manifest
+	// status and the resource bundle conditions are in the manifest bundle status.
+	backfill := `
+UPDATE resources SET conditions = ARRAY(
+	SELECT (c->>'type') || '=' || (c->>'status')
+	FROM json_array_elements(
+		CASE WHEN type = 'Bundle' THEN status->'data'->'conditions' ELSE status->'data'->'status'->'conditions' END
+	) AS c
+	ORDER BY 1
+)
+WHERE json_typeof(
+	CASE WHEN type = 'Bundle' THEN status->'data'->'conditions' ELSE status->'data'->'status'->'conditions' END
+) = 'array';`
+
+	return &gormigrate.Migration{
+		ID: "202610141430",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&Resource{}); err != nil {
+				return err
+			}
+			return tx.Exec(backfill).Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn(&Resource{}, "conditions")
+		},
+	}
+}
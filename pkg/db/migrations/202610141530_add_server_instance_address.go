@@ -0,0 +1,25 @@
+package migrations
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+)
+
+// addServerInstanceAddress adds the advertised gRPC broker address of the server instances, it is used to redirect
+// the agents to the instance that owns their consumers.
+func addServerInstanceAddress() *gormigrate.Migration {
+	type ServerInstance struct {
+		Address string
+	}
+
+	return &gormigrate.Migration{
+		ID: "202610141530",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&ServerInstance{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn(&ServerInstance{}, "address")
+		},
+	}
+}
@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+)
+
+// addResourceLastDispatched adds the maestro instance that last broadcast the status of a resource and when.
+func addResourceLastDispatched() *gormigrate.Migration {
+	type Resource struct {
+		LastDispatchedBy string
+		LastDispatchedAt *time.Time
+	}
+
+	return &gormigrate.Migration{
+		ID: "202610141630",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&Resource{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropColumn(&Resource{}, "last_dispatched_at"); err != nil {
+				return err
+			}
+			return tx.Migrator().DropColumn(&Resource{}, "last_dispatched_by")
+		},
+	}
+}
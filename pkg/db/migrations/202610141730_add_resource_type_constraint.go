@@ -0,0 +1,59 @@
+package migrations
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+)
+
+// addResourceTypeConstraint ensures a resource always has a known type (Single or Bundle). The existing resources
+// without a type are backfilled by the shape of their manifests, a single resource has the "manifest" and a resource
+// bundle has the "manifests" in its payload data. The resources whose type can't be inferred are never removed, the
+// migration fails with their IDs instead, so they can be fixed or removed by the operators before it is rerun.
+func addResourceTypeConstraint() *gormigrate.Migration {
+	backfill := `
+UPDATE resources SET type = CASE
+	WHEN json_typeof(payload->'data'->'manifests') = 'array' THEN 'Bundle'
+	WHEN json_typeof(payload->'data'->'manifest') = 'object' THEN 'Single'
+	ELSE type
+END
+WHERE type IS NULL OR type NOT IN ('Single', 'Bundle');`
+
+	constraint := `
+ALTER TABLE resources
+	ALTER COLUMN type SET DEFAULT 'Single',
+	ALTER COLUMN type SET NOT NULL,
+	ADD CONSTRAINT chk_resources_type CHECK (type IN ('Single', 'Bundle'));`
+
+	return &gormigrate.Migration{
+		ID: "202610141730",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.Exec(backfill).Error; err != nil {
+				return err
+			}
+
+			var invalidIDs []string
+			if err := tx.Table("resources").
+				Where("type IS NULL OR type NOT IN ('Single', 'Bundle')").
+				Pluck("id", &invalidIDs).Error; err != nil {
+				return err
+			}
+			if len(invalidIDs) > 0 {
+				return fmt.Errorf("the type of %d resources can't be inferred from their payloads, fix or remove them "+
+					"before the migration: %s", len(invalidIDs), strings.Join(invalidIDs, ", "))
+			}
+
+			return tx.Exec(constraint).Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Exec(`
+ALTER TABLE resources
+	DROP CONSTRAINT IF EXISTS chk_resources_type,
+	ALTER COLUMN type DROP NOT NULL,
+	ALTER COLUMN type DROP DEFAULT;`).Error
+		},
+	}
+}
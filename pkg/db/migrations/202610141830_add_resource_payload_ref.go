@@ -0,0 +1,24 @@
+package migrations
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+)
+
+// addResourcePayloadRef adds the reference of the resource payload that is offloaded to the object store.
+func addResourcePayloadRef() *gormigrate.Migration {
+	type Resource struct {
+		PayloadRef string
+	}
+
+	return &gormigrate.Migration{
+		ID: "202610141830",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&Resource{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn(&Resource{}, "payload_ref")
+		},
+	}
+}
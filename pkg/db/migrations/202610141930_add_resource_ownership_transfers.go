@@ -0,0 +1,27 @@
+package migrations
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+)
+
+func addResourceOwnershipTransfers() *gormigrate.Migration {
+	type ResourceOwnershipTransfer struct {
+		Model
+		ResourceID    string `gorm:"index;not null"`
+		FromSource    string `gorm:"not null"`
+		ToSource      string `gorm:"not null"`
+		TransferredBy string
+	}
+
+	return &gormigrate.Migration{
+		ID: "202610141930",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&ResourceOwnershipTransfer{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&ResourceOwnershipTransfer{})
+		},
+	}
+}
@@ -0,0 +1,24 @@
+package migrations
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+)
+
+// addResourcePriority adds the dispatch priority of a resource, the existing resources have the default priority.
+func addResourcePriority() *gormigrate.Migration {
+	type Resource struct {
+		Priority int32 `gorm:"not null;default:0"`
+	}
+
+	return &gormigrate.Migration{
+		ID: "202610142030",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&Resource{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn(&Resource{}, "priority")
+		},
+	}
+}
@@ -0,0 +1,31 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+)
+
+// addResourceQuarantine adds the number of the consecutive failed reconcile reports of a resource and the time when
+// the resource is quarantined, the existing resources are not quarantined.
+func addResourceQuarantine() *gormigrate.Migration {
+	type Resource struct {
+		ReconcileFailures int32 `gorm:"not null;default:0"`
+		QuarantinedAt     *time.Time
+	}
+
+	return &gormigrate.Migration{
+		ID: "202610142130",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&Resource{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropColumn(&Resource{}, "quarantined_at"); err != nil {
+				return err
+			}
+			return tx.Migrator().DropColumn(&Resource{}, "reconcile_failures")
+		},
+	}
+}
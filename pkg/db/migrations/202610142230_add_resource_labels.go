@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+)
+
+// addResourceLabels adds the labels column of the resources, the column holds the "<key>=<value>" pairs of the
+// manifest labels that are mirrored to the resource and is indexed with a GIN index, so the resources can be filtered
+// by label with the array containment operator. The column is not backfilled, the labels of an existing resource are
+// mirrored on its next update.
+func addResourceLabels() *gormigrate.Migration {
+	type Resource struct {
+		Labels pq.StringArray `gorm:"type:text[];index:idx_resources_labels,type:gin"`
+	}
+
+	return &gormigrate.Migration{
+		ID: "202610142230",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&Resource{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn(&Resource{}, "labels")
+		},
+	}
+}
@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+)
+
+// addConsumerListIndexes adds the indexes to list the consumers page by page, the name_pattern index matches the
+// consumers by the prefix of their names regardless of the database collation, and the labels GIN index matches the
+// consumers by their labels, the labels column is json, so the jsonb cast of the column is indexed.
+func addConsumerListIndexes() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "202610142330",
+		Migrate: func(tx *gorm.DB) error {
+			for _, stmt := range []string{
+				`CREATE INDEX IF NOT EXISTS idx_consumers_name_pattern ON consumers (name text_pattern_ops);`,
+				`CREATE INDEX IF NOT EXISTS idx_consumers_labels ON consumers USING gin ((labels::jsonb));`,
+			} {
+				if err := tx.Exec(stmt).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Exec(`DROP INDEX IF EXISTS idx_consumers_labels, idx_consumers_name_pattern;`).Error
+		},
+	}
+}
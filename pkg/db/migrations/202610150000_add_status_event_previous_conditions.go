@@ -0,0 +1,27 @@
+package migrations
+
+import (
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+)
+
+// addStatusEventPreviousConditions adds the previous_conditions column of the status events, the column holds the
+// conditions summary of the resource before the status update, so the condition transitions of the update can be
+// told when the status event is broadcast by any maestro instance.
+func addStatusEventPreviousConditions() *gormigrate.Migration {
+	type StatusEvent struct {
+		PreviousConditions pq.StringArray `gorm:"type:text[]"`
+	}
+
+	return &gormigrate.Migration{
+		ID: "202610150000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&StatusEvent{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn(&StatusEvent{}, "previous_conditions")
+		},
+	}
+}
@@ -0,0 +1,26 @@
+package migrations
+
+import (
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+)
+
+// addConsumerFeedbackRules adds the feedback_rules column of the consumers, the column holds the status feedback
+// rules that are merged into the manifests of all the resources of the consumer.
+func addConsumerFeedbackRules() *gormigrate.Migration {
+	type Consumer struct {
+		FeedbackRules datatypes.JSON `gorm:"type:json"`
+	}
+
+	return &gormigrate.Migration{
+		ID: "202610150030",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&Consumer{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn(&Consumer{}, "feedback_rules")
+		},
+	}
+}
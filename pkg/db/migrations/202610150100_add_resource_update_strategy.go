@@ -0,0 +1,35 @@
+package migrations
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+)
+
+// addResourceUpdateStrategy adds the indexed update_strategy column of the resources, the column holds the effective
+// update strategy of the resource so the resources can be filtered by update strategy. The column of the existing
+// single resources is backfilled from their stored manifest configs.
+func addResourceUpdateStrategy() *gormigrate.Migration {
+	type Resource struct {
+		UpdateStrategy string `gorm:"index"`
+	}
+
+	// a single resource without an update strategy is applied with ServerSideApply, the offloaded payloads are not in
+	// the database, so their resources are refreshed once they are updated.
+	backfill := `
+UPDATE resources SET update_strategy = COALESCE(payload->'data'->'configOption'->'updateStrategy'->>'type', 'ServerSideApply')
+WHERE type = 'Single' AND COALESCE(payload_ref, '') = '';`
+
+	return &gormigrate.Migration{
+		ID: "202610150100",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&Resource{}); err != nil {
+				return err
+			}
+			return tx.Exec(backfill).Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn(&Resource{}, "update_strategy")
+		},
+	}
+}
@@ -0,0 +1,25 @@
+package migrations
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+)
+
+// addConsumerDeletePropagationPolicy adds the delete_propagation_policy column of the consumers, the column holds the
+// default delete propagation policy of the resources of the consumer.
+func addConsumerDeletePropagationPolicy() *gormigrate.Migration {
+	type Consumer struct {
+		DeletePropagationPolicy string
+	}
+
+	return &gormigrate.Migration{
+		ID: "202610160100",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&Consumer{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn(&Consumer{}, "delete_propagation_policy")
+		},
+	}
+}
@@ -0,0 +1,29 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+)
+
+func addResourceLocks() *gormigrate.Migration {
+	type ResourceLock struct {
+		Model
+		ResourceID string    `gorm:"uniqueIndex;not null"`
+		Owner      string    `gorm:"not null"`
+		AcquiredAt time.Time `gorm:"not null"`
+		ExpiresAt  time.Time `gorm:"index;not null"`
+	}
+
+	return &gormigrate.Migration{
+		ID: "202610160200",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&ResourceLock{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&ResourceLock{})
+		},
+	}
+}
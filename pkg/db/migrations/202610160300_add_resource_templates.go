@@ -0,0 +1,29 @@
+package migrations
+
+import (
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+)
+
+func addResourceTemplates() *gormigrate.Migration {
+	type ResourceTemplate struct {
+		Model
+		Name        string `gorm:"uniqueIndex;not null"`
+		Description string
+		Manifest    datatypes.JSON `gorm:"type:json;not null"`
+		Parameters  datatypes.JSON `gorm:"type:json"`
+		CreatedBy   string
+	}
+
+	return &gormigrate.Migration{
+		ID: "202610160300",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&ResourceTemplate{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&ResourceTemplate{})
+		},
+	}
+}
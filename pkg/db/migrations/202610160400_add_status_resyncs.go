@@ -0,0 +1,33 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+)
+
+func addStatusResyncs() *gormigrate.Migration {
+	type StatusResync struct {
+		Model
+		State           string    `gorm:"not null"`
+		Consumers       int       `gorm:"not null;default:0"`
+		Processed       int       `gorm:"not null;default:0"`
+		Failed          int       `gorm:"not null;default:0"`
+		StartedAt       time.Time `gorm:"index;not null"`
+		FinishedAt      *time.Time
+		InstanceID      string `gorm:"not null"`
+		CancelRequested bool   `gorm:"not null;default:false"`
+	}
+
+	return &gormigrate.Migration{
+		ID: "202610160400",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&StatusResync{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&StatusResync{})
+		},
+	}
+}
@@ -0,0 +1,33 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+)
+
+// addResourceSpecUpdatedAt adds the spec_updated_at column of the resources, the column holds when the spec of the
+// resource is last changed so the resources that are not reconciled within the reconcile timeout are found regardless
+// of their status updates. The column of the existing resources is backfilled from their updated_at.
+func addResourceSpecUpdatedAt() *gormigrate.Migration {
+	type Resource struct {
+		SpecUpdatedAt *time.Time
+	}
+
+	backfill := `UPDATE resources SET spec_updated_at = updated_at WHERE spec_updated_at IS NULL;`
+
+	return &gormigrate.Migration{
+		ID: "202610160500",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&Resource{}); err != nil {
+				return err
+			}
+			return tx.Exec(backfill).Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn(&Resource{}, "spec_updated_at")
+		},
+	}
+}
@@ -0,0 +1,35 @@
+package migrations
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+)
+
+// addResourceVersionDriftsView adds the resource_version_drifts view of the resources that are not marked as deleting
+// and whose observed version drifts from the resource version, so the drifts can be listed by the generic list with
+// paging, search and ordering. The observed version is the resourceversion extension of the status, it is 0 if the
+// resource has no status yet or its resourceversion is not a number, so a malformed status never fails the view.
+func addResourceVersionDriftsView() *gormigrate.Migration {
+	view := `
+CREATE OR REPLACE VIEW resource_version_drifts AS
+SELECT id AS resource_id, consumer_name, type, version, updated_at, observed_version,
+	CASE WHEN observed_version = 0 THEN 'Unreported' WHEN observed_version < version THEN 'Lagging' ELSE 'Ahead' END AS state,
+	abs(version - observed_version) AS drift
+FROM (
+	SELECT id, consumer_name, type, version, updated_at,
+		CASE WHEN status->>'resourceversion' ~ '^[0-9]{1,18}$' THEN (status->>'resourceversion')::bigint ELSE 0 END AS observed_version
+	FROM resources WHERE deleted_at IS NULL
+) r
+WHERE observed_version <> version;`
+
+	return &gormigrate.Migration{
+		ID: "202610160600",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.Exec(view).Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Exec(`DROP VIEW IF EXISTS resource_version_drifts;`).Error
+		},
+	}
+}
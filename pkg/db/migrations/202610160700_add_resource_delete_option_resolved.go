@@ -0,0 +1,26 @@
+package migrations
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+)
+
+// addResourceDeleteOptionResolved adds the delete_option_resolved column of the resources, the column tells the
+// delete option in the payload is resolved from the consumer policy, so it is resolved again once the policy is changed.
+// The existing resources are not marked, the ones without a delete option are resolved on their next update.
+func addResourceDeleteOptionResolved() *gormigrate.Migration {
+	type Resource struct {
+		DeleteOptionResolved bool `gorm:"not null;default:false"`
+	}
+
+	return &gormigrate.Migration{
+		ID: "202610160700",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&Resource{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn(&Resource{}, "delete_option_resolved")
+		},
+	}
+}
@@ -17,6 +17,7 @@ import (
 //  1. IDs are numerical timestamps that must sort ascending.
 //     Use YYYYMMDDHHMM w/ 24 hour time for format
 //     Example: August 21 2018 at 2:54pm would be 201808211454.
+//     The IDs are validated with ValidateMigrationList before migrating, the migration fails if they are out of order.
 //
 //  2. Include models inline with migrations to see the evolution of the object over time.
 //     Using our internal type models directly in the first migration would fail in future clean installs.
@@ -36,6 +37,54 @@ var MigrationList = []*gormigrate.Migration{
 	addEventInstances(),
 	addLastHeartBeatAndReadyColumnInServerInstancesTable(),
 	alterEventInstances(),
+	addResourceRevisions(),
+	addResourceMetadata(),
+	alterResourceVersion(),
+	addConsumerTokens(),
+	addResourceConditions(),
+	addServerInstanceAddress(),
+	addResourceLastDispatched(),
+	addResourceTypeConstraint(),
+	addResourcePayloadRef(),
+	addResourceOwnershipTransfers(),
+	addResourcePriority(),
+	addResourceQuarantine(),
+	addResourceLabels(),
+	addConsumerListIndexes(),
+	addStatusEventPreviousConditions(),
+	addConsumerFeedbackRules(),
+	addResourceUpdateStrategy(),
+	addConsumerDeletePropagationPolicy(),
+	addResourceLocks(),
+	addResourceTemplates(),
+	addStatusResyncs(),
+	addResourceSpecUpdatedAt(),
+	addResourceVersionDriftsView(),
+	addResourceDeleteOptionResolved(),
+}
+
+// migrationIDFormat is the YYYYMMDDHHMM format of the migration IDs.
+const migrationIDFormat = "200601021504"
+
+// ValidateMigrationList validates the migrations follow the rule 1 of the MigrationList, each migration ID must be a
+// valid YYYYMMDDHHMM timestamp that is strictly greater than the ID of its previous migration, a migration out of order
+// would be applied in a different order on the existing and the new databases.
+func ValidateMigrationList(migrations []*gormigrate.Migration) error {
+	previous := ""
+	for i, migration := range migrations {
+		if len(migration.ID) != len(migrationIDFormat) {
+			return fmt.Errorf("invalid migration ID %q at index %d, it must be in the YYYYMMDDHHMM format", migration.ID, i)
+		}
+		if _, err := time.Parse(migrationIDFormat, migration.ID); err != nil {
+			return fmt.Errorf("invalid migration ID %q at index %d, it must be in the YYYYMMDDHHMM format: %v", migration.ID, i, err)
+		}
+		// the IDs have the same length, so they are compared as strings
+		if migration.ID <= previous {
+			return fmt.Errorf("migration ID %q at index %d must be greater than the previous migration ID %q", migration.ID, i, previous)
+		}
+		previous = migration.ID
+	}
+	return nil
 }
 
 // Model represents the base model struct. All entities will have this struct embedded.
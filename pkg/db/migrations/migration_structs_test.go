@@ -0,0 +1,51 @@
+package migrations
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+)
+
+func TestMigrationList(t *testing.T) {
+	if err := ValidateMigrationList(MigrationList); err != nil {
+		t.Errorf("invalid MigrationList: %v", err)
+	}
+}
+
+func TestValidateMigrationList(t *testing.T) {
+	cases := []struct {
+		name          string
+		ids           []string
+		expectedError string
+	}{
+		{name: "ascending ids", ids: []string{"202401151014", "202406241426", "202406241506"}},
+		{name: "out of order id", ids: []string{"202401151014", "202406241506", "202406241426"},
+			expectedError: `migration ID "202406241426" at index 2 must be greater than the previous migration ID "202406241506"`},
+		{name: "duplicated id", ids: []string{"202401151014", "202401151014"},
+			expectedError: `migration ID "202401151014" at index 1 must be greater than the previous migration ID "202401151014"`},
+		{name: "short id", ids: []string{"20240115"},
+			expectedError: `invalid migration ID "20240115" at index 0, it must be in the YYYYMMDDHHMM format`},
+		{name: "invalid timestamp", ids: []string{"202413151014"},
+			expectedError: `invalid migration ID "202413151014" at index 0, it must be in the YYYYMMDDHHMM format`},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			migrations := []*gormigrate.Migration{}
+			for _, id := range c.ids {
+				migrations = append(migrations, &gormigrate.Migration{ID: id})
+			}
+			err := ValidateMigrationList(migrations)
+			if c.expectedError == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.HasPrefix(err.Error(), c.expectedError) {
+				t.Errorf("expected error %q, but got %v", c.expectedError, err)
+			}
+		})
+	}
+}
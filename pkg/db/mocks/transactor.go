@@ -0,0 +1,18 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/openshift-online/maestro/pkg/db"
+)
+
+// MockTransactor runs the functions without a transaction, the mock DAOs are not transactional.
+type MockTransactor struct{}
+
+func NewMockTransactor() db.Transactor {
+	return &MockTransactor{}
+}
+
+func (t *MockTransactor) Transact(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
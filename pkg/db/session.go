@@ -14,6 +14,10 @@ type SessionFactory interface {
 	Init(*config.DatabaseConfig)
 	DirectDB() *sql.DB
 	New(ctx context.Context) *gorm.DB
+	// NewReadOnly returns a session for the read-only queries, e.g. the lists and searches. It is connected to the
+	// read replica of the database if the replica is configured, otherwise it is the same as New. The replica can lag
+	// behind the primary, so the queries that must see the latest writes use the New session.
+	NewReadOnly(ctx context.Context) *gorm.DB
 	CheckConnection() error
 	Close() error
 	ResetDB()
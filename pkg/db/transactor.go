@@ -0,0 +1,51 @@
+package db
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	dbContext "github.com/openshift-online/maestro/pkg/db/db_context"
+)
+
+// Transactor runs a function in a database transaction, the sessions created from the context passed to the function
+// join the transaction, so the changes of the DAOs in the function are committed together or rolled back once the
+// function returns an error. A nested Transact joins the transaction of its context.
+type Transactor interface {
+	Transact(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type sessionTransactor struct {
+	sessionFactory SessionFactory
+}
+
+// NewTransactor returns a transactor that begins the transactions with the sessions of the session factory.
+func NewTransactor(sessionFactory SessionFactory) Transactor {
+	return &sessionTransactor{sessionFactory: sessionFactory}
+}
+
+func (t *sessionTransactor) Transact(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := dbContext.Session(ctx); ok {
+		return fn(ctx)
+	}
+
+	hooks := &dbContext.AfterCommitHooks{}
+	if err := t.sessionFactory.New(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(dbContext.WithAfterCommitHooks(dbContext.WithSession(ctx, tx), hooks))
+	}); err != nil {
+		return err
+	}
+	hooks.Run()
+	return nil
+}
+
+// AfterCommit runs fn once the changes made with the context are committed, e.g. to delete the external data that is
+// no longer referenced by the committed rows. fn is not run if the transaction of the context is rolled back, and it
+// runs right away if the context has no transaction, since the changes are committed as they are made.
+func AfterCommit(ctx context.Context, fn func()) {
+	if hooks, ok := dbContext.AfterCommit(ctx); ok {
+		hooks.Add(fn)
+		return
+	}
+	fn()
+}
@@ -3,14 +3,11 @@ package dispatcher
 import (
 	"context"
 	"fmt"
-	"strings"
 	"sync"
 	"time"
 
-	"github.com/buraksezer/consistent"
 	"github.com/cespare/xxhash"
 	mapset "github.com/deckarep/golang-set/v2"
-	"github.com/openshift-online/maestro/pkg/api"
 	"github.com/openshift-online/maestro/pkg/client/cloudevents"
 	"github.com/openshift-online/maestro/pkg/config"
 	"github.com/openshift-online/maestro/pkg/dao"
@@ -29,30 +26,26 @@ var _ Dispatcher = &HashDispatcher{}
 type HashDispatcher struct {
 	instanceID     string
 	sessionFactory db.SessionFactory
-	instanceDao    dao.InstanceDao
 	consumerDao    dao.ConsumerDao
 	sourceClient   cloudevents.SourceClient
 	consumerSet    mapset.Set[string]
 	workQueue      workqueue.RateLimitingInterface
-	consistent     *consistent.Consistent
+	ring           *HashRing
 }
 
 func NewHashDispatcher(instanceID string, sessionFactory db.SessionFactory, sourceClient cloudevents.SourceClient, consistentHashingConfig *config.ConsistentHashConfig) *HashDispatcher {
-	return &HashDispatcher{
+	d := &HashDispatcher{
 		instanceID:     instanceID,
 		sessionFactory: sessionFactory,
-		instanceDao:    dao.NewInstanceDao(&sessionFactory),
 		consumerDao:    dao.NewConsumerDao(&sessionFactory),
 		sourceClient:   sourceClient,
 		consumerSet:    mapset.NewSet[string](),
 		workQueue:      workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "hash-dispatcher"),
-		consistent: consistent.New(nil, consistent.Config{
-			PartitionCount:    consistentHashingConfig.PartitionCount,
-			ReplicationFactor: consistentHashingConfig.ReplicationFactor,
-			Load:              consistentHashingConfig.Load,
-			Hasher:            hasher{},
-		}),
+		ring:           NewHashRing(dao.NewInstanceDao(&sessionFactory), consistentHashingConfig),
 	}
+	// update the consumer set for the current instance once the hashing ring is changed
+	d.ring.onChange = d.updateConsumerSet
+	return d
 }
 
 // Start initializes and runs the dispatcher, updating the hashing ring and consumer set for the current instance.
@@ -65,7 +58,7 @@ func (d *HashDispatcher) Start(ctx context.Context) {
 
 	// listen for server_instance update
 	klog.Infof("HashDispatcher listening for server_instances updates")
-	go d.sessionFactory.NewListener(ctx, "server_instances", d.onInstanceUpdate)
+	go d.sessionFactory.NewListener(ctx, "server_instances", d.ring.onInstanceUpdate)
 
 	// start a goroutine to resync current consumers for this source when the client is reconnected
 	go d.resyncOnReconnect(ctx)
@@ -75,28 +68,6 @@ func (d *HashDispatcher) Start(ctx context.Context) {
 	d.workQueue.ShutDown()
 }
 
-func (d *HashDispatcher) onInstanceUpdate(ids string) {
-	states := strings.Split(ids, ":")
-	if len(states) != 2 {
-		klog.Infof("watched server instances updated with invalid ids: %s", ids)
-		return
-	}
-	idList := strings.Split(states[1], ",")
-	if states[0] == "ready" {
-		for _, id := range idList {
-			if err := d.onInstanceUp(id); err != nil {
-				klog.Errorf("failed to call OnInstancesUp for instance %s: %s", id, err)
-			}
-		}
-	} else {
-		for _, id := range idList {
-			if err := d.onInstanceDown(id); err != nil {
-				klog.Errorf("failed to call OnInstancesDown for instance %s: %s", id, err)
-			}
-		}
-	}
-}
-
 // resyncOnReconnect listens for the client reconnected signal and resyncs current consumers for this source.
 func (d *HashDispatcher) resyncOnReconnect(ctx context.Context) {
 	log := logger.NewOCMLogger(ctx)
@@ -121,53 +92,10 @@ func (d *HashDispatcher) Dispatch(consumerName string) bool {
 	return d.consumerSet.Contains(consumerName)
 }
 
-// onInstanceUp adds the new instance to the hashing ring and updates the consumer set for the current instance.
-func (d *HashDispatcher) onInstanceUp(instanceID string) error {
-	members := d.consistent.GetMembers()
-	for _, member := range members {
-		if member.String() == instanceID {
-			// instance already exists, hashing ring won't be changed
-			return nil
-		}
-	}
-
-	// add the new instance to the hashing ring
-	d.consistent.Add(&api.ServerInstance{
-		Meta: api.Meta{
-			ID: instanceID,
-		},
-	})
-
-	return d.updateConsumerSet()
-}
-
-// onInstanceDown removes the instance from the hashing ring and updates the consumer set for the current instance.
-func (d *HashDispatcher) onInstanceDown(instanceID string) error {
-	members := d.consistent.GetMembers()
-	deletedMember := true
-	for _, member := range members {
-		if member.String() == instanceID {
-			// the instance is still in the hashing ring
-			deletedMember = false
-			break
-		}
-	}
-
-	// if the instance is already deleted, the hash ring won't be changed
-	if deletedMember {
-		return nil
-	}
-
-	// remove the instance from the hashing ring
-	d.consistent.Remove(instanceID)
-
-	return d.updateConsumerSet()
-}
-
 // updateConsumerSet updates the consumer set for the current instance based on the hashing ring.
 func (d *HashDispatcher) updateConsumerSet() error {
 	// return if the hashing ring is not ready
-	if d.consistent == nil || len(d.consistent.GetMembers()) == 0 {
+	if len(d.ring.consistent.GetMembers()) == 0 {
 		return nil
 	}
 
@@ -180,9 +108,16 @@ func (d *HashDispatcher) updateConsumerSet() error {
 		return fmt.Errorf("unable to list consumers: %s", err.Error())
 	}
 
+	// count the consumers owned by each instance, including the instances that own no consumer
+	ownedConsumers := map[string]int{}
+	for _, member := range d.ring.members() {
+		ownedConsumers[member] = 0
+	}
+
 	toAddConsumers, toRemoveConsumers := []string{}, []string{}
 	for _, consumer := range consumers {
-		instanceID := d.consistent.LocateKey([]byte(consumer.Name)).String()
+		instanceID := d.ring.consistent.LocateKey([]byte(consumer.Name)).String()
+		ownedConsumers[instanceID]++
 		if instanceID == d.instanceID {
 			if !d.consumerSet.Contains(consumer.Name) {
 				// new consumer added to the current instance, need to resync resource status updates for this consumer
@@ -199,6 +134,7 @@ func (d *HashDispatcher) updateConsumerSet() error {
 
 	_ = d.consumerSet.Append(toAddConsumers...)
 	d.consumerSet.RemoveAll(toRemoveConsumers...)
+	setOwnedConsumersMetric(ownedConsumers)
 	log.V(4).Infof("Consumers set for current instance: %s", d.consumerSet.String())
 
 	return nil
@@ -223,25 +159,10 @@ func (d *HashDispatcher) startStatusResyncWorkers(ctx context.Context) {
 func (d *HashDispatcher) check(ctx context.Context) {
 	log := logger.NewOCMLogger(ctx)
 
-	instances, err := d.instanceDao.All(ctx)
-	if err != nil {
-		log.Error(fmt.Sprintf("Unable to get all maestro instances: %s", err.Error()))
-		return
-	}
-
 	// ensure the hashing ring members are up-to-date
-	members := d.consistent.GetMembers()
-	for _, member := range members {
-		isMemberActive := false
-		for _, instance := range instances {
-			if member.String() == instance.ID {
-				isMemberActive = true
-				break
-			}
-		}
-		if !isMemberActive {
-			d.consistent.Remove(member.String())
-		}
+	if err := d.ring.check(ctx); err != nil {
+		log.Error(err.Error())
+		return
 	}
 
 	if err := d.updateConsumerSet(); err != nil {
@@ -0,0 +1,256 @@
+package dispatcher
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/buraksezer/consistent"
+	mapset "github.com/deckarep/golang-set/v2"
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/config"
+	"github.com/openshift-online/maestro/pkg/dao"
+	"github.com/openshift-online/maestro/pkg/db"
+	"github.com/openshift-online/maestro/pkg/logger"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+// HashRing is the live consistent hashing ring of the maestro instances, it maps each consumer to the instance that
+// owns it. An instance that becomes ready is admitted into the ring after the warm-up delay, so the consumers are only
+// mapped to the instance after it is truly serving.
+type HashRing struct {
+	instanceDao dao.InstanceDao
+	consistent  *consistent.Consistent
+	// warmupDelay is the delay after an instance becomes ready before it is admitted into the hashing ring.
+	warmupDelay      time.Duration
+	warmingInstances mapset.Set[string]
+	// onChange is called after the members of the hashing ring are changed.
+	onChange func() error
+
+	mu sync.RWMutex
+	// addresses caches the advertised addresses of the instances, it is refreshed when the instances are checked.
+	addresses map[string]string
+}
+
+// NewHashRing creates an empty hashing ring with the consistent hashing configuration.
+func NewHashRing(instanceDao dao.InstanceDao, consistentHashingConfig *config.ConsistentHashConfig) *HashRing {
+	return &HashRing{
+		instanceDao: instanceDao,
+		consistent: consistent.New(nil, consistent.Config{
+			PartitionCount:    consistentHashingConfig.PartitionCount,
+			ReplicationFactor: consistentHashingConfig.ReplicationFactor,
+			Load:              consistentHashingConfig.Load,
+			Hasher:            hasher{},
+		}),
+		warmupDelay:      consistentHashingConfig.WarmupDelay,
+		warmingInstances: mapset.NewSet[string](),
+		onChange:         func() error { return nil },
+		addresses:        map[string]string{},
+	}
+}
+
+// Start keeps the hashing ring up-to-date by listening for the server_instances updates and periodically checking
+// the instances.
+func (r *HashRing) Start(ctx context.Context, sessionFactory db.SessionFactory) {
+	if err := r.admitReadyInstances(ctx); err != nil {
+		logger.NewOCMLogger(ctx).Error(err.Error())
+	}
+
+	go wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := r.check(ctx); err != nil {
+			logger.NewOCMLogger(ctx).Error(err.Error())
+		}
+	}, 5*time.Second)
+
+	klog.Infof("HashRing listening for server_instances updates")
+	go sessionFactory.NewListener(ctx, "server_instances", r.onInstanceUpdate)
+
+	// wait until context is canceled
+	<-ctx.Done()
+}
+
+// Locate returns the instance that owns the consumer on the hashing ring, the address of the instance is empty if it
+// is not known yet. It returns nil if there is no instance on the ring.
+func (r *HashRing) Locate(consumerName string) *api.ServerInstance {
+	if len(r.consistent.GetMembers()) == 0 {
+		return nil
+	}
+
+	instanceID := r.consistent.LocateKey([]byte(consumerName)).String()
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return &api.ServerInstance{
+		Meta: api.Meta{
+			ID: instanceID,
+		},
+		Address: r.addresses[instanceID],
+	}
+}
+
+func (r *HashRing) onInstanceUpdate(ids string) {
+	states := strings.Split(ids, ":")
+	if len(states) != 2 {
+		klog.Infof("watched server instances updated with invalid ids: %s", ids)
+		return
+	}
+	idList := strings.Split(states[1], ",")
+	if states[0] == "ready" {
+		for _, id := range idList {
+			if err := r.onInstanceUp(id); err != nil {
+				klog.Errorf("failed to call OnInstancesUp for instance %s: %s", id, err)
+			}
+		}
+	} else {
+		for _, id := range idList {
+			if err := r.onInstanceDown(id); err != nil {
+				klog.Errorf("failed to call OnInstancesDown for instance %s: %s", id, err)
+			}
+		}
+	}
+}
+
+// onInstanceUp adds the new instance to the hashing ring. If the warm-up delay is set, the instance is admitted into
+// the hashing ring after the delay.
+func (r *HashRing) onInstanceUp(instanceID string) error {
+	if r.warmupDelay <= 0 {
+		return r.addInstance(instanceID)
+	}
+
+	if r.isMember(instanceID) {
+		// instance already exists, hashing ring won't be changed
+		return nil
+	}
+
+	if !r.warmingInstances.Add(instanceID) {
+		// the instance is already warming up
+		return nil
+	}
+
+	klog.Infof("instance %s is ready, admit it into the hashing ring after %s", instanceID, r.warmupDelay)
+	time.AfterFunc(r.warmupDelay, func() {
+		r.admitInstance(instanceID)
+	})
+	return nil
+}
+
+// admitInstance adds the warmed up instance to the hashing ring if the instance is still ready.
+func (r *HashRing) admitInstance(instanceID string) {
+	defer r.warmingInstances.Remove(instanceID)
+
+	instance, err := r.instanceDao.Get(context.TODO(), instanceID)
+	if err != nil {
+		klog.Errorf("failed to get instance %s after warm-up: %s", instanceID, err)
+		return
+	}
+
+	if !instance.Ready {
+		klog.Infof("instance %s is not ready after warm-up, skip admitting it into the hashing ring", instanceID)
+		return
+	}
+
+	r.setAddress(instanceID, instance.Address)
+	if err := r.addInstance(instanceID); err != nil {
+		klog.Errorf("failed to admit instance %s into the hashing ring: %s", instanceID, err)
+	}
+}
+
+// addInstance adds the instance to the hashing ring.
+func (r *HashRing) addInstance(instanceID string) error {
+	if r.isMember(instanceID) {
+		// instance already exists, hashing ring won't be changed
+		return nil
+	}
+
+	r.consistent.Add(&api.ServerInstance{
+		Meta: api.Meta{
+			ID: instanceID,
+		},
+	})
+
+	return r.onChange()
+}
+
+// onInstanceDown removes the instance from the hashing ring.
+func (r *HashRing) onInstanceDown(instanceID string) error {
+	// if the instance is already deleted, the hash ring won't be changed
+	if !r.isMember(instanceID) {
+		return nil
+	}
+
+	r.consistent.Remove(instanceID)
+
+	return r.onChange()
+}
+
+// isMember checks if the instance is in the hashing ring.
+func (r *HashRing) isMember(instanceID string) bool {
+	for _, member := range r.consistent.GetMembers() {
+		if member.String() == instanceID {
+			return true
+		}
+	}
+	return false
+}
+
+// members returns the IDs of the instances in the hashing ring.
+func (r *HashRing) members() []string {
+	members := []string{}
+	for _, member := range r.consistent.GetMembers() {
+		members = append(members, member.String())
+	}
+	return members
+}
+
+// check removes the instances that no longer exist from the hashing ring and refreshes the instance addresses.
+func (r *HashRing) check(ctx context.Context) error {
+	instances, err := r.instanceDao.All(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to get all maestro instances: %s", err.Error())
+	}
+
+	addresses := map[string]string{}
+	for _, instance := range instances {
+		addresses[instance.ID] = instance.Address
+	}
+	r.mu.Lock()
+	r.addresses = addresses
+	r.mu.Unlock()
+
+	// ensure the hashing ring members are up-to-date
+	for _, member := range r.consistent.GetMembers() {
+		if _, ok := addresses[member.String()]; !ok {
+			r.consistent.Remove(member.String())
+		}
+	}
+
+	return nil
+}
+
+// admitReadyInstances admits the instances that are already ready into the hashing ring without the warm-up, they were
+// warmed up before the ring is started.
+func (r *HashRing) admitReadyInstances(ctx context.Context) error {
+	instances, err := r.instanceDao.All(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to get all maestro instances: %s", err.Error())
+	}
+
+	for _, instance := range instances {
+		r.setAddress(instance.ID, instance.Address)
+		if instance.Ready {
+			if err := r.addInstance(instance.ID); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *HashRing) setAddress(instanceID, address string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.addresses[instanceID] = address
+}
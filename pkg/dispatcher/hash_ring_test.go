@@ -0,0 +1,129 @@
+package dispatcher
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/config"
+	"github.com/openshift-online/maestro/pkg/dao/mocks"
+)
+
+func newTestHashRing(t *testing.T, warmupDelay time.Duration, instances ...*api.ServerInstance) *HashRing {
+	instanceDao := mocks.NewInstanceDao()
+	for _, instance := range instances {
+		if _, err := instanceDao.Create(context.Background(), instance); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	consistentHashConfig := config.NewConsistentHashConfig()
+	consistentHashConfig.WarmupDelay = warmupDelay
+	return NewHashRing(instanceDao, consistentHashConfig)
+}
+
+func TestHashRingLocate(t *testing.T) {
+	ring := newTestHashRing(t, 0,
+		&api.ServerInstance{Meta: api.Meta{ID: "maestro-1"}, Address: "maestro-1:8091", Ready: true},
+		&api.ServerInstance{Meta: api.Meta{ID: "maestro-2"}, Address: "maestro-2:8091", Ready: true},
+		&api.ServerInstance{Meta: api.Meta{ID: "maestro-3"}, Address: "maestro-3:8091"},
+	)
+
+	if owner := ring.Locate("cluster1"); owner != nil {
+		t.Fatalf("expected no owner on the empty ring, but got %s", owner.ID)
+	}
+
+	if err := ring.admitReadyInstances(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, consumerName := range []string{"cluster1", "cluster2", "cluster3", "cluster4"} {
+		owner := ring.Locate(consumerName)
+		if owner == nil {
+			t.Fatalf("expected the owner of %s is located", consumerName)
+		}
+		if owner.ID == "maestro-3" {
+			t.Errorf("expected the unready instance doesn't own %s", consumerName)
+		}
+		if owner.Address != owner.ID+":8091" {
+			t.Errorf("unexpected address %s of the owner %s", owner.Address, owner.ID)
+		}
+		// the owner is stable as long as the ring is not changed
+		if again := ring.Locate(consumerName); again.ID != owner.ID {
+			t.Errorf("expected the owner of %s is %s, but got %s", consumerName, owner.ID, again.ID)
+		}
+	}
+
+	// the consumers are moved to the remaining instance once an instance is down
+	if err := ring.onInstanceDown("maestro-2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if owner := ring.Locate("cluster1"); owner == nil || owner.ID != "maestro-1" {
+		t.Errorf("expected the owner is maestro-1, but got %v", owner)
+	}
+}
+
+func TestHashRingWarmup(t *testing.T) {
+	ring := newTestHashRing(t, 100*time.Millisecond,
+		&api.ServerInstance{Meta: api.Meta{ID: "maestro-1"}, Address: "maestro-1:8091", Ready: true},
+	)
+
+	if err := ring.onInstanceUp("maestro-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the instance is not on the ring until it is warmed up
+	if owner := ring.Locate("cluster1"); owner != nil {
+		t.Fatalf("expected no owner while the instance is warming up, but got %s", owner.ID)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for ring.Locate("cluster1") == nil {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the instance is admitted into the ring after the warm-up")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if owner := ring.Locate("cluster1"); owner.ID != "maestro-1" || owner.Address != "maestro-1:8091" {
+		t.Errorf("unexpected owner %v", owner)
+	}
+}
+
+func TestHashRingCheck(t *testing.T) {
+	ring := newTestHashRing(t, 0,
+		&api.ServerInstance{Meta: api.Meta{ID: "maestro-1"}, Ready: true},
+	)
+
+	// the address is unknown until the instances are checked
+	if err := ring.onInstanceUp("maestro-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if owner := ring.Locate("cluster1"); owner == nil || owner.Address != "" {
+		t.Fatalf("expected the owner without address, but got %v", owner)
+	}
+
+	instance, err := ring.instanceDao.Get(context.Background(), "maestro-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	instance.Address = "maestro-1:8091"
+	if err := ring.check(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if owner := ring.Locate("cluster1"); owner == nil || owner.Address != "maestro-1:8091" {
+		t.Fatalf("expected the owner address is refreshed, but got %v", owner)
+	}
+
+	// the deleted instance is removed from the ring
+	if err := ring.instanceDao.Delete(context.Background(), "maestro-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := ring.check(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if owner := ring.Locate("cluster1"); owner != nil {
+		t.Errorf("expected no owner after the instance is deleted, but got %s", owner.ID)
+	}
+}
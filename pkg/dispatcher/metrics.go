@@ -0,0 +1,57 @@
+package dispatcher
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	// Register the metrics for the hash dispatcher
+	RegisterHashDispatcherMetrics()
+}
+
+// Subsystem used to define the metrics:
+const metricsSubsystem = "hash_dispatcher"
+
+// Names of the labels added to metrics:
+const (
+	metricsInstanceIDLabel = "instance_id"
+)
+
+// Names of the metrics:
+const (
+	ownedConsumersMetric = "owned_consumers"
+)
+
+// Register the metrics:
+func RegisterHashDispatcherMetrics() {
+	prometheus.MustRegister(hashDispatcherOwnedConsumersMetric)
+}
+
+// Unregister the metrics:
+func UnregisterHashDispatcherMetrics() {
+	prometheus.Unregister(hashDispatcherOwnedConsumersMetric)
+}
+
+// Reset the metrics:
+func ResetHashDispatcherMetrics() {
+	hashDispatcherOwnedConsumersMetric.Reset()
+}
+
+// setOwnedConsumersMetric exports the number of consumers owned by each instance of the hashing ring, the instances
+// that are removed from the ring are removed from the metric.
+func setOwnedConsumersMetric(ownedConsumers map[string]int) {
+	hashDispatcherOwnedConsumersMetric.Reset()
+	for instanceID, count := range ownedConsumers {
+		hashDispatcherOwnedConsumersMetric.WithLabelValues(instanceID).Set(float64(count))
+	}
+}
+
+// Description of the hash dispatcher owned consumers metric:
+var hashDispatcherOwnedConsumersMetric = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Subsystem: metricsSubsystem,
+		Name:      ownedConsumersMetric,
+		Help:      "Number of consumers owned by each maestro instance, derived from the live hashing ring.",
+	},
+	[]string{metricsInstanceIDLabel},
+)
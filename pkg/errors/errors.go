@@ -57,6 +57,15 @@ const (
 
 	// DatabaseAdvisoryLock occurs whe the advisory lock is failed to get
 	ErrorDatabaseAdvisoryLock ServiceErrorCode = 26
+
+	// Timeout occurs when a request is not handled before the handler timeout
+	ErrorTimeout ServiceErrorCode = 27
+
+	// Unavailable occurs when a request cannot be handled for now, e.g. the database migrations are not complete
+	ErrorUnavailable ServiceErrorCode = 28
+
+	// Locked occurs when a resource is locked by another owner, see the resource soft-locks
+	ErrorLocked ServiceErrorCode = 29
 )
 
 type ServiceErrorCode int
@@ -87,6 +96,9 @@ func Errors() ServiceErrors {
 		ServiceError{ErrorBadRequest, "Bad request", http.StatusBadRequest},
 		ServiceError{ErrorFailedToParseSearch, "Failed to parse search query", http.StatusBadRequest},
 		ServiceError{ErrorDatabaseAdvisoryLock, "Database advisory lock error", http.StatusInternalServerError},
+		ServiceError{ErrorTimeout, "Request timed out", http.StatusGatewayTimeout},
+		ServiceError{ErrorUnavailable, "Service unavailable", http.StatusServiceUnavailable},
+		ServiceError{ErrorLocked, "Resource is locked", http.StatusLocked},
 	}
 }
 
@@ -137,14 +149,38 @@ func (e *ServiceError) IsForbidden() bool {
 	return e.Code == Forbidden("").Code
 }
 
+// IsUnavailable tells whether the request can be retried later, e.g. the database circuit breaker is open.
+func (e *ServiceError) IsUnavailable() bool {
+	return e.Code == Unavailable("").Code
+}
+
+func (e *ServiceError) IsLocked() bool {
+	return e.Code == Locked("").Code
+}
+
+func (e *ServiceError) IsValidation() bool {
+	return e.Code == Validation("").Code
+}
+
+// AsOpenapiError converts the error to the problem-detail body returned for all the failed API requests, the
+// operationID is used as the correlation ID of the error.
 func (e *ServiceError) AsOpenapiError(operationID string) openapi.Error {
+	// the message is the general description of the error code, while the reason is context-specific
+	message := e.Reason
+	if exists, defaultErr := Find(e.Code); exists {
+		message = defaultErr.Reason
+	}
+
 	return openapi.Error{
-		Kind:        openapi.PtrString("Error"),
-		Id:          openapi.PtrString(strconv.Itoa(int(e.Code))),
-		Href:        Href(e.Code),
-		Code:        CodeStr(e.Code),
-		Reason:      openapi.PtrString(e.Reason),
-		OperationId: openapi.PtrString(operationID),
+		Kind:          openapi.PtrString("Error"),
+		Id:            openapi.PtrString(strconv.Itoa(int(e.Code))),
+		Href:          Href(e.Code),
+		Code:          CodeStr(e.Code),
+		Reason:        openapi.PtrString(e.Reason),
+		OperationId:   openapi.PtrString(operationID),
+		Message:       openapi.PtrString(message),
+		Status:        openapi.PtrInt32(int32(e.HttpCode)),
+		CorrelationId: openapi.PtrString(operationID),
 	}
 }
 
@@ -201,6 +237,18 @@ func FailedToParseSearch(reason string, values ...interface{}) *ServiceError {
 	return New(ErrorFailedToParseSearch, message, values...)
 }
 
+func Timeout(reason string, values ...interface{}) *ServiceError {
+	return New(ErrorTimeout, reason, values...)
+}
+
+func Unavailable(reason string, values ...interface{}) *ServiceError {
+	return New(ErrorUnavailable, reason, values...)
+}
+
+func Locked(reason string, values ...interface{}) *ServiceError {
+	return New(ErrorLocked, reason, values...)
+}
+
 func DatabaseAdvisoryLock(err error) *ServiceError {
 	return New(ErrorDatabaseAdvisoryLock, err.Error(), []string{})
 }
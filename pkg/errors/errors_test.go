@@ -23,3 +23,34 @@ func TestErrorFind(t *testing.T) {
 	Expect(exists).To(Equal(false))
 	Expect(err).To(BeNil())
 }
+
+func TestAsOpenapiError(t *testing.T) {
+	RegisterTestingT(t)
+
+	cases := []struct {
+		err     *ServiceError
+		status  int32
+		code    string
+		message string
+	}{
+		{err: NotFound("Resource with id='foo' not found"), status: 404, code: "maestro-7", message: "Resource not found"},
+		{err: Conflict("consumer foo already exists"), status: 409, code: "maestro-6", message: "An entity with the specified unique values already exists"},
+		{err: Validation("name is required"), status: 400, code: "maestro-8", message: "General validation failure"},
+		{err: GeneralError("database is unavailable"), status: 500, code: "maestro-9", message: "Unspecified error"},
+		{err: Timeout("the request is not handled in 30s"), status: 504, code: "maestro-27", message: "Request timed out"},
+		{err: Unavailable("the database migrations are not complete"), status: 503, code: "maestro-28", message: "Service unavailable"},
+		{err: Locked("the resource is locked by alice"), status: 423, code: "maestro-29", message: "Resource is locked"},
+	}
+
+	for _, c := range cases {
+		openapiErr := c.err.AsOpenapiError("op-1")
+		Expect(*openapiErr.Kind).To(Equal("Error"))
+		Expect(*openapiErr.Code).To(Equal(c.code))
+		Expect(*openapiErr.Href).To(Equal("/api/maestro/v1/errors/" + *openapiErr.Id))
+		Expect(*openapiErr.Reason).To(Equal(c.err.Reason))
+		Expect(*openapiErr.Message).To(Equal(c.message))
+		Expect(*openapiErr.Status).To(Equal(c.status))
+		Expect(*openapiErr.CorrelationId).To(Equal("op-1"))
+		Expect(*openapiErr.OperationId).To(Equal("op-1"))
+	}
+}
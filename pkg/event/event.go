@@ -2,21 +2,67 @@ package event
 
 import (
 	"context"
+	"fmt"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/prometheus/client_golang/prometheus"
 	"k8s.io/klog/v2"
 )
 
+func init() {
+	// Register the metrics for event broadcaster
+	RegisterBroadcasterMetrics()
+}
+
+// OverflowPolicy is the policy of the event broadcaster when its buffer is full.
+type OverflowPolicy string
+
+const (
+	// OverflowPolicyBlock blocks the broadcast until there is room in the buffer.
+	OverflowPolicyBlock OverflowPolicy = "block"
+	// OverflowPolicyDropOldest drops the oldest buffered event to make room for the new event.
+	OverflowPolicyDropOldest OverflowPolicy = "drop-oldest"
+	// OverflowPolicyDropNewest drops the new event.
+	OverflowPolicyDropNewest OverflowPolicy = "drop-newest"
+)
+
+// ParseOverflowPolicy parses the overflow policy of the event broadcaster.
+func ParseOverflowPolicy(policy string) (OverflowPolicy, error) {
+	switch p := OverflowPolicy(policy); p {
+	case OverflowPolicyBlock, OverflowPolicyDropOldest, OverflowPolicyDropNewest:
+		return p, nil
+	default:
+		return "", fmt.Errorf("unsupported broadcaster overflow policy %q, must be one of %s, %s or %s",
+			policy, OverflowPolicyBlock, OverflowPolicyDropOldest, OverflowPolicyDropNewest)
+	}
+}
+
+// AllClusters is the wildcard cluster name of a subscription, the client registered with it (or an empty cluster name)
+// receives the resource status change events of all the clusters of its source, the same as the "+" of the status
+// topic pattern "sources/<source>/clusters/+/status".
+const AllClusters = "+"
+
 // resourceHandler is a function that can handle resource status change events.
 type resourceHandler func(res *api.Resource) error
 
 // eventClient is a client that can receive and handle resource status change events.
 type eventClient struct {
-	source  string
-	handler resourceHandler
-	errChan chan<- error
+	source      string
+	clusterName string
+	handler     resourceHandler
+	errChan     chan<- error
+}
+
+// matches returns whether the resource is from the source and the cluster that the client subscribes to, a client
+// with the wildcard cluster name matches all the clusters of the source.
+func (c *eventClient) matches(res *api.Resource) bool {
+	if c.source != res.Source {
+		return false
+	}
+	return c.clusterName == AllClusters || c.clusterName == res.ConsumerName
 }
 
 // EventBroadcaster is a component that can broadcast resource status change events to registered clients.
@@ -26,32 +72,53 @@ type EventBroadcaster struct {
 	// registered clients.
 	clients map[string]*eventClient
 
-	// inbound messages from the clients.
+	// inbound messages from the clients, buffered up to the buffer size.
 	broadcast chan *api.Resource
+
+	// overflowPolicy is the policy when the broadcast buffer is full.
+	overflowPolicy OverflowPolicy
+
+	suspendMu sync.Mutex
+	// suspendedUntil is the time when the suspended broadcaster is resumed automatically, zero if the broadcaster is
+	// not suspended.
+	suspendedUntil time.Time
+	resumeTimer    *time.Timer
+	// pending are the latest events of the resources broadcast while the broadcaster is suspended, in the order of
+	// their first broadcast.
+	pending    map[string]*api.Resource
+	pendingIDs []string
 }
 
-// NewEventBroadcaster creates a new event broadcaster.
-func NewEventBroadcaster() *EventBroadcaster {
+// NewEventBroadcaster creates a new event broadcaster with the given buffer size and overflow policy.
+func NewEventBroadcaster(bufferSize int, overflowPolicy OverflowPolicy) *EventBroadcaster {
 	return &EventBroadcaster{
-		clients:   make(map[string]*eventClient),
-		broadcast: make(chan *api.Resource),
+		clients:        make(map[string]*eventClient),
+		broadcast:      make(chan *api.Resource, bufferSize),
+		overflowPolicy: overflowPolicy,
 	}
 }
 
-// Register registers a client and return client id and error channel.
-func (h *EventBroadcaster) Register(source string, handler resourceHandler) (string, <-chan error) {
+// Register registers a client for the resource status change events of the given source and cluster, and return client
+// id and error channel. The client receives the events of the resources on the cluster (the consumer name of the
+// resources), or of all the clusters of the source if the cluster name is AllClusters or empty.
+func (h *EventBroadcaster) Register(source, clusterName string, handler resourceHandler) (string, <-chan error) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
+	if len(clusterName) == 0 {
+		clusterName = AllClusters
+	}
+
 	id := uuid.NewString()
 	errChan := make(chan error)
 	h.clients[id] = &eventClient{
-		source:  source,
-		handler: handler,
-		errChan: errChan,
+		source:      source,
+		clusterName: clusterName,
+		handler:     handler,
+		errChan:     errChan,
 	}
 
-	klog.V(4).Infof("registered a broadcaster client %s (source=%s)", id, source)
+	klog.V(4).Infof("registered a broadcaster client %s (source=%s, cluster=%s)", id, source, clusterName)
 	return id, errChan
 }
 
@@ -65,9 +132,131 @@ func (h *EventBroadcaster) Unregister(id string) {
 	klog.V(4).Infof("unregistered broadcaster client %s", id)
 }
 
+// Suspend suspends the broadcasting for the given timeout, e.g. during a bulk import, the events broadcast while the
+// broadcaster is suspended are coalesced to the latest event of each resource, and are broadcast once the broadcaster
+// is resumed. The broadcaster is resumed automatically once the timeout is reached, so a crashed import never leaves
+// the broadcasting off, suspending a suspended broadcaster resets the timeout. It returns the time when the
+// broadcaster is resumed automatically. The suspension only applies to this maestro instance.
+func (h *EventBroadcaster) Suspend(timeout time.Duration) time.Time {
+	h.suspendMu.Lock()
+	defer h.suspendMu.Unlock()
+
+	if h.pending == nil {
+		h.pending = map[string]*api.Resource{}
+	}
+	if h.resumeTimer != nil {
+		h.resumeTimer.Stop()
+	}
+	h.suspendedUntil = time.Now().Add(timeout)
+	h.resumeTimer = time.AfterFunc(timeout, func() {
+		if until, _ := h.Suspended(); until.IsZero() || time.Now().Before(until) {
+			// the broadcaster is resumed or suspended again
+			return
+		}
+		klog.Warningf("the broadcaster suspension is timed out, resuming the broadcaster")
+		h.Resume()
+	})
+
+	klog.Infof("suspended the broadcaster until %s", h.suspendedUntil.Format(time.RFC3339))
+	return h.suspendedUntil
+}
+
+// Resume resumes the suspended broadcaster and broadcasts the coalesced events, it returns the number of the
+// broadcast events.
+func (h *EventBroadcaster) Resume() int {
+	h.suspendMu.Lock()
+	if h.suspendedUntil.IsZero() {
+		h.suspendMu.Unlock()
+		return 0
+	}
+	if h.resumeTimer != nil {
+		h.resumeTimer.Stop()
+		h.resumeTimer = nil
+	}
+	pending := make([]*api.Resource, 0, len(h.pendingIDs))
+	for _, id := range h.pendingIDs {
+		pending = append(pending, h.pending[id])
+	}
+	h.suspendedUntil = time.Time{}
+	h.pending = nil
+	h.pendingIDs = nil
+	h.suspendMu.Unlock()
+
+	// the events are broadcast outside of the lock, the broadcast can be blocked by a full buffer
+	klog.Infof("resumed the broadcaster, broadcasting %d coalesced events", len(pending))
+	for _, res := range pending {
+		h.Broadcast(res)
+	}
+	return len(pending)
+}
+
+// Suspended returns the time when the suspended broadcaster is resumed automatically and the number of the pending
+// events, a zero time is returned if the broadcaster is not suspended.
+func (h *EventBroadcaster) Suspended() (time.Time, int) {
+	h.suspendMu.Lock()
+	defer h.suspendMu.Unlock()
+
+	return h.suspendedUntil, len(h.pendingIDs)
+}
+
 // Broadcast broadcasts a resource status change event to all registered clients.
+// If the broadcast buffer is full, the event is handled according to the overflow policy.
+// If the broadcaster is suspended, the event is kept until the broadcaster is resumed, see Suspend.
 func (h *EventBroadcaster) Broadcast(res *api.Resource) {
-	h.broadcast <- res
+	if h.suspend(res) {
+		return
+	}
+
+	switch h.overflowPolicy {
+	case OverflowPolicyDropNewest:
+		select {
+		case h.broadcast <- res:
+		default:
+			klog.Warningf("broadcast buffer is full, dropping the event of resource %s", res.ID)
+			broadcasterOverflowCountMetric.WithLabelValues(string(h.overflowPolicy)).Inc()
+		}
+	case OverflowPolicyDropOldest:
+		for {
+			select {
+			case h.broadcast <- res:
+				return
+			default:
+			}
+
+			// the buffer is full, drop the oldest event and retry
+			select {
+			case dropped := <-h.broadcast:
+				klog.Warningf("broadcast buffer is full, dropping the event of resource %s", dropped.ID)
+				broadcasterOverflowCountMetric.WithLabelValues(string(h.overflowPolicy)).Inc()
+			default:
+			}
+		}
+	default:
+		select {
+		case h.broadcast <- res:
+		default:
+			// the buffer is full, block until there is room in the buffer
+			broadcasterOverflowCountMetric.WithLabelValues(string(h.overflowPolicy)).Inc()
+			h.broadcast <- res
+		}
+	}
+}
+
+// suspend keeps the event as the latest pending event of its resource if the broadcaster is suspended.
+func (h *EventBroadcaster) suspend(res *api.Resource) bool {
+	h.suspendMu.Lock()
+	defer h.suspendMu.Unlock()
+
+	if h.suspendedUntil.IsZero() {
+		return false
+	}
+	if _, ok := h.pending[res.ID]; ok {
+		broadcasterCoalescedCountMetric.WithLabelValues(res.Source).Inc()
+	} else {
+		h.pendingIDs = append(h.pendingIDs, res.ID)
+	}
+	h.pending[res.ID] = res
+	return true
 }
 
 // Start starts the event broadcaster and waits for events to broadcast.
@@ -81,7 +270,7 @@ func (h *EventBroadcaster) Start(ctx context.Context) {
 		case res := <-h.broadcast:
 			h.mu.RLock()
 			for _, client := range h.clients {
-				if client.source == res.Source {
+				if client.matches(res) {
 					if err := client.handler(res); err != nil {
 						client.errChan <- err
 					}
@@ -91,3 +280,56 @@ func (h *EventBroadcaster) Start(ctx context.Context) {
 		}
 	}
 }
+
+// Subsystem used to define the metrics:
+const metricsSubsystem = "event_broadcaster"
+
+// Names of the labels added to metrics:
+const (
+	metricsPolicyLabel = "policy"
+	metricsSourceLabel = "source"
+)
+
+// Names of the metrics:
+const (
+	overflowCountMetric  = "overflow_total"
+	coalescedCountMetric = "coalesced_total"
+)
+
+// Register the metrics:
+func RegisterBroadcasterMetrics() {
+	prometheus.MustRegister(broadcasterOverflowCountMetric)
+	prometheus.MustRegister(broadcasterCoalescedCountMetric)
+}
+
+// Unregister the metrics:
+func UnregisterBroadcasterMetrics() {
+	prometheus.Unregister(broadcasterOverflowCountMetric)
+	prometheus.Unregister(broadcasterCoalescedCountMetric)
+}
+
+// Reset the metrics:
+func ResetBroadcasterMetrics() {
+	broadcasterOverflowCountMetric.Reset()
+	broadcasterCoalescedCountMetric.Reset()
+}
+
+// Description of the broadcaster overflow count metric:
+var broadcasterOverflowCountMetric = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Subsystem: metricsSubsystem,
+		Name:      overflowCountMetric,
+		Help:      "Number of events that overflow the broadcast buffer, the events are blocked or dropped according to the policy.",
+	},
+	[]string{metricsPolicyLabel},
+)
+
+// Description of the broadcaster coalesced count metric:
+var broadcasterCoalescedCountMetric = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Subsystem: metricsSubsystem,
+		Name:      coalescedCountMetric,
+		Help:      "Number of events that are replaced by a later event of the same resource while the broadcaster is suspended.",
+	},
+	[]string{metricsSourceLabel},
+)
@@ -0,0 +1,163 @@
+package event
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openshift-online/maestro/pkg/api"
+)
+
+func TestBroadcastOverflow(t *testing.T) {
+	cases := []struct {
+		name        string
+		policy      OverflowPolicy
+		expectedIDs []string
+	}{
+		{
+			name:        "drop oldest",
+			policy:      OverflowPolicyDropOldest,
+			expectedIDs: []string{"2", "3"},
+		},
+		{
+			name:        "drop newest",
+			policy:      OverflowPolicyDropNewest,
+			expectedIDs: []string{"1", "2"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			broadcaster := NewEventBroadcaster(2, c.policy)
+			for _, id := range []string{"1", "2", "3"} {
+				broadcaster.Broadcast(&api.Resource{Meta: api.Meta{ID: id}})
+			}
+
+			if len(broadcaster.broadcast) != len(c.expectedIDs) {
+				t.Fatalf("expected %d buffered events, but got %d", len(c.expectedIDs), len(broadcaster.broadcast))
+			}
+			for _, id := range c.expectedIDs {
+				if res := <-broadcaster.broadcast; res.ID != id {
+					t.Errorf("expected buffered event of resource %s, but got %s", id, res.ID)
+				}
+			}
+		})
+	}
+}
+
+func TestBroadcastSuspend(t *testing.T) {
+	broadcaster := NewEventBroadcaster(10, OverflowPolicyBlock)
+	if until := broadcaster.Suspend(time.Hour); !until.After(time.Now()) {
+		t.Errorf("expected the broadcaster is suspended until a later time, but got %s", until)
+	}
+
+	for _, event := range []struct{ id, source string }{{"1", "a"}, {"2", "a"}, {"1", "b"}} {
+		broadcaster.Broadcast(&api.Resource{Meta: api.Meta{ID: event.id}, Source: event.source})
+	}
+	if len(broadcaster.broadcast) != 0 {
+		t.Errorf("expected no buffered events while suspended, but got %d", len(broadcaster.broadcast))
+	}
+	if _, pending := broadcaster.Suspended(); pending != 2 {
+		t.Errorf("expected 2 pending events, but got %d", pending)
+	}
+
+	if resumed := broadcaster.Resume(); resumed != 2 {
+		t.Errorf("expected 2 coalesced events, but got %d", resumed)
+	}
+	for _, expected := range []struct{ id, source string }{{"1", "b"}, {"2", "a"}} {
+		if res := <-broadcaster.broadcast; res.ID != expected.id || res.Source != expected.source {
+			t.Errorf("expected the latest event of resource %s, but got %s (%s)", expected.id, res.ID, res.Source)
+		}
+	}
+	if until, _ := broadcaster.Suspended(); !until.IsZero() {
+		t.Errorf("expected the broadcaster is resumed, but it is suspended until %s", until)
+	}
+	if resumed := broadcaster.Resume(); resumed != 0 {
+		t.Errorf("expected no events for a resumed broadcaster, but got %d", resumed)
+	}
+
+	// the broadcaster is resumed once the timeout is reached
+	broadcaster.Suspend(10 * time.Millisecond)
+	broadcaster.Broadcast(&api.Resource{Meta: api.Meta{ID: "3"}})
+	select {
+	case res := <-broadcaster.broadcast:
+		if res.ID != "3" {
+			t.Errorf("expected the event of resource 3, but got %s", res.ID)
+		}
+	case <-time.After(5 * time.Second):
+		t.Errorf("expected the broadcaster is resumed after the timeout")
+	}
+}
+
+func TestBroadcastClusters(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	broadcaster := NewEventBroadcaster(10, OverflowPolicyBlock)
+	go broadcaster.Start(ctx)
+
+	var mu sync.Mutex
+	received := map[string][]string{}
+	record := func(name string) resourceHandler {
+		return func(res *api.Resource) error {
+			mu.Lock()
+			defer mu.Unlock()
+			received[name] = append(received[name], res.ID)
+			return nil
+		}
+	}
+	broadcaster.Register("a", AllClusters, record("wildcard"))
+	broadcaster.Register("a", "", record("empty"))
+	broadcaster.Register("a", "cluster1", record("cluster1"))
+	broadcaster.Register("b", AllClusters, record("other source"))
+
+	// the events are handled in order, so all the events are handled once the last event is handled
+	done := make(chan struct{})
+	broadcaster.Register("done", AllClusters, func(res *api.Resource) error {
+		close(done)
+		return nil
+	})
+
+	for _, res := range []struct{ id, cluster string }{{"1", "cluster1"}, {"2", "cluster2"}, {"3", "cluster1"}} {
+		broadcaster.Broadcast(&api.Resource{Meta: api.Meta{ID: res.id}, Source: "a", ConsumerName: res.cluster})
+	}
+	broadcaster.Broadcast(&api.Resource{Meta: api.Meta{ID: "done"}, Source: "done"})
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected the events are handled")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for name, expected := range map[string][]string{
+		"wildcard":     {"1", "2", "3"},
+		"empty":        {"1", "2", "3"},
+		"cluster1":     {"1", "3"},
+		"other source": nil,
+	} {
+		if len(received[name]) != len(expected) {
+			t.Errorf("expected %s client receives %v, but got %v", name, expected, received[name])
+			continue
+		}
+		for i, id := range expected {
+			if received[name][i] != id {
+				t.Errorf("expected %s client receives %v, but got %v", name, expected, received[name])
+				break
+			}
+		}
+	}
+}
+
+func TestParseOverflowPolicy(t *testing.T) {
+	for _, policy := range []string{"block", "drop-oldest", "drop-newest"} {
+		if _, err := ParseOverflowPolicy(policy); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+
+	if _, err := ParseOverflowPolicy("unknown"); err == nil {
+		t.Errorf("expected error for unknown policy")
+	}
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/openshift-online/maestro/pkg/api/openapi"
+	"github.com/openshift-online/maestro/pkg/errors"
+	"github.com/openshift-online/maestro/pkg/event"
+)
+
+const (
+	// defaultBroadcasterSuspendTimeout is the suspension timeout if the timeout is not requested.
+	defaultBroadcasterSuspendTimeout = 10 * time.Minute
+	// maxBroadcasterSuspendTimeout caps the suspension timeout, so the broadcaster is never suspended for long.
+	maxBroadcasterSuspendTimeout = time.Hour
+)
+
+// broadcasterHandler suspends and resumes the event broadcaster of the maestro instance, see
+// event.EventBroadcaster.Suspend. The suspension is per instance, so the status reports the instance ID for the
+// callers to tell the instances apart.
+type broadcasterHandler struct {
+	broadcaster *event.EventBroadcaster
+	instanceID  string
+}
+
+func NewBroadcasterHandler(broadcaster *event.EventBroadcaster, instanceID string) *broadcasterHandler {
+	return &broadcasterHandler{
+		broadcaster: broadcaster,
+		instanceID:  instanceID,
+	}
+}
+
+func (h broadcasterHandler) Get(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			return h.status(), nil
+		},
+	}
+
+	handleGet(w, r, cfg)
+}
+
+func (h broadcasterHandler) Suspend(w http.ResponseWriter, r *http.Request) {
+	var req openapi.BroadcasterSuspendRequest
+
+	cfg := &handlerConfig{
+		&req,
+		[]validate{},
+		func() (interface{}, *errors.ServiceError) {
+			timeout := defaultBroadcasterSuspendTimeout
+			if req.Timeout != nil {
+				var err error
+				if timeout, err = time.ParseDuration(*req.Timeout); err != nil {
+					return nil, errors.Validation("invalid timeout %q: %s", *req.Timeout, err)
+				}
+			}
+			if timeout <= 0 || timeout > maxBroadcasterSuspendTimeout {
+				return nil, errors.Validation("the timeout must be greater than 0 and at most %s", maxBroadcasterSuspendTimeout)
+			}
+
+			h.broadcaster.Suspend(timeout)
+			return h.status(), nil
+		},
+		handleError,
+	}
+
+	handle(w, r, cfg, http.StatusOK)
+}
+
+func (h broadcasterHandler) Resume(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			h.broadcaster.Resume()
+			return h.status(), nil
+		},
+	}
+
+	handleGet(w, r, cfg)
+}
+
+func (h broadcasterHandler) status() openapi.BroadcasterStatus {
+	suspendedUntil, pending := h.broadcaster.Suspended()
+	status := openapi.BroadcasterStatus{
+		Suspended:     openapi.PtrBool(!suspendedUntil.IsZero()),
+		PendingEvents: openapi.PtrInt32(int32(pending)),
+		InstanceId:    openapi.PtrString(h.instanceID),
+	}
+	if !suspendedUntil.IsZero() {
+		status.SuspendedUntil = &suspendedUntil
+	}
+	return status
+}
@@ -2,13 +2,13 @@ package handlers
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/gorilla/mux"
 
 	"github.com/openshift-online/maestro/pkg/api"
 	"github.com/openshift-online/maestro/pkg/api/openapi"
 	"github.com/openshift-online/maestro/pkg/api/presenters"
-	"github.com/openshift-online/maestro/pkg/db"
 	"github.com/openshift-online/maestro/pkg/errors"
 	"github.com/openshift-online/maestro/pkg/services"
 )
@@ -38,10 +38,13 @@ func (h consumerHandler) Create(w http.ResponseWriter, r *http.Request) {
 		},
 		func() (interface{}, *errors.ServiceError) {
 			ctx := r.Context()
-			consumer := presenters.ConvertConsumer(consumer)
-			consumer, err := h.consumer.Create(ctx, consumer)
+			consumer, err := presenters.ConvertConsumer(consumer)
 			if err != nil {
-				return nil, err
+				return nil, errors.Validation("the feedback rules of the consumer are invalid, %s", err)
+			}
+			consumer, serviceErr := h.consumer.Create(ctx, consumer)
+			if serviceErr != nil {
+				return nil, serviceErr
 			}
 			return presenters.PresentConsumer(consumer), nil
 		},
@@ -51,6 +54,60 @@ func (h consumerHandler) Create(w http.ResponseWriter, r *http.Request) {
 	handle(w, r, cfg, http.StatusCreated)
 }
 
+func (h consumerHandler) BatchCreate(w http.ResponseWriter, r *http.Request) {
+	var req openapi.ConsumerBatchCreateRequest
+	cfg := &handlerConfig{
+		&req,
+		[]validate{
+			func() *errors.ServiceError {
+				for _, consumer := range req.Items {
+					if consumer.Id != nil {
+						return errors.Validation("id must be empty")
+					}
+				}
+				return nil
+			},
+		},
+		func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			consumers := []*api.Consumer{}
+			for _, consumer := range req.Items {
+				converted, err := presenters.ConvertConsumer(consumer)
+				if err != nil {
+					return nil, errors.Validation("the feedback rules of the consumer %s are invalid, %s",
+						consumer.GetName(), err)
+				}
+				consumers = append(consumers, converted)
+			}
+
+			results, err := h.consumer.BatchCreate(ctx, consumers, req.GetSkipExisting())
+			if err != nil {
+				return nil, err
+			}
+
+			resp := openapi.ConsumerBatchCreateResponse{
+				Items: []openapi.ConsumerBatchCreateResult{},
+			}
+			for _, result := range results {
+				consumer := presenters.PresentConsumer(result.Consumer)
+				item := openapi.ConsumerBatchCreateResult{
+					Name:     openapi.PtrString(result.Name),
+					Status:   openapi.PtrString(string(result.Status)),
+					Consumer: &consumer,
+				}
+				if result.Reason != "" {
+					item.Reason = openapi.PtrString(result.Reason)
+				}
+				resp.Items = append(resp.Items, item)
+			}
+			return resp, nil
+		},
+		handleError,
+	}
+
+	handle(w, r, cfg, http.StatusCreated)
+}
+
 func (h consumerHandler) Patch(w http.ResponseWriter, r *http.Request) {
 	var patch openapi.ConsumerPatchRequest
 
@@ -60,17 +117,35 @@ func (h consumerHandler) Patch(w http.ResponseWriter, r *http.Request) {
 		func() (interface{}, *errors.ServiceError) {
 			ctx := r.Context()
 			id := mux.Vars(r)["id"]
-			found, err := h.consumer.Get(ctx, id)
+			consumer, err := h.consumer.PatchLabels(ctx, id, services.ConsumerLabelsPatch{
+				Replace: patch.GetLabels(),
+				Add:     patch.GetAddLabels(),
+				Remove:  patch.GetRemoveLabels(),
+			})
 			if err != nil {
 				return nil, err
 			}
-			if patch.Labels != nil {
-				found.Labels = db.EmptyMapToNilStringMap(patch.Labels)
+			// the feedback rules are replaced only if they are set, an empty list removes them
+			if patch.HasFeedbackRules() {
+				rules, convErr := presenters.ConvertFeedbackRules(patch.GetFeedbackRules())
+				if convErr != nil {
+					return nil, errors.Validation("the feedback rules of the consumer are invalid, %s", convErr)
+				}
+				consumer, err = h.consumer.PatchFeedbackRules(ctx, id, rules)
+				if err != nil {
+					return nil, err
+				}
 			}
-
-			consumer, err := h.consumer.Replace(ctx, found)
-			if err != nil {
-				return nil, err
+			// the delete propagation policy is replaced only if it is set, an empty value removes it
+			if patch.HasDeletePropagationPolicy() {
+				consumer, err = h.consumer.PatchDeletePropagationPolicy(ctx, id, patch.GetDeletePropagationPolicy())
+				if err != nil {
+					return nil, err
+				}
+				// the resources without a delete option of their own follow the new policy
+				if _, err := h.resource.ResolveDeleteOptionByConsumer(ctx, consumer.Name); err != nil {
+					return nil, err
+				}
 			}
 			return presenters.PresentConsumer(consumer), nil
 		},
@@ -146,3 +221,27 @@ func (h consumerHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 	handleDelete(w, r, cfg, http.StatusNoContent)
 }
+
+// DeleteResources marks all the resources of the consumer as deleting in the request transaction, the confirm query
+// parameter must be the number of the resources that are not marked as deleting to prevent accidental deletions.
+func (h consumerHandler) DeleteResources(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			name := mux.Vars(r)["name"]
+			confirm := r.URL.Query().Get("confirm")
+			confirmation, err := strconv.Atoi(confirm)
+			if err != nil || confirmation < 0 {
+				return nil, errors.Validation("the confirm query parameter must be the number of the resources of the consumer, got %q", confirm)
+			}
+
+			marked, svcErr := h.resource.MarkAsDeletingByConsumer(r.Context(), name, confirmation)
+			if svcErr != nil {
+				return nil, svcErr
+			}
+			return openapi.ConsumerResourcesDeleteResponse{
+				Resources: openapi.PtrInt32(int32(marked)),
+			}, nil
+		},
+	}
+	handleDelete(w, r, cfg, http.StatusOK)
+}
@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/api/openapi"
+	"github.com/openshift-online/maestro/pkg/api/presenters"
+	"github.com/openshift-online/maestro/pkg/errors"
+	"github.com/openshift-online/maestro/pkg/services"
+)
+
+// consumerGroupHandler handles the consumer groups, the groups are derived from the consumer labels, see
+// api.ConsumerGroupLabelPrefix.
+type consumerGroupHandler struct {
+	consumer services.ConsumerService
+	resource services.ResourceService
+	generic  services.GenericService
+}
+
+func NewConsumerGroupHandler(consumer services.ConsumerService, resource services.ResourceService, generic services.GenericService) *consumerGroupHandler {
+	return &consumerGroupHandler{
+		consumer: consumer,
+		resource: resource,
+		generic:  generic,
+	}
+}
+
+func (h consumerGroupHandler) List(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			listArgs := services.NewListArguments(r.URL.Query())
+			groups, paging, err := h.consumer.Groups(r.Context(), listArgs)
+			if err != nil {
+				return nil, err
+			}
+			groupList := openapi.ConsumerGroupList{
+				Kind:  *presenters.ObjectKind(groups),
+				Page:  int32(paging.Page),
+				Size:  int32(paging.Size),
+				Total: int32(paging.Total),
+				Items: []openapi.ConsumerGroup{},
+			}
+			for _, group := range groups {
+				groupList.Items = append(groupList.Items, presenters.PresentConsumerGroup(group))
+			}
+			return groupList, nil
+		},
+	}
+
+	handleList(w, r, cfg)
+}
+
+// ListConsumers lists a page of the consumers in the consumer group, the consumers are ordered by name by default.
+func (h consumerGroupHandler) ListConsumers(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			group := mux.Vars(r)["name"]
+			if err := services.ValidateConsumerGroup(group); err != nil {
+				return nil, errors.Validation("invalid consumer group: %s", err)
+			}
+
+			listArgs := services.NewListArguments(r.URL.Query())
+			listArgs.LabelKeys = []string{api.ConsumerGroupLabel(group)}
+			if len(listArgs.OrderBy) == 0 {
+				listArgs.OrderBy = []string{"name"}
+			}
+			consumers := []api.Consumer{}
+			paging, err := h.generic.List(r.Context(), "username", listArgs, &consumers)
+			if err != nil {
+				return nil, err
+			}
+			consumerList := openapi.ConsumerList{
+				Kind:  *presenters.ObjectKind(consumers),
+				Page:  int32(paging.Page),
+				Size:  int32(paging.Size),
+				Total: int32(paging.Total),
+				Items: []openapi.Consumer{},
+			}
+			for _, consumer := range consumers {
+				consumerList.Items = append(consumerList.Items, presenters.PresentConsumer(&consumer))
+			}
+			return consumerList, nil
+		},
+	}
+
+	handleList(w, r, cfg)
+}
+
+const (
+	// defaultConsumerGroupResyncSize is the default number of the consumers resynced by a consumer group resync
+	// request, the consumers are resynced in batches so a request doesn't exceed the request timeout.
+	defaultConsumerGroupResyncSize = 10
+	maxConsumerGroupResyncSize     = 100
+)
+
+// Resync reconciles the resources of a batch of the consumers in the consumer group, the resource versions are bumped,
+// so the resources are re-broadcast to the agents. The batch starts after the consumer of the after query parameter,
+// the response reports the consumer to start the next batch after until all the consumers are resynced.
+func (h consumerGroupHandler) Resync(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			group := mux.Vars(r)["name"]
+			size := defaultConsumerGroupResyncSize
+			if v := r.URL.Query().Get("size"); v != "" {
+				var err error
+				size, err = strconv.Atoi(v)
+				if err != nil || size < 1 || size > maxConsumerGroupResyncSize {
+					return nil, errors.Validation("the size query parameter must be between 1 and %d, got %q",
+						maxConsumerGroupResyncSize, v)
+				}
+			}
+
+			consumers, err := h.consumer.FindByGroup(ctx, group)
+			if err != nil {
+				return nil, err
+			}
+			if len(consumers) == 0 {
+				return nil, errors.NotFound("no consumer in the consumer group %s", group)
+			}
+
+			batch, next := consumerGroupResyncBatch(consumers, r.URL.Query().Get("after"), size)
+			resources := 0
+			for _, consumer := range batch {
+				reconciled, err := h.resource.ReconcileByConsumer(ctx, consumer.Name)
+				if err != nil {
+					return nil, err
+				}
+				resources += reconciled
+			}
+
+			resp := openapi.ConsumerGroupResyncResponse{
+				Consumers:      openapi.PtrInt32(int32(len(batch))),
+				Resources:      openapi.PtrInt32(int32(resources)),
+				TotalConsumers: openapi.PtrInt32(int32(len(consumers))),
+			}
+			if next != "" {
+				resp.Next = openapi.PtrString(next)
+			}
+			return resp, nil
+		},
+	}
+
+	handleGet(w, r, cfg)
+}
+
+// consumerGroupResyncBatch returns at most size consumers whose names are after the given name in the byte order of
+// the names, the database collation may order the names differently. It also returns the name to start the next batch
+// after, it is empty if there is no more consumer.
+func consumerGroupResyncBatch(consumers api.ConsumerList, after string, size int) (api.ConsumerList, string) {
+	consumers = append(api.ConsumerList{}, consumers...)
+	sort.Slice(consumers, func(i, j int) bool { return consumers[i].Name < consumers[j].Name })
+	start := sort.Search(len(consumers), func(i int) bool { return consumers[i].Name > after })
+	end := start + size
+	if end >= len(consumers) {
+		return consumers[start:], ""
+	}
+	return consumers[start:end], consumers[end-1].Name
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/openshift-online/maestro/pkg/api"
+)
+
+func TestConsumerGroupResyncBatch(t *testing.T) {
+	consumers := api.ConsumerList{{Name: "cluster2"}, {Name: "cluster1"}, {Name: "cluster3"}}
+
+	cases := []struct {
+		name          string
+		after         string
+		size          int
+		expectedBatch []string
+		expectedNext  string
+	}{
+		{
+			name:          "first batch",
+			size:          2,
+			expectedBatch: []string{"cluster1", "cluster2"},
+			expectedNext:  "cluster2",
+		},
+		{
+			name:          "last batch",
+			after:         "cluster2",
+			size:          2,
+			expectedBatch: []string{"cluster3"},
+		},
+		{
+			name:          "exact batch",
+			after:         "cluster1",
+			size:          2,
+			expectedBatch: []string{"cluster2", "cluster3"},
+		},
+		{
+			name:          "all in one batch",
+			size:          10,
+			expectedBatch: []string{"cluster1", "cluster2", "cluster3"},
+		},
+		{
+			name:          "after a removed consumer",
+			after:         "cluster1a",
+			size:          1,
+			expectedBatch: []string{"cluster2"},
+			expectedNext:  "cluster2",
+		},
+		{
+			name:          "after the last consumer",
+			after:         "cluster3",
+			size:          1,
+			expectedBatch: []string{},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			batch, next := consumerGroupResyncBatch(consumers, c.after, c.size)
+			names := []string{}
+			for _, consumer := range batch {
+				names = append(names, consumer.Name)
+			}
+			if len(names) != len(c.expectedBatch) {
+				t.Fatalf("expected the batch %v, but got %v", c.expectedBatch, names)
+			}
+			for i := range names {
+				if names[i] != c.expectedBatch[i] {
+					t.Errorf("expected the batch %v, but got %v", c.expectedBatch, names)
+				}
+			}
+			if next != c.expectedNext {
+				t.Errorf("expected the next %q, but got %q", c.expectedNext, next)
+			}
+		})
+	}
+}
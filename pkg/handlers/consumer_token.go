@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/api/openapi"
+	"github.com/openshift-online/maestro/pkg/api/presenters"
+	"github.com/openshift-online/maestro/pkg/auth"
+	"github.com/openshift-online/maestro/pkg/errors"
+	"github.com/openshift-online/maestro/pkg/services"
+)
+
+type consumerTokenHandler struct {
+	consumerToken services.ConsumerTokenService
+	consumer      services.ConsumerService
+	generic       services.GenericService
+}
+
+func NewConsumerTokenHandler(consumerToken services.ConsumerTokenService, consumer services.ConsumerService,
+	generic services.GenericService) *consumerTokenHandler {
+	return &consumerTokenHandler{
+		consumerToken: consumerToken,
+		consumer:      consumer,
+		generic:       generic,
+	}
+}
+
+// Create issues a token scoped to the consumer, the token value is only returned in the response.
+func (h consumerTokenHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var consumerToken openapi.ConsumerToken
+	cfg := &handlerConfig{
+		&consumerToken,
+		[]validate{
+			validateEmpty(&consumerToken, "Id", "id"),
+			validateEmpty(&consumerToken, "Token", "token"),
+		},
+		func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			consumerID := mux.Vars(r)["id"]
+			issued, token, err := h.consumerToken.Issue(ctx, consumerID, consumerToken.ExpiresAt, auth.GetUsernameFromContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+			return presenters.PresentConsumerToken(issued, token), nil
+		},
+		handleError,
+	}
+
+	handle(w, r, cfg, http.StatusCreated)
+}
+
+func (h consumerTokenHandler) List(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			consumerID := mux.Vars(r)["id"]
+			consumer, err := h.consumer.Get(ctx, consumerID)
+			if err != nil {
+				return nil, err
+			}
+
+			listArgs := services.NewListArguments(r.URL.Query())
+			if len(listArgs.Search) == 0 {
+				listArgs.Search = fmt.Sprintf("consumer_id='%s'", consumer.ID)
+			} else {
+				listArgs.Search = fmt.Sprintf("consumer_id='%s' and (%s)", consumer.ID, listArgs.Search)
+			}
+			tokens := []api.ConsumerToken{}
+			paging, err := h.generic.List(ctx, "username", listArgs, &tokens)
+			if err != nil {
+				return nil, err
+			}
+
+			tokenList := openapi.ConsumerTokenList{
+				Kind:  *presenters.ObjectKind(tokens),
+				Page:  int32(paging.Page),
+				Size:  int32(paging.Size),
+				Total: int32(paging.Total),
+				Items: []openapi.ConsumerToken{},
+			}
+			for _, token := range tokens {
+				tokenList.Items = append(tokenList.Items, presenters.PresentConsumerToken(&token, ""))
+			}
+			return tokenList, nil
+		},
+	}
+
+	handleList(w, r, cfg)
+}
+
+// Delete revokes the consumer token, the token metadata is kept for audit.
+func (h consumerTokenHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			consumerID := mux.Vars(r)["id"]
+			tokenID := mux.Vars(r)["token_id"]
+			if err := h.consumerToken.Revoke(r.Context(), consumerID, tokenID); err != nil {
+				return nil, err
+			}
+			return nil, nil
+		},
+	}
+	handleDelete(w, r, cfg, http.StatusNoContent)
+}
@@ -114,6 +114,35 @@ func handleGet(w http.ResponseWriter, r *http.Request, cfg *handlerConfig) {
 	}
 }
 
+// handleGetWithETag is handleGet with a strong ETag of the result, the ETag is the version of the result followed by
+// the hash of the response body, so it changes with the status of a resource as well as its version. The response is
+// 304 Not Modified without a body if the ETag matches the If-None-Match header of the request.
+func handleGetWithETag(w http.ResponseWriter, r *http.Request, cfg *handlerConfig, version func() int64) {
+	if cfg.ErrorHandler == nil {
+		cfg.ErrorHandler = handleError
+	}
+
+	result, serviceErr := cfg.Action()
+	if serviceErr != nil {
+		cfg.ErrorHandler(r.Context(), w, serviceErr)
+		return
+	}
+
+	response, err := json.Marshal(result)
+	if err != nil {
+		cfg.ErrorHandler(r.Context(), w, errors.GeneralError("Unable to marshal the response: %s", err))
+		return
+	}
+	etag := strongETag(version(), response)
+	w.Header().Set("ETag", etag)
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.Header().Set("Vary", "Authorization")
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	writeJSONBytesResponse(w, http.StatusOK, response)
+}
+
 func handleList(w http.ResponseWriter, r *http.Request, cfg *handlerConfig) {
 	if cfg.ErrorHandler == nil {
 		cfg.ErrorHandler = handleError
@@ -1,6 +1,13 @@
 package handlers
 
-import "net/http"
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/openshift-online/maestro/pkg/errors"
+)
 
 type mockResponseWriter struct {
 	written string
@@ -17,3 +24,44 @@ func (m *mockResponseWriter) Write(b []byte) (int, error) {
 func (m *mockResponseWriter) WriteHeader(code int) {
 	m.status = code
 }
+
+func TestHandleGetWithETag(t *testing.T) {
+	result := map[string]string{"id": "foo"}
+	cfg := &handlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			return result, nil
+		},
+	}
+	get := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/api/maestro/v1/resources/foo", nil)
+		if ifNoneMatch != "" {
+			r.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		w := httptest.NewRecorder()
+		handleGetWithETag(w, r, cfg, func() int64 { return 1 })
+		return w
+	}
+
+	w := get("")
+	etag := w.Header().Get("ETag")
+	if w.Code != http.StatusOK || w.Body.String() != `{"id":"foo"}` {
+		t.Fatalf("expected the resource is returned, but got %d %q", w.Code, w.Body.String())
+	}
+	if !strings.HasPrefix(etag, `"1-`) {
+		t.Errorf("expected a strong ETag of the version 1, but got %q", etag)
+	}
+
+	for _, ifNoneMatch := range []string{etag, `"0-abc", ` + etag, "W/" + etag, "*"} {
+		w = get(ifNoneMatch)
+		if w.Code != http.StatusNotModified || w.Body.Len() != 0 || w.Header().Get("ETag") != etag {
+			t.Errorf("expected not modified for If-None-Match %q, but got %d %q", ifNoneMatch, w.Code, w.Body.String())
+		}
+	}
+
+	// the ETag changes with the response body, e.g. a new status at the same version
+	result["status"] = "Applied"
+	w = get(etag)
+	if w.Code != http.StatusOK || w.Header().Get("ETag") == etag {
+		t.Errorf("expected the changed resource is returned with a new ETag, but got %d %q", w.Code, w.Header().Get("ETag"))
+	}
+}
@@ -1,12 +1,24 @@
 package handlers
 
 import (
+	"crypto/sha256"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"reflect"
+	"strings"
 )
 
 func writeJSONResponse(w http.ResponseWriter, code int, payload interface{}) {
+	var response []byte
+	if payload != nil {
+		response, _ = json.Marshal(payload)
+	}
+	writeJSONBytesResponse(w, code, response)
+}
+
+// writeJSONBytesResponse writes the marshaled JSON payload, no body is written if the payload is empty.
+func writeJSONBytesResponse(w http.ResponseWriter, code int, response []byte) {
 	w.Header().Set("Content-Type", "application/json")
 	// By default, decide whether or not a cache is usable based on the matching of the JWT
 	// For example, this will keep caches from being used in the same browser if two users were to log in back to back
@@ -14,12 +26,29 @@ func writeJSONResponse(w http.ResponseWriter, code int, payload interface{}) {
 
 	w.WriteHeader(code)
 
-	if payload != nil {
-		response, _ := json.Marshal(payload)
+	if len(response) != 0 {
 		_, _ = w.Write(response)
 	}
 }
 
+// strongETag returns the quoted strong ETag of the response body at the version, e.g. "3-5d41402abc4b2a76".
+func strongETag(version int64, response []byte) string {
+	sum := sha256.Sum256(response)
+	return fmt.Sprintf(`"%d-%x"`, version, sum[:8])
+}
+
+// etagMatches tells whether the ETag matches the If-None-Match header, which is either "*" or a comma-separated
+// list of the ETags, the weak ETags are compared by their opaque tags as RFC 9110 requires for If-None-Match.
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
 // Prepare a 'list' of non-db-backed resources
 func determineListRange(obj interface{}, page int, size int64) (list []interface{}, total int64) {
 	items := reflect.ValueOf(obj)
@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/openshift-online/maestro/pkg/errors"
+)
+
+// MigrationGateMiddleware creates a new HTTP middleware that rejects the mutating requests with a service unavailable
+// error and a Retry-After header until the database migrations are complete, so the requests don't hit a
+// half-migrated schema. The reads are rejected as well if gateReads is true.
+func MigrationGateMiddleware(migrated func(ctx context.Context) bool, retryAfter time.Duration, gateReads bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if (!gateReads && isReadRequest(r)) || migrated(r.Context()) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter.Seconds())))
+			handleError(r.Context(), w, errors.Unavailable("the database migrations are not complete, retry after %s", retryAfter))
+		})
+	}
+}
+
+func isReadRequest(r *http.Request) bool {
+	return r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions
+}
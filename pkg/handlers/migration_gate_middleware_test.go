@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestMigrationGateMiddleware(t *testing.T) {
+	RegisterTestingT(t)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	cases := []struct {
+		name       string
+		migrated   bool
+		gateReads  bool
+		method     string
+		status     int
+		retryAfter string
+	}{
+		{
+			name:       "the mutation is rejected before the migrations are complete",
+			method:     http.MethodPost,
+			status:     http.StatusServiceUnavailable,
+			retryAfter: "5",
+		},
+		{
+			name:   "the read is allowed before the migrations are complete",
+			method: http.MethodGet,
+			status: http.StatusOK,
+		},
+		{
+			name:       "the read is rejected if the reads are gated",
+			gateReads:  true,
+			method:     http.MethodGet,
+			status:     http.StatusServiceUnavailable,
+			retryAfter: "5",
+		},
+		{
+			name:     "the mutation is allowed once the migrations are complete",
+			migrated: true,
+			method:   http.MethodDelete,
+			status:   http.StatusOK,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			migrated := func(ctx context.Context) bool { return c.migrated }
+			w := httptest.NewRecorder()
+			MigrationGateMiddleware(migrated, 5*time.Second, c.gateReads)(handler).ServeHTTP(w, httptest.NewRequest(c.method, "/api/maestro/v1/resources", nil))
+			Expect(w.Code).To(Equal(c.status))
+			Expect(w.Header().Get("Retry-After")).To(Equal(c.retryAfter))
+			if c.status == http.StatusServiceUnavailable {
+				Expect(w.Body.String()).To(ContainSubstring(`"code":"maestro-28"`))
+			}
+		})
+	}
+}
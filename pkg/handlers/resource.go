@@ -1,14 +1,21 @@
 package handlers
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
+	workv1 "open-cluster-management.io/api/work/v1"
 
 	"github.com/openshift-online/maestro/pkg/api"
 	"github.com/openshift-online/maestro/pkg/api/openapi"
 	"github.com/openshift-online/maestro/pkg/api/presenters"
+	"github.com/openshift-online/maestro/pkg/auth"
+	"github.com/openshift-online/maestro/pkg/client/cloudevents"
 	"github.com/openshift-online/maestro/pkg/errors"
 	"github.com/openshift-online/maestro/pkg/services"
 )
@@ -17,13 +24,26 @@ var _ RestHandler = resourceHandler{}
 
 type resourceHandler struct {
 	resource services.ResourceService
+	consumer services.ConsumerService
 	generic  services.GenericService
+	// lock rejects the patches, reverts and deletions of the resources locked by another owner, see
+	// services.ResourceLockService.
+	lock services.ResourceLockService
+	// admins authorizes the requests that override the locks of another owner.
+	admins auth.AdminAuthorizer
+	// namespaceDefaults is applied to the namespaceless manifests, see api.NamespaceDefaults.
+	namespaceDefaults *api.NamespaceDefaults
 }
 
-func NewResourceHandler(resource services.ResourceService, generic services.GenericService) *resourceHandler {
+func NewResourceHandler(resource services.ResourceService, consumer services.ConsumerService, generic services.GenericService,
+	lock services.ResourceLockService, admins auth.AdminAuthorizer, namespaceDefaults *api.NamespaceDefaults) *resourceHandler {
 	return &resourceHandler{
-		resource: resource,
-		generic:  generic,
+		resource:          resource,
+		consumer:          consumer,
+		generic:           generic,
+		lock:              lock,
+		admins:            admins,
+		namespaceDefaults: namespaceDefaults,
 	}
 }
 
@@ -36,29 +56,270 @@ func (h resourceHandler) Create(w http.ResponseWriter, r *http.Request) {
 			validateNotEmpty(&rs, "ConsumerName", "consumer_name"),
 			validateNotEmpty(&rs, "Manifest", "manifest"),
 			validateDeleteOptionAndUpdateStrategy(&rs),
+			validateUpdateStrategy(&rs.UpdateStrategy),
+			validateForceConflicts(&rs),
+			validateStatusFeedbackPolicy(&rs.Manifest),
 		},
 		func() (interface{}, *errors.ServiceError) {
-			ctx := r.Context()
-			resource, err := presenters.ConvertResource(rs)
+			return h.create(r.Context(), rs)
+		},
+		handleError,
+	}
+
+	handle(w, r, cfg, http.StatusCreated)
+}
+
+// create creates the resource of the request with the status feedback rules of its consumer merged into the manifest.
+func (h resourceHandler) create(ctx context.Context, rs openapi.Resource) (*openapi.Resource, *errors.ServiceError) {
+	consumerRules, serviceErr := h.consumerFeedbackRules(ctx, rs.GetConsumerName())
+	if serviceErr != nil {
+		return nil, serviceErr
+	}
+	resource, err := presenters.ConvertResource(rs, h.namespaceDefaults, consumerRules)
+	if err != nil {
+		return nil, errors.GeneralError("failed to convert resource: %s", err)
+	}
+	resource, serviceErr = h.resource.Create(ctx, resource)
+	if serviceErr != nil {
+		return nil, serviceErr
+	}
+	res, err := presenters.PresentResource(resource)
+	if err != nil {
+		return nil, errors.GeneralError("failed to present resource: %s", err)
+	}
+	return res, nil
+}
+
+// ValidateManifest validates the manifest of the request as the resource of the request is created, nothing is
+// created and the consumer of the resource is not required. The manifest is encoded with the same pipeline of a
+// resource creation, so an encoding error is reported as a validation error.
+func (h resourceHandler) ValidateManifest(w http.ResponseWriter, r *http.Request) {
+	var rs openapi.Resource
+	cfg := &handlerConfig{
+		&rs,
+		[]validate{
+			validateNotEmpty(&rs, "Manifest", "manifest"),
+			validateDeleteOptionAndUpdateStrategy(&rs),
+			validateUpdateStrategy(&rs.UpdateStrategy),
+			validateForceConflicts(&rs),
+			validateStatusFeedbackPolicy(&rs.Manifest),
+		},
+		func() (interface{}, *errors.ServiceError) {
+			// the feedback rules of the consumer are merged if the consumer is given
+			consumerRules, serviceErr := h.consumerFeedbackRules(r.Context(), rs.GetConsumerName())
+			if serviceErr != nil {
+				return nil, serviceErr
+			}
+			resource, err := presenters.ConvertResource(rs, h.namespaceDefaults, consumerRules)
 			if err != nil {
-				return nil, errors.GeneralError("failed to convert resource: %s", err)
+				return nil, errors.Validation("the manifest in the resource is invalid, %s", err)
 			}
-			resource, serviceErr := h.resource.Create(ctx, resource)
-			if serviceErr != nil {
+			if serviceErr := h.resource.Validate(resource); serviceErr != nil {
 				return nil, serviceErr
 			}
-			res, err := presenters.PresentResource(resource)
+			res, err := presenters.PresentManifestValidation(resource)
 			if err != nil {
-				return nil, errors.GeneralError("failed to present resource: %s", err)
+				return nil, errors.GeneralError("failed to present manifest validation: %s", err)
 			}
 			return res, nil
 		},
 		handleError,
 	}
 
+	handle(w, r, cfg, http.StatusOK)
+}
+
+// BatchCreate creates the resource of the request for each of the selected consumers, the consumers are selected by
+// their IDs or by a label selector. The consumers that are not found (or duplicated), and the consumers whose resource
+// is invalid or already exists, are reported as failed.
+func (h resourceHandler) BatchCreate(w http.ResponseWriter, r *http.Request) {
+	var req openapi.ResourceBatchCreateRequest
+	// the resource of the request is validated as a single resource once the request body is decoded
+	var rs openapi.Resource
+	cfg := &handlerConfig{
+		&req,
+		[]validate{
+			func() *errors.ServiceError {
+				if req.Resource == nil {
+					return errors.Validation("resource is required")
+				}
+				if (len(req.ConsumerIds) == 0) == (req.GetConsumerSelector() == "") {
+					return errors.Validation("exactly one of consumer_ids and consumer_selector is required")
+				}
+				rs = *req.Resource
+				return nil
+			},
+			validateEmpty(&rs, "Id", "id"),
+			validateEmpty(&rs, "ConsumerName", "consumer_name"),
+			validateNotEmpty(&rs, "Manifest", "manifest"),
+			validateDeleteOptionAndUpdateStrategy(&rs),
+			validateUpdateStrategy(&rs.UpdateStrategy),
+			validateForceConflicts(&rs),
+			validateStatusFeedbackPolicy(&rs.Manifest),
+		},
+		func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			consumers, results, serviceErr := h.selectConsumers(ctx, req)
+			if serviceErr != nil {
+				return nil, serviceErr
+			}
+			if len(consumers) == 0 {
+				return nil, errors.NotFound("none of the consumers is found")
+			}
+
+			// the resource is converted for each consumer, so the feedback rules of the consumer are merged, the
+			// consumers whose resource cannot be created are reported as failed and are left out of the batch
+			failed := map[string]string{}
+			toCreate := api.ResourceList{}
+			for _, consumer := range consumers {
+				resource, err := presenters.ConvertResource(rs, h.namespaceDefaults, consumer.FeedbackRules)
+				if err != nil {
+					failed[consumer.Name] = fmt.Sprintf("failed to convert resource: %s", err)
+					continue
+				}
+				resource.ConsumerName = consumer.Name
+				if rs.Name != nil && *rs.Name != "" {
+					resource.Name = batchResourceName(*rs.Name, consumer.Name)
+				}
+				if serviceErr := h.resource.Validate(resource); serviceErr != nil {
+					failed[consumer.Name] = serviceErr.Reason
+					continue
+				}
+				if resource.Name != "" {
+					if existing, serviceErr := h.resource.FindConflicting(ctx, resource); serviceErr == nil {
+						failed[consumer.Name] = fmt.Sprintf("the resource %s already exists with id %s", resource.Name, existing.ID)
+						continue
+					} else if !serviceErr.Is404() {
+						return nil, serviceErr
+					}
+				}
+				toCreate = append(toCreate, resource)
+			}
+
+			created := map[string]string{}
+			if len(toCreate) != 0 {
+				resources, serviceErr := h.resource.BatchCreate(ctx, toCreate)
+				switch {
+				case serviceErr == nil:
+					for _, resource := range resources {
+						created[resource.ConsumerName] = resource.ID
+					}
+				case serviceErr.IsConflict() || serviceErr.IsValidation():
+					// the batch is created in a single transaction, none of its resources is created
+					for _, resource := range toCreate {
+						failed[resource.ConsumerName] = fmt.Sprintf("the batch is rolled back: %s", serviceErr.Reason)
+					}
+				default:
+					return nil, serviceErr
+				}
+			}
+
+			resp := openapi.ResourceBatchCreateResponse{
+				Items: []openapi.ResourceBatchCreateResult{},
+			}
+			for _, result := range results {
+				if result.Status == nil {
+					if reason, ok := failed[result.GetConsumerName()]; ok {
+						result.Status = openapi.PtrString(string(services.ResourceBatchFailed))
+						result.Reason = openapi.PtrString(reason)
+					} else {
+						result.Status = openapi.PtrString(string(services.ResourceBatchCreated))
+						result.ResourceId = openapi.PtrString(created[result.GetConsumerName()])
+					}
+				}
+				resp.Items = append(resp.Items, result)
+			}
+			return resp, nil
+		},
+		handleError,
+	}
+
 	handle(w, r, cfg, http.StatusCreated)
 }
 
+// batchResourceName returns the name of the resource that is created for the consumer by a batch creation, the
+// resource names are unique, so the consumer name is appended to the requested name.
+func batchResourceName(name, consumerName string) string {
+	return fmt.Sprintf("%s-%s", name, consumerName)
+}
+
+// checkLock returns Locked if the resource is locked by another owner than the requesting user, an admin can override
+// the lock with the force=true query parameter.
+func (h resourceHandler) checkLock(r *http.Request, id string) *errors.ServiceError {
+	if isForced(r) {
+		return requireAdminToForce(r, h.admins)
+	}
+	return h.lock.Check(r.Context(), id, auth.GetUsernameFromContext(r.Context()))
+}
+
+// consumerFeedbackRules returns the status feedback rules of the consumer with the given name, no rules are returned
+// if the name is empty or the consumer is not found, the resource creation reports the missing consumer.
+func (h resourceHandler) consumerFeedbackRules(ctx context.Context, consumerName string) ([]workv1.FeedbackRule, *errors.ServiceError) {
+	if consumerName == "" {
+		return nil, nil
+	}
+	consumers, serviceErr := h.consumer.FindByNames(ctx, []string{consumerName})
+	if serviceErr != nil {
+		return nil, serviceErr
+	}
+	if len(consumers) == 0 {
+		return nil, nil
+	}
+	return consumers[0].FeedbackRules, nil
+}
+
+// selectConsumers returns the consumers selected by the batch creation request, and the results of the selected
+// consumers in the order of the request. The results of the consumers that are not found, or duplicated in the
+// request, are marked as failed.
+func (h resourceHandler) selectConsumers(ctx context.Context, req openapi.ResourceBatchCreateRequest) (
+	api.ConsumerList, []openapi.ResourceBatchCreateResult, *errors.ServiceError) {
+	if req.GetConsumerSelector() != "" {
+		consumers, serviceErr := h.consumer.FindByLabelSelector(ctx, req.GetConsumerSelector())
+		if serviceErr != nil {
+			return nil, nil, serviceErr
+		}
+		results := []openapi.ResourceBatchCreateResult{}
+		for _, consumer := range consumers {
+			results = append(results, openapi.ResourceBatchCreateResult{
+				ConsumerId:   openapi.PtrString(consumer.ID),
+				ConsumerName: openapi.PtrString(consumer.Name),
+			})
+		}
+		return consumers, results, nil
+	}
+
+	found, serviceErr := h.consumer.FindByIDs(ctx, req.ConsumerIds)
+	if serviceErr != nil {
+		return nil, nil, serviceErr
+	}
+	foundByID := map[string]*api.Consumer{}
+	for _, consumer := range found {
+		foundByID[consumer.ID] = consumer
+	}
+
+	consumers := api.ConsumerList{}
+	results := []openapi.ResourceBatchCreateResult{}
+	seen := map[string]bool{}
+	for _, id := range req.ConsumerIds {
+		result := openapi.ResourceBatchCreateResult{ConsumerId: openapi.PtrString(id)}
+		consumer, ok := foundByID[id]
+		switch {
+		case seen[id]:
+			result.Status = openapi.PtrString(string(services.ResourceBatchFailed))
+			result.Reason = openapi.PtrString("consumer is duplicated in the request")
+		case !ok:
+			result.Status = openapi.PtrString(string(services.ResourceBatchFailed))
+			result.Reason = openapi.PtrString("consumer is not found")
+		default:
+			result.ConsumerName = openapi.PtrString(consumer.Name)
+			consumers = append(consumers, consumer)
+		}
+		seen[id] = true
+		results = append(results, result)
+	}
+	return consumers, results, nil
+}
+
 func (h resourceHandler) Patch(w http.ResponseWriter, r *http.Request) {
 	var patch openapi.ResourcePatchRequest
 
@@ -67,17 +328,64 @@ func (h resourceHandler) Patch(w http.ResponseWriter, r *http.Request) {
 		[]validate{
 			validateNotEmpty(&patch, "Version", "version"),
 			validateNotEmpty(&patch, "Manifest", "manifest"),
+			validatePatchType(&patch),
+			validateUpdateStrategy(&patch.UpdateStrategy),
 		},
 		func() (interface{}, *errors.ServiceError) {
 			ctx := r.Context()
 			id := mux.Vars(r)["id"]
-			payload, err := presenters.ConvertResourceManifest(patch.Manifest, patch.DeleteOption, patch.UpdateStrategy)
+			manifest, deleteOption, updateStrategy := patch.Manifest, patch.DeleteOption, patch.UpdateStrategy
+			forceConflicts := patch.ForceConflicts
+			patchType, _ := api.ParseManifestPatchType(patch.GetPatchType())
+			if serviceErr := h.checkLock(r, id); serviceErr != nil {
+				return nil, serviceErr
+			}
+			// the stored resource is needed to merge the feedback rules of its consumer, to patch the stored
+			// manifest, or to keep the stored force conflicts when neither the update strategy nor the force
+			// conflicts is requested
+			found, serviceErr := h.resource.Get(ctx, id)
+			if serviceErr != nil {
+				return nil, serviceErr
+			}
+			consumerRules, serviceErr := h.consumerFeedbackRules(ctx, found.ConsumerName)
+			if serviceErr != nil {
+				return nil, serviceErr
+			}
+			keepForceConflicts := updateStrategy == nil && forceConflicts == nil
+			if patchType != api.ManifestPatchTypeReplace || keepForceConflicts {
+				foundManifest, foundDeleteOption, foundUpdateStrategy, err := api.DecodeManifest(found.Payload)
+				if err != nil {
+					return nil, errors.GeneralError("failed to decode resource manifest: %s", err)
+				}
+				if patchType != api.ManifestPatchTypeReplace {
+					manifest, err = api.PatchManifest(foundManifest, patch.Manifest, patchType)
+					if err != nil {
+						return nil, errors.Validation("failed to patch resource manifest: %s", err)
+					}
+					// keep the stored delete option and update strategy if they are not patched
+					if deleteOption == nil {
+						deleteOption = foundDeleteOption
+					}
+					if updateStrategy == nil {
+						updateStrategy = foundUpdateStrategy
+					}
+				} else if keepForceConflicts && api.ForceConflicts(foundUpdateStrategy) {
+					forceConflicts = openapi.PtrBool(true)
+				}
+			}
+			if forceConflicts != nil {
+				if err := api.ValidateForceConflicts(updateStrategy, *forceConflicts); err != nil {
+					return nil, errors.Validation("%s", err)
+				}
+			}
+			payload, err := presenters.ConvertResourceManifest(manifest, deleteOption, updateStrategy, forceConflicts,
+				h.namespaceDefaults, consumerRules)
 			if err != nil {
 				return nil, errors.GeneralError("failed to convert resource manifest: %s", err)
 			}
 			resource, serviceErr := h.resource.Update(ctx, &api.Resource{
 				Meta:    api.Meta{ID: id},
-				Version: *patch.Version,
+				Version: int64(*patch.Version),
 				Type:    api.ResourceTypeSingle,
 				Payload: payload,
 			})
@@ -102,6 +410,16 @@ func (h resourceHandler) List(w http.ResponseWriter, r *http.Request) {
 			ctx := r.Context()
 
 			listArgs := services.NewListArguments(r.URL.Query())
+			conditions, serviceErr := conditionFilter(r)
+			if serviceErr != nil {
+				return nil, serviceErr
+			}
+			listArgs.Conditions = conditions
+			labels, serviceErr := labelFilter(r)
+			if serviceErr != nil {
+				return nil, serviceErr
+			}
+			listArgs.Labels = labels
 			if listArgs.Search == "" {
 				listArgs.Search = fmt.Sprintf("type='%s'", api.ResourceTypeSingle)
 			} else {
@@ -142,6 +460,7 @@ func (h resourceHandler) List(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h resourceHandler) Get(w http.ResponseWriter, r *http.Request) {
+	var version int64
 	cfg := &handlerConfig{
 		Action: func() (interface{}, *errors.ServiceError) {
 			id := mux.Vars(r)["id"]
@@ -150,16 +469,20 @@ func (h resourceHandler) Get(w http.ResponseWriter, r *http.Request) {
 			if serviceErr != nil {
 				return nil, serviceErr
 			}
+			version = resource.Version
 
 			res, err := presenters.PresentResource(resource)
 			if err != nil {
 				return nil, errors.GeneralError("failed to present resource: %s", err)
 			}
+			if fields := services.NewListArguments(r.URL.Query()).Fields; fields != nil {
+				return presenters.ObjectFilter(fields, res)
+			}
 			return res, nil
 		},
 	}
 
-	handleGet(w, r, cfg)
+	handleGetWithETag(w, r, cfg, func() int64 { return version })
 }
 
 // Resource Deletion Flow:
@@ -168,15 +491,23 @@ func (h resourceHandler) Get(w http.ResponseWriter, r *http.Request) {
 // 3. Maestro handles delete event and sends CloudEvent to work-agent
 // 4. Work-agent deletes resource, sends CloudEvent back to Maestro
 // 5. Maestro deletes resource from DB
+// The deletion is idempotent, deleting an already deleting or deleted resource succeeds. With the strict=true
+// query parameter, deleting a resource that is not found returns not found.
 func (h resourceHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	cfg := &handlerConfig{
 		Action: func() (interface{}, *errors.ServiceError) {
 			id := mux.Vars(r)["id"]
 			ctx := r.Context()
-			err := h.resource.MarkAsDeleting(ctx, id)
+			if err := h.checkLock(r, id); err != nil {
+				return nil, err
+			}
+			result, err := h.resource.MarkAsDeletingWithResult(ctx, id)
 			if err != nil {
 				return nil, err
 			}
+			if result == services.DeletionNotFound && r.URL.Query().Get("strict") == "true" {
+				return nil, errors.NotFound("Resource with id='%s' not found", id)
+			}
 			return nil, nil
 		},
 	}
@@ -184,6 +515,7 @@ func (h resourceHandler) Delete(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h resourceHandler) GetBundle(w http.ResponseWriter, r *http.Request) {
+	var version int64
 	cfg := &handlerConfig{
 		Action: func() (interface{}, *errors.ServiceError) {
 			id := mux.Vars(r)["id"]
@@ -192,24 +524,111 @@ func (h resourceHandler) GetBundle(w http.ResponseWriter, r *http.Request) {
 			if serviceErr != nil {
 				return nil, serviceErr
 			}
+			version = resource.Version
 
 			resBundle, err := presenters.PresentResourceBundle(resource)
 			if err != nil {
 				return nil, errors.GeneralError("failed to present resource bundle: %s", err)
 			}
+			if fields := services.NewListArguments(r.URL.Query()).Fields; fields != nil {
+				return presenters.ObjectFilter(fields, resBundle)
+			}
 			return resBundle, nil
 		},
 	}
 
+	handleGetWithETag(w, r, cfg, func() int64 { return version })
+}
+
+// GetBundleManifestWork returns the ManifestWork that the agent reconstructs from the resource bundle, see
+// cloudevents.DecodeManifestWork.
+func (h resourceHandler) GetBundleManifestWork(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			id := mux.Vars(r)["id"]
+			resource, serviceErr := h.resource.Get(r.Context(), id)
+			if serviceErr != nil {
+				return nil, serviceErr
+			}
+			if resource.Type != api.ResourceTypeBundle {
+				return nil, errors.NotFound("Resource bundle with id='%s' not found", id)
+			}
+
+			work, err := cloudevents.DecodeManifestWork(resource)
+			if err != nil {
+				return nil, errors.GeneralError("failed to decode the manifestwork: %s", err)
+			}
+			return work, nil
+		},
+	}
+
 	handleGet(w, r, cfg)
 }
 
+// PatchBundle updates the delete option and the manifest configs of a resource bundle, its manifests are kept as
+// they are.
+func (h resourceHandler) PatchBundle(w http.ResponseWriter, r *http.Request) {
+	var patch openapi.ResourceBundlePatchRequest
+
+	cfg := &handlerConfig{
+		&patch,
+		[]validate{
+			validateNotEmpty(&patch, "Version", "version"),
+		},
+		func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			id := mux.Vars(r)["id"]
+			found, serviceErr := h.resource.Get(ctx, id)
+			if serviceErr != nil {
+				return nil, serviceErr
+			}
+			if found.Type != api.ResourceTypeBundle {
+				return nil, errors.NotFound("Resource bundle with id='%s' not found", id)
+			}
+			if serviceErr := h.checkLock(r, id); serviceErr != nil {
+				return nil, serviceErr
+			}
+			payload, err := api.PatchManifestBundleOptions(found.Payload, patch.DeleteOption, patch.ManifestConfigs)
+			if err != nil {
+				return nil, errors.Validation("failed to patch resource bundle: %s", err)
+			}
+			resource, serviceErr := h.resource.Update(ctx, &api.Resource{
+				Meta:    api.Meta{ID: id},
+				Version: int64(*patch.Version),
+				Type:    api.ResourceTypeBundle,
+				Payload: payload,
+			})
+			if serviceErr != nil {
+				return nil, serviceErr
+			}
+			resBundle, err := presenters.PresentResourceBundle(resource)
+			if err != nil {
+				return nil, errors.GeneralError("failed to present resource bundle: %s", err)
+			}
+			return resBundle, nil
+		},
+		handleError,
+	}
+
+	handle(w, r, cfg, http.StatusOK)
+}
+
 func (h resourceHandler) ListBundle(w http.ResponseWriter, r *http.Request) {
 	cfg := &handlerConfig{
 		Action: func() (interface{}, *errors.ServiceError) {
 			ctx := r.Context()
 
 			listArgs := services.NewListArguments(r.URL.Query())
+			conditions, serviceErr := conditionFilter(r)
+			if serviceErr != nil {
+				return nil, serviceErr
+			}
+			listArgs.Conditions = conditions
+			labels, serviceErr := labelFilter(r)
+			if serviceErr != nil {
+				return nil, serviceErr
+			}
+			listArgs.Labels = labels
 			if listArgs.Search == "" {
 				listArgs.Search = fmt.Sprintf("type='%s'", api.ResourceTypeBundle)
 			} else {
@@ -248,3 +667,490 @@ func (h resourceHandler) ListBundle(w http.ResponseWriter, r *http.Request) {
 
 	handleList(w, r, cfg)
 }
+
+// ListPendingDeletion lists the resources that are marked as deleting but not yet confirmed deleted by the agent.
+// The optional olderThan query parameter (e.g. 10m) only returns the resources that have been deleting longer than it.
+func (h resourceHandler) ListPendingDeletion(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			resources, paging, serviceErr := h.findPendingDeletion(r, api.ResourceTypeSingle)
+			if serviceErr != nil {
+				return nil, serviceErr
+			}
+			resourceList := openapi.ResourceList{
+				Kind:  *presenters.ObjectKind(resources),
+				Page:  int32(paging.Page),
+				Size:  int32(paging.Size),
+				Total: int32(paging.Total),
+				Items: []openapi.Resource{},
+			}
+
+			for i := range resources {
+				converted, err := presenters.PresentResource(&resources[i])
+				if err != nil {
+					return nil, errors.GeneralError("failed to present resource: %s", err)
+				}
+				resourceList.Items = append(resourceList.Items, *converted)
+			}
+			return resourceList, nil
+		},
+	}
+
+	handleList(w, r, cfg)
+}
+
+// ListVersionDrift lists a page of the resources whose version observed by the agent drifts from the resource version,
+// the largest drifts come first unless the orderBy is given. The optional olderThan query parameter (e.g. 10m) only
+// returns the unreported and lagging resources that are not updated for longer than it, and the optional state query
+// parameter only returns the resources in the given drift state.
+func (h resourceHandler) ListVersionDrift(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			updatedBefore := time.Now()
+			if olderThan := r.URL.Query().Get("olderThan"); olderThan != "" {
+				duration, err := time.ParseDuration(olderThan)
+				if err != nil {
+					return nil, errors.BadRequest("invalid olderThan %q: %s", olderThan, err)
+				}
+				updatedBefore = updatedBefore.Add(-duration)
+			}
+			state, err := api.ParseVersionDriftState(r.URL.Query().Get("state"))
+			if err != nil {
+				return nil, errors.BadRequest("invalid state: %s", err)
+			}
+
+			listArgs := services.NewListArguments(r.URL.Query())
+			// the resources that are ahead are always listed
+			filters := []string{fmt.Sprintf("(state='%s' or updated_at<'%s')", api.VersionDriftAhead, updatedBefore.UTC().Format(time.RFC3339Nano))}
+			if len(state) != 0 {
+				filters = append(filters, fmt.Sprintf("state='%s'", state))
+			}
+			if listArgs.Search != "" {
+				filters = append(filters, fmt.Sprintf("(%s)", listArgs.Search))
+			}
+			listArgs.Search = strings.Join(filters, " and ")
+			if len(listArgs.OrderBy) == 0 {
+				listArgs.OrderBy = []string{"drift desc", "updated_at", "resource_id"}
+			}
+			var drifts []api.ResourceVersionDrift
+			paging, serviceErr := h.generic.List(r.Context(), "username", listArgs, &drifts)
+			if serviceErr != nil {
+				return nil, serviceErr
+			}
+			driftList := openapi.ResourceVersionDriftList{
+				Kind:  *presenters.ObjectKind(drifts),
+				Page:  int32(paging.Page),
+				Size:  int32(paging.Size),
+				Total: int32(paging.Total),
+				Items: []openapi.ResourceVersionDrift{},
+			}
+			for i := range drifts {
+				driftList.Items = append(driftList.Items, presenters.PresentResourceVersionDrift(&drifts[i]))
+			}
+			return driftList, nil
+		},
+	}
+
+	handleList(w, r, cfg)
+}
+
+// ListBundlePendingDeletion lists the resource bundles that are marked as deleting but not yet confirmed deleted by the agent.
+func (h resourceHandler) ListBundlePendingDeletion(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			resources, paging, serviceErr := h.findPendingDeletion(r, api.ResourceTypeBundle)
+			if serviceErr != nil {
+				return nil, serviceErr
+			}
+			resourceBundleList := openapi.ResourceBundleList{
+				Kind:  "ResourceBundleList",
+				Page:  int32(paging.Page),
+				Size:  int32(paging.Size),
+				Total: int32(paging.Total),
+				Items: []openapi.ResourceBundle{},
+			}
+
+			for i := range resources {
+				converted, err := presenters.PresentResourceBundle(&resources[i])
+				if err != nil {
+					return nil, errors.GeneralError("failed to present resource: %s", err)
+				}
+				resourceBundleList.Items = append(resourceBundleList.Items, *converted)
+			}
+			return resourceBundleList, nil
+		},
+	}
+
+	handleList(w, r, cfg)
+}
+
+// ListOrphaned lists the resources whose consumer doesn't exist, so they can be reconciled manually, e.g. by
+// recreating the consumer or deleting the resources.
+func (h resourceHandler) ListOrphaned(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			listArgs := services.NewListArguments(r.URL.Query())
+			resources, paging, serviceErr := h.resource.FindOrphaned(r.Context(), api.ResourceTypeSingle, listArgs)
+			if serviceErr != nil {
+				return nil, serviceErr
+			}
+			resourceList := openapi.ResourceList{
+				Kind:  *presenters.ObjectKind(resources),
+				Page:  int32(paging.Page),
+				Size:  int32(paging.Size),
+				Total: int32(paging.Total),
+				Items: []openapi.Resource{},
+			}
+
+			for _, resource := range resources {
+				converted, err := presenters.PresentResource(resource)
+				if err != nil {
+					return nil, errors.GeneralError("failed to present resource: %s", err)
+				}
+				resourceList.Items = append(resourceList.Items, *converted)
+			}
+			return resourceList, nil
+		},
+	}
+
+	handleList(w, r, cfg)
+}
+
+// ListBundleOrphaned lists the resource bundles whose consumer doesn't exist.
+func (h resourceHandler) ListBundleOrphaned(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			listArgs := services.NewListArguments(r.URL.Query())
+			resources, paging, serviceErr := h.resource.FindOrphaned(r.Context(), api.ResourceTypeBundle, listArgs)
+			if serviceErr != nil {
+				return nil, serviceErr
+			}
+			resourceBundleList := openapi.ResourceBundleList{
+				Kind:  "ResourceBundleList",
+				Page:  int32(paging.Page),
+				Size:  int32(paging.Size),
+				Total: int32(paging.Total),
+				Items: []openapi.ResourceBundle{},
+			}
+
+			for _, resource := range resources {
+				converted, err := presenters.PresentResourceBundle(resource)
+				if err != nil {
+					return nil, errors.GeneralError("failed to present resource: %s", err)
+				}
+				resourceBundleList.Items = append(resourceBundleList.Items, *converted)
+			}
+			return resourceBundleList, nil
+		},
+	}
+
+	handleList(w, r, cfg)
+}
+
+// conditionFilter returns the conditions that the listed resources must have, e.g. "?condition=Degraded=True".
+func conditionFilter(r *http.Request) ([]string, *errors.ServiceError) {
+	conditions, err := api.ParseConditionFilter(r.URL.Query().Get("condition"))
+	if err != nil {
+		return nil, errors.BadRequest("invalid condition filter: %s", err)
+	}
+	return conditions, nil
+}
+
+// labelFilter returns the labels that the listed resources must have, e.g. "?label=app.kubernetes.io/name=web".
+func labelFilter(r *http.Request) ([]string, *errors.ServiceError) {
+	labels, err := api.ParseLabelFilter(r.URL.Query().Get("label"))
+	if err != nil {
+		return nil, errors.BadRequest("invalid label filter: %s", err)
+	}
+	return labels, nil
+}
+
+// findPendingDeletion lists a page of the resources of the type that are marked as deleting before the olderThan, the
+// resources that have been deleting the longest come first unless the orderBy is given.
+func (h resourceHandler) findPendingDeletion(r *http.Request, resourceType api.ResourceType) ([]api.Resource, *api.PagingMeta, *errors.ServiceError) {
+	deletedBefore := time.Now()
+	if olderThan := r.URL.Query().Get("olderThan"); olderThan != "" {
+		duration, err := time.ParseDuration(olderThan)
+		if err != nil {
+			return nil, nil, errors.BadRequest("invalid olderThan %q: %s", olderThan, err)
+		}
+		deletedBefore = deletedBefore.Add(-duration)
+	}
+
+	listArgs := services.NewListArguments(r.URL.Query())
+	// the resources marked as deleting are soft deleted, they are listed by the generic list as well
+	search := fmt.Sprintf("type='%s' and deleted_at<'%s'", resourceType, deletedBefore.UTC().Format(time.RFC3339Nano))
+	if listArgs.Search != "" {
+		search = fmt.Sprintf("%s and (%s)", search, listArgs.Search)
+	}
+	listArgs.Search = search
+	if len(listArgs.OrderBy) == 0 {
+		listArgs.OrderBy = []string{"deleted_at", "id"}
+	}
+	var resources []api.Resource
+	paging, serviceErr := h.resource.ListWithArgs(r.Context(), "username", listArgs, &resources)
+	if serviceErr != nil {
+		return nil, nil, serviceErr
+	}
+	return resources, paging, nil
+}
+
+// ListRevisions lists the manifest revisions of the resource, each revision is presented as the resource at the
+// revision version.
+func (h resourceHandler) ListRevisions(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			resources, paging, serviceErr := h.findRevisions(r)
+			if serviceErr != nil {
+				return nil, serviceErr
+			}
+			resourceList := openapi.ResourceList{
+				Kind:  *presenters.ObjectKind(resources),
+				Page:  int32(paging.Page),
+				Size:  int32(paging.Size),
+				Total: int32(paging.Total),
+				Items: []openapi.Resource{},
+			}
+
+			for _, resource := range resources {
+				converted, err := presenters.PresentResource(resource)
+				if err != nil {
+					return nil, errors.GeneralError("failed to present resource: %s", err)
+				}
+				resourceList.Items = append(resourceList.Items, *converted)
+			}
+			return resourceList, nil
+		},
+	}
+
+	handleList(w, r, cfg)
+}
+
+// ListBundleRevisions lists the manifest revisions of the resource bundle.
+func (h resourceHandler) ListBundleRevisions(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			resources, paging, serviceErr := h.findRevisions(r)
+			if serviceErr != nil {
+				return nil, serviceErr
+			}
+			resourceBundleList := openapi.ResourceBundleList{
+				Kind:  "ResourceBundleList",
+				Page:  int32(paging.Page),
+				Size:  int32(paging.Size),
+				Total: int32(paging.Total),
+				Items: []openapi.ResourceBundle{},
+			}
+
+			for _, resource := range resources {
+				converted, err := presenters.PresentResourceBundle(resource)
+				if err != nil {
+					return nil, errors.GeneralError("failed to present resource: %s", err)
+				}
+				resourceBundleList.Items = append(resourceBundleList.Items, *converted)
+			}
+			return resourceBundleList, nil
+		},
+	}
+
+	handleList(w, r, cfg)
+}
+
+// Revert reverts the resource manifest to the given revision by creating a new resource version.
+func (h resourceHandler) Revert(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			id := mux.Vars(r)["id"]
+			version, err := strconv.ParseInt(mux.Vars(r)["version"], 10, 64)
+			if err != nil {
+				return nil, errors.BadRequest("invalid revision version %q: %s", mux.Vars(r)["version"], err)
+			}
+			found, serviceErr := h.resource.Get(ctx, id)
+			if serviceErr != nil {
+				return nil, serviceErr
+			}
+			if found.Type != api.ResourceTypeSingle {
+				return nil, errors.NotFound("Resource with id='%s' not found", id)
+			}
+			if serviceErr := h.checkLock(r, id); serviceErr != nil {
+				return nil, serviceErr
+			}
+			resource, serviceErr := h.resource.Revert(ctx, id, version)
+			if serviceErr != nil {
+				return nil, serviceErr
+			}
+			res, err := presenters.PresentResource(resource)
+			if err != nil {
+				return nil, errors.GeneralError("failed to present resource: %s", err)
+			}
+			return res, nil
+		},
+	}
+
+	handleGet(w, r, cfg)
+}
+
+// RevertBundle reverts the resource bundle manifests to the given revision by creating a new resource bundle version.
+func (h resourceHandler) RevertBundle(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			id := mux.Vars(r)["id"]
+			version, err := strconv.ParseInt(mux.Vars(r)["version"], 10, 64)
+			if err != nil {
+				return nil, errors.BadRequest("invalid revision version %q: %s", mux.Vars(r)["version"], err)
+			}
+			found, serviceErr := h.resource.Get(ctx, id)
+			if serviceErr != nil {
+				return nil, serviceErr
+			}
+			if found.Type != api.ResourceTypeBundle {
+				return nil, errors.NotFound("Resource bundle with id='%s' not found", id)
+			}
+			if serviceErr := h.checkLock(r, id); serviceErr != nil {
+				return nil, serviceErr
+			}
+			resource, serviceErr := h.resource.Revert(ctx, id, version)
+			if serviceErr != nil {
+				return nil, serviceErr
+			}
+			resBundle, err := presenters.PresentResourceBundle(resource)
+			if err != nil {
+				return nil, errors.GeneralError("failed to present resource bundle: %s", err)
+			}
+			return resBundle, nil
+		},
+	}
+
+	handleGet(w, r, cfg)
+}
+
+// Reconcile forces the agent to reconcile the resource by bumping the resource version without changing its manifest.
+// The manifest is not edited, so the reconcile is not rejected by the lock of another owner.
+func (h resourceHandler) Reconcile(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			id := mux.Vars(r)["id"]
+			resource, serviceErr := h.resource.Reconcile(ctx, id)
+			if serviceErr != nil {
+				return nil, serviceErr
+			}
+			res, err := presenters.PresentResource(resource)
+			if err != nil {
+				return nil, errors.GeneralError("failed to present resource: %s", err)
+			}
+			return res, nil
+		},
+	}
+
+	handleGet(w, r, cfg)
+}
+
+// ReleaseQuarantine releases the quarantined resource and re-broadcasts it to the agent, a released resource bundle
+// is presented as a resource bundle.
+func (h resourceHandler) ReleaseQuarantine(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			id := mux.Vars(r)["id"]
+			resource, serviceErr := h.resource.ReleaseQuarantine(ctx, id)
+			if serviceErr != nil {
+				return nil, serviceErr
+			}
+			if resource.Type == api.ResourceTypeBundle {
+				resBundle, err := presenters.PresentResourceBundle(resource)
+				if err != nil {
+					return nil, errors.GeneralError("failed to present resource bundle: %s", err)
+				}
+				return resBundle, nil
+			}
+			res, err := presenters.PresentResource(resource)
+			if err != nil {
+				return nil, errors.GeneralError("failed to present resource: %s", err)
+			}
+			return res, nil
+		},
+	}
+
+	handleGet(w, r, cfg)
+}
+
+// TransferOwnership reassigns the resource to the to_source of the request body, so the new source can manage the
+// resource. The transfer is recorded with the user of the request for audit.
+func (h resourceHandler) TransferOwnership(w http.ResponseWriter, r *http.Request) {
+	var transfer openapi.ResourceOwnershipTransfer
+	cfg := &handlerConfig{
+		&transfer,
+		[]validate{
+			validateEmpty(&transfer, "Id", "id"),
+			validateEmpty(&transfer, "FromSource", "from_source"),
+			validateEmpty(&transfer, "TransferredBy", "transferred_by"),
+			validateNotEmpty(&transfer, "ToSource", "to_source"),
+		},
+		func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			id := mux.Vars(r)["id"]
+			transferred, serviceErr := h.resource.TransferOwnership(ctx, id, *transfer.ToSource, auth.GetUsernameFromContext(ctx))
+			if serviceErr != nil {
+				return nil, serviceErr
+			}
+			return presenters.PresentResourceOwnershipTransfer(transferred), nil
+		},
+		handleError,
+	}
+
+	handle(w, r, cfg, http.StatusCreated)
+}
+
+// ListOwnershipTransfers lists a page of the ownership transfers of the resource, the latest transfer comes first.
+func (h resourceHandler) ListOwnershipTransfers(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			listArgs := services.NewListArguments(r.URL.Query())
+			transfers, paging, serviceErr := h.resource.ListOwnershipTransfers(r.Context(), mux.Vars(r)["id"], listArgs)
+			if serviceErr != nil {
+				return nil, serviceErr
+			}
+			transferList := openapi.ResourceOwnershipTransferList{
+				Kind:  *presenters.ObjectKind(transfers),
+				Page:  int32(paging.Page),
+				Size:  int32(paging.Size),
+				Total: int32(paging.Total),
+				Items: []openapi.ResourceOwnershipTransfer{},
+			}
+			for _, transfer := range transfers {
+				transferList.Items = append(transferList.Items, presenters.PresentResourceOwnershipTransfer(transfer))
+			}
+			return transferList, nil
+		},
+	}
+
+	handleList(w, r, cfg)
+}
+
+// findRevisions returns a page of the revisions of the requested resource as the resources at the revision versions.
+func (h resourceHandler) findRevisions(r *http.Request) (api.ResourceList, *api.PagingMeta, *errors.ServiceError) {
+	id := mux.Vars(r)["id"]
+	ctx := r.Context()
+	resource, serviceErr := h.resource.Get(ctx, id)
+	if serviceErr != nil {
+		return nil, nil, serviceErr
+	}
+
+	revisions, paging, serviceErr := h.resource.ListRevisions(ctx, id, services.NewListArguments(r.URL.Query()))
+	if serviceErr != nil {
+		return nil, nil, serviceErr
+	}
+
+	resources := api.ResourceList{}
+	for _, revision := range revisions {
+		res := *resource
+		res.Version = revision.Version
+		res.Payload = revision.Payload
+		res.UpdatedAt = revision.CreatedAt
+		resources = append(resources, &res)
+	}
+	return resources, paging, nil
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/openshift-online/maestro/pkg/api/openapi"
+	"github.com/openshift-online/maestro/pkg/api/presenters"
+	"github.com/openshift-online/maestro/pkg/auth"
+	"github.com/openshift-online/maestro/pkg/errors"
+	"github.com/openshift-online/maestro/pkg/services"
+)
+
+type resourceLockHandler struct {
+	lock   services.ResourceLockService
+	admins auth.AdminAuthorizer
+}
+
+func NewResourceLockHandler(lock services.ResourceLockService, admins auth.AdminAuthorizer) *resourceLockHandler {
+	return &resourceLockHandler{
+		lock:   lock,
+		admins: admins,
+	}
+}
+
+func (h resourceLockHandler) Get(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			lock, err := h.lock.Get(r.Context(), mux.Vars(r)["id"])
+			if err != nil {
+				return nil, err
+			}
+			return presenters.PresentResourceLock(lock), nil
+		},
+	}
+
+	handleGet(w, r, cfg)
+}
+
+// Acquire locks the resource for the requesting user, the owner cannot be given by the request. The lock held by the
+// user is renewed.
+func (h resourceLockHandler) Acquire(w http.ResponseWriter, r *http.Request) {
+	var lock openapi.ResourceLock
+	cfg := &handlerConfig{
+		&lock,
+		[]validate{
+			validateEmpty(&lock, "ResourceId", "resource_id"),
+			validateEmpty(&lock, "Owner", "owner"),
+		},
+		func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			ttl := time.Duration(lock.GetTtlSeconds()) * time.Second
+			acquired, err := h.lock.Acquire(ctx, mux.Vars(r)["id"], auth.GetUsernameFromContext(ctx), ttl)
+			if err != nil {
+				return nil, err
+			}
+			return presenters.PresentResourceLock(acquired), nil
+		},
+		handleError,
+	}
+
+	handle(w, r, cfg, http.StatusOK)
+}
+
+// Release unlocks the resource held by the requesting user, the lock of another owner is only released by an admin
+// with the force=true query parameter.
+func (h resourceLockHandler) Release(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			force := isForced(r)
+			if force {
+				if err := requireAdminToForce(r, h.admins); err != nil {
+					return nil, err
+				}
+			}
+			if err := h.lock.Release(r.Context(), mux.Vars(r)["id"], auth.GetUsernameFromContext(r.Context()), force); err != nil {
+				return nil, err
+			}
+			return nil, nil
+		},
+	}
+	handleDelete(w, r, cfg, http.StatusNoContent)
+}
+
+// isForced tells whether the resource soft-lock of another owner is overridden by the force=true query parameter.
+func isForced(r *http.Request) bool {
+	return r.URL.Query().Get("force") == "true"
+}
+
+// requireAdminToForce returns Forbidden if the requesting user is not an admin, only an admin can override the
+// resource soft-lock of another owner.
+func requireAdminToForce(r *http.Request, admins auth.AdminAuthorizer) *errors.ServiceError {
+	if !admins.IsAdmin(r.Context()) {
+		return errors.Forbidden("User '%s' is not an admin, overriding the lock of a resource requires an admin",
+			auth.GetUsernameFromContext(r.Context()))
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/auth"
+	"github.com/openshift-online/maestro/pkg/dao/mocks"
+	dbmocks "github.com/openshift-online/maestro/pkg/db/mocks"
+	"github.com/openshift-online/maestro/pkg/services"
+)
+
+// TestResourceLockWithoutJWT checks the resource soft-lock when the JWT authentication is disabled, the requests have
+// no user and every user is an admin.
+func TestResourceLockWithoutJWT(t *testing.T) {
+	ctx := context.Background()
+	resourceDao := mocks.NewResourceDao()
+	if _, err := resourceDao.Create(ctx, &api.Resource{Meta: api.Meta{ID: "locked"}, ConsumerName: "cluster1",
+		Type: api.ResourceTypeSingle, Version: 1}); err != nil {
+		t.Fatalf("failed to create the resource: %v", err)
+	}
+	lockService := services.NewResourceLockService(dbmocks.NewMockAdvisoryLockFactory(), resourceDao,
+		mocks.NewResourceLockDao(), time.Minute, time.Hour)
+	resourceService := services.NewResourceService(dbmocks.NewMockAdvisoryLockFactory(), dbmocks.NewMockTransactor(), resourceDao,
+		mocks.NewResourceRevisionDao(), mocks.NewResourceOwnershipTransferDao(), mocks.NewConsumerDao(),
+		services.NewEventService(mocks.NewEventDao()), nil, services.ResourceServiceOptions{})
+	admins := auth.NewAdminAuthorizerMock()
+	resourceHandler := NewResourceHandler(resourceService, nil, nil, lockService, admins, nil)
+	lockHandler := NewResourceLockHandler(lockService, admins)
+
+	request := func(method, target string, body io.Reader) *http.Request {
+		return mux.SetURLVars(httptest.NewRequest(method, target, body), map[string]string{"id": "locked"})
+	}
+
+	// the lock cannot be acquired without a user
+	w := httptest.NewRecorder()
+	lockHandler.Acquire(w, request(http.MethodPost, "/api/maestro/v1/resources/locked/lock", strings.NewReader("{}")))
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "the lock owner is required") {
+		t.Errorf("expected the lock without a user is rejected with %d, but got %d %s", http.StatusBadRequest, w.Code, w.Body.String())
+	}
+
+	if _, svcErr := lockService.Acquire(ctx, "locked", "alice", 0); svcErr != nil {
+		t.Fatalf("failed to acquire the lock: %s", svcErr)
+	}
+
+	// a request without a user is not the owner of the lock
+	w = httptest.NewRecorder()
+	resourceHandler.Delete(w, request(http.MethodDelete, "/api/maestro/v1/resources/locked", nil))
+	if w.Code != http.StatusLocked {
+		t.Fatalf("expected the deletion of the locked resource is rejected with %d, but got %d %s", http.StatusLocked, w.Code, w.Body.String())
+	}
+	resource, err := resourceDao.Get(ctx, "locked")
+	if err != nil {
+		t.Fatalf("failed to get the resource: %v", err)
+	}
+	if resource.DeletedAt.Valid {
+		t.Errorf("expected the locked resource is not marked as deleting")
+	}
+
+	// every user is an admin without the JWT authentication, so the lock is overridden by force
+	w = httptest.NewRecorder()
+	resourceHandler.Delete(w, request(http.MethodDelete, "/api/maestro/v1/resources/locked?force=true", nil))
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected the forced deletion of the locked resource succeeds, but got %d %s", w.Code, w.Body.String())
+	}
+	resource, err = resourceDao.Get(ctx, "locked")
+	if err != nil {
+		t.Fatalf("failed to get the resource: %v", err)
+	}
+	if !resource.DeletedAt.Valid {
+		t.Errorf("expected the resource is marked as deleting by the forced deletion")
+	}
+}
+
+// TestRevertLockedResource checks the revert of a resource (or a resource bundle) locked by another owner is rejected,
+// so the revert cannot clobber the edits of the lock owner.
+func TestRevertLockedResource(t *testing.T) {
+	ctx := context.Background()
+	resourceDao := mocks.NewResourceDao()
+	for id, resourceType := range map[string]api.ResourceType{"single": api.ResourceTypeSingle, "bundle": api.ResourceTypeBundle} {
+		if _, err := resourceDao.Create(ctx, &api.Resource{Meta: api.Meta{ID: id}, ConsumerName: "cluster1",
+			Type: resourceType, Version: 2}); err != nil {
+			t.Fatalf("failed to create the resource: %v", err)
+		}
+	}
+	lockService := services.NewResourceLockService(dbmocks.NewMockAdvisoryLockFactory(), resourceDao,
+		mocks.NewResourceLockDao(), time.Minute, time.Hour)
+	resourceService := services.NewResourceService(dbmocks.NewMockAdvisoryLockFactory(), dbmocks.NewMockTransactor(), resourceDao,
+		mocks.NewResourceRevisionDao(), mocks.NewResourceOwnershipTransferDao(), mocks.NewConsumerDao(),
+		services.NewEventService(mocks.NewEventDao()), nil, services.ResourceServiceOptions{})
+	resourceHandler := NewResourceHandler(resourceService, nil, nil, lockService, auth.NewAdminAuthorizerMock(), nil)
+
+	cases := []struct {
+		id     string
+		revert http.HandlerFunc
+	}{
+		{id: "single", revert: resourceHandler.Revert},
+		{id: "bundle", revert: resourceHandler.RevertBundle},
+	}
+	for _, c := range cases {
+		if _, svcErr := lockService.Acquire(ctx, c.id, "alice", 0); svcErr != nil {
+			t.Fatalf("failed to acquire the lock: %s", svcErr)
+		}
+
+		w := httptest.NewRecorder()
+		c.revert(w, mux.SetURLVars(httptest.NewRequest(http.MethodPost, "/api/maestro/v1/resources/"+c.id+"/revisions/1/revert", nil),
+			map[string]string{"id": c.id, "version": "1"}))
+		if w.Code != http.StatusLocked {
+			t.Fatalf("expected the revert of the locked resource %s is rejected with %d, but got %d %s", c.id, http.StatusLocked, w.Code, w.Body.String())
+		}
+		resource, err := resourceDao.Get(ctx, c.id)
+		if err != nil {
+			t.Fatalf("failed to get the resource: %v", err)
+		}
+		if resource.Version != 2 {
+			t.Errorf("expected the locked resource %s is not reverted, but got version %d", c.id, resource.Version)
+		}
+	}
+}
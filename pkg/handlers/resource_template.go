@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/api/openapi"
+	"github.com/openshift-online/maestro/pkg/api/presenters"
+	"github.com/openshift-online/maestro/pkg/auth"
+	"github.com/openshift-online/maestro/pkg/errors"
+	"github.com/openshift-online/maestro/pkg/services"
+)
+
+type resourceTemplateHandler struct {
+	template services.ResourceTemplateService
+	// resource creates the resources from the rendered templates, so they are created as the resources of the
+	// resource creation requests.
+	resource *resourceHandler
+	generic  services.GenericService
+	// admins authorizes the deletions of the templates created by another user.
+	admins auth.AdminAuthorizer
+}
+
+func NewResourceTemplateHandler(template services.ResourceTemplateService, resource *resourceHandler,
+	generic services.GenericService, admins auth.AdminAuthorizer) *resourceTemplateHandler {
+	return &resourceTemplateHandler{
+		template: template,
+		resource: resource,
+		generic:  generic,
+		admins:   admins,
+	}
+}
+
+func (h resourceTemplateHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var template openapi.ResourceTemplate
+	cfg := &handlerConfig{
+		&template,
+		[]validate{
+			validateEmpty(&template, "Id", "id"),
+			validateNotEmpty(&template, "Name", "name"),
+		},
+		func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			resourceTemplate := presenters.ConvertResourceTemplate(template)
+			resourceTemplate.CreatedBy = auth.GetUsernameFromContext(ctx)
+			created, err := h.template.Create(ctx, resourceTemplate)
+			if err != nil {
+				return nil, err
+			}
+			return presenters.PresentResourceTemplate(created), nil
+		},
+		handleError,
+	}
+
+	handle(w, r, cfg, http.StatusCreated)
+}
+
+func (h resourceTemplateHandler) List(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+
+			listArgs := services.NewListArguments(r.URL.Query())
+			templates := []api.ResourceTemplate{}
+			paging, err := h.generic.List(ctx, "username", listArgs, &templates)
+			if err != nil {
+				return nil, err
+			}
+
+			templateList := openapi.ResourceTemplateList{
+				Kind:  *presenters.ObjectKind(templates),
+				Page:  int32(paging.Page),
+				Size:  int32(paging.Size),
+				Total: int32(paging.Total),
+				Items: []openapi.ResourceTemplate{},
+			}
+			for _, template := range templates {
+				templateList.Items = append(templateList.Items, presenters.PresentResourceTemplate(&template))
+			}
+			return templateList, nil
+		},
+	}
+
+	handleList(w, r, cfg)
+}
+
+func (h resourceTemplateHandler) Get(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			template, err := h.template.Get(r.Context(), mux.Vars(r)["id"])
+			if err != nil {
+				return nil, err
+			}
+			return presenters.PresentResourceTemplate(template), nil
+		},
+	}
+
+	handleGet(w, r, cfg)
+}
+
+// Delete deletes the template, the resources created from the template are not changed. Only the user who created
+// the template or an admin can delete it.
+func (h resourceTemplateHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			id := mux.Vars(r)["id"]
+			template, err := h.template.Get(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			if err := h.checkCreator(r, template); err != nil {
+				return nil, err
+			}
+			if err := h.template.Delete(ctx, id); err != nil {
+				return nil, err
+			}
+			return nil, nil
+		},
+	}
+	handleDelete(w, r, cfg, http.StatusNoContent)
+}
+
+// CreateResource renders the template with the parameter values of the request and creates the resource of the
+// rendered manifest on the consumer of the request.
+func (h resourceTemplateHandler) CreateResource(w http.ResponseWriter, r *http.Request) {
+	var req openapi.ResourceFromTemplateRequest
+	cfg := &handlerConfig{
+		&req,
+		[]validate{
+			validateNotEmpty(&req, "ConsumerName", "consumer_name"),
+		},
+		func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			manifest, serviceErr := h.template.Render(ctx, mux.Vars(r)["id"], req.GetParameters())
+			if serviceErr != nil {
+				return nil, serviceErr
+			}
+			rs := openapi.Resource{
+				Name:         req.Name,
+				ConsumerName: req.ConsumerName,
+				Manifest:     manifest,
+			}
+			if serviceErr := validateStatusFeedbackPolicy(&rs.Manifest)(); serviceErr != nil {
+				return nil, serviceErr
+			}
+			return h.resource.create(ctx, rs)
+		},
+		handleError,
+	}
+
+	handle(w, r, cfg, http.StatusCreated)
+}
+
+// checkCreator returns Forbidden if the requesting user is neither the user who created the template nor an admin.
+func (h resourceTemplateHandler) checkCreator(r *http.Request, template *api.ResourceTemplate) *errors.ServiceError {
+	username := auth.GetUsernameFromContext(r.Context())
+	if template.CreatedBy == username || h.admins.IsAdmin(r.Context()) {
+		return nil
+	}
+	return errors.Forbidden("User '%s' is not the creator of the resource template %s, only the creator or an admin can change it",
+		username, template.ID)
+}
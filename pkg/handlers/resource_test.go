@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/auth"
+	"github.com/openshift-online/maestro/pkg/dao/mocks"
+	dbmocks "github.com/openshift-online/maestro/pkg/db/mocks"
+	"github.com/openshift-online/maestro/pkg/services"
+)
+
+// TestRevertResourceType checks a resource bundle cannot be reverted as a resource and vice versa.
+func TestRevertResourceType(t *testing.T) {
+	ctx := context.Background()
+	resourceDao := mocks.NewResourceDao()
+	for id, resourceType := range map[string]api.ResourceType{"single": api.ResourceTypeSingle, "bundle": api.ResourceTypeBundle} {
+		if _, err := resourceDao.Create(ctx, &api.Resource{Meta: api.Meta{ID: id}, ConsumerName: "cluster1",
+			Type: resourceType, Version: 2}); err != nil {
+			t.Fatalf("failed to create the resource: %v", err)
+		}
+	}
+	resourceService := services.NewResourceService(dbmocks.NewMockAdvisoryLockFactory(), dbmocks.NewMockTransactor(), resourceDao,
+		mocks.NewResourceRevisionDao(), mocks.NewResourceOwnershipTransferDao(), mocks.NewConsumerDao(),
+		services.NewEventService(mocks.NewEventDao()), nil, services.ResourceServiceOptions{})
+	resourceHandler := NewResourceHandler(resourceService, nil, nil, nil, auth.NewAdminAuthorizerMock(), nil)
+
+	cases := []struct {
+		id     string
+		revert http.HandlerFunc
+	}{
+		{id: "bundle", revert: resourceHandler.Revert},
+		{id: "single", revert: resourceHandler.RevertBundle},
+	}
+	for _, c := range cases {
+		w := httptest.NewRecorder()
+		c.revert(w, mux.SetURLVars(httptest.NewRequest(http.MethodPost, "/api/maestro/v1/resources/"+c.id+"/revisions/1/revert", nil),
+			map[string]string{"id": c.id, "version": "1"}))
+		if w.Code != http.StatusNotFound {
+			t.Errorf("expected the revert of the resource %s of another type is rejected with %d, but got %d %s", c.id, http.StatusNotFound, w.Code, w.Body.String())
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	e "errors"
+	"net/http"
+
+	"github.com/openshift-online/maestro/pkg/api/openapi"
+	"github.com/openshift-online/maestro/pkg/controllers"
+	"github.com/openshift-online/maestro/pkg/errors"
+)
+
+// statusResyncHandler starts, cancels and reports the status resync of all the consumers, see
+// controllers.StatusResyncer.
+type statusResyncHandler struct {
+	resyncer *controllers.StatusResyncer
+}
+
+func NewStatusResyncHandler(resyncer *controllers.StatusResyncer) *statusResyncHandler {
+	return &statusResyncHandler{
+		resyncer: resyncer,
+	}
+}
+
+func (h statusResyncHandler) Get(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			progress, started, err := h.resyncer.Progress(r.Context())
+			if err != nil {
+				return nil, errors.GeneralError("%s", err)
+			}
+			if !started {
+				return nil, errors.NotFound("no status resync is started")
+			}
+			return presentStatusResync(progress), nil
+		},
+	}
+
+	handleGet(w, r, cfg)
+}
+
+func (h statusResyncHandler) Start(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			progress, err := h.resyncer.Start(r.Context())
+			if err != nil {
+				return nil, statusResyncError(err)
+			}
+			return presentStatusResync(progress), nil
+		},
+	}
+
+	handleGet(w, r, cfg)
+}
+
+func (h statusResyncHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			progress, err := h.resyncer.Cancel(r.Context())
+			if err != nil {
+				return nil, statusResyncError(err)
+			}
+			return presentStatusResync(progress), nil
+		},
+	}
+
+	handleDelete(w, r, cfg, http.StatusOK)
+}
+
+func statusResyncError(err error) *errors.ServiceError {
+	switch {
+	case e.Is(err, controllers.ErrStatusResyncRunning):
+		return errors.Conflict("%s", err)
+	case e.Is(err, controllers.ErrStatusResyncNotRunning):
+		return errors.NotFound("%s", err)
+	case e.Is(err, controllers.ErrStatusResyncNotSupported):
+		return errors.BadRequest("%s", err)
+	default:
+		return errors.GeneralError("%s", err)
+	}
+}
+
+func presentStatusResync(progress controllers.StatusResyncProgress) openapi.StatusResync {
+	resync := openapi.StatusResync{
+		State:     openapi.PtrString(string(progress.State)),
+		Consumers: openapi.PtrInt32(int32(progress.Consumers)),
+		Processed: openapi.PtrInt32(int32(progress.Processed)),
+		Failed:    openapi.PtrInt32(int32(progress.Failed)),
+		StartedAt: &progress.StartedAt,
+	}
+	if len(progress.InstanceID) != 0 {
+		resync.InstanceId = openapi.PtrString(progress.InstanceID)
+	}
+	if !progress.FinishedAt.IsZero() {
+		resync.FinishedAt = &progress.FinishedAt
+	}
+	return resync
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openshift-online/maestro/pkg/errors"
+)
+
+// TimeoutMiddleware creates a new HTTP middleware that cancels the request context once the request is not handled
+// in the given timeout and responds with a timeout error, so a slow database query doesn't tie up the server. The
+// DAOs run the queries with the request context, so the queries are canceled along with the request.
+// The long-running requests (see isLongRunningRequest) are not bounded, and the timeout is disabled if it is 0.
+func TimeoutMiddleware(timeout time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if timeout <= 0 {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if isLongRunningRequest(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+
+			// the response is buffered, so the handler doesn't write to the response once it is timed out
+			tw := &timeoutWriter{header: make(http.Header)}
+			done := make(chan struct{})
+			panicChan := make(chan interface{}, 1)
+			go func() {
+				defer func() {
+					if p := recover(); p != nil {
+						panicChan <- p
+					}
+				}()
+				next.ServeHTTP(tw, r.WithContext(ctx))
+				close(done)
+			}()
+
+			select {
+			case p := <-panicChan:
+				panic(p)
+			case <-done:
+				tw.mu.Lock()
+				defer tw.mu.Unlock()
+				for k, v := range tw.header {
+					w.Header()[k] = v
+				}
+				if tw.code == 0 {
+					tw.code = http.StatusOK
+				}
+				w.WriteHeader(tw.code)
+				_, _ = w.Write(tw.buf.Bytes())
+			case <-ctx.Done():
+				tw.mu.Lock()
+				defer tw.mu.Unlock()
+				tw.timedOut = true
+				if ctx.Err() != context.DeadlineExceeded {
+					// the client has gone away, there is nobody to respond to
+					return
+				}
+				handleError(ctx, w, errors.Timeout("the request is not handled in %s", timeout))
+			}
+		})
+	}
+}
+
+// isLongRunningRequest returns true if the request is a streaming request, e.g. a watch or a server-sent events
+// stream, it is expected to be long-running, so it is excluded from the handler timeout.
+func isLongRunningRequest(r *http.Request) bool {
+	return r.URL.Query().Get("watch") == "true" || strings.Contains(r.Header.Get("Accept"), "text/event-stream")
+}
+
+// timeoutWriter buffers the response of the handler, the response is discarded once the request is timed out.
+type timeoutWriter struct {
+	mu       sync.Mutex
+	header   http.Header
+	buf      bytes.Buffer
+	code     int
+	timedOut bool
+}
+
+func (tw *timeoutWriter) Header() http.Header {
+	return tw.header
+}
+
+func (tw *timeoutWriter) Write(b []byte) (int, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	if tw.code == 0 {
+		tw.code = http.StatusOK
+	}
+	return tw.buf.Write(b)
+}
+
+func (tw *timeoutWriter) WriteHeader(code int) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.timedOut || tw.code != 0 {
+		return
+	}
+	tw.code = code
+}
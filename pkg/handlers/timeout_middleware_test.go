@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestTimeoutMiddleware(t *testing.T) {
+	RegisterTestingT(t)
+
+	slowHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+			w.WriteHeader(http.StatusOK)
+		}
+	})
+	fastHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"test"}`))
+	})
+
+	cases := []struct {
+		name    string
+		timeout time.Duration
+		handler http.Handler
+		url     string
+		status  int
+		body    string
+	}{
+		{
+			name:    "the slow request is timed out",
+			timeout: 10 * time.Millisecond,
+			handler: slowHandler,
+			url:     "/api/maestro/v1/resource-bundles",
+			status:  http.StatusGatewayTimeout,
+			body:    `"code":"maestro-27"`,
+		},
+		{
+			name:    "the fast request is handled",
+			timeout: time.Second,
+			handler: fastHandler,
+			url:     "/api/maestro/v1/resource-bundles",
+			status:  http.StatusCreated,
+			body:    `{"id":"test"}`,
+		},
+		{
+			name:    "the watch request is not bounded",
+			timeout: 10 * time.Millisecond,
+			handler: slowHandler,
+			url:     "/api/maestro/v1/resource-bundles?watch=true",
+			status:  http.StatusOK,
+		},
+		{
+			name:    "the timeout is disabled",
+			timeout: 0,
+			handler: slowHandler,
+			url:     "/api/maestro/v1/resource-bundles",
+			status:  http.StatusOK,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			TimeoutMiddleware(c.timeout)(c.handler).ServeHTTP(w, httptest.NewRequest(http.MethodGet, c.url, nil))
+			Expect(w.Code).To(Equal(c.status))
+			Expect(w.Body.String()).To(ContainSubstring(c.body))
+		})
+	}
+}
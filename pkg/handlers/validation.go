@@ -3,6 +3,7 @@ package handlers
 import (
 	"reflect"
 
+	"github.com/openshift-online/maestro/pkg/api"
 	"github.com/openshift-online/maestro/pkg/api/openapi"
 	"github.com/openshift-online/maestro/pkg/errors"
 )
@@ -59,3 +60,46 @@ func validateDeleteOptionAndUpdateStrategy(rs *openapi.Resource) validate {
 		return nil
 	}
 }
+
+// validateUpdateStrategy validates the type of the update strategy is supported by the work agent. The update
+// strategy is passed by pointer since it is read after the request body is decoded.
+func validateUpdateStrategy(updateStrategy *map[string]interface{}) validate {
+	return func() *errors.ServiceError {
+		if err := api.ValidateUpdateStrategy(*updateStrategy); err != nil {
+			return errors.Validation("%s", err)
+		}
+		return nil
+	}
+}
+
+// validatePatchType validates the patch type of the resource patch request.
+func validatePatchType(patch *openapi.ResourcePatchRequest) validate {
+	return func() *errors.ServiceError {
+		if _, err := api.ParseManifestPatchType(patch.GetPatchType()); err != nil {
+			return errors.Validation("%s", err)
+		}
+		return nil
+	}
+}
+
+// validateForceConflicts validates the force conflicts of the resource, it is only allowed with the update strategy
+// ServerSideApply.
+func validateForceConflicts(rs *openapi.Resource) validate {
+	return func() *errors.ServiceError {
+		if err := api.ValidateForceConflicts(rs.UpdateStrategy, rs.GetForceConflicts()); err != nil {
+			return errors.Validation("%s", err)
+		}
+		return nil
+	}
+}
+
+// validateStatusFeedbackPolicy validates the status feedback policy in the annotation of the manifest, see
+// api.StatusFeedbackAnnotation.
+func validateStatusFeedbackPolicy(manifest *map[string]interface{}) validate {
+	return func() *errors.ServiceError {
+		if _, err := api.ParseStatusFeedbackPolicy(*manifest); err != nil {
+			return errors.Validation("%s", err)
+		}
+		return nil
+	}
+}
@@ -2,6 +2,12 @@ package services
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/labels"
+	workv1 "open-cluster-management.io/api/work/v1"
 
 	"github.com/openshift-online/maestro/pkg/dao"
 	"github.com/openshift-online/maestro/pkg/db"
@@ -14,24 +20,77 @@ type ConsumerService interface {
 	Get(ctx context.Context, id string) (*api.Consumer, *errors.ServiceError)
 	Create(ctx context.Context, consumer *api.Consumer) (*api.Consumer, *errors.ServiceError)
 	Replace(ctx context.Context, consumer *api.Consumer) (*api.Consumer, *errors.ServiceError)
+	// PatchLabels patches the labels of the consumer atomically and records a consumer update event, the resources of
+	// the consumer are not changed. See ConsumerLabelsPatch for the order of the label operations.
+	PatchLabels(ctx context.Context, id string, patch ConsumerLabelsPatch) (*api.Consumer, *errors.ServiceError)
+	// PatchFeedbackRules replaces the status feedback rules of the consumer and records a consumer update event, the
+	// rules are merged into the manifests of the resources that are created or updated after the patch.
+	PatchFeedbackRules(ctx context.Context, id string, rules []workv1.FeedbackRule) (*api.Consumer, *errors.ServiceError)
+	// PatchDeletePropagationPolicy replaces the default delete propagation policy of the consumer and records a
+	// consumer update event, an empty policy removes it. The policy is resolved for the resources that are marked as
+	// deleting after the patch, see api.ResolveDeleteOption.
+	PatchDeletePropagationPolicy(ctx context.Context, id string, policy string) (*api.Consumer, *errors.ServiceError)
 	Delete(ctx context.Context, id string) *errors.ServiceError
 	All(ctx context.Context) (api.ConsumerList, *errors.ServiceError)
 
 	FindByIDs(ctx context.Context, ids []string) (api.ConsumerList, *errors.ServiceError)
+	FindByNames(ctx context.Context, names []string) (api.ConsumerList, *errors.ServiceError)
+
+	// BatchCreate validates and creates the given consumers, if one of the consumers is invalid or already exists
+	// (and skipExisting is false), none of the consumers will be created.
+	BatchCreate(ctx context.Context, consumers []*api.Consumer, skipExisting bool) ([]ConsumerBatchCreateResult, *errors.ServiceError)
+
+	// Groups returns a page of the consumer groups, a consumer group is defined by the consumer label
+	// group.maestro.open-cluster-management.io/<name>.
+	Groups(ctx context.Context, args *ListArguments) (api.ConsumerGroupList, *api.PagingMeta, *errors.ServiceError)
+	// FindByGroup returns the consumers in the given consumer group.
+	FindByGroup(ctx context.Context, group string) (api.ConsumerList, *errors.ServiceError)
+	// FindByLabelSelector returns the consumers whose labels match the given label selector, e.g. "env=prod,tier".
+	FindByLabelSelector(ctx context.Context, selector string) (api.ConsumerList, *errors.ServiceError)
+}
+
+// ConsumerLabelsPatch is the patch of the consumer labels, the labels are replaced by Replace (if it's not nil) first,
+// then the Add labels are added and the Remove label keys are removed.
+type ConsumerLabelsPatch struct {
+	Replace map[string]string
+	Add     map[string]string
+	Remove  []string
+}
+
+// ConsumerBatchCreateStatus is the status of a consumer in a batch creation.
+type ConsumerBatchCreateStatus string
+
+const (
+	// ConsumerBatchCreated means the consumer is created by the batch.
+	ConsumerBatchCreated ConsumerBatchCreateStatus = "Created"
+	// ConsumerBatchSkipped means the consumer already exists and is skipped by the batch.
+	ConsumerBatchSkipped ConsumerBatchCreateStatus = "Skipped"
+)
+
+// ConsumerBatchCreateResult is the result of a consumer in a batch creation.
+type ConsumerBatchCreateResult struct {
+	Name     string
+	Status   ConsumerBatchCreateStatus
+	Reason   string
+	Consumer *api.Consumer
 }
 
 func NewConsumerService(lockFactory db.LockFactory, consumerDao dao.ConsumerDao, resourceDao dao.ResourceDao, events EventService) ConsumerService {
 	return &sqlConsumerService{
+		lockFactory: lockFactory,
 		consumerDao: consumerDao,
 		resourceDao: resourceDao,
+		events:      events,
 	}
 }
 
 var _ ConsumerService = &sqlConsumerService{}
 
 type sqlConsumerService struct {
+	lockFactory db.LockFactory
 	consumerDao dao.ConsumerDao
 	resourceDao dao.ResourceDao
+	events      EventService
 }
 
 func (s *sqlConsumerService) Get(ctx context.Context, id string) (*api.Consumer, *errors.ServiceError) {
@@ -43,6 +102,12 @@ func (s *sqlConsumerService) Get(ctx context.Context, id string) (*api.Consumer,
 }
 
 func (s *sqlConsumerService) Create(ctx context.Context, consumer *api.Consumer) (*api.Consumer, *errors.ServiceError) {
+	if err := api.ValidateFeedbackRules(consumer.FeedbackRules); err != nil {
+		return nil, errors.Validation("the feedback rules of the consumer are invalid, %v", err)
+	}
+	if err := api.ValidateConsumerDeletePropagationPolicy(consumer.DeletePropagationPolicy); err != nil {
+		return nil, errors.Validation("the delete propagation policy of the consumer is invalid, %v", err)
+	}
 	if consumer.Name != "" {
 		if err := ValidateConsumer(consumer); err != nil {
 			return nil, handleCreateError("Consumer", err)
@@ -64,6 +129,106 @@ func (s *sqlConsumerService) Replace(ctx context.Context, consumer *api.Consumer
 	return consumer, nil
 }
 
+func (s *sqlConsumerService) PatchLabels(ctx context.Context, id string, patch ConsumerLabelsPatch) (*api.Consumer, *errors.ServiceError) {
+	if err := ValidateConsumerLabelsPatch(patch); err != nil {
+		return nil, errors.Validation("the labels of the consumer are invalid, %v", err)
+	}
+
+	// the labels are patched on the stored labels under the lock, so the concurrent patches are not lost
+	lockOwnerID, err := s.lockFactory.NewAdvisoryLock(ctx, id, db.Consumers)
+	// Ensure that the transaction related to this lock always end.
+	defer s.lockFactory.Unlock(ctx, lockOwnerID)
+	if err != nil {
+		return nil, errors.DatabaseAdvisoryLock(err)
+	}
+
+	found, err := s.consumerDao.Get(ctx, id)
+	if err != nil {
+		return nil, handleGetError("Consumer", "id", id, err)
+	}
+
+	labels := map[string]string{}
+	switch {
+	case patch.Replace != nil:
+		for key, value := range patch.Replace {
+			labels[key] = value
+		}
+	case found.Labels != nil:
+		for key, value := range *found.Labels {
+			labels[key] = value
+		}
+	}
+	for key, value := range patch.Add {
+		labels[key] = value
+	}
+	for _, key := range patch.Remove {
+		delete(labels, key)
+	}
+	found.Labels = db.EmptyMapToNilStringMap(&labels)
+
+	return s.replaceWithEvent(ctx, found)
+}
+
+func (s *sqlConsumerService) PatchFeedbackRules(ctx context.Context, id string, rules []workv1.FeedbackRule) (*api.Consumer, *errors.ServiceError) {
+	if err := api.ValidateFeedbackRules(rules); err != nil {
+		return nil, errors.Validation("the feedback rules of the consumer are invalid, %v", err)
+	}
+
+	lockOwnerID, err := s.lockFactory.NewAdvisoryLock(ctx, id, db.Consumers)
+	// Ensure that the transaction related to this lock always end.
+	defer s.lockFactory.Unlock(ctx, lockOwnerID)
+	if err != nil {
+		return nil, errors.DatabaseAdvisoryLock(err)
+	}
+
+	found, err := s.consumerDao.Get(ctx, id)
+	if err != nil {
+		return nil, handleGetError("Consumer", "id", id, err)
+	}
+	found.FeedbackRules = rules
+
+	return s.replaceWithEvent(ctx, found)
+}
+
+func (s *sqlConsumerService) PatchDeletePropagationPolicy(ctx context.Context, id string, policy string) (*api.Consumer, *errors.ServiceError) {
+	if err := api.ValidateConsumerDeletePropagationPolicy(policy); err != nil {
+		return nil, errors.Validation("the delete propagation policy of the consumer is invalid, %v", err)
+	}
+
+	lockOwnerID, err := s.lockFactory.NewAdvisoryLock(ctx, id, db.Consumers)
+	// Ensure that the transaction related to this lock always end.
+	defer s.lockFactory.Unlock(ctx, lockOwnerID)
+	if err != nil {
+		return nil, errors.DatabaseAdvisoryLock(err)
+	}
+
+	found, err := s.consumerDao.Get(ctx, id)
+	if err != nil {
+		return nil, handleGetError("Consumer", "id", id, err)
+	}
+	found.DeletePropagationPolicy = policy
+
+	return s.replaceWithEvent(ctx, found)
+}
+
+// replaceWithEvent replaces the consumer and records a consumer update event.
+func (s *sqlConsumerService) replaceWithEvent(ctx context.Context, consumer *api.Consumer) (*api.Consumer, *errors.ServiceError) {
+	updated, err := s.consumerDao.Replace(ctx, consumer)
+	if err != nil {
+		return nil, handleUpdateError("Consumer", err)
+	}
+
+	if _, eErr := s.events.Create(ctx, &api.Event{
+		Source:    "Consumers",
+		SourceID:  updated.ID,
+		EventType: api.UpdateEventType,
+	}); eErr != nil {
+		return nil, eErr
+	}
+
+	return updated, nil
+}
+
 // Delete will remove the consumer from the storage:
 // 1. Perform a hard delete on the consumer, the resource creation will be blocked after it.
 // 2. Forbid consumer deletion if there are associated resources(include the marked as deleted resources).
@@ -83,6 +248,111 @@ func (s *sqlConsumerService) FindByIDs(ctx context.Context, ids []string) (api.C
 	return consumers, nil
 }
 
+func (s *sqlConsumerService) FindByNames(ctx context.Context, names []string) (api.ConsumerList, *errors.ServiceError) {
+	consumers, err := s.consumerDao.FindByNames(ctx, names)
+	if err != nil {
+		return nil, errors.GeneralError("Unable to find consumers by names: %s", err)
+	}
+	return consumers, nil
+}
+
+func (s *sqlConsumerService) BatchCreate(ctx context.Context, consumers []*api.Consumer, skipExisting bool) ([]ConsumerBatchCreateResult, *errors.ServiceError) {
+	if len(consumers) == 0 {
+		return nil, errors.Validation("at least one consumer is required")
+	}
+
+	failures := []string{}
+	names := []string{}
+	seen := map[string]bool{}
+	for i, consumer := range consumers {
+		// the consumers without a name are reported by their index in the request
+		item := consumer.Name
+		if item == "" {
+			item = fmt.Sprintf("items[%d]", i)
+		}
+		if err := api.ValidateFeedbackRules(consumer.FeedbackRules); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %s", item, err))
+			continue
+		}
+		if err := api.ValidateConsumerDeletePropagationPolicy(consumer.DeletePropagationPolicy); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %s", item, err))
+			continue
+		}
+		if err := ValidateConsumerLabels(consumer); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %s", item, err))
+			continue
+		}
+		if consumer.Name == "" {
+			// the consumer id will be used as its name
+			continue
+		}
+
+		if err := ValidateConsumer(consumer); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %s", item, err))
+			continue
+		}
+
+		if seen[consumer.Name] {
+			failures = append(failures, fmt.Sprintf("%s: duplicated in the request", consumer.Name))
+			continue
+		}
+
+		seen[consumer.Name] = true
+		names = append(names, consumer.Name)
+	}
+
+	if len(failures) != 0 {
+		return nil, errors.Validation("invalid consumers: %s", strings.Join(failures, "; "))
+	}
+
+	existing := map[string]*api.Consumer{}
+	if len(names) != 0 {
+		found, err := s.consumerDao.FindByNames(ctx, names)
+		if err != nil {
+			return nil, errors.GeneralError("Unable to find consumers by names: %s", err)
+		}
+		for _, consumer := range found {
+			existing[consumer.Name] = consumer
+		}
+	}
+
+	if len(existing) != 0 && !skipExisting {
+		conflicts := []string{}
+		for _, name := range names {
+			if _, ok := existing[name]; ok {
+				conflicts = append(conflicts, name)
+			}
+		}
+		return nil, errors.Conflict("consumers already exist: %s", strings.Join(conflicts, ", "))
+	}
+
+	results := make([]ConsumerBatchCreateResult, 0, len(consumers))
+	for _, consumer := range consumers {
+		if found, ok := existing[consumer.Name]; ok {
+			results = append(results, ConsumerBatchCreateResult{
+				Name:     consumer.Name,
+				Status:   ConsumerBatchSkipped,
+				Reason:   "consumer already exists",
+				Consumer: found,
+			})
+			continue
+		}
+
+		// the consumers are created in the request transaction, a failure rolls back the whole batch
+		created, err := s.consumerDao.Create(ctx, consumer)
+		if err != nil {
+			return nil, handleCreateError("Consumer", err)
+		}
+		results = append(results, ConsumerBatchCreateResult{
+			Name:     created.Name,
+			Status:   ConsumerBatchCreated,
+			Consumer: created,
+		})
+	}
+
+	return results, nil
+}
+
 func (s *sqlConsumerService) All(ctx context.Context) (api.ConsumerList, *errors.ServiceError) {
 	consumers, err := s.consumerDao.All(ctx)
 	if err != nil {
@@ -90,3 +360,53 @@ func (s *sqlConsumerService) All(ctx context.Context) (api.ConsumerList, *errors
 	}
 	return consumers, nil
 }
+
+func (s *sqlConsumerService) Groups(ctx context.Context, args *ListArguments) (api.ConsumerGroupList, *api.PagingMeta, *errors.ServiceError) {
+	if args.Page < 1 {
+		return nil, nil, errors.BadRequest("invalid page %d, the page starts from 1", args.Page)
+	}
+	groups, total, err := s.consumerDao.Groups(ctx, args.Page, args.Size)
+	if err != nil {
+		return nil, nil, errors.GeneralError("Unable to get consumer groups: %s", err)
+	}
+	return groups, &api.PagingMeta{Page: args.Page, Size: int64(len(groups)), Total: total}, nil
+}
+
+func (s *sqlConsumerService) FindByGroup(ctx context.Context, group string) (api.ConsumerList, *errors.ServiceError) {
+	if err := ValidateConsumerGroup(group); err != nil {
+		return nil, errors.Validation("invalid consumer group: %s", err)
+	}
+
+	consumers, err := s.consumerDao.FindByGroup(ctx, group)
+	if err != nil {
+		return nil, errors.GeneralError("Unable to get consumers of group %s: %s", group, err)
+	}
+	return consumers, nil
+}
+
+func (s *sqlConsumerService) FindByLabelSelector(ctx context.Context, selector string) (api.ConsumerList, *errors.ServiceError) {
+	labelSelector, err := labels.Parse(selector)
+	if err != nil {
+		return nil, errors.Validation("invalid label selector: %s", err)
+	}
+
+	consumers, err := s.consumerDao.All(ctx)
+	if err != nil {
+		return nil, errors.GeneralError("Unable to get all consumers: %s", err)
+	}
+
+	matched := api.ConsumerList{}
+	for _, consumer := range consumers {
+		consumerLabels := labels.Set{}
+		if consumer.Labels != nil {
+			consumerLabels = labels.Set(*consumer.Labels)
+		}
+		if labelSelector.Matches(consumerLabels) {
+			matched = append(matched, consumer)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		return matched[i].Name < matched[j].Name
+	})
+	return matched, nil
+}
@@ -0,0 +1,264 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/datatypes"
+	workv1 "open-cluster-management.io/api/work/v1"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/db"
+	"github.com/openshift-online/maestro/pkg/errors"
+)
+
+func init() {
+	// Register the metrics for the consumer cache
+	RegisterConsumerCacheMetrics()
+}
+
+// ConsumerCache is a TTL-bounded in-memory cache of the consumers, it is safe for concurrent use and is shared by the
+// consumer services of the instance. The entries are invalidated once the consumers are created, replaced or deleted
+// through the cached consumer service, a change made by another instance is visible after at most the TTL.
+type ConsumerCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]consumerCacheEntry
+	// generation is increased on each invalidation, a consumer loaded before an invalidation is not cached, so a
+	// lookup racing with an update never caches the outdated consumer.
+	generation uint64
+}
+
+type consumerCacheEntry struct {
+	consumer  *api.Consumer
+	expiresAt time.Time
+}
+
+func NewConsumerCache(ttl time.Duration) *ConsumerCache {
+	return &ConsumerCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: map[string]consumerCacheEntry{},
+	}
+}
+
+// get returns a copy of the cached consumer if it is not expired.
+func (c *ConsumerCache) get(id string) (*api.Consumer, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[id]
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(entry.expiresAt) {
+		delete(c.entries, id)
+		return nil, false
+	}
+	return copyConsumer(entry.consumer), true
+}
+
+// currentGeneration returns the generation before a consumer is loaded.
+func (c *ConsumerCache) currentGeneration() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.generation
+}
+
+// add caches a copy of the consumer loaded in the given generation, the consumer is dropped if the cache is
+// invalidated after it is loaded.
+func (c *ConsumerCache) add(consumer *api.Consumer, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+	c.entries[consumer.ID] = consumerCacheEntry{consumer: copyConsumer(consumer), expiresAt: c.now().Add(c.ttl)}
+}
+
+// invalidate removes the consumers from the cache.
+func (c *ConsumerCache) invalidate(ids ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	for _, id := range ids {
+		delete(c.entries, id)
+	}
+}
+
+// copyConsumer returns a copy of the consumer, so the callers can modify the consumer without changing the cache.
+func copyConsumer(consumer *api.Consumer) *api.Consumer {
+	copied := *consumer
+	if consumer.Labels != nil {
+		labels := make(db.StringMap, len(*consumer.Labels))
+		for k, v := range *consumer.Labels {
+			labels[k] = v
+		}
+		copied.Labels = &labels
+	}
+	if consumer.FeedbackRules != nil {
+		copied.FeedbackRules = append(datatypes.JSONSlice[workv1.FeedbackRule]{}, consumer.FeedbackRules...)
+	}
+	return &copied
+}
+
+// NewCachedConsumerService returns a consumer service that looks up the consumers through the given cache, and
+// invalidates the cache once the consumers are changed by the service.
+func NewCachedConsumerService(service ConsumerService, cache *ConsumerCache) ConsumerService {
+	return &cachedConsumerService{ConsumerService: service, cache: cache}
+}
+
+var _ ConsumerService = &cachedConsumerService{}
+
+type cachedConsumerService struct {
+	ConsumerService
+	cache *ConsumerCache
+}
+
+func (s *cachedConsumerService) Get(ctx context.Context, id string) (*api.Consumer, *errors.ServiceError) {
+	if consumer, ok := s.cache.get(id); ok {
+		consumerCacheLookupsCountMetric.WithLabelValues(consumerCacheHit).Inc()
+		return consumer, nil
+	}
+	consumerCacheLookupsCountMetric.WithLabelValues(consumerCacheMiss).Inc()
+
+	generation := s.cache.currentGeneration()
+	consumer, err := s.ConsumerService.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	s.cache.add(consumer, generation)
+	return consumer, nil
+}
+
+func (s *cachedConsumerService) FindByIDs(ctx context.Context, ids []string) (api.ConsumerList, *errors.ServiceError) {
+	consumers := api.ConsumerList{}
+	missingIDs := []string{}
+	for _, id := range ids {
+		if consumer, ok := s.cache.get(id); ok {
+			consumerCacheLookupsCountMetric.WithLabelValues(consumerCacheHit).Inc()
+			consumers = append(consumers, consumer)
+			continue
+		}
+		consumerCacheLookupsCountMetric.WithLabelValues(consumerCacheMiss).Inc()
+		missingIDs = append(missingIDs, id)
+	}
+	if len(missingIDs) == 0 {
+		return consumers, nil
+	}
+
+	generation := s.cache.currentGeneration()
+	found, err := s.ConsumerService.FindByIDs(ctx, missingIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, consumer := range found {
+		s.cache.add(consumer, generation)
+	}
+	return append(consumers, found...), nil
+}
+
+func (s *cachedConsumerService) Create(ctx context.Context, consumer *api.Consumer) (*api.Consumer, *errors.ServiceError) {
+	created, err := s.ConsumerService.Create(ctx, consumer)
+	if err != nil {
+		return nil, err
+	}
+	s.cache.invalidate(created.ID)
+	return created, nil
+}
+
+func (s *cachedConsumerService) Replace(ctx context.Context, consumer *api.Consumer) (*api.Consumer, *errors.ServiceError) {
+	// the consumer is invalidated even if the replace fails, since the stored consumer is unknown in that case
+	defer s.cache.invalidate(consumer.ID)
+	return s.ConsumerService.Replace(ctx, consumer)
+}
+
+func (s *cachedConsumerService) PatchLabels(ctx context.Context, id string, patch ConsumerLabelsPatch) (*api.Consumer, *errors.ServiceError) {
+	defer s.cache.invalidate(id)
+	return s.ConsumerService.PatchLabels(ctx, id, patch)
+}
+
+func (s *cachedConsumerService) PatchFeedbackRules(ctx context.Context, id string, rules []workv1.FeedbackRule) (*api.Consumer, *errors.ServiceError) {
+	defer s.cache.invalidate(id)
+	return s.ConsumerService.PatchFeedbackRules(ctx, id, rules)
+}
+
+func (s *cachedConsumerService) PatchDeletePropagationPolicy(ctx context.Context, id string, policy string) (*api.Consumer, *errors.ServiceError) {
+	defer s.cache.invalidate(id)
+	return s.ConsumerService.PatchDeletePropagationPolicy(ctx, id, policy)
+}
+
+func (s *cachedConsumerService) Delete(ctx context.Context, id string) *errors.ServiceError {
+	defer s.cache.invalidate(id)
+	return s.ConsumerService.Delete(ctx, id)
+}
+
+func (s *cachedConsumerService) BatchCreate(ctx context.Context, consumers []*api.Consumer, skipExisting bool) ([]ConsumerBatchCreateResult, *errors.ServiceError) {
+	results, err := s.ConsumerService.BatchCreate(ctx, consumers, skipExisting)
+	if err != nil {
+		return nil, err
+	}
+	ids := []string{}
+	for _, result := range results {
+		if result.Status == ConsumerBatchCreated {
+			ids = append(ids, result.Consumer.ID)
+		}
+	}
+	s.cache.invalidate(ids...)
+	return results, nil
+}
+
+// The consumer cache metrics are exposed with the maestro namespace, e.g. maestro_consumer_cache_lookups_total.
+const consumerCacheMetricsNamespace = "maestro"
+
+// Subsystem used to define the consumer cache metrics:
+const consumerCacheMetricsSubsystem = "consumer_cache"
+
+// Names of the labels added to the consumer cache metrics:
+const (
+	metricsResultLabel = "result"
+)
+
+// Values of the result label of the consumer cache metrics:
+const (
+	consumerCacheHit  = "hit"
+	consumerCacheMiss = "miss"
+)
+
+// Names of the consumer cache metrics:
+const (
+	lookupsCountMetric = "lookups_total"
+)
+
+// Register the metrics:
+func RegisterConsumerCacheMetrics() {
+	prometheus.MustRegister(consumerCacheLookupsCountMetric)
+}
+
+// Unregister the metrics:
+func UnregisterConsumerCacheMetrics() {
+	prometheus.Unregister(consumerCacheLookupsCountMetric)
+}
+
+// Reset the metrics:
+func ResetConsumerCacheMetrics() {
+	consumerCacheLookupsCountMetric.Reset()
+}
+
+// Description of the consumer cache lookups count metric:
+var consumerCacheLookupsCountMetric = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: consumerCacheMetricsNamespace,
+		Subsystem: consumerCacheMetricsSubsystem,
+		Name:      lookupsCountMetric,
+		Help:      "Number of consumer lookups through the consumer cache by the result, either hit or miss.",
+	},
+	[]string{metricsResultLabel},
+)
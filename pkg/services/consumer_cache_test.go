@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	gm "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/dao/mocks"
+	"github.com/openshift-online/maestro/pkg/db"
+)
+
+func TestCachedConsumerService(t *testing.T) {
+	gm.RegisterTestingT(t)
+	ResetConsumerCacheMetrics()
+
+	ctx := context.Background()
+	consumerDao := mocks.NewConsumerDao()
+	_, err := consumerDao.Create(ctx, &api.Consumer{Meta: api.Meta{ID: Fukuisaurus}, Name: "cluster1", Labels: &db.StringMap{"env": "dev"}})
+	gm.Expect(err).To(gm.BeNil())
+
+	now := time.Now()
+	cache := NewConsumerCache(time.Minute)
+	cache.now = func() time.Time { return now }
+	consumerService := NewCachedConsumerService(NewConsumerService(nil, consumerDao, nil, nil), cache)
+
+	// the first lookup reads through the dao, the second lookup is served from the cache
+	consumer, svcErr := consumerService.Get(ctx, Fukuisaurus)
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(consumer.Name).To(gm.Equal("cluster1"))
+	consumer, svcErr = consumerService.Get(ctx, Fukuisaurus)
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(testutil.ToFloat64(consumerCacheLookupsCountMetric.WithLabelValues(consumerCacheMiss))).To(gm.Equal(float64(1)))
+	gm.Expect(testutil.ToFloat64(consumerCacheLookupsCountMetric.WithLabelValues(consumerCacheHit))).To(gm.Equal(float64(1)))
+
+	// the cached consumer is not changed by the callers
+	(*consumer.Labels)["env"] = "prod"
+	cached, _ := consumerService.Get(ctx, Fukuisaurus)
+	gm.Expect((*cached.Labels)["env"]).To(gm.Equal("dev"))
+
+	// the consumer is invalidated once it is replaced
+	_, svcErr = consumerService.Replace(ctx, consumer)
+	gm.Expect(svcErr).To(gm.BeNil())
+	replaced, _ := consumerService.Get(ctx, Fukuisaurus)
+	gm.Expect((*replaced.Labels)["env"]).To(gm.Equal("prod"))
+
+	// the consumer changed bypassing the cache is visible once the cache entry is expired
+	_, err = consumerDao.Replace(ctx, &api.Consumer{Meta: api.Meta{ID: Fukuisaurus}, Name: "cluster1", Labels: &db.StringMap{"env": "test"}})
+	gm.Expect(err).To(gm.BeNil())
+	stale, _ := consumerService.Get(ctx, Fukuisaurus)
+	gm.Expect((*stale.Labels)["env"]).To(gm.Equal("prod"))
+	now = now.Add(time.Minute)
+	fresh, _ := consumerService.Get(ctx, Fukuisaurus)
+	gm.Expect((*fresh.Labels)["env"]).To(gm.Equal("test"))
+
+	// the consumers are looked up in a batch
+	created, svcErr := consumerService.Create(ctx, &api.Consumer{Meta: api.Meta{ID: Seismosaurus}, Name: "cluster2"})
+	gm.Expect(svcErr).To(gm.BeNil())
+	consumers, svcErr := consumerService.FindByIDs(ctx, []string{Fukuisaurus, created.ID})
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(consumers).To(gm.HaveLen(2))
+
+	// the consumer is invalidated once it is deleted
+	gm.Expect(consumerService.Delete(ctx, Fukuisaurus)).To(gm.BeNil())
+	_, svcErr = consumerService.Get(ctx, Fukuisaurus)
+	gm.Expect(svcErr).NotTo(gm.BeNil())
+	gm.Expect(svcErr.Is404()).To(gm.BeTrue())
+}
+
+func TestConsumerCacheInvalidatedWhileLoading(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	cache := NewConsumerCache(time.Minute)
+	consumer := &api.Consumer{Meta: api.Meta{ID: Fukuisaurus}, Name: "cluster1"}
+
+	// the consumer loaded before an invalidation is not cached
+	generation := cache.currentGeneration()
+	cache.invalidate(Fukuisaurus)
+	cache.add(consumer, generation)
+	_, ok := cache.get(Fukuisaurus)
+	gm.Expect(ok).To(gm.BeFalse())
+
+	cache.add(consumer, cache.currentGeneration())
+	_, ok = cache.get(Fukuisaurus)
+	gm.Expect(ok).To(gm.BeTrue())
+}
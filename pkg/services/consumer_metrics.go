@@ -0,0 +1,321 @@
+package services
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/openshift-online/maestro/pkg/api"
+)
+
+func init() {
+	// Register the metrics for the consumers
+	RegisterConsumerMetrics()
+}
+
+// ConsumerMetricsMode is the mode of the consumer-scoped metrics, the series of the consumer-scoped metrics grow with
+// the number of the consumers (or the consumer groups), so they are disabled by default.
+type ConsumerMetricsMode string
+
+const (
+	// ConsumerMetricsModeNone disables the consumer-scoped metrics.
+	ConsumerMetricsModeNone ConsumerMetricsMode = "none"
+	// ConsumerMetricsModeConsumer exposes the consumer-scoped metrics with a consumer label, there are series for
+	// each consumer, so it is only suitable for the deployments with a few consumers.
+	ConsumerMetricsModeConsumer ConsumerMetricsMode = "consumer"
+	// ConsumerMetricsModeGroup exposes the consumer-scoped metrics aggregated by the consumer groups with a group
+	// label, the cardinality is bounded by the number of the groups, see api.ConsumerGroupLabelPrefix.
+	ConsumerMetricsModeGroup ConsumerMetricsMode = "group"
+)
+
+// ParseConsumerMetricsMode parses the mode of the consumer-scoped metrics.
+func ParseConsumerMetricsMode(mode string) (ConsumerMetricsMode, error) {
+	switch m := ConsumerMetricsMode(mode); m {
+	case ConsumerMetricsModeNone, ConsumerMetricsModeConsumer, ConsumerMetricsModeGroup:
+		return m, nil
+	default:
+		return "", fmt.Errorf("unsupported consumer metrics mode %q, must be one of %s, %s or %s",
+			mode, ConsumerMetricsModeNone, ConsumerMetricsModeConsumer, ConsumerMetricsModeGroup)
+	}
+}
+
+// consumerMetrics is the mode of the consumer-scoped metrics and the groups of the consumers, the groups are
+// refreshed with the resource counts, so the metrics are aggregated without looking up the consumers.
+var consumerMetrics = &consumerMetricsState{mode: ConsumerMetricsModeNone, groups: map[string][]string{},
+	series: map[*prometheus.GaugeVec]map[string]bool{}}
+
+type consumerMetricsState struct {
+	mu     sync.RWMutex
+	mode   ConsumerMetricsMode
+	groups map[string][]string
+
+	// series are the label values of the series last set on each gauge, see syncGauge.
+	seriesMu sync.Mutex
+	series   map[*prometheus.GaugeVec]map[string]bool
+}
+
+// syncGauge sets the series of the gauge to the given counts by label value in place, and deletes the series whose
+// label values are no longer counted, e.g. the deleted consumers and groups. Unlike a reset of the gauge, the series
+// still counted are never missing from a scrape during the refresh.
+func (s *consumerMetricsState) syncGauge(gauge *prometheus.GaugeVec, counts map[string]int) {
+	s.seriesMu.Lock()
+	defer s.seriesMu.Unlock()
+
+	series := map[string]bool{}
+	for value, count := range counts {
+		gauge.WithLabelValues(value).Set(float64(count))
+		series[value] = true
+	}
+	for value := range s.series[gauge] {
+		if !series[value] {
+			gauge.DeleteLabelValues(value)
+		}
+	}
+	s.series[gauge] = series
+}
+
+// labelValues returns the consumer label values of the metrics of the given consumer, it is the consumer name in
+// the consumer mode and the groups of the consumer in the group mode, the consumers without a group are counted with
+// an empty group. No value is returned if the metrics are disabled.
+func (s *consumerMetricsState) labelValues(consumerName string) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	switch s.mode {
+	case ConsumerMetricsModeConsumer:
+		return []string{consumerName}
+	case ConsumerMetricsModeGroup:
+		if groups := s.groups[consumerName]; len(groups) != 0 {
+			return groups
+		}
+		return []string{""}
+	default:
+		return nil
+	}
+}
+
+// SetConsumerMetricsMode sets the mode of the consumer-scoped metrics.
+func SetConsumerMetricsMode(mode ConsumerMetricsMode) {
+	consumerMetrics.mu.Lock()
+	defer consumerMetrics.mu.Unlock()
+
+	consumerMetrics.mode = mode
+}
+
+// SyncConsumerMetrics refreshes the resource counts of the consumer-scoped metrics with the given resource counts by
+// consumer name, and the groups of the given consumers.
+func SyncConsumerMetrics(resourceCounts map[string]int, consumers api.ConsumerList) {
+	groups := map[string][]string{}
+	for _, consumer := range consumers {
+		groups[consumer.Name] = consumer.Groups()
+	}
+
+	consumerMetrics.mu.Lock()
+	consumerMetrics.groups = groups
+	mode := consumerMetrics.mode
+	consumerMetrics.mu.Unlock()
+
+	// the series of the deleted consumers and groups are removed, as well as the series of the other mode
+	consumerCounts, groupCounts := map[string]int{}, map[string]int{}
+	switch mode {
+	case ConsumerMetricsModeConsumer:
+		consumerCounts = resourceCounts
+	case ConsumerMetricsModeGroup:
+		for _, consumer := range consumers {
+			for _, group := range consumerMetrics.labelValues(consumer.Name) {
+				groupCounts[group] += resourceCounts[consumer.Name]
+			}
+		}
+	}
+	consumerMetrics.syncGauge(consumerResourcesMetric, consumerCounts)
+	consumerMetrics.syncGauge(consumerGroupResourcesMetric, groupCounts)
+}
+
+// SyncConsumerPendingDeletionMetrics refreshes the consumer-scoped metrics of the resources awaiting the deletion
+// confirmation with the given counts by consumer name, the consumers are aggregated by the groups refreshed by
+// SyncConsumerMetrics.
+func SyncConsumerPendingDeletionMetrics(pendingCounts map[string]int) {
+	// the series of the consumers without pending deletions are removed
+	consumerCounts, groupCounts := map[string]int{}, map[string]int{}
+	switch consumerMetricsMode() {
+	case ConsumerMetricsModeConsumer:
+		consumerCounts = pendingCounts
+	case ConsumerMetricsModeGroup:
+		for consumerName, count := range pendingCounts {
+			for _, group := range consumerMetrics.labelValues(consumerName) {
+				groupCounts[group] += count
+			}
+		}
+	}
+	consumerMetrics.syncGauge(consumerPendingDeletionMetric, consumerCounts)
+	consumerMetrics.syncGauge(consumerGroupPendingDeletionMetric, groupCounts)
+}
+
+// observeConsumerReconcile records the time taken by the agent of the consumer to apply a resource with the given
+// update strategy since the spec change of the resource.
+func observeConsumerReconcile(consumerName, updateStrategy string, duration time.Duration) {
+	metric := consumerReconcileDurationMetric
+	if consumerMetricsMode() == ConsumerMetricsModeGroup {
+		metric = consumerGroupReconcileDurationMetric
+	}
+	for _, value := range consumerMetrics.labelValues(consumerName) {
+		metric.WithLabelValues(value, updateStrategy).Observe(duration.Seconds())
+	}
+}
+
+// countConsumerChurn counts the resource changes of the consumer groups, the changes of each consumer are already
+// counted by the resource churn metrics.
+func countConsumerChurn(consumerName, action string) {
+	if consumerMetricsMode() != ConsumerMetricsModeGroup {
+		return
+	}
+	for _, group := range consumerMetrics.labelValues(consumerName) {
+		consumerGroupChurnCountMetric.WithLabelValues(group, action).Inc()
+	}
+}
+
+func consumerMetricsMode() ConsumerMetricsMode {
+	consumerMetrics.mu.RLock()
+	defer consumerMetrics.mu.RUnlock()
+
+	return consumerMetrics.mode
+}
+
+// The consumer-scoped metrics are exposed with the maestro namespace, e.g. maestro_consumer_resources.
+const consumerMetricsNamespace = "maestro"
+
+// Names of the labels added to the consumer-scoped metrics:
+const (
+	metricsGroupLabel = "group"
+)
+
+// Subsystem used to define the consumer-scoped metrics:
+const consumerMetricsSubsystem = "consumer"
+
+// Names of the consumer-scoped metrics:
+const (
+	resourcesMetric              = "resources"
+	reconcileDurationMetric      = "reconcile_duration_seconds"
+	pendingDeletionsMetric       = "pending_deletion_resources"
+	groupResourcesMetric         = "group_resources"
+	groupReconcileDurationMetric = "group_reconcile_duration_seconds"
+	groupChurnCountMetric        = "group_resource_changes_total"
+	groupPendingDeletionsMetric  = "group_pending_deletion_resources"
+)
+
+// consumerReconcileDurationBuckets are the buckets of the reconcile duration metrics in seconds.
+var consumerReconcileDurationBuckets = []float64{0.5, 1, 2, 5, 10, 30, 60, 120, 300, 600}
+
+// Register the metrics:
+func RegisterConsumerMetrics() {
+	prometheus.MustRegister(consumerResourcesMetric)
+	prometheus.MustRegister(consumerReconcileDurationMetric)
+	prometheus.MustRegister(consumerGroupResourcesMetric)
+	prometheus.MustRegister(consumerGroupReconcileDurationMetric)
+	prometheus.MustRegister(consumerGroupChurnCountMetric)
+	prometheus.MustRegister(consumerPendingDeletionMetric)
+	prometheus.MustRegister(consumerGroupPendingDeletionMetric)
+}
+
+// Unregister the metrics:
+func UnregisterConsumerMetrics() {
+	prometheus.Unregister(consumerResourcesMetric)
+	prometheus.Unregister(consumerReconcileDurationMetric)
+	prometheus.Unregister(consumerGroupResourcesMetric)
+	prometheus.Unregister(consumerGroupReconcileDurationMetric)
+	prometheus.Unregister(consumerGroupChurnCountMetric)
+	prometheus.Unregister(consumerPendingDeletionMetric)
+	prometheus.Unregister(consumerGroupPendingDeletionMetric)
+}
+
+// Reset the metrics:
+func ResetConsumerMetrics() {
+	consumerResourcesMetric.Reset()
+	consumerReconcileDurationMetric.Reset()
+	consumerGroupResourcesMetric.Reset()
+	consumerGroupReconcileDurationMetric.Reset()
+	consumerGroupChurnCountMetric.Reset()
+	consumerPendingDeletionMetric.Reset()
+	consumerGroupPendingDeletionMetric.Reset()
+}
+
+// Description of the consumer resources metric:
+var consumerResourcesMetric = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: consumerMetricsNamespace,
+		Subsystem: consumerMetricsSubsystem,
+		Name:      resourcesMetric,
+		Help:      "Number of resources (that are not being deleted) of each consumer.",
+	},
+	[]string{metricsConsumerLabel},
+)
+
+// Description of the consumer reconcile duration metric:
+var consumerReconcileDurationMetric = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Namespace: consumerMetricsNamespace,
+		Subsystem: consumerMetricsSubsystem,
+		Name:      reconcileDurationMetric,
+		Help:      "Time in seconds from the last spec change of a resource to the status reporting the resource version is applied by the agent of each consumer.",
+		Buckets:   consumerReconcileDurationBuckets,
+	},
+	[]string{metricsConsumerLabel, metricsUpdateStrategyLabel},
+)
+
+// Description of the consumer group resources metric:
+var consumerGroupResourcesMetric = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: consumerMetricsNamespace,
+		Subsystem: consumerMetricsSubsystem,
+		Name:      groupResourcesMetric,
+		Help:      "Number of resources (that are not being deleted) of the consumers in each consumer group.",
+	},
+	[]string{metricsGroupLabel},
+)
+
+// Description of the consumer group reconcile duration metric:
+var consumerGroupReconcileDurationMetric = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Namespace: consumerMetricsNamespace,
+		Subsystem: consumerMetricsSubsystem,
+		Name:      groupReconcileDurationMetric,
+		Help:      "Time in seconds from the last spec change of a resource to the status reporting the resource version is applied by the agents of the consumers in each consumer group.",
+		Buckets:   consumerReconcileDurationBuckets,
+	},
+	[]string{metricsGroupLabel, metricsUpdateStrategyLabel},
+)
+
+// Description of the consumer group churn count metric:
+var consumerGroupChurnCountMetric = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: consumerMetricsNamespace,
+		Subsystem: consumerMetricsSubsystem,
+		Name:      groupChurnCountMetric,
+		Help:      "Number of created, updated and deleted resources of the consumers in each consumer group.",
+	},
+	[]string{metricsGroupLabel, metricsActionLabel},
+)
+
+// Description of the consumer pending deletion metric:
+var consumerPendingDeletionMetric = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: consumerMetricsNamespace,
+		Subsystem: consumerMetricsSubsystem,
+		Name:      pendingDeletionsMetric,
+		Help:      "Number of resources marked as deleting and awaiting the deletion confirmation from the agent of each consumer.",
+	},
+	[]string{metricsConsumerLabel},
+)
+
+// Description of the consumer group pending deletion metric:
+var consumerGroupPendingDeletionMetric = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: consumerMetricsNamespace,
+		Subsystem: consumerMetricsSubsystem,
+		Name:      groupPendingDeletionsMetric,
+		Help:      "Number of resources marked as deleting and awaiting the deletion confirmation from the agents of the consumers in each consumer group.",
+	},
+	[]string{metricsGroupLabel},
+)
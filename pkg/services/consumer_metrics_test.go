@@ -0,0 +1,111 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	gm "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/db"
+)
+
+func TestConsumerMetrics(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	ResetConsumerMetrics()
+	defer ResetConsumerMetrics()
+	defer SetConsumerMetricsMode(ConsumerMetricsModeNone)
+
+	consumers := api.ConsumerList{
+		{Name: "cluster1", Labels: &db.StringMap{api.ConsumerGroupLabel("us-east"): "true"}},
+		{Name: "cluster2", Labels: &db.StringMap{api.ConsumerGroupLabel("us-east"): "true", api.ConsumerGroupLabel("canary"): "true"}},
+		{Name: "cluster3"},
+	}
+	counts := map[string]int{"cluster1": 1, "cluster2": 2, "cluster3": 3}
+
+	// no series if the consumer metrics are disabled
+	SyncConsumerMetrics(counts, consumers)
+	observeConsumerReconcile("cluster1", api.UpdateStrategyMixed, time.Second)
+	countConsumerChurn("cluster1", "create")
+	gm.Expect(testutil.CollectAndCount(consumerResourcesMetric)).To(gm.Equal(0))
+	gm.Expect(testutil.CollectAndCount(consumerReconcileDurationMetric)).To(gm.Equal(0))
+	gm.Expect(testutil.CollectAndCount(consumerGroupChurnCountMetric)).To(gm.Equal(0))
+
+	SetConsumerMetricsMode(ConsumerMetricsModeConsumer)
+	SyncConsumerMetrics(counts, consumers)
+	observeConsumerReconcile("cluster1", api.UpdateStrategyMixed, time.Second)
+	countConsumerChurn("cluster1", "create")
+	gm.Expect(testutil.CollectAndCount(consumerResourcesMetric)).To(gm.Equal(3))
+	gm.Expect(testutil.ToFloat64(consumerResourcesMetric.WithLabelValues("cluster2"))).To(gm.Equal(2.0))
+	gm.Expect(testutil.CollectAndCount(consumerReconcileDurationMetric)).To(gm.Equal(1))
+	gm.Expect(testutil.CollectAndCount(consumerGroupResourcesMetric)).To(gm.Equal(0))
+	gm.Expect(testutil.CollectAndCount(consumerGroupChurnCountMetric)).To(gm.Equal(0))
+
+	// the series are aggregated by the consumer groups, a consumer is counted in each of its groups
+	ResetConsumerMetrics()
+	SetConsumerMetricsMode(ConsumerMetricsModeGroup)
+	SyncConsumerMetrics(counts, consumers)
+	observeConsumerReconcile("cluster2", api.UpdateStrategyMixed, time.Second)
+	countConsumerChurn("cluster2", "update")
+	countConsumerChurn("cluster3", "update")
+	gm.Expect(testutil.CollectAndCount(consumerResourcesMetric)).To(gm.Equal(0))
+	gm.Expect(testutil.ToFloat64(consumerGroupResourcesMetric.WithLabelValues("us-east"))).To(gm.Equal(3.0))
+	gm.Expect(testutil.ToFloat64(consumerGroupResourcesMetric.WithLabelValues("canary"))).To(gm.Equal(2.0))
+	gm.Expect(testutil.ToFloat64(consumerGroupResourcesMetric.WithLabelValues(""))).To(gm.Equal(3.0))
+	gm.Expect(testutil.CollectAndCount(consumerGroupReconcileDurationMetric)).To(gm.Equal(2))
+	gm.Expect(testutil.ToFloat64(consumerGroupChurnCountMetric.WithLabelValues("canary", "update"))).To(gm.Equal(1.0))
+	gm.Expect(testutil.ToFloat64(consumerGroupChurnCountMetric.WithLabelValues("", "update"))).To(gm.Equal(1.0))
+}
+
+func TestConsumerPendingDeletionMetrics(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	ResetConsumerMetrics()
+	defer ResetConsumerMetrics()
+	defer SetConsumerMetricsMode(ConsumerMetricsModeNone)
+
+	consumers := api.ConsumerList{
+		{Name: "cluster1", Labels: &db.StringMap{api.ConsumerGroupLabel("us-east"): "true"}},
+		{Name: "cluster2", Labels: &db.StringMap{api.ConsumerGroupLabel("us-east"): "true", api.ConsumerGroupLabel("canary"): "true"}},
+		{Name: "cluster3"},
+	}
+	pendingCounts := map[string]int{"cluster1": 1, "cluster2": 2, "cluster3": 3}
+
+	// no series if the consumer metrics are disabled
+	SyncConsumerPendingDeletionMetrics(pendingCounts)
+	gm.Expect(testutil.CollectAndCount(consumerPendingDeletionMetric)).To(gm.Equal(0))
+	gm.Expect(testutil.CollectAndCount(consumerGroupPendingDeletionMetric)).To(gm.Equal(0))
+
+	SetConsumerMetricsMode(ConsumerMetricsModeConsumer)
+	SyncConsumerPendingDeletionMetrics(pendingCounts)
+	gm.Expect(testutil.CollectAndCount(consumerPendingDeletionMetric)).To(gm.Equal(3))
+	gm.Expect(testutil.ToFloat64(consumerPendingDeletionMetric.WithLabelValues("cluster2"))).To(gm.Equal(2.0))
+	gm.Expect(testutil.CollectAndCount(consumerGroupPendingDeletionMetric)).To(gm.Equal(0))
+
+	// the consumers without pending deletions are removed
+	SyncConsumerPendingDeletionMetrics(map[string]int{"cluster1": 1})
+	gm.Expect(testutil.CollectAndCount(consumerPendingDeletionMetric)).To(gm.Equal(1))
+
+	// the pending deletions are aggregated by the groups of the consumers
+	SetConsumerMetricsMode(ConsumerMetricsModeGroup)
+	SyncConsumerMetrics(map[string]int{}, consumers)
+	SyncConsumerPendingDeletionMetrics(pendingCounts)
+	gm.Expect(testutil.CollectAndCount(consumerPendingDeletionMetric)).To(gm.Equal(0))
+	gm.Expect(testutil.ToFloat64(consumerGroupPendingDeletionMetric.WithLabelValues("us-east"))).To(gm.Equal(3.0))
+	gm.Expect(testutil.ToFloat64(consumerGroupPendingDeletionMetric.WithLabelValues("canary"))).To(gm.Equal(2.0))
+	gm.Expect(testutil.ToFloat64(consumerGroupPendingDeletionMetric.WithLabelValues(""))).To(gm.Equal(3.0))
+}
+
+func TestParseConsumerMetricsMode(t *testing.T) {
+	for _, mode := range []string{"none", "consumer", "group"} {
+		if _, err := ParseConsumerMetricsMode(mode); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+
+	if _, err := ParseConsumerMetricsMode("unknown"); err == nil {
+		t.Errorf("expected error for unknown mode")
+	}
+}
@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	gm "github.com/onsi/gomega"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/dao/mocks"
+	"github.com/openshift-online/maestro/pkg/db"
+	dbmocks "github.com/openshift-online/maestro/pkg/db/mocks"
+	"github.com/openshift-online/maestro/pkg/errors"
+)
+
+func TestConsumerGroups(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	ctx := context.Background()
+	consumerDao := mocks.NewConsumerDao()
+	consumerService := NewConsumerService(dbmocks.NewMockAdvisoryLockFactory(), consumerDao, mocks.NewResourceDao(), nil)
+
+	consumers := []*api.Consumer{
+		{Name: "cluster1", Labels: &db.StringMap{api.ConsumerGroupLabel("us-east"): "true", "env": "prod"}},
+		{Name: "cluster2", Labels: &db.StringMap{api.ConsumerGroupLabel("us-east"): "true", api.ConsumerGroupLabel("canary"): "true"}},
+		{Name: "cluster3", Labels: &db.StringMap{"env": "prod"}},
+		{Name: "cluster4"},
+	}
+	for _, consumer := range consumers {
+		_, err := consumerDao.Create(ctx, consumer)
+		gm.Expect(err).To(gm.BeNil())
+	}
+	gm.Expect(consumers[1].Groups()).To(gm.Equal([]string{"canary", "us-east"}))
+	gm.Expect(consumers[3].Groups()).To(gm.BeEmpty())
+
+	groups, paging, svcErr := consumerService.Groups(ctx, &ListArguments{Page: 1, Size: 100})
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(groups).To(gm.Equal(api.ConsumerGroupList{
+		{Name: "canary", ConsumerCount: 1},
+		{Name: "us-east", ConsumerCount: 2},
+	}))
+	gm.Expect(*paging).To(gm.Equal(api.PagingMeta{Page: 1, Size: 2, Total: 2}))
+
+	// the groups are listed by page
+	groups, paging, svcErr = consumerService.Groups(ctx, &ListArguments{Page: 2, Size: 1})
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(groups).To(gm.Equal(api.ConsumerGroupList{{Name: "us-east", ConsumerCount: 2}}))
+	gm.Expect(*paging).To(gm.Equal(api.PagingMeta{Page: 2, Size: 1, Total: 2}))
+	groups, paging, svcErr = consumerService.Groups(ctx, &ListArguments{Page: 3, Size: 1})
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(groups).To(gm.BeEmpty())
+	gm.Expect(paging.Total).To(gm.Equal(int64(2)))
+	_, _, svcErr = consumerService.Groups(ctx, &ListArguments{Page: 0, Size: 1})
+	gm.Expect(svcErr).NotTo(gm.BeNil())
+
+	members, svcErr := consumerService.FindByGroup(ctx, "us-east")
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(len(members)).To(gm.Equal(2))
+	gm.Expect(members[0].Name).To(gm.Equal("cluster1"))
+	gm.Expect(members[1].Name).To(gm.Equal("cluster2"))
+
+	members, svcErr = consumerService.FindByGroup(ctx, "eu-west")
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(members).To(gm.BeEmpty())
+
+	// the group name must be a valid label name
+	_, svcErr = consumerService.FindByGroup(ctx, "us east")
+	gm.Expect(svcErr).NotTo(gm.BeNil())
+	_, svcErr = consumerService.FindByGroup(ctx, "")
+	gm.Expect(svcErr).NotTo(gm.BeNil())
+}
+
+func TestFindByLabelSelector(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	ctx := context.Background()
+	consumerDao := mocks.NewConsumerDao()
+	consumerService := NewConsumerService(dbmocks.NewMockAdvisoryLockFactory(), consumerDao, mocks.NewResourceDao(), nil)
+
+	consumers := []*api.Consumer{
+		{Name: "cluster2", Labels: &db.StringMap{"env": "prod", "tier": "gold"}},
+		{Name: "cluster1", Labels: &db.StringMap{"env": "prod"}},
+		{Name: "cluster3", Labels: &db.StringMap{"env": "dev"}},
+		{Name: "cluster4"},
+	}
+	for _, consumer := range consumers {
+		_, err := consumerDao.Create(ctx, consumer)
+		gm.Expect(err).To(gm.BeNil())
+	}
+
+	// the consumers are ordered by name
+	matched, svcErr := consumerService.FindByLabelSelector(ctx, "env=prod")
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(len(matched)).To(gm.Equal(2))
+	gm.Expect(matched[0].Name).To(gm.Equal("cluster1"))
+	gm.Expect(matched[1].Name).To(gm.Equal("cluster2"))
+
+	matched, svcErr = consumerService.FindByLabelSelector(ctx, "env=prod,tier")
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(len(matched)).To(gm.Equal(1))
+	gm.Expect(matched[0].Name).To(gm.Equal("cluster2"))
+
+	// the consumers without labels match the selectors of the absent labels
+	matched, svcErr = consumerService.FindByLabelSelector(ctx, "!env")
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(len(matched)).To(gm.Equal(1))
+	gm.Expect(matched[0].Name).To(gm.Equal("cluster4"))
+
+	_, svcErr = consumerService.FindByLabelSelector(ctx, "env in prod")
+	gm.Expect(svcErr).NotTo(gm.BeNil())
+}
+
+func TestBatchCreateConsumersValidatesLabels(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	ctx := context.Background()
+	consumerDao := mocks.NewConsumerDao()
+	consumerService := NewConsumerService(dbmocks.NewMockAdvisoryLockFactory(), consumerDao, mocks.NewResourceDao(), nil)
+
+	// the labels are validated for the consumers with or without a name
+	_, svcErr := consumerService.BatchCreate(ctx, []*api.Consumer{
+		{Name: "cluster1", Labels: &db.StringMap{"env": "prod"}},
+		{Labels: &db.StringMap{"_env": "prod"}},
+		{Name: "cluster3", Labels: &db.StringMap{"env": "-"}},
+	}, false)
+	gm.Expect(svcErr).NotTo(gm.BeNil())
+	gm.Expect(svcErr.Code).To(gm.Equal(errors.ErrorValidation))
+	gm.Expect(svcErr.Reason).To(gm.ContainSubstring("items[1]: consumer.labels: Invalid value: \"_env\""))
+	gm.Expect(svcErr.Reason).To(gm.ContainSubstring("cluster3: consumer.labels: Invalid value: \"-\""))
+	gm.Expect(svcErr.Reason).NotTo(gm.ContainSubstring("cluster1"))
+
+	// no consumer is created if any of the consumers is invalid
+	consumers, err := consumerDao.All(ctx)
+	gm.Expect(err).To(gm.BeNil())
+	gm.Expect(consumers).To(gm.BeEmpty())
+}
+
+func TestPatchConsumerLabels(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	ctx := context.Background()
+	consumerDao := mocks.NewConsumerDao()
+	eventDao := mocks.NewEventDao()
+	consumerService := NewConsumerService(dbmocks.NewMockAdvisoryLockFactory(), consumerDao, mocks.NewResourceDao(), NewEventService(eventDao))
+
+	_, err := consumerDao.Create(ctx, &api.Consumer{Meta: api.Meta{ID: "c1"}, Name: "cluster1", Labels: &db.StringMap{"env": "dev", "tier": "web"}})
+	gm.Expect(err).To(gm.BeNil())
+
+	// the labels are added and removed on the stored labels
+	consumer, svcErr := consumerService.PatchLabels(ctx, "c1", ConsumerLabelsPatch{
+		Add:    map[string]string{"env": "prod", "region": "us-east"},
+		Remove: []string{"tier"},
+	})
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(*consumer.Labels).To(gm.Equal(db.StringMap{"env": "prod", "region": "us-east"}))
+
+	// the labels are replaced before the added labels
+	consumer, svcErr = consumerService.PatchLabels(ctx, "c1", ConsumerLabelsPatch{
+		Replace: map[string]string{"env": "dev"},
+		Add:     map[string]string{"tier": "db"},
+	})
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(*consumer.Labels).To(gm.Equal(db.StringMap{"env": "dev", "tier": "db"}))
+
+	// a consumer update event is recorded for each patch
+	events, err := eventDao.All(ctx)
+	gm.Expect(err).To(gm.BeNil())
+	gm.Expect(len(events)).To(gm.Equal(2))
+	gm.Expect(events[0].Source).To(gm.Equal("Consumers"))
+	gm.Expect(events[0].SourceID).To(gm.Equal("c1"))
+	gm.Expect(events[0].EventType).To(gm.Equal(api.UpdateEventType))
+
+	// the invalid labels are rejected
+	_, svcErr = consumerService.PatchLabels(ctx, "c1", ConsumerLabelsPatch{Add: map[string]string{"invalid key": "v"}})
+	gm.Expect(svcErr).NotTo(gm.BeNil())
+	gm.Expect(svcErr.IsValidation()).To(gm.BeTrue())
+	_, svcErr = consumerService.PatchLabels(ctx, "c1", ConsumerLabelsPatch{Add: map[string]string{"env": "prod"}, Remove: []string{"env"}})
+	gm.Expect(svcErr).NotTo(gm.BeNil())
+	gm.Expect(svcErr.IsValidation()).To(gm.BeTrue())
+
+	_, svcErr = consumerService.PatchLabels(ctx, "c2", ConsumerLabelsPatch{Add: map[string]string{"env": "prod"}})
+	gm.Expect(svcErr).NotTo(gm.BeNil())
+	gm.Expect(svcErr.Is404()).To(gm.BeTrue())
+}
@@ -0,0 +1,176 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	e "errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/dao"
+	"github.com/openshift-online/maestro/pkg/errors"
+)
+
+// consumerTokenSecretSize is the number of random bytes of a consumer token secret.
+const consumerTokenSecretSize = 32
+
+// ErrInvalidConsumerToken is wrapped by the errors of Validate if the token is malformed, unknown, revoked or expired,
+// the other errors (e.g. the database is unavailable) say nothing about the token.
+var ErrInvalidConsumerToken = e.New("invalid consumer token")
+
+type ConsumerTokenService interface {
+	Get(ctx context.Context, id string) (*api.ConsumerToken, *errors.ServiceError)
+	// Issue mints a token scoped to the given consumer, the token value is only returned here. If the expiresAt
+	// is nil, the token expires after the default TTL.
+	Issue(ctx context.Context, consumerID string, expiresAt *time.Time, createdBy string) (*api.ConsumerToken, string, *errors.ServiceError)
+	// Revoke revokes the token, the revoked token is kept for audit.
+	Revoke(ctx context.Context, consumerID, id string) *errors.ServiceError
+	FindByConsumerID(ctx context.Context, consumerID string) (api.ConsumerTokenList, *errors.ServiceError)
+
+	// Validate returns the token metadata if the token is valid, a token is invalid if it is unknown, expired or
+	// revoked, then the error wraps ErrInvalidConsumerToken. Other errors are returned if the token can't be looked up.
+	Validate(ctx context.Context, token string) (*api.ConsumerToken, error)
+}
+
+func NewConsumerTokenService(consumerDao dao.ConsumerDao, consumerTokenDao dao.ConsumerTokenDao, defaultTTL time.Duration) ConsumerTokenService {
+	return &sqlConsumerTokenService{
+		consumerDao:      consumerDao,
+		consumerTokenDao: consumerTokenDao,
+		defaultTTL:       defaultTTL,
+	}
+}
+
+var _ ConsumerTokenService = &sqlConsumerTokenService{}
+
+type sqlConsumerTokenService struct {
+	consumerDao      dao.ConsumerDao
+	consumerTokenDao dao.ConsumerTokenDao
+	defaultTTL       time.Duration
+}
+
+func (s *sqlConsumerTokenService) Get(ctx context.Context, id string) (*api.ConsumerToken, *errors.ServiceError) {
+	token, err := s.consumerTokenDao.Get(ctx, id)
+	if err != nil {
+		return nil, handleGetError("ConsumerToken", "id", id, err)
+	}
+	return token, nil
+}
+
+func (s *sqlConsumerTokenService) Issue(ctx context.Context, consumerID string, expiresAt *time.Time, createdBy string) (*api.ConsumerToken, string, *errors.ServiceError) {
+	consumer, err := s.consumerDao.Get(ctx, consumerID)
+	if err != nil {
+		return nil, "", handleGetError("Consumer", "id", consumerID, err)
+	}
+
+	now := time.Now()
+	expiration := now.Add(s.defaultTTL)
+	if expiresAt != nil {
+		if !expiresAt.After(now) {
+			return nil, "", errors.Validation("the token expiration %s must be in the future", expiresAt.Format(time.RFC3339))
+		}
+		expiration = *expiresAt
+	}
+
+	secret := make([]byte, consumerTokenSecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", errors.GeneralError("Unable to generate consumer token: %s", err)
+	}
+
+	consumerToken := &api.ConsumerToken{
+		Meta: api.Meta{
+			ID: api.NewID(),
+		},
+		ConsumerID:   consumer.ID,
+		ConsumerName: consumer.Name,
+		ExpiresAt:    expiration,
+		CreatedBy:    createdBy,
+	}
+
+	// the token is in the format of <token id>.<secret>, the token id is used to find the token metadata
+	token := fmt.Sprintf("%s.%s", consumerToken.ID, base64.RawURLEncoding.EncodeToString(secret))
+	consumerToken.TokenHash = hashConsumerToken(token)
+
+	consumerToken, err = s.consumerTokenDao.Create(ctx, consumerToken)
+	if err != nil {
+		return nil, "", handleCreateError("ConsumerToken", err)
+	}
+
+	return consumerToken, token, nil
+}
+
+func (s *sqlConsumerTokenService) Revoke(ctx context.Context, consumerID, id string) *errors.ServiceError {
+	token, err := s.consumerTokenDao.Get(ctx, id)
+	if err != nil {
+		return handleGetError("ConsumerToken", "id", id, err)
+	}
+
+	if token.ConsumerID != consumerID {
+		return errors.NotFound("ConsumerToken with id='%s' not found", id)
+	}
+
+	if token.IsRevoked() {
+		return nil
+	}
+
+	now := time.Now()
+	token.RevokedAt = &now
+	if _, err := s.consumerTokenDao.Replace(ctx, token); err != nil {
+		return handleUpdateError("ConsumerToken", err)
+	}
+
+	return nil
+}
+
+func (s *sqlConsumerTokenService) FindByConsumerID(ctx context.Context, consumerID string) (api.ConsumerTokenList, *errors.ServiceError) {
+	if _, err := s.consumerDao.Get(ctx, consumerID); err != nil {
+		return nil, handleGetError("Consumer", "id", consumerID, err)
+	}
+
+	tokens, err := s.consumerTokenDao.FindByConsumerID(ctx, consumerID)
+	if err != nil {
+		return nil, errors.GeneralError("Unable to get consumer tokens: %s", err)
+	}
+	return tokens, nil
+}
+
+func (s *sqlConsumerTokenService) Validate(ctx context.Context, token string) (*api.ConsumerToken, error) {
+	id, _, found := strings.Cut(token, ".")
+	if !found || len(id) == 0 {
+		return nil, fmt.Errorf("%w: malformed consumer token", ErrInvalidConsumerToken)
+	}
+
+	consumerToken, err := s.consumerTokenDao.Get(ctx, id)
+	if err != nil {
+		if e.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: unknown consumer token", ErrInvalidConsumerToken)
+		}
+		return nil, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(consumerToken.TokenHash), []byte(hashConsumerToken(token))) != 1 {
+		return nil, fmt.Errorf("%w: unknown consumer token", ErrInvalidConsumerToken)
+	}
+
+	if consumerToken.IsRevoked() {
+		return nil, fmt.Errorf("%w: consumer token %s has been revoked", ErrInvalidConsumerToken, consumerToken.ID)
+	}
+
+	if consumerToken.IsExpired(time.Now()) {
+		return nil, fmt.Errorf("%w: consumer token %s has expired", ErrInvalidConsumerToken, consumerToken.ID)
+	}
+
+	return consumerToken, nil
+}
+
+func hashConsumerToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	e "errors"
+	"testing"
+	"time"
+
+	gm "github.com/onsi/gomega"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/dao"
+	"github.com/openshift-online/maestro/pkg/dao/mocks"
+)
+
+func TestConsumerToken(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	ctx := context.Background()
+	consumerDao := mocks.NewConsumerDao()
+	_, err := consumerDao.Create(ctx, &api.Consumer{Meta: api.Meta{ID: Fukuisaurus}, Name: "cluster1"})
+	gm.Expect(err).To(gm.BeNil())
+
+	consumerTokenService := NewConsumerTokenService(consumerDao, mocks.NewConsumerTokenDao(), time.Hour)
+
+	// issue a token for an unknown consumer
+	_, _, svcErr := consumerTokenService.Issue(ctx, Seismosaurus, nil, "admin")
+	gm.Expect(svcErr).NotTo(gm.BeNil())
+	gm.Expect(svcErr.Is404()).To(gm.BeTrue())
+
+	// issue a token with an expiration in the past
+	past := time.Now().Add(-time.Minute)
+	_, _, svcErr = consumerTokenService.Issue(ctx, Fukuisaurus, &past, "admin")
+	gm.Expect(svcErr).NotTo(gm.BeNil())
+
+	issued, token, svcErr := consumerTokenService.Issue(ctx, Fukuisaurus, nil, "admin")
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(issued.ConsumerName).To(gm.Equal("cluster1"))
+	gm.Expect(issued.CreatedBy).To(gm.Equal("admin"))
+	gm.Expect(issued.TokenHash).NotTo(gm.ContainSubstring(token))
+
+	validated, err := consumerTokenService.Validate(ctx, token)
+	gm.Expect(err).To(gm.BeNil())
+	gm.Expect(validated.ID).To(gm.Equal(issued.ID))
+
+	_, err = consumerTokenService.Validate(ctx, issued.ID+".invalid")
+	gm.Expect(e.Is(err, ErrInvalidConsumerToken)).To(gm.BeTrue())
+	_, err = consumerTokenService.Validate(ctx, "invalid")
+	gm.Expect(e.Is(err, ErrInvalidConsumerToken)).To(gm.BeTrue())
+	_, err = consumerTokenService.Validate(ctx, "unknown.invalid")
+	gm.Expect(e.Is(err, ErrInvalidConsumerToken)).To(gm.BeTrue())
+
+	// the revoked token is invalid but kept for audit
+	gm.Expect(consumerTokenService.Revoke(ctx, Fukuisaurus, issued.ID)).To(gm.BeNil())
+	_, err = consumerTokenService.Validate(ctx, token)
+	gm.Expect(err).NotTo(gm.BeNil())
+
+	tokens, svcErr := consumerTokenService.FindByConsumerID(ctx, Fukuisaurus)
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(len(tokens)).To(gm.Equal(1))
+	gm.Expect(tokens[0].IsRevoked()).To(gm.BeTrue())
+
+	// the expired token is invalid
+	expiresAt := time.Now().Add(time.Second)
+	_, token, svcErr = consumerTokenService.Issue(ctx, Fukuisaurus, &expiresAt, "admin")
+	gm.Expect(svcErr).To(gm.BeNil())
+	time.Sleep(time.Until(expiresAt))
+	_, err = consumerTokenService.Validate(ctx, token)
+	gm.Expect(e.Is(err, ErrInvalidConsumerToken)).To(gm.BeTrue())
+}
+
+// unavailableConsumerTokenDao fails to get the tokens as the database is unavailable.
+type unavailableConsumerTokenDao struct {
+	dao.ConsumerTokenDao
+}
+
+func (d *unavailableConsumerTokenDao) Get(ctx context.Context, id string) (*api.ConsumerToken, error) {
+	return nil, e.New("driver: bad connection")
+}
+
+func TestValidateConsumerTokenLookupFailure(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	consumerTokenService := NewConsumerTokenService(mocks.NewConsumerDao(), &unavailableConsumerTokenDao{}, time.Hour)
+
+	// the token is not invalid if it can't be looked up
+	_, err := consumerTokenService.Validate(context.Background(), "id.secret")
+	gm.Expect(err).NotTo(gm.BeNil())
+	gm.Expect(e.Is(err, ErrInvalidConsumerToken)).To(gm.BeFalse())
+}
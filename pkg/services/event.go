@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"time"
 
 	"github.com/openshift-online/maestro/pkg/api"
 	"github.com/openshift-online/maestro/pkg/dao"
@@ -19,6 +20,9 @@ type EventService interface {
 
 	FindAllUnreconciledEvents(ctx context.Context) (api.EventList, *errors.ServiceError)
 	DeleteAllReconciledEvents(ctx context.Context) *errors.ServiceError
+	// DeleteCreatedBefore deletes the events created before the given time regardless of whether they are
+	// reconciled, it returns the number of the deleted events.
+	DeleteCreatedBefore(ctx context.Context, before time.Time) (int64, *errors.ServiceError)
 }
 
 func NewEventService(eventDao dao.EventDao) EventService {
@@ -94,3 +98,11 @@ func (s *sqlEventService) DeleteAllReconciledEvents(ctx context.Context) *errors
 	}
 	return nil
 }
+
+func (s *sqlEventService) DeleteCreatedBefore(ctx context.Context, before time.Time) (int64, *errors.ServiceError) {
+	deleted, err := s.eventDao.DeleteCreatedBefore(ctx, before)
+	if err != nil {
+		return 0, handleDeleteError("Event", errors.GeneralError("Unable to delete events created before %s: %s", before, err))
+	}
+	return deleted, nil
+}
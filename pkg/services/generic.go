@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
 	"github.com/openshift-online/ocm-common/pkg/utils/parser/sql_parser"
 	"github.com/yaacov/tree-search-language/pkg/tsl"
 	"github.com/yaacov/tree-search-language/pkg/walkers/ident"
@@ -94,6 +95,15 @@ func (s *sqlGenericService) List(ctx context.Context, username string, args *Lis
 		// add "ORDER BY"
 		s.buildOrderBy,
 
+		// translate "conditions" into "WHERE" with the indexed conditions summary.
+		s.buildConditions,
+
+		// translate "labels" into "WHERE" with the indexed resource labels.
+		s.buildLabels,
+
+		// translate "label keys" into "WHERE" with the consumer labels.
+		s.buildLabelKeys,
+
 		// translate "search" into "WHERE"(s), and "JOIN"(s) if related resource is searched.
 		s.buildSearch,
 
@@ -148,6 +158,36 @@ func (s *sqlGenericService) buildOrderBy(listCtx *listContext, d *dao.GenericDao
 	return false, nil
 }
 
+func (s *sqlGenericService) buildConditions(listCtx *listContext, d *dao.GenericDao) (bool, *errors.ServiceError) {
+	if len(listCtx.args.Conditions) == 0 {
+		return false, nil
+	}
+
+	// the array containment is backed by the GIN index of the conditions summary column
+	(*d).Where(fmt.Sprintf("%s.conditions @> ?", (*d).GetTableName()), []interface{}{pq.StringArray(listCtx.args.Conditions)})
+	return false, nil
+}
+
+func (s *sqlGenericService) buildLabels(listCtx *listContext, d *dao.GenericDao) (bool, *errors.ServiceError) {
+	if len(listCtx.args.Labels) == 0 {
+		return false, nil
+	}
+
+	// the array containment is backed by the GIN index of the labels column
+	(*d).Where(fmt.Sprintf("%s.labels @> ?", (*d).GetTableName()), []interface{}{pq.StringArray(listCtx.args.Labels)})
+	return false, nil
+}
+
+func (s *sqlGenericService) buildLabelKeys(listCtx *listContext, d *dao.GenericDao) (bool, *errors.ServiceError) {
+	if len(listCtx.args.LabelKeys) == 0 {
+		return false, nil
+	}
+
+	// the labels column of the consumers is json, it is cast to jsonb to check the label keys
+	(*d).Where(fmt.Sprintf("jsonb_exists_all(%s.labels::jsonb, ?)", (*d).GetTableName()), []interface{}{pq.StringArray(listCtx.args.LabelKeys)})
+	return false, nil
+}
+
 func (s *sqlGenericService) buildSearch(listCtx *listContext, d *dao.GenericDao) (bool, *errors.ServiceError) {
 	if listCtx.args.Search == "" {
 		s.addJoins(listCtx, d)
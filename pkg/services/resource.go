@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sync"
 	"time"
 
-	cloudeventstypes "github.com/cloudevents/sdk-go/v2/types"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/lib/pq"
 	"github.com/openshift-online/maestro/pkg/dao"
 	"github.com/openshift-online/maestro/pkg/db"
 	logger "github.com/openshift-online/maestro/pkg/logger"
@@ -28,34 +30,160 @@ func init() {
 type ResourceService interface {
 	Get(ctx context.Context, id string) (*api.Resource, *errors.ServiceError)
 	Create(ctx context.Context, resource *api.Resource) (*api.Resource, *errors.ServiceError)
+	// FindConflicting returns the existing resource that has the same ID or name as the given resource, e.g. the
+	// resource that is created concurrently by another source, a not found error is returned if there is none.
+	FindConflicting(ctx context.Context, resource *api.Resource) (*api.Resource, *errors.ServiceError)
+	// Validate validates the resource with the validations of a resource creation, the resource is not created.
+	Validate(resource *api.Resource) *errors.ServiceError
+	// BatchCreate creates the given resources of the consumers, e.g. the copies of a resource for each of the
+	// consumers, and returns them in the given order. The resources are created in a single statement, so if one of
+	// them is invalid or cannot be created, none of them is created.
+	BatchCreate(ctx context.Context, resources api.ResourceList) (api.ResourceList, *errors.ServiceError)
 	Update(ctx context.Context, resource *api.Resource) (*api.Resource, *errors.ServiceError)
 	UpdateStatus(ctx context.Context, resource *api.Resource) (*api.Resource, bool, *errors.ServiceError)
 	MarkAsDeleting(ctx context.Context, id string) *errors.ServiceError
+	// MarkDispatched records that the resource status is broadcast by the given maestro instance.
+	MarkDispatched(ctx context.Context, id, instanceID string) *errors.ServiceError
+	MarkAsDeletingWithResult(ctx context.Context, id string) (DeletionResult, *errors.ServiceError)
 	Delete(ctx context.Context, id string) *errors.ServiceError
 	All(ctx context.Context) (api.ResourceList, *errors.ServiceError)
 
 	FindByIDs(ctx context.Context, ids []string) (api.ResourceList, *errors.ServiceError)
 	FindBySource(ctx context.Context, source string) (api.ResourceList, *errors.ServiceError)
+	// FindOrphaned returns a page of the resources that are not marked as deleting and whose consumer doesn't exist.
+	FindOrphaned(ctx context.Context, resourceType api.ResourceType, args *ListArguments) (api.ResourceList, *api.PagingMeta, *errors.ServiceError)
+	// CountVersionDrift counts the resources whose version observed by the agent drifts from the resource version by
+	// the drift state. The resources that are ahead are always counted, the lagging and the unreported resources are
+	// counted only if they are not updated since the given time.
+	CountVersionDrift(ctx context.Context, updatedBefore time.Time) (map[api.VersionDriftState]int, *errors.ServiceError)
+	// CountByConsumer counts the resources that are not being deleted by the consumer name.
+	CountByConsumer(ctx context.Context) (map[string]int, *errors.ServiceError)
+	// CountPendingDeletion counts the resources that were marked as deleting before the given time and are still
+	// awaiting the deletion confirmation, by the resource type and the consumer name.
+	CountPendingDeletion(ctx context.Context, deletedBefore time.Time) (map[api.ResourceType]map[string]int, *errors.ServiceError)
+	// FindActiveByConsumerName returns a page of the resources of the consumer that are not marked as deleting, the
+	// resources are ordered by ID and the page starts after the given resource ID. An empty resource type means all
+	// the resource types.
+	FindActiveByConsumerName(ctx context.Context, consumerName string, resourceType api.ResourceType, afterID string, limit int) (api.ResourceList, *errors.ServiceError)
+	// ListRevisions returns a page of the manifest revisions of the resource, the latest revision comes first unless
+	// the args are ordered.
+	ListRevisions(ctx context.Context, id string, args *ListArguments) (api.ResourceRevisionList, *api.PagingMeta, *errors.ServiceError)
+	Revert(ctx context.Context, id string, version int64) (*api.Resource, *errors.ServiceError)
+	// Reconcile bumps the resource version with the unchanged manifest, so the resource is re-broadcast to the agent.
+	Reconcile(ctx context.Context, id string) (*api.Resource, *errors.ServiceError)
+	// ReleaseQuarantine releases the quarantined resource and reconciles it, so the resource is re-broadcast to the
+	// agent. The resource is quarantined once its consecutive reconcile failures reach the quarantine threshold.
+	ReleaseQuarantine(ctx context.Context, id string) (*api.Resource, *errors.ServiceError)
+	// CountQuarantined counts the quarantined resources that are not marked as deleting by the resource type.
+	CountQuarantined(ctx context.Context) (map[api.ResourceType]int, *errors.ServiceError)
+	// MarkReconcileStale marks the resources whose version is not observed by the agent and whose spec is not changed
+	// since the given time with the ReconcileStaleCondition, it returns the number of the newly marked resources.
+	MarkReconcileStale(ctx context.Context, specUpdatedBefore time.Time) (int64, *errors.ServiceError)
+	// DeleteUnreferencedPayloads deletes the offloaded payloads that are last modified before the given time and are
+	// not referenced by any resource, it returns the number of the deleted payloads.
+	DeleteUnreferencedPayloads(ctx context.Context, modifiedBefore time.Time) (int, *errors.ServiceError)
+	// SyncStatusFeedback updates the status feedback rules of the resource with its readiness, see
+	// api.UpdateStatusFeedback. The resource version is increased only if the rules are changed, so the resource is
+	// re-broadcast to the agent with the new rules. It returns whether the rules are changed.
+	SyncStatusFeedback(ctx context.Context, id string) (*api.Resource, bool, *errors.ServiceError)
+	// ReconcileByConsumer reconciles all the resources of the consumer that are not marked as deleting, it returns the
+	// number of the reconciled resources.
+	ReconcileByConsumer(ctx context.Context, consumerName string) (int, *errors.ServiceError)
+	// ResolveDeleteOptionByConsumer resolves the delete option of the resources of the consumer again after the default
+	// delete propagation policy of the consumer is changed, the resources with a delete option of their own are not
+	// changed. It returns the number of the resources whose delete option is changed.
+	ResolveDeleteOptionByConsumer(ctx context.Context, consumerName string) (int, *errors.ServiceError)
+	// MarkAsDeletingByConsumer marks all the resources of the consumer as deleting, the confirmation must match the
+	// number of the resources that are not marked as deleting, otherwise a conflict error with the number is returned
+	// and no resource is marked. It returns the number of the marked resources.
+	MarkAsDeletingByConsumer(ctx context.Context, consumerName string, confirmation int) (int, *errors.ServiceError)
+	// TransferOwnership reassigns the resource to the given source, so the new source can manage the resource and the
+	// former source can't. The transfer is recorded with the user who made it for audit, the record is returned.
+	TransferOwnership(ctx context.Context, id, source, transferredBy string) (*api.ResourceOwnershipTransfer, *errors.ServiceError)
+	// ListOwnershipTransfers returns a page of the ownership transfers of the resource, the latest transfer comes first.
+	ListOwnershipTransfers(ctx context.Context, id string, args *ListArguments) (api.ResourceOwnershipTransferList, *api.PagingMeta, *errors.ServiceError)
 	List(listOpts cetypes.ListOptions) ([]*api.Resource, error)
 	ListWithArgs(ctx context.Context, username string, args *ListArguments, resources *[]api.Resource) (*api.PagingMeta, *errors.ServiceError)
 }
 
-func NewResourceService(lockFactory db.LockFactory, resourceDao dao.ResourceDao, events EventService, generic GenericService) ResourceService {
+// ResourceBatchCreateStatus is the status of a consumer in a resource batch creation.
+type ResourceBatchCreateStatus string
+
+const (
+	// ResourceBatchCreated means the resource is created for the consumer by the batch.
+	ResourceBatchCreated ResourceBatchCreateStatus = "Created"
+	// ResourceBatchFailed means the resource is not created for the consumer, e.g. the consumer is not found.
+	ResourceBatchFailed ResourceBatchCreateStatus = "Failed"
+)
+
+// DeletionResult is the result of marking a resource as deleting.
+type DeletionResult string
+
+const (
+	// DeletionMarked means the resource is marked as deleting by this request.
+	DeletionMarked DeletionResult = "Marked"
+	// DeletionAlreadyDeleting means the resource had already been marked as deleting.
+	DeletionAlreadyDeleting DeletionResult = "AlreadyDeleting"
+	// DeletionNotFound means the resource is not found, it never existed or had already been deleted by the agent.
+	DeletionNotFound DeletionResult = "NotFound"
+)
+
+// reconcilePageSize is the number of resources loaded from the database at a time when reconciling the resources of a
+// consumer.
+const reconcilePageSize = 500
+
+// ResourceServiceOptions are the settings of the resource service, the zero value disables all of them.
+type ResourceServiceOptions struct {
+	// RevisionLimit is the max number of the manifest revisions kept for each resource, 0 disables the revisions.
+	RevisionLimit int
+	// Limits are the limits of the resource manifests, see ManifestLimits.
+	Limits ManifestLimits
+	// QuarantineThreshold is the number of the consecutive reconcile failures after which a resource is quarantined,
+	// 0 disables the quarantine.
+	QuarantineThreshold int
+	// LabelPropagation selects the manifest labels that are mirrored to the resource labels, see api.LabelPropagation.
+	LabelPropagation *api.LabelPropagation
+}
+
+func NewResourceService(lockFactory db.LockFactory, transactor db.Transactor, resourceDao dao.ResourceDao,
+	resourceRevisionDao dao.ResourceRevisionDao, ownershipTransferDao dao.ResourceOwnershipTransferDao, consumerDao dao.ConsumerDao,
+	events EventService, generic GenericService, opts ResourceServiceOptions) ResourceService {
 	return &sqlResourceService{
-		lockFactory: lockFactory,
-		resourceDao: resourceDao,
-		events:      events,
-		generic:     generic,
+		lockFactory:          lockFactory,
+		transactor:           transactor,
+		resourceDao:          resourceDao,
+		resourceRevisionDao:  resourceRevisionDao,
+		ownershipTransferDao: ownershipTransferDao,
+		consumerDao:          consumerDao,
+		events:               events,
+		generic:              generic,
+		revisionLimit:        opts.RevisionLimit,
+		limits:               opts.Limits,
+		quarantineThreshold:  opts.QuarantineThreshold,
+		labelPropagation:     opts.LabelPropagation,
 	}
 }
 
 var _ ResourceService = &sqlResourceService{}
 
 type sqlResourceService struct {
-	lockFactory db.LockFactory
-	resourceDao dao.ResourceDao
-	events      EventService
-	generic     GenericService
+	lockFactory          db.LockFactory
+	transactor           db.Transactor
+	resourceDao          dao.ResourceDao
+	resourceRevisionDao  dao.ResourceRevisionDao
+	ownershipTransferDao dao.ResourceOwnershipTransferDao
+	consumerDao          dao.ConsumerDao
+	events               EventService
+	generic              GenericService
+	// revisionLimit is the max number of the manifest revisions kept for each resource, 0 disables the revisions.
+	revisionLimit int
+	// limits are the limits of the resource manifests, see ManifestLimits.
+	limits ManifestLimits
+	// quarantineThreshold is the number of the consecutive reconcile failures after which a resource is quarantined,
+	// 0 disables the quarantine.
+	quarantineThreshold int
+	// labelPropagation selects the manifest labels that are mirrored to the resource labels, see api.LabelPropagation.
+	labelPropagation *api.LabelPropagation
 }
 
 func (s *sqlResourceService) Get(ctx context.Context, id string) (*api.Resource, *errors.ServiceError) {
@@ -71,18 +199,141 @@ func (s *sqlResourceService) Get(ctx context.Context, id string) (*api.Resource,
 }
 
 func (s *sqlResourceService) Create(ctx context.Context, resource *api.Resource) (*api.Resource, *errors.ServiceError) {
+	if err := s.validateCreate(resource); err != nil {
+		return nil, err
+	}
+	if err := s.syncLabels(resource); err != nil {
+		return nil, err
+	}
+	if _, err := s.resolveDeleteOption(ctx, resource); err != nil {
+		return nil, err
+	}
+
+	var created *api.Resource
+	serviceErr := s.transact(ctx, func(ctx context.Context) *errors.ServiceError {
+		var err error
+		if created, err = s.resourceDao.Create(ctx, resource); err != nil {
+			return handleCreateError("Resource", err)
+		}
+		return s.onCreated(ctx, created)
+	})
+	if serviceErr != nil {
+		if !serviceErr.IsConflict() {
+			return nil, serviceErr
+		}
+		// the ID or the name is taken, e.g. by a concurrent creation of the same resource, report the existing
+		// resource so the client can adopt it, it's looked up after the failed transaction is rolled back
+		if existing, findErr := s.FindConflicting(ctx, resource); findErr == nil {
+			return nil, errors.Conflict("The Resource already exists with id %s", existing.ID)
+		}
+		return nil, serviceErr
+	}
+
+	countCreated(created)
+	return created, nil
+}
+
+func (s *sqlResourceService) FindConflicting(ctx context.Context, resource *api.Resource) (*api.Resource, *errors.ServiceError) {
+	if resource.ID != "" {
+		existing, err := s.resourceDao.Get(ctx, resource.ID)
+		if err == nil {
+			return existing, nil
+		}
+		if serviceErr := handleGetError("Resource", "id", resource.ID, err); !serviceErr.Is404() {
+			return nil, serviceErr
+		}
+	}
+	if resource.Name != "" {
+		existing, err := s.resourceDao.GetByName(ctx, resource.Name)
+		if err != nil {
+			return nil, handleGetError("Resource", "name", resource.Name, err)
+		}
+		return existing, nil
+	}
+	return nil, errors.NotFound("no resource conflicts with the resource %s", resource.ID)
+}
+
+func (s *sqlResourceService) BatchCreate(ctx context.Context, resources api.ResourceList) (api.ResourceList, *errors.ServiceError) {
+	if len(resources) == 0 {
+		return nil, errors.Validation("at least one consumer is required")
+	}
+	for _, resource := range resources {
+		if err := s.validateCreate(resource); err != nil {
+			return nil, err
+		}
+		if err := s.syncLabels(resource); err != nil {
+			return nil, err
+		}
+		if _, err := s.resolveDeleteOption(ctx, resource); err != nil {
+			return nil, err
+		}
+	}
+
+	var created api.ResourceList
+	if err := s.transact(ctx, func(ctx context.Context) *errors.ServiceError {
+		var err error
+		if created, err = s.resourceDao.BatchCreate(ctx, resources); err != nil {
+			return handleCreateError("Resource", err)
+		}
+		for _, resource := range created {
+			if err := s.onCreated(ctx, resource); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, resource := range created {
+		countCreated(resource)
+	}
+	return created, nil
+}
+
+func (s *sqlResourceService) Validate(resource *api.Resource) *errors.ServiceError {
+	return s.validateCreate(resource)
+}
+
+// validateCreate validates the name and the manifest of the resource to be created.
+func (s *sqlResourceService) validateCreate(resource *api.Resource) *errors.ServiceError {
 	if resource.Name != "" {
 		if err := ValidateResourceName(resource); err != nil {
-			return nil, errors.Validation("the name in the resource is invalid, %v", err)
+			return errors.Validation("the name in the resource is invalid, %v", err)
 		}
 	}
+	if err := ValidateManifestBundleSize(resource.Type, resource.Payload, s.limits.MaxBundleManifests); err != nil {
+		return errors.Validation("the manifest bundle in the resource is oversized, %v", err)
+	}
+	if err := ValidateManifestComplexity(resource.Payload, s.limits.MaxDepth, s.limits.MaxKeys); err != nil {
+		return errors.Validation("the manifest in the resource is too complex, %v", err)
+	}
 	if err := ValidateManifest(resource.Type, resource.Payload); err != nil {
-		return nil, errors.Validation("the manifest in the resource is invalid, %v", err)
+		return errors.Validation("the manifest in the resource is invalid, %v", err)
+	}
+	if err := ValidateManifestKinds(resource.Type, resource.Payload, s.limits.AllowedKinds, s.limits.DeniedKinds); err != nil {
+		return errors.Validation("the manifest in the resource is not admitted, %v", err)
 	}
+	return nil
+}
 
-	resource, err := s.resourceDao.Create(ctx, resource)
+// syncLabels mirrors the selected manifest labels of the resource to its labels, so the labels removed from the
+// manifest are removed from the resource as well. The effective update strategy of the resource is refreshed too.
+func (s *sqlResourceService) syncLabels(resource *api.Resource) *errors.ServiceError {
+	labels, err := s.labelPropagation.Labels(resource.Type, resource.Payload)
 	if err != nil {
-		return nil, handleCreateError("Resource", err)
+		return errors.Validation("the manifest labels in the resource are invalid, %v", err)
+	}
+	resource.Labels = labels
+	resource.UpdateStrategy = api.EffectiveUpdateStrategy(resource.Type, resource.Payload)
+	return nil
+}
+
+// onCreated records the revision and the create event of the created resource, it runs in the transaction of the
+// resource creation, so the create event is committed with the resource.
+func (s *sqlResourceService) onCreated(ctx context.Context, resource *api.Resource) *errors.ServiceError {
+	if err := s.createRevision(ctx, resource); err != nil {
+		return handleCreateError("ResourceRevision", err)
 	}
 
 	_, eErr := s.events.Create(ctx, &api.Event{
@@ -91,10 +342,16 @@ func (s *sqlResourceService) Create(ctx context.Context, resource *api.Resource)
 		EventType: api.CreateEventType,
 	})
 	if eErr != nil {
-		return nil, handleCreateError("Resource", err)
+		return eErr
 	}
+	return nil
+}
 
-	return resource, nil
+// countCreated counts the creation of the resource, it's called after the transaction of the creation is committed,
+// so the creations that are rolled back are not counted.
+func countCreated(resource *api.Resource) {
+	resourceCreatesCountMetric.With(resourceChurnLabels(resource)).Inc()
+	countConsumerChurn(resource.ConsumerName, "create")
 }
 
 func (s *sqlResourceService) Update(ctx context.Context, resource *api.Resource) (*api.Resource, *errors.ServiceError) {
@@ -122,30 +379,50 @@ func (s *sqlResourceService) Update(ctx context.Context, resource *api.Resource)
 		return nil, errors.Conflict("the resource version is not the latest, the latest version: %d", found.Version)
 	}
 
+	// The delete option is resolved before the comparison, so a manifest without a delete option of its own is not
+	// changed by the delete option that is resolved at create.
+	if _, svcErr := s.resolveDeleteOption(ctx, resource); svcErr != nil {
+		return nil, svcErr
+	}
+
 	// New manifest is not changed, the update action is not needed.
 	if reflect.DeepEqual(resource.Payload, found.Payload) {
 		return found, nil
 	}
 
+	if err := ValidateManifestBundleSize(resource.Type, resource.Payload, s.limits.MaxBundleManifests); err != nil {
+		return nil, errors.Validation("the new manifest bundle in the resource is oversized, %v", err)
+	}
+	if err := ValidateManifestComplexity(resource.Payload, s.limits.MaxDepth, s.limits.MaxKeys); err != nil {
+		return nil, errors.Validation("the new manifest in the resource is too complex, %v", err)
+	}
 	if err := ValidateManifestUpdate(resource.Type, resource.Payload, found.Payload); err != nil {
 		return nil, errors.Validation("the new manifest in the resource is invalid, %v", err)
 	}
+	if err := ValidateManifestKinds(resource.Type, resource.Payload, s.limits.AllowedKinds, s.limits.DeniedKinds); err != nil {
+		return nil, errors.Validation("the new manifest in the resource is not admitted, %v", err)
+	}
+	// the immutable fields are validated here rather than by the REST API, so the reverts are validated too
+	if err := ValidateManifestImmutableFields(resource.Type, resource.Payload, found.Payload, s.limits.ImmutableFields); err != nil {
+		return nil, errors.Validation("the new manifest in the resource is invalid, %v", err)
+	}
 
 	// Increase the current resource version and update its manifest.
-	found.Version = found.Version + 1
+	if err := increaseResourceVersion(found); err != nil {
+		return nil, err
+	}
 	found.Payload = resource.Payload
-
-	updated, err := s.resourceDao.Update(ctx, found)
-	if err != nil {
-		return nil, handleUpdateError("Resource", err)
+	found.DeleteOptionResolved = resource.DeleteOptionResolved
+	if resource.Metadata != nil {
+		found.Metadata = resource.Metadata
+	}
+	if err := s.syncLabels(found); err != nil {
+		return nil, err
 	}
 
-	if _, err := s.events.Create(ctx, &api.Event{
-		Source:    "Resources",
-		SourceID:  updated.ID,
-		EventType: api.UpdateEventType,
-	}); err != nil {
-		return nil, handleUpdateError("Resource", err)
+	updated, svcErr := s.updateWithEvent(ctx, found)
+	if svcErr != nil {
+		return nil, svcErr
 	}
 
 	// Create the set of labels that we will add to all the resource process:
@@ -156,6 +433,8 @@ func (s *sqlResourceService) Update(ctx context.Context, resource *api.Resource)
 
 	// Update the metric containing the number of processed resources:
 	resourceProcessedCountMetric.With(labels).Inc()
+	resourceUpdatesCountMetric.With(resourceChurnLabels(updated)).Inc()
+	countConsumerChurn(updated.ConsumerName, "update")
 
 	return updated, nil
 }
@@ -196,39 +475,98 @@ func (s *sqlResourceService) UpdateStatus(ctx context.Context, resource *api.Res
 
 	logger.V(4).Info(fmt.Sprintf("Updating resource status with event %s", resourceStatusEvent))
 
-	sequenceID, err := cloudeventstypes.ToString(resourceStatusEvent.Context.GetExtensions()[cetypes.ExtensionStatusUpdateSequenceID])
+	sequenceID, err := statusSequenceID(resourceStatusEvent)
 	if err != nil {
 		return nil, false, errors.GeneralError("Unable to get sequence ID from resource status: %s", err)
 	}
 
+	// The found resource has no status if this is the first status update of the resource.
 	foundSequenceID := ""
+	var foundStatusEvent *cloudevents.Event
 	if len(found.Status) != 0 {
-		foundStatusEvent, err := api.JSONMAPToCloudEvent(found.Status)
+		foundStatusEvent, err = api.JSONMAPToCloudEvent(found.Status)
 		if err != nil {
 			return nil, false, errors.GeneralError("Unable to convert resource status to cloudevent: %s", err)
 		}
 
-		foundSequenceID, err = cloudeventstypes.ToString(foundStatusEvent.Context.GetExtensions()[cetypes.ExtensionStatusUpdateSequenceID])
+		foundSequenceID, err = statusSequenceID(foundStatusEvent)
 		if err != nil {
 			return nil, false, errors.GeneralError("Unable to get sequence ID from found resource status: %s", err)
 		}
 	}
 
-	newer, err := compareSequenceIDs(sequenceID, foundSequenceID)
-	if err != nil {
-		return nil, false, errors.GeneralError("Unable to compare sequence IDs: %s", err)
+	// Ensure the status progresses monotonically, a status is newer only if its sequence ID is greater than the
+	// one of the found status. A status without sequence ID cannot be ordered, so it is only accepted when the
+	// found status has no sequence ID either.
+	newer := foundSequenceID == ""
+	if sequenceID != "" && foundSequenceID != "" {
+		newer, err = compareSequenceIDs(sequenceID, foundSequenceID)
+		if err != nil {
+			return nil, false, errors.GeneralError("Unable to compare sequence IDs: %s", err)
+		}
 	}
 	if !newer {
 		logger.Warning(fmt.Sprintf("Updating status for stale resource; disregard it: id=%s, foundSequenceID=%s, wantedSequenceID=%s",
 			resource.ID, foundSequenceID, sequenceID))
+		resourceStaleStatusCountMetric.With(prometheus.Labels{metricsTypeLabel: string(found.Type)}).Inc()
 		return found, false, nil
 	}
 
-	found.Status = resource.Status
-	updated, err := s.resourceDao.Update(ctx, found)
+	// Some agents resend the same status periodically, the status with the same content as the found status is
+	// neither written nor broadcast. The stale check above still applies to it, and the sequence ID of the found
+	// status is advanced by the next status that changes the content.
+	if foundStatusEvent != nil {
+		same, err := sameStatus(resourceStatusEvent, foundStatusEvent)
+		if err != nil {
+			return nil, false, errors.GeneralError("Unable to compare resource status: %s", err)
+		}
+		if same {
+			logger.V(4).Info(fmt.Sprintf("Resource status is not changed; skip it: id=%s, sequenceID=%s", resource.ID, sequenceID))
+			resourceStatusNoopCountMetric.With(prometheus.Labels{metricsTypeLabel: string(found.Type)}).Inc()
+			// the resent failure is still a failed reconcile report of the agent
+			if svcErr := s.trackReconcileFailure(ctx, found, found.Conditions); svcErr != nil {
+				return nil, false, svcErr
+			}
+			return found, false, nil
+		}
+	}
+
+	// Refresh the conditions summary with the status, so the resources can be filtered by condition.
+	conditions, err := api.ConditionsSummary(found.Type, resource.Status)
+	if err != nil {
+		return nil, false, errors.GeneralError("Unable to summarize resource status conditions: %s", err)
+	}
+
+	// the resource is reconciled once the status first reports the resource version is applied
+	foundObservedVersion, _ := api.ObservedVersion(found.Status)
+	observedVersion, _ := api.ObservedVersion(resource.Status)
+	reconciled := observedVersion == found.Version && foundObservedVersion != found.Version
+	// the status updates bump the updated_at, so the reconcile is timed from the spec change of the version
+	specUpdatedAt := found.CreatedAt
+	if found.SpecUpdatedAt != nil {
+		specUpdatedAt = *found.SpecUpdatedAt
+	}
+
+	// only the status fields are written, the spec of the resource is never changed by a status update
+	written, err := s.resourceDao.UpdateStatus(ctx, found.ID, found.Version, resource.Status, conditions)
 	if err != nil {
 		return nil, false, handleUpdateError("Resource", err)
 	}
+	if !written {
+		// the spec is updated to a new version after the resource is read, the status is reported for the old version
+		logger.Warning(fmt.Sprintf("Updating status for stale resource; disregard it: id=%s, the resource is updated from version %d",
+			resource.ID, found.Version))
+		return found, false, nil
+	}
+	found.Status = resource.Status
+	found.Conditions = conditions
+	updated := found
+	if reconciled {
+		observeConsumerReconcile(updated.ConsumerName, updateStrategyLabel(updated), time.Since(specUpdatedAt))
+	}
+	if svcErr := s.trackReconcileFailure(ctx, updated, conditions); svcErr != nil {
+		return nil, false, svcErr
+	}
 
 	// Create the set of labels that we will add to all the resource process:
 	labels := prometheus.Labels{
@@ -242,6 +580,45 @@ func (s *sqlResourceService) UpdateStatus(ctx context.Context, resource *api.Res
 	return updated, true, nil
 }
 
+// trackReconcileFailure counts the consecutive reconcile failures of the resource with the conditions summary of its
+// latest status report, the resource is quarantined once the failures reach the quarantine threshold. The failures of
+// a quarantined resource are no longer counted until it is released. Nothing is tracked if the quarantine is disabled.
+func (s *sqlResourceService) trackReconcileFailure(ctx context.Context, resource *api.Resource, conditions pq.StringArray) *errors.ServiceError {
+	if s.quarantineThreshold <= 0 || resource.IsQuarantined() {
+		return nil
+	}
+
+	failures := int32(0)
+	if api.IsReconcileFailed(conditions) {
+		failures = resource.ReconcileFailures + 1
+	}
+	if failures == resource.ReconcileFailures {
+		return nil
+	}
+
+	var quarantinedAt *time.Time
+	if int(failures) >= s.quarantineThreshold {
+		now := time.Now()
+		quarantinedAt = &now
+		logger.NewOCMLogger(ctx).Warning(fmt.Sprintf("Quarantining resource %s after %d consecutive reconcile failures, its spec is not sent to the agent until it is released",
+			resource.ID, failures))
+	}
+	if err := s.resourceDao.UpdateQuarantine(ctx, resource.ID, failures, quarantinedAt); err != nil {
+		return handleUpdateError("Resource", err)
+	}
+	resource.ReconcileFailures = failures
+	resource.QuarantinedAt = quarantinedAt
+	return nil
+}
+
+// MarkDispatched records the instance that dispatched the resource status and the dispatch time.
+func (s *sqlResourceService) MarkDispatched(ctx context.Context, id, instanceID string) *errors.ServiceError {
+	if err := s.resourceDao.MarkDispatched(ctx, id, instanceID, time.Now()); err != nil {
+		return handleUpdateError("Resource", err)
+	}
+	return nil
+}
+
 // MarkAsDeleting marks the resource as deleting by setting the delete_at timestamp.
 // The Resource Deletion Flow:
 // 1. User requests deletion
@@ -249,29 +626,106 @@ func (s *sqlResourceService) UpdateStatus(ctx context.Context, resource *api.Res
 // 3. Maestro handles delete event and sends CloudEvent to work-agent
 // 4. Work-agent deletes resource, sends CloudEvent back to Maestro
 // 5. Maestro hard deletes resource from DB
+// Until the work-agent confirms the deletion in step 4, the resource stays pending deletion, see CountPendingDeletion.
+// MarkAsDeleting is idempotent, it succeeds if the resource is already deleting or already deleted, use
+// MarkAsDeletingWithResult to tell these cases apart.
 func (s *sqlResourceService) MarkAsDeleting(ctx context.Context, id string) *errors.ServiceError {
+	_, err := s.MarkAsDeletingWithResult(ctx, id)
+	return err
+}
+
+// MarkAsDeletingWithResult marks the resource as deleting and returns whether the resource is marked by this
+// request, was already deleting, or is not found.
+func (s *sqlResourceService) MarkAsDeletingWithResult(ctx context.Context, id string) (DeletionResult, *errors.ServiceError) {
 	// If there are multiple requests to write the resource at the same time, it will cause the race conditions among these
 	// requests (read–modify–write), the advisory lock is used here to prevent the race conditions.
 	lockOwnerID, err := s.lockFactory.NewAdvisoryLock(ctx, id, db.Resources)
 	// Ensure that the transaction related to this lock always end.
 	defer s.lockFactory.Unlock(ctx, lockOwnerID)
 	if err != nil {
-		return errors.DatabaseAdvisoryLock(err)
+		return "", errors.DatabaseAdvisoryLock(err)
 	}
 
-	if err := s.resourceDao.Delete(ctx, id, false); err != nil {
-		return handleDeleteError("Resource", errors.GeneralError("Unable to delete resource: %s", err))
+	found, err := s.resourceDao.Get(ctx, id)
+	if err != nil {
+		if svcErr := handleGetError("Resource", "id", id, err); !svcErr.Is404() {
+			return "", svcErr
+		}
+		return DeletionNotFound, nil
 	}
 
-	if _, err := s.events.Create(ctx, &api.Event{
-		Source:    "Resources",
-		SourceID:  id,
-		EventType: api.DeleteEventType,
-	}); err != nil {
-		return handleDeleteError("Resource", err)
+	if !found.DeletedAt.Time.IsZero() {
+		// the delete event has been created when the resource was marked as deleting
+		return DeletionAlreadyDeleting, nil
 	}
 
-	return nil
+	if svcErr := s.transact(ctx, func(ctx context.Context) *errors.ServiceError {
+		// the resources created before the delete option is resolved at create are resolved here, so their deletion
+		// carries the resolved delete option too
+		changed, svcErr := s.resolveDeleteOption(ctx, found)
+		if svcErr != nil {
+			if !svcErr.IsValidation() {
+				return svcErr
+			}
+			// the deletion is not blocked by an undecodable payload, the payload is kept as it is
+			logger.NewOCMLogger(ctx).Warning(fmt.Sprintf("Unable to resolve the delete option of resource %s: %s", found.ID, svcErr))
+		} else if changed {
+			if _, err := s.resourceDao.Update(ctx, found); err != nil {
+				return handleUpdateError("Resource", err)
+			}
+		}
+
+		if err := s.resourceDao.Delete(ctx, id, false); err != nil {
+			return handleDeleteError("Resource", errors.GeneralError("Unable to delete resource: %s", err))
+		}
+
+		if _, err := s.events.Create(ctx, &api.Event{
+			Source:    "Resources",
+			SourceID:  id,
+			EventType: api.DeleteEventType,
+		}); err != nil {
+			return handleDeleteError("Resource", err)
+		}
+		return nil
+	}); svcErr != nil {
+		return "", svcErr
+	}
+
+	resourceDeletesCountMetric.With(resourceChurnLabels(found)).Inc()
+	countConsumerChurn(found.ConsumerName, "delete")
+
+	return DeletionMarked, nil
+}
+
+// resolveDeleteOption writes the delete option resolved with the default delete propagation policy of the consumer
+// into the payload of the resource that has no delete option of its own, so the agent receives the resolved delete
+// option with the spec of the resource, see api.ResolveDeleteOption. A delete option that was resolved before is
+// resolved again, so the payload follows the changes of the consumer policy. It returns whether the payload is changed.
+func (s *sqlResourceService) resolveDeleteOption(ctx context.Context, resource *api.Resource) (bool, *errors.ServiceError) {
+	if len(resource.Payload) == 0 {
+		return false, nil
+	}
+
+	consumers, err := s.consumerDao.FindByNames(ctx, []string{resource.ConsumerName})
+	if err != nil {
+		return false, handleGetError("Consumer", "name", resource.ConsumerName, err)
+	}
+	consumerPolicy := ""
+	if len(consumers) != 0 {
+		consumerPolicy = consumers[0].DeletePropagationPolicy
+	}
+
+	payload, resolved, err := api.ResolvePayloadDeleteOption(resource.Type, resource.Payload, consumerPolicy,
+		resource.DeleteOptionResolved)
+	if err != nil {
+		return false, errors.Validation("the delete option of the resource cannot be resolved, %v", err)
+	}
+	resource.DeleteOptionResolved = resolved
+	if reflect.DeepEqual(payload, resource.Payload) {
+		return false, nil
+	}
+	resource.Payload = payload
+	return true, nil
 }
 
 func (s *sqlResourceService) Delete(ctx context.Context, id string) *errors.ServiceError {
@@ -279,6 +733,10 @@ func (s *sqlResourceService) Delete(ctx context.Context, id string) *errors.Serv
 		return handleDeleteError("Resource", errors.GeneralError("Unable to delete resource: %s", err))
 	}
 
+	if err := s.resourceRevisionDao.DeleteByResourceID(ctx, id); err != nil {
+		return handleDeleteError("ResourceRevision", errors.GeneralError("Unable to delete resource revisions: %s", err))
+	}
+
 	return nil
 }
 
@@ -298,6 +756,542 @@ func (s *sqlResourceService) FindBySource(ctx context.Context, source string) (a
 	return resources, nil
 }
 
+func (s *sqlResourceService) FindActiveByConsumerName(ctx context.Context, consumerName string, resourceType api.ResourceType, afterID string, limit int) (api.ResourceList, *errors.ServiceError) {
+	resources, err := s.resourceDao.FindActiveByConsumerName(ctx, consumerName, resourceType, afterID, limit)
+	if err != nil {
+		return nil, errors.GeneralError("Unable to get resources of consumer %s: %s", consumerName, err)
+	}
+	return resources, nil
+}
+
+// FindOrphaned returns the resources that are not marked as deleting and whose consumer doesn't exist, the consumers
+// with resources can't be deleted by the consumer service, so these resources are left by the consumers that are
+// deleted by bypassing it, e.g. from the database directly.
+func (s *sqlResourceService) FindOrphaned(ctx context.Context, resourceType api.ResourceType, args *ListArguments) (api.ResourceList, *api.PagingMeta, *errors.ServiceError) {
+	if args.Page < 1 {
+		return nil, nil, errors.BadRequest("invalid page %d, the page starts from 1", args.Page)
+	}
+	resources, total, err := s.resourceDao.FindOrphaned(ctx, resourceType, args.Page, args.Size)
+	if err != nil {
+		return nil, nil, errors.GeneralError("Unable to get orphaned resources: %s", err)
+	}
+	return resources, &api.PagingMeta{Page: args.Page, Size: int64(len(resources)), Total: total}, nil
+}
+
+func (s *sqlResourceService) CountVersionDrift(ctx context.Context, updatedBefore time.Time) (map[api.VersionDriftState]int, *errors.ServiceError) {
+	counts, err := s.resourceDao.CountVersionDrift(ctx, updatedBefore)
+	if err != nil {
+		return nil, errors.GeneralError("Unable to count version drift resources: %s", err)
+	}
+	return counts, nil
+}
+
+func (s *sqlResourceService) CountByConsumer(ctx context.Context) (map[string]int, *errors.ServiceError) {
+	counts, err := s.resourceDao.CountByConsumer(ctx)
+	if err != nil {
+		return nil, errors.GeneralError("Unable to count resources by consumer: %s", err)
+	}
+	return counts, nil
+}
+
+func (s *sqlResourceService) CountPendingDeletion(ctx context.Context, deletedBefore time.Time) (map[api.ResourceType]map[string]int, *errors.ServiceError) {
+	counts, err := s.resourceDao.CountDeletingByConsumer(ctx, deletedBefore)
+	if err != nil {
+		return nil, errors.GeneralError("Unable to count pending deletion resources: %s", err)
+	}
+	return counts, nil
+}
+
+func (s *sqlResourceService) ListRevisions(ctx context.Context, id string, args *ListArguments) (api.ResourceRevisionList, *api.PagingMeta, *errors.ServiceError) {
+	if _, err := s.resourceDao.Get(ctx, id); err != nil {
+		return nil, nil, handleGetError("Resource", "id", id, err)
+	}
+
+	search := fmt.Sprintf("resource_id='%s'", id)
+	if args.Search != "" {
+		search = fmt.Sprintf("%s and (%s)", search, args.Search)
+	}
+	args.Search = search
+	if len(args.OrderBy) == 0 {
+		args.OrderBy = []string{"version desc"}
+	}
+	var revisions []api.ResourceRevision
+	paging, serviceErr := s.generic.List(ctx, "username", args, &revisions)
+	if serviceErr != nil {
+		return nil, nil, serviceErr
+	}
+	revisionList := make(api.ResourceRevisionList, 0, len(revisions))
+	for i := range revisions {
+		revisionList = append(revisionList, &revisions[i])
+	}
+	return revisionList, paging, nil
+}
+
+// Revert reverts the resource manifest to the given revision, a new resource version is created with the manifest
+// of the revision.
+func (s *sqlResourceService) Revert(ctx context.Context, id string, version int64) (*api.Resource, *errors.ServiceError) {
+	found, err := s.resourceDao.Get(ctx, id)
+	if err != nil {
+		return nil, handleGetError("Resource", "id", id, err)
+	}
+
+	revision, err := s.resourceRevisionDao.Get(ctx, id, version)
+	if err != nil {
+		return nil, handleGetError("ResourceRevision", "version", version, err)
+	}
+
+	// the delete option of the revision is resolved again if the delete option of the resource is resolved, so the
+	// revert follows the current consumer policy
+	return s.Update(ctx, &api.Resource{
+		Meta:                 api.Meta{ID: found.ID},
+		Version:              found.Version,
+		ConsumerName:         found.ConsumerName,
+		Type:                 found.Type,
+		Payload:              revision.Payload,
+		DeleteOptionResolved: found.DeleteOptionResolved,
+	})
+}
+
+// Reconcile forces the agent to reconcile the resource, the resource version is increased and the resource spec is
+// re-broadcast without modifying its manifest. The new version is recorded as a revision, so the observed version of the
+// resource status keeps tracking the latest resource version.
+func (s *sqlResourceService) Reconcile(ctx context.Context, id string) (*api.Resource, *errors.ServiceError) {
+	lockOwnerID, err := s.lockFactory.NewAdvisoryLock(ctx, id, db.Resources)
+	// Ensure that the transaction related to this lock always end.
+	defer s.lockFactory.Unlock(ctx, lockOwnerID)
+	if err != nil {
+		return nil, errors.DatabaseAdvisoryLock(err)
+	}
+
+	found, err := s.resourceDao.Get(ctx, id)
+	if err != nil {
+		return nil, handleGetError("Resource", "id", id, err)
+	}
+
+	if !found.DeletedAt.Time.IsZero() {
+		return nil, errors.Conflict("the resource is under deletion, id: %s", id)
+	}
+
+	if err := increaseResourceVersion(found); err != nil {
+		return nil, err
+	}
+	updated, svcErr := s.updateWithEvent(ctx, found)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	resourceProcessedCountMetric.With(prometheus.Labels{
+		metricsIDLabel:     updated.ID,
+		metricsActionLabel: "reconcile",
+	}).Inc()
+
+	return updated, nil
+}
+
+func (s *sqlResourceService) ReleaseQuarantine(ctx context.Context, id string) (*api.Resource, *errors.ServiceError) {
+	// the quarantine is tracked with the status updates, so the status lock is used to release it
+	lockOwnerID, err := s.lockFactory.NewAdvisoryLock(ctx, id, db.ResourceStatus)
+	// Ensure that the transaction related to this lock always end.
+	defer s.lockFactory.Unlock(ctx, lockOwnerID)
+	if err != nil {
+		return nil, errors.DatabaseAdvisoryLock(err)
+	}
+	// the resource is reconciled with its spec, so the spec lock is held as well
+	specLockOwnerID, err := s.lockFactory.NewAdvisoryLock(ctx, id, db.Resources)
+	defer s.lockFactory.Unlock(ctx, specLockOwnerID)
+	if err != nil {
+		return nil, errors.DatabaseAdvisoryLock(err)
+	}
+
+	found, err := s.resourceDao.Get(ctx, id)
+	if err != nil {
+		return nil, handleGetError("Resource", "id", id, err)
+	}
+
+	if !found.IsQuarantined() {
+		return nil, errors.Conflict("the resource is not quarantined, id: %s", id)
+	}
+
+	if !found.DeletedAt.Time.IsZero() {
+		return nil, errors.Conflict("the resource is under deletion, id: %s", id)
+	}
+
+	// the spec changes of the quarantined resource were not sent, re-broadcast the resource with a new version, the
+	// quarantine is cleared in the same transaction, so a failed reconcile keeps the resource quarantined.
+	if err := increaseResourceVersion(found); err != nil {
+		return nil, err
+	}
+	found.ReconcileFailures = 0
+	found.QuarantinedAt = nil
+
+	var updated *api.Resource
+	if svcErr := s.transact(ctx, func(ctx context.Context) *errors.ServiceError {
+		if err := s.resourceDao.UpdateQuarantine(ctx, id, 0, nil); err != nil {
+			return handleUpdateError("Resource", err)
+		}
+		var svcErr *errors.ServiceError
+		updated, svcErr = s.updateWithEvent(ctx, found)
+		return svcErr
+	}); svcErr != nil {
+		return nil, svcErr
+	}
+
+	resourceProcessedCountMetric.With(prometheus.Labels{
+		metricsIDLabel:     updated.ID,
+		metricsActionLabel: "reconcile",
+	}).Inc()
+
+	return updated, nil
+}
+
+func (s *sqlResourceService) CountQuarantined(ctx context.Context) (map[api.ResourceType]int, *errors.ServiceError) {
+	counts, err := s.resourceDao.CountQuarantined(ctx)
+	if err != nil {
+		return nil, errors.GeneralError("Unable to count quarantined resources: %s", err)
+	}
+	return counts, nil
+}
+
+func (s *sqlResourceService) MarkReconcileStale(ctx context.Context, specUpdatedBefore time.Time) (int64, *errors.ServiceError) {
+	marked, err := s.resourceDao.MarkReconcileStale(ctx, specUpdatedBefore)
+	if err != nil {
+		return 0, errors.GeneralError("Unable to mark reconcile stale resources: %s", err)
+	}
+	return marked, nil
+}
+
+func (s *sqlResourceService) DeleteUnreferencedPayloads(ctx context.Context, modifiedBefore time.Time) (int, *errors.ServiceError) {
+	deleted, err := s.resourceDao.DeleteUnreferencedPayloads(ctx, modifiedBefore)
+	if err != nil {
+		return deleted, errors.GeneralError("Unable to delete unreferenced resource payloads: %s", err)
+	}
+	return deleted, nil
+}
+
+func (s *sqlResourceService) SyncStatusFeedback(ctx context.Context, id string) (*api.Resource, bool, *errors.ServiceError) {
+	lockOwnerID, err := s.lockFactory.NewAdvisoryLock(ctx, id, db.Resources)
+	// Ensure that the transaction related to this lock always end.
+	defer s.lockFactory.Unlock(ctx, lockOwnerID)
+	if err != nil {
+		return nil, false, errors.DatabaseAdvisoryLock(err)
+	}
+
+	found, err := s.resourceDao.Get(ctx, id)
+	if err != nil {
+		return nil, false, handleGetError("Resource", "id", id, err)
+	}
+
+	if !found.DeletedAt.Time.IsZero() {
+		return found, false, nil
+	}
+
+	payload, changed, err := api.UpdateStatusFeedback(found.Type, found.Payload, api.IsReady(found.Conditions))
+	if err != nil {
+		return nil, false, errors.GeneralError("Unable to update the status feedback of the resource: %s", err)
+	}
+	if !changed {
+		return found, false, nil
+	}
+
+	if err := increaseResourceVersion(found); err != nil {
+		return nil, false, err
+	}
+	found.Payload = payload
+	updated, svcErr := s.updateWithEvent(ctx, found)
+	if svcErr != nil {
+		return nil, false, svcErr
+	}
+
+	resourceProcessedCountMetric.With(prometheus.Labels{
+		metricsIDLabel:     updated.ID,
+		metricsActionLabel: "status_feedback",
+	}).Inc()
+
+	return updated, true, nil
+}
+
+// ReconcileByConsumer reconciles the resources of the consumer page by page, the resources that are marked as deleting
+// in the meantime are skipped.
+func (s *sqlResourceService) ReconcileByConsumer(ctx context.Context, consumerName string) (int, *errors.ServiceError) {
+	reconciled := 0
+	afterID := ""
+	for {
+		resources, svcErr := s.FindActiveByConsumerName(ctx, consumerName, "", afterID, reconcilePageSize)
+		if svcErr != nil {
+			return reconciled, svcErr
+		}
+
+		for _, resource := range resources {
+			if _, svcErr := s.Reconcile(ctx, resource.ID); svcErr != nil {
+				if svcErr.Is404() || svcErr.IsConflict() {
+					continue
+				}
+				return reconciled, svcErr
+			}
+			reconciled++
+		}
+
+		if len(resources) < reconcilePageSize {
+			return reconciled, nil
+		}
+		afterID = resources[len(resources)-1].ID
+	}
+}
+
+// ResolveDeleteOptionByConsumer resolves the delete option of the resources of the consumer page by page, the
+// resources that are marked as deleting in the meantime are skipped.
+func (s *sqlResourceService) ResolveDeleteOptionByConsumer(ctx context.Context, consumerName string) (int, *errors.ServiceError) {
+	changed := 0
+	afterID := ""
+	for {
+		resources, svcErr := s.FindActiveByConsumerName(ctx, consumerName, "", afterID, reconcilePageSize)
+		if svcErr != nil {
+			return changed, svcErr
+		}
+
+		for _, resource := range resources {
+			resolved, svcErr := s.resolveDeleteOptionByID(ctx, resource.ID)
+			if svcErr != nil {
+				if svcErr.Is404() {
+					continue
+				}
+				return changed, svcErr
+			}
+			if resolved {
+				changed++
+			}
+		}
+
+		if len(resources) < reconcilePageSize {
+			return changed, nil
+		}
+		afterID = resources[len(resources)-1].ID
+	}
+}
+
+// resolveDeleteOptionByID resolves the delete option of the resource again, the resource version is increased only if
+// the delete option is changed, so the resource is re-broadcast to the agent with the new delete option.
+func (s *sqlResourceService) resolveDeleteOptionByID(ctx context.Context, id string) (bool, *errors.ServiceError) {
+	lockOwnerID, err := s.lockFactory.NewAdvisoryLock(ctx, id, db.Resources)
+	// Ensure that the transaction related to this lock always end.
+	defer s.lockFactory.Unlock(ctx, lockOwnerID)
+	if err != nil {
+		return false, errors.DatabaseAdvisoryLock(err)
+	}
+
+	found, err := s.resourceDao.Get(ctx, id)
+	if err != nil {
+		return false, handleGetError("Resource", "id", id, err)
+	}
+
+	if !found.DeletedAt.Time.IsZero() {
+		return false, nil
+	}
+
+	changed, svcErr := s.resolveDeleteOption(ctx, found)
+	if svcErr != nil || !changed {
+		return false, svcErr
+	}
+
+	if err := increaseResourceVersion(found); err != nil {
+		return false, err
+	}
+	if _, svcErr := s.updateWithEvent(ctx, found); svcErr != nil {
+		return false, svcErr
+	}
+	return true, nil
+}
+
+// MarkAsDeletingByConsumer collects the resources of the consumer page by page before marking them, so the resources
+// are only marked if the confirmation matches. The resources that are marked as deleting in the meantime are skipped.
+func (s *sqlResourceService) MarkAsDeletingByConsumer(ctx context.Context, consumerName string, confirmation int) (int, *errors.ServiceError) {
+	consumers, err := s.consumerDao.FindByNames(ctx, []string{consumerName})
+	if err != nil {
+		return 0, handleGetError("Consumer", "name", consumerName, err)
+	}
+	if len(consumers) == 0 {
+		return 0, errors.NotFound("Consumer with name='%s' not found", consumerName)
+	}
+
+	ids := []string{}
+	afterID := ""
+	for {
+		resources, svcErr := s.FindActiveByConsumerName(ctx, consumerName, "", afterID, reconcilePageSize)
+		if svcErr != nil {
+			return 0, svcErr
+		}
+		for _, resource := range resources {
+			ids = append(ids, resource.ID)
+		}
+		if len(resources) < reconcilePageSize {
+			break
+		}
+		afterID = resources[len(resources)-1].ID
+	}
+
+	if confirmation != len(ids) {
+		return 0, errors.Conflict("the confirmation %d does not match the %d resources of the consumer %s",
+			confirmation, len(ids), consumerName)
+	}
+
+	marked := 0
+	for _, id := range ids {
+		result, svcErr := s.MarkAsDeletingWithResult(ctx, id)
+		if svcErr != nil {
+			return marked, svcErr
+		}
+		if result == DeletionMarked {
+			marked++
+		}
+	}
+	return marked, nil
+}
+
+// TransferOwnership reassigns the source of the resource, the resource version is not changed, so the resource is not
+// re-broadcast to the agent. The status of the resource is broadcast to the new source from now on, the new source can
+// resync the status to get the current status.
+func (s *sqlResourceService) TransferOwnership(ctx context.Context, id, source, transferredBy string) (*api.ResourceOwnershipTransfer, *errors.ServiceError) {
+	if len(source) == 0 {
+		return nil, errors.Validation("the source of the resource is required")
+	}
+
+	lockOwnerID, err := s.lockFactory.NewAdvisoryLock(ctx, id, db.Resources)
+	// Ensure that the transaction related to this lock always end.
+	defer s.lockFactory.Unlock(ctx, lockOwnerID)
+	if err != nil {
+		return nil, errors.DatabaseAdvisoryLock(err)
+	}
+
+	found, err := s.resourceDao.Get(ctx, id)
+	if err != nil {
+		return nil, handleGetError("Resource", "id", id, err)
+	}
+
+	if !found.DeletedAt.Time.IsZero() {
+		return nil, errors.Conflict("the resource is under deletion, id: %s", id)
+	}
+
+	if found.Source == source {
+		return nil, errors.Conflict("the resource %s already belongs to the source %s", id, source)
+	}
+
+	transfer := &api.ResourceOwnershipTransfer{
+		ResourceID:    id,
+		FromSource:    found.Source,
+		ToSource:      source,
+		TransferredBy: transferredBy,
+	}
+	// the source is reassigned with its audit record, so a transfer is never left unrecorded
+	if svcErr := s.transact(ctx, func(ctx context.Context) *errors.ServiceError {
+		if err := s.resourceDao.UpdateSource(ctx, id, source); err != nil {
+			return handleUpdateError("Resource", err)
+		}
+
+		var err error
+		if transfer, err = s.ownershipTransferDao.Create(ctx, transfer); err != nil {
+			return handleCreateError("ResourceOwnershipTransfer", err)
+		}
+		return nil
+	}); svcErr != nil {
+		return nil, svcErr
+	}
+
+	resourceProcessedCountMetric.With(prometheus.Labels{
+		metricsIDLabel:     id,
+		metricsActionLabel: "transfer",
+	}).Inc()
+
+	return transfer, nil
+}
+
+func (s *sqlResourceService) ListOwnershipTransfers(ctx context.Context, id string, args *ListArguments) (api.ResourceOwnershipTransferList, *api.PagingMeta, *errors.ServiceError) {
+	if _, err := s.resourceDao.Get(ctx, id); err != nil {
+		return nil, nil, handleGetError("Resource", "id", id, err)
+	}
+
+	if args.Page < 1 {
+		return nil, nil, errors.BadRequest("invalid page %d, the page starts from 1", args.Page)
+	}
+	transfers, total, err := s.ownershipTransferDao.FindByResourceID(ctx, id, args.Page, args.Size)
+	if err != nil {
+		return nil, nil, errors.GeneralError("Unable to get resource ownership transfers: %s", err)
+	}
+	return transfers, &api.PagingMeta{Page: args.Page, Size: int64(len(transfers)), Total: total}, nil
+}
+
+// increaseResourceVersion increases the resource version and records the spec change, it rejects the resource that
+// reached the max version the agents support rather than sending a truncated version to the agent.
+func increaseResourceVersion(resource *api.Resource) *errors.ServiceError {
+	if resource.Version >= api.MaxResourceVersion {
+		return errors.Conflict("the resource %s reached the max version %d that the agents support",
+			resource.ID, api.MaxResourceVersion)
+	}
+	resource.Version = resource.Version + 1
+	now := time.Now()
+	resource.SpecUpdatedAt = &now
+	return nil
+}
+
+// transact runs fn in a database transaction, so the resource changes are committed with their events, the events
+// table is the outbox of the resource dispatches. The transaction is rolled back if fn returns an error.
+func (s *sqlResourceService) transact(ctx context.Context, fn func(ctx context.Context) *errors.ServiceError) *errors.ServiceError {
+	var svcErr *errors.ServiceError
+	if err := s.transactor.Transact(ctx, func(ctx context.Context) error {
+		if svcErr = fn(ctx); svcErr != nil {
+			return svcErr
+		}
+		return nil
+	}); err != nil && svcErr == nil {
+		return errors.GeneralError("Unable to commit the resource changes: %s", err)
+	}
+	return svcErr
+}
+
+// updateWithEvent updates the resource, records its revision and its update event in a transaction.
+func (s *sqlResourceService) updateWithEvent(ctx context.Context, resource *api.Resource) (*api.Resource, *errors.ServiceError) {
+	var updated *api.Resource
+	if svcErr := s.transact(ctx, func(ctx context.Context) *errors.ServiceError {
+		var err error
+		if updated, err = s.resourceDao.Update(ctx, resource); err != nil {
+			return handleUpdateError("Resource", err)
+		}
+
+		if err := s.createRevision(ctx, updated); err != nil {
+			return handleCreateError("ResourceRevision", err)
+		}
+
+		if _, err := s.events.Create(ctx, &api.Event{
+			Source:    "Resources",
+			SourceID:  updated.ID,
+			EventType: api.UpdateEventType,
+		}); err != nil {
+			return handleUpdateError("Resource", err)
+		}
+		return nil
+	}); svcErr != nil {
+		return nil, svcErr
+	}
+	return updated, nil
+}
+
+// createRevision captures the manifest of the resource at its current version and prunes the revisions beyond the
+// revision limit.
+func (s *sqlResourceService) createRevision(ctx context.Context, resource *api.Resource) error {
+	if s.revisionLimit <= 0 {
+		return nil
+	}
+
+	if _, err := s.resourceRevisionDao.Create(ctx, &api.ResourceRevision{
+		ResourceID: resource.ID,
+		Version:    resource.Version,
+		Payload:    resource.Payload,
+	}); err != nil {
+		return err
+	}
+
+	return s.resourceRevisionDao.DeleteBeforeVersion(ctx, resource.ID, resource.Version-int64(s.revisionLimit)+1)
+}
+
 func (s *sqlResourceService) All(ctx context.Context) (api.ResourceList, *errors.ServiceError) {
 	resources, err := s.resourceDao.All(ctx)
 	if err != nil {
@@ -326,6 +1320,8 @@ func (s *sqlResourceService) List(listOpts cetypes.ListOptions) ([]*api.Resource
 	if err != nil {
 		return nil, err
 	}
+	// the resources are resynced to the agent in their dispatch priority order
+	api.SortByPriority(resourceList)
 	return resourceList, nil
 }
 
@@ -336,6 +1332,15 @@ func (s *sqlResourceService) ListWithArgs(ctx context.Context, username string,
 		return nil, serviceErr
 	}
 
+	// the resources are listed by the generic service, fetch their offloaded payloads back
+	listed := make(api.ResourceList, 0, len(*resources))
+	for i := range *resources {
+		listed = append(listed, &(*resources)[i])
+	}
+	if err := s.resourceDao.LoadPayloads(ctx, listed); err != nil {
+		return nil, errors.GeneralError("Unable to load resource payload: %s", err)
+	}
+
 	for _, resource := range *resources {
 		// sync the creationTimestamp and deletionTimestamp from resource meta to work metadata
 		s.syncTimestampsFromResourceMeta(&resource)
@@ -364,10 +1369,24 @@ const metricsSubsystem = "resource"
 
 // Names of the labels added to metrics:
 const (
-	metricsIDLabel     = "id"
-	metricsActionLabel = "action"
+	metricsIDLabel       = "id"
+	metricsActionLabel   = "action"
+	metricsTypeLabel     = "type"
+	metricsConsumerLabel = "consumer"
+	metricsSourceLabel   = "source"
+	metricsStateLabel    = "state"
+	// metricsUpdateStrategyLabel is the effective update strategy of the resource, its values are bounded by
+	// api.EffectiveUpdateStrategy.
+	metricsUpdateStrategyLabel = "update_strategy"
 )
 
+// churnMetricsLabels - Array of labels added to the resource churn metrics:
+var churnMetricsLabels = []string{
+	metricsConsumerLabel,
+	metricsSourceLabel,
+	metricsUpdateStrategyLabel,
+}
+
 // metricsLabels - Array of labels added to metrics:
 var metricsLabels = []string{
 	metricsIDLabel,
@@ -376,22 +1395,130 @@ var metricsLabels = []string{
 
 // Names of the metrics:
 const (
-	processedCountMetric = "processed_total"
+	processedCountMetric   = "processed_total"
+	pendingDeletionMetric  = "pending_deletion"
+	versionDriftMetric     = "version_drift"
+	quarantinedMetric      = "quarantined"
+	staleStatusCountMetric = "stale_status_total"
+	createsCountMetric     = "creates_total"
+	updatesCountMetric     = "updates_total"
+	deletesCountMetric     = "deletes_total"
+	statusNoopCountMetric  = "status_noop_total"
 )
 
+// The resource churn metrics are exposed with the maestro namespace, e.g. maestro_resource_creates_total.
+const churnMetricsNamespace = "maestro"
+
+// maxChurnMetricsSources is the max number of the distinct sources in the resource churn metrics, the sources are
+// set by the clients, so the resources of the sources beyond the limit are counted with the overflow source to keep
+// the metrics cardinality bounded.
+const maxChurnMetricsSources = 100
+
+// churnMetricsOverflowSource is the source label value of the sources beyond maxChurnMetricsSources.
+const churnMetricsOverflowSource = "other"
+
+var churnMetricsSources = &boundedLabelValues{limit: maxChurnMetricsSources, values: map[string]struct{}{}}
+
+// boundedLabelValues tracks the values of a metrics label and caps the number of the distinct values.
+type boundedLabelValues struct {
+	mu     sync.Mutex
+	limit  int
+	values map[string]struct{}
+}
+
+// value returns the given value if it is tracked or the limit is not reached, otherwise returns the overflow value.
+func (b *boundedLabelValues) value(v string) string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.values[v]; ok {
+		return v
+	}
+	if len(b.values) >= b.limit {
+		return churnMetricsOverflowSource
+	}
+	b.values[v] = struct{}{}
+	return v
+}
+
+func (b *boundedLabelValues) reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.values = map[string]struct{}{}
+}
+
+// resourceChurnLabels returns the labels of the resource churn metrics for the given resource.
+func resourceChurnLabels(resource *api.Resource) prometheus.Labels {
+	return prometheus.Labels{
+		metricsConsumerLabel:       resource.ConsumerName,
+		metricsSourceLabel:         churnMetricsSources.value(resource.Source),
+		metricsUpdateStrategyLabel: updateStrategyLabel(resource),
+	}
+}
+
+// updateStrategyLabel returns the update strategy label value of the resource, the update strategy is derived from
+// the payload if it is not recorded yet, e.g. the resource is created before the update strategy is recorded.
+func updateStrategyLabel(resource *api.Resource) string {
+	if resource.UpdateStrategy != "" {
+		return resource.UpdateStrategy
+	}
+	return api.EffectiveUpdateStrategy(resource.Type, resource.Payload)
+}
+
 // Register the metrics:
 func RegisterResourceMetrics() {
 	prometheus.MustRegister(resourceProcessedCountMetric)
+	prometheus.MustRegister(resourcePendingDeletionMetric)
+	prometheus.MustRegister(resourceVersionDriftMetric)
+	prometheus.MustRegister(resourceQuarantinedMetric)
+	prometheus.MustRegister(resourceStaleStatusCountMetric)
+	prometheus.MustRegister(resourceCreatesCountMetric)
+	prometheus.MustRegister(resourceUpdatesCountMetric)
+	prometheus.MustRegister(resourceDeletesCountMetric)
+	prometheus.MustRegister(resourceStatusNoopCountMetric)
 }
 
 // Unregister the metrics:
 func UnregisterResourceMetrics() {
 	prometheus.Unregister(resourceProcessedCountMetric)
+	prometheus.Unregister(resourcePendingDeletionMetric)
+	prometheus.Unregister(resourceVersionDriftMetric)
+	prometheus.Unregister(resourceQuarantinedMetric)
+	prometheus.Unregister(resourceStaleStatusCountMetric)
+	prometheus.Unregister(resourceCreatesCountMetric)
+	prometheus.Unregister(resourceUpdatesCountMetric)
+	prometheus.Unregister(resourceDeletesCountMetric)
+	prometheus.Unregister(resourceStatusNoopCountMetric)
 }
 
 // Reset the metrics:
 func ResetResourceMetrics() {
 	resourceProcessedCountMetric.Reset()
+	resourcePendingDeletionMetric.Reset()
+	resourceVersionDriftMetric.Reset()
+	resourceQuarantinedMetric.Reset()
+	resourceStaleStatusCountMetric.Reset()
+	resourceCreatesCountMetric.Reset()
+	resourceUpdatesCountMetric.Reset()
+	resourceDeletesCountMetric.Reset()
+	resourceStatusNoopCountMetric.Reset()
+	churnMetricsSources.reset()
+}
+
+// SetResourcePendingDeletionMetric sets the number of resources awaiting the deletion confirmation from the work-agent.
+func SetResourcePendingDeletionMetric(resourceType api.ResourceType, count int) {
+	resourcePendingDeletionMetric.WithLabelValues(string(resourceType)).Set(float64(count))
+}
+
+// SetResourceVersionDriftMetric sets the number of resources whose observed version drifts from the resource version.
+func SetResourceVersionDriftMetric(state api.VersionDriftState, count int) {
+	resourceVersionDriftMetric.WithLabelValues(string(state)).Set(float64(count))
+}
+
+// SetResourceQuarantinedMetric sets the number of resources that are quarantined.
+func SetResourceQuarantinedMetric(resourceType api.ResourceType, count int) {
+	resourceQuarantinedMetric.WithLabelValues(string(resourceType)).Set(float64(count))
 }
 
 // Description of the resource process count metric:
@@ -403,3 +1530,86 @@ var resourceProcessedCountMetric = prometheus.NewCounterVec(
 	},
 	metricsLabels,
 )
+
+// Description of the resource pending deletion metric:
+var resourcePendingDeletionMetric = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Subsystem: metricsSubsystem,
+		Name:      pendingDeletionMetric,
+		Help:      "Number of resources marked as deleting and awaiting the deletion confirmation from the agent.",
+	},
+	[]string{metricsTypeLabel},
+)
+
+// Description of the resource version drift metric:
+var resourceVersionDriftMetric = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Subsystem: metricsSubsystem,
+		Name:      versionDriftMetric,
+		Help:      "Number of resources whose version observed by the agent is unreported, lagging or ahead of the resource version.",
+	},
+	[]string{metricsStateLabel},
+)
+
+// Description of the resource quarantined metric:
+var resourceQuarantinedMetric = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Subsystem: metricsSubsystem,
+		Name:      quarantinedMetric,
+		Help:      "Number of resources quarantined after their consecutive reconcile failures, their specs are not sent to the agents until they are released.",
+	},
+	[]string{metricsTypeLabel},
+)
+
+// Description of the resource stale status count metric:
+var resourceStaleStatusCountMetric = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Subsystem: metricsSubsystem,
+		Name:      staleStatusCountMetric,
+		Help:      "Number of resource status updates dropped because they are older than the stored status.",
+	},
+	[]string{metricsTypeLabel},
+)
+
+// Description of the status noop count metric:
+var resourceStatusNoopCountMetric = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: churnMetricsNamespace,
+		Name:      statusNoopCountMetric,
+		Help:      "Number of resource status updates skipped because the status is not changed.",
+	},
+	[]string{metricsTypeLabel},
+)
+
+// Description of the resource creates count metric:
+var resourceCreatesCountMetric = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: churnMetricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      createsCountMetric,
+		Help:      "Number of created resources.",
+	},
+	churnMetricsLabels,
+)
+
+// Description of the resource updates count metric:
+var resourceUpdatesCountMetric = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: churnMetricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      updatesCountMetric,
+		Help:      "Number of resource manifest updates.",
+	},
+	churnMetricsLabels,
+)
+
+// Description of the resource deletes count metric:
+var resourceDeletesCountMetric = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: churnMetricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      deletesCountMetric,
+		Help:      "Number of resources marked as deleting.",
+	},
+	churnMetricsLabels,
+)
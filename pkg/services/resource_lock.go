@@ -0,0 +1,186 @@
+package services
+
+import (
+	"context"
+	e "errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/dao"
+	"github.com/openshift-online/maestro/pkg/db"
+	"github.com/openshift-online/maestro/pkg/errors"
+)
+
+// ResourceLockService manages the advisory soft-locks of the resources. A lock is held by an owner until it is
+// released or expired, the expired locks are ignored and can be pruned by DeleteExpired. The locks are only checked
+// by the REST API, the resources published by the sources through the gRPC server are not checked against them.
+type ResourceLockService interface {
+	// Get returns the active lock of the resource, an expired lock is not found.
+	Get(ctx context.Context, resourceID string) (*api.ResourceLock, *errors.ServiceError)
+	// Acquire locks the resource for the owner within the ttl, the lock held by the owner is renewed. If the ttl is
+	// 0, the lock expires after the default TTL. It returns Locked if the resource is locked by another owner.
+	Acquire(ctx context.Context, resourceID, owner string, ttl time.Duration) (*api.ResourceLock, *errors.ServiceError)
+	// Release unlocks the resource, releasing an unlocked resource succeeds. It returns Locked if the resource is
+	// locked by another owner unless it is forced.
+	Release(ctx context.Context, resourceID, owner string, force bool) *errors.ServiceError
+	// Check returns Locked if the resource is locked by another owner.
+	Check(ctx context.Context, resourceID, owner string) *errors.ServiceError
+	// DeleteExpired deletes the expired locks and returns the number of the deleted locks.
+	DeleteExpired(ctx context.Context) (int64, *errors.ServiceError)
+}
+
+func NewResourceLockService(lockFactory db.LockFactory, resourceDao dao.ResourceDao, resourceLockDao dao.ResourceLockDao,
+	defaultTTL, maxTTL time.Duration) ResourceLockService {
+	return &sqlResourceLockService{
+		lockFactory:     lockFactory,
+		resourceDao:     resourceDao,
+		resourceLockDao: resourceLockDao,
+		defaultTTL:      defaultTTL,
+		maxTTL:          maxTTL,
+	}
+}
+
+var _ ResourceLockService = &sqlResourceLockService{}
+
+type sqlResourceLockService struct {
+	lockFactory     db.LockFactory
+	resourceDao     dao.ResourceDao
+	resourceLockDao dao.ResourceLockDao
+	defaultTTL      time.Duration
+	maxTTL          time.Duration
+}
+
+func (s *sqlResourceLockService) Get(ctx context.Context, resourceID string) (*api.ResourceLock, *errors.ServiceError) {
+	lock, err := s.activeLock(ctx, resourceID, time.Now())
+	if err != nil {
+		return nil, handleGetError("ResourceLock", "resource_id", resourceID, err)
+	}
+	if lock == nil {
+		return nil, errors.NotFound("ResourceLock with resource_id='%s' not found", resourceID)
+	}
+	return lock, nil
+}
+
+func (s *sqlResourceLockService) Acquire(ctx context.Context, resourceID, owner string, ttl time.Duration) (*api.ResourceLock, *errors.ServiceError) {
+	if len(owner) == 0 {
+		return nil, errors.Validation("the lock owner is required")
+	}
+	if ttl == 0 {
+		ttl = s.defaultTTL
+	}
+	if ttl < 0 || ttl > s.maxTTL {
+		return nil, errors.Validation("the lock ttl %s must be positive and at most %s", ttl, s.maxTTL)
+	}
+
+	// the lock of a resource is acquired under the advisory lock, so the concurrent acquisitions are serialized
+	lockOwnerID, err := s.lockFactory.NewAdvisoryLock(ctx, resourceID, db.ResourceLocks)
+	// Ensure that the transaction related to this lock always end.
+	defer s.lockFactory.Unlock(ctx, lockOwnerID)
+	if err != nil {
+		return nil, errors.DatabaseAdvisoryLock(err)
+	}
+
+	if _, err := s.resourceDao.Get(ctx, resourceID); err != nil {
+		return nil, handleGetError("Resource", "id", resourceID, err)
+	}
+
+	now := time.Now()
+	found, err := s.resourceLockDao.GetByResourceID(ctx, resourceID)
+	if err != nil && !e.Is(err, gorm.ErrRecordNotFound) {
+		return nil, handleGetError("ResourceLock", "resource_id", resourceID, err)
+	}
+
+	if found == nil {
+		lock, err := s.resourceLockDao.Create(ctx, &api.ResourceLock{
+			ResourceID: resourceID,
+			Owner:      owner,
+			AcquiredAt: now,
+			ExpiresAt:  now.Add(ttl),
+		})
+		if err != nil {
+			return nil, handleCreateError("ResourceLock", err)
+		}
+		return lock, nil
+	}
+
+	// the lock held by the owner is renewed, the expired lock is taken over
+	if found.IsExpired(now) {
+		found.Owner = owner
+		found.AcquiredAt = now
+	} else if found.Owner != owner {
+		return nil, lockedError(found)
+	}
+	found.ExpiresAt = now.Add(ttl)
+	lock, err := s.resourceLockDao.Replace(ctx, found)
+	if err != nil {
+		return nil, handleUpdateError("ResourceLock", err)
+	}
+	return lock, nil
+}
+
+func (s *sqlResourceLockService) Release(ctx context.Context, resourceID, owner string, force bool) *errors.ServiceError {
+	lockOwnerID, err := s.lockFactory.NewAdvisoryLock(ctx, resourceID, db.ResourceLocks)
+	// Ensure that the transaction related to this lock always end.
+	defer s.lockFactory.Unlock(ctx, lockOwnerID)
+	if err != nil {
+		return errors.DatabaseAdvisoryLock(err)
+	}
+
+	found, err := s.resourceLockDao.GetByResourceID(ctx, resourceID)
+	if err != nil {
+		if e.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return handleGetError("ResourceLock", "resource_id", resourceID, err)
+	}
+
+	if !force && !found.IsExpired(time.Now()) && found.Owner != owner {
+		return lockedError(found)
+	}
+
+	if err := s.resourceLockDao.Delete(ctx, found.ID); err != nil {
+		return handleDeleteError("ResourceLock", err)
+	}
+	return nil
+}
+
+func (s *sqlResourceLockService) Check(ctx context.Context, resourceID, owner string) *errors.ServiceError {
+	lock, err := s.activeLock(ctx, resourceID, time.Now())
+	if err != nil {
+		return handleGetError("ResourceLock", "resource_id", resourceID, err)
+	}
+	if lock != nil && lock.Owner != owner {
+		return lockedError(lock)
+	}
+	return nil
+}
+
+func (s *sqlResourceLockService) DeleteExpired(ctx context.Context) (int64, *errors.ServiceError) {
+	deleted, err := s.resourceLockDao.DeleteExpired(ctx, time.Now())
+	if err != nil {
+		return 0, handleDeleteError("ResourceLock", err)
+	}
+	return deleted, nil
+}
+
+// activeLock returns the lock of the resource that is not expired at the given time, or nil if there is no such lock.
+func (s *sqlResourceLockService) activeLock(ctx context.Context, resourceID string, now time.Time) (*api.ResourceLock, error) {
+	lock, err := s.resourceLockDao.GetByResourceID(ctx, resourceID)
+	if err != nil {
+		if e.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if lock.IsExpired(now) {
+		return nil, nil
+	}
+	return lock, nil
+}
+
+func lockedError(lock *api.ResourceLock) *errors.ServiceError {
+	return errors.Locked("the resource %s is locked by %s until %s", lock.ResourceID, lock.Owner,
+		lock.ExpiresAt.Format(time.RFC3339))
+}
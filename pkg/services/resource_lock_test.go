@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	gm "github.com/onsi/gomega"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/dao/mocks"
+	dbmocks "github.com/openshift-online/maestro/pkg/db/mocks"
+)
+
+func TestResourceLock(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	ctx := context.Background()
+	resourceDao := mocks.NewResourceDao()
+	_, err := resourceDao.Create(ctx, &api.Resource{Meta: api.Meta{ID: Fukuisaurus}, ConsumerName: "cluster1"})
+	gm.Expect(err).To(gm.BeNil())
+
+	resourceLockDao := mocks.NewResourceLockDao()
+	resourceLockService := NewResourceLockService(dbmocks.NewMockAdvisoryLockFactory(), resourceDao, resourceLockDao,
+		5*time.Minute, time.Hour)
+
+	// lock an unknown resource
+	_, svcErr := resourceLockService.Acquire(ctx, Seismosaurus, "alice", 0)
+	gm.Expect(svcErr).NotTo(gm.BeNil())
+	gm.Expect(svcErr.Is404()).To(gm.BeTrue())
+
+	// lock without an owner or beyond the max ttl
+	_, svcErr = resourceLockService.Acquire(ctx, Fukuisaurus, "", 0)
+	gm.Expect(svcErr.IsValidation()).To(gm.BeTrue())
+	_, svcErr = resourceLockService.Acquire(ctx, Fukuisaurus, "alice", 2*time.Hour)
+	gm.Expect(svcErr.IsValidation()).To(gm.BeTrue())
+
+	// the resource is not locked yet
+	_, svcErr = resourceLockService.Get(ctx, Fukuisaurus)
+	gm.Expect(svcErr.Is404()).To(gm.BeTrue())
+	gm.Expect(resourceLockService.Check(ctx, Fukuisaurus, "bob")).To(gm.BeNil())
+
+	lock, svcErr := resourceLockService.Acquire(ctx, Fukuisaurus, "alice", 0)
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(lock.Owner).To(gm.Equal("alice"))
+	gm.Expect(lock.ExpiresAt.Sub(lock.AcquiredAt)).To(gm.Equal(5 * time.Minute))
+
+	// the lock is renewed by its owner
+	renewed, svcErr := resourceLockService.Acquire(ctx, Fukuisaurus, "alice", 10*time.Minute)
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(renewed.ID).To(gm.Equal(lock.ID))
+	gm.Expect(renewed.ExpiresAt.Sub(renewed.AcquiredAt) >= 10*time.Minute).To(gm.BeTrue())
+
+	// the lock is neither acquired nor released by another owner
+	_, svcErr = resourceLockService.Acquire(ctx, Fukuisaurus, "bob", 0)
+	gm.Expect(svcErr.IsLocked()).To(gm.BeTrue())
+	gm.Expect(resourceLockService.Check(ctx, Fukuisaurus, "bob").IsLocked()).To(gm.BeTrue())
+	gm.Expect(resourceLockService.Check(ctx, Fukuisaurus, "alice")).To(gm.BeNil())
+	gm.Expect(resourceLockService.Release(ctx, Fukuisaurus, "bob", false).IsLocked()).To(gm.BeTrue())
+
+	// the lock is released by force
+	gm.Expect(resourceLockService.Release(ctx, Fukuisaurus, "bob", true)).To(gm.BeNil())
+	_, svcErr = resourceLockService.Get(ctx, Fukuisaurus)
+	gm.Expect(svcErr.Is404()).To(gm.BeTrue())
+	gm.Expect(resourceLockService.Release(ctx, Fukuisaurus, "alice", false)).To(gm.BeNil())
+
+	// the expired lock is ignored and taken over by another owner
+	_, svcErr = resourceLockService.Acquire(ctx, Fukuisaurus, "alice", time.Minute)
+	gm.Expect(svcErr).To(gm.BeNil())
+	expired, err := resourceLockDao.GetByResourceID(ctx, Fukuisaurus)
+	gm.Expect(err).To(gm.BeNil())
+	expired.ExpiresAt = time.Now().Add(-time.Second)
+	_, svcErr = resourceLockService.Get(ctx, Fukuisaurus)
+	gm.Expect(svcErr.Is404()).To(gm.BeTrue())
+	gm.Expect(resourceLockService.Check(ctx, Fukuisaurus, "bob")).To(gm.BeNil())
+
+	lock, svcErr = resourceLockService.Acquire(ctx, Fukuisaurus, "bob", 0)
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(lock.Owner).To(gm.Equal("bob"))
+
+	// the expired locks are pruned
+	lock.ExpiresAt = time.Now().Add(-time.Second)
+	deleted, svcErr := resourceLockService.DeleteExpired(ctx)
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(deleted).To(gm.Equal(int64(1)))
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+
+	"gorm.io/datatypes"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/dao"
+	"github.com/openshift-online/maestro/pkg/errors"
+)
+
+// ResourceTemplateService manages the parameterized manifests stored server-side, the resources are created from a
+// template with its manifest rendered by Render.
+type ResourceTemplateService interface {
+	Get(ctx context.Context, id string) (*api.ResourceTemplate, *errors.ServiceError)
+	// Create validates the parameters of the template and the parameters referenced by its manifest.
+	Create(ctx context.Context, template *api.ResourceTemplate) (*api.ResourceTemplate, *errors.ServiceError)
+	Delete(ctx context.Context, id string) *errors.ServiceError
+	All(ctx context.Context) (api.ResourceTemplateList, *errors.ServiceError)
+	// Render returns the manifest of the template rendered with the parameter values, it returns a validation error
+	// if a required parameter is not given or the rendered manifest is invalid, see ValidateObject.
+	Render(ctx context.Context, id string, values map[string]string) (datatypes.JSONMap, *errors.ServiceError)
+}
+
+func NewResourceTemplateService(resourceTemplateDao dao.ResourceTemplateDao) ResourceTemplateService {
+	return &sqlResourceTemplateService{
+		resourceTemplateDao: resourceTemplateDao,
+	}
+}
+
+var _ ResourceTemplateService = &sqlResourceTemplateService{}
+
+type sqlResourceTemplateService struct {
+	resourceTemplateDao dao.ResourceTemplateDao
+}
+
+func (s *sqlResourceTemplateService) Get(ctx context.Context, id string) (*api.ResourceTemplate, *errors.ServiceError) {
+	template, err := s.resourceTemplateDao.Get(ctx, id)
+	if err != nil {
+		return nil, handleGetError("ResourceTemplate", "id", id, err)
+	}
+	return template, nil
+}
+
+func (s *sqlResourceTemplateService) Create(ctx context.Context, template *api.ResourceTemplate) (*api.ResourceTemplate, *errors.ServiceError) {
+	if len(template.Name) == 0 {
+		return nil, errors.Validation("the name of the template is required")
+	}
+	if err := api.ValidateResourceTemplate(template); err != nil {
+		return nil, errors.Validation("the template %s is invalid, %s", template.Name, err)
+	}
+
+	template, err := s.resourceTemplateDao.Create(ctx, template)
+	if err != nil {
+		return nil, handleCreateError("ResourceTemplate", err)
+	}
+	return template, nil
+}
+
+func (s *sqlResourceTemplateService) Delete(ctx context.Context, id string) *errors.ServiceError {
+	if _, err := s.resourceTemplateDao.Get(ctx, id); err != nil {
+		return handleGetError("ResourceTemplate", "id", id, err)
+	}
+	if err := s.resourceTemplateDao.Delete(ctx, id); err != nil {
+		return handleDeleteError("ResourceTemplate", err)
+	}
+	return nil
+}
+
+func (s *sqlResourceTemplateService) All(ctx context.Context) (api.ResourceTemplateList, *errors.ServiceError) {
+	templates, err := s.resourceTemplateDao.All(ctx)
+	if err != nil {
+		return nil, errors.GeneralError("Unable to get all resource templates: %s", err)
+	}
+	return templates, nil
+}
+
+func (s *sqlResourceTemplateService) Render(ctx context.Context, id string, values map[string]string) (datatypes.JSONMap, *errors.ServiceError) {
+	template, serviceErr := s.Get(ctx, id)
+	if serviceErr != nil {
+		return nil, serviceErr
+	}
+
+	manifest, err := template.Render(values)
+	if err != nil {
+		return nil, errors.Validation("failed to render the template %s, %s", template.Name, err)
+	}
+	if err := ValidateObject(manifest); err != nil {
+		return nil, errors.Validation("the rendered manifest of the template %s is invalid, %s", template.Name, err)
+	}
+	return manifest, nil
+}
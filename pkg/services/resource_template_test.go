@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	gm "github.com/onsi/gomega"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/dao/mocks"
+)
+
+func TestResourceTemplate(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	ctx := context.Background()
+	resourceTemplateService := NewResourceTemplateService(mocks.NewResourceTemplateDao())
+
+	newTemplate := func(manifest map[string]interface{}) *api.ResourceTemplate {
+		return &api.ResourceTemplate{
+			Name:     "configmap",
+			Manifest: manifest,
+			Parameters: []api.ResourceTemplateParameter{
+				{Name: "NAME", Required: true},
+				{Name: "NAMESPACE", Value: "default"},
+			},
+		}
+	}
+
+	// the manifest references an undefined parameter
+	_, svcErr := resourceTemplateService.Create(ctx, newTemplate(map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": "${NAME}", "namespace": "${NAMESPACE}"},
+		"data":       map[string]interface{}{"owner": "${OWNER}"},
+	}))
+	gm.Expect(svcErr).NotTo(gm.BeNil())
+	gm.Expect(svcErr.IsValidation()).To(gm.BeTrue())
+
+	template, svcErr := resourceTemplateService.Create(ctx, newTemplate(map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": "${NAME}", "namespace": "${NAMESPACE}"},
+	}))
+	gm.Expect(svcErr).To(gm.BeNil())
+
+	// the name of the template is unique
+	_, svcErr = resourceTemplateService.Create(ctx, newTemplate(template.Manifest))
+	gm.Expect(svcErr).NotTo(gm.BeNil())
+	gm.Expect(svcErr.IsConflict()).To(gm.BeTrue())
+
+	// the required parameter is not given
+	_, svcErr = resourceTemplateService.Render(ctx, template.ID, nil)
+	gm.Expect(svcErr).NotTo(gm.BeNil())
+	gm.Expect(svcErr.IsValidation()).To(gm.BeTrue())
+
+	// the rendered manifest is invalid
+	_, svcErr = resourceTemplateService.Render(ctx, template.ID, map[string]string{"NAME": "test", "NAMESPACE": "Invalid_NS"})
+	gm.Expect(svcErr).NotTo(gm.BeNil())
+	gm.Expect(svcErr.IsValidation()).To(gm.BeTrue())
+
+	manifest, svcErr := resourceTemplateService.Render(ctx, template.ID, map[string]string{"NAME": "test"})
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(manifest["metadata"]).To(gm.Equal(map[string]interface{}{"name": "test", "namespace": "default"}))
+
+	gm.Expect(resourceTemplateService.Delete(ctx, template.ID)).To(gm.BeNil())
+	_, svcErr = resourceTemplateService.Render(ctx, template.ID, map[string]string{"NAME": "test"})
+	gm.Expect(svcErr).NotTo(gm.BeNil())
+	gm.Expect(svcErr.Is404()).To(gm.BeTrue())
+}
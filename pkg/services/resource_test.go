@@ -2,14 +2,27 @@ package services
 
 import (
 	"context"
+	"database/sql/driver"
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/bwmarrin/snowflake"
+	"github.com/lib/pq"
 	gm "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
 	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/types"
 	"open-cluster-management.io/sdk-go/pkg/cloudevents/work/payload"
 
 	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/api/presenters"
+	"github.com/openshift-online/maestro/pkg/dao"
 	"github.com/openshift-online/maestro/pkg/dao/mocks"
+	"github.com/openshift-online/maestro/pkg/db"
 	dbmocks "github.com/openshift-online/maestro/pkg/db/mocks"
 )
 
@@ -19,13 +32,19 @@ const (
 	Breviceratops = "c4df9ff0-bfeb-5bc6-a0ab-4c9128d698b4"
 )
 
+// newTestResourceService returns a resource service with the mock DAOs, the locks and the transactions of the tests.
+func newTestResourceService(resourceDAO dao.ResourceDao, events EventService, opts ResourceServiceOptions) ResourceService {
+	return NewResourceService(dbmocks.NewMockAdvisoryLockFactory(), dbmocks.NewMockTransactor(), resourceDAO, mocks.NewResourceRevisionDao(),
+		mocks.NewResourceOwnershipTransferDao(), mocks.NewConsumerDao(), events, nil, opts)
+}
+
 func TestResourceFindByConsumerID(t *testing.T) {
 	gm.RegisterTestingT(t)
 
 	resourceDAO := mocks.NewResourceDao()
 	events := NewEventService(mocks.NewEventDao())
 
-	resourceService := NewResourceService(dbmocks.NewMockAdvisoryLockFactory(), resourceDAO, events, nil)
+	resourceService := newTestResourceService(resourceDAO, events, ResourceServiceOptions{})
 
 	resources := api.ResourceList{
 		&api.Resource{ConsumerName: Fukuisaurus, Type: api.ResourceTypeSingle, Payload: newPayload(t, "{\"id\":\"75479c10-b537-4261-8058-ca2e36bac384\",\"time\":\"2024-03-07T03:29:03.194843266Z\",\"type\":\"io.open-cluster-management.works.v1alpha1.manifests.spec.create_request\",\"source\":\"maestro\",\"specversion\":\"1.0\",\"datacontenttype\":\"application/json\",\"data\":{\"manifest\":{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"test\",\"namespace\":\"test\"}}}}")},
@@ -58,7 +77,7 @@ func TestCreateInvalidResource(t *testing.T) {
 
 	resourceDAO := mocks.NewResourceDao()
 	events := NewEventService(mocks.NewEventDao())
-	resourceService := NewResourceService(dbmocks.NewMockAdvisoryLockFactory(), resourceDAO, events, nil)
+	resourceService := newTestResourceService(resourceDAO, events, ResourceServiceOptions{})
 
 	resource := &api.Resource{ConsumerName: "invalidation", Payload: newPayload(t, "{}")}
 
@@ -70,13 +89,32 @@ func TestCreateInvalidResource(t *testing.T) {
 	gm.Expect(len(invalidations)).To(gm.Equal(0))
 }
 
+func TestCreateDeepResource(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	resourceDAO := mocks.NewResourceDao()
+	events := NewEventService(mocks.NewEventDao())
+	resourceService := newTestResourceService(resourceDAO, events, ResourceServiceOptions{Limits: ManifestLimits{MaxDepth: 100, MaxKeys: 1000}})
+
+	// the manifest is rejected before it is written to the database
+	resource := &api.Resource{ConsumerName: Fukuisaurus, Type: api.ResourceTypeSingle, Payload: newDeepManifest(10000)}
+	_, svcErr := resourceService.Create(context.Background(), resource)
+	gm.Expect(svcErr).ShouldNot(gm.BeNil())
+	gm.Expect(svcErr.IsValidation()).To(gm.BeTrue())
+	gm.Expect(svcErr.Reason).To(gm.ContainSubstring("the nesting depth of the manifest exceeds the limit of 100"))
+
+	resources, err := resourceDAO.FindByConsumerName(context.Background(), Fukuisaurus)
+	gm.Expect(err).To(gm.BeNil())
+	gm.Expect(len(resources)).To(gm.Equal(0))
+}
+
 func TestList(t *testing.T) {
 	gm.RegisterTestingT(t)
 
 	resourceDAO := mocks.NewResourceDao()
 	events := NewEventService(mocks.NewEventDao())
 
-	resourceService := NewResourceService(dbmocks.NewMockAdvisoryLockFactory(), resourceDAO, events, nil)
+	resourceService := newTestResourceService(resourceDAO, events, ResourceServiceOptions{})
 	resources := api.ResourceList{
 		&api.Resource{ConsumerName: Fukuisaurus, Type: api.ResourceTypeSingle, Payload: newPayload(t, "{\"id\":\"75479c10-b537-4261-8058-ca2e36bac384\",\"time\":\"2024-03-07T03:29:03.194843266Z\",\"type\":\"io.open-cluster-management.works.v1alpha1.manifests.spec.create_request\",\"source\":\"maestro\",\"specversion\":\"1.0\",\"datacontenttype\":\"application/json\",\"data\":{\"manifest\":{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"test\",\"namespace\":\"test\"}}}}")},
 		&api.Resource{ConsumerName: Fukuisaurus, Type: api.ResourceTypeSingle, Payload: newPayload(t, "{\"id\":\"75479c10-b537-4261-8058-ca2e36bac384\",\"time\":\"2024-03-07T03:29:03.194843266Z\",\"type\":\"io.open-cluster-management.works.v1alpha1.manifests.spec.create_request\",\"source\":\"maestro\",\"specversion\":\"1.0\",\"datacontenttype\":\"application/json\",\"data\":{\"manifest\":{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"test\",\"namespace\":\"test\"}}}}")},
@@ -109,3 +147,985 @@ func TestList(t *testing.T) {
 	gm.Expect(err).To(gm.BeNil())
 	gm.Expect(len(resoruces)).To(gm.Equal(1))
 }
+
+func TestListByPriority(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	resourceDAO := mocks.NewResourceDao()
+	events := NewEventService(mocks.NewEventDao())
+
+	resourceService := newTestResourceService(resourceDAO, events, ResourceServiceOptions{})
+	for i, priority := range []int32{0, -10, 100, 0, 50} {
+		resource := &api.Resource{
+			Meta:         api.Meta{ID: fmt.Sprintf("resource%d", i)},
+			ConsumerName: Fukuisaurus,
+			Type:         api.ResourceTypeSingle,
+			Priority:     priority,
+			Payload:      newPayload(t, "{\"id\":\"75479c10-b537-4261-8058-ca2e36bac384\",\"time\":\"2024-03-07T03:29:03.194843266Z\",\"type\":\"io.open-cluster-management.works.v1alpha1.manifests.spec.create_request\",\"source\":\"maestro\",\"specversion\":\"1.0\",\"datacontenttype\":\"application/json\",\"data\":{\"manifest\":{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"test\",\"namespace\":\"test\"}}}}"),
+		}
+		_, err := resourceService.Create(context.Background(), resource)
+		gm.Expect(err).To(gm.BeNil())
+	}
+
+	// the resources with a higher priority are resynced first, the others keep their order
+	resources, err := resourceService.List(types.ListOptions{
+		ClusterName:         Fukuisaurus,
+		CloudEventsDataType: payload.ManifestEventDataType,
+	})
+	gm.Expect(err).To(gm.BeNil())
+	ids := []string{}
+	for _, resource := range resources {
+		ids = append(ids, resource.ID)
+	}
+	gm.Expect(ids).To(gm.Equal([]string{"resource2", "resource4", "resource0", "resource3", "resource1"}))
+}
+
+func TestBatchCreate(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	ctx := context.Background()
+	resourceDAO := mocks.NewResourceDao()
+	events := NewEventService(mocks.NewEventDao())
+	resourceService := newTestResourceService(resourceDAO, events, ResourceServiceOptions{})
+
+	payload := newPayload(t, "{\"id\":\"75479c10-b537-4261-8058-ca2e36bac384\",\"time\":\"2024-03-07T03:29:03.194843266Z\",\"type\":\"io.open-cluster-management.works.v1alpha1.manifests.spec.create_request\",\"source\":\"maestro\",\"specversion\":\"1.0\",\"datacontenttype\":\"application/json\",\"data\":{\"manifest\":{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"test\",\"namespace\":\"test\"}}}}")
+	resources := api.ResourceList{
+		{Type: api.ResourceTypeSingle, ConsumerName: Fukuisaurus, Payload: payload},
+		{Type: api.ResourceTypeSingle, ConsumerName: Seismosaurus, Payload: payload},
+	}
+
+	created, svcErr := resourceService.BatchCreate(ctx, resources)
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(len(created)).To(gm.Equal(2))
+	gm.Expect(created[0].ConsumerName).To(gm.Equal(Fukuisaurus))
+	gm.Expect(created[1].ConsumerName).To(gm.Equal(Seismosaurus))
+
+	for _, consumerName := range []string{Fukuisaurus, Seismosaurus} {
+		resources, err := resourceDAO.FindByConsumerName(ctx, consumerName)
+		gm.Expect(err).To(gm.BeNil())
+		gm.Expect(len(resources)).To(gm.Equal(1))
+	}
+
+	// the invalid resource is not created for any consumer
+	_, svcErr = resourceService.BatchCreate(ctx, api.ResourceList{
+		{Type: api.ResourceTypeSingle, ConsumerName: "invalidation", Payload: newPayload(t, "{}")},
+	})
+	gm.Expect(svcErr).NotTo(gm.BeNil())
+	invalidations, err := resourceDAO.FindByConsumerName(ctx, "invalidation")
+	gm.Expect(err).To(gm.BeNil())
+	gm.Expect(len(invalidations)).To(gm.Equal(0))
+
+	// at least one consumer is required
+	_, svcErr = resourceService.BatchCreate(ctx, nil)
+	gm.Expect(svcErr).NotTo(gm.BeNil())
+}
+
+func TestResourceRevisionLimit(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	resourceRevisionDAO := mocks.NewResourceRevisionDao()
+	events := NewEventService(mocks.NewEventDao())
+	resourceService := &sqlResourceService{
+		lockFactory:         dbmocks.NewMockAdvisoryLockFactory(),
+		resourceDao:         mocks.NewResourceDao(),
+		resourceRevisionDao: resourceRevisionDAO,
+		events:              events,
+		revisionLimit:       3,
+	}
+
+	resource := &api.Resource{Meta: api.Meta{ID: Fukuisaurus}, Type: api.ResourceTypeSingle}
+	for version := int64(1); version <= 5; version++ {
+		resource.Version = version
+		gm.Expect(resourceService.createRevision(context.Background(), resource)).To(gm.Succeed())
+	}
+
+	revisions, err := resourceRevisionDAO.FindByResourceID(context.Background(), Fukuisaurus)
+	gm.Expect(err).To(gm.BeNil())
+	gm.Expect(len(revisions)).To(gm.Equal(3))
+	gm.Expect(revisions[0].Version).To(gm.Equal(int64(5)))
+	gm.Expect(revisions[2].Version).To(gm.Equal(int64(3)))
+
+	// the revision history is disabled
+	resourceService.revisionLimit = 0
+	resource.Version = 6
+	gm.Expect(resourceService.createRevision(context.Background(), resource)).To(gm.Succeed())
+	revisions, err = resourceRevisionDAO.FindByResourceID(context.Background(), Fukuisaurus)
+	gm.Expect(err).To(gm.BeNil())
+	gm.Expect(len(revisions)).To(gm.Equal(3))
+}
+
+func TestMarkAsDeletingTwice(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	ctx := context.Background()
+	resourceDAO := mocks.NewResourceDao()
+	events := NewEventService(mocks.NewEventDao())
+	resourceService := newTestResourceService(resourceDAO, events, ResourceServiceOptions{})
+
+	_, err := resourceDAO.Create(ctx, &api.Resource{Meta: api.Meta{ID: Breviceratops}, ConsumerName: Fukuisaurus, Type: api.ResourceTypeSingle})
+	gm.Expect(err).To(gm.BeNil())
+
+	result, svcErr := resourceService.MarkAsDeletingWithResult(ctx, Breviceratops)
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(result).To(gm.Equal(DeletionMarked))
+
+	// the second deletion succeeds without creating another delete event
+	result, svcErr = resourceService.MarkAsDeletingWithResult(ctx, Breviceratops)
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(result).To(gm.Equal(DeletionAlreadyDeleting))
+	gm.Expect(resourceService.MarkAsDeleting(ctx, Breviceratops)).To(gm.BeNil())
+
+	deleteEvents, err := events.FindAllUnreconciledEvents(ctx)
+	gm.Expect(err).To(gm.BeNil())
+	gm.Expect(len(deleteEvents)).To(gm.Equal(1))
+
+	// the deletion after the resource is deleted by the agent succeeds
+	gm.Expect(resourceService.Delete(ctx, Breviceratops)).To(gm.BeNil())
+	result, svcErr = resourceService.MarkAsDeletingWithResult(ctx, Breviceratops)
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(result).To(gm.Equal(DeletionNotFound))
+	gm.Expect(resourceService.MarkAsDeleting(ctx, Breviceratops)).To(gm.BeNil())
+}
+
+func TestCountPendingDeletion(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	ctx := context.Background()
+	resourceDAO := mocks.NewResourceDao()
+	events := NewEventService(mocks.NewEventDao())
+	resourceService := newTestResourceService(resourceDAO, events, ResourceServiceOptions{})
+
+	resources := []*api.Resource{
+		{Meta: api.Meta{ID: "resource1"}, ConsumerName: "cluster1", Type: api.ResourceTypeSingle},
+		{Meta: api.Meta{ID: "resource2"}, ConsumerName: "cluster1", Type: api.ResourceTypeSingle},
+		{Meta: api.Meta{ID: "resource3"}, ConsumerName: "cluster2", Type: api.ResourceTypeBundle},
+		{Meta: api.Meta{ID: "resource4"}, ConsumerName: "cluster2", Type: api.ResourceTypeSingle},
+	}
+	for _, resource := range resources {
+		_, err := resourceDAO.Create(ctx, resource)
+		gm.Expect(err).To(gm.BeNil())
+	}
+	for _, id := range []string{"resource1", "resource2", "resource3"} {
+		gm.Expect(resourceService.MarkAsDeleting(ctx, id)).To(gm.BeNil())
+	}
+
+	counts, svcErr := resourceService.CountPendingDeletion(ctx, time.Now().Add(time.Second))
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(counts).To(gm.Equal(map[api.ResourceType]map[string]int{
+		api.ResourceTypeSingle: {"cluster1": 2},
+		api.ResourceTypeBundle: {"cluster2": 1},
+	}))
+
+	// the resources marked as deleting after the given time are not counted
+	counts, svcErr = resourceService.CountPendingDeletion(ctx, time.Now().Add(-time.Hour))
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(counts).To(gm.BeEmpty())
+}
+
+func TestMarkAsDeletingResolvesDeleteOption(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	ctx := context.Background()
+	resourceDAO := mocks.NewResourceDao()
+	consumerDAO := mocks.NewConsumerDao()
+	events := NewEventService(mocks.NewEventDao())
+	resourceService := NewResourceService(dbmocks.NewMockAdvisoryLockFactory(), dbmocks.NewMockTransactor(), resourceDAO, mocks.NewResourceRevisionDao(), mocks.NewResourceOwnershipTransferDao(), consumerDAO, events, nil, ResourceServiceOptions{})
+
+	_, err := consumerDAO.Create(ctx, &api.Consumer{Meta: api.Meta{ID: Fukuisaurus}, Name: Fukuisaurus, DeletePropagationPolicy: "Orphan"})
+	gm.Expect(err).To(gm.BeNil())
+
+	manifest := map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": "test", "namespace": "default"},
+	}
+	cases := []struct {
+		id           string
+		consumer     string
+		deleteOption map[string]interface{}
+		expected     string
+	}{
+		// the consumer policy applies to the resource without its own delete option
+		{id: "a", consumer: Fukuisaurus, expected: "Orphan"},
+		// the delete option of the resource overrides the consumer policy
+		{id: "b", consumer: Fukuisaurus, deleteOption: map[string]interface{}{"propagationPolicy": "Foreground"}, expected: "Foreground"},
+		// the global default applies to the consumer without a policy
+		{id: "c", consumer: Seismosaurus, expected: "Foreground"},
+	}
+	for _, c := range cases {
+		payload, err := api.EncodeManifest(manifest, c.deleteOption, nil, nil, nil, nil)
+		gm.Expect(err).To(gm.BeNil())
+		_, err = resourceDAO.Create(ctx, &api.Resource{Meta: api.Meta{ID: c.id}, ConsumerName: c.consumer,
+			Type: api.ResourceTypeSingle, Version: 1, Payload: payload})
+		gm.Expect(err).To(gm.BeNil())
+
+		gm.Expect(resourceService.MarkAsDeleting(ctx, c.id)).To(gm.BeNil())
+
+		deleting, err := resourceDAO.Get(ctx, c.id)
+		gm.Expect(err).To(gm.BeNil())
+		gm.Expect(deleting.Version).To(gm.Equal(int64(1)))
+		_, deleteOption, _, err := api.DecodeManifest(deleting.Payload)
+		gm.Expect(err).To(gm.BeNil())
+		gm.Expect(deleteOption["propagationPolicy"]).To(gm.Equal(c.expected))
+	}
+}
+
+func TestResolveDeleteOptionByConsumer(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	ctx := context.Background()
+	resourceDAO := mocks.NewResourceDao()
+	consumerDAO := mocks.NewConsumerDao()
+	events := NewEventService(mocks.NewEventDao())
+	resourceService := NewResourceService(dbmocks.NewMockAdvisoryLockFactory(), dbmocks.NewMockTransactor(), resourceDAO, mocks.NewResourceRevisionDao(), mocks.NewResourceOwnershipTransferDao(), consumerDAO, events, nil, ResourceServiceOptions{})
+
+	consumer, err := consumerDAO.Create(ctx, &api.Consumer{Meta: api.Meta{ID: Fukuisaurus}, Name: Fukuisaurus, DeletePropagationPolicy: "Orphan"})
+	gm.Expect(err).To(gm.BeNil())
+
+	manifest := map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": "test", "namespace": "default"},
+	}
+	created := map[string]*api.Resource{}
+	versions := map[string]int64{}
+	for name, deleteOption := range map[string]map[string]interface{}{
+		"resolved": nil,
+		"own":      {"propagationPolicy": "Orphan"},
+	} {
+		payload, err := api.EncodeManifest(manifest, deleteOption, nil, nil, nil, nil)
+		gm.Expect(err).To(gm.BeNil())
+		resource, svcErr := resourceService.Create(ctx, &api.Resource{Meta: api.Meta{ID: name}, ConsumerName: Fukuisaurus,
+			Type: api.ResourceTypeSingle, Payload: payload})
+		gm.Expect(svcErr).To(gm.BeNil())
+		created[name] = resource
+		versions[name] = resource.Version
+	}
+	// the consumer policy is resolved at create
+	gm.Expect(created["resolved"].DeleteOptionResolved).To(gm.BeTrue())
+	gm.Expect(created["own"].DeleteOptionResolved).To(gm.BeFalse())
+
+	consumer.DeletePropagationPolicy = "Foreground"
+	_, err = consumerDAO.Replace(ctx, consumer)
+	gm.Expect(err).To(gm.BeNil())
+
+	// only the resource without its own delete option follows the new consumer policy
+	changed, svcErr := resourceService.ResolveDeleteOptionByConsumer(ctx, Fukuisaurus)
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(changed).To(gm.Equal(1))
+	for name, expected := range map[string]struct {
+		policy    string
+		increased bool
+	}{
+		"resolved": {policy: "Foreground", increased: true},
+		"own":      {policy: "Orphan", increased: false},
+	} {
+		resource, err := resourceDAO.Get(ctx, created[name].ID)
+		gm.Expect(err).To(gm.BeNil())
+		gm.Expect(resource.Version > versions[name]).To(gm.Equal(expected.increased))
+		_, deleteOption, _, err := api.DecodeManifest(resource.Payload)
+		gm.Expect(err).To(gm.BeNil())
+		gm.Expect(deleteOption["propagationPolicy"]).To(gm.Equal(expected.policy))
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	ctx := context.Background()
+	resourceDAO := mocks.NewResourceDao()
+	resourceRevisionDAO := mocks.NewResourceRevisionDao()
+	events := NewEventService(mocks.NewEventDao())
+	resourceService := NewResourceService(dbmocks.NewMockAdvisoryLockFactory(), dbmocks.NewMockTransactor(), resourceDAO, resourceRevisionDAO, mocks.NewResourceOwnershipTransferDao(), mocks.NewConsumerDao(), events, nil, ResourceServiceOptions{RevisionLimit: 10})
+
+	payload := datatypes.JSONMap{"manifest": map[string]interface{}{"kind": "ConfigMap"}}
+	_, err := resourceDAO.Create(ctx, &api.Resource{Meta: api.Meta{ID: Breviceratops}, ConsumerName: Fukuisaurus,
+		Type: api.ResourceTypeSingle, Version: 1, Payload: payload})
+	gm.Expect(err).To(gm.BeNil())
+
+	reconciled, svcErr := resourceService.Reconcile(ctx, Breviceratops)
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(reconciled.Version).To(gm.Equal(int64(2)))
+	gm.Expect(reconciled.Payload).To(gm.Equal(payload))
+
+	// the new version is recorded and re-broadcast
+	revisions, err := resourceRevisionDAO.FindByResourceID(ctx, Breviceratops)
+	gm.Expect(err).To(gm.BeNil())
+	gm.Expect(len(revisions)).To(gm.Equal(1))
+	gm.Expect(revisions[0].Version).To(gm.Equal(int64(2)))
+	updateEvents, err := events.FindAllUnreconciledEvents(ctx)
+	gm.Expect(err).To(gm.BeNil())
+	gm.Expect(len(updateEvents)).To(gm.Equal(1))
+	gm.Expect(updateEvents[0].EventType).To(gm.Equal(api.UpdateEventType))
+
+	// the resource under deletion cannot be reconciled
+	gm.Expect(resourceService.MarkAsDeleting(ctx, Breviceratops)).To(gm.BeNil())
+	_, svcErr = resourceService.Reconcile(ctx, Breviceratops)
+	gm.Expect(svcErr.IsConflict()).To(gm.BeTrue())
+
+	_, svcErr = resourceService.Reconcile(ctx, Fukuisaurus)
+	gm.Expect(svcErr.Is404()).To(gm.BeTrue())
+}
+
+func TestReconcileMaxResourceVersion(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	ctx := context.Background()
+	resourceDAO := mocks.NewResourceDao()
+	events := NewEventService(mocks.NewEventDao())
+	resourceService := newTestResourceService(resourceDAO, events, ResourceServiceOptions{RevisionLimit: 10})
+
+	_, err := resourceDAO.Create(ctx, &api.Resource{Meta: api.Meta{ID: Breviceratops}, ConsumerName: Fukuisaurus,
+		Type: api.ResourceTypeSingle, Version: api.MaxResourceVersion,
+		Payload: datatypes.JSONMap{"manifest": map[string]interface{}{"kind": "ConfigMap"}}})
+	gm.Expect(err).To(gm.BeNil())
+
+	// the version beyond the int32 range cannot be sent to the agents
+	_, svcErr := resourceService.Reconcile(ctx, Breviceratops)
+	gm.Expect(svcErr.IsConflict()).To(gm.BeTrue())
+
+	found, err := resourceDAO.Get(ctx, Breviceratops)
+	gm.Expect(err).To(gm.BeNil())
+	gm.Expect(found.Version).To(gm.Equal(int64(api.MaxResourceVersion)))
+	updateEvents, err := events.FindAllUnreconciledEvents(ctx)
+	gm.Expect(err).To(gm.BeNil())
+	gm.Expect(len(updateEvents)).To(gm.Equal(0))
+}
+
+func TestReconcileByConsumer(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	ctx := context.Background()
+	resourceDAO := mocks.NewResourceDao()
+	events := NewEventService(mocks.NewEventDao())
+	resourceService := newTestResourceService(resourceDAO, events, ResourceServiceOptions{})
+
+	for _, id := range []string{"a", "b", "c"} {
+		_, err := resourceDAO.Create(ctx, &api.Resource{Meta: api.Meta{ID: id}, ConsumerName: Fukuisaurus, Type: api.ResourceTypeSingle, Version: 1})
+		gm.Expect(err).To(gm.BeNil())
+	}
+	_, err := resourceDAO.Create(ctx, &api.Resource{Meta: api.Meta{ID: "d"}, ConsumerName: Seismosaurus, Type: api.ResourceTypeSingle, Version: 1})
+	gm.Expect(err).To(gm.BeNil())
+	gm.Expect(resourceService.MarkAsDeleting(ctx, "c")).To(gm.BeNil())
+
+	// the resources under deletion and the resources of other consumers are not reconciled
+	reconciled, svcErr := resourceService.ReconcileByConsumer(ctx, Fukuisaurus)
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(reconciled).To(gm.Equal(2))
+
+	for id, version := range map[string]int64{"a": 2, "b": 2, "c": 1, "d": 1} {
+		resource, err := resourceDAO.Get(ctx, id)
+		gm.Expect(err).To(gm.BeNil())
+		gm.Expect(resource.Version).To(gm.Equal(version))
+	}
+}
+
+func TestMarkAsDeletingByConsumer(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	ctx := context.Background()
+	resourceDAO := mocks.NewResourceDao()
+	events := NewEventService(mocks.NewEventDao())
+	consumerDAO := mocks.NewConsumerDao()
+	resourceService := NewResourceService(dbmocks.NewMockAdvisoryLockFactory(), dbmocks.NewMockTransactor(), resourceDAO, mocks.NewResourceRevisionDao(), mocks.NewResourceOwnershipTransferDao(), consumerDAO, events, nil, ResourceServiceOptions{})
+
+	for _, name := range []string{Fukuisaurus, Seismosaurus} {
+		_, err := consumerDAO.Create(ctx, &api.Consumer{Name: name})
+		gm.Expect(err).To(gm.BeNil())
+	}
+	for _, id := range []string{"a", "b", "c"} {
+		_, err := resourceDAO.Create(ctx, &api.Resource{Meta: api.Meta{ID: id}, ConsumerName: Fukuisaurus, Type: api.ResourceTypeSingle, Version: 1})
+		gm.Expect(err).To(gm.BeNil())
+	}
+	_, err := resourceDAO.Create(ctx, &api.Resource{Meta: api.Meta{ID: "d"}, ConsumerName: Seismosaurus, Type: api.ResourceTypeSingle, Version: 1})
+	gm.Expect(err).To(gm.BeNil())
+	gm.Expect(resourceService.MarkAsDeleting(ctx, "c")).To(gm.BeNil())
+
+	// the resource under deletion is not counted, no resource is marked if the confirmation does not match
+	_, svcErr := resourceService.MarkAsDeletingByConsumer(ctx, Fukuisaurus, 3)
+	gm.Expect(svcErr.IsConflict()).To(gm.BeTrue())
+
+	// a nonexistent consumer is not found rather than having no resource
+	_, svcErr = resourceService.MarkAsDeletingByConsumer(ctx, "nonexistent", 0)
+	gm.Expect(svcErr.Is404()).To(gm.BeTrue())
+	for _, id := range []string{"a", "b"} {
+		resource, err := resourceDAO.Get(ctx, id)
+		gm.Expect(err).To(gm.BeNil())
+		gm.Expect(resource.DeletedAt.Time.IsZero()).To(gm.BeTrue())
+	}
+
+	marked, svcErr := resourceService.MarkAsDeletingByConsumer(ctx, Fukuisaurus, 2)
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(marked).To(gm.Equal(2))
+	for id, deleting := range map[string]bool{"a": true, "b": true, "c": true, "d": false} {
+		resource, err := resourceDAO.Get(ctx, id)
+		gm.Expect(err).To(gm.BeNil())
+		gm.Expect(!resource.DeletedAt.Time.IsZero()).To(gm.Equal(deleting))
+	}
+}
+
+func TestResourceLabelPropagation(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	ctx := context.Background()
+	resourceDAO := mocks.NewResourceDao()
+	events := NewEventService(mocks.NewEventDao())
+	resourceService := newTestResourceService(resourceDAO, events, ResourceServiceOptions{LabelPropagation: &api.LabelPropagation{Keys: []string{"env"}, Prefixes: []string{"app.kubernetes.io/"}}})
+
+	manifest := func(labels string) datatypes.JSONMap {
+		return newPayload(t, fmt.Sprintf("{\"specversion\":\"1.0\",\"id\":\"266a8cd2-2fab-4e89-9bf0-a56425ebcdf8\",\"source\":\"grpc\",\"type\":\"io.open-cluster-management.works.v1alpha1.manifests.spec.create_request\",\"datacontenttype\":\"application/json\",\"data\":{\"manifest\":{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"test\",\"namespace\":\"test\",\"labels\":%s}}}}", labels))
+	}
+
+	created, svcErr := resourceService.Create(ctx, &api.Resource{ConsumerName: Fukuisaurus, Type: api.ResourceTypeSingle,
+		Payload: manifest(`{"env":"prod","app.kubernetes.io/name":"web","tier":"frontend"}`)})
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(created.Labels).To(gm.Equal(pq.StringArray{"app.kubernetes.io/name=web", "env=prod"}))
+
+	// the labels removed from the manifest are removed from the resource
+	updated, svcErr := resourceService.Update(ctx, &api.Resource{Meta: api.Meta{ID: created.ID}, Version: created.Version,
+		Type: api.ResourceTypeSingle, Payload: manifest(`{"app.kubernetes.io/name":"api"}`)})
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(updated.Labels).To(gm.Equal(pq.StringArray{"app.kubernetes.io/name=api"}))
+
+	updated, svcErr = resourceService.Update(ctx, &api.Resource{Meta: api.Meta{ID: created.ID}, Version: updated.Version,
+		Type: api.ResourceTypeSingle, Payload: manifest(`{}`)})
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(updated.Labels).To(gm.BeEmpty())
+}
+
+func TestUpdateImmutableFields(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	ctx := context.Background()
+	resourceDAO := mocks.NewResourceDao()
+	resourceRevisionDAO := mocks.NewResourceRevisionDao()
+	events := NewEventService(mocks.NewEventDao())
+	immutableFields, err := api.ParseImmutableFields([]string{"apps/v1/StatefulSet:spec.volumeClaimTemplates"})
+	gm.Expect(err).To(gm.BeNil())
+	resourceService := NewResourceService(dbmocks.NewMockAdvisoryLockFactory(), dbmocks.NewMockTransactor(), resourceDAO, resourceRevisionDAO, mocks.NewResourceOwnershipTransferDao(), mocks.NewConsumerDao(), events, nil, ResourceServiceOptions{Limits: ManifestLimits{ImmutableFields: immutableFields}})
+
+	statefulSet := func(storage string, replicas int) datatypes.JSONMap {
+		payload, err := api.EncodeManifest(map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "StatefulSet",
+			"metadata":   map[string]interface{}{"name": "test", "namespace": "default"},
+			"spec": map[string]interface{}{
+				"replicas":             replicas,
+				"volumeClaimTemplates": []interface{}{map[string]interface{}{"spec": map[string]interface{}{"storage": storage}}},
+			},
+		}, nil, nil, nil, nil, nil)
+		gm.Expect(err).To(gm.BeNil())
+		return payload
+	}
+
+	created, svcErr := resourceService.Create(ctx, &api.Resource{Meta: api.Meta{ID: "a"}, ConsumerName: Fukuisaurus,
+		Type: api.ResourceTypeSingle, Payload: statefulSet("1Gi", 1)})
+	gm.Expect(svcErr).To(gm.BeNil())
+
+	// the mutable fields can be changed
+	updated, svcErr := resourceService.Update(ctx, &api.Resource{Meta: api.Meta{ID: created.ID}, Version: created.Version,
+		Type: api.ResourceTypeSingle, Payload: statefulSet("1Gi", 2)})
+	gm.Expect(svcErr).To(gm.BeNil())
+	version := updated.Version
+
+	// the immutable fields cannot be changed by an update
+	_, svcErr = resourceService.Update(ctx, &api.Resource{Meta: api.Meta{ID: created.ID}, Version: version,
+		Type: api.ResourceTypeSingle, Payload: statefulSet("2Gi", 2)})
+	gm.Expect(svcErr).NotTo(gm.BeNil())
+	gm.Expect(svcErr.Reason).To(gm.ContainSubstring("the field spec.volumeClaimTemplates of the StatefulSet test is immutable"))
+
+	// nor by a revert to a revision with other immutable fields
+	_, err = resourceRevisionDAO.Create(ctx, &api.ResourceRevision{ResourceID: created.ID, Version: 1, Payload: statefulSet("2Gi", 1)})
+	gm.Expect(err).To(gm.BeNil())
+	_, svcErr = resourceService.Revert(ctx, created.ID, 1)
+	gm.Expect(svcErr).NotTo(gm.BeNil())
+	gm.Expect(svcErr.Reason).To(gm.ContainSubstring("the field spec.volumeClaimTemplates of the StatefulSet test is immutable"))
+
+	found, err := resourceDAO.Get(ctx, created.ID)
+	gm.Expect(err).To(gm.BeNil())
+	gm.Expect(found.Version).To(gm.Equal(version))
+}
+
+func TestTransferOwnership(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	ctx := context.Background()
+	resourceDAO := mocks.NewResourceDao()
+	events := NewEventService(mocks.NewEventDao())
+	resourceService := newTestResourceService(resourceDAO, events, ResourceServiceOptions{})
+
+	for _, id := range []string{"a", "b"} {
+		_, err := resourceDAO.Create(ctx, &api.Resource{Meta: api.Meta{ID: id}, Source: "old-source", ConsumerName: Fukuisaurus, Type: api.ResourceTypeSingle, Version: 1})
+		gm.Expect(err).To(gm.BeNil())
+	}
+	gm.Expect(resourceService.MarkAsDeleting(ctx, "b")).To(gm.BeNil())
+
+	transfer, svcErr := resourceService.TransferOwnership(ctx, "a", "new-source", "admin")
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(transfer.FromSource).To(gm.Equal("old-source"))
+	gm.Expect(transfer.ToSource).To(gm.Equal("new-source"))
+
+	// the resource version is not changed
+	resource, err := resourceDAO.Get(ctx, "a")
+	gm.Expect(err).To(gm.BeNil())
+	gm.Expect(resource.Source).To(gm.Equal("new-source"))
+	gm.Expect(resource.Version).To(gm.Equal(int64(1)))
+
+	transfers, paging, svcErr := resourceService.ListOwnershipTransfers(ctx, "a", &ListArguments{Page: 1, Size: 100})
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(len(transfers)).To(gm.Equal(1))
+	gm.Expect(paging.Total).To(gm.Equal(int64(1)))
+	gm.Expect(transfers[0].ID).To(gm.Equal(transfer.ID))
+	gm.Expect(transfers[0].TransferredBy).To(gm.Equal("admin"))
+
+	// the resource already belongs to the source
+	_, svcErr = resourceService.TransferOwnership(ctx, "a", "new-source", "admin")
+	gm.Expect(svcErr.IsConflict()).To(gm.BeTrue())
+
+	// the transfers are listed page by page, the latest transfer comes first
+	transferBack, svcErr := resourceService.TransferOwnership(ctx, "a", "old-source", "admin")
+	gm.Expect(svcErr).To(gm.BeNil())
+	transfers, paging, svcErr = resourceService.ListOwnershipTransfers(ctx, "a", &ListArguments{Page: 1, Size: 1})
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(len(transfers)).To(gm.Equal(1))
+	gm.Expect(transfers[0].ID).To(gm.Equal(transferBack.ID))
+	gm.Expect(*paging).To(gm.Equal(api.PagingMeta{Page: 1, Size: 1, Total: 2}))
+	transfers, paging, svcErr = resourceService.ListOwnershipTransfers(ctx, "a", &ListArguments{Page: 2, Size: 1})
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(transfers[0].ID).To(gm.Equal(transfer.ID))
+	gm.Expect(*paging).To(gm.Equal(api.PagingMeta{Page: 2, Size: 1, Total: 2}))
+	transfers, paging, svcErr = resourceService.ListOwnershipTransfers(ctx, "a", &ListArguments{Page: 3, Size: 1})
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(transfers).To(gm.BeEmpty())
+	gm.Expect(paging.Total).To(gm.Equal(int64(2)))
+	_, _, svcErr = resourceService.ListOwnershipTransfers(ctx, "a", &ListArguments{Page: 0, Size: 1})
+	gm.Expect(svcErr).NotTo(gm.BeNil())
+
+	// the resource is under deletion
+	_, svcErr = resourceService.TransferOwnership(ctx, "b", "new-source", "admin")
+	gm.Expect(svcErr.IsConflict()).To(gm.BeTrue())
+
+	_, svcErr = resourceService.TransferOwnership(ctx, "a", "", "admin")
+	gm.Expect(svcErr).NotTo(gm.BeNil())
+
+	_, svcErr = resourceService.TransferOwnership(ctx, "c", "new-source", "admin")
+	gm.Expect(svcErr.Is404()).To(gm.BeTrue())
+}
+
+func TestFindActiveByConsumerName(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	ctx := context.Background()
+	resourceDAO := mocks.NewResourceDao()
+	resourceService := newTestResourceService(resourceDAO, NewEventService(mocks.NewEventDao()), ResourceServiceOptions{})
+
+	for _, id := range []string{"c", "a", "d", "b"} {
+		_, err := resourceDAO.Create(ctx, &api.Resource{Meta: api.Meta{ID: id}, ConsumerName: Fukuisaurus, Type: api.ResourceTypeSingle})
+		gm.Expect(err).To(gm.BeNil())
+	}
+	_, err := resourceDAO.Create(ctx, &api.Resource{Meta: api.Meta{ID: "e"}, ConsumerName: Fukuisaurus, Type: api.ResourceTypeBundle})
+	gm.Expect(err).To(gm.BeNil())
+	_, err = resourceDAO.Create(ctx, &api.Resource{Meta: api.Meta{ID: "f"}, ConsumerName: Seismosaurus, Type: api.ResourceTypeSingle})
+	gm.Expect(err).To(gm.BeNil())
+	gm.Expect(resourceService.MarkAsDeleting(ctx, "d")).To(gm.BeNil())
+
+	// the resources under deletion are skipped
+	page, svcErr := resourceService.FindActiveByConsumerName(ctx, Fukuisaurus, api.ResourceTypeSingle, "", 2)
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(len(page)).To(gm.Equal(2))
+	gm.Expect(page[0].ID).To(gm.Equal("a"))
+	gm.Expect(page[1].ID).To(gm.Equal("b"))
+
+	page, svcErr = resourceService.FindActiveByConsumerName(ctx, Fukuisaurus, api.ResourceTypeSingle, "b", 2)
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(len(page)).To(gm.Equal(1))
+	gm.Expect(page[0].ID).To(gm.Equal("c"))
+
+	// all the resource types are returned without the resource type
+	page, svcErr = resourceService.FindActiveByConsumerName(ctx, Fukuisaurus, "", "c", 2)
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(len(page)).To(gm.Equal(1))
+	gm.Expect(page[0].ID).To(gm.Equal("e"))
+}
+
+func TestUpdateStatusSequenceID(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	ctx := context.Background()
+	resourceDAO := mocks.NewResourceDao()
+	events := NewEventService(mocks.NewEventDao())
+	resourceService := newTestResourceService(resourceDAO, events, ResourceServiceOptions{})
+
+	_, err := resourceDAO.Create(ctx, &api.Resource{Meta: api.Meta{ID: Breviceratops}, ConsumerName: Fukuisaurus, Type: api.ResourceTypeSingle, Version: 1})
+	gm.Expect(err).To(gm.BeNil())
+
+	node, err := snowflake.NewNode(1)
+	gm.Expect(err).To(gm.BeNil())
+	older := node.Generate().String()
+	newer := node.Generate().String()
+	newest := node.Generate().String()
+
+	cases := []struct {
+		name       string
+		sequenceID string
+		data       string
+		updated    bool
+	}{
+		{name: "first status", sequenceID: newer, updated: true},
+		{name: "stale status", sequenceID: older, data: appliedStatusData, updated: false},
+		{name: "status without sequence id", sequenceID: "", data: appliedStatusData, updated: false},
+		{name: "newer status", sequenceID: newest, data: appliedStatusData, updated: true},
+	}
+
+	for _, c := range cases {
+		status := newStatusWithData(t, c.sequenceID, c.data)
+		_, updated, svcErr := resourceService.UpdateStatus(ctx, &api.Resource{Meta: api.Meta{ID: Breviceratops}, Version: 1, Status: status})
+		gm.Expect(svcErr).To(gm.BeNil(), c.name)
+		gm.Expect(updated).To(gm.Equal(c.updated), c.name)
+	}
+
+	found, err := resourceDAO.Get(ctx, Breviceratops)
+	gm.Expect(err).To(gm.BeNil())
+	gm.Expect(found.Status["sequenceid"]).To(gm.Equal(newest))
+}
+
+func TestUpdateStatusNoop(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	ResetResourceMetrics()
+	defer ResetResourceMetrics()
+
+	ctx := context.Background()
+	resourceDAO := mocks.NewResourceDao()
+	events := NewEventService(mocks.NewEventDao())
+	resourceService := newTestResourceService(resourceDAO, events, ResourceServiceOptions{})
+
+	_, err := resourceDAO.Create(ctx, &api.Resource{Meta: api.Meta{ID: Breviceratops}, ConsumerName: Fukuisaurus, Type: api.ResourceTypeSingle, Version: 1})
+	gm.Expect(err).To(gm.BeNil())
+
+	node, err := snowflake.NewNode(1)
+	gm.Expect(err).To(gm.BeNil())
+	older := node.Generate().String()
+	first := node.Generate().String()
+	resent := node.Generate().String()
+	changed := node.Generate().String()
+
+	cases := []struct {
+		name       string
+		sequenceID string
+		data       string
+		updated    bool
+	}{
+		{name: "first status", sequenceID: first, data: appliedStatusData, updated: true},
+		{name: "resent status", sequenceID: resent, data: appliedStatusData, updated: false},
+		{name: "stale status", sequenceID: older, updated: false},
+		{name: "changed status", sequenceID: changed, updated: true},
+	}
+
+	for _, c := range cases {
+		_, updated, svcErr := resourceService.UpdateStatus(ctx, &api.Resource{Meta: api.Meta{ID: Breviceratops}, Version: 1,
+			Status: newStatusWithData(t, c.sequenceID, c.data)})
+		gm.Expect(svcErr).To(gm.BeNil(), c.name)
+		gm.Expect(updated).To(gm.Equal(c.updated), c.name)
+	}
+
+	found, err := resourceDAO.Get(ctx, Breviceratops)
+	gm.Expect(err).To(gm.BeNil())
+	gm.Expect(found.Status["sequenceid"]).To(gm.Equal(changed))
+	gm.Expect(testutil.ToFloat64(resourceStatusNoopCountMetric.WithLabelValues(string(api.ResourceTypeSingle)))).To(gm.Equal(float64(1)))
+	gm.Expect(testutil.ToFloat64(resourceStaleStatusCountMetric.WithLabelValues(string(api.ResourceTypeSingle)))).To(gm.Equal(float64(1)))
+}
+
+func TestUpdateStatusKeepsSpec(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	ctx := context.Background()
+	resourceDAO := mocks.NewResourceDao()
+	events := NewEventService(mocks.NewEventDao())
+	resourceService := newTestResourceService(resourceDAO, events, ResourceServiceOptions{})
+
+	payload := newPayload(t, "{\"id\":\"spec\"}")
+	_, err := resourceDAO.Create(ctx, &api.Resource{Meta: api.Meta{ID: Breviceratops}, ConsumerName: Fukuisaurus, Type: api.ResourceTypeSingle, Version: 2, Payload: payload})
+	gm.Expect(err).To(gm.BeNil())
+
+	node, err := snowflake.NewNode(1)
+	gm.Expect(err).To(gm.BeNil())
+
+	// the status of a stale version is not written
+	_, updated, svcErr := resourceService.UpdateStatus(ctx, &api.Resource{Meta: api.Meta{ID: Breviceratops}, Version: 1,
+		Status: newStatusWithData(t, node.Generate().String(), appliedStatusData)})
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(updated).To(gm.BeFalse())
+
+	// a status event carries the spec fields of the agent, they must not overwrite the spec of the resource
+	status := newStatusWithData(t, node.Generate().String(), appliedStatusData)
+	_, updated, svcErr = resourceService.UpdateStatus(ctx, &api.Resource{Meta: api.Meta{ID: Breviceratops}, Version: 2,
+		Payload: newPayload(t, "{\"id\":\"agent\"}"), Status: status})
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(updated).To(gm.BeTrue())
+
+	found, err := resourceDAO.Get(ctx, Breviceratops)
+	gm.Expect(err).To(gm.BeNil())
+	gm.Expect(found.Version).To(gm.Equal(int64(2)))
+	gm.Expect(found.Payload).To(gm.Equal(payload))
+	gm.Expect(found.Status).To(gm.Equal(status))
+}
+
+func TestResourceCircuitBreaker(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	ctx := context.Background()
+	breaker := db.NewCircuitBreaker(1, time.Hour, 1)
+	resourceDAO := dao.NewCircuitBreakerResourceDao(mocks.NewResourceDao(), breaker)
+	events := NewEventService(mocks.NewEventDao())
+	resourceService := newTestResourceService(resourceDAO, events, ResourceServiceOptions{})
+
+	_, err := resourceDAO.Create(ctx, &api.Resource{Meta: api.Meta{ID: Breviceratops}, ConsumerName: Fukuisaurus, Type: api.ResourceTypeSingle, Version: 1})
+	gm.Expect(err).To(gm.BeNil())
+	_, svcErr := resourceService.Get(ctx, Breviceratops)
+	gm.Expect(svcErr).To(gm.BeNil())
+
+	// the not found resource is not a database failure
+	_, svcErr = resourceService.Get(ctx, Seismosaurus)
+	gm.Expect(svcErr.Is404()).To(gm.BeTrue())
+	gm.Expect(breaker.State()).To(gm.Equal(db.CircuitBreakerClosed))
+
+	gm.Expect(breaker.Allow()).To(gm.Succeed())
+	breaker.Record(driver.ErrBadConn)
+	_, svcErr = resourceService.Get(ctx, Breviceratops)
+	gm.Expect(svcErr.IsUnavailable()).To(gm.BeTrue())
+	_, svcErr = resourceService.Create(ctx, &api.Resource{ConsumerName: Fukuisaurus, Type: api.ResourceTypeSingle,
+		Payload: newPayload(t, "{\"id\":\"75479c10-b537-4261-8058-ca2e36bac384\",\"specversion\":\"1.0\",\"data\":{\"manifest\":{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"test\",\"namespace\":\"test\"}}}}")})
+	gm.Expect(svcErr.IsUnavailable()).To(gm.BeTrue())
+}
+
+// appliedStatusData is the status data of a resource that is applied.
+const appliedStatusData = "{\"conditions\":[],\"status\":{\"conditions\":[{\"type\":\"Applied\",\"status\":\"True\",\"lastTransitionTime\":\"2024-01-01T00:00:00Z\",\"reason\":\"\",\"message\":\"\"}]}}"
+
+func newStatus(t *testing.T, sequenceID string) datatypes.JSONMap {
+	return newStatusWithData(t, sequenceID, "")
+}
+
+// newStatusWithData returns a status with the given data, the data defaults to the status without conditions.
+func newStatusWithData(t *testing.T, sequenceID, data string) datatypes.JSONMap {
+	extension := ""
+	if sequenceID != "" {
+		extension = fmt.Sprintf("\"sequenceid\":\"%s\",", sequenceID)
+	}
+	if data == "" {
+		data = "{\"conditions\":[]}"
+	}
+	return newPayload(t, fmt.Sprintf("{\"specversion\":\"1.0\",\"id\":\"%s\",\"source\":\"test\",%s\"type\":\"io.open-cluster-management.works.v1alpha1.manifests.status.update_request\",\"datacontenttype\":\"application/json\",\"data\":%s}", api.NewID(), extension, data))
+}
+
+func TestResourceChurnMetrics(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	ResetResourceMetrics()
+	defer ResetResourceMetrics()
+
+	ctx := context.Background()
+	resourceDAO := mocks.NewResourceDao()
+	events := NewEventService(mocks.NewEventDao())
+	resourceService := newTestResourceService(resourceDAO, events, ResourceServiceOptions{})
+
+	resource, svcErr := resourceService.Create(ctx, &api.Resource{
+		Meta:         api.Meta{ID: Breviceratops},
+		ConsumerName: Fukuisaurus,
+		Source:       "maestro",
+		Type:         api.ResourceTypeSingle,
+		Version:      1,
+		Payload:      newPayload(t, "{\"id\":\"75479c10-b537-4261-8058-ca2e36bac384\",\"time\":\"2024-03-07T03:29:03.194843266Z\",\"type\":\"io.open-cluster-management.works.v1alpha1.manifests.spec.create_request\",\"source\":\"maestro\",\"specversion\":\"1.0\",\"datacontenttype\":\"application/json\",\"data\":{\"manifest\":{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"test\",\"namespace\":\"test\"}}}}"),
+	})
+	gm.Expect(svcErr).To(gm.BeNil())
+
+	_, svcErr = resourceService.Update(ctx, &api.Resource{
+		Meta:    api.Meta{ID: resource.ID},
+		Type:    api.ResourceTypeSingle,
+		Version: resource.Version,
+		Payload: newPayload(t, "{\"id\":\"75479c10-b537-4261-8058-ca2e36bac384\",\"time\":\"2024-03-07T03:29:03.194843266Z\",\"type\":\"io.open-cluster-management.works.v1alpha1.manifests.spec.create_request\",\"source\":\"maestro\",\"specversion\":\"1.0\",\"datacontenttype\":\"application/json\",\"data\":{\"manifest\":{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"test\",\"namespace\":\"test\"},\"data\":{\"test\":\"test\"}}}}"),
+	})
+	gm.Expect(svcErr).To(gm.BeNil())
+
+	gm.Expect(resourceService.MarkAsDeleting(ctx, resource.ID)).To(gm.BeNil())
+	// the resource that is already deleting is not counted again
+	gm.Expect(resourceService.MarkAsDeleting(ctx, resource.ID)).To(gm.BeNil())
+
+	gm.Expect(testutil.ToFloat64(resourceCreatesCountMetric.WithLabelValues(Fukuisaurus, "maestro", "ServerSideApply"))).To(gm.Equal(1.0))
+	gm.Expect(testutil.ToFloat64(resourceUpdatesCountMetric.WithLabelValues(Fukuisaurus, "maestro", "ServerSideApply"))).To(gm.Equal(1.0))
+	gm.Expect(testutil.ToFloat64(resourceDeletesCountMetric.WithLabelValues(Fukuisaurus, "maestro", "ServerSideApply"))).To(gm.Equal(1.0))
+
+	// the sources beyond the limit are counted with the overflow source
+	for i := 0; i < maxChurnMetricsSources; i++ {
+		churnMetricsSources.value(fmt.Sprintf("source-%d", i))
+	}
+	labels := resourceChurnLabels(&api.Resource{ConsumerName: Fukuisaurus, Source: "new-source"})
+	gm.Expect(labels[metricsSourceLabel]).To(gm.Equal(churnMetricsOverflowSource))
+	labels = resourceChurnLabels(&api.Resource{ConsumerName: Fukuisaurus, Source: "maestro"})
+	gm.Expect(labels[metricsSourceLabel]).To(gm.Equal("maestro"))
+	gm.Expect(labels[metricsUpdateStrategyLabel]).To(gm.Equal(api.UpdateStrategyUnknown))
+}
+
+func TestResourceQuarantine(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	ctx := context.Background()
+	resourceDAO := mocks.NewResourceDao()
+	events := NewEventService(mocks.NewEventDao())
+	resourceService := newTestResourceService(resourceDAO, events, ResourceServiceOptions{QuarantineThreshold: 3})
+
+	_, err := resourceDAO.Create(ctx, &api.Resource{Meta: api.Meta{ID: Breviceratops}, ConsumerName: Fukuisaurus, Type: api.ResourceTypeSingle, Version: 1})
+	gm.Expect(err).To(gm.BeNil())
+
+	_, svcErr := resourceService.ReleaseQuarantine(ctx, Breviceratops)
+	gm.Expect(svcErr).NotTo(gm.BeNil())
+	gm.Expect(svcErr.IsConflict()).To(gm.BeTrue())
+
+	node, err := snowflake.NewNode(1)
+	gm.Expect(err).To(gm.BeNil())
+
+	cases := []struct {
+		name        string
+		data        string
+		failures    int32
+		quarantined bool
+	}{
+		{name: "first failure", data: failedStatusData, failures: 1},
+		{name: "resent failure", data: failedStatusData, failures: 2},
+		{name: "applied status", data: appliedStatusData, failures: 0},
+		{name: "failure after applied", data: failedStatusData, failures: 1},
+		{name: "second failure", data: failedStatusData, failures: 2},
+		{name: "third failure", data: failedStatusData, failures: 3, quarantined: true},
+		{name: "failure after quarantined", data: failedStatusData, failures: 3, quarantined: true},
+	}
+
+	for _, c := range cases {
+		_, _, svcErr := resourceService.UpdateStatus(ctx, &api.Resource{Meta: api.Meta{ID: Breviceratops}, Version: 1,
+			Status: newStatusWithData(t, node.Generate().String(), c.data)})
+		gm.Expect(svcErr).To(gm.BeNil(), c.name)
+
+		found, err := resourceDAO.Get(ctx, Breviceratops)
+		gm.Expect(err).To(gm.BeNil(), c.name)
+		gm.Expect(found.ReconcileFailures).To(gm.Equal(c.failures), c.name)
+		gm.Expect(found.IsQuarantined()).To(gm.Equal(c.quarantined), c.name)
+	}
+
+	counts, svcErr := resourceService.CountQuarantined(ctx)
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(counts).To(gm.Equal(map[api.ResourceType]int{api.ResourceTypeSingle: 1}))
+
+	released, svcErr := resourceService.ReleaseQuarantine(ctx, Breviceratops)
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(released.IsQuarantined()).To(gm.BeFalse())
+	gm.Expect(released.ReconcileFailures).To(gm.Equal(int32(0)))
+	// the released resource is re-broadcast with a new version
+	gm.Expect(released.Version).To(gm.Equal(int64(2)))
+}
+
+func TestReleaseQuarantineUnderDeletion(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	ctx := context.Background()
+	resourceDAO := mocks.NewResourceDao()
+	events := NewEventService(mocks.NewEventDao())
+	resourceService := newTestResourceService(resourceDAO, events, ResourceServiceOptions{QuarantineThreshold: 3})
+
+	quarantinedAt := time.Now()
+	_, err := resourceDAO.Create(ctx, &api.Resource{Meta: api.Meta{ID: Breviceratops, DeletedAt: gorm.DeletedAt{Time: time.Now(), Valid: true}},
+		ConsumerName: Fukuisaurus, Type: api.ResourceTypeSingle, Version: 1, ReconcileFailures: 3, QuarantinedAt: &quarantinedAt})
+	gm.Expect(err).To(gm.BeNil())
+
+	_, svcErr := resourceService.ReleaseQuarantine(ctx, Breviceratops)
+	gm.Expect(svcErr).NotTo(gm.BeNil())
+	gm.Expect(svcErr.IsConflict()).To(gm.BeTrue())
+
+	// the resource is kept quarantined as it is not reconciled
+	found, err := resourceDAO.Get(ctx, Breviceratops)
+	gm.Expect(err).To(gm.BeNil())
+	gm.Expect(found.IsQuarantined()).To(gm.BeTrue())
+	gm.Expect(found.ReconcileFailures).To(gm.Equal(int32(3)))
+	gm.Expect(found.Version).To(gm.Equal(int64(1)))
+}
+
+// failedStatusData is the status data of a resource that failed to apply.
+const failedStatusData = "{\"conditions\":[],\"status\":{\"conditions\":[{\"type\":\"Applied\",\"status\":\"False\",\"lastTransitionTime\":\"2024-01-01T00:00:00Z\",\"reason\":\"AppliedManifestFailed\",\"message\":\"\"}]}}"
+
+func TestReconcileStaleConditionCleared(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	ctx := context.Background()
+	resourceDAO := mocks.NewResourceDao()
+	events := NewEventService(mocks.NewEventDao())
+	resourceService := newTestResourceService(resourceDAO, events, ResourceServiceOptions{})
+
+	specUpdatedAt := time.Now().Add(-time.Hour)
+	_, err := resourceDAO.Create(ctx, &api.Resource{Meta: api.Meta{ID: Breviceratops, UpdatedAt: specUpdatedAt},
+		ConsumerName: Fukuisaurus, Type: api.ResourceTypeSingle, Version: 1, SpecUpdatedAt: &specUpdatedAt})
+	gm.Expect(err).To(gm.BeNil())
+
+	marked, svcErr := resourceService.MarkReconcileStale(ctx, time.Now().Add(-10*time.Minute))
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(marked).To(gm.Equal(int64(1)))
+
+	found, err := resourceDAO.Get(ctx, Breviceratops)
+	gm.Expect(err).To(gm.BeNil())
+	gm.Expect(found.Conditions).To(gm.Equal(pq.StringArray{api.ReconcileStaleCondition}))
+	presented, err := presenters.PresentResource(found)
+	gm.Expect(err).To(gm.BeNil())
+	gm.Expect(presented.GetReconcileStale()).To(gm.BeTrue())
+
+	node, err := snowflake.NewNode(1)
+	gm.Expect(err).To(gm.BeNil())
+	_, updated, svcErr := resourceService.UpdateStatus(ctx, &api.Resource{Meta: api.Meta{ID: Breviceratops}, Version: 1,
+		Status: newStatusWithData(t, node.Generate().String(), appliedStatusData)})
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(updated).To(gm.BeTrue())
+
+	// the synthetic condition is cleared by the status of the agent
+	found, err = resourceDAO.Get(ctx, Breviceratops)
+	gm.Expect(err).To(gm.BeNil())
+	gm.Expect(found.Conditions).To(gm.Equal(pq.StringArray{"Applied=True"}))
+	gm.Expect(found.IsReconcileStale()).To(gm.BeFalse())
+
+	// a new version of the spec restarts the reconcile timeout
+	_, svcErr = resourceService.Reconcile(ctx, Breviceratops)
+	gm.Expect(svcErr).To(gm.BeNil())
+	found, err = resourceDAO.Get(ctx, Breviceratops)
+	gm.Expect(err).To(gm.BeNil())
+	gm.Expect(found.SpecUpdatedAt.After(specUpdatedAt)).To(gm.BeTrue())
+}
+
+func TestConsumerReconcileDurationFromSpecChange(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	ResetConsumerMetrics()
+	defer ResetConsumerMetrics()
+	SetConsumerMetricsMode(ConsumerMetricsModeConsumer)
+	defer SetConsumerMetricsMode(ConsumerMetricsModeNone)
+
+	ctx := context.Background()
+	resourceDAO := mocks.NewResourceDao()
+	resourceService := newTestResourceService(resourceDAO, NewEventService(mocks.NewEventDao()), ResourceServiceOptions{})
+
+	// the status of the resource is updated recently, but its spec is changed an hour ago
+	specUpdatedAt := time.Now().Add(-time.Hour)
+	_, err := resourceDAO.Create(ctx, &api.Resource{Meta: api.Meta{ID: Breviceratops, UpdatedAt: time.Now()},
+		ConsumerName: Fukuisaurus, Type: api.ResourceTypeSingle, Version: 1, SpecUpdatedAt: &specUpdatedAt,
+		UpdateStrategy: api.UpdateStrategyMixed})
+	gm.Expect(err).To(gm.BeNil())
+
+	node, err := snowflake.NewNode(1)
+	gm.Expect(err).To(gm.BeNil())
+	status := newPayload(t, fmt.Sprintf("{\"specversion\":\"1.0\",\"id\":\"%s\",\"source\":\"test\",\"sequenceid\":\"%s\",\"resourceversion\":1,\"type\":\"io.open-cluster-management.works.v1alpha1.manifests.status.update_request\",\"datacontenttype\":\"application/json\",\"data\":%s}",
+		api.NewID(), node.Generate().String(), appliedStatusData))
+	_, updated, svcErr := resourceService.UpdateStatus(ctx, &api.Resource{Meta: api.Meta{ID: Breviceratops}, Version: 1, Status: status})
+	gm.Expect(svcErr).To(gm.BeNil())
+	gm.Expect(updated).To(gm.BeTrue())
+
+	// the reconcile is timed from the spec change rather than the last status update
+	metric := &dto.Metric{}
+	observer := consumerReconcileDurationMetric.WithLabelValues(Fukuisaurus, api.UpdateStrategyMixed)
+	gm.Expect(observer.(prometheus.Metric).Write(metric)).To(gm.Succeed())
+	gm.Expect(metric.GetHistogram().GetSampleCount()).To(gm.Equal(uint64(1)))
+	gm.Expect(metric.GetHistogram().GetSampleSum()).To(gm.BeNumerically(">=", time.Hour.Seconds()))
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"time"
 
 	"github.com/openshift-online/maestro/pkg/api"
 	"github.com/openshift-online/maestro/pkg/dao"
@@ -19,6 +20,9 @@ type StatusEventService interface {
 	FindAllUnreconciledEvents(ctx context.Context) (api.StatusEventList, *errors.ServiceError)
 	DeleteAllReconciledEvents(ctx context.Context) *errors.ServiceError
 	DeleteAllEvents(ctx context.Context, eventIDs []string) *errors.ServiceError
+	// DeleteCreatedBefore deletes the status events created before the given time regardless of whether they are
+	// reconciled, it returns the number of the deleted status events.
+	DeleteCreatedBefore(ctx context.Context, before time.Time) (int64, *errors.ServiceError)
 }
 
 func NewStatusEventService(statusEventDao dao.StatusEventDao) StatusEventService {
@@ -101,3 +105,11 @@ func (s *sqlStatusEventService) DeleteAllEvents(ctx context.Context, eventIDs []
 	}
 	return nil
 }
+
+func (s *sqlStatusEventService) DeleteCreatedBefore(ctx context.Context, before time.Time) (int64, *errors.ServiceError) {
+	deleted, err := s.statusEventDao.DeleteCreatedBefore(ctx, before)
+	if err != nil {
+		return 0, handleDeleteError("StatusEvent", errors.GeneralError("Unable to delete status events created before %s: %s", before, err))
+	}
+	return deleted, nil
+}
@@ -15,6 +15,15 @@ type ListArguments struct {
 	Search   string
 	OrderBy  []string
 	Fields   []string
+	// Conditions are the "<type>=<status>" pairs that the listed resources must have in their conditions summary,
+	// it only applies to the resources.
+	Conditions []string
+	// Labels are the "<key>=<value>" pairs that the listed resources must have in their labels, it only applies to
+	// the resources.
+	Labels []string
+	// LabelKeys are the label keys that the listed consumers must have in their labels, it only applies to the
+	// consumers.
+	LabelKeys []string
 }
 
 // ~65500 is the maximum number of parameters that can be provided to a postgres WHERE IN clause
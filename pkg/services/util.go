@@ -1,12 +1,20 @@
 package services
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	e "errors"
+	"fmt"
 	"strings"
 
 	"github.com/bwmarrin/snowflake"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	cloudeventstypes "github.com/cloudevents/sdk-go/v2/types"
 	"gorm.io/gorm"
+	cetypes "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/types"
 
+	"github.com/openshift-online/maestro/pkg/db"
 	"github.com/openshift-online/maestro/pkg/errors"
 )
 
@@ -30,10 +38,16 @@ func handleGetError(resourceType, field string, value interface{}, err error) *e
 	if e.Is(err, gorm.ErrRecordNotFound) {
 		return errors.NotFound("%s with %s='%v' not found", resourceType, field, value)
 	}
+	if e.Is(err, db.ErrCircuitOpen) {
+		return errors.Unavailable("Unable to find %s with %s='%v': %s", resourceType, field, value, err)
+	}
 	return errors.GeneralError("Unable to find %s with %s='%v': %s", resourceType, field, value, err)
 }
 
 func handleCreateError(resourceType string, err error) *errors.ServiceError {
+	if e.Is(err, db.ErrCircuitOpen) {
+		return errors.Unavailable("Unable to create %s: %s", resourceType, err)
+	}
 	if strings.Contains(err.Error(), "violates unique constraint") {
 		return errors.Conflict("This %s already exists", resourceType)
 	}
@@ -41,6 +55,9 @@ func handleCreateError(resourceType string, err error) *errors.ServiceError {
 }
 
 func handleUpdateError(resourceType string, err error) *errors.ServiceError {
+	if e.Is(err, db.ErrCircuitOpen) {
+		return errors.Unavailable("Unable to update %s: %s", resourceType, err)
+	}
 	if strings.Contains(err.Error(), "violates unique constraint") {
 		return errors.Conflict("Changes to %s conflict with existing records", resourceType)
 	}
@@ -54,6 +71,16 @@ func handleDeleteError(resourceType string, err error) *errors.ServiceError {
 	return errors.GeneralError("Unable to delete %s: %s", resourceType, err.Error())
 }
 
+// statusSequenceID returns the status update sequence ID of the status event, it returns an empty string if the
+// event has no sequence ID.
+func statusSequenceID(evt *cloudevents.Event) (string, error) {
+	val, ok := evt.Extensions()[cetypes.ExtensionStatusUpdateSequenceID]
+	if !ok {
+		return "", nil
+	}
+	return cloudeventstypes.ToString(val)
+}
+
 // compareSequenceIDs compares two snowflake sequence IDs and returns true if the first ID is greater than the second.
 func compareSequenceIDs(sequenceID1, sequenceID2 string) (bool, error) {
 	// If the second sequence ID is empty, then the first is greater
@@ -79,3 +106,40 @@ func compareSequenceIDs(sequenceID1, sequenceID2 string) (bool, error) {
 
 	return id1.Step() > id2.Step(), nil
 }
+
+// statusHash returns the hash of the status event content, i.e. the observed resource version and the status data.
+// The event ID, time and sequence ID are different each time the agent sends the status, so they are not hashed,
+// and the status resent with the same content has the same hash.
+func statusHash(evt *cloudevents.Event) (string, error) {
+	var data interface{}
+	if len(evt.Data()) != 0 {
+		if err := json.Unmarshal(evt.Data(), &data); err != nil {
+			return "", fmt.Errorf("failed to decode status data: %v", err)
+		}
+	}
+
+	// the map keys are sorted when marshaling, so the hash doesn't depend on the order of the status fields
+	content, err := json.Marshal(map[string]interface{}{
+		"resourceVersion": evt.Extensions()[cetypes.ExtensionResourceVersion],
+		"data":            data,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode status content: %v", err)
+	}
+
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// sameStatus returns true if the two status events have the same content, see statusHash.
+func sameStatus(evt1, evt2 *cloudevents.Event) (bool, error) {
+	hash1, err := statusHash(evt1)
+	if err != nil {
+		return false, err
+	}
+	hash2, err := statusHash(evt2)
+	if err != nil {
+		return false, err
+	}
+	return hash1 == hash2, nil
+}
@@ -6,7 +6,9 @@ import (
 
 	"github.com/openshift-online/maestro/pkg/api"
 	"gorm.io/datatypes"
+	workv1 "open-cluster-management.io/api/work/v1"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	v1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
@@ -40,17 +42,70 @@ func ValidateConsumer(consumer *api.Consumer) error {
 	return fmt.Errorf(errs.ToAggregate().Error())
 }
 
+// ValidateConsumerLabels validates the label syntax of the consumer labels.
+func ValidateConsumerLabels(consumer *api.Consumer) error {
+	if consumer.Labels == nil {
+		return nil
+	}
+
+	errs := v1validation.ValidateLabels(*consumer.Labels, field.NewPath("consumer").Child("labels"))
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf(errs.ToAggregate().Error())
+}
+
+// ValidateConsumerGroup validates the consumer group name, the group label key must be a valid label key.
+func ValidateConsumerGroup(group string) error {
+	errs := field.ErrorList{}
+	if len(group) == 0 {
+		errs = append(errs, field.Required(field.NewPath("group").Child("name"), "the consumer group name is required"))
+	}
+	for _, msg := range utilvalidation.IsQualifiedName(api.ConsumerGroupLabel(group)) {
+		errs = append(errs, field.Invalid(field.NewPath("group").Child("name"), group, msg))
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf(errs.ToAggregate().Error())
+}
+
+// ValidateConsumerLabelsPatch validates the label syntax of the replaced and added labels, a label key cannot be both
+// added and removed.
+func ValidateConsumerLabelsPatch(patch ConsumerLabelsPatch) error {
+	errs := field.ErrorList{}
+	errs = append(errs, v1validation.ValidateLabels(patch.Replace, field.NewPath("labels"))...)
+	errs = append(errs, v1validation.ValidateLabels(patch.Add, field.NewPath("add_labels"))...)
+	for i, key := range patch.Remove {
+		if _, ok := patch.Add[key]; ok {
+			errs = append(errs, field.Invalid(field.NewPath("remove_labels").Index(i), key, "the label is also added"))
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf(errs.ToAggregate().Error())
+}
+
 func ValidateManifest(resType api.ResourceType, manifest datatypes.JSONMap) error {
 	switch resType {
 	case api.ResourceTypeSingle:
-		// TODO: validate the deleteOption and updateStrategy
-		obj, _, _, err := api.DecodeManifest(manifest)
+		// TODO: validate the deleteOption
+		obj, _, updateStrategy, err := api.DecodeManifest(manifest)
 		if err != nil {
 			return fmt.Errorf("failed to decode manifest: %v", err)
 		}
 		if len(obj) == 0 {
 			return fmt.Errorf("manifest is empty")
 		}
+		if err := api.ValidateUpdateStrategy(updateStrategy); err != nil {
+			return err
+		}
 		return ValidateObject(obj)
 	case api.ResourceTypeBundle:
 		objs, err := api.DecodeManifestBundleToObjects(manifest)
@@ -65,6 +120,13 @@ func ValidateManifest(resType api.ResourceType, manifest datatypes.JSONMap) erro
 				return err
 			}
 		}
+		_, manifestBundle, err := api.DecodeManifestBundle(manifest)
+		if err != nil {
+			return fmt.Errorf("failed to decode manifest bundle: %v", err)
+		}
+		if err := ValidateManifestConfigs(objs, manifestBundle.ManifestConfigs); err != nil {
+			return err
+		}
 	default:
 		return fmt.Errorf("unknown resource type: %s", resType)
 	}
@@ -72,6 +134,263 @@ func ValidateManifest(resType api.ResourceType, manifest datatypes.JSONMap) erro
 	return nil
 }
 
+// ValidateManifestConfigs validates each manifest config of a resource bundle references a manifest of the bundle
+// and has valid feedback rules and update strategy, so a dangling or an invalid config is rejected rather than being
+// ignored by the agent. The resource of the resource identifier is matched with the lowercase kind of the manifest or
+// its plural form guessed from the kind, e.g. deployments for a Deployment.
+func ValidateManifestConfigs(objs []map[string]interface{}, configs []workv1.ManifestConfigOption) error {
+	errs := field.ErrorList{}
+	for i, config := range configs {
+		fldPath := field.NewPath("manifestConfigs").Index(i)
+		identifier := config.ResourceIdentifier
+		idPath := fldPath.Child("resourceIdentifier")
+		if len(identifier.Resource) == 0 {
+			errs = append(errs, field.Required(idPath.Child("resource"), "field not set"))
+		}
+		if len(identifier.Name) == 0 {
+			errs = append(errs, field.Required(idPath.Child("name"), "field not set"))
+		}
+		if len(identifier.Resource) != 0 && len(identifier.Name) != 0 && !manifestConfigMatches(objs, identifier) {
+			errs = append(errs, field.Invalid(idPath, resourceIdentifierString(identifier), "no manifest in the bundle matches the resource identifier"))
+		}
+
+		for j, rule := range config.FeedbackRules {
+			rulePath := fldPath.Child("feedbackRules").Index(j)
+			switch rule.Type {
+			case workv1.WellKnownStatusType:
+			case workv1.JSONPathsType:
+				if len(rule.JsonPaths) == 0 {
+					errs = append(errs, field.Required(rulePath.Child("jsonPaths"), "the jsonPaths are required by the JSONPaths type"))
+				}
+				for k, jsonPath := range rule.JsonPaths {
+					if len(jsonPath.Name) == 0 {
+						errs = append(errs, field.Required(rulePath.Child("jsonPaths").Index(k).Child("name"), "field not set"))
+					}
+					if len(jsonPath.Path) == 0 {
+						errs = append(errs, field.Required(rulePath.Child("jsonPaths").Index(k).Child("path"), "field not set"))
+					}
+				}
+			default:
+				errs = append(errs, field.NotSupported(rulePath.Child("type"), rule.Type,
+					[]string{string(workv1.WellKnownStatusType), string(workv1.JSONPathsType)}))
+			}
+		}
+
+		if config.UpdateStrategy != nil {
+			strategyPath := fldPath.Child("updateStrategy")
+			if err := api.ValidateUpdateStrategyType(config.UpdateStrategy.Type); err != nil {
+				errs = append(errs, field.Invalid(strategyPath.Child("type"), config.UpdateStrategy.Type, err.Error()))
+			}
+			if ssa := config.UpdateStrategy.ServerSideApply; ssa != nil {
+				if len(ssa.FieldManager) != 0 && !strings.HasPrefix(ssa.FieldManager, workv1.DefaultFieldManager) {
+					errs = append(errs, field.Invalid(strategyPath.Child("serverSideApply", "fieldManager"), ssa.FieldManager,
+						fmt.Sprintf("the field manager must start with %s", workv1.DefaultFieldManager)))
+				}
+				for k, ignoreField := range ssa.IgnoreFields {
+					ignorePath := strategyPath.Child("serverSideApply", "ignoreFields").Index(k)
+					if ignoreField.Condition != workv1.IgnoreFieldsConditionOnSpokePresent &&
+						ignoreField.Condition != workv1.IgnoreFieldsConditionOnSpokeChange {
+						errs = append(errs, field.NotSupported(ignorePath.Child("condition"), ignoreField.Condition,
+							[]string{string(workv1.IgnoreFieldsConditionOnSpokePresent), string(workv1.IgnoreFieldsConditionOnSpokeChange)}))
+					}
+					if len(ignoreField.JSONPaths) == 0 {
+						errs = append(errs, field.Required(ignorePath.Child("jsonPaths"), "field not set"))
+					}
+				}
+			}
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf(errs.ToAggregate().Error())
+}
+
+// manifestConfigMatches tells whether a manifest of the bundle has the group, resource, name and namespace of the
+// resource identifier.
+func manifestConfigMatches(objs []map[string]interface{}, identifier workv1.ResourceIdentifier) bool {
+	for _, obj := range objs {
+		unstructuredObj := unstructured.Unstructured{Object: obj}
+		gvk := unstructuredObj.GroupVersionKind()
+		if gvk.Group != identifier.Group || unstructuredObj.GetName() != identifier.Name ||
+			unstructuredObj.GetNamespace() != identifier.Namespace {
+			continue
+		}
+		plural, singular := meta.UnsafeGuessKindToResource(gvk)
+		if strings.EqualFold(identifier.Resource, plural.Resource) || strings.EqualFold(identifier.Resource, singular.Resource) {
+			return true
+		}
+	}
+	return false
+}
+
+// resourceIdentifierString returns the resource identifier in the <resource>.<group>/<namespace>/<name> format.
+func resourceIdentifierString(identifier workv1.ResourceIdentifier) string {
+	resource := identifier.Resource
+	if len(identifier.Group) != 0 {
+		resource = resource + "." + identifier.Group
+	}
+	if len(identifier.Namespace) == 0 {
+		return resource + "/" + identifier.Name
+	}
+	return resource + "/" + identifier.Namespace + "/" + identifier.Name
+}
+
+// ValidateManifestBundleSize validates the number of the manifests in a resource bundle doesn't exceed the
+// maxManifests, there is no limit if the maxManifests is 0.
+func ValidateManifestBundleSize(resType api.ResourceType, manifest datatypes.JSONMap, maxManifests int) error {
+	if resType != api.ResourceTypeBundle || maxManifests <= 0 {
+		return nil
+	}
+
+	_, manifestBundle, err := api.DecodeManifestBundle(manifest)
+	if err != nil {
+		return fmt.Errorf("failed to decode manifest bundle: %v", err)
+	}
+	if manifestBundle == nil {
+		return nil
+	}
+
+	if count := len(manifestBundle.Manifests); count > maxManifests {
+		return fmt.Errorf("the manifest bundle has %d manifests, which exceeds the limit of %d", count, maxManifests)
+	}
+
+	return nil
+}
+
+// ManifestLimits are the limits of the resource manifests, the manifests exceeding the limits are rejected before they
+// are written to the database. A limit is disabled if it is 0 or empty.
+type ManifestLimits struct {
+	// MaxBundleManifests is the max number of the manifests in a resource bundle, see ValidateManifestBundleSize.
+	MaxBundleManifests int
+	// MaxDepth is the max nesting depth of the resource manifest, see ValidateManifestComplexity.
+	MaxDepth int
+	// MaxKeys is the max number of the object keys in the resource manifest, see ValidateManifestComplexity.
+	MaxKeys int
+	// AllowedKinds and DeniedKinds are the kind patterns of the manifests that are allowed and denied, see
+	// ValidateManifestKinds.
+	AllowedKinds api.KindPatterns
+	DeniedKinds  api.KindPatterns
+	// ImmutableFields are the manifest fields that cannot be changed by an update, see
+	// ValidateManifestImmutableFields.
+	ImmutableFields api.ImmutableFields
+}
+
+// ValidateManifestKinds validates the kinds of the resource manifests are allowed, see api.ParseKindPatterns. A denied
+// kind is rejected even if it is allowed, and all the kinds that are not denied are allowed if there is no allowed
+// kind.
+func ValidateManifestKinds(resType api.ResourceType, manifest datatypes.JSONMap, allowed, denied api.KindPatterns) error {
+	if len(allowed) == 0 && len(denied) == 0 {
+		return nil
+	}
+
+	objs, err := decodeManifestObjects(resType, manifest)
+	if err != nil {
+		return err
+	}
+
+	for _, obj := range objs {
+		gvk := (&unstructured.Unstructured{Object: obj}).GroupVersionKind()
+		kind := gvk.GroupVersion().String() + "/" + gvk.Kind
+		if denied.Matches(gvk) {
+			return fmt.Errorf("the kind %s is denied", kind)
+		}
+		if len(allowed) != 0 && !allowed.Matches(gvk) {
+			return fmt.Errorf("the kind %s is not allowed", kind)
+		}
+	}
+	return nil
+}
+
+// ValidateManifestImmutableFields validates the immutable fields of the resource manifests are not changed from the
+// old manifests, see api.ImmutableFields. The manifests of a bundle are compared in their order, which is kept by the
+// update, see ValidateManifestUpdate.
+func ValidateManifestImmutableFields(resType api.ResourceType, new, old datatypes.JSONMap, fields api.ImmutableFields) error {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	newObjs, err := decodeManifestObjects(resType, new)
+	if err != nil {
+		return err
+	}
+	oldObjs, err := decodeManifestObjects(resType, old)
+	if err != nil {
+		return err
+	}
+	for i := range newObjs {
+		if i >= len(oldObjs) {
+			break
+		}
+		if err := fields.Validate(oldObjs[i], newObjs[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeManifestObjects decodes the objects of the resource manifest, a single resource has one object.
+func decodeManifestObjects(resType api.ResourceType, manifest datatypes.JSONMap) ([]map[string]interface{}, error) {
+	if resType == api.ResourceTypeBundle {
+		objs, err := api.DecodeManifestBundleToObjects(manifest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode manifest bundle: %v", err)
+		}
+		return objs, nil
+	}
+
+	obj, _, _, err := api.DecodeManifest(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode manifest: %v", err)
+	}
+	return []map[string]interface{}{obj}, nil
+}
+
+// ValidateManifestComplexity validates the nesting depth and the number of the object keys of the resource manifest
+// don't exceed the maxDepth and the maxKeys, so the deeply nested or huge manifests are rejected with a clear error
+// rather than a database error of the JSONB column. The manifest is the CloudEvent JSONMap representation of the
+// resource manifest, so a few levels are added to the depth of the manifests. There is no limit if it is 0.
+func ValidateManifestComplexity(manifest datatypes.JSONMap, maxDepth, maxKeys int) error {
+	if maxDepth <= 0 && maxKeys <= 0 {
+		return nil
+	}
+
+	keys := 0
+	var walk func(value interface{}, depth int) error
+	walk = func(value interface{}, depth int) error {
+		var children []interface{}
+		switch v := value.(type) {
+		case map[string]interface{}:
+			keys += len(v)
+			if maxKeys > 0 && keys > maxKeys {
+				return fmt.Errorf("the number of the keys in the manifest exceeds the limit of %d", maxKeys)
+			}
+			for _, child := range v {
+				children = append(children, child)
+			}
+		case []interface{}:
+			children = v
+		default:
+			return nil
+		}
+
+		// the depth is checked before the children are walked, so a pathologically deep manifest is not walked through
+		if maxDepth > 0 && depth >= maxDepth {
+			return fmt.Errorf("the nesting depth of the manifest exceeds the limit of %d", maxDepth)
+		}
+		for _, child := range children {
+			if err := walk(child, depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return walk(map[string]interface{}(manifest), 0)
+}
+
 func ValidateObject(obj datatypes.JSONMap) error {
 	errs := field.ErrorList{}
 	unstructuredObj := unstructured.Unstructured{Object: obj}
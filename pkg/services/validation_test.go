@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/db"
 	"gorm.io/datatypes"
 )
 
@@ -48,6 +49,44 @@ func TestValidateConsumer(t *testing.T) {
 	}
 }
 
+func TestValidateConsumerLabels(t *testing.T) {
+	cases := []struct {
+		name             string
+		consumer         *api.Consumer
+		expectedErrorMsg string
+	}{
+		{
+			name:     "no labels",
+			consumer: &api.Consumer{},
+		},
+		{
+			name: "validated",
+			consumer: &api.Consumer{
+				Labels: &db.StringMap{"env": "prod", api.ConsumerGroupLabel("us-east"): "true"},
+			},
+		},
+		{
+			name: "wrong label key",
+			consumer: &api.Consumer{
+				Labels: &db.StringMap{"_env": "prod"},
+			},
+			expectedErrorMsg: "consumer.labels: Invalid value: \"_env\": name part must consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my.name',  or '123-abc', regex used for validation is '([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]')",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := ValidateConsumerLabels(c.consumer)
+			if err == nil && c.expectedErrorMsg != "" {
+				t.Errorf("expected %#v but got nil", c.expectedErrorMsg)
+			}
+			if err != nil && err.Error() != c.expectedErrorMsg {
+				t.Errorf("expected %#v but got: %#v", c.expectedErrorMsg, err)
+			}
+		})
+	}
+}
+
 func TestValidateResourceName(t *testing.T) {
 	cases := []struct {
 		name             string
@@ -109,6 +148,47 @@ func TestValidateNewManifest(t *testing.T) {
 			manifest:         newPayload(t, "{\"id\":\"266a8cd2-2fab-4e89-9bf0-a56425ebcdf8\",\"time\":\"2024-02-05T17:31:05Z\",\"type\":\"io.open-cluster-management.works.v1alpha1.manifestbundles.spec.create_request\",\"source\":\"grpc\",\"specversion\":\"1.0\",\"datacontenttype\":\"application/json\",\"resourceid\":\"c4df9ff0-bfeb-5bc6-a0ab-4c9128d698b4\",\"clustername\":\"b288a9da-8bfe-4c82-94cc-2b48e773fc46\",\"resourceversion\":1,\"data\":{\"manifests\":[{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"nginx\",\"namespace\":\"default\"}},{\"apiVersion\":\"apps/v1\",\"kind\":\"Deployment\",\"metadata\":{\"name\":\"nginx\",\"namespace\":\"default\"},\"spec\":{\"replicas\":1,\"selector\":{\"matchLabels\":{\"app\":\"nginx\"}},\"template\":{\"spec\":{\"containers\":[{\"name\":\"nginx\",\"image\":\"nginxinc/nginx-unprivileged\"}]},\"metadata\":{\"labels\":{\"app\":\"nginx\"}}}}}],\"deleteOption\":{\"propagationPolicy\":\"Foreground\"},\"manifestConfigs\":[{\"updateStrategy\":{\"type\":\"ServerSideApply\"},\"resourceIdentifier\":{\"name\":\"nginx\",\"group\":\"apps\",\"resource\":\"deployments\",\"namespace\":\"default\"}}]}}"),
 			expectedErrorMsg: "manifest is empty",
 		},
+		{
+			name:     "validated single manifest with the create only update strategy",
+			resType:  api.ResourceTypeSingle,
+			manifest: newPayload(t, "{\"id\":\"75479c10-b537-4261-8058-ca2e36bac384\",\"time\":\"2024-03-07T03:29:03.194843266Z\",\"type\":\"io.open-cluster-management.works.v1alpha1.manifests.spec.create_request\",\"source\":\"maestro\",\"specversion\":\"1.0\",\"datacontenttype\":\"application/json\",\"data\":{\"configOption\":{\"updateStrategy\":{\"type\":\"CreateOnly\"}},\"manifest\":{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"test\",\"namespace\":\"test\"}}}}"),
+		},
+		{
+			name:             "invalidated single manifest update strategy",
+			resType:          api.ResourceTypeSingle,
+			manifest:         newPayload(t, "{\"id\":\"75479c10-b537-4261-8058-ca2e36bac384\",\"time\":\"2024-03-07T03:29:03.194843266Z\",\"type\":\"io.open-cluster-management.works.v1alpha1.manifests.spec.create_request\",\"source\":\"maestro\",\"specversion\":\"1.0\",\"datacontenttype\":\"application/json\",\"data\":{\"configOption\":{\"updateStrategy\":{\"type\":\"CreateOrUpdate\"}},\"manifest\":{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"test\",\"namespace\":\"test\"}}}}"),
+			expectedErrorMsg: "unsupported update strategy type CreateOrUpdate, the supported types are [ServerSideApply Update CreateOnly ReadOnly]",
+		},
+		{
+			name:             "invalidated bundle manifest update strategy",
+			resType:          api.ResourceTypeBundle,
+			manifest:         newPayload(t, "{\"id\":\"266a8cd2-2fab-4e89-9bf0-a56425ebcdf8\",\"time\":\"2024-02-05T17:31:05Z\",\"type\":\"io.open-cluster-management.works.v1alpha1.manifestbundles.spec.create_request\",\"source\":\"grpc\",\"specversion\":\"1.0\",\"datacontenttype\":\"application/json\",\"resourceid\":\"c4df9ff0-bfeb-5bc6-a0ab-4c9128d698b4\",\"clustername\":\"b288a9da-8bfe-4c82-94cc-2b48e773fc46\",\"resourceversion\":1,\"data\":{\"manifests\":[{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"nginx\",\"namespace\":\"default\"}}],\"deleteOption\":{\"propagationPolicy\":\"Foreground\"},\"manifestConfigs\":[{\"updateStrategy\":{\"type\":\"CreateOrUpdate\"},\"resourceIdentifier\":{\"name\":\"nginx\",\"resource\":\"configmaps\",\"namespace\":\"default\"}}]}}"),
+			expectedErrorMsg: "manifestConfigs[0].updateStrategy.type: Invalid value: \"CreateOrUpdate\": unsupported update strategy type CreateOrUpdate, the supported types are [ServerSideApply Update CreateOnly ReadOnly]",
+		},
+		{
+			name:             "invalidated bundle manifest config without a manifest",
+			resType:          api.ResourceTypeBundle,
+			manifest:         newPayload(t, "{\"id\":\"266a8cd2-2fab-4e89-9bf0-a56425ebcdf8\",\"time\":\"2024-02-05T17:31:05Z\",\"type\":\"io.open-cluster-management.works.v1alpha1.manifestbundles.spec.create_request\",\"source\":\"grpc\",\"specversion\":\"1.0\",\"datacontenttype\":\"application/json\",\"resourceid\":\"c4df9ff0-bfeb-5bc6-a0ab-4c9128d698b4\",\"clustername\":\"b288a9da-8bfe-4c82-94cc-2b48e773fc46\",\"resourceversion\":1,\"data\":{\"manifests\":[{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"nginx\",\"namespace\":\"default\"}}],\"deleteOption\":{\"propagationPolicy\":\"Foreground\"},\"manifestConfigs\":[{\"resourceIdentifier\":{\"name\":\"nginx\",\"group\":\"apps\",\"resource\":\"deployments\",\"namespace\":\"default\"}}]}}"),
+			expectedErrorMsg: "manifestConfigs[0].resourceIdentifier: Invalid value: \"deployments.apps/default/nginx\": no manifest in the bundle matches the resource identifier",
+		},
+		{
+			name:             "invalidated bundle manifest config without a resource name",
+			resType:          api.ResourceTypeBundle,
+			manifest:         newPayload(t, "{\"id\":\"266a8cd2-2fab-4e89-9bf0-a56425ebcdf8\",\"time\":\"2024-02-05T17:31:05Z\",\"type\":\"io.open-cluster-management.works.v1alpha1.manifestbundles.spec.create_request\",\"source\":\"grpc\",\"specversion\":\"1.0\",\"datacontenttype\":\"application/json\",\"resourceid\":\"c4df9ff0-bfeb-5bc6-a0ab-4c9128d698b4\",\"clustername\":\"b288a9da-8bfe-4c82-94cc-2b48e773fc46\",\"resourceversion\":1,\"data\":{\"manifests\":[{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"nginx\",\"namespace\":\"default\"}}],\"deleteOption\":{\"propagationPolicy\":\"Foreground\"},\"manifestConfigs\":[{\"resourceIdentifier\":{\"resource\":\"configmaps\",\"namespace\":\"default\"}}]}}"),
+			expectedErrorMsg: "manifestConfigs[0].resourceIdentifier.name: Required value: field not set",
+		},
+		{
+			name:             "invalidated bundle manifest config feedback rules",
+			resType:          api.ResourceTypeBundle,
+			manifest:         newPayload(t, "{\"id\":\"266a8cd2-2fab-4e89-9bf0-a56425ebcdf8\",\"time\":\"2024-02-05T17:31:05Z\",\"type\":\"io.open-cluster-management.works.v1alpha1.manifestbundles.spec.create_request\",\"source\":\"grpc\",\"specversion\":\"1.0\",\"datacontenttype\":\"application/json\",\"resourceid\":\"c4df9ff0-bfeb-5bc6-a0ab-4c9128d698b4\",\"clustername\":\"b288a9da-8bfe-4c82-94cc-2b48e773fc46\",\"resourceversion\":1,\"data\":{\"manifests\":[{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"nginx\",\"namespace\":\"default\"}}],\"deleteOption\":{\"propagationPolicy\":\"Foreground\"},\"manifestConfigs\":[{\"feedbackRules\":[{\"type\":\"JSONPaths\"},{\"type\":\"Unknown\"}],\"resourceIdentifier\":{\"name\":\"nginx\",\"resource\":\"configmaps\",\"namespace\":\"default\"}}]}}"),
+			expectedErrorMsg: "[manifestConfigs[0].feedbackRules[0].jsonPaths: Required value: the jsonPaths are required by the JSONPaths type, manifestConfigs[0].feedbackRules[1].type: Unsupported value: \"Unknown\": supported values: \"WellKnownStatus\", \"JSONPaths\"]",
+		},
+		{
+			name:             "invalidated bundle manifest config server side apply",
+			resType:          api.ResourceTypeBundle,
+			manifest:         newPayload(t, "{\"id\":\"266a8cd2-2fab-4e89-9bf0-a56425ebcdf8\",\"time\":\"2024-02-05T17:31:05Z\",\"type\":\"io.open-cluster-management.works.v1alpha1.manifestbundles.spec.create_request\",\"source\":\"grpc\",\"specversion\":\"1.0\",\"datacontenttype\":\"application/json\",\"resourceid\":\"c4df9ff0-bfeb-5bc6-a0ab-4c9128d698b4\",\"clustername\":\"b288a9da-8bfe-4c82-94cc-2b48e773fc46\",\"resourceversion\":1,\"data\":{\"manifests\":[{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"nginx\",\"namespace\":\"default\"}}],\"deleteOption\":{\"propagationPolicy\":\"Foreground\"},\"manifestConfigs\":[{\"updateStrategy\":{\"type\":\"ServerSideApply\",\"serverSideApply\":{\"fieldManager\":\"kubectl\"}},\"resourceIdentifier\":{\"name\":\"nginx\",\"resource\":\"configmaps\",\"namespace\":\"default\"}}]}}"),
+			expectedErrorMsg: "manifestConfigs[0].updateStrategy.serverSideApply.fieldManager: Invalid value: \"kubectl\": the field manager must start with work-agent",
+		},
 		{
 			name:             "invalidated bundle manifest",
 			resType:          api.ResourceTypeBundle,
@@ -126,6 +206,9 @@ func TestValidateNewManifest(t *testing.T) {
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
 			err := ValidateManifest(c.resType, c.manifest)
+			if err == nil && c.expectedErrorMsg != "" {
+				t.Errorf("expected %#v but got no error", c.expectedErrorMsg)
+			}
 			if err != nil && err.Error() != c.expectedErrorMsg {
 				t.Errorf("expected %#v but got: %#v", c.expectedErrorMsg, err)
 			}
@@ -133,6 +216,210 @@ func TestValidateNewManifest(t *testing.T) {
 	}
 }
 
+func TestValidateManifestBundleSize(t *testing.T) {
+	cases := []struct {
+		name             string
+		resType          api.ResourceType
+		manifest         datatypes.JSONMap
+		maxManifests     int
+		expectedErrorMsg string
+	}{
+		{
+			name:         "bundle within the limit",
+			resType:      api.ResourceTypeBundle,
+			manifest:     newPayload(t, "{\"id\":\"266a8cd2-2fab-4e89-9bf0-a56425ebcdf8\",\"time\":\"2024-02-05T17:31:05Z\",\"type\":\"io.open-cluster-management.works.v1alpha1.manifestbundles.spec.create_request\",\"source\":\"grpc\",\"specversion\":\"1.0\",\"datacontenttype\":\"application/json\",\"data\":{\"manifests\":[{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"nginx1\",\"namespace\":\"default\"}},{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"nginx2\",\"namespace\":\"default\"}}]}}"),
+			maxManifests: 2,
+		},
+		{
+			name:             "bundle exceeds the limit",
+			resType:          api.ResourceTypeBundle,
+			manifest:         newPayload(t, "{\"id\":\"266a8cd2-2fab-4e89-9bf0-a56425ebcdf8\",\"time\":\"2024-02-05T17:31:05Z\",\"type\":\"io.open-cluster-management.works.v1alpha1.manifestbundles.spec.create_request\",\"source\":\"grpc\",\"specversion\":\"1.0\",\"datacontenttype\":\"application/json\",\"data\":{\"manifests\":[{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"nginx1\",\"namespace\":\"default\"}},{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"nginx2\",\"namespace\":\"default\"}}]}}"),
+			maxManifests:     1,
+			expectedErrorMsg: "the manifest bundle has 2 manifests, which exceeds the limit of 1",
+		},
+		{
+			name:         "no limit",
+			resType:      api.ResourceTypeBundle,
+			manifest:     newPayload(t, "{\"id\":\"266a8cd2-2fab-4e89-9bf0-a56425ebcdf8\",\"time\":\"2024-02-05T17:31:05Z\",\"type\":\"io.open-cluster-management.works.v1alpha1.manifestbundles.spec.create_request\",\"source\":\"grpc\",\"specversion\":\"1.0\",\"datacontenttype\":\"application/json\",\"data\":{\"manifests\":[{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"nginx1\",\"namespace\":\"default\"}},{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"nginx2\",\"namespace\":\"default\"}}]}}"),
+			maxManifests: 0,
+		},
+		{
+			name:         "single manifest is not limited",
+			resType:      api.ResourceTypeSingle,
+			manifest:     newPayload(t, "{\"id\":\"75479c10-b537-4261-8058-ca2e36bac384\",\"time\":\"2024-03-07T03:29:03.194843266Z\",\"type\":\"io.open-cluster-management.works.v1alpha1.manifests.spec.create_request\",\"source\":\"maestro\",\"specversion\":\"1.0\",\"datacontenttype\":\"application/json\",\"data\":{\"manifest\":{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"test\",\"namespace\":\"test\"}}}}"),
+			maxManifests: 1,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := ValidateManifestBundleSize(c.resType, c.manifest, c.maxManifests)
+			if len(c.expectedErrorMsg) == 0 {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != c.expectedErrorMsg {
+				t.Errorf("expected %#v but got: %#v", c.expectedErrorMsg, err)
+			}
+		})
+	}
+}
+
+func TestValidateManifestComplexity(t *testing.T) {
+	cases := []struct {
+		name             string
+		manifest         datatypes.JSONMap
+		maxDepth         int
+		maxKeys          int
+		expectedErrorMsg string
+	}{
+		{
+			name:     "manifest within the limits",
+			manifest: newPayload(t, "{\"id\":\"75479c10-b537-4261-8058-ca2e36bac384\",\"specversion\":\"1.0\",\"data\":{\"manifest\":{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"test\",\"namespace\":\"test\"}}}}"),
+			maxDepth: 4,
+			maxKeys:  9,
+		},
+		{
+			name:             "manifest exceeds the depth limit",
+			manifest:         newPayload(t, "{\"id\":\"75479c10-b537-4261-8058-ca2e36bac384\",\"specversion\":\"1.0\",\"data\":{\"manifest\":{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"test\",\"namespace\":\"test\"}}}}"),
+			maxDepth:         3,
+			expectedErrorMsg: "the nesting depth of the manifest exceeds the limit of 3",
+		},
+		{
+			name:             "manifest exceeds the keys limit",
+			manifest:         newPayload(t, "{\"id\":\"75479c10-b537-4261-8058-ca2e36bac384\",\"specversion\":\"1.0\",\"data\":{\"manifest\":{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"test\",\"namespace\":\"test\"}}}}"),
+			maxKeys:          8,
+			expectedErrorMsg: "the number of the keys in the manifest exceeds the limit of 8",
+		},
+		{
+			name:             "arrays are nested",
+			manifest:         datatypes.JSONMap{"data": []interface{}{[]interface{}{[]interface{}{"a"}}}},
+			maxDepth:         3,
+			expectedErrorMsg: "the nesting depth of the manifest exceeds the limit of 3",
+		},
+		{
+			name:             "pathologically deep manifest",
+			manifest:         newDeepManifest(100000),
+			maxDepth:         100,
+			expectedErrorMsg: "the nesting depth of the manifest exceeds the limit of 100",
+		},
+		{
+			name:     "no limit",
+			manifest: newDeepManifest(1000),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := ValidateManifestComplexity(c.manifest, c.maxDepth, c.maxKeys)
+			if len(c.expectedErrorMsg) == 0 {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != c.expectedErrorMsg {
+				t.Errorf("expected %#v but got: %#v", c.expectedErrorMsg, err)
+			}
+		})
+	}
+}
+
+func TestValidateManifestKinds(t *testing.T) {
+	bundle := newPayload(t, "{\"id\":\"266a8cd2-2fab-4e89-9bf0-a56425ebcdf8\",\"specversion\":\"1.0\",\"data\":{\"manifests\":[{\"apiVersion\":\"v1\",\"kind\":\"ConfigMap\",\"metadata\":{\"name\":\"nginx\",\"namespace\":\"default\"}},{\"apiVersion\":\"apps/v1\",\"kind\":\"Deployment\",\"metadata\":{\"name\":\"nginx\",\"namespace\":\"default\"}}]}}")
+	widget := newPayload(t, "{\"id\":\"75479c10-b537-4261-8058-ca2e36bac384\",\"specversion\":\"1.0\",\"data\":{\"manifest\":{\"apiVersion\":\"widgets.example.com/v1beta1\",\"kind\":\"Widget\",\"metadata\":{\"name\":\"test\",\"namespace\":\"test\"}}}}")
+
+	cases := []struct {
+		name             string
+		resType          api.ResourceType
+		manifest         datatypes.JSONMap
+		allowed          []string
+		denied           []string
+		expectedErrorMsg string
+	}{
+		{
+			name:     "no allowed or denied kinds",
+			resType:  api.ResourceTypeBundle,
+			manifest: bundle,
+		},
+		{
+			name:     "all the kinds are allowed",
+			resType:  api.ResourceTypeBundle,
+			manifest: bundle,
+			allowed:  []string{"v1/ConfigMap", "apps/v1/Deployment"},
+		},
+		{
+			name:             "a kind of the bundle is not allowed",
+			resType:          api.ResourceTypeBundle,
+			manifest:         bundle,
+			allowed:          []string{"v1/ConfigMap"},
+			expectedErrorMsg: "the kind apps/v1/Deployment is not allowed",
+		},
+		{
+			name:             "a denied kind is rejected even if it is allowed",
+			resType:          api.ResourceTypeBundle,
+			manifest:         bundle,
+			allowed:          []string{"*/*"},
+			denied:           []string{"*/v1/ConfigMap"},
+			expectedErrorMsg: "the kind v1/ConfigMap is denied",
+		},
+		{
+			name:     "wildcard group",
+			resType:  api.ResourceTypeSingle,
+			manifest: widget,
+			allowed:  []string{"*.example.com/*/*"},
+		},
+		{
+			name:             "wildcard group doesn't match the other groups",
+			resType:          api.ResourceTypeSingle,
+			manifest:         widget,
+			allowed:          []string{"*.example.io/*/*", "*/Gadget"},
+			expectedErrorMsg: "the kind widgets.example.com/v1beta1/Widget is not allowed",
+		},
+		{
+			name:     "wildcard apiVersion",
+			resType:  api.ResourceTypeSingle,
+			manifest: widget,
+			denied:   []string{"*/Gadget"},
+			allowed:  []string{"*/Widget"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			allowed, err := api.ParseKindPatterns(c.allowed)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			denied, err := api.ParseKindPatterns(c.denied)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			err = ValidateManifestKinds(c.resType, c.manifest, allowed, denied)
+			if len(c.expectedErrorMsg) == 0 {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != c.expectedErrorMsg {
+				t.Errorf("expected %#v but got: %#v", c.expectedErrorMsg, err)
+			}
+		})
+	}
+}
+
+// newDeepManifest returns a manifest with the objects nested to the given depth.
+func newDeepManifest(depth int) datatypes.JSONMap {
+	nested := map[string]interface{}{"value": "leaf"}
+	for i := 1; i < depth; i++ {
+		nested = map[string]interface{}{"nested": nested}
+	}
+	return datatypes.JSONMap(nested)
+}
+
 func TestValidateNewObject(t *testing.T) {
 	cases := []struct {
 		name             string
@@ -63,7 +63,7 @@ var _ = Describe("GRPC", Ordered, Label("e2e-tests-grpc"), func() {
 						continue
 					}
 
-					resourceVersion, err := cetypes.ToInteger(evtExtensions[types.ExtensionResourceVersion])
+					resourceVersion, err := api.ToResourceVersion(evtExtensions[types.ExtensionResourceVersion])
 					if err != nil {
 						continue
 					}
@@ -288,7 +288,7 @@ var _ = Describe("GRPC", Ordered, Label("e2e-tests-grpc"), func() {
 						continue
 					}
 
-					resourceVersion, err := cetypes.ToInteger(evtExtensions[types.ExtensionResourceVersion])
+					resourceVersion, err := api.ToResourceVersion(evtExtensions[types.ExtensionResourceVersion])
 					if err != nil {
 						continue
 					}
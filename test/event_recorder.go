@@ -0,0 +1,130 @@
+package test
+
+import (
+	"encoding/json"
+	"sync"
+
+	ce "github.com/cloudevents/sdk-go/v2"
+	cetypes "github.com/cloudevents/sdk-go/v2/types"
+	"github.com/onsi/gomega"
+	gomegatypes "github.com/onsi/gomega/types"
+
+	"github.com/openshift-online/maestro/cmd/maestro/server"
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/event"
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/types"
+)
+
+// EventRecorder is an in-memory subscriber of the event broadcaster, it captures the resource status events that are
+// delivered to the subscribers of a source, so the tests can assert on the exact event content instead of the
+// metrics. The events are encoded in the same way as the gRPC server sends them to its status subscribers.
+type EventRecorder struct {
+	mu     sync.RWMutex
+	events []ce.Event
+	errs   []error
+
+	broadcaster *event.EventBroadcaster
+	clientID    string
+}
+
+// NewEventRecorder registers an event recorder for the given source against the event broadcaster of the helper, the
+// recorder should be stopped once the test is done.
+func (helper *Helper) NewEventRecorder(source string) *EventRecorder {
+	recorder := &EventRecorder{broadcaster: helper.EventBroadcaster}
+	recorder.clientID, _ = helper.EventBroadcaster.Register(source, event.AllClusters, func(res *api.Resource) error {
+		evt, err := server.EncodeResourceStatus(res, nil, "")
+
+		recorder.mu.Lock()
+		defer recorder.mu.Unlock()
+		if err != nil {
+			// the error is recorded instead of being returned, the broadcaster blocks on the error channel of
+			// the client until the error is received
+			recorder.errs = append(recorder.errs, err)
+			return nil
+		}
+		recorder.events = append(recorder.events, *evt)
+		return nil
+	})
+	return recorder
+}
+
+// Events returns the captured events in the order they are delivered.
+func (r *EventRecorder) Events() []ce.Event {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	events := make([]ce.Event, len(r.events))
+	for i, evt := range r.events {
+		events[i] = evt.Clone()
+	}
+	return events
+}
+
+// Errors returns the errors that occurred when encoding the delivered resources.
+func (r *EventRecorder) Errors() []error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]error{}, r.errs...)
+}
+
+// Reset drops the captured events and errors.
+func (r *EventRecorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events = nil
+	r.errs = nil
+}
+
+// Stop unregisters the recorder from the event broadcaster.
+func (r *EventRecorder) Stop() {
+	r.broadcaster.Unregister(r.clientID)
+}
+
+// HaveEventType succeeds if the actual CloudEvent has the given type.
+func HaveEventType(eventType string) gomegatypes.GomegaMatcher {
+	return gomega.WithTransform(func(evt ce.Event) string {
+		return evt.Type()
+	}, gomega.Equal(eventType))
+}
+
+// HaveEventSource succeeds if the actual CloudEvent has the given source.
+func HaveEventSource(source string) gomegatypes.GomegaMatcher {
+	return gomega.WithTransform(func(evt ce.Event) string {
+		return evt.Source()
+	}, gomega.Equal(source))
+}
+
+// HaveEventExtension succeeds if the actual CloudEvent has the extension with the given value, the values are
+// compared in their canonical string form, e.g. the resource version 1 matches the extension "1".
+func HaveEventExtension(name string, value interface{}) gomegatypes.GomegaMatcher {
+	expected, err := cetypes.Format(value)
+	if err != nil {
+		expected = ""
+	}
+	return gomega.WithTransform(func(evt ce.Event) (string, error) {
+		val, ok := evt.Extensions()[name]
+		if !ok {
+			return "", nil
+		}
+		return cetypes.Format(val)
+	}, gomega.And(gomega.Not(gomega.BeEmpty()), gomega.Equal(expected)))
+}
+
+// HaveEventResource succeeds if the actual CloudEvent is the event of the resource with the given ID.
+func HaveEventResource(resourceID string) gomegatypes.GomegaMatcher {
+	return HaveEventExtension(types.ExtensionResourceID, resourceID)
+}
+
+// HaveEventData succeeds if the JSON payload of the actual CloudEvent, decoded into a map, satisfies the given
+// matcher, e.g. HaveEventData(HaveKey("conditions")).
+func HaveEventData(matcher gomegatypes.GomegaMatcher) gomegatypes.GomegaMatcher {
+	return gomega.WithTransform(func(evt ce.Event) (map[string]interface{}, error) {
+		data := map[string]interface{}{}
+		if err := json.Unmarshal(evt.Data(), &data); err != nil {
+			return nil, err
+		}
+		return data, nil
+	}, matcher)
+}
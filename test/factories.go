@@ -130,9 +130,9 @@ func (helper *Helper) NewReadOnlyAPIResource(consumerName, deployName string) op
 
 // NewReadOnlyResourceManifestJSON creates a resource with the given consumer name, deploy name, replicas, and resource version.
 // It generates a deployment for nginx using the testManifestJSON template, assigning a random deploy name to avoid testing conflicts.
-func (helper *Helper) NewResource(consumerName, deployName string, replicas int, resourceVersion int32) *api.Resource {
+func (helper *Helper) NewResource(consumerName, deployName string, replicas int, resourceVersion int64) *api.Resource {
 	testResource := helper.NewAPIResource(consumerName, deployName, replicas)
-	testPayload, err := api.EncodeManifest(testResource.Manifest, testResource.DeleteOption, testResource.UpdateStrategy)
+	testPayload, err := api.EncodeManifest(testResource.Manifest, testResource.DeleteOption, testResource.UpdateStrategy, testResource.ForceConflicts, nil, nil)
 	if err != nil {
 		helper.T.Errorf("error encoding manifest: %q", err)
 	}
@@ -246,7 +246,7 @@ func (helper *Helper) EncodeManifestBundle(manifestJSON, deployName, deployNames
 }
 
 // NewResourceBundle creates a resource bundle with the given consumer name, deploy name, replicas, and resource version.
-func (helper *Helper) NewResourceBundle(consumerName, deployName string, replicas int, resourceVersion int32) *api.Resource {
+func (helper *Helper) NewResourceBundle(consumerName, deployName string, replicas int, resourceVersion int64) *api.Resource {
 	namespace := "default" // default namespace
 	manifestJSON := helper.NewResourceManifestJSON(deployName, replicas)
 	payload, err := helper.EncodeManifestBundle(manifestJSON, deployName, namespace)
@@ -33,7 +33,7 @@ func (c *ResourceCodec) Encode(source string, eventType types.CloudEventsType, r
 
 	eventBuilder := types.NewEventBuilder(source, eventType).
 		WithResourceID(resource.ID).
-		WithResourceVersion(int64(resource.Version)).
+		WithResourceVersion(resource.Version).
 		WithClusterName(resource.ConsumerName)
 
 	if !resource.GetDeletionTimestamp().IsZero() {
@@ -71,7 +71,7 @@ func (c *ResourceCodec) Decode(evt *cloudevents.Event) (*api.Resource, error) {
 		return nil, fmt.Errorf("failed to get resourceid extension: %v", err)
 	}
 
-	resourceVersion, err := cloudeventstypes.ToInteger(evtExtensions[types.ExtensionResourceVersion])
+	resourceVersion, err := api.ToResourceVersion(evtExtensions[types.ExtensionResourceVersion])
 	if err != nil {
 		return nil, fmt.Errorf("failed to get resourceversion extension: %v", err)
 	}
@@ -145,7 +145,7 @@ func (c *ResourceBundleCodec) Encode(source string, eventType types.CloudEventsT
 
 	eventBuilder := types.NewEventBuilder(source, eventType).
 		WithResourceID(resource.ID).
-		WithResourceVersion(int64(resource.Version)).
+		WithResourceVersion(resource.Version).
 		WithClusterName(resource.ConsumerName)
 
 	if !resource.GetDeletionTimestamp().IsZero() {
@@ -197,7 +197,7 @@ func (c *ResourceBundleCodec) Decode(evt *cloudevents.Event) (*api.Resource, err
 		return nil, fmt.Errorf("failed to get resourceid extension: %v", err)
 	}
 
-	resourceVersion, err := cloudeventstypes.ToInteger(evtExtensions[types.ExtensionResourceVersion])
+	resourceVersion, err := api.ToResourceVersion(evtExtensions[types.ExtensionResourceVersion])
 	if err != nil {
 		return nil, fmt.Errorf("failed to get resourceversion extension: %v", err)
 	}
@@ -129,7 +129,7 @@ func NewHelper(t *testing.T) *Helper {
 			Ctx:               ctx,
 			ContextCancelFunc: cancel,
 			Broker:            env.Config.MessageBroker.MessageBrokerType,
-			EventBroadcaster:  event.NewEventBroadcaster(),
+			EventBroadcaster:  event.NewEventBroadcaster(env.Config.EventServer.BroadcasterBufferSize, event.OverflowPolicyBlock),
 			AppConfig:         env.Config,
 			DBFactory:         env.Database.SessionFactory,
 			JWTPrivateKey:     jwtKey,
@@ -430,6 +430,11 @@ func (helper *Helper) NewRandAccount() *amv1.Account {
 	return helper.NewAccount(helper.NewID(), faker.Name(), faker.Email())
 }
 
+// NewAdminAccount returns an account of the admin user that is set by the admin-users flag of the testing environment.
+func (helper *Helper) NewAdminAccount() *amv1.Account {
+	return helper.NewAccount("maestro-admin", faker.Name(), faker.Email())
+}
+
 func (helper *Helper) NewAccount(username, name, email string) *amv1.Account {
 	var firstName string
 	var lastName string
@@ -518,6 +523,10 @@ func (helper *Helper) CleanDB() error {
 		"events",
 		"status_events",
 		"resources",
+		"resource_revisions",
+		"resource_locks",
+		"resource_templates",
+		"consumer_tokens",
 		"consumers",
 		"server_instances",
 	} {
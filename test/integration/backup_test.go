@@ -0,0 +1,124 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/rand"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/backup"
+	"github.com/openshift-online/maestro/pkg/client/objectstore"
+	"github.com/openshift-online/maestro/test"
+)
+
+func TestBackupExportImport(t *testing.T) {
+	h, _ := test.RegisterIntegration(t)
+
+	ctx := context.Background()
+	consumer := h.CreateConsumer("cluster-" + rand.String(5))
+	resource := h.CreateResource(consumer.Name, "nginx-"+rand.String(5), 1)
+
+	buf := &bytes.Buffer{}
+	Expect(backup.Export(ctx, h.DBFactory.New(ctx), nil, buf)).NotTo(HaveOccurred())
+
+	// the consumers are exported before the resources
+	consumerLine, resourceLine := -1, -1
+	for i, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		record := backup.Record{}
+		Expect(json.Unmarshal([]byte(line), &record)).NotTo(HaveOccurred())
+		switch {
+		case record.Kind == backup.KindConsumer && record.Consumer.ID == consumer.ID:
+			consumerLine = i
+		case record.Kind == backup.KindResource && record.Resource.ID == resource.ID:
+			resourceLine = i
+			Expect(record.Resource.Version).To(Equal(resource.Version))
+			Expect(record.Resource.Payload).To(Equal(resource.Payload))
+		}
+	}
+	Expect(consumerLine).NotTo(Equal(-1))
+	Expect(resourceLine).To(BeNumerically(">", consumerLine))
+
+	// the existing records are skipped
+	result, err := backup.Import(ctx, h.DBFactory.New(ctx), bytes.NewReader(buf.Bytes()))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(result.Created).To(Equal(0))
+	Expect(result.Skipped).To(BeNumerically(">=", 2))
+
+	// the deleted records are restored by their IDs
+	h.Delete(resource)
+	h.Delete(consumer)
+	result, err = backup.Import(ctx, h.DBFactory.New(ctx), bytes.NewReader(buf.Bytes()))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(result.Created).To(Equal(2))
+
+	restored, svcErr := h.Env().Services.Resources().Get(ctx, resource.ID)
+	Expect(svcErr).To(BeNil())
+	Expect(restored.ConsumerName).To(Equal(consumer.Name))
+	Expect(restored.Version).To(Equal(resource.Version))
+	Expect(restored.Payload).To(Equal(resource.Payload))
+
+	restoredConsumer, svcErr := h.Env().Services.Consumers().Get(ctx, consumer.ID)
+	Expect(svcErr).To(BeNil())
+	Expect(restoredConsumer.Name).To(Equal(consumer.Name))
+
+	// an invalid record fails the import
+	_, err = backup.Import(ctx, h.DBFactory.New(ctx), strings.NewReader(`{"kind":"Unknown"}`))
+	Expect(err).To(HaveOccurred())
+}
+
+func TestBackupExportOffloadedPayload(t *testing.T) {
+	h, _ := test.RegisterIntegration(t)
+
+	ctx := context.Background()
+
+	// offload all the bundle payloads to the object store
+	objectStore := objectstore.NewMockClient()
+	h.Env().Clients.ObjectStore = objectStore
+	h.Env().Config.ObjectStore.PayloadOffloadThreshold = 1
+	defer func() {
+		h.Env().Clients.ObjectStore = nil
+		h.Env().Config.ObjectStore.PayloadOffloadThreshold = 0
+	}()
+
+	consumer := h.CreateConsumer("cluster-" + rand.String(5))
+	resourceBundle := h.CreateResourceBundle(consumer.Name, "nginx-"+rand.String(5), 1)
+	Expect(resourceBundle.PayloadRef).NotTo(BeEmpty())
+
+	// the export fails rather than losing the offloaded payload without the object store
+	err := backup.Export(ctx, h.DBFactory.New(ctx), nil, &bytes.Buffer{})
+	Expect(err).To(HaveOccurred())
+	Expect(err.Error()).To(ContainSubstring("is offloaded to the object store"))
+
+	// the offloaded payload is inlined in the backup
+	buf := &bytes.Buffer{}
+	Expect(backup.Export(ctx, h.DBFactory.New(ctx), objectStore, buf)).NotTo(HaveOccurred())
+	var exported *api.Resource
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		record := backup.Record{}
+		Expect(json.Unmarshal([]byte(line), &record)).NotTo(HaveOccurred())
+		if record.Kind == backup.KindResource && record.Resource.ID == resourceBundle.ID {
+			exported = record.Resource
+		}
+	}
+	Expect(exported).NotTo(BeNil())
+	Expect(exported.PayloadRef).To(BeEmpty())
+	Expect(exported.Payload).To(HaveKey("data"))
+
+	// the resource is restored with its full payload, even if the object store is lost
+	h.Delete(resourceBundle)
+	h.Delete(consumer)
+	Expect(objectStore.Delete(ctx, resourceBundle.PayloadRef)).To(Succeed())
+	result, err := backup.Import(ctx, h.DBFactory.New(ctx), bytes.NewReader(buf.Bytes()))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(result.Created).To(BeNumerically(">=", 2))
+
+	restored, svcErr := h.Env().Services.Resources().Get(ctx, resourceBundle.ID)
+	Expect(svcErr).To(BeNil())
+	Expect(restored.PayloadRef).To(BeEmpty())
+	Expect(restored.Payload).To(Equal(exported.Payload))
+}
@@ -0,0 +1,102 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"gopkg.in/resty.v1"
+	"k8s.io/apimachinery/pkg/util/rand"
+
+	"github.com/openshift-online/maestro/pkg/api"
+	"github.com/openshift-online/maestro/pkg/api/openapi"
+	"github.com/openshift-online/maestro/pkg/event"
+	"github.com/openshift-online/maestro/test"
+)
+
+func TestBroadcasterSuspend(t *testing.T) {
+	h, _ := test.RegisterIntegration(t)
+
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+	jwtToken := ctx.Value(openapi.ContextAccessToken)
+
+	source := "source-" + rand.String(5)
+	var mu sync.Mutex
+	received := map[string]string{}
+	clientID, _ := h.EventBroadcaster.Register(source, event.AllClusters, func(res *api.Resource) error {
+		mu.Lock()
+		defer mu.Unlock()
+		received[res.ID] = res.Name
+		return nil
+	})
+	defer h.EventBroadcaster.Unregister(clientID)
+	receivedEvents := func() map[string]string {
+		mu.Lock()
+		defer mu.Unlock()
+		events := map[string]string{}
+		for id, name := range received {
+			events[id] = name
+		}
+		return events
+	}
+
+	// 400 for the invalid timeout
+	restyResp, err := resty.R().
+		SetHeader("Content-Type", "application/json").
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+		SetBody(`{"timeout": "2h"}`).
+		Post(h.RestURL("/broadcaster/suspend"))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusBadRequest))
+
+	restyResp, err = resty.R().
+		SetHeader("Content-Type", "application/json").
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+		SetBody(`{"timeout": "1m"}`).
+		Post(h.RestURL("/broadcaster/suspend"))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusOK))
+
+	status := openapi.BroadcasterStatus{}
+	Expect(json.Unmarshal(restyResp.Body(), &status)).NotTo(HaveOccurred())
+	Expect(status.GetSuspended()).To(BeTrue())
+	Expect(status.GetSuspendedUntil()).To(BeTemporally("~", time.Now().Add(time.Minute), 10*time.Second))
+	// the suspension is per instance, the response tells the instance apart
+	Expect(status.GetInstanceId()).To(Equal(h.Env().Config.MessageBroker.ClientID))
+
+	// the events are coalesced while the broadcaster is suspended
+	for _, name := range []string{"first", "second"} {
+		h.EventBroadcaster.Broadcast(&api.Resource{Meta: api.Meta{ID: "resource-1"}, Source: source, Name: name})
+	}
+	h.EventBroadcaster.Broadcast(&api.Resource{Meta: api.Meta{ID: "resource-2"}, Source: source, Name: "first"})
+	Consistently(receivedEvents, 2*time.Second, 500*time.Millisecond).Should(BeEmpty())
+
+	restyResp, err = resty.R().
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+		Get(h.RestURL("/broadcaster"))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusOK))
+	Expect(json.Unmarshal(restyResp.Body(), &status)).NotTo(HaveOccurred())
+	Expect(status.GetPendingEvents()).To(Equal(int32(2)))
+
+	restyResp, err = resty.R().
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+		Post(h.RestURL("/broadcaster/resume"))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusOK))
+
+	status = openapi.BroadcasterStatus{}
+	Expect(json.Unmarshal(restyResp.Body(), &status)).NotTo(HaveOccurred())
+	Expect(status.GetSuspended()).To(BeFalse())
+	Expect(status.HasSuspendedUntil()).To(BeFalse())
+
+	Eventually(receivedEvents, 10*time.Second, 500*time.Millisecond).Should(Equal(map[string]string{
+		"resource-1": "second",
+		"resource-2": "first",
+	}))
+}
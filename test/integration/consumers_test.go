@@ -9,9 +9,12 @@ import (
 
 	. "github.com/onsi/gomega"
 	"gopkg.in/resty.v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/rand"
 
+	"github.com/openshift-online/maestro/pkg/api"
 	"github.com/openshift-online/maestro/pkg/api/openapi"
+	"github.com/openshift-online/maestro/pkg/dao"
 	"github.com/openshift-online/maestro/test"
 )
 
@@ -95,6 +98,74 @@ func TestConsumerPost(t *testing.T) {
 	Expect(*consumer.Name).To(Equal(*consumer.Id), "the name and id are not same")
 }
 
+func TestConsumerBatchCreate(t *testing.T) {
+	h, client := test.RegisterIntegration(t)
+
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+
+	existing := h.CreateConsumer("cluster-" + rand.String(5))
+	first := "cluster-" + rand.String(5)
+	second := "cluster-" + rand.String(5)
+
+	req := openapi.ConsumerBatchCreateRequest{
+		Items: []openapi.Consumer{
+			{Name: openapi.PtrString(first), Labels: &map[string]string{"foo": "bar"}},
+			{Name: openapi.PtrString(existing.Name)},
+			{Name: openapi.PtrString(second)},
+		},
+	}
+
+	// 409 conflict, the batch contains an existing consumer
+	_, resp, err := client.DefaultApi.ApiMaestroV1ConsumersBatchCreatePost(ctx).ConsumerBatchCreateRequest(req).Execute()
+	Expect(err).To(HaveOccurred())
+	Expect(resp.StatusCode).To(Equal(http.StatusConflict))
+
+	// nothing is created for a failed batch
+	consumers, _, err := client.DefaultApi.ApiMaestroV1ConsumersGet(ctx).
+		Search(fmt.Sprintf("name in ('%s', '%s')", first, second)).Execute()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(consumers.Items).To(BeEmpty())
+
+	// 400 bad request, the batch contains invalid and duplicated names
+	invalid := openapi.ConsumerBatchCreateRequest{
+		Items: []openapi.Consumer{
+			{Name: openapi.PtrString(first)},
+			{Name: openapi.PtrString(first)},
+			{Name: openapi.PtrString("Invalid_Name")},
+		},
+	}
+	_, resp, err = client.DefaultApi.ApiMaestroV1ConsumersBatchCreatePost(ctx).ConsumerBatchCreateRequest(invalid).Execute()
+	Expect(err).To(HaveOccurred())
+	Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+
+	// 201 created, the existing consumer is skipped
+	req.SkipExisting = openapi.PtrBool(true)
+	result, resp, err := client.DefaultApi.ApiMaestroV1ConsumersBatchCreatePost(ctx).ConsumerBatchCreateRequest(req).Execute()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+	Expect(result.Items).To(HaveLen(3))
+
+	Expect(*result.Items[0].Name).To(Equal(first))
+	Expect(*result.Items[0].Status).To(Equal("Created"))
+	Expect(*result.Items[0].Consumer.Id).NotTo(BeEmpty())
+	Expect(*result.Items[0].Consumer.Labels).To(Equal(map[string]string{"foo": "bar"}))
+
+	Expect(*result.Items[1].Name).To(Equal(existing.Name))
+	Expect(*result.Items[1].Status).To(Equal("Skipped"))
+	Expect(*result.Items[1].Consumer.Id).To(Equal(existing.ID))
+
+	Expect(*result.Items[2].Name).To(Equal(second))
+	Expect(*result.Items[2].Status).To(Equal("Created"))
+
+	for _, item := range []openapi.ConsumerBatchCreateResult{result.Items[0], result.Items[2]} {
+		found, resp, err := client.DefaultApi.ApiMaestroV1ConsumersIdGet(ctx, *item.Consumer.Id).Execute()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		Expect(*found.Name).To(Equal(*item.Name))
+	}
+}
+
 func TestConsumerPatch(t *testing.T) {
 	h, client := test.RegisterIntegration(t)
 
@@ -124,6 +195,20 @@ func TestConsumerPatch(t *testing.T) {
 	patched, resp, err = client.DefaultApi.ApiMaestroV1ConsumersIdPatch(ctx, consumer.ID).ConsumerPatchRequest(openapi.ConsumerPatchRequest{}).Execute()
 	assert(patched, resp, err, openapi.PtrString("brontosaurus"), &labels)
 
+	// add and remove labels on the current labels
+	patched, resp, err = client.DefaultApi.ApiMaestroV1ConsumersIdPatch(ctx, consumer.ID).ConsumerPatchRequest(openapi.ConsumerPatchRequest{
+		AddLabels:    &map[string]string{"env": "prod"},
+		RemoveLabels: []string{"foo"},
+	}).Execute()
+	assert(patched, resp, err, openapi.PtrString("brontosaurus"), &map[string]string{"env": "prod"})
+
+	// 400 for the invalid label
+	_, resp, err = client.DefaultApi.ApiMaestroV1ConsumersIdPatch(ctx, consumer.ID).ConsumerPatchRequest(openapi.ConsumerPatchRequest{
+		AddLabels: &map[string]string{"invalid key": "bar"},
+	}).Execute()
+	Expect(err).To(HaveOccurred())
+	Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+
 	// delete labels
 	patched, resp, err = client.DefaultApi.ApiMaestroV1ConsumersIdPatch(ctx, consumer.ID).ConsumerPatchRequest(openapi.ConsumerPatchRequest{Labels: &map[string]string{}}).Execute()
 	assert(patched, resp, err, openapi.PtrString("brontosaurus"), nil)
@@ -356,6 +441,105 @@ func TestConsumerPaging(t *testing.T) {
 	Expect(list.Page).To(Equal(int32(2)))
 }
 
+func TestConsumerGroupConsumersPaging(t *testing.T) {
+	h, _ := test.RegisterIntegration(t)
+
+	account := h.NewRandAccount()
+	jwtToken := h.CreateJWTString(account)
+
+	group := "group-" + rand.String(5)
+	groupLabels := map[string]string{api.ConsumerGroupLabel(group): ""}
+	for i := 1; i <= 3; i++ {
+		h.CreateConsumerWithLabels(fmt.Sprintf("%s-cluster%d", group, i), groupLabels)
+	}
+	h.CreateConsumer(group + "-other")
+
+	list := openapi.ConsumerList{}
+	resp, err := resty.R().
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+		SetQueryParams(map[string]string{"page": "2", "size": "2"}).
+		SetResult(&list).
+		Get(h.RestURL(fmt.Sprintf("/consumer-groups/%s/consumers", group)))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(resp.StatusCode()).To(Equal(http.StatusOK))
+	Expect(list.Page).To(Equal(int32(2)))
+	Expect(list.Size).To(Equal(int32(1)))
+	Expect(list.Total).To(Equal(int32(3)))
+	Expect(len(list.Items)).To(Equal(1))
+	Expect(*list.Items[0].Name).To(Equal(group + "-cluster3"))
+
+	resp, err = resty.R().
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+		Get(h.RestURL("/consumer-groups/us%20east/consumers"))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(resp.StatusCode()).To(Equal(http.StatusBadRequest))
+}
+
+func TestConsumerDaoList(t *testing.T) {
+	h, _ := test.RegisterIntegration(t)
+
+	ctx := context.Background()
+	consumerDao := dao.NewConsumerDao(&h.Env().Database.SessionFactory)
+
+	prefix := fmt.Sprintf("list-%s-", rand.String(5))
+	for i := 1; i <= 5; i++ {
+		labels := map[string]string{"env": "prod"}
+		if i%2 == 0 {
+			labels = map[string]string{"env": "dev", "tier": "web"}
+		}
+		_ = h.CreateConsumerWithLabels(fmt.Sprintf("%s%d", prefix, i), labels)
+	}
+	// a consumer out of the name prefix
+	_ = h.CreateConsumer(fmt.Sprintf("%s-other", rand.String(5)))
+
+	names := func(page *dao.ConsumerPage) []string {
+		names := []string{}
+		for _, consumer := range page.Items {
+			names = append(names, consumer.Name)
+		}
+		return names
+	}
+
+	page, err := consumerDao.List(ctx, dao.ConsumerListOptions{Limit: 2, NamePrefix: prefix})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(page.Total).To(Equal(int64(5)))
+	Expect(names(page)).To(Equal([]string{prefix + "1", prefix + "2"}))
+	Expect(page.NextCursor).NotTo(BeEmpty())
+
+	page, err = consumerDao.List(ctx, dao.ConsumerListOptions{Limit: 2, NamePrefix: prefix, Cursor: page.NextCursor})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(names(page)).To(Equal([]string{prefix + "3", prefix + "4"}))
+
+	page, err = consumerDao.List(ctx, dao.ConsumerListOptions{Limit: 2, NamePrefix: prefix, Cursor: page.NextCursor})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(names(page)).To(Equal([]string{prefix + "5"}))
+	Expect(page.NextCursor).To(BeEmpty())
+
+	// the wildcards of the name prefix are not matched
+	page, err = consumerDao.List(ctx, dao.ConsumerListOptions{NamePrefix: prefix[:len(prefix)-1] + "_"})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(page.Total).To(Equal(int64(0)))
+
+	for selector, expected := range map[string][]string{
+		"env=prod":         {prefix + "1", prefix + "3", prefix + "5"},
+		"env in (dev,qa)":  {prefix + "2", prefix + "4"},
+		"env!=prod":        {prefix + "2", prefix + "4"},
+		"tier":             {prefix + "2", prefix + "4"},
+		"!tier,env=prod":   {prefix + "1", prefix + "3", prefix + "5"},
+		"env notin (prod)": {prefix + "2", prefix + "4"},
+	} {
+		labelSelector, err := labels.Parse(selector)
+		Expect(err).NotTo(HaveOccurred())
+		page, err := consumerDao.List(ctx, dao.ConsumerListOptions{NamePrefix: prefix, LabelSelector: labelSelector})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names(page)).To(Equal(expected), "unexpected consumers of the label selector %q", selector)
+		Expect(page.Total).To(Equal(int64(len(expected))))
+	}
+
+	_, err = consumerDao.List(ctx, dao.ConsumerListOptions{Cursor: "%"})
+	Expect(err).To(HaveOccurred())
+}
+
 type Result struct {
 	resource     *openapi.Resource
 	consumerName string
@@ -296,6 +296,7 @@ func TestControllerSync(t *testing.T) {
 		},
 		ConsumerName: consumer.Name,
 		Name:         "resource4",
+		Type:         api.ResourceTypeSingle,
 	}); err != nil {
 		t.Fatal(err)
 	}
@@ -307,6 +308,7 @@ func TestControllerSync(t *testing.T) {
 		},
 		ConsumerName: consumer.Name,
 		Name:         "resource5",
+		Type:         api.ResourceTypeSingle,
 	}); err != nil {
 		t.Fatal(err)
 	}
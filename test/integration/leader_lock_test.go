@@ -0,0 +1,61 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/maestro/pkg/db"
+	"github.com/openshift-online/maestro/test"
+)
+
+func TestLeaderLock(t *testing.T) {
+	h, _ := test.RegisterIntegration(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+	}()
+
+	sessionFactory := h.Env().Database.SessionFactory
+	leader := db.NewLeaderLock(sessionFactory, "maestro-leader-lock-test", time.Second)
+	candidate := db.NewLeaderLock(sessionFactory, "maestro-leader-lock-test", time.Second)
+
+	acquired, err := leader.TryAcquire(ctx)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(acquired).To(BeTrue())
+	Expect(leader.IsLeader()).To(BeTrue())
+
+	// the lock is held by the leader, the candidate can't acquire it
+	acquired, err = candidate.TryAcquire(ctx)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(acquired).To(BeFalse())
+	Expect(candidate.IsLeader()).To(BeFalse())
+
+	// the held lock is renewed
+	time.Sleep(3 * time.Second)
+	Expect(leader.IsLeader()).To(BeTrue())
+
+	// the lock is handed over once the leader releases it
+	Expect(leader.Release(ctx)).NotTo(HaveOccurred())
+	Expect(leader.IsLeader()).To(BeFalse())
+	acquired, err = candidate.TryAcquire(ctx)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(acquired).To(BeTrue())
+
+	// the lock is released once the context of the leader is done
+	candidateCtx, candidateCancel := context.WithCancel(ctx)
+	Expect(candidate.Release(ctx)).NotTo(HaveOccurred())
+	acquired, err = candidate.TryAcquire(candidateCtx)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(acquired).To(BeTrue())
+	candidateCancel()
+	Eventually(func() bool {
+		acquired, err := leader.TryAcquire(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		return acquired
+	}, 5*time.Second, 100*time.Millisecond).Should(BeTrue())
+	Expect(candidate.IsLeader()).To(BeFalse())
+	Expect(leader.Release(ctx)).NotTo(HaveOccurred())
+}
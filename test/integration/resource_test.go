@@ -11,12 +11,15 @@ import (
 	"testing"
 	"time"
 
+	cloudevents "github.com/cloudevents/sdk-go/v2"
 	jsonpatch "github.com/evanphx/json-patch"
 	"github.com/google/uuid"
 	. "github.com/onsi/gomega"
+	amv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
 	prommodel "github.com/prometheus/client_model/go"
 	"github.com/prometheus/common/expfmt"
 	"gopkg.in/resty.v1"
+	"gorm.io/datatypes"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8stypes "k8s.io/apimachinery/pkg/types"
@@ -29,7 +32,9 @@ import (
 
 	"github.com/openshift-online/maestro/pkg/api"
 	"github.com/openshift-online/maestro/pkg/api/openapi"
+	"github.com/openshift-online/maestro/pkg/client/objectstore"
 	"github.com/openshift-online/maestro/pkg/dao"
+	"github.com/openshift-online/maestro/pkg/services"
 	"github.com/openshift-online/maestro/test"
 )
 
@@ -64,6 +69,54 @@ func TestResourceGet(t *testing.T) {
 	Expect(*res.Version).To(Equal(resource.Version))
 }
 
+func TestResourceGetFields(t *testing.T) {
+	h, _ := test.RegisterIntegration(t)
+
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+	jwtToken := ctx.Value(openapi.ContextAccessToken)
+
+	consumer := h.CreateConsumer("cluster-" + rand.String(5))
+	deployName := fmt.Sprintf("nginx-%s", rand.String(5))
+	resource := h.CreateResource(consumer.Name, deployName, 1)
+
+	// the response is projected to the requested fields, the id is always returned
+	restyResp, err := resty.R().
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+		SetQueryParam("fields", "version,consumer_name").
+		Get(h.RestURL(fmt.Sprintf("/resources/%s", resource.ID)))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusOK))
+
+	res := map[string]interface{}{}
+	Expect(json.Unmarshal(restyResp.Body(), &res)).NotTo(HaveOccurred())
+	Expect(res).To(HaveLen(3))
+	Expect(res["id"]).To(Equal(resource.ID))
+	Expect(res["consumer_name"]).To(Equal(consumer.Name))
+	Expect(res["version"]).To(BeEquivalentTo(resource.Version))
+
+	// the nested fields of the object fields can be selected
+	restyResp, err = resty.R().
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+		SetQueryParam("fields", "manifest.kind").
+		Get(h.RestURL(fmt.Sprintf("/resources/%s", resource.ID)))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusOK))
+
+	res = map[string]interface{}{}
+	Expect(json.Unmarshal(restyResp.Body(), &res)).NotTo(HaveOccurred())
+	Expect(res).To(HaveKeyWithValue("manifest", map[string]interface{}{"kind": "Deployment"}))
+
+	// 400 for the unknown fields
+	restyResp, err = resty.R().
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+		SetQueryParam("fields", "version,consumer_id").
+		Get(h.RestURL(fmt.Sprintf("/resources/%s", resource.ID)))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusBadRequest))
+	Expect(string(restyResp.Body())).To(ContainSubstring("consumer_id"))
+}
+
 func TestResourcePost(t *testing.T) {
 	h, client := test.RegisterIntegration(t)
 	account := h.NewRandAccount()
@@ -181,6 +234,19 @@ func TestResourcePost(t *testing.T) {
 	Expect(contentStatus["readyReplicas"]).To(Equal(float64(1)))
 	Expect(contentStatus["updatedReplicas"]).To(Equal(float64(1)))
 
+	// the instance that broadcast the status is recorded
+	Eventually(func() error {
+		newRes, _, err = client.DefaultApi.ApiMaestroV1ResourcesIdGet(ctx, *resource.Id).Execute()
+		if err != nil {
+			return err
+		}
+		if newRes.LastDispatchedAt == nil {
+			return fmt.Errorf("resource status is not dispatched")
+		}
+		return nil
+	}, 10*time.Second, 1*time.Second).Should(Succeed())
+	Expect(newRes.GetLastDispatchedBy()).To(Equal(h.Env().Config.MessageBroker.ClientID))
+
 	if h.Broker != "grpc" {
 		time.Sleep(1 * time.Second)
 		families := getServerMetrics(t, "http://localhost:8080/metrics")
@@ -265,6 +331,46 @@ func TestResourcePostWithName(t *testing.T) {
 	cancel()
 }
 
+func TestResourceValidateManifest(t *testing.T) {
+	h, _ := test.RegisterIntegration(t)
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+	jwtToken := ctx.Value(openapi.ContextAccessToken)
+
+	// the manifest is validated without a consumer
+	deployName := fmt.Sprintf("nginx-%s", rand.String(5))
+	res := h.NewAPIResource("", deployName, 1)
+	res.ConsumerName = nil
+	restyResp, err := resty.R().
+		SetHeader("Content-Type", "application/json").
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+		SetBody(res).
+		Post(h.RestURL("/manifests:validate"))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusOK))
+
+	validation := openapi.ManifestValidation{}
+	Expect(json.Unmarshal(restyResp.Body(), &validation)).NotTo(HaveOccurred())
+	Expect(validation.Manifest["metadata"].(map[string]interface{})["name"]).To(Equal(deployName))
+	Expect(validation.Encoded["data"]).To(HaveKey("configOption"))
+
+	// nothing is created
+	var count int64
+	Expect(h.DBFactory.New(ctx).Table("resources").Where("name = ?", deployName).Count(&count).Error).NotTo(HaveOccurred())
+	Expect(count).To(BeZero())
+
+	// 400 for the manifest without a kind
+	delete(res.Manifest, "kind")
+	restyResp, err = resty.R().
+		SetHeader("Content-Type", "application/json").
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+		SetBody(res).
+		Post(h.RestURL("/manifests:validate"))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusBadRequest))
+	Expect(string(restyResp.Body())).To(ContainSubstring("the manifest in the resource is invalid"))
+}
+
 func TestResourcePatch(t *testing.T) {
 	h, client := test.RegisterIntegration(t)
 	account := h.NewRandAccount()
@@ -282,7 +388,7 @@ func TestResourcePatch(t *testing.T) {
 
 	deployName := fmt.Sprintf("nginx-%s", rand.String(5))
 	res := h.CreateResource(consumer.ID, deployName, 1)
-	Expect(res.Version).To(Equal(int32(1)))
+	Expect(res.Version).To(Equal(int64(1)))
 
 	var work *workv1.ManifestWork
 	Eventually(func() error {
@@ -310,7 +416,7 @@ func TestResourcePatch(t *testing.T) {
 
 	// 200 OK
 	newRes := h.NewAPIResource(consumer.Name, deployName, 2)
-	resource, resp, err := client.DefaultApi.ApiMaestroV1ResourcesIdPatch(ctx, res.ID).ResourcePatchRequest(openapi.ResourcePatchRequest{Version: &res.Version, Manifest: newRes.Manifest}).Execute()
+	resource, resp, err := client.DefaultApi.ApiMaestroV1ResourcesIdPatch(ctx, res.ID).ResourcePatchRequest(openapi.ResourcePatchRequest{Version: openapi.PtrInt32(int32(res.Version)), Manifest: newRes.Manifest}).Execute()
 	Expect(err).NotTo(HaveOccurred(), "Error posting object:  %v", err)
 	Expect(resp.StatusCode).To(Equal(http.StatusOK))
 	Expect(*resource.Id).To(Equal(res.ID))
@@ -340,7 +446,7 @@ func TestResourcePatch(t *testing.T) {
 
 	// 409 conflict error. using an out of date resource version
 	_, resp, err = client.DefaultApi.ApiMaestroV1ResourcesIdPatch(ctx, res.ID).ResourcePatchRequest(
-		openapi.ResourcePatchRequest{Version: &res.Version, Manifest: newRes.Manifest}).Execute()
+		openapi.ResourcePatchRequest{Version: openapi.PtrInt32(int32(res.Version)), Manifest: newRes.Manifest}).Execute()
 	Expect(err).To(HaveOccurred())
 	Expect(resp.StatusCode).To(Equal(http.StatusConflict))
 
@@ -372,11 +478,62 @@ func TestResourcePatch(t *testing.T) {
 
 	// patch the deleting resource should return 409 conflict
 	_, resp, err = client.DefaultApi.ApiMaestroV1ResourcesIdPatch(ctx, res.ID).ResourcePatchRequest(
-		openapi.ResourcePatchRequest{Version: &res.Version, Manifest: newRes.Manifest}).Execute()
+		openapi.ResourcePatchRequest{Version: openapi.PtrInt32(int32(res.Version)), Manifest: newRes.Manifest}).Execute()
 	Expect(err).To(HaveOccurred())
 	Expect(resp.StatusCode).To(Equal(http.StatusConflict))
 }
 
+func TestResourceForceConflicts(t *testing.T) {
+	h, client := test.RegisterIntegration(t)
+
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+
+	consumer := h.CreateConsumer("cluster-" + rand.String(5))
+	deployName := fmt.Sprintf("nginx-%s", rand.String(5))
+
+	// the force conflicts is only allowed with the ServerSideApply update strategy
+	res := h.NewAPIResource(consumer.Name, deployName, 1)
+	res.UpdateStrategy = map[string]interface{}{"type": "Update"}
+	res.ForceConflicts = openapi.PtrBool(true)
+	_, resp, err := client.DefaultApi.ApiMaestroV1ResourcesPost(ctx).Resource(res).Execute()
+	Expect(err).To(HaveOccurred())
+	Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+
+	res = h.NewAPIResource(consumer.Name, deployName, 1)
+	res.ForceConflicts = openapi.PtrBool(true)
+	resource, resp, err := client.DefaultApi.ApiMaestroV1ResourcesPost(ctx).Resource(res).Execute()
+	Expect(err).NotTo(HaveOccurred(), "Error posting object:  %v", err)
+	Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+	Expect(resource.GetForceConflicts()).To(BeTrue())
+	Expect(resource.UpdateStrategy["type"]).To(Equal("ServerSideApply"))
+
+	// the force conflicts is kept if it is not patched
+	newRes := h.NewAPIResource(consumer.Name, deployName, 2)
+	resource, resp, err = client.DefaultApi.ApiMaestroV1ResourcesIdPatch(ctx, *resource.Id).ResourcePatchRequest(openapi.ResourcePatchRequest{
+		Version:  resource.Version,
+		Manifest: newRes.Manifest,
+	}).Execute()
+	Expect(err).NotTo(HaveOccurred(), "Error patching object:  %v", err)
+	Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	Expect(resource.GetForceConflicts()).To(BeTrue())
+
+	resource, resp, err = client.DefaultApi.ApiMaestroV1ResourcesIdPatch(ctx, *resource.Id).ResourcePatchRequest(openapi.ResourcePatchRequest{
+		Version:        resource.Version,
+		Manifest:       map[string]interface{}{"spec": map[string]interface{}{"replicas": 3}},
+		ForceConflicts: openapi.PtrBool(false),
+		PatchType:      openapi.PtrString(string(api.ManifestPatchTypeJSONMerge)),
+	}).Execute()
+	Expect(err).NotTo(HaveOccurred(), "Error patching object:  %v", err)
+	Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	Expect(resource.GetForceConflicts()).To(BeFalse())
+
+	found, resp, err := client.DefaultApi.ApiMaestroV1ResourcesIdGet(ctx, *resource.Id).Execute()
+	Expect(err).NotTo(HaveOccurred(), "Error getting object:  %v", err)
+	Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	Expect(found.GetForceConflicts()).To(BeFalse())
+}
+
 func TestResourcePaging(t *testing.T) {
 	h, client := test.RegisterIntegration(t)
 
@@ -423,6 +580,354 @@ func TestResourceListSearch(t *testing.T) {
 	Expect(*list.Items[0].Id).To(Equal(resources[0].ID))
 }
 
+func TestResourceListCondition(t *testing.T) {
+	h, client := test.RegisterIntegration(t)
+
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+
+	consumer := h.CreateConsumer("cluster-" + rand.String(5))
+	resources := h.CreateResourceList(consumer.Name, 3)
+
+	// the third resource has no status yet
+	resourceService := h.Env().Services.Resources()
+	for i, conditions := range [][]metav1.Condition{
+		{{Type: "Applied", Status: metav1.ConditionTrue}, {Type: "Degraded", Status: metav1.ConditionTrue}},
+		{{Type: "Applied", Status: metav1.ConditionTrue}, {Type: "Degraded", Status: metav1.ConditionFalse}},
+	} {
+		_, updated, svcErr := resourceService.UpdateStatus(ctx, &api.Resource{
+			Meta:    api.Meta{ID: resources[i].ID},
+			Version: resources[i].Version,
+			Status:  newConditionsStatus(t, resources[i].Version, conditions),
+		})
+		Expect(svcErr).To(BeNil())
+		Expect(updated).To(BeTrue())
+	}
+
+	list, _, err := client.DefaultApi.ApiMaestroV1ResourcesGet(ctx).Condition("Degraded=True").Execute()
+	Expect(err).NotTo(HaveOccurred(), "Error getting resource list: %v", err)
+	Expect(len(list.Items)).To(Equal(1))
+	Expect(*list.Items[0].Id).To(Equal(resources[0].ID))
+
+	list, _, err = client.DefaultApi.ApiMaestroV1ResourcesGet(ctx).Condition("Applied=True").Execute()
+	Expect(err).NotTo(HaveOccurred(), "Error getting resource list: %v", err)
+	Expect(len(list.Items)).To(Equal(2))
+
+	list, _, err = client.DefaultApi.ApiMaestroV1ResourcesGet(ctx).Condition("Applied=True,Degraded=False").Execute()
+	Expect(err).NotTo(HaveOccurred(), "Error getting resource list: %v", err)
+	Expect(len(list.Items)).To(Equal(1))
+	Expect(*list.Items[0].Id).To(Equal(resources[1].ID))
+
+	list, _, err = client.DefaultApi.ApiMaestroV1ResourcesGet(ctx).Condition("Available=True").Execute()
+	Expect(err).NotTo(HaveOccurred(), "Error getting resource list: %v", err)
+	Expect(len(list.Items)).To(Equal(0))
+
+	_, resp, err := client.DefaultApi.ApiMaestroV1ResourcesGet(ctx).Condition("Degraded").Execute()
+	Expect(err).To(HaveOccurred())
+	Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+}
+
+func TestResourceVersionDrift(t *testing.T) {
+	h, _ := test.RegisterIntegration(t)
+
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+
+	consumer := h.CreateConsumer("cluster-" + rand.String(5))
+	resources := h.CreateResourceList(consumer.Name, 4)
+
+	// the first resource is in sync, the second one is ahead, the third one is lagging after its spec is updated,
+	// and the fourth one has no status yet
+	resourceService := h.Env().Services.Resources()
+	for i, observedVersion := range []int64{resources[0].Version, resources[1].Version + 1, resources[2].Version} {
+		_, updated, svcErr := resourceService.UpdateStatus(ctx, &api.Resource{
+			Meta:    api.Meta{ID: resources[i].ID},
+			Version: resources[i].Version,
+			Status:  newConditionsStatus(t, observedVersion, []metav1.Condition{{Type: "Applied", Status: metav1.ConditionTrue}}),
+		})
+		Expect(svcErr).To(BeNil())
+		Expect(updated).To(BeTrue())
+	}
+	newResource := h.NewResource(consumer.Name, "nginx-"+rand.String(5), 2, resources[2].Version)
+	_, svcErr := resourceService.Update(ctx, &api.Resource{
+		Meta:    api.Meta{ID: resources[2].ID},
+		Version: resources[2].Version,
+		Type:    api.ResourceTypeSingle,
+		Payload: newResource.Payload,
+	})
+	Expect(svcErr).To(BeNil())
+
+	// a non-numeric observed version is treated as unreported rather than failing the drift queries
+	err := h.Env().Database.SessionFactory.New(ctx).Exec(`UPDATE resources SET status = '{"resourceversion": "invalid"}' WHERE id = ?`,
+		resources[3].ID).Error
+	Expect(err).NotTo(HaveOccurred())
+
+	counts, svcErr := resourceService.CountVersionDrift(ctx, time.Now())
+	Expect(svcErr).To(BeNil())
+	Expect(counts).To(Equal(map[api.VersionDriftState]int{
+		api.VersionDriftAhead:      1,
+		api.VersionDriftLagging:    1,
+		api.VersionDriftUnreported: 1,
+	}))
+
+	jwtToken := h.CreateJWTString(account)
+	listVersionDrift := func(params map[string]string) openapi.ResourceVersionDriftList {
+		restyResp, err := resty.R().
+			SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+			SetQueryParams(params).
+			Get(h.RestURL("/resources/version-drift"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(restyResp.StatusCode()).To(Equal(http.StatusOK))
+		driftList := openapi.ResourceVersionDriftList{}
+		Expect(json.Unmarshal(restyResp.Body(), &driftList)).NotTo(HaveOccurred())
+		return driftList
+	}
+
+	driftList := listVersionDrift(map[string]string{"state": string(api.VersionDriftLagging),
+		"search": fmt.Sprintf("consumer_name='%s'", consumer.Name)})
+	Expect(driftList.Items).To(HaveLen(1))
+	Expect(driftList.Items[0].GetResourceId()).To(Equal(resources[2].ID))
+	Expect(driftList.Items[0].GetVersion()).To(Equal(resources[2].Version + 1))
+	Expect(driftList.Items[0].GetObservedVersion()).To(Equal(resources[2].Version))
+
+	// the drifts are paged
+	driftList = listVersionDrift(map[string]string{"search": fmt.Sprintf("consumer_name='%s'", consumer.Name), "size": "2"})
+	Expect(driftList.Items).To(HaveLen(2))
+	Expect(driftList.Total).To(Equal(int32(3)))
+	driftList = listVersionDrift(map[string]string{"search": fmt.Sprintf("consumer_name='%s'", consumer.Name), "size": "2", "page": "2"})
+	Expect(driftList.Items).To(HaveLen(1))
+
+	// the lagging and unreported resources are only returned once they are not updated for a while, the resources
+	// that are ahead are always returned
+	driftList = listVersionDrift(map[string]string{"olderThan": "1h", "search": fmt.Sprintf("consumer_name='%s'", consumer.Name)})
+	Expect(driftList.Items).To(HaveLen(1))
+	Expect(driftList.Items[0].GetResourceId()).To(Equal(resources[1].ID))
+	Expect(driftList.Items[0].GetState()).To(Equal(string(api.VersionDriftAhead)))
+}
+
+func TestResourceBatchCreate(t *testing.T) {
+	h, _ := test.RegisterIntegration(t)
+
+	account := h.NewRandAccount()
+	jwtToken := h.CreateJWTString(account)
+
+	consumers := []*api.Consumer{
+		h.CreateConsumer("cluster-" + rand.String(5)),
+		h.CreateConsumer("cluster-" + rand.String(5)),
+		// the resource name derived for this consumer is too long
+		h.CreateConsumer("cluster-" + rand.String(50)),
+	}
+	name := "nginx-" + rand.String(5)
+	resource := h.NewAPIResource("", name, 1)
+	resource.ConsumerName = nil
+	resource.Name = &name
+	req := openapi.ResourceBatchCreateRequest{
+		Resource:    &resource,
+		ConsumerIds: []string{consumers[0].ID, consumers[1].ID, consumers[2].ID, "unknown"},
+	}
+
+	batchCreate := func() openapi.ResourceBatchCreateResponse {
+		restyResp, err := resty.R().
+			SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+			SetBody(req).
+			Post(h.RestURL("/resources:batchCreate"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(restyResp.StatusCode()).To(Equal(http.StatusCreated))
+		resp := openapi.ResourceBatchCreateResponse{}
+		Expect(json.Unmarshal(restyResp.Body(), &resp)).NotTo(HaveOccurred())
+		Expect(resp.Items).To(HaveLen(4))
+		return resp
+	}
+
+	// a resource with a per-consumer name is created for each of the valid consumers, the other consumers are
+	// reported as failed
+	resp := batchCreate()
+	for i, consumer := range consumers[:2] {
+		Expect(resp.Items[i].GetStatus()).To(Equal("Created"))
+		found, svcErr := h.Env().Services.Resources().Get(context.Background(), resp.Items[i].GetResourceId())
+		Expect(svcErr).To(BeNil())
+		Expect(found.ConsumerName).To(Equal(consumer.Name))
+		Expect(found.Name).To(Equal(fmt.Sprintf("%s-%s", name, consumer.Name)))
+	}
+	Expect(resp.Items[2].GetStatus()).To(Equal("Failed"))
+	Expect(resp.Items[2].GetReason()).To(ContainSubstring("name"))
+	Expect(resp.Items[3].GetStatus()).To(Equal("Failed"))
+	Expect(resp.Items[3].GetReason()).To(Equal("consumer is not found"))
+
+	// the resources already exist for the consumers
+	resp = batchCreate()
+	for i := range consumers[:2] {
+		Expect(resp.Items[i].GetStatus()).To(Equal("Failed"))
+		Expect(resp.Items[i].GetReason()).To(ContainSubstring("already exists"))
+	}
+}
+
+func TestResourceOwnershipTransfer(t *testing.T) {
+	h, _ := test.RegisterIntegration(t)
+
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+
+	consumer := h.CreateConsumer("cluster-" + rand.String(5))
+	resource := h.CreateResourceList(consumer.Name, 1)[0]
+
+	resourceService := h.Env().Services.Resources()
+	transfer, svcErr := resourceService.TransferOwnership(ctx, resource.ID, "new-source", account.Username())
+	Expect(svcErr).To(BeNil())
+	Expect(transfer.FromSource).To(Equal(resource.Source))
+	Expect(transfer.ToSource).To(Equal("new-source"))
+
+	// the source is reassigned without bumping the resource version
+	found, svcErr := resourceService.Get(ctx, resource.ID)
+	Expect(svcErr).To(BeNil())
+	Expect(found.Source).To(Equal("new-source"))
+	Expect(found.Version).To(Equal(resource.Version))
+
+	transfers, paging, svcErr := resourceService.ListOwnershipTransfers(ctx, resource.ID, &services.ListArguments{Page: 1, Size: 100})
+	Expect(svcErr).To(BeNil())
+	Expect(len(transfers)).To(Equal(1))
+	Expect(paging.Total).To(Equal(int64(1)))
+	Expect(transfers[0].ID).To(Equal(transfer.ID))
+	Expect(transfers[0].TransferredBy).To(Equal(account.Username()))
+
+	_, svcErr = resourceService.TransferOwnership(ctx, resource.ID, "new-source", account.Username())
+	Expect(svcErr.IsConflict()).To(BeTrue())
+
+	// the transfer is an admin operation
+	body := `{"to_source": "another-source"}`
+	restyResp, err := resty.R().
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", h.CreateJWTString(account))).
+		SetBody(body).
+		Post(h.RestURL(fmt.Sprintf("/resources/%s/ownership-transfers", resource.ID)))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusForbidden))
+
+	admin := h.NewAdminAccount()
+	restyResp, err = resty.R().
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", h.CreateJWTString(admin))).
+		SetBody(body).
+		Post(h.RestURL(fmt.Sprintf("/resources/%s/ownership-transfers", resource.ID)))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusCreated))
+
+	// the transfers are listed page by page
+	restyResp, err = resty.R().
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", h.CreateJWTString(account))).
+		SetQueryParam("size", "1").
+		Get(h.RestURL(fmt.Sprintf("/resources/%s/ownership-transfers", resource.ID)))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusOK))
+	transferList := map[string]interface{}{}
+	Expect(json.Unmarshal(restyResp.Body(), &transferList)).NotTo(HaveOccurred())
+	Expect(transferList["size"]).To(BeEquivalentTo(1))
+	Expect(transferList["total"]).To(BeEquivalentTo(2))
+}
+
+func TestResourceLock(t *testing.T) {
+	h, _ := test.RegisterIntegration(t)
+
+	owner := h.NewRandAccount()
+	another := h.NewRandAccount()
+	admin := h.NewAdminAccount()
+
+	consumer := h.CreateConsumer("cluster-" + rand.String(5))
+	resource := h.CreateResourceList(consumer.Name, 1)[0]
+	lockURL := h.RestURL(fmt.Sprintf("/resources/%s/lock", resource.ID))
+
+	request := func(account *amv1.Account) *resty.Request {
+		return resty.R().SetHeader("Authorization", fmt.Sprintf("Bearer %s", h.CreateJWTString(account)))
+	}
+
+	// the owner of the lock cannot be given by the request
+	restyResp, err := request(another).SetBody(fmt.Sprintf(`{"owner": "%s"}`, owner.Username())).Post(lockURL)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusBadRequest))
+
+	// the lock is held by the requesting user
+	restyResp, err = request(owner).SetBody(`{"ttl_seconds": 300}`).Post(lockURL)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusOK))
+	lock := map[string]interface{}{}
+	Expect(json.Unmarshal(restyResp.Body(), &lock)).NotTo(HaveOccurred())
+	Expect(lock["owner"]).To(Equal(owner.Username()))
+
+	// another user cannot edit the resource, nor override the lock without being an admin
+	restyResp, err = request(another).Delete(h.RestURL(fmt.Sprintf("/resources/%s", resource.ID)))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusLocked))
+	restyResp, err = request(another).SetQueryParam("force", "true").Delete(h.RestURL(fmt.Sprintf("/resources/%s", resource.ID)))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusForbidden))
+	restyResp, err = request(another).Delete(lockURL)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusLocked))
+	restyResp, err = request(another).SetQueryParam("force", "true").Delete(lockURL)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusForbidden))
+
+	// an admin can force-release the lock
+	restyResp, err = request(admin).SetQueryParam("force", "true").Delete(lockURL)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusNoContent))
+	restyResp, err = request(owner).Get(lockURL)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusNotFound))
+}
+
+func TestResourceTemplateListAndDelete(t *testing.T) {
+	h, _ := test.RegisterIntegration(t)
+
+	creator := h.NewRandAccount()
+	another := h.NewRandAccount()
+	admin := h.NewAdminAccount()
+
+	request := func(account *amv1.Account) *resty.Request {
+		return resty.R().SetHeader("Authorization", fmt.Sprintf("Bearer %s", h.CreateJWTString(account)))
+	}
+
+	templateIDs := []string{}
+	for i := 0; i < 3; i++ {
+		restyResp, err := request(creator).
+			SetBody(fmt.Sprintf(`{"name": "template-%d", "manifest": {"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "test", "namespace": "default"}}}`, i)).
+			Post(h.RestURL("/resource-templates"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(restyResp.StatusCode()).To(Equal(http.StatusCreated))
+		template := openapi.ResourceTemplate{}
+		Expect(json.Unmarshal(restyResp.Body(), &template)).NotTo(HaveOccurred())
+		templateIDs = append(templateIDs, *template.Id)
+	}
+
+	// the templates are listed by page
+	restyResp, err := request(another).SetQueryParams(map[string]string{"page": "2", "size": "2"}).Get(h.RestURL("/resource-templates"))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusOK))
+	templateList := openapi.ResourceTemplateList{}
+	Expect(json.Unmarshal(restyResp.Body(), &templateList)).NotTo(HaveOccurred())
+	Expect(templateList.Page).To(Equal(int32(2)))
+	Expect(templateList.Size).To(Equal(int32(1)))
+	Expect(templateList.Total).To(Equal(int32(3)))
+
+	// the templates are searched
+	restyResp, err = request(another).SetQueryParam("search", "name = 'template-1'").Get(h.RestURL("/resource-templates"))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusOK))
+	templateList = openapi.ResourceTemplateList{}
+	Expect(json.Unmarshal(restyResp.Body(), &templateList)).NotTo(HaveOccurred())
+	Expect(templateList.Items).To(HaveLen(1))
+	Expect(*templateList.Items[0].Id).To(Equal(templateIDs[1]))
+
+	// only the creator or an admin can delete the template
+	restyResp, err = request(another).Delete(h.RestURL(fmt.Sprintf("/resource-templates/%s", templateIDs[0])))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusForbidden))
+	restyResp, err = request(creator).Delete(h.RestURL(fmt.Sprintf("/resource-templates/%s", templateIDs[0])))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusNoContent))
+	restyResp, err = request(admin).Delete(h.RestURL(fmt.Sprintf("/resource-templates/%s", templateIDs[1])))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusNoContent))
+}
+
 func TestResourceBundleGet(t *testing.T) {
 	h, client := test.RegisterIntegration(t)
 
@@ -463,6 +968,130 @@ func TestResourceBundleGet(t *testing.T) {
 	checkServerCounterMetric(t, families, "rest_api_inbound_request_count", labels, 1.0)
 }
 
+func TestResourceBundlePatch(t *testing.T) {
+	h, client := test.RegisterIntegration(t)
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+	jwtToken := ctx.Value(openapi.ContextAccessToken)
+
+	consumer := h.CreateConsumer("cluster-" + rand.String(5))
+	deployName := fmt.Sprintf("nginx-%s", rand.String(5))
+	resourceBundle := h.CreateResourceBundle(consumer.Name, deployName, 1)
+
+	patch := openapi.ResourceBundlePatchRequest{
+		Version:      openapi.PtrInt32(int32(resourceBundle.Version)),
+		DeleteOption: map[string]interface{}{"propagationPolicy": "Orphan"},
+	}
+	restyResp, err := resty.R().
+		SetHeader("Content-Type", "application/json").
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+		SetBody(patch).
+		Patch(h.RestURL(fmt.Sprintf("/resource-bundles/%s", resourceBundle.ID)))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusOK))
+
+	resBundle, resp, err := client.DefaultApi.ApiMaestroV1ResourceBundlesIdGet(ctx, resourceBundle.ID).Execute()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	Expect(*resBundle.Version).To(Equal(resourceBundle.Version + 1))
+	Expect(resBundle.DeleteOption["propagationPolicy"]).To(Equal("Orphan"))
+	Expect(resBundle.Manifests).To(HaveLen(len(resourceBundle.Payload["data"].(map[string]interface{})["manifests"].([]interface{}))))
+
+	// 409 for the stale version
+	restyResp, err = resty.R().
+		SetHeader("Content-Type", "application/json").
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+		SetBody(patch).
+		Patch(h.RestURL(fmt.Sprintf("/resource-bundles/%s", resourceBundle.ID)))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusConflict))
+
+	// 400 for the invalid manifest configs
+	patch.Version = resBundle.Version
+	patch.DeleteOption = nil
+	patch.ManifestConfigs = []map[string]interface{}{{"updateStrategy": map[string]interface{}{"type": "Unknown"}}}
+	restyResp, err = resty.R().
+		SetHeader("Content-Type", "application/json").
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+		SetBody(patch).
+		Patch(h.RestURL(fmt.Sprintf("/resource-bundles/%s", resourceBundle.ID)))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusBadRequest))
+}
+
+func TestResourceBundleRevert(t *testing.T) {
+	h, client := test.RegisterIntegration(t)
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+	jwtToken := ctx.Value(openapi.ContextAccessToken)
+
+	consumer := h.CreateConsumer("cluster-" + rand.String(5))
+	deployName := fmt.Sprintf("nginx-%s", rand.String(5))
+	resourceBundle := h.CreateResourceBundle(consumer.Name, deployName, 1)
+
+	patch := openapi.ResourceBundlePatchRequest{
+		Version:      openapi.PtrInt32(int32(resourceBundle.Version)),
+		DeleteOption: map[string]interface{}{"propagationPolicy": "Orphan"},
+	}
+	restyResp, err := resty.R().
+		SetHeader("Content-Type", "application/json").
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+		SetBody(patch).
+		Patch(h.RestURL(fmt.Sprintf("/resource-bundles/%s", resourceBundle.ID)))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusOK))
+
+	// the reverted resource bundle is a new version with the manifests of the revision
+	restyResp, err = resty.R().
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+		Post(h.RestURL(fmt.Sprintf("/resource-bundles/%s/revisions/%d/revert", resourceBundle.ID, resourceBundle.Version)))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusOK))
+
+	resBundle, resp, err := client.DefaultApi.ApiMaestroV1ResourceBundlesIdGet(ctx, resourceBundle.ID).Execute()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	Expect(*resBundle.Version).To(Equal(resourceBundle.Version + 2))
+	Expect(resBundle.DeleteOption["propagationPolicy"]).NotTo(Equal("Orphan"))
+
+	// the revisions are paged, the latest revision comes first
+	restyResp, err = resty.R().
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+		SetQueryParams(map[string]string{"size": "2"}).
+		Get(h.RestURL(fmt.Sprintf("/resource-bundles/%s/revisions", resourceBundle.ID)))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusOK))
+	revisionList := openapi.ResourceBundleList{}
+	Expect(json.Unmarshal(restyResp.Body(), &revisionList)).NotTo(HaveOccurred())
+	Expect(revisionList.Total).To(Equal(int32(3)))
+	Expect(revisionList.Items).To(HaveLen(2))
+	Expect(revisionList.Items[0].GetVersion()).To(Equal(int32(resourceBundle.Version + 2)))
+	restyResp, err = resty.R().
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+		SetQueryParams(map[string]string{"size": "2", "page": "2"}).
+		Get(h.RestURL(fmt.Sprintf("/resource-bundles/%s/revisions", resourceBundle.ID)))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusOK))
+	Expect(json.Unmarshal(restyResp.Body(), &revisionList)).NotTo(HaveOccurred())
+	Expect(revisionList.Items).To(HaveLen(1))
+	Expect(revisionList.Items[0].GetVersion()).To(Equal(int32(resourceBundle.Version)))
+
+	// 404 for the unknown revision
+	restyResp, err = resty.R().
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+		Post(h.RestURL(fmt.Sprintf("/resource-bundles/%s/revisions/%d/revert", resourceBundle.ID, 100)))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusNotFound))
+
+	// 404 for the resource that is not a resource bundle
+	resource := h.CreateResource(consumer.Name, fmt.Sprintf("nginx-%s", rand.String(5)), 1)
+	restyResp, err = resty.R().
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+		Post(h.RestURL(fmt.Sprintf("/resource-bundles/%s/revisions/%d/revert", resource.ID, resource.Version)))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusNotFound))
+}
+
 func TestResourceBundleListSearch(t *testing.T) {
 	h, client := test.RegisterIntegration(t)
 
@@ -498,6 +1127,71 @@ func TestResourceBundleListSearch(t *testing.T) {
 	checkServerCounterMetric(t, families, "rest_api_inbound_request_count", labels, 2.0)
 }
 
+func TestResourceBundlePayloadOffload(t *testing.T) {
+	h, client := test.RegisterIntegration(t)
+
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+
+	// offload all the bundle payloads to the object store
+	objectStore := objectstore.NewMockClient()
+	h.Env().Clients.ObjectStore = objectStore
+	h.Env().Config.ObjectStore.PayloadOffloadThreshold = 1
+	defer func() {
+		h.Env().Clients.ObjectStore = nil
+		h.Env().Config.ObjectStore.PayloadOffloadThreshold = 0
+	}()
+
+	consumer := h.CreateConsumer("cluster-" + rand.String(5))
+	deployName := fmt.Sprintf("nginx-%s", rand.String(5))
+	resourceBundle := h.CreateResourceBundle(consumer.Name, deployName, 1)
+	Expect(resourceBundle.PayloadRef).To(HavePrefix(fmt.Sprintf("resources/%s/", resourceBundle.ID)))
+
+	// only the reference and the CloudEvent attributes of the payload are kept in the database
+	var payload string
+	err := h.DBFactory.DirectDB().
+		QueryRow("SELECT payload FROM resources WHERE id = $1", resourceBundle.ID).
+		Scan(&payload)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(payload).NotTo(ContainSubstring("manifests"))
+	keys, err := objectStore.List(context.Background(), fmt.Sprintf("resources/%s/", resourceBundle.ID))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(keys).To(HaveLen(1))
+
+	// the payload is fetched back from the object store on read
+	resBundle, resp, err := client.DefaultApi.ApiMaestroV1ResourceBundlesIdGet(ctx, resourceBundle.ID).Execute()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	Expect(resBundle.Manifests).To(HaveLen(1))
+
+	search := fmt.Sprintf("consumer_name = '%s'", consumer.Name)
+	list, _, err := client.DefaultApi.ApiMaestroV1ResourceBundlesGet(ctx).Search(search).Execute()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(list.Items).To(HaveLen(1))
+	Expect(list.Items[0].Manifests).To(HaveLen(1))
+
+	// the stale payloads that are not referenced by any resource are pruned, e.g. the payload put by a rolled back
+	// transaction, the referenced and the recent payloads are kept
+	orphanKey := fmt.Sprintf("resources/%s/orphan", resourceBundle.ID)
+	recentKey := fmt.Sprintf("resources/%s/recent", resourceBundle.ID)
+	Expect(objectStore.Put(context.Background(), orphanKey, []byte("{}"))).To(Succeed())
+	Expect(objectStore.Put(context.Background(), recentKey, []byte("{}"))).To(Succeed())
+	objectStore.SetLastModified(orphanKey, time.Now().Add(-2*time.Hour))
+	objectStore.SetLastModified(resourceBundle.PayloadRef, time.Now().Add(-2*time.Hour))
+	deleted, svcErr := h.Env().Services.Resources().DeleteUnreferencedPayloads(context.Background(), time.Now().Add(-time.Hour))
+	Expect(svcErr).To(BeNil())
+	Expect(deleted).To(Equal(1))
+	keys, err = objectStore.List(context.Background(), fmt.Sprintf("resources/%s/", resourceBundle.ID))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(keys).To(HaveLen(2))
+
+	// the read fails with a clear error if the offloaded payload is unavailable
+	Expect(objectStore.Delete(context.Background(), resourceBundle.PayloadRef)).To(Succeed())
+	_, svcErr = h.Env().Services.Resources().Get(context.Background(), resourceBundle.ID)
+	Expect(svcErr).NotTo(BeNil())
+	Expect(svcErr.Error()).To(ContainSubstring("failed to fetch the payload of resource"))
+}
+
 func TestUpdateResourceWithRacingRequests(t *testing.T) {
 	h, client := test.RegisterIntegration(t)
 
@@ -530,7 +1224,7 @@ func TestUpdateResourceWithRacingRequests(t *testing.T) {
 		go func() {
 			defer wg.Done()
 			_, resp, err := client.DefaultApi.ApiMaestroV1ResourcesIdPatch(ctx, res.ID).ResourcePatchRequest(
-				openapi.ResourcePatchRequest{Version: &res.Version, Manifest: newRes.Manifest}).Execute()
+				openapi.ResourcePatchRequest{Version: openapi.PtrInt32(int32(res.Version)), Manifest: newRes.Manifest}).Execute()
 			if err != nil && resp.StatusCode == http.StatusConflict {
 				conflictRequests = conflictRequests + 1
 			}
@@ -578,6 +1272,54 @@ func TestUpdateResourceWithRacingRequests(t *testing.T) {
 	}, 20*time.Second, 1*time.Second).Should(Succeed())
 }
 
+func TestResourceConcurrentCreate(t *testing.T) {
+	h, _ := test.RegisterIntegration(t)
+
+	consumer := h.CreateConsumer("cluster-" + rand.String(5))
+	deployName := fmt.Sprintf("nginx-%s", rand.String(5))
+	resourceID := uuid.NewString()
+	resourceService := h.Env().Services.Resources()
+
+	// the sources create the same resource concurrently, only one of them wins
+	const creators = 5
+	var wg sync.WaitGroup
+	createdIDs := make([]string, creators)
+	conflicts := make([]string, creators)
+	for i := 0; i < creators; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			res := h.NewResource(consumer.Name, deployName, 1, 1)
+			res.ID = resourceID
+			res.Source = fmt.Sprintf("source-%d", i)
+			created, svcErr := resourceService.Create(context.Background(), res)
+			if svcErr != nil {
+				Expect(svcErr.IsConflict()).To(BeTrue(), "unexpected error: %v", svcErr)
+				conflicts[i] = svcErr.Reason
+				return
+			}
+			createdIDs[i] = created.ID
+		}(i)
+	}
+	wg.Wait()
+
+	created := 0
+	for i := 0; i < creators; i++ {
+		if createdIDs[i] != "" {
+			created++
+			Expect(createdIDs[i]).To(Equal(resourceID))
+			continue
+		}
+		// the losers are told the ID of the existing resource
+		Expect(conflicts[i]).To(ContainSubstring(resourceID))
+	}
+	Expect(created).To(Equal(1))
+
+	existing, svcErr := resourceService.FindConflicting(context.Background(), &api.Resource{Meta: api.Meta{ID: resourceID}})
+	Expect(svcErr).To(BeNil())
+	Expect(existing.ConsumerName).To(Equal(consumer.Name))
+}
+
 func TestResourceFromGRPC(t *testing.T) {
 	h, client := test.RegisterIntegration(t)
 	account := h.NewRandAccount()
@@ -670,9 +1412,21 @@ func TestResourceFromGRPC(t *testing.T) {
 		},
 	}
 
+	// capture the status events delivered to the source
+	recorder := h.NewEventRecorder("maestro")
+	defer recorder.Stop()
+
 	// update the work status
 	Expect(updateWorkStatus(ctx, agentWorkClient, work, newWorkStatus)).NotTo(HaveOccurred())
 
+	Eventually(recorder.Events, 10*time.Second, 1*time.Second).Should(ContainElement(And(
+		test.HaveEventResource(res.ID),
+		test.HaveEventType("io.open-cluster-management.works.v1alpha1.manifests.status.update_request"),
+		test.HaveEventExtension(types.ExtensionResourceVersion, 1),
+		test.HaveEventData(HaveKey("conditions")),
+	)))
+	Expect(recorder.Errors()).To(BeEmpty())
+
 	Eventually(func() error {
 		newRes, err := h.Store.Get(res.ID)
 		if err != nil {
@@ -704,7 +1458,7 @@ func TestResourceFromGRPC(t *testing.T) {
 
 	newRes := h.NewResource(consumer.Name, deployName, 2, 1)
 	newRes.ID = *resource.Id
-	newRes.Version = *resource.Version
+	newRes.Version = int64(*resource.Version)
 	err = h.GRPCSourceClient.Publish(ctx, types.CloudEventsType{
 		CloudEventsDataType: payload.ManifestEventDataType,
 		SubResource:         types.SubResourceSpec,
@@ -823,6 +1577,37 @@ func TestResourceFromGRPC(t *testing.T) {
 	}
 }
 
+func TestResourceFromGRPCWithMismatchedSource(t *testing.T) {
+	h, _ := test.RegisterIntegration(t)
+
+	// the resource belongs to another source
+	consumer := h.CreateConsumer("cluster-" + rand.String(5))
+	res := h.NewResource(consumer.Name, fmt.Sprintf("nginx-%s", rand.String(5)), 1, 1)
+	res.Source = "other-source"
+	created, svcErr := h.Env().Services.Resources().Create(context.Background(), res)
+	Expect(svcErr).To(BeNil())
+
+	h.StartGRPCResourceSourceClient()
+	newRes := h.NewResource(consumer.Name, fmt.Sprintf("nginx-%s", rand.String(5)), 2, created.Version)
+	newRes.ID = created.ID
+	for _, action := range []types.EventAction{common.UpdateRequestAction, common.DeleteRequestAction} {
+		err := h.GRPCSourceClient.Publish(context.Background(), types.CloudEventsType{
+			CloudEventsDataType: payload.ManifestEventDataType,
+			SubResource:         types.SubResourceSpec,
+			Action:              action,
+		}, newRes)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("doesn't belong to the source"))
+	}
+
+	// the resource is untouched
+	found, svcErr := h.Env().Services.Resources().Get(context.Background(), created.ID)
+	Expect(svcErr).To(BeNil())
+	Expect(found.Version).To(Equal(created.Version))
+	Expect(found.Payload).To(Equal(created.Payload))
+	Expect(found.DeletedAt.Valid).To(BeFalse())
+}
+
 func TestResourceBundleFromGRPC(t *testing.T) {
 	h, client := test.RegisterIntegration(t)
 	account := h.NewRandAccount()
@@ -1084,3 +1869,27 @@ func compareMetricLabels(labels []*prommodel.LabelPair, metricLabels []*prommode
 func strPtr(s string) *string {
 	return &s
 }
+
+func newConditionsStatus(t *testing.T, version int64, conditions []metav1.Condition) datatypes.JSONMap {
+	evt := cloudevents.NewEvent()
+	evt.SetID(uuid.NewString())
+	evt.SetSource("test")
+	evt.SetType(types.CloudEventsType{
+		CloudEventsDataType: payload.ManifestEventDataType,
+		SubResource:         types.SubResourceStatus,
+		Action:              common.UpdateRequestAction,
+	}.String())
+	evt.SetExtension(types.ExtensionResourceVersion, version)
+	evt.SetExtension(types.ExtensionStatusUpdateSequenceID, "1")
+	if err := evt.SetData(cloudevents.ApplicationJSON, &payload.ManifestStatus{
+		Status: &workv1.ManifestCondition{Conditions: conditions},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	status, err := api.CloudEventToJSONMap(&evt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return status
+}
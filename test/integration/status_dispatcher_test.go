@@ -76,4 +76,28 @@ func TestStatusDispatcher(t *testing.T) {
 		{Name: strPtr("type"), Value: strPtr("io.open-cluster-management.works.v1alpha1.manifests")},
 	}
 	checkServerCounterMetric(t, families, "cloudevents_sent_total", labels, 2.0)
+
+	// the current instance owns all consumers after instance1 is removed from the hash ring
+	labels = []*prommodel.LabelPair{
+		{Name: strPtr("instance_id"), Value: strPtr(h.Env().Config.MessageBroker.ClientID)},
+	}
+	checkServerGaugeMetric(t, families, "hash_dispatcher_owned_consumers", labels, 2.0)
+}
+
+func checkServerGaugeMetric(t *testing.T, families map[string]*prommodel.MetricFamily, name string, labels []*prommodel.LabelPair, value float64) {
+	family, ok := families[name]
+	if !ok {
+		t.Errorf("Metric %s not found", name)
+		return
+	}
+	for _, metric := range family.GetMetric() {
+		if !compareMetricLabels(labels, metric.GetLabel()) {
+			continue
+		}
+		if *metric.Gauge.Value != value {
+			t.Errorf("Gauge metric %s value is %f, expected %f", name, *metric.Gauge.Value, value)
+		}
+		return
+	}
+	t.Errorf("Gauge metric %s with labels %v not found", name, labels)
 }